@@ -19,6 +19,7 @@ type AccountService struct {
 	cfg         *cfg.Config
 	sdb         *state.ChainStateDB
 	ks          *key.Store
+	signer      key.TxSigner
 	accountLock sync.RWMutex
 	accounts    []*types.Account
 	testConfig  bool
@@ -37,6 +38,15 @@ func NewAccountService(cfg *cfg.Config, sdb *state.ChainStateDB) *AccountService
 
 func (as *AccountService) BeforeStart() {
 	as.ks = key.NewStore(as.cfg.DataDir, as.cfg.Account.UnlockTimeout)
+	if conf := as.cfg.Account; conf.Signer != "" {
+		signer, err := key.NewRemoteSigner(conf.Signer, conf.SignerCACert, conf.SignerCert, conf.SignerKey)
+		if err != nil {
+			as.Logger.Fatal().Err(err).Str("signer", conf.Signer).Msg("could not use the remote signer")
+		}
+		as.signer = signer
+	} else {
+		as.signer = as.ks
+	}
 
 	as.accounts = []*types.Account{}
 	addresses, err := as.ks.GetAddresses()
@@ -235,7 +245,7 @@ func (as *AccountService) lockAccount(address []byte, passphrase string) (*types
 
 func (as *AccountService) signTx(c actor.Context, msg *message.SignTx) error {
 	//sign tx
-	prop := actor.FromInstance(NewSigner(as.ks))
+	prop := actor.FromInstance(NewSigner(as.signer))
 	signer := c.Spawn(prop)
 	signer.Request(msg, c.Sender())
 	return nil
@@ -0,0 +1,322 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package key
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aergoio/aergo/internal/enc"
+	"github.com/aergoio/aergo/types"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/golang/protobuf/proto"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	sha256 "github.com/minio/sha256-simd"
+)
+
+// A signer process keeps keys out of the node, e.g. in an HSM or a vault, and
+// signs transactions, blocks and BFT votes on request. It serves a single
+// HTTPS endpoint, which must authenticate the node by its client certificate:
+//
+//   POST <url>/sign {"address": <base58 address>, "type": "tx"|"block"|"vote",
+//                    "payload": <base58 protobuf>, "chainid": <base58 chain id>}
+//
+// where the payload is the TxBody, the BlockHeader or the BFTVote to sign, and
+// the chain id is given for a vote only. It responds {"sign": <base58 DER
+// signature>}, or {"error": <message>} with a non-200 status. The signer
+// computes the signed hash from the payload itself, so it knows what it signs
+// and never signs an arbitrary hash. NewSignerHandler serves it with the keys
+// unlocked in a Store, which is useful as a stand-in of the real signer.
+
+const remoteSignTimeout = 10 * time.Second
+
+const (
+	remoteSignTx    = "tx"
+	remoteSignBlock = "block"
+	remoteSignVote  = "vote"
+)
+
+var (
+	errRemoteSignMismatch = errors.New("the remote signer returned a signature not matching the key")
+	errRemoteSignUntyped  = errors.New("the remote signer signs only transactions, block headers and votes")
+	errRemoteSignPubKey   = errors.New("the public key of the block header doesn't match the address")
+	errRemoteSignInsecure = errors.New("the remote signer must be requested over https")
+)
+
+type remoteSignRequest struct {
+	Address string `json:"address"`
+	Type    string `json:"type"`
+	Payload string `json:"payload"`
+	ChainID string `json:"chainid,omitempty"`
+}
+
+type remoteSignResponse struct {
+	Sign  string `json:"sign,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// hash returns the hash signed for the request, which is computed from its
+// payload.
+func (r *remoteSignRequest) hash() ([]byte, error) {
+	payload, err := enc.ToBytes(r.Payload)
+	if err != nil {
+		return nil, err
+	}
+	switch r.Type {
+	case remoteSignTx:
+		var body types.TxBody
+		if err = proto.Unmarshal(payload, &body); err != nil {
+			return nil, err
+		}
+		return CalculateHashWithoutSign(&body), nil
+	case remoteSignBlock:
+		var bh types.BlockHeader
+		if err = proto.Unmarshal(payload, &bh); err != nil {
+			return nil, err
+		}
+		addr, err := types.DecodeAddress(r.Address)
+		if err != nil {
+			return nil, err
+		}
+		pubkey, err := crypto.UnmarshalSecp256k1PublicKey(addr)
+		if err != nil {
+			return nil, err
+		}
+		if raw, err := pubkey.Bytes(); err != nil || !bytes.Equal(raw, bh.PubKey) {
+			return nil, errRemoteSignPubKey
+		}
+		msg, err := bh.BytesForDigest()
+		if err != nil {
+			return nil, err
+		}
+		hash := sha256.Sum256(msg)
+		return hash[:], nil
+	case remoteSignVote:
+		var vote types.BFTVote
+		if err = proto.Unmarshal(payload, &vote); err != nil {
+			return nil, err
+		}
+		chainID, err := enc.ToBytes(r.ChainID)
+		if err != nil {
+			return nil, err
+		}
+		hash := sha256.Sum256(vote.SignBytes(chainID))
+		return hash[:], nil
+	default:
+		return nil, fmt.Errorf("unknown sign type: %q", r.Type)
+	}
+}
+
+// RemoteSigner signs by the keys kept in a signer process.
+type RemoteSigner struct {
+	url    string
+	client *http.Client
+}
+
+// NewRemoteSigner returns a RemoteSigner requesting to the signer process at
+// signerURL over https. The signer is authenticated by caCert, and the node
+// by the client certificate cert and its key.
+func NewRemoteSigner(signerURL, caCert, cert, key string) (*RemoteSigner, error) {
+	certificate, err := tls.LoadX509KeyPair(cert, key)
+	if err != nil {
+		return nil, fmt.Errorf("could not load the client key pair of the remote signer: %v", err)
+	}
+	ca, err := ioutil.ReadFile(caCert)
+	if err != nil {
+		return nil, fmt.Errorf("could not read the CA cert of the remote signer: %v", err)
+	}
+	certPool := x509.NewCertPool()
+	if ok := certPool.AppendCertsFromPEM(ca); !ok {
+		return nil, errors.New("failed to append the CA cert of the remote signer")
+	}
+	return newRemoteSigner(signerURL, &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		RootCAs:      certPool,
+		MinVersion:   tls.VersionTLS12,
+	})
+}
+
+func newRemoteSigner(signerURL string, tlsConfig *tls.Config) (*RemoteSigner, error) {
+	u, err := url.Parse(signerURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "https" {
+		return nil, errRemoteSignInsecure
+	}
+	return &RemoteSigner{
+		url: strings.TrimRight(signerURL, "/") + "/sign",
+		client: &http.Client{
+			Timeout:   remoteSignTimeout,
+			Transport: &http.Transport{TLSClientConfig: tlsConfig},
+		},
+	}, nil
+}
+
+// sign returns the signature of req by the key of addr. The signature is
+// verified before returned, so a misbehaving signer can't make the node
+// publish invalid signatures.
+func (rs *RemoteSigner) sign(addr Identity, req *remoteSignRequest) ([]byte, error) {
+	pubkey, err := btcec.ParsePubKey(addr, btcec.S256())
+	if err != nil {
+		return nil, err
+	}
+	req.Address = types.EncodeAddress(addr)
+	hash, err := req.hash()
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := rs.client.Post(rs.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var out remoteSignResponse
+	if err = json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("invalid response from the remote signer: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("remote signer: %s", out.Error)
+	}
+	sign, err := enc.ToBytes(out.Sign)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := btcec.ParseSignature(sign, btcec.S256())
+	if err != nil || !parsed.Verify(hash, pubkey) {
+		return nil, errRemoteSignMismatch
+	}
+	return sign, nil
+}
+
+func (rs *RemoteSigner) signMessage(addr Identity, typ string, msg proto.Message, chainID []byte) ([]byte, error) {
+	payload, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	req := &remoteSignRequest{Type: typ, Payload: enc.ToString(payload)}
+	if chainID != nil {
+		req.ChainID = enc.ToString(chainID)
+	}
+	return rs.sign(addr, req)
+}
+
+// SignTx signs tx by the key of requester, or of tx.Account if requester is
+// nil.
+func (rs *RemoteSigner) SignTx(tx *types.Tx, requester []byte) error {
+	addr := tx.Body.Account
+	if requester != nil {
+		addr = requester
+	}
+	body := *tx.Body
+	body.Sign = nil
+	sign, err := rs.signMessage(addr, remoteSignTx, &body, nil)
+	if err != nil {
+		return err
+	}
+	tx.Body.Sign = sign
+	tx.Hash = tx.CalculateTxHash()
+	return nil
+}
+
+// BlockSigner returns the signer of blocks and BFT votes by the key of addr.
+func (rs *RemoteSigner) BlockSigner(addr Identity) (types.BlockSigner, error) {
+	pubkey, err := crypto.UnmarshalSecp256k1PublicKey(addr)
+	if err != nil {
+		return nil, err
+	}
+	return &remoteBlockSigner{rs: rs, addr: addr, pubkey: pubkey}, nil
+}
+
+type remoteBlockSigner struct {
+	rs     *RemoteSigner
+	addr   Identity
+	pubkey crypto.PubKey
+}
+
+func (s *remoteBlockSigner) GetPublic() crypto.PubKey {
+	return s.pubkey
+}
+
+// Sign always fails, since the remote signer doesn't sign raw messages.
+// Blocks and votes are signed by SignHeader and SignVote.
+func (s *remoteBlockSigner) Sign(msg []byte) ([]byte, error) {
+	return nil, errRemoteSignUntyped
+}
+
+// SignHeader signs the block header like a secp256k1 crypto.PrivKey signs its
+// digest bytes.
+func (s *remoteBlockSigner) SignHeader(bh *types.BlockHeader) ([]byte, error) {
+	header := *bh
+	header.Sign = nil
+	return s.rs.signMessage(s.addr, remoteSignBlock, &header, nil)
+}
+
+// SignVote signs the vote on the chain of chainID like a secp256k1
+// crypto.PrivKey signs its sign bytes.
+func (s *remoteBlockSigner) SignVote(m *types.BFTVote, chainID []byte) ([]byte, error) {
+	vote := *m
+	vote.Sign = nil
+	return s.rs.signMessage(s.addr, remoteSignVote, &vote, chainID)
+}
+
+// NewSignerHandler returns the HTTP handler of a signer process signing by
+// the keys unlocked in ks. It must be served over TLS requiring the client
+// certificates of the nodes.
+func NewSignerHandler(ks *Store) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/sign", func(w http.ResponseWriter, r *http.Request) {
+		respond := func(status int, out *remoteSignResponse) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			json.NewEncoder(w).Encode(out)
+		}
+		if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 {
+			respond(http.StatusUnauthorized, &remoteSignResponse{Error: "client certificate required"})
+			return
+		}
+		if r.Method != http.MethodPost {
+			respond(http.StatusMethodNotAllowed, &remoteSignResponse{Error: "method not allowed"})
+			return
+		}
+		var in remoteSignRequest
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			respond(http.StatusBadRequest, &remoteSignResponse{Error: err.Error()})
+			return
+		}
+		addr, err := types.DecodeAddress(in.Address)
+		if err != nil {
+			respond(http.StatusBadRequest, &remoteSignResponse{Error: err.Error()})
+			return
+		}
+		hash, err := in.hash()
+		if err != nil {
+			respond(http.StatusBadRequest, &remoteSignResponse{Error: err.Error()})
+			return
+		}
+		sign, err := ks.SignHash(addr, hash)
+		if err != nil {
+			respond(http.StatusForbidden, &remoteSignResponse{Error: err.Error()})
+			return
+		}
+		respond(http.StatusOK, &remoteSignResponse{Sign: enc.ToString(sign)})
+	})
+	return mux
+}
@@ -0,0 +1,178 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package key
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aergoio/aergo/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// testCert issues a certificate signed by parent, or a self-signed one if
+// parent is nil, and writes it and its key in PEM to the files named name.
+func testCert(t *testing.T, name string, serial int64, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+		parent, parentKey = tmpl, priv
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &priv.PublicKey, parentKey)
+	assert.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(priv)
+	assert.NoError(t, err)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(testDir, name+".crt"),
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(testDir, name+".key"),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	return cert, priv
+}
+
+// startTestSigner starts a signer process requiring client certificates
+// issued by the CA of ca.crt.
+func startTestSigner(t *testing.T) *httptest.Server {
+	ca, caKey := testCert(t, "ca", 1, nil, nil)
+	testCert(t, "server", 2, ca, caKey)
+	testCert(t, "client", 3, ca, caKey)
+	testCert(t, "other", 4, nil, nil)
+
+	serverCert, err := tls.LoadX509KeyPair(filepath.Join(testDir, "server.crt"), filepath.Join(testDir, "server.key"))
+	assert.NoError(t, err)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca)
+
+	server := httptest.NewUnstartedServer(NewSignerHandler(ks))
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+	}
+	server.StartTLS()
+	return server
+}
+
+func newTestRemoteSigner(t *testing.T, url, client string) (*RemoteSigner, error) {
+	return NewRemoteSigner(url, filepath.Join(testDir, "ca.crt"),
+		filepath.Join(testDir, client+".crt"), filepath.Join(testDir, client+".key"))
+}
+
+func TestRemoteSigner(t *testing.T) {
+	initTest()
+	defer deinitTest()
+	server := startTestSigner(t)
+	defer server.Close()
+	rs, err := newTestRemoteSigner(t, server.URL, "client")
+	assert.NoError(t, err)
+
+	addr, err := ks.CreateKey("pass")
+	assert.NoError(t, err)
+	tx := &types.Tx{Body: &types.TxBody{
+		Account:   addr,
+		Recipient: []byte("recipient"),
+		Amount:    []byte{1},
+		Nonce:     1,
+	}}
+	assert.Error(t, rs.SignTx(tx, nil), "should fail for the locked account")
+
+	_, err = ks.Unlock(addr, "pass")
+	assert.NoError(t, err)
+	assert.NoError(t, rs.SignTx(tx, nil))
+	assert.NoError(t, VerifyTx(tx))
+	assert.Equal(t, tx.CalculateTxHash(), tx.Hash)
+
+	signer, err := rs.BlockSigner(addr)
+	assert.NoError(t, err)
+	block := types.NewBlock(types.EmptyBlockHeaderInfo, nil, nil, nil, nil, nil)
+	assert.NoError(t, block.Sign(signer))
+	valid, err := block.VerifySign()
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	chainID := []byte("test chain id")
+	vote := &types.BFTVote{Type: types.BFTVoteType_PREVOTE, Height: 1, BlockHash: block.BlockHash()}
+	assert.NoError(t, vote.SignWith(signer, chainID))
+	assert.NoError(t, vote.VerifySign(chainID))
+
+	_, err = signer.Sign([]byte("raw message"))
+	assert.Error(t, err, "should not sign a raw message")
+}
+
+func TestRemoteSignerRequests(t *testing.T) {
+	initTest()
+	defer deinitTest()
+	server := startTestSigner(t)
+	defer server.Close()
+
+	addr, err := ks.CreateKey("pass")
+	assert.NoError(t, err)
+	_, err = ks.Unlock(addr, "pass")
+	assert.NoError(t, err)
+	other, err := ks.CreateKey("pass")
+	assert.NoError(t, err)
+	tx := &types.Tx{Body: &types.TxBody{Account: addr, Nonce: 1}}
+
+	_, err = NewRemoteSigner("http://127.0.0.1:1", filepath.Join(testDir, "ca.crt"),
+		filepath.Join(testDir, "client.crt"), filepath.Join(testDir, "client.key"))
+	assert.Equal(t, errRemoteSignInsecure, err)
+
+	rs, err := newTestRemoteSigner(t, server.URL, "other")
+	assert.NoError(t, err)
+	assert.Error(t, rs.SignTx(tx, nil), "should fail for the client not issued by the CA")
+
+	rs, err = newTestRemoteSigner(t, server.URL, "client")
+	assert.NoError(t, err)
+	noCert, err := newRemoteSigner(server.URL, &tls.Config{RootCAs: rs.client.Transport.(*http.Transport).TLSClientConfig.RootCAs})
+	assert.NoError(t, err)
+	assert.Error(t, noCert.SignTx(tx, nil), "should fail for the client without a certificate")
+
+	// The public key of a block header must be of the key signing it.
+	signer, err := rs.BlockSigner(addr)
+	assert.NoError(t, err)
+	block := types.NewBlock(types.EmptyBlockHeaderInfo, nil, nil, nil, nil, nil)
+	block.Header.PubKey, err = signer.GetPublic().Bytes()
+	assert.NoError(t, err)
+	signerOfOther, err := rs.BlockSigner(other)
+	assert.NoError(t, err)
+	_, err = signerOfOther.(types.HeaderSigner).SignHeader(block.Header)
+	assert.Equal(t, errRemoteSignPubKey, err)
+
+	// The signer rejects the requests of unknown types, e.g. of a raw hash.
+	body, err := json.Marshal(&remoteSignRequest{Address: types.EncodeAddress(addr), Type: "hash", Payload: "3"})
+	assert.NoError(t, err)
+	resp, err := rs.client.Post(rs.url, "application/json", bytes.NewReader(body))
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -6,6 +6,7 @@
 package key
 
 import (
+	"bytes"
 	"encoding/binary"
 
 	"github.com/aergoio/aergo/types"
@@ -38,17 +39,48 @@ func SignTx(tx *types.Tx, key *aergokey) error {
 	return nil
 }
 
+// TxSigner signs transactions on behalf of the accounts. Store signs with the
+// unlocked keys and RemoteSigner asks a signer process keeping the keys.
+type TxSigner interface {
+	// SignTx signs tx by the key of requester, or of tx.Account if requester
+	// is nil.
+	SignTx(tx *types.Tx, requester []byte) error
+}
+
 // SignTx return transaction which signed with unlocked key. if requester is nil, requester is assumed to tx.Account
 func (ks *Store) SignTx(tx *types.Tx, requester []byte) error {
 	addr := tx.Body.Account
 	if requester != nil {
 		addr = requester
 	}
+	key := ks.unlockedKey(addr)
+	if key == nil {
+		return types.ErrShouldUnlockAccount
+	}
+	return SignTx(tx, key)
+}
+
+// SignHash returns the signature of hash by the unlocked key of addr.
+func (ks *Store) SignHash(addr Identity, hash []byte) ([]byte, error) {
+	key := ks.unlockedKey(addr)
+	if key == nil {
+		return nil, types.ErrShouldUnlockAccount
+	}
+	sign, err := key.Sign(hash)
+	if err != nil {
+		return nil, err
+	}
+	return sign.Serialize(), nil
+}
+
+func (ks *Store) unlockedKey(addr Identity) *aergokey {
+	ks.unlockedLock.Lock()
+	defer ks.unlockedLock.Unlock()
 	keyPair, exist := ks.unlocked[types.EncodeAddress(addr)]
 	if !exist {
-		return types.ErrShouldUnlockAccount
+		return nil
 	}
-	return SignTx(tx, keyPair.key)
+	return keyPair.key
 }
 
 // VerifyTx return result to verify sign
@@ -73,6 +105,61 @@ func VerifyTxWithAddress(tx *types.Tx, address []byte) error {
 	return nil
 }
 
+// AddMultiSign adds the signature of key to a tx of a multisig account. The
+// signatures already in the tx are kept.
+func AddMultiSign(tx *types.Tx, key *aergokey) error {
+	ms, err := types.DecodeMultiSign(tx.Body.Sign)
+	if err != nil {
+		return err
+	}
+	sign, err := key.Sign(CalculateHashWithoutSign(tx.Body))
+	if err != nil {
+		return err
+	}
+	ms.Add(key.PubKey().SerializeCompressed(), sign.Serialize())
+	if tx.Body.Sign, err = ms.Encode(); err != nil {
+		return err
+	}
+	tx.Hash = tx.CalculateTxHash()
+	return nil
+}
+
+// VerifyTxWithMultisig verifies the signatures of a tx of a multisig account.
+// The tx must be signed by at least the threshold number of the signers.
+func VerifyTxWithMultisig(tx *types.Tx, multisig *types.Multisig) error {
+	ms, err := types.DecodeMultiSign(tx.Body.Sign)
+	if err != nil {
+		return err
+	}
+	hash := CalculateHashWithoutSign(tx.Body)
+	var signed uint32
+	for i, s := range ms.GetSigns() {
+		if i > 0 && bytes.Compare(ms.Signs[i-1].Signer, s.Signer) >= 0 {
+			// the signers are sorted and unique
+			return types.ErrTxInvalidMultiSign
+		}
+		if !multisig.IsSigner(s.Signer) {
+			return types.ErrTxInvalidMultiSign
+		}
+		sign, err := btcec.ParseSignature(s.Sign, btcec.S256())
+		if err != nil {
+			return err
+		}
+		pubkey, err := btcec.ParsePubKey(s.Signer, btcec.S256())
+		if err != nil {
+			return err
+		}
+		if !sign.Verify(hash, pubkey) {
+			return types.ErrSignNotMatch
+		}
+		signed++
+	}
+	if signed < multisig.GetThreshold() {
+		return types.ErrMultiSignNotEnough
+	}
+	return nil
+}
+
 // VerifyTx return result to varify sign
 func (ks *Store) VerifyTx(tx *types.Tx) error {
 	return VerifyTx(tx)
@@ -90,5 +177,9 @@ func CalculateHashWithoutSign(txBody *types.TxBody) []byte {
 	h.Write(txBody.GasPrice)
 	binary.Write(h, binary.LittleEndian, txBody.Type)
 	h.Write(txBody.ChainIdHash)
+	if txBody.HasTimeLock() {
+		binary.Write(h, binary.LittleEndian, txBody.ValidAfter)
+		binary.Write(h, binary.LittleEndian, txBody.ValidBefore)
+	}
 	return h.Sum(nil)
 }
@@ -0,0 +1,54 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package key
+
+import (
+	"testing"
+
+	"github.com/aergoio/aergo/types"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyTxWithMultisig(t *testing.T) {
+	var keys []*btcec.PrivateKey
+	multisig := &types.Multisig{Threshold: 2}
+	for i := 0; i < 3; i++ {
+		k, err := btcec.NewPrivateKey(btcec.S256())
+		assert.NoError(t, err)
+		keys = append(keys, k)
+		multisig.Signers = append(multisig.Signers, k.PubKey().SerializeCompressed())
+	}
+	outsider, err := btcec.NewPrivateKey(btcec.S256())
+	assert.NoError(t, err)
+
+	tx := &types.Tx{Body: &types.TxBody{
+		Account:   []byte("multisigaccount"),
+		Recipient: []byte("recipient"),
+		Amount:    []byte{1},
+		Nonce:     1,
+	}}
+	assert.Equal(t, types.ErrMultiSignNotEnough, VerifyTxWithMultisig(tx, multisig))
+
+	assert.NoError(t, AddMultiSign(tx, keys[0]))
+	assert.Equal(t, types.ErrMultiSignNotEnough, VerifyTxWithMultisig(tx, multisig))
+
+	// a signature of the same signer is counted once
+	assert.NoError(t, AddMultiSign(tx, keys[0]))
+	assert.Equal(t, types.ErrMultiSignNotEnough, VerifyTxWithMultisig(tx, multisig))
+
+	assert.NoError(t, AddMultiSign(tx, keys[2]))
+	assert.NoError(t, VerifyTxWithMultisig(tx, multisig))
+	assert.Equal(t, tx.CalculateTxHash(), tx.Hash)
+
+	signed := tx.Clone()
+	assert.NoError(t, AddMultiSign(signed, outsider))
+	assert.Equal(t, types.ErrTxInvalidMultiSign, VerifyTxWithMultisig(signed, multisig))
+
+	tampered := tx.Clone()
+	tampered.Body.Amount = []byte{2}
+	assert.Equal(t, types.ErrSignNotMatch, VerifyTxWithMultisig(tampered, multisig))
+}
@@ -7,18 +7,18 @@ import (
 )
 
 type Signer struct {
-	keystore *key.Store
+	signer key.TxSigner
 }
 
-func NewSigner(s *key.Store) *Signer {
-	return &Signer{keystore: s}
+func NewSigner(s key.TxSigner) *Signer {
+	return &Signer{signer: s}
 }
 
 //Receive actor message
 func (s *Signer) Receive(context actor.Context) {
 	switch msg := context.Message().(type) {
 	case *message.SignTx:
-		err := s.keystore.SignTx(msg.Tx, msg.Requester)
+		err := s.signer.SignTx(msg.Tx, msg.Requester)
 		defer context.Self().Stop()
 		if err != nil {
 			context.Respond(&message.SignTxRsp{Tx: nil, Err: err})
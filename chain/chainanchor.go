@@ -27,6 +27,8 @@ func (cs *ChainService) getAnchorsNew() (ChainAnchor, types.BlockNo, error) {
 	logger.Debug().Msg("get anchors")
 
 	blkNo := cs.getBestBlockNo()
+	// the blocks below the installed snapshot are missing
+	base := cs.cdb.getSnapshotBase()
 	var lastNo types.BlockNo
 LOOP:
 	for i := 0; i < cnt; i++ {
@@ -45,8 +47,10 @@ LOOP:
 		switch {
 		case blkNo == 0:
 			break LOOP
-		case blkNo < Skip:
+		case blkNo == base:
 			blkNo = 0
+		case blkNo < base+Skip:
+			blkNo = base
 		default:
 			blkNo -= Skip
 		}
@@ -19,6 +19,7 @@ import (
 	"github.com/aergoio/aergo/consensus"
 	"github.com/aergoio/aergo/internal/common"
 	"github.com/aergoio/aergo/internal/enc"
+	"github.com/aergoio/aergo/internal/metrics"
 	"github.com/aergoio/aergo/types"
 	"github.com/golang/protobuf/proto"
 )
@@ -81,6 +82,10 @@ type ChainDB struct {
 	bestBlock atomic.Value // *types.Block
 	//	blocks []*types.Block
 	store db.DB
+
+	eventIndex bool
+
+	statePruneFloor uint64 // types.BlockNo
 }
 
 func NewChainDB() *ChainDB {
@@ -363,6 +368,7 @@ func (cdb *ChainDB) setLatest(newBestBlock *types.Block) (oldLatest types.BlockN
 	newLatest := types.BlockNo(newBestBlock.GetHeader().GetBlockNo())
 	cdb.latest.Store(newLatest)
 	cdb.bestBlock.Store(newBestBlock)
+	metrics.BlockHeight.Set(float64(newLatest))
 
 	logger.Debug().Uint64("old", oldLatest).Uint64("new", newLatest).Msg("update latest block")
 
@@ -397,7 +403,7 @@ func (cdb *ChainDB) connectToChain(dbtx db.Transaction, block *types.Block, skip
 	return
 }
 
-func (cdb *ChainDB) swapChainMapping(newBlocks []*types.Block) error {
+func (cdb *ChainDB) swapChainMapping(newBlocks []*types.Block, events *eventIndexBatch) error {
 	oldNo := cdb.getBestBlockNo()
 	newNo := newBlocks[0].GetHeader().GetBlockNo()
 
@@ -422,6 +428,9 @@ func (cdb *ChainDB) swapChainMapping(newBlocks []*types.Block) error {
 
 	bulk.Set(latestKey, blockIdx)
 
+	// The index must cover the new blocks as soon as they are in the main chain.
+	events.writeTo(bulk)
+
 	// Save the last consensus status.
 	cdb.cc.Save(bulk)
 
@@ -0,0 +1,230 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package chain
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	cfg "github.com/aergoio/aergo/config"
+	"github.com/aergoio/aergo/types"
+	"github.com/golang/protobuf/proto"
+)
+
+// A chain export file consists of the magic bytes, the format version as a
+// 4-byte big endian integer, a ChainExportHeader record and an ExportedBlock
+// record per block in the ascending order of the block numbers. A record is a
+// protobuf message prefixed by its length as an uvarint. The whole file may
+// be compressed by gzip.
+
+const (
+	ChainExportVersion uint32 = 1
+
+	maxExportRecordSize = 1 << 30
+)
+
+var (
+	chainExportMagic = []byte("AERGOCHN")
+	gzipMagic        = []byte{0x1f, 0x8b}
+
+	ErrChainExportFormat  = errors.New("invalid chain export file")
+	ErrChainExportVersion = errors.New("unsupported chain export version")
+	ErrChainExportBlock   = errors.New("invalid block in chain export file")
+)
+
+// ChainExportWriter writes a chain export file.
+type ChainExportWriter struct {
+	w  *bufio.Writer
+	zw *gzip.Writer
+}
+
+// NewChainExportWriter writes the magic bytes, the version and header to w.
+// The file is compressed by gzip if compress is true.
+func NewChainExportWriter(w io.Writer, header *types.ChainExportHeader, compress bool) (*ChainExportWriter, error) {
+	ew := &ChainExportWriter{}
+	if compress {
+		ew.zw = gzip.NewWriter(w)
+		w = ew.zw
+	}
+	ew.w = bufio.NewWriter(w)
+
+	if _, err := ew.w.Write(chainExportMagic); err != nil {
+		return nil, err
+	}
+	if err := binary.Write(ew.w, binary.BigEndian, ChainExportVersion); err != nil {
+		return nil, err
+	}
+	if err := ew.writeRecord(header); err != nil {
+		return nil, err
+	}
+	return ew, nil
+}
+
+func (ew *ChainExportWriter) writeRecord(msg proto.Message) error {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	var l [binary.MaxVarintLen64]byte
+	if _, err = ew.w.Write(l[:binary.PutUvarint(l[:], uint64(len(data)))]); err != nil {
+		return err
+	}
+	_, err = ew.w.Write(data)
+	return err
+}
+
+// Write appends a block with its receipts.
+func (ew *ChainExportWriter) Write(eb *types.ExportedBlock) error {
+	return ew.writeRecord(eb)
+}
+
+// Close flushes the buffered records. It doesn't close the underlying writer.
+func (ew *ChainExportWriter) Close() error {
+	if err := ew.w.Flush(); err != nil {
+		return err
+	}
+	if ew.zw != nil {
+		return ew.zw.Close()
+	}
+	return nil
+}
+
+// ChainExportReader reads a chain export file, which may be compressed.
+type ChainExportReader struct {
+	r      *bufio.Reader
+	header *types.ChainExportHeader
+}
+
+// NewChainExportReader reads and verifies the magic bytes, the version and the
+// header from r.
+func NewChainExportReader(r io.Reader) (*ChainExportReader, error) {
+	br := bufio.NewReader(r)
+	if magic, err := br.Peek(len(gzipMagic)); err == nil && bytes.Equal(magic, gzipMagic) {
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		br = bufio.NewReader(zr)
+	}
+	er := &ChainExportReader{r: br}
+
+	magic := make([]byte, len(chainExportMagic))
+	if _, err := io.ReadFull(er.r, magic); err != nil || !bytes.Equal(magic, chainExportMagic) {
+		return nil, ErrChainExportFormat
+	}
+	var version uint32
+	if err := binary.Read(er.r, binary.BigEndian, &version); err != nil {
+		return nil, ErrChainExportFormat
+	}
+	if version != ChainExportVersion {
+		return nil, ErrChainExportVersion
+	}
+
+	er.header = &types.ChainExportHeader{}
+	if err := er.readRecord(er.header); err != nil {
+		if err == io.EOF {
+			err = ErrChainExportFormat
+		}
+		return nil, err
+	}
+	return er, nil
+}
+
+// Header returns the header of the file.
+func (er *ChainExportReader) Header() *types.ChainExportHeader {
+	return er.header
+}
+
+func (er *ChainExportReader) readRecord(msg proto.Message) error {
+	l, err := binary.ReadUvarint(er.r)
+	if err != nil {
+		// io.EOF is returned only if no byte is read
+		return err
+	}
+	if l > maxExportRecordSize {
+		return ErrChainExportFormat
+	}
+	data := make([]byte, l)
+	if _, err = io.ReadFull(er.r, data); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	return proto.Unmarshal(data, msg)
+}
+
+// Next returns the next block with its receipts. The hash of the block is
+// verified against its header. It returns io.EOF at the end of the file.
+func (er *ChainExportReader) Next() (*types.ExportedBlock, error) {
+	eb := &types.ExportedBlock{}
+	if err := er.readRecord(eb); err != nil {
+		return nil, err
+	}
+	block := eb.GetBlock()
+	if block.GetHeader() == nil {
+		return nil, ErrChainExportBlock
+	}
+	hash := block.GetHash()
+	block.Hash = nil
+	if !bytes.Equal(hash, block.BlockHash()) {
+		return nil, ErrChainExportBlock
+	}
+	return eb, nil
+}
+
+// ExportChain writes the blocks from the block number from to to with their
+// receipts to w. to is the best block if it is 0. The receipts are not needed
+// for an import, where they are regenerated by executing the blocks, but they
+// make the file a complete archive of the chain.
+func (core *Core) ExportChain(w io.Writer, from, to types.BlockNo, compress bool, hardforkConfig *cfg.HardforkConfig) error {
+	best := core.cdb.getBestBlockNo()
+	if to == 0 {
+		to = best
+	} else if to > best {
+		return fmt.Errorf("block %d is beyond the best block %d", to, best)
+	}
+	if from > to {
+		return fmt.Errorf("invalid block range %d-%d", from, to)
+	}
+
+	header := &types.ChainExportHeader{
+		GenesisHash: core.cdb.GetGenesisInfo().Block().BlockHash(),
+		From:        from,
+		To:          to,
+	}
+	ew, err := NewChainExportWriter(w, header, compress)
+	if err != nil {
+		return err
+	}
+
+	for no := from; no <= to; no++ {
+		block, err := core.cdb.GetBlockByNo(no)
+		if err != nil {
+			return err
+		}
+		eb := &types.ExportedBlock{Block: block}
+		if core.cdb.checkExistReceipts(block.BlockHash(), no) {
+			receipts, err := core.cdb.getReceipts(block.BlockHash(), no, hardforkConfig)
+			if err != nil {
+				return err
+			}
+			eb.Receipts = receipts.Get()
+		}
+		if err = ew.Write(eb); err != nil {
+			return err
+		}
+		if no%10000 == 0 {
+			logger.Info().Uint64("no", no).Msg("exporting blocks")
+		}
+	}
+	return ew.Close()
+}
@@ -0,0 +1,88 @@
+package chain
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/aergoio/aergo/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func testExportBlocks(n int) []*types.Block {
+	blocks := make([]*types.Block, n)
+	var prevHash []byte
+	for i := range blocks {
+		blocks[i] = &types.Block{
+			Header: &types.BlockHeader{BlockNo: types.BlockNo(i + 1), Timestamp: int64(i), PrevBlockHash: prevHash},
+			Body:   &types.BlockBody{Txs: []*types.Tx{{Hash: []byte{byte(i)}}}},
+		}
+		prevHash = blocks[i].BlockHash()
+	}
+	return blocks
+}
+
+func TestChainExportReadWrite(t *testing.T) {
+	blocks := testExportBlocks(5)
+	header := &types.ChainExportHeader{GenesisHash: []byte("genesis"), From: 1, To: 5}
+
+	for _, compress := range []bool{false, true} {
+		var buf bytes.Buffer
+		ew, err := NewChainExportWriter(&buf, header, compress)
+		assert.NoError(t, err)
+		for _, block := range blocks {
+			receipt := types.NewReceipt(make([]byte, types.AddressLength), "SUCCESS", "")
+			receipt.TxHash = block.Body.Txs[0].Hash
+			assert.NoError(t, ew.Write(&types.ExportedBlock{Block: block, Receipts: []*types.Receipt{receipt}}))
+		}
+		assert.NoError(t, ew.Close())
+
+		er, err := NewChainExportReader(bytes.NewReader(buf.Bytes()))
+		assert.NoError(t, err)
+		assert.Equal(t, header.GenesisHash, er.Header().GenesisHash)
+		assert.Equal(t, header.To, er.Header().To)
+		for _, block := range blocks {
+			eb, err := er.Next()
+			assert.NoError(t, err)
+			assert.Equal(t, block.BlockHash(), eb.GetBlock().BlockHash())
+			assert.Equal(t, block.Body.Txs[0].Hash, eb.GetReceipts()[0].TxHash)
+		}
+		_, err = er.Next()
+		assert.Equal(t, io.EOF, err)
+
+		if !compress {
+			// a truncated file is not mistaken for its end
+			er, err = NewChainExportReader(bytes.NewReader(buf.Bytes()[:buf.Len()-3]))
+			assert.NoError(t, err)
+			for i := 0; i < len(blocks)-1; i++ {
+				_, err = er.Next()
+				assert.NoError(t, err)
+			}
+			_, err = er.Next()
+			assert.Equal(t, io.ErrUnexpectedEOF, err)
+		}
+	}
+}
+
+func TestChainExportInvalid(t *testing.T) {
+	_, err := NewChainExportReader(bytes.NewReader([]byte("NOTACHAIN")))
+	assert.Equal(t, ErrChainExportFormat, err)
+
+	data := append(append([]byte{}, chainExportMagic...), 0, 0, 0, 9)
+	_, err = NewChainExportReader(bytes.NewReader(data))
+	assert.Equal(t, ErrChainExportVersion, err)
+
+	// a block whose hash doesn't match its header
+	var buf bytes.Buffer
+	ew, err := NewChainExportWriter(&buf, &types.ChainExportHeader{}, false)
+	assert.NoError(t, err)
+	block := testExportBlocks(1)[0]
+	block.Header.Timestamp = 100
+	assert.NoError(t, ew.Write(&types.ExportedBlock{Block: block}))
+	assert.NoError(t, ew.Close())
+
+	er, err := NewChainExportReader(&buf)
+	assert.NoError(t, err)
+	_, err = er.Next()
+	assert.Equal(t, ErrChainExportBlock, err)
+}
@@ -12,6 +12,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/aergoio/aergo/contract/system"
 
@@ -19,6 +20,7 @@ import (
 	"github.com/aergoio/aergo/contract"
 	"github.com/aergoio/aergo/contract/name"
 	"github.com/aergoio/aergo/internal/enc"
+	"github.com/aergoio/aergo/internal/metrics"
 	"github.com/aergoio/aergo/message"
 	"github.com/aergoio/aergo/state"
 	"github.com/aergoio/aergo/types"
@@ -135,6 +137,62 @@ func (cs *ChainService) getReceipt(txHash []byte) (*types.Receipt, error) {
 	return r, nil
 }
 
+// mainBlockOfTx returns the block of the main chain which includes the tx of
+// txHash and the index of the tx.
+func (cs *ChainService) mainBlockOfTx(txHash []byte) (*types.Block, int32, error) {
+	_, i, err := cs.cdb.getTx(txHash)
+	if err != nil {
+		return nil, 0, err
+	}
+	block, err := cs.cdb.getBlock(i.BlockHash)
+	if err != nil {
+		return nil, 0, err
+	}
+	blockInMainChain, err := cs.cdb.GetBlockByNo(block.Header.BlockNo)
+	if err != nil || !bytes.Equal(block.BlockHash(), blockInMainChain.BlockHash()) {
+		return nil, 0, errors.New("tx is not in the main chain")
+	}
+	return block, i.Idx, nil
+}
+
+func (cs *ChainService) getTxProof(txHash []byte) (*types.TxProof, error) {
+	block, idx, err := cs.mainBlockOfTx(txHash)
+	if err != nil {
+		return nil, err
+	}
+	txs := block.GetBody().GetTxs()
+	return &types.TxProof{
+		Tx:        txs[idx],
+		BlockHash: block.BlockHash(),
+		BlockNo:   block.BlockNo(),
+		Index:     idx,
+		Count:     int32(len(txs)),
+		AuditPath: types.CalculateTxsProof(txs, int(idx)),
+	}, nil
+}
+
+func (cs *ChainService) getReceiptProof(txHash []byte) (*types.ReceiptProof, error) {
+	block, idx, err := cs.mainBlockOfTx(txHash)
+	if err != nil {
+		return nil, err
+	}
+	receipts, err := cs.cdb.getReceipts(block.BlockHash(), block.BlockNo(), cs.cfg.Hardfork)
+	if err != nil {
+		return nil, err
+	}
+	if int(idx) >= len(receipts.Get()) {
+		return nil, fmt.Errorf("cannot find a receipt: invalid index (%d)", idx)
+	}
+	return &types.ReceiptProof{
+		Receipt:   receipts.Get()[idx],
+		BlockHash: block.BlockHash(),
+		BlockNo:   block.BlockNo(),
+		Index:     idx,
+		Count:     int32(receipts.MerkleLeafCount()),
+		AuditPath: receipts.MerkleProof(int(idx)),
+	}, nil
+}
+
 func (cs *ChainService) getEvents(events *[]*types.Event, blkNo types.BlockNo, filter *types.FilterInfo,
 	argFilter []types.ArgFilter) uint64 {
 	blkHash, err := cs.cdb.getHashByNo(blkNo)
@@ -182,6 +240,17 @@ func (cs *ChainService) listEvents(filter *types.FilterInfo) ([]*types.Event, er
 			to = cs.cdb.getBestBlockNo()
 		}
 	}
+	if cs.cdb.isEventIndexed(from) {
+		if err := filter.ValidateIndexedCheck(to); err != nil {
+			return nil, err
+		}
+		argFilter, err := filter.GetExArgFilter()
+		if err != nil {
+			return nil, err
+		}
+		return cs.cdb.lookupEvents(filter, argFilter, from, to, cs.cfg.Hardfork)
+	}
+
 	err := filter.ValidateCheck(to)
 	if err != nil {
 		return nil, err
@@ -332,6 +401,7 @@ func (cp *chainProcessor) execute(block *types.Block) error {
 
 	var err error
 
+	start := time.Now()
 	err = cp.executeBlock(block)
 	if err != nil {
 		logger.Error().Str("error", err.Error()).Str("hash", block.ID()).
@@ -345,6 +415,8 @@ func (cp *chainProcessor) execute(block *types.Block) error {
 	if _, err = cp.connectToChain(block); err != nil {
 		return err
 	}
+	metrics.BlockProcessTime.Observe(time.Since(start).Seconds())
+	contract.WriteBlockProfile(block.BlockNo())
 
 	cp.notifyBlockByOther(block)
 
@@ -360,6 +432,7 @@ func (cp *chainProcessor) connectToChain(block *types.Block) (types.BlockNo, err
 	if err := cp.cdb.addTxsOfBlock(&dbTx, block.GetBody().GetTxs(), block.BlockHash()); err != nil {
 		return 0, err
 	}
+	cp.cdb.addEventIndex(dbTx, block, cp.cfg.Hardfork)
 
 	dbTx.Commit()
 
@@ -522,6 +595,8 @@ func (cs *ChainService) addBlock(newBlock *types.Block, usedBstate *state.BlockS
 		return err
 	}
 
+	cs.statePruner.maybePrune()
+
 	return nil
 }
 
@@ -633,6 +708,7 @@ func NewTxExecutor(ccc consensus.ChainConsensusCluster, cdb contract.ChainAccess
 		}
 		snapshot := bState.Snapshot()
 
+		start := time.Now()
 		err := executeTx(ccc, cdb, bState, tx, bi, preLoadService)
 		if err != nil {
 			logger.Error().Err(err).Str("hash", enc.ToString(tx.GetHash())).Msg("tx failed")
@@ -642,6 +718,10 @@ func NewTxExecutor(ccc consensus.ChainConsensusCluster, cdb contract.ChainAccess
 
 			return err
 		}
+		metrics.TxExecuteTime.Observe(time.Since(start).Seconds())
+		if receipts := bState.Receipts().Get(); len(receipts) > 0 {
+			metrics.ContractGasUsed.Add(float64(receipts[len(receipts)-1].GasUsed))
+		}
 		return nil
 	}
 }
@@ -676,7 +756,7 @@ func (e *blockExecutor) execute() error {
 			return err
 		}
 
-		if err := contract.SaveRecoveryPoint(e.BlockState); err != nil {
+		if err := contract.SaveRecoveryPoint(e.BlockState, e.bi); err != nil {
 			return err
 		}
 
@@ -782,6 +862,7 @@ func (cs *ChainService) verifyBlock(block *types.Block) error {
 	if err = cs.sdb.SetRoot(block.GetHeader().GetBlocksRootHash()); err != nil {
 		return fmt.Errorf("failed to set root of sdb(no=%d,hash=%v)", block.BlockNo(), block.ID())
 	}
+	contract.WriteBlockProfile(block.BlockNo())
 
 	logger.Debug().Uint64("no", block.GetHeader().BlockNo).Msg("end verify")
 
@@ -876,6 +957,20 @@ func resetAccount(account *state.V, fee *big.Int, nonce *uint64) error {
 	return account.PutState()
 }
 
+// chargeTip subtracts the priority tip for gasUsed from the balance of payer
+// and returns the charged amount. The balance is checked to cover the tip for
+// the gas limit before the execution, so the tip is never truncated.
+func chargeTip(payer *state.V, tipPrice *big.Int, gasUsed uint64) (*big.Int, error) {
+	tip := new(big.Int).Mul(tipPrice, new(big.Int).SetUint64(gasUsed))
+	if tip.Sign() > 0 {
+		if payer.Balance().Cmp(tip) < 0 {
+			return nil, &types.InternalError{Reason: "tip is greater than balance"}
+		}
+		payer.SubBalance(tip)
+	}
+	return tip, nil
+}
+
 func executeTx(
 	ccc consensus.ChainConsensusCluster,
 	cdb contract.ChainAccessor,
@@ -909,6 +1004,13 @@ func executeTx(
 		return err
 	}
 
+	if err = txBody.ValidateTimeLock(bi.Version); err != nil {
+		return err
+	}
+	if err = txBody.CheckTimeLock(bi.No, bi.Ts); err != nil {
+		return err
+	}
+
 	sender, err := bs.GetAccountStateV(account)
 	if err != nil {
 		return err
@@ -919,6 +1021,32 @@ func executeTx(
 		return err
 	}
 
+	checkFee := new(big.Int)
+	if len(sender.State().GetCodeHash()) > 0 {
+		// the signature of a contract account is checked by the contract
+		if bi.Version < 3 {
+			return types.ErrSignNotMatch
+		}
+		var contractState *state.ContractState
+		contractState, err = bs.OpenContractState(sender.AccountID(), sender.State())
+		if err != nil {
+			return err
+		}
+		if checkFee, err = contract.CheckContractAccount(account, bs, cdb, contractState, tx.GetTx()); err != nil {
+			logger.Warn().Err(err).Str("txhash", enc.ToString(tx.GetHash())).Msg("checkContractAccount Error")
+			return err
+		}
+		// the contract pays for the check, and the rest of its balance must
+		// still cover tx
+		if sender.Balance().Cmp(checkFee) < 0 {
+			return types.ErrInsufficientBalance
+		}
+		sender.SubBalance(checkFee)
+		if err = tx.ValidateWithSenderState(sender.State(), bs.GasPrice, bi.Version); err != nil {
+			return err
+		}
+	}
+
 	if recipient, err = name.Resolve(bs, txBody.Recipient, isQuirkTx); err != nil {
 		return err
 	}
@@ -943,6 +1071,20 @@ func executeTx(
 	var events []*types.Event
 	switch txBody.Type {
 	case types.TxType_NORMAL, types.TxType_REDEPLOY, types.TxType_TRANSFER, types.TxType_CALL, types.TxType_DEPLOY:
+		if txBody.GetTipPrice(bs.GasPrice, bi.Version).Sign() > 0 {
+			balance := new(big.Int).Sub(sender.Balance(), txBody.GetAmountBigInt())
+			if balance.Sign() < 0 {
+				return types.ErrInsufficientBalance
+			}
+			var fee *big.Int
+			fee, err = tx.GetMaxFee(balance, bs.GasPrice, bi.Version)
+			if err != nil {
+				return err
+			}
+			if fee.Cmp(balance) > 0 {
+				return types.ErrInsufficientBalance
+			}
+		}
 		rv, events, txFee, err = contract.Execute(bs, cdb, tx.GetTx(), sender, receiver, bi, preLoadService, false)
 		sender.SubBalance(txFee)
 	case types.TxType_GOVERNANCE:
@@ -979,17 +1121,28 @@ func executeTx(
 		receiver.SubBalance(txFee)
 	}
 
+	var gasUsed uint64
+	var tip *big.Int
+	if txFee != nil {
+		gasUsed = contract.GasUsed(txFee, bs.GasPrice, txBody.Type, bi.Version)
+	}
+	payer := sender
+	if txBody.Type == types.TxType_FEEDELEGATION {
+		payer = receiver
+	}
+	tipPrice := txBody.GetTipPrice(bs.GasPrice, bi.Version)
+
 	if err != nil {
 		if !contract.IsRuntimeError(err) {
 			return err
 		}
 		if txBody.Type != types.TxType_FEEDELEGATION || sender.AccountID() == receiver.AccountID() {
-			sErr := resetAccount(sender, txFee, &txBody.Nonce)
+			sErr := resetAccount(sender, new(big.Int).Add(txFee, checkFee), &txBody.Nonce)
 			if sErr != nil {
 				return sErr
 			}
 		} else {
-			sErr := resetAccount(sender, nil, &txBody.Nonce)
+			sErr := resetAccount(sender, checkFee, &txBody.Nonce)
 			if sErr != nil {
 				return sErr
 			}
@@ -998,9 +1151,21 @@ func executeTx(
 				return sErr
 			}
 		}
+		var sErr error
+		if tip, sErr = chargeTip(payer, tipPrice, gasUsed); sErr != nil {
+			return sErr
+		}
+		if tip.Sign() > 0 {
+			if sErr = payer.PutState(); sErr != nil {
+				return sErr
+			}
+		}
 		status = "ERROR"
 		rv = err.Error()
 	} else {
+		if tip, err = chargeTip(payer, tipPrice, gasUsed); err != nil {
+			return err
+		}
 		if txBody.Type != types.TxType_FEEDELEGATION {
 			if sender.Balance().Sign() < 0 {
 				return &types.InternalError{Reason: "fee is greater than balance"}
@@ -1023,6 +1188,8 @@ func executeTx(
 		}
 		rv = adjustRv(rv)
 	}
+	txFee = new(big.Int).Add(txFee, tip)
+	txFee.Add(txFee, checkFee)
 	bs.BpReward.Add(&bs.BpReward, txFee)
 
 	receipt := types.NewReceipt(receiver.ID(), status, rv)
@@ -1030,7 +1197,7 @@ func executeTx(
 	receipt.TxHash = tx.GetHash()
 	receipt.Events = events
 	receipt.FeeDelegation = txBody.Type == types.TxType_FEEDELEGATION
-	receipt.GasUsed = contract.GasUsed(txFee, bs.GasPrice, txBody.Type, bi.Version)
+	receipt.GasUsed = gasUsed
 
 	return bs.AddReceipt(receipt)
 }
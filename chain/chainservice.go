@@ -6,6 +6,7 @@
 package chain
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"math/big"
@@ -39,6 +40,8 @@ var (
 	ErrNotSupportedConsensus = errors.New("not supported by this consensus")
 	ErrRecoNoBestStateRoot   = errors.New("state root of best block is not exist")
 	ErrRecoInvalidSdbRoot    = errors.New("state root of sdb is invalid")
+	ErrBlockNotInMainChain   = errors.New("block is not in the main chain")
+	ErrStateNotAvailable     = errors.New("state of the block is not available")
 
 	TestDebugger *Debugger
 )
@@ -162,6 +165,13 @@ func (core *Core) Close() {
 	contract.CloseDatabase()
 }
 
+// RebuildEventIndex indexes the contract events of all the blocks in the
+// chain DB.
+func (core *Core) RebuildEventIndex(hardforkConfig *cfg.HardforkConfig) error {
+	core.cdb.initEventIndex(true)
+	return core.cdb.rebuildEventIndex(hardforkConfig)
+}
+
 // InitGenesisBlock initialize chain database and generate specified genesis block if necessary
 func (core *Core) InitGenesisBlock(gb *types.Genesis, useTestnet bool) error {
 	_, err := core.initGenesis(gb, useTestnet, false)
@@ -177,10 +187,13 @@ type IChainHandler interface {
 	getBlockByNo(blockNo types.BlockNo) (*types.Block, error)
 	getTx(txHash []byte) (*types.Tx, *types.TxIdx, error)
 	getReceipt(txHash []byte) (*types.Receipt, error)
+	getTxProof(txHash []byte) (*types.TxProof, error)
+	getReceiptProof(txHash []byte) (*types.ReceiptProof, error)
 	getAccountVote(addr []byte) (*types.AccountVoteInfo, error)
 	getVotes(id string, n uint32) (*types.VoteList, error)
-	getStaking(addr []byte) (*types.Staking, error)
-	getNameInfo(name string, blockNo types.BlockNo) (*types.NameInfo, error)
+	getStaking(addr []byte, blockNo types.BlockNo, blockHash []byte) (*types.Staking, error)
+	getNameInfo(name string, blockNo types.BlockNo, blockHash []byte) (*types.NameInfo, error)
+	getContractVersions(contract []byte, blockNo types.BlockNo, blockHash []byte) (*types.ContractVersionList, error)
 	getEnterpriseConf(key string) (*types.EnterpriseConfig, error)
 	addBlock(newBlock *types.Block, usedBstate *state.BlockState, peerID types.PeerID) error
 	getAnchorsNew() (ChainAnchor, types.BlockNo, error)
@@ -188,6 +201,8 @@ type IChainHandler interface {
 	setSkipMempool(val bool)
 	listEvents(filter *types.FilterInfo) ([]*types.Event, error)
 	verifyBlock(block *types.Block) error
+	simulateTx(tx *types.Tx) (*types.Receipt, error)
+	installSnapshot(block *types.Block) error
 }
 
 // ChainService manage connectivity of blocks
@@ -208,6 +223,8 @@ type ChainService struct {
 
 	stat stats
 
+	statePruner *statePruner
+
 	recovered  atomic.Value
 	debuggable bool
 }
@@ -271,6 +288,11 @@ func NewChainService(cfg *cfg.Config) *ChainService {
 		panic(msg)
 	}
 
+	cs.cdb.initEventIndex(cfg.Blockchain.EventIndex)
+
+	cs.cdb.initStatePrune()
+	cs.statePruner = newStatePruner(cs.Core, cfg.Blockchain.StateKeep, cfg.Blockchain.StatePruneIntvl)
+
 	if ConsensusName() == consensus.ConsensusName[consensus.ConsensusDPOS] {
 		top, err := cs.getVotes(types.OpvoteBP.ID(), 1)
 		if err != nil {
@@ -289,6 +311,7 @@ func NewChainService(cfg *cfg.Config) *ChainService {
 	}
 	contract.PubNet = pubNet
 	contract.TraceBlockNo = cfg.Blockchain.StateTrace
+	contract.ProfileBlockNo = cfg.Blockchain.ProfileBlock
 	contract.SetStateSQLMaxDBSize(cfg.SQL.MaxDbSize)
 	contract.StartLStateFactory((cfg.Blockchain.NumWorkers+2)*(contract.MaxCallDepth+2), cfg.Blockchain.NumLStateClosers, cfg.Blockchain.CloseLimit)
 	contract.HardforkConfig = cs.cfg.Hardfork
@@ -341,6 +364,11 @@ func (cs *ChainService) WalDB() consensus.ChainWAL {
 	return cs.cdb
 }
 
+// GetReceipts returns the receipts of the block of blockHash and blockNo.
+func (cs *ChainService) GetReceipts(blockHash []byte, blockNo types.BlockNo) (*types.Receipts, error) {
+	return cs.cdb.getReceipts(blockHash, blockNo, cs.cfg.Hardfork)
+}
+
 // GetConsensusInfo returns consensus-related information, which is different
 // from consensus to consensus.
 func (cs *ChainService) GetConsensusInfo() string {
@@ -427,7 +455,9 @@ func (cs *ChainService) Receive(context actor.Context) {
 	switch msg := context.Message().(type) {
 	case *message.AddBlock,
 		*message.GetAnchors, //TODO move to ChainWorker (need chain lock)
-		*message.GetAncestor:
+		*message.GetAncestor,
+		*message.InstallSnapshot,
+		*message.SimulateTx: // writes the SQL databases like the block execution
 		cs.chainManager.Request(msg, context.Sender())
 
 		//pass to chainWorker
@@ -437,7 +467,10 @@ func (cs *ChainService) Receive(context actor.Context) {
 		*message.GetStateAndProof,
 		*message.GetTx,
 		*message.GetReceipt,
+		*message.GetTxProof,
+		*message.GetReceiptProof,
 		*message.GetABI,
+		*message.GetContractVersions,
 		*message.GetQuery,
 		*message.GetStateQuery,
 		*message.GetElected,
@@ -447,7 +480,9 @@ func (cs *ChainService) Receive(context actor.Context) {
 		*message.GetEnterpriseConf,
 		*message.GetParams,
 		*message.ListEvents,
-		*message.CheckFeeDelegation:
+		*message.CheckFeeDelegation,
+		*message.CheckContractAccount,
+		*message.ReadStateSnapshot:
 		cs.chainWorker.Request(msg, context.Sender())
 
 		//handle directly
@@ -533,12 +568,15 @@ func (cs *ChainService) getAccountVote(addr []byte) (*types.AccountVoteInfo, err
 	return &types.AccountVoteInfo{Voting: voteInfo}, nil
 }
 
-func (cs *ChainService) getStaking(addr []byte) (*types.Staking, error) {
+func (cs *ChainService) getStaking(addr []byte, blockNo types.BlockNo, blockHash []byte) (*types.Staking, error) {
 	if cs.GetType() != consensus.ConsensusDPOS {
 		return nil, ErrNotSupportedConsensus
 	}
 
-	sdb := cs.sdb.OpenNewStateDB(cs.sdb.GetRoot())
+	sdb, _, err := cs.openStateDB(blockNo, blockHash)
+	if err != nil {
+		return nil, err
+	}
 	scs, err := sdb.GetSystemAccountState()
 	if err != nil {
 		return nil, err
@@ -554,20 +592,62 @@ func (cs *ChainService) getStaking(addr []byte) (*types.Staking, error) {
 	return staking, nil
 }
 
-func (cs *ChainService) getNameInfo(qname string, blockNo types.BlockNo) (*types.NameInfo, error) {
-	var stateDB *state.StateDB
-	if blockNo != 0 {
-		block, err := cs.cdb.GetBlockByNo(blockNo)
-		if err != nil {
-			return nil, err
-		}
-		stateDB = cs.sdb.OpenNewStateDB(block.GetHeader().GetBlocksRootHash())
-	} else {
-		stateDB = cs.sdb.OpenNewStateDB(cs.sdb.GetRoot())
+func (cs *ChainService) getContractVersions(contractAddr []byte, blockNo types.BlockNo, blockHash []byte) (*types.ContractVersionList, error) {
+	sdb, _, err := cs.openStateDB(blockNo, blockHash)
+	if err != nil {
+		return nil, err
+	}
+	address, err := getAddressNameResolved(sdb, contractAddr)
+	if err != nil {
+		return nil, err
+	}
+	contractState, err := sdb.OpenContractStateAccount(types.ToAccountID(address))
+	if err != nil {
+		return nil, err
+	}
+	return contract.GetCodeVersions(contractState)
+}
+
+func (cs *ChainService) getNameInfo(qname string, blockNo types.BlockNo, blockHash []byte) (*types.NameInfo, error) {
+	stateDB, _, err := cs.openStateDB(blockNo, blockHash)
+	if err != nil {
+		return nil, err
 	}
 	return name.GetNameInfo(stateDB, qname)
 }
 
+// openStateDB opens the state of the block of blockHash or blockNo. If neither
+// of them is set, the state of the best block is opened and the returned block
+// is nil.
+func (core *Core) openStateDB(blockNo types.BlockNo, blockHash []byte) (*state.StateDB, *types.Block, error) {
+	var (
+		block *types.Block
+		err   error
+	)
+	switch {
+	case len(blockHash) != 0:
+		if block, err = core.cdb.getBlock(blockHash); err != nil {
+			return nil, nil, err
+		}
+		// The states of the blocks in a side chain are not guaranteed to exist.
+		if mainHash, err := core.cdb.getHashByNo(block.BlockNo()); err != nil || !bytes.Equal(mainHash, block.BlockHash()) {
+			return nil, nil, ErrBlockNotInMainChain
+		}
+	case blockNo != 0:
+		if block, err = core.cdb.GetBlockByNo(blockNo); err != nil {
+			return nil, nil, err
+		}
+	default:
+		return core.sdb.OpenNewStateDB(core.sdb.GetRoot()), nil, nil
+	}
+
+	root := block.GetHeader().GetBlocksRootHash()
+	if core.cdb.isStatePruned(block.BlockNo()) || !core.sdb.GetStateDB().HasMarker(root) {
+		return nil, nil, ErrStateNotAvailable
+	}
+	return core.sdb.OpenNewStateDB(root), block, nil
+}
+
 func (cs *ChainService) getEnterpriseConf(key string) (*types.EnterpriseConfig, error) {
 	sdb := cs.sdb.OpenNewStateDB(cs.sdb.GetRoot())
 	if strings.ToUpper(key) != enterprise.AdminsKey {
@@ -679,6 +759,17 @@ func (cm *ChainManager) Receive(context actor.Context) {
 			Ancestor: ancestor,
 			Err:      err,
 		})
+	case *message.InstallSnapshot:
+		err := cm.installSnapshot(msg.Block)
+		if err != nil {
+			logger.Error().Err(err).Uint64("no", msg.Block.BlockNo()).Str("hash", msg.Block.ID()).Msg("failed to install snapshot")
+		}
+		context.Respond(message.InstallSnapshotRsp{Err: err})
+	case *message.SimulateTx:
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		receipt, err := cm.simulateTx(msg.Tx)
+		context.Respond(message.SimulateTxRsp{Receipt: receipt, Err: err})
 	case *actor.Started, *actor.Stopping, *actor.Stopped, *component.CompStatReq: // donothing
 	default:
 		debug := fmt.Sprintf("[%s] Missed message. (%v) %s", cm.name, reflect.TypeOf(msg), msg)
@@ -721,7 +812,15 @@ func (cw *ChainWorker) Receive(context actor.Context) {
 			Err:   err,
 		})
 	case *message.GetState:
-		sdb = cw.sdb.OpenNewStateDB(cw.sdb.GetRoot())
+		sdb, _, err := cw.openStateDB(msg.BlockNo, msg.BlockHash)
+		if err != nil {
+			context.Respond(message.GetStateRsp{
+				Account: msg.Account,
+				State:   nil,
+				Err:     err,
+			})
+			return
+		}
 		address, err := getAddressNameResolved(sdb, msg.Account)
 		if err != nil {
 			context.Respond(message.GetStateRsp{
@@ -774,8 +873,27 @@ func (cw *ChainWorker) Receive(context actor.Context) {
 			Receipt: receipt,
 			Err:     err,
 		})
+	case *message.GetTxProof:
+		proof, err := cw.getTxProof(msg.TxHash)
+		context.Respond(message.GetTxProofRsp{
+			Proof: proof,
+			Err:   err,
+		})
+	case *message.GetReceiptProof:
+		proof, err := cw.getReceiptProof(msg.TxHash)
+		context.Respond(message.GetReceiptProofRsp{
+			Proof: proof,
+			Err:   err,
+		})
 	case *message.GetABI:
-		sdb = cw.sdb.OpenNewStateDB(cw.sdb.GetRoot())
+		sdb, _, err := cw.openStateDB(msg.BlockNo, msg.BlockHash)
+		if err != nil {
+			context.Respond(message.GetABIRsp{
+				ABI: nil,
+				Err: err,
+			})
+			break
+		}
 		address, err := getAddressNameResolved(sdb, msg.Contract)
 		if err != nil {
 			context.Respond(message.GetABIRsp{
@@ -797,10 +915,20 @@ func (cw *ChainWorker) Receive(context actor.Context) {
 				Err: err,
 			})
 		}
+	case *message.GetContractVersions:
+		versions, err := cw.getContractVersions(msg.Contract, msg.BlockNo, msg.BlockHash)
+		context.Respond(message.GetContractVersionsRsp{
+			Versions: versions,
+			Err:      err,
+		})
 	case *message.GetQuery:
 		runtime.LockOSThread()
 		defer runtime.UnlockOSThread()
-		sdb = cw.sdb.OpenNewStateDB(cw.sdb.GetRoot())
+		sdb, block, err := cw.openStateDB(msg.BlockNo, msg.BlockHash)
+		if err != nil {
+			context.Respond(message.GetQueryRsp{Result: nil, Err: err})
+			break
+		}
 		address, err := getAddressNameResolved(sdb, msg.Contract)
 		if err != nil {
 			context.Respond(message.GetQueryRsp{Result: nil, Err: err})
@@ -812,9 +940,11 @@ func (cw *ChainWorker) Receive(context actor.Context) {
 			context.Respond(message.GetQueryRsp{Result: nil, Err: err})
 		} else {
 			bs := state.NewBlockState(sdb)
-			ret, err := contract.Query(address, bs, cw.cdb, ctrState, msg.Queryinfo)
+			ret, err := contract.QueryAt(address, bs, cw.cdb, ctrState, msg.Queryinfo, block)
 			context.Respond(message.GetQueryRsp{Result: ret, Err: err})
 		}
+	case *message.ReadStateSnapshot:
+		context.Respond(message.ReadStateSnapshotRsp{Rsp: cw.readStateSnapshot(msg.Req)})
 	case *message.GetStateQuery:
 		var varProofs []*types.ContractVarProof
 		var contractProof *types.AccountProof
@@ -866,13 +996,13 @@ func (cw *ChainWorker) Receive(context actor.Context) {
 			Err:  err,
 		})
 	case *message.GetStaking:
-		staking, err := cw.getStaking(msg.Addr)
+		staking, err := cw.getStaking(msg.Addr, msg.BlockNo, msg.BlockHash)
 		context.Respond(&message.GetStakingRsp{
 			Staking: staking,
 			Err:     err,
 		})
 	case *message.GetNameInfo:
-		owner, err := cw.getNameInfo(msg.Name, msg.BlockNo)
+		owner, err := cw.getNameInfo(msg.Name, msg.BlockNo, msg.BlockHash)
 		context.Respond(&message.GetNameInfoRsp{
 			Owner: owner,
 			Err:   err,
@@ -909,6 +1039,21 @@ func (cw *ChainWorker) Receive(context actor.Context) {
 			err := contract.CheckFeeDelegation(msg.Contract, bs, cw.cdb, ctrState, msg.Payload, msg.TxHash, msg.Sender, msg.Amount)
 			context.Respond(message.CheckFeeDelegationRsp{Err: err})
 		}
+	case *message.CheckContractAccount:
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+
+		sdb = cw.sdb.OpenNewStateDB(cw.sdb.GetRoot())
+		ctrState, err := sdb.OpenContractStateAccount(types.ToAccountID(msg.Contract))
+		if err != nil {
+			logger.Error().Str("hash", enc.ToString(msg.Contract)).Err(err).Msg("failed to get state for contract")
+			context.Respond(message.CheckContractAccountRsp{Err: err})
+		} else {
+			bs := state.NewBlockState(sdb)
+			bs.SetGasPrice(system.GetGasPriceFromState(bs))
+			_, err := contract.CheckContractAccount(msg.Contract, bs, cw.cdb, ctrState, msg.Tx)
+			context.Respond(message.CheckContractAccountRsp{Err: err})
+		}
 
 	case *actor.Started, *actor.Stopping, *actor.Stopped, *component.CompStatReq: // donothing
 	default:
@@ -245,6 +245,25 @@ func TestSideChainReorg(t *testing.T) {
 	assert.Equal(t, sideBestBlock.BlockHash(), mainBestBlock.BlockHash())
 }
 
+func TestOpenStateDB(t *testing.T) {
+	cs, _, sideChain := testSideBranch(t, 3)
+
+	sdb, block, err := cs.openStateDB(0, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, block)
+	assert.Equal(t, cs.sdb.GetRoot(), sdb.GetRoot())
+
+	// The state of a block in a side chain is not served.
+	_, _, err = cs.openStateDB(0, sideChain.GetBlockByNo(2).BlockHash())
+	assert.Equal(t, ErrBlockNotInMainChain, err)
+
+	_, _, err = cs.openStateDB(0, []byte("unknown block"))
+	assert.Error(t, err)
+
+	_, _, err = cs.openStateDB(100, nil)
+	assert.Error(t, err)
+}
+
 func TestAddErroredBlock(t *testing.T) {
 	// make chain
 	cs, stubChain := testAddBlock(t, 10)
@@ -0,0 +1,388 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package chain
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/aergoio/aergo/config"
+	"github.com/aergoio/aergo/types"
+	"github.com/golang/protobuf/proto"
+)
+
+// The event index maps contract events to their position (block no, tx index,
+// event index) in the main chain. Three kinds of keys are maintained for each
+// event:
+//
+//	ev.a | contract address | position
+//	ev.n | contract address | event name hash | position
+//	ev.g | contract address | event name hash | arg no | arg value hash | position
+//
+// The position is encoded in big endian so that the keys of a contract are
+// sorted by block number. The values are empty: an event is loaded from the
+// receipts of its block and is checked against the filter again, which also
+// discards stale entries possibly left by an interrupted reorganization.
+var (
+	eventIndexAddrPrefix = []byte("ev.a")
+	eventIndexNamePrefix = []byte("ev.n")
+	eventIndexArgPrefix  = []byte("ev.g")
+
+	// eventIndexBaseKey stores the block number from which the index is
+	// complete.
+	eventIndexBaseKey = []byte("ev.base")
+)
+
+const (
+	// maxIndexedEventArgs is the number of leading event arguments indexed.
+	maxIndexedEventArgs = 4
+
+	eventNameHashLen = 8
+	eventArgHashLen  = 8
+	eventPosLen      = 16
+)
+
+var (
+	ErrEventIndexDisabled = errors.New("event index is disabled")
+	ErrEventIndexNotReady = errors.New("event index does not cover the requested block range")
+)
+
+// eventIndexWriter is implemented by both db.Transaction and db.Bulk.
+type eventIndexWriter interface {
+	Set(key, value []byte)
+	Delete(key []byte)
+}
+
+// eventIndexBatch buffers the updates of the event index, which are written
+// later with the other updates of the chain in the same transaction.
+type eventIndexBatch struct {
+	deleted [][]byte
+	added   [][]byte
+}
+
+func (b *eventIndexBatch) Set(key, _ []byte) {
+	b.added = append(b.added, key)
+}
+
+func (b *eventIndexBatch) Delete(key []byte) {
+	b.deleted = append(b.deleted, key)
+}
+
+// writeTo writes the updates to w. The keys are deleted before added, since
+// an event of a new block may have the same key as one of an old block.
+func (b *eventIndexBatch) writeTo(w eventIndexWriter) {
+	if b == nil {
+		return
+	}
+	for _, key := range b.deleted {
+		w.Delete(key)
+	}
+	for _, key := range b.added {
+		w.Set(key, []byte{})
+	}
+}
+
+// initEventIndex enables or disables the event index. When the index is
+// enabled on a chain which already has blocks, only the blocks connected from
+// now on are indexed until the index is rebuilt.
+func (cdb *ChainDB) initEventIndex(enable bool) {
+	cdb.eventIndex = enable
+
+	if !enable {
+		// The blocks connected while the index is disabled are not indexed.
+		// So, forget the coverage to prevent the index from being used after
+		// re-enabling it without a rebuild.
+		if len(cdb.store.Get(eventIndexBaseKey)) != 0 {
+			cdb.store.Delete(eventIndexBaseKey)
+		}
+		return
+	}
+
+	if _, ok := cdb.getEventIndexBase(); ok {
+		return
+	}
+
+	base := cdb.getBestBlockNo()
+	if base > 0 {
+		base++
+		logger.Warn().Uint64("from", base).Msg("event index only covers blocks connected from now on. rebuild it to index the existing blocks")
+	}
+	cdb.setEventIndexBase(base)
+}
+
+func (cdb *ChainDB) getEventIndexBase() (types.BlockNo, bool) {
+	data := cdb.store.Get(eventIndexBaseKey)
+	if len(data) == 0 {
+		return 0, false
+	}
+	return types.BlockNoFromBytes(data), true
+}
+
+func (cdb *ChainDB) setEventIndexBase(no types.BlockNo) {
+	cdb.store.Set(eventIndexBaseKey, types.BlockNoToBytes(no))
+}
+
+// isEventIndexed reports whether the events of the blocks from blockfrom are
+// able to be looked up from the event index.
+func (cdb *ChainDB) isEventIndexed(from types.BlockNo) bool {
+	if !cdb.eventIndex {
+		return false
+	}
+	base, ok := cdb.getEventIndexBase()
+	return ok && base <= from
+}
+
+func eventNameHash(name string) []byte {
+	h := sha256.Sum256([]byte(name))
+	return h[:eventNameHashLen]
+}
+
+// eventArgHash returns the hash of a decoded json value. json.Marshal sorts
+// the keys of a map, so that the same value always results in the same hash.
+func eventArgHash(v interface{}) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	h := sha256.Sum256(b)
+	return h[:eventArgHashLen], nil
+}
+
+func eventPos(blockNo types.BlockNo, txIdx int, evIdx int) []byte {
+	pos := make([]byte, eventPosLen)
+	binary.BigEndian.PutUint64(pos[0:8], blockNo)
+	binary.BigEndian.PutUint32(pos[8:12], uint32(txIdx))
+	binary.BigEndian.PutUint32(pos[12:16], uint32(evIdx))
+	return pos
+}
+
+func parseEventPos(key []byte) (blockNo types.BlockNo, txIdx int, evIdx int) {
+	pos := key[len(key)-eventPosLen:]
+	blockNo = binary.BigEndian.Uint64(pos[0:8])
+	txIdx = int(binary.BigEndian.Uint32(pos[8:12]))
+	evIdx = int(binary.BigEndian.Uint32(pos[12:16]))
+	return
+}
+
+func eventIndexKey(parts ...[]byte) []byte {
+	return bytes.Join(parts, nil)
+}
+
+// eventIndexKeys returns the index keys of the event ev.
+func eventIndexKeys(ev *types.Event, blockNo types.BlockNo, txIdx int, evIdx int) [][]byte {
+	addr := ev.ContractAddress
+	if len(addr) != types.AddressLength {
+		logger.Warn().Int("len", len(addr)).Uint64("no", blockNo).Msg("skip indexing event of invalid contract address")
+		return nil
+	}
+	pos := eventPos(blockNo, txIdx, evIdx)
+	nameHash := eventNameHash(ev.EventName)
+
+	keys := [][]byte{
+		eventIndexKey(eventIndexAddrPrefix, addr, pos),
+		eventIndexKey(eventIndexNamePrefix, addr, nameHash, pos),
+	}
+
+	var args []interface{}
+	if err := json.Unmarshal([]byte(ev.JsonArgs), &args); err != nil {
+		return keys
+	}
+	for i, arg := range args {
+		if i >= maxIndexedEventArgs {
+			break
+		}
+		argHash, err := eventArgHash(arg)
+		if err != nil {
+			continue
+		}
+		keys = append(keys, eventIndexKey(eventIndexArgPrefix, addr, nameHash, []byte{byte(i)}, argHash, pos))
+	}
+
+	return keys
+}
+
+func (cdb *ChainDB) updateEventIndex(w eventIndexWriter, blockNo types.BlockNo, receipts *types.Receipts, add bool) {
+	for txIdx, r := range receipts.Get() {
+		for evIdx, ev := range r.Events {
+			for _, key := range eventIndexKeys(ev, blockNo, txIdx, evIdx) {
+				if add {
+					w.Set(key, []byte{})
+				} else {
+					w.Delete(key)
+				}
+			}
+		}
+	}
+}
+
+// addEventIndex adds the events of block to the event index.
+func (cdb *ChainDB) addEventIndex(w eventIndexWriter, block *types.Block, hardForkConfig *config.HardforkConfig) {
+	if !cdb.eventIndex || len(block.GetBody().GetTxs()) == 0 {
+		return
+	}
+	receipts, err := cdb.getReceipts(block.BlockHash(), block.BlockNo(), hardForkConfig)
+	if err != nil {
+		return
+	}
+	cdb.updateEventIndex(w, block.BlockNo(), receipts, true)
+}
+
+// deleteEventIndex removes the events of block from the event index. It must
+// be called before the receipts of block are deleted.
+func (cdb *ChainDB) deleteEventIndex(w eventIndexWriter, block *types.Block, hardForkConfig *config.HardforkConfig) {
+	if !cdb.eventIndex || len(block.GetBody().GetTxs()) == 0 {
+		return
+	}
+	receipts, err := cdb.getReceipts(block.BlockHash(), block.BlockNo(), hardForkConfig)
+	if err != nil {
+		return
+	}
+	cdb.updateEventIndex(w, block.BlockNo(), receipts, false)
+}
+
+// rebuildEventIndex indexes the events of all the blocks in the main chain.
+func (cdb *ChainDB) rebuildEventIndex(hardForkConfig *config.HardforkConfig) error {
+	if !cdb.eventIndex {
+		return ErrEventIndexDisabled
+	}
+
+	best := cdb.getBestBlockNo()
+
+	logger.Info().Uint64("best", best).Msg("rebuild event index")
+
+	bulk := cdb.store.NewBulk()
+	defer bulk.DiscardLast()
+
+	for no := types.BlockNo(1); no <= best; no++ {
+		block, err := cdb.GetBlockByNo(no)
+		if err != nil {
+			return err
+		}
+		cdb.addEventIndex(bulk, block, hardForkConfig)
+
+		if no%10000 == 0 {
+			logger.Info().Uint64("no", no).Msg("rebuilding event index")
+		}
+	}
+
+	bulk.Flush()
+
+	cdb.setEventIndexBase(0)
+
+	logger.Info().Uint64("best", best).Msg("event index rebuilt")
+
+	return nil
+}
+
+// lookupEvents returns the events in the blocks from 'from' to 'to' matching
+// filter by using the event index.
+func (cdb *ChainDB) lookupEvents(filter *types.FilterInfo, argFilter []types.ArgFilter,
+	from types.BlockNo, to types.BlockNo, hardForkConfig *config.HardforkConfig) ([]*types.Event, error) {
+	if !cdb.isEventIndexed(from) {
+		return nil, ErrEventIndexNotReady
+	}
+
+	prefix := eventIndexKey(eventIndexAddrPrefix, filter.ContractAddress)
+	if len(filter.EventName) != 0 {
+		nameHash := eventNameHash(filter.EventName)
+		prefix = eventIndexKey(eventIndexNamePrefix, filter.ContractAddress, nameHash)
+		for _, af := range argFilter {
+			if af.ArgNo() >= maxIndexedEventArgs {
+				continue
+			}
+			argHash, err := eventArgHash(af.Value())
+			if err != nil {
+				continue
+			}
+			prefix = eventIndexKey(eventIndexArgPrefix, filter.ContractAddress, nameHash, []byte{byte(af.ArgNo())}, argHash)
+			break
+		}
+	}
+
+	var start, end []byte
+	if filter.Desc {
+		start = eventIndexKey(prefix, types.BlockNoToBytes(to), bytes.Repeat([]byte{0xff}, eventPosLen-8))
+		end = eventIndexKey(prefix, types.BlockNoToBytes(from))
+	} else {
+		start = eventIndexKey(prefix, types.BlockNoToBytes(from))
+		end = eventIndexKey(prefix, types.BlockNoToBytes(to+1))
+	}
+
+	var (
+		events    = []*types.Event{}
+		blkEvents []*types.Event
+		totalSize uint64
+
+		loaded   bool
+		curNo    types.BlockNo
+		blkHash  []byte
+		receipts []*types.Receipt
+	)
+
+	// The events of a block are always returned in ascending order like the
+	// block-by-block scan even though blocks are iterated in descending order.
+	flush := func() {
+		if filter.Desc {
+			for i := len(blkEvents) - 1; i >= 0; i-- {
+				events = append(events, blkEvents[i])
+			}
+		} else {
+			events = append(events, blkEvents...)
+		}
+		blkEvents = blkEvents[:0]
+	}
+
+	for iter := cdb.store.Iterator(start, end); iter.Valid(); iter.Next() {
+		key := iter.Key()
+		if len(key) != len(prefix)+eventPosLen || !bytes.HasPrefix(key, prefix) {
+			continue
+		}
+		no, txIdx, evIdx := parseEventPos(key)
+		if no < from || no > to {
+			continue
+		}
+
+		if !loaded || no != curNo {
+			flush()
+			loaded = true
+			curNo = no
+			receipts = nil
+
+			var err error
+			if blkHash, err = cdb.getHashByNo(no); err != nil {
+				continue
+			}
+			stored, err := cdb.getReceipts(blkHash, no, hardForkConfig)
+			if err != nil {
+				continue
+			}
+			receipts = stored.Get()
+		}
+
+		if txIdx >= len(receipts) || evIdx >= len(receipts[txIdx].Events) {
+			continue
+		}
+		r := receipts[txIdx]
+		e := r.Events[evIdx]
+		if !e.Filter(filter, argFilter) {
+			continue
+		}
+		e.SetMemoryInfo(r, blkHash, no, int32(txIdx))
+		blkEvents = append(blkEvents, e)
+
+		totalSize += uint64(proto.Size(e))
+		if totalSize > MaxEventSize {
+			return nil, errors.New(fmt.Sprintf("too large size of event (%v)", totalSize))
+		}
+	}
+	flush()
+
+	return events, nil
+}
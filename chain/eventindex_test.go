@@ -0,0 +1,203 @@
+package chain
+
+import (
+	"testing"
+
+	"github.com/aergoio/aergo-lib/db"
+	"github.com/aergoio/aergo/config"
+	"github.com/aergoio/aergo/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestEventIndexDB() *ChainDB {
+	cdb := NewChainDB()
+	cdb.store = db.NewDB(db.MemoryImpl, "")
+	cdb.initEventIndex(true)
+	return cdb
+}
+
+func testContractAddress(seed byte) []byte {
+	addr := make([]byte, types.AddressLength)
+	addr[0] = 0x02
+	addr[1] = seed
+	return addr
+}
+
+func testEvent(addr []byte, name string, args string, idx int32) *types.Event {
+	return &types.Event{ContractAddress: addr, EventName: name, JsonArgs: args, EventIdx: idx}
+}
+
+// connectTestEventBlock stores a block whose single tx emitted events and
+// connects it to the main chain.
+func connectTestEventBlock(cdb *ChainDB, no types.BlockNo, ts int64, events ...*types.Event) *types.Block {
+	block := writeTestEventBlock(cdb, no, ts, events...)
+
+	dbTx := cdb.NewTx()
+	dbTx.Set(types.BlockNoToBytes(no), block.BlockHash())
+	cdb.addEventIndex(dbTx, block, config.AllEnabledHardforkConfig)
+	dbTx.Commit()
+
+	return block
+}
+
+// writeTestEventBlock stores the receipts of a block whose single tx emitted
+// events.
+func writeTestEventBlock(cdb *ChainDB, no types.BlockNo, ts int64, events ...*types.Event) *types.Block {
+	block := &types.Block{
+		Header: &types.BlockHeader{BlockNo: no, Timestamp: ts},
+		Body:   &types.BlockBody{Txs: []*types.Tx{{Hash: []byte{byte(no), byte(ts)}}}},
+	}
+
+	receipt := types.NewReceipt(testContractAddress(0), "SUCCESS", "")
+	receipt.TxHash = block.Body.Txs[0].Hash
+	receipt.Events = events
+
+	var receipts types.Receipts
+	receipts.SetHardFork(config.AllEnabledHardforkConfig, no)
+	receipts.Set([]*types.Receipt{receipt})
+	cdb.writeReceipts(block.BlockHash(), no, &receipts)
+
+	return block
+}
+
+func lookupTestEvents(t *testing.T, cdb *ChainDB, filter *types.FilterInfo, from, to types.BlockNo) []*types.Event {
+	argFilter, err := filter.GetExArgFilter()
+	assert.NoError(t, err)
+	events, err := cdb.lookupEvents(filter, argFilter, from, to, config.AllEnabledHardforkConfig)
+	assert.NoError(t, err)
+	return events
+}
+
+func TestEventIndexLookup(t *testing.T) {
+	cdb := newTestEventIndexDB()
+	defer cdb.Close()
+
+	a1 := testContractAddress(1)
+	a2 := testContractAddress(2)
+
+	connectTestEventBlock(cdb, 1, 0,
+		testEvent(a1, "transfer", `["alice", 10]`, 0),
+		testEvent(a2, "transfer", `["alice", 10]`, 1))
+	connectTestEventBlock(cdb, 2, 0,
+		testEvent(a1, "mint", `["bob", 5]`, 0),
+		testEvent(a1, "transfer", `["bob", 20]`, 1))
+	connectTestEventBlock(cdb, 3, 0,
+		testEvent(a1, "transfer", `["alice", {"_bignum":"30"}]`, 0))
+
+	events := lookupTestEvents(t, cdb, &types.FilterInfo{ContractAddress: a1}, 1, 3)
+	if assert.Len(t, events, 4) {
+		assert.Equal(t, uint64(1), events[0].BlockNo)
+		assert.Equal(t, "mint", events[1].EventName)
+		assert.Equal(t, "transfer", events[2].EventName)
+		assert.Equal(t, uint64(3), events[3].BlockNo)
+	}
+
+	events = lookupTestEvents(t, cdb, &types.FilterInfo{ContractAddress: a1, EventName: "transfer"}, 2, 3)
+	assert.Len(t, events, 2)
+
+	events = lookupTestEvents(t, cdb, &types.FilterInfo{ContractAddress: a1, EventName: "transfer",
+		ArgFilter: []byte(`{"0":"alice"}`)}, 1, 3)
+	if assert.Len(t, events, 2) {
+		assert.Equal(t, uint64(1), events[0].BlockNo)
+		assert.Equal(t, uint64(3), events[1].BlockNo)
+	}
+
+	events = lookupTestEvents(t, cdb, &types.FilterInfo{ContractAddress: a1, EventName: "transfer",
+		ArgFilter: []byte(`{"1":{"_bignum":"30"}}`)}, 1, 3)
+	assert.Len(t, events, 1)
+
+	// Blocks are returned in descending order, but the events in a block are
+	// in ascending order like the block-by-block scan.
+	events = lookupTestEvents(t, cdb, &types.FilterInfo{ContractAddress: a1, Desc: true}, 1, 3)
+	if assert.Len(t, events, 4) {
+		assert.Equal(t, uint64(3), events[0].BlockNo)
+		assert.Equal(t, "mint", events[1].EventName)
+		assert.Equal(t, "transfer", events[2].EventName)
+		assert.Equal(t, uint64(1), events[3].BlockNo)
+	}
+}
+
+func TestEventIndexReorg(t *testing.T) {
+	cdb := newTestEventIndexDB()
+	defer cdb.Close()
+
+	a1 := testContractAddress(1)
+
+	connectTestEventBlock(cdb, 1, 0, testEvent(a1, "transfer", `["alice"]`, 0))
+	old := connectTestEventBlock(cdb, 2, 0, testEvent(a1, "transfer", `["bob"]`, 0))
+
+	// The index of the old block is replaced by that of the new block.
+	dbTx := cdb.NewTx()
+	cdb.deleteEventIndex(dbTx, old, config.AllEnabledHardforkConfig)
+	dbTx.Commit()
+	connectTestEventBlock(cdb, 2, 1, testEvent(a1, "transfer", `["carol"]`, 0))
+
+	events := lookupTestEvents(t, cdb, &types.FilterInfo{ContractAddress: a1, EventName: "transfer",
+		ArgFilter: []byte(`{"0":"bob"}`)}, 1, 2)
+	assert.Empty(t, events)
+
+	events = lookupTestEvents(t, cdb, &types.FilterInfo{ContractAddress: a1, EventName: "transfer",
+		ArgFilter: []byte(`{"0":"carol"}`)}, 1, 2)
+	assert.Len(t, events, 1)
+
+	// A stale entry which was not deleted is discarded by checking the event
+	// again.
+	connectTestEventBlock(cdb, 2, 2, testEvent(a1, "transfer", `["dave"]`, 0))
+	events = lookupTestEvents(t, cdb, &types.FilterInfo{ContractAddress: a1, EventName: "transfer",
+		ArgFilter: []byte(`{"0":"carol"}`)}, 1, 2)
+	assert.Empty(t, events)
+}
+
+func TestEventIndexBatch(t *testing.T) {
+	cdb := newTestEventIndexDB()
+	defer cdb.Close()
+
+	a1 := testContractAddress(1)
+	filter := func(arg string) *types.FilterInfo {
+		return &types.FilterInfo{ContractAddress: a1, EventName: "transfer", ArgFilter: []byte(`{"0":"` + arg + `"}`)}
+	}
+
+	old := connectTestEventBlock(cdb, 1, 0, testEvent(a1, "transfer", `["bob"]`, 0))
+	newBlk := writeTestEventBlock(cdb, 1, 1, testEvent(a1, "transfer", `["carol"]`, 0))
+
+	// The updates are buffered until written with the chain swap.
+	events := &eventIndexBatch{}
+	cdb.deleteEventIndex(events, old, config.AllEnabledHardforkConfig)
+	cdb.addEventIndex(events, newBlk, config.AllEnabledHardforkConfig)
+	assert.Len(t, lookupTestEvents(t, cdb, filter("bob"), 1, 1), 1)
+	assert.Empty(t, lookupTestEvents(t, cdb, filter("carol"), 1, 1))
+
+	dbTx := cdb.NewTx()
+	dbTx.Set(types.BlockNoToBytes(1), newBlk.BlockHash())
+	events.writeTo(dbTx)
+	dbTx.Commit()
+	assert.Empty(t, lookupTestEvents(t, cdb, filter("bob"), 1, 1))
+	assert.Len(t, lookupTestEvents(t, cdb, filter("carol"), 1, 1), 1)
+
+	// The same event of the old and the new block stays indexed.
+	same := writeTestEventBlock(cdb, 1, 2, testEvent(a1, "transfer", `["carol"]`, 0))
+	events = &eventIndexBatch{}
+	cdb.deleteEventIndex(events, newBlk, config.AllEnabledHardforkConfig)
+	cdb.addEventIndex(events, same, config.AllEnabledHardforkConfig)
+	dbTx = cdb.NewTx()
+	dbTx.Set(types.BlockNoToBytes(1), same.BlockHash())
+	events.writeTo(dbTx)
+	dbTx.Commit()
+	assert.Len(t, lookupTestEvents(t, cdb, filter("carol"), 1, 1), 1)
+}
+
+func TestEventIndexCoverage(t *testing.T) {
+	cdb := newTestEventIndexDB()
+	defer cdb.Close()
+
+	assert.True(t, cdb.isEventIndexed(0))
+
+	cdb.initEventIndex(false)
+	assert.False(t, cdb.isEventIndexed(0))
+
+	// The blocks connected while the index was disabled are not covered.
+	cdb.latest.Store(types.BlockNo(10))
+	cdb.initEventIndex(true)
+	assert.False(t, cdb.isEventIndexed(10))
+	assert.True(t, cdb.isEventIndexed(11))
+}
@@ -0,0 +1,155 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package chain
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/aergoio/aergo/state"
+	"github.com/aergoio/aergo/types"
+)
+
+var (
+	// statePruneBaseKey stores the lowest block number whose state may be
+	// available.
+	statePruneBaseKey = []byte("sp.base")
+)
+
+const (
+	// minStateKeep is the minimum number of the latest block states to keep.
+	// A reorganization can't go deeper than the kept states.
+	minStateKeep = 128
+)
+
+// initStatePrune loads the block number below which the states are pruned.
+func (cdb *ChainDB) initStatePrune() {
+	atomic.StoreUint64(&cdb.statePruneFloor, cdb.getStatePruneBase())
+}
+
+func (cdb *ChainDB) getStatePruneBase() types.BlockNo {
+	data := cdb.store.Get(statePruneBaseKey)
+	if len(data) == 0 {
+		return 0
+	}
+	return types.BlockNoFromBytes(data)
+}
+
+func (cdb *ChainDB) setStatePruneBase(no types.BlockNo) {
+	cdb.store.Set(statePruneBaseKey, types.BlockNoToBytes(no))
+}
+
+// isStatePruned reports whether the state of the block blockNo is pruned or
+// being pruned.
+func (cdb *ChainDB) isStatePruned(blockNo types.BlockNo) bool {
+	return blockNo < atomic.LoadUint64(&cdb.statePruneFloor)
+}
+
+// statePruner periodically prunes the states of the old blocks. Only the
+// states of the latest keep blocks are retained.
+type statePruner struct {
+	cdb      *ChainDB
+	sdb      *state.ChainStateDB
+	keep     uint64
+	interval uint64
+	running  int32
+}
+
+// newStatePruner returns nil if keep is 0, which means all the states are
+// kept (archive mode).
+func newStatePruner(core *Core, keep uint64, interval uint64) *statePruner {
+	if keep == 0 {
+		return nil
+	}
+	if keep < minStateKeep {
+		logger.Warn().Uint64("statekeep", keep).Uint64("min", minStateKeep).Msg("too small number of states to keep. use the minimum")
+		keep = minStateKeep
+	}
+	if interval == 0 {
+		interval = 1
+	}
+	logger.Info().Uint64("keep", keep).Uint64("interval", interval).Msg("state pruning enabled")
+
+	return &statePruner{
+		cdb:      core.cdb,
+		sdb:      core.sdb,
+		keep:     keep,
+		interval: interval,
+	}
+}
+
+// maybePrune starts a pruning in background if enough blocks are connected
+// since the last one. It must be called by the chain service between block
+// connections.
+func (sp *statePruner) maybePrune() {
+	if sp == nil || atomic.LoadInt32(&sp.running) != 0 {
+		return
+	}
+
+	best := sp.cdb.getBestBlockNo()
+	base := sp.cdb.getStatePruneBase()
+	if best < sp.keep || best-sp.keep+1 < base+sp.interval {
+		return
+	}
+	// the states of the blocks in [base, to] are pruned
+	to := best - sp.keep
+
+	// The written keys are protected from now on, so the states of the blocks
+	// connected during the pruning are built on the retained states.
+	pruner, err := sp.sdb.NewPruner()
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to start state pruning")
+		return
+	}
+	// reject the reorganizations and the queries into the pruned states
+	atomic.StoreUint64(&sp.cdb.statePruneFloor, to+1)
+	atomic.StoreInt32(&sp.running, 1)
+
+	go func() {
+		defer atomic.StoreInt32(&sp.running, 0)
+		defer pruner.Close()
+
+		if err := sp.prune(pruner, base, to, best); err != nil {
+			logger.Error().Err(err).Uint64("from", base).Uint64("to", to).Msg("failed to prune states")
+		}
+	}()
+}
+
+func (sp *statePruner) prune(pruner *state.Pruner, base, to, best types.BlockNo) error {
+	begT := time.Now()
+
+	retained, err := sp.stateRoots(to+1, best)
+	if err != nil {
+		return err
+	}
+	pruned, err := sp.stateRoots(base, to)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := pruner.Run(retained, pruned)
+	if err != nil {
+		return err
+	}
+	sp.cdb.setStatePruneBase(to + 1)
+
+	logger.Info().Uint64("from", base).Uint64("to", to).Int("deleted", deleted).
+		Str("elapsed", time.Since(begT).String()).Msg("states pruned")
+
+	return nil
+}
+
+func (sp *statePruner) stateRoots(from, to types.BlockNo) ([][]byte, error) {
+	roots := make([][]byte, 0, to-from+1)
+	for no := from; no <= to; no++ {
+		block, err := sp.cdb.GetBlockByNo(no)
+		if err != nil {
+			return nil, err
+		}
+		roots = append(roots, block.GetHeader().GetBlocksRootHash())
+	}
+	return roots, nil
+}
@@ -242,6 +242,9 @@ func (reorg *reorganizer) swapChain() error {
 		return err
 	}
 
+	// the index of the old blocks is made of their receipts
+	events := reorg.eventIndexUpdates()
+
 	reorg.deleteOldReceipts()
 
 	//TODO batch notification of rollforward blocks
@@ -250,7 +253,7 @@ func (reorg *reorganizer) swapChain() error {
 		return err
 	}
 
-	if err := reorg.swapChainMapping(); err != nil {
+	if err := reorg.swapChainMapping(events); err != nil {
 		return err
 	}
 
@@ -264,8 +267,9 @@ func (reorg *reorganizer) swapChain() error {
 }
 
 // swapChainMapping swaps chain meta from org chain to side chain and deleting reorg marker.
-// it should be executed by 1 tx to be atomic.
-func (reorg *reorganizer) swapChainMapping() error {
+// it should be executed by 1 tx to be atomic. The event index is updated by events in
+// the same tx.
+func (reorg *reorganizer) swapChainMapping(events *eventIndexBatch) error {
 	cdb := reorg.cs.cdb
 
 	logger.Info().Msg("swap chain mapping for new branch")
@@ -280,7 +284,7 @@ func (reorg *reorganizer) swapChainMapping() error {
 		return nil
 	}
 
-	if err := cdb.swapChainMapping(reorg.newBlocks); err != nil {
+	if err := cdb.swapChainMapping(reorg.newBlocks, events); err != nil {
 		return err
 	}
 
@@ -483,7 +487,14 @@ func (reorg *reorganizer) rollback() error {
 
 	logger.Info().Str("hash", brStartBlock.ID()).Uint64("no", brStartBlockNo).Msg("rollback chain to branch start block")
 
-	if err := reorg.cs.sdb.SetRoot(brStartBlock.GetHeader().GetBlocksRootHash()); err != nil {
+	// the state of the branch start block may be pruned.
+	brStartRoot := brStartBlock.GetHeader().GetBlocksRootHash()
+	if reorg.cs.cdb.isStatePruned(brStartBlockNo) || !reorg.cs.sdb.GetStateDB().HasMarker(brStartRoot) {
+		logger.Error().Str("hash", brStartBlock.ID()).Uint64("no", brStartBlockNo).Msg("state of branch start block is not available")
+		return ErrStateNoMarker
+	}
+
+	if err := reorg.cs.sdb.SetRoot(brStartRoot); err != nil {
 		return fmt.Errorf("failed to rollback sdb(branchRoot:no=%d,hash=%v)", brStartBlockNo,
 			brStartBlock.ID())
 	}
@@ -493,6 +504,23 @@ func (reorg *reorganizer) rollback() error {
 	return nil
 }
 
+// eventIndexUpdates returns the updates of the event index replacing the
+// events of the old blocks with those of the new blocks. It must be called
+// before the receipts of the old blocks are deleted.
+func (reorg *reorganizer) eventIndexUpdates() *eventIndexBatch {
+	cdb := reorg.cs.cdb
+	hardfork := reorg.cs.cfg.Hardfork
+
+	events := &eventIndexBatch{}
+	for _, blk := range reorg.oldBlocks {
+		cdb.deleteEventIndex(events, blk, hardfork)
+	}
+	for i := len(reorg.newBlocks) - 1; i >= 0; i-- {
+		cdb.addEventIndex(events, reorg.newBlocks[i], hardfork)
+	}
+	return events
+}
+
 func (reorg *reorganizer) deleteOldReceipts() {
 	dbTx := reorg.cs.cdb.NewTx()
 	for _, blk := range reorg.oldBlocks {
@@ -7,6 +7,7 @@ import (
 	"github.com/aergoio/aergo-actor/actor"
 	"github.com/aergoio/aergo/account/key"
 	"github.com/aergoio/aergo/contract/name"
+	"github.com/aergoio/aergo/contract/system"
 	"github.com/aergoio/aergo/internal/enc"
 	"github.com/aergoio/aergo/message"
 	"github.com/aergoio/aergo/pkg/component"
@@ -131,26 +132,59 @@ func (sv *SignVerifier) verifyTx(comm component.IComponentRequester, tx *types.T
 		}
 	}
 
+	address := tx.Body.Account
 	if tx.NeedNameVerify() {
 		cs, err := sv.sdb.GetStateDB().OpenContractStateAccount(types.ToAccountID([]byte(types.AergoName)))
 		if err != nil {
 			logger.Error().Err(err).Msg("failed to get verify because of openning contract error")
 			return false, err
 		}
-		address := name.GetOwner(cs, tx.Body.Account)
+		address = name.GetOwner(cs, tx.Body.Account)
+	}
+	multisig, err := sv.getMultisig(address)
+	if err != nil {
+		return false, err
+	}
+	switch {
+	case multisig != nil:
+		err = key.VerifyTxWithMultisig(tx, multisig)
+	case sv.isContract(address):
+		// a contract account checks its txs by itself on the execution
+	case tx.NeedNameVerify():
 		err = key.VerifyTxWithAddress(tx, address)
-		if err != nil {
-			return false, err
-		}
-	} else {
-		err := key.VerifyTx(tx)
-		if err != nil {
-			return false, err
-		}
+	default:
+		err = key.VerifyTx(tx)
+	}
+	if err != nil {
+		return false, err
 	}
 	return false, nil
 }
 
+// getMultisig returns the signer set of the multisig account, or nil if
+// address is not a multisig account.
+func (sv *SignVerifier) getMultisig(address []byte) (*types.Multisig, error) {
+	if len(address) != types.AddressLength {
+		return nil, nil
+	}
+	cs, err := sv.sdb.GetStateDB().OpenContractStateAccount(types.ToAccountID([]byte(types.AergoSystem)))
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to get multisig because of openning contract error")
+		return nil, err
+	}
+	return system.GetMultisig(cs, address)
+}
+
+// isContract returns true if address is a contract, which may be the sender
+// of txs as a contract account.
+func (sv *SignVerifier) isContract(address []byte) bool {
+	st, err := sv.sdb.GetStateDB().GetAccountState(types.ToAccountID(address))
+	if err != nil {
+		return false
+	}
+	return len(st.GetCodeHash()) > 0
+}
+
 func (sv *SignVerifier) RequestVerifyTxs(txlist *types.TxList) {
 	txs := txlist.GetTxs()
 	txLen := len(txs)
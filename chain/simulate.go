@@ -0,0 +1,101 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package chain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/aergoio/aergo/consensus"
+	"github.com/aergoio/aergo/contract"
+	"github.com/aergoio/aergo/contract/name"
+	"github.com/aergoio/aergo/contract/system"
+	"github.com/aergoio/aergo/state"
+	"github.com/aergoio/aergo/types"
+	"github.com/golang/protobuf/proto"
+)
+
+// simulateLockTimeout is the time to wait for the block being generated.
+var simulateLockTimeout = 3 * time.Second
+
+// ErrSimulationBusy is returned when a simulation can't start since a block is
+// being generated for too long.
+var ErrSimulationBusy = errors.New("a block is being generated. retry the simulation later")
+
+// simulationCluster never proposes a membership change, so that a simulated
+// enterprise tx behaves as on a follower node.
+type simulationCluster struct{}
+
+func (simulationCluster) MakeConfChangeProposal(req *types.MembershipChange) (*consensus.ConfChangePropose, error) {
+	return nil, consensus.ErrorMembershipChangeSkip
+}
+
+// simulateTx executes tx in the same way as the block execution against a
+// throw-away block state on top of the best block, and returns its receipt.
+// The chain id hash, the nonce and the hash of tx are filled in if they are
+// omitted, and the signature is not verified. The SQL databases are written in
+// the transactions of the next block, which are rolled back at the end. So it
+// must be called by the chain manager between the block executions, and it
+// holds InAddBlock like a block factory generating a block, which uses the same
+// transactions.
+func (cs *ChainService) simulateTx(tx *types.Tx) (*types.Receipt, error) {
+	if tx.GetBody() == nil {
+		return nil, types.ErrTxFormatInvalid
+	}
+
+	select {
+	case InAddBlock <- struct{}{}:
+	case <-time.After(simulateLockTimeout):
+		return nil, ErrSimulationBusy
+	}
+	defer func() {
+		<-InAddBlock
+	}()
+	defer contract.CloseDatabase()
+
+	bestBlock, err := cs.cdb.GetBestBlock()
+	if err != nil {
+		return nil, err
+	}
+	bi := types.NewBlockHeaderInfoFromPrevBlock(bestBlock, time.Now().UnixNano(), cs.cfg.Hardfork)
+
+	bState := state.NewBlockState(
+		cs.sdb.OpenTempStateDB(bestBlock.GetHeader().GetBlocksRootHash()),
+		state.SetPrevBlockHash(bestBlock.BlockHash()),
+	)
+	bState.SetGasPrice(system.GetGasPriceFromState(bState))
+	bState.Receipts().SetHardFork(cs.cfg.Hardfork, bi.No)
+
+	simTx := &types.Tx{Body: proto.Clone(tx.GetBody()).(*types.TxBody)}
+	body := simTx.Body
+	if len(body.ChainIdHash) == 0 {
+		body.ChainIdHash = bi.ChainIdHash()
+	}
+	if body.Nonce == 0 {
+		account, err := name.Resolve(bState, body.GetAccount(), false)
+		if err != nil {
+			return nil, err
+		}
+		sender, err := bState.GetAccountState(types.ToAccountID(account))
+		if err != nil {
+			return nil, err
+		}
+		body.Nonce = sender.GetNonce() + 1
+	}
+	simTx.Hash = simTx.CalculateTxHash()
+
+	if err := executeTx(simulationCluster{}, cs.cdb, bState, types.NewTransaction(simTx), bi, contract.Simulation); err != nil {
+		return nil, err
+	}
+
+	receipts := bState.Receipts().Get()
+	receipt := receipts[len(receipts)-1]
+	receipt.BlockNo = bi.No
+	receipt.From = body.GetAccount()
+	receipt.To = body.GetRecipient()
+
+	return receipt, nil
+}
@@ -0,0 +1,26 @@
+package chain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aergoio/aergo/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimulateTxWaitsForBlockGeneration(t *testing.T) {
+	defer func(timeout time.Duration) { simulateLockTimeout = timeout }(simulateLockTimeout)
+	simulateLockTimeout = 10 * time.Millisecond
+
+	cs := &ChainService{}
+	tx := &types.Tx{Body: &types.TxBody{}}
+
+	// a block factory is gathering the txs of a block
+	InAddBlock <- struct{}{}
+	_, err := cs.simulateTx(tx)
+	<-InAddBlock
+	assert.Equal(t, ErrSimulationBusy, err)
+
+	_, err = cs.simulateTx(&types.Tx{})
+	assert.Equal(t, types.ErrTxFormatInvalid, err)
+}
@@ -0,0 +1,150 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package chain
+
+import (
+	"bytes"
+	"errors"
+	"sync/atomic"
+
+	"github.com/aergoio/aergo/contract"
+	"github.com/aergoio/aergo/contract/system"
+	"github.com/aergoio/aergo/types"
+	"github.com/golang/protobuf/proto"
+)
+
+var (
+	// snapshotBaseKey stores the number of the block installed from a state
+	// snapshot. The blocks between the genesis block and it are missing.
+	snapshotBaseKey = []byte("ss.base")
+
+	ErrSnapshotNotEmpty = errors.New("snapshot can be installed only on an empty chain")
+	ErrSnapshotBlock    = errors.New("snapshot block does not match the trusted block")
+)
+
+const (
+	snapshotMaxLeaves = 4096
+	snapshotMaxValues = 256
+	snapshotMaxSize   = 1 << 20
+)
+
+func (cdb *ChainDB) getSnapshotBase() types.BlockNo {
+	data := cdb.store.Get(snapshotBaseKey)
+	if len(data) == 0 {
+		return 0
+	}
+	return types.BlockNoFromBytes(data)
+}
+
+// readStateSnapshot reads the part of the state snapshot requested by a peer.
+func (core *Core) readStateSnapshot(req *types.GetStateSnapshotRequest) *types.GetStateSnapshotResponse {
+	rsp := &types.GetStateSnapshotResponse{Status: types.ResultStatus_OK}
+
+	switch req.Kind {
+	case types.SnapshotKind_SNAPSHOT_BLOCK:
+		block, err := core.cdb.GetBlockByNo(req.BlockNo)
+		if err != nil {
+			rsp.Status = types.ResultStatus_NOT_FOUND
+			break
+		}
+		if rsp.Data, err = proto.Marshal(block); err != nil {
+			rsp.Status = types.ResultStatus_INTERNAL
+		}
+	case types.SnapshotKind_SNAPSHOT_LEAVES:
+		keys, values, more, err := core.sdb.GetSnapshotLeaves(req.Root, req.After, snapshotMaxLeaves, snapshotMaxSize)
+		if err != nil {
+			// the state may be pruned
+			rsp.Status = types.ResultStatus_NOT_FOUND
+			break
+		}
+		rsp.Keys, rsp.Values, rsp.HasNext = keys, values, more
+	case types.SnapshotKind_SNAPSHOT_VALUES:
+		if len(req.Hashes) > snapshotMaxValues {
+			rsp.Status = types.ResultStatus_INVALID_ARGUMENT
+			break
+		}
+		rsp.Values = core.sdb.GetSnapshotValues(req.Hashes)
+		for _, value := range rsp.Values {
+			if value == nil {
+				rsp.Status, rsp.Values = types.ResultStatus_NOT_FOUND, nil
+				break
+			}
+		}
+	case types.SnapshotKind_SNAPSHOT_SQL_LIST:
+		names, err := contract.DatabaseNames()
+		if err != nil {
+			rsp.Status = types.ResultStatus_INTERNAL
+			break
+		}
+		rsp.Names = names
+	case types.SnapshotKind_SNAPSHOT_SQL_FILE:
+		buf := make([]byte, snapshotMaxSize)
+		n, size, err := contract.ReadDatabaseFile(req.Name, int64(req.Offset), buf)
+		if err != nil {
+			rsp.Status = types.ResultStatus_NOT_FOUND
+			break
+		}
+		rsp.Data, rsp.Size = buf[:n], uint64(size)
+		rsp.HasNext = req.Offset+uint64(n) < rsp.Size
+	default:
+		rsp.Status = types.ResultStatus_UNIMPLEMENTED
+	}
+
+	if rsp.Status != types.ResultStatus_OK {
+		logger.Debug().Uint32("kind", uint32(req.Kind)).Str("status", rsp.Status.String()).Msg("failed to read state snapshot")
+	}
+	return rsp
+}
+
+// installSnapshot makes block the best block of an empty chain. block must be
+// the trusted snapshot block of the configuration, and its state must be
+// imported from a snapshot in advance, which is marked only after the tries
+// are verified by the state root of the header. The blocks before it are not
+// available, and neither are their states.
+func (cs *ChainService) installSnapshot(block *types.Block) error {
+	if cs.cdb.getBestBlockNo() != 0 {
+		return ErrSnapshotNotEmpty
+	}
+	no, hash, err := cs.cfg.Blockchain.SnapshotCheckpoint()
+	if err != nil {
+		return err
+	}
+	if hash == nil || block.BlockNo() != no || !bytes.Equal(block.BlockHash(), hash) ||
+		!bytes.Equal(block.GetHeader().GetTxsRootHash(), types.CalculateTxsRootHash(block.GetBody().GetTxs())) {
+		return ErrSnapshotBlock
+	}
+	root := block.GetHeader().GetBlocksRootHash()
+	if !cs.sdb.GetStateDB().HasMarker(root) {
+		return ErrStateNotAvailable
+	}
+	blockNo := block.BlockNo()
+
+	dbTx := cs.cdb.NewTx()
+	defer dbTx.Discard()
+
+	dbTx.Set(snapshotBaseKey, types.BlockNoToBytes(blockNo))
+	dbTx.Set(statePruneBaseKey, types.BlockNoToBytes(blockNo))
+	if err := cs.cdb.addTxsOfBlock(&dbTx, block.GetBody().GetTxs(), block.BlockHash()); err != nil {
+		return err
+	}
+	cs.cdb.connectToChain(dbTx, block, false)
+	dbTx.Commit()
+
+	atomic.StoreUint64(&cs.cdb.statePruneFloor, blockNo)
+	if err := cs.sdb.SetRoot(root); err != nil {
+		return err
+	}
+
+	systemState, err := cs.sdb.GetSystemAccountState()
+	if err != nil {
+		return err
+	}
+	system.InitSystemParams(systemState, len(cs.GetGenesisInfo().BPs))
+	cs.Update(block)
+
+	logger.Info().Uint64("no", blockNo).Str("hash", block.ID()).Msg("state snapshot installed")
+	return nil
+}
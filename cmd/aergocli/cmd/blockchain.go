@@ -8,8 +8,8 @@ package cmd
 import (
 	"context"
 
-	"github.com/aergoio/aergo/cmd/aergocli/util"
 	aergorpc "github.com/aergoio/aergo/types"
+	"github.com/aergoio/aergo/types/jsonrpc"
 	"github.com/spf13/cobra"
 )
 
@@ -30,9 +30,9 @@ var blockchainCmd = &cobra.Command{
 			return
 		}
 		if printHex {
-			cmd.Println(util.ConvHexBlockchainStatus(msg))
+			cmd.Println(jsonrpc.ConvHexBlockchainStatus(msg))
 		} else {
-			cmd.Println(util.ConvBlockchainStatus(msg))
+			cmd.Println(jsonrpc.ConvBlockchainStatus(msg))
 		}
 	},
 }
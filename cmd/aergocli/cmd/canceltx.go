@@ -0,0 +1,93 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/aergoio/aergo/types"
+	"github.com/aergoio/aergo/types/jsonrpc"
+	"github.com/mr-tron/base58"
+	"github.com/spf13/cobra"
+)
+
+var canceltxCmd = &cobra.Command{
+	Use:   "canceltx <txhash>",
+	Short: "Cancel a pending transaction in the mempool",
+	Long: `Replace a pending transaction in the mempool by a transfer of 0 AER from the sender to itself
+with the same nonce. The replacing transaction offers a tip higher than the pending one by --bump
+percent unless --gasprice is given. It must satisfy the minimum bump of the mempool. Since the tip
+is paid from the hardfork version 3, no transaction is replaced before that.`,
+	Args: cobra.ExactArgs(1),
+	RunE: execCancelTX,
+}
+
+var cancelGasPrice string
+var cancelBump uint32
+
+func init() {
+	rootCmd.AddCommand(canceltxCmd)
+	canceltxCmd.Flags().StringVar(&cancelGasPrice, "gasprice", "", "Gas price of the replacing transaction in AER")
+	canceltxCmd.Flags().Uint32Var(&cancelBump, "bump", types.DefaultReplaceBump, "Tip increase in percent")
+	canceltxCmd.Flags().StringVar(&pw, "password", "", "Password")
+}
+
+func execCancelTX(cmd *cobra.Command, args []string) error {
+	txHash, err := base58.Decode(args[0])
+	if err != nil {
+		return errors.New("Wrong transaction hash\n" + err.Error())
+	}
+	pending, err := client.GetTX(context.Background(), &types.SingleBytes{Value: txHash})
+	if err != nil {
+		return errors.New("Failed to get the pending transaction\n" + err.Error())
+	}
+
+	var price *big.Int
+	if cancelGasPrice != "" {
+		if price, err = jsonrpc.ParseUnit(cancelGasPrice); err != nil {
+			return errors.New("Wrong value in --gasprice flag\n" + err.Error())
+		}
+	} else {
+		stat, err := client.GetTipStat(context.Background(), &types.Empty{})
+		if err != nil {
+			return errors.New("Failed to get the gas price\n" + err.Error())
+		}
+		if stat.GetBlockVersion() < 3 {
+			return errors.New("No transaction is replaced before the hardfork version 3")
+		}
+		gasPrice := new(big.Int).SetBytes(stat.GetGasPrice())
+		tip := pending.GetBody().GetTipPrice(gasPrice, stat.GetBlockVersion())
+		price = new(big.Int).Add(gasPrice, bumpTip(tip, cancelBump))
+	}
+
+	account := pending.GetBody().GetAccount()
+	tx := &types.Tx{Body: &types.TxBody{
+		Type:        types.TxType_TRANSFER,
+		Account:     account,
+		Recipient:   account,
+		Amount:      new(big.Int).Bytes(),
+		Nonce:       pending.GetBody().GetNonce(),
+		GasPrice:    price.Bytes(),
+		ChainIdHash: pending.GetBody().GetChainIdHash(),
+	}}
+
+	cmd.Println(sendTX(cmd, tx, account))
+	return nil
+}
+
+// bumpTip returns the tip increased by bump percent, which is higher than
+// tip by at least 1.
+func bumpTip(tip *big.Int, bump uint32) *big.Int {
+	bumped := new(big.Int).Mul(tip, big.NewInt(100+int64(bump)))
+	bumped.Add(bumped, big.NewInt(99))
+	bumped.Div(bumped, big.NewInt(100))
+	if bumped.Cmp(tip) <= 0 {
+		bumped.Add(tip, big.NewInt(1))
+	}
+	return bumped
+}
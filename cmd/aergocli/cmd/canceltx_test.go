@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBumpTip(t *testing.T) {
+	for _, tc := range []struct {
+		tip, bump, expected int64
+	}{
+		{0, 10, 1},
+		{1, 10, 2},
+		{100, 10, 110},
+		{101, 10, 112},
+		{100, 0, 101},
+	} {
+		assert.Equal(t, big.NewInt(tc.expected), bumpTip(big.NewInt(tc.tip), uint32(tc.bump)), "tip %d", tc.tip)
+	}
+}
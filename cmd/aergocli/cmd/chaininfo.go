@@ -3,7 +3,7 @@ package cmd
 import (
 	"context"
 
-	"github.com/aergoio/aergo/cmd/aergocli/util"
+	"github.com/aergoio/aergo/types/jsonrpc"
 
 	"github.com/aergoio/aergo/types"
 	"github.com/spf13/cobra"
@@ -22,6 +22,6 @@ var chaininfoCmd = &cobra.Command{
 			cmd.Printf("Failed: %s\n", err.Error())
 			return
 		}
-		cmd.Println(util.ConvChainInfoMsg(msg))
+		cmd.Println(jsonrpc.ConvChainInfoMsg(msg))
 	},
 }
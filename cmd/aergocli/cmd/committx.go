@@ -12,6 +12,7 @@ import (
 
 	"github.com/aergoio/aergo/cmd/aergocli/util"
 	"github.com/aergoio/aergo/types"
+	"github.com/aergoio/aergo/types/jsonrpc"
 	"github.com/spf13/cobra"
 )
 
@@ -59,7 +60,7 @@ func execCommitTX(cmd *cobra.Command, args []string) error {
 
 	if jsonTx != "" {
 		var msg *types.CommitResultList
-		txlist, err := util.ParseBase58Tx([]byte(jsonTx))
+		txlist, err := jsonrpc.ParseBase58Tx([]byte(jsonTx))
 		if err != nil {
 			return errors.New("Failed to parse --jsontx\n" + err.Error())
 		}
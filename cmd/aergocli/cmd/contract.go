@@ -15,6 +15,7 @@ import (
 	"github.com/aergoio/aergo/internal/common"
 	"github.com/aergoio/aergo/types"
 	aergorpc "github.com/aergoio/aergo/types"
+	"github.com/aergoio/aergo/types/jsonrpc"
 	"github.com/mr-tron/base58/base58"
 	"github.com/spf13/cobra"
 )
@@ -108,21 +109,36 @@ func init() {
 	stateQueryCmd.Flags().StringVar(&stateroot, "root", "", "Query the state at a specified state root")
 	stateQueryCmd.Flags().BoolVar(&compressed, "compressed", false, "Get a compressed proof for the state")
 
+	abiCmd := &cobra.Command{
+		Use:   "abi [flags] <contractAddress>",
+		Short: "Get ABI of the contract",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runGetABICmd,
+	}
+	abiCmd.Flags().StringVar(&block, "block", "", "Get the ABI at a specified block number or hash")
+
+	versionsCmd := &cobra.Command{
+		Use:   "versions [flags] <contractAddress>",
+		Short: "Get the code versions of the contract",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runGetVersionsCmd,
+	}
+	versionsCmd.Flags().StringVar(&block, "block", "", "Get the code versions at a specified block number or hash")
+
+	queryCmd := &cobra.Command{
+		Use:   "query [flags] <contractAddress> <funcname> [args]",
+		Short: "Query contract by executing read-only function",
+		Args:  cobra.MinimumNArgs(2),
+		RunE:  runQueryCmd,
+	}
+	queryCmd.Flags().StringVar(&block, "block", "", "Query the contract at a specified block number or hash")
+
 	contractCmd.AddCommand(
 		deployCmd,
 		callCmd,
-		&cobra.Command{
-			Use:   "abi [flags] <contractAddress>",
-			Short: "Get ABI of the contract",
-			Args:  cobra.ExactArgs(1),
-			RunE:  runGetABICmd,
-		},
-		&cobra.Command{
-			Use:   "query [flags] <contractAddress> <funcname> [args]",
-			Short: "Query contract by executing read-only function",
-			Args:  cobra.MinimumNArgs(2),
-			RunE:  runQueryCmd,
-		},
+		abiCmd,
+		versionsCmd,
+		queryCmd,
 		stateQueryCmd,
 	)
 	rootCmd.AddCommand(contractCmd)
@@ -139,7 +155,7 @@ func runDeployCmd(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("could not decode address: %v", err.Error())
 	}
-	state, err := client.GetState(context.Background(), &types.SingleBytes{Value: creator})
+	state, err := client.GetState(context.Background(), &types.AccountAtBlock{Value: creator})
 	if err != nil {
 		return fmt.Errorf("failed to get creator account's state: %v", err.Error())
 	}
@@ -184,7 +200,7 @@ func runDeployCmd(cmd *cobra.Command, args []string) error {
 		payload = luac.NewLuaCodePayload(luac.LuaCode(code), deployArgs)
 	}
 
-	amountBigInt, err := util.ParseUnit(amount)
+	amountBigInt, err := jsonrpc.ParseUnit(amount)
 	if err != nil {
 		return fmt.Errorf("failed to parse amount: %v", err.Error())
 	}
@@ -222,7 +238,7 @@ func runCallCmd(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("could not decode sender address: %v", err.Error())
 	}
 	if nonce == 0 {
-		state, err := client.GetState(context.Background(), &types.SingleBytes{Value: caller})
+		state, err := client.GetState(context.Background(), &types.AccountAtBlock{Value: caller})
 		if err != nil {
 			return fmt.Errorf("failed to get creator account's state: %v", err.Error())
 		}
@@ -247,7 +263,7 @@ func runCallCmd(cmd *cobra.Command, args []string) error {
 	}
 
 	if !toJSON && !gover {
-		abi, err := client.GetABI(context.Background(), &types.SingleBytes{Value: contract})
+		abi, err := client.GetABI(context.Background(), &types.AccountAtBlock{Value: contract})
 		if err != nil {
 			return fmt.Errorf("failed to get abi: %v", err.Error())
 		}
@@ -256,7 +272,7 @@ func runCallCmd(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	amountBigInt, err := util.ParseUnit(amount)
+	amountBigInt, err := jsonrpc.ParseUnit(amount)
 	if err != nil {
 		return fmt.Errorf("failed to parse amount: %v", err)
 	}
@@ -314,7 +330,7 @@ func runCallCmd(cmd *cobra.Command, args []string) error {
 	}
 
 	if toJSON {
-		cmd.Println(util.TxConvBase58Addr(tx))
+		cmd.Println(jsonrpc.TxConvBase58Addr(tx))
 	} else {
 		txs := []*types.Tx{tx}
 		var msgs *types.CommitResultList
@@ -334,7 +350,11 @@ func runGetABICmd(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to decode address: %v", err.Error())
 	}
-	abi, err := client.GetABI(context.Background(), &types.SingleBytes{Value: contract})
+	blockNo, blockHash, err := parseBlock(block)
+	if err != nil {
+		return err
+	}
+	abi, err := client.GetABI(context.Background(), &types.AccountAtBlock{Value: contract, BlockNo: blockNo, BlockHash: blockHash})
 	if err != nil {
 		return fmt.Errorf("failed to get abi: %v", err.Error())
 	}
@@ -342,6 +362,25 @@ func runGetABICmd(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runGetVersionsCmd(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	contract, err := types.DecodeAddress(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to decode address: %v", err.Error())
+	}
+	blockNo, blockHash, err := parseBlock(block)
+	if err != nil {
+		return err
+	}
+	versions, err := client.ListContractVersions(context.Background(), &types.AccountAtBlock{Value: contract, BlockNo: blockNo, BlockHash: blockHash})
+	if err != nil {
+		return fmt.Errorf("failed to get code versions: %v", err.Error())
+	}
+	cmd.Println(jsonrpc.ConvContractVersions(versions))
+	return nil
+}
+
 func runQueryCmd(cmd *cobra.Command, args []string) error {
 	cmd.SilenceUsage = true
 
@@ -363,9 +402,15 @@ func runQueryCmd(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to encode JSON: %v", err.Error())
 	}
 
+	blockNo, blockHash, err := parseBlock(block)
+	if err != nil {
+		return err
+	}
 	query := &types.Query{
 		ContractAddress: contract,
 		Queryinfo:       callinfo,
+		BlockNo:         blockNo,
+		BlockHash:       blockHash,
 	}
 
 	ret, err := client.QueryContract(context.Background(), query)
@@ -398,6 +443,23 @@ func runQueryStateCmd(cmd *cobra.Command, args []string) error {
 		storageKeyPlain.WriteString(args[2])
 	}
 	storageKey := common.Hasher([]byte(storageKeyPlain.Bytes()))
+	if lightMode {
+		ctx := context.Background()
+		lc, err := newLightClient(ctx)
+		if err != nil {
+			return err
+		}
+		no, err := lightBlockNo(lc, root)
+		if err != nil {
+			return err
+		}
+		ret, err := lc.QueryContractState(ctx, contract, [][]byte{storageKey}, no)
+		if err != nil {
+			return fmt.Errorf("failed to query contract state: %v", err.Error())
+		}
+		cmd.Println(ret)
+		return nil
+	}
 	stateQuery := &types.StateQuery{
 		ContractAddress: contract,
 		StorageKeys:     [][]byte{storageKey},
@@ -0,0 +1,65 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+
+	"github.com/aergoio/aergo/cmd/aergocli/util"
+	"github.com/aergoio/aergo/types/jsonrpc"
+	"github.com/spf13/cobra"
+)
+
+var estimateCmd = &cobra.Command{
+	Use:   "estimate",
+	Short: "Estimate the gas and the fee of a transaction without sending it",
+	Long: `Execute a transaction against the state of the best block without sending it.
+The receipt which the transaction would have in the next block is printed: the status,
+the return value or the error, the used fee, the used gas and the events.
+The nonce and the chain id hash are filled in by the server if they are omitted,
+and the signature is not required.`,
+	Args: cobra.MinimumNArgs(0),
+	RunE: execEstimateTX,
+}
+
+var estimateJsonTx string
+var estimateJsonPath string
+
+func init() {
+	rootCmd.AddCommand(estimateCmd)
+
+	estimateCmd.Flags().StringVar(&estimateJsonTx, "jsontx", "", "Transaction json in the same format as committx")
+	estimateCmd.Flags().StringVar(&estimateJsonPath, "jsontxpath", "", "Transaction json file path")
+}
+
+func execEstimateTX(cmd *cobra.Command, args []string) error {
+	if estimateJsonPath != "" {
+		b, readerr := ioutil.ReadFile(estimateJsonPath)
+		if readerr != nil {
+			return errors.New("Failed to read --jsontxpath\n" + readerr.Error())
+		}
+		estimateJsonTx = string(b)
+	}
+	if estimateJsonTx == "" {
+		return errors.New("--jsontx or --jsontxpath is required")
+	}
+
+	txs, err := jsonrpc.ParseBase58Tx([]byte(estimateJsonTx))
+	if err != nil {
+		return errors.New("Failed to parse --jsontx\n" + err.Error())
+	}
+	if len(txs) != 1 {
+		return errors.New("only a single transaction can be estimated")
+	}
+	msg, err := client.SimulateTx(context.Background(), txs[0])
+	if err != nil {
+		return errors.New("Failed request to aergo server\n" + err.Error())
+	}
+	cmd.Println(util.JSON(msg))
+	return nil
+}
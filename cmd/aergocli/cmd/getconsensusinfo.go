@@ -7,9 +7,9 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
+	"github.com/aergoio/aergo/consensus"
 	aergorpc "github.com/aergoio/aergo/types"
 	"github.com/spf13/cobra"
 )
@@ -24,30 +24,7 @@ func getConsensusInfo() ([]byte, error) {
 		return nil, err
 	}
 
-	type outInfo struct {
-		Type string             `json:",omitempty"`
-		Info *json.RawMessage   `json:",omitempty"`
-		Bps  []*json.RawMessage `json:",omitempty"`
-	}
-
-	var out = &outInfo{}
-	out.Type = msg.Type
-
-	//cmd.Println(fmt.Sprintf("consensus:%s, leader:%d", msg.Type, uinfo.Leader))
-	if len(msg.Info) > 0 {
-		infoB := json.RawMessage(msg.Info)
-		out.Info = &infoB
-	}
-
-	if len(msg.Bps) > 0 {
-		out.Bps = make([]*json.RawMessage, len(msg.Bps))
-		for i, bpstr := range msg.Bps {
-			b := json.RawMessage([]byte(bpstr))
-			out.Bps[i] = &b
-		}
-	}
-
-	jsonout, err := json.Marshal(out)
+	jsonout, err := consensus.FormatInfo(msg)
 	if err != nil {
 		return nil, fmt.Errorf("decode consensus info: %v\n", err)
 	}
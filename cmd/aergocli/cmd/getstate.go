@@ -7,9 +7,11 @@ package cmd
 
 import (
 	"context"
+	"errors"
+	"strconv"
 
-	"github.com/aergoio/aergo/cmd/aergocli/util"
 	"github.com/aergoio/aergo/types"
+	"github.com/aergoio/aergo/types/jsonrpc"
 	"github.com/mr-tron/base58/base58"
 	"github.com/spf13/cobra"
 )
@@ -24,6 +26,7 @@ func init() {
 	getstateCmd.Flags().StringVar(&address, "address", "", "Get state from the address")
 	getstateCmd.MarkFlagRequired("address")
 	getstateCmd.Flags().StringVar(&stateroot, "root", "", "Get the state at a specified state root")
+	getstateCmd.Flags().StringVar(&block, "block", "", "Get the state at a specified block number or hash")
 	getstateCmd.Flags().BoolVar(&proof, "proof", false, "Get the proof for the state")
 	getstateCmd.Flags().BoolVar(&compressed, "compressed", false, "Get a compressed proof for the state")
 	getstateCmd.Flags().BoolVar(&staking, "staking", false, "Get the staking info from the address")
@@ -53,7 +56,7 @@ func execGetState(cmd *cobra.Command, args []string) {
 			cmd.Printf("Failed: %s", err.Error())
 			return
 		}
-		amount, err := util.ConvertUnit(msg.GetAmountBigInt(), unit)
+		amount, err := jsonrpc.ConvertUnit(msg.GetAmountBigInt(), unit)
 		if err != nil {
 			cmd.Printf("Failed: %s", err.Error())
 			return
@@ -64,16 +67,26 @@ func execGetState(cmd *cobra.Command, args []string) {
 		return
 	}
 
+	if lightMode {
+		execGetStateLight(cmd, addr, root)
+		return
+	}
+
 	if !proof {
 		// NOTE GetState first queries the statedb buffer.
 		// So the prefered way to get the state is with a proof
+		blockNo, blockHash, err := parseBlock(block)
+		if err != nil {
+			cmd.Printf("Failed: %s\n", err.Error())
+			return
+		}
 		msg, err := client.GetState(context.Background(),
-			&types.SingleBytes{Value: addr})
+			&types.AccountAtBlock{Value: addr, BlockNo: blockNo, BlockHash: blockHash})
 		if err != nil {
 			cmd.Printf("Failed: %s", err.Error())
 			return
 		}
-		balance, err := util.ConvertUnit(msg.GetBalanceBigInt(), unit)
+		balance, err := jsonrpc.ConvertUnit(msg.GetBalanceBigInt(), unit)
 		if err != nil {
 			cmd.Printf("Failed: %s", err.Error())
 			return
@@ -83,13 +96,17 @@ func execGetState(cmd *cobra.Command, args []string) {
 	} else {
 		// Get the state and proof at a specific root.
 		// If root is nil, the latest block is queried.
+		if len(block) != 0 {
+			cmd.Printf("Failed: --block can't be used with --proof, use --root instead\n")
+			return
+		}
 		msg, err := client.GetStateAndProof(context.Background(),
 			&types.AccountAndRoot{Account: addr, Root: root, Compressed: compressed})
 		if err != nil {
 			cmd.Printf("Failed: %s", err.Error())
 			return
 		}
-		balance, err := util.ConvertUnit(msg.GetState().GetBalanceBigInt(), unit)
+		balance, err := jsonrpc.ConvertUnit(msg.GetState().GetBalanceBigInt(), unit)
 		if err != nil {
 			cmd.Printf("Failed: %s", err.Error())
 			return
@@ -98,3 +115,47 @@ func execGetState(cmd *cobra.Command, args []string) {
 			address, msg.GetState().GetNonce(), balance, msg.GetInclusion(), len(msg.GetAuditPath()), msg.GetHeight())
 	}
 }
+
+// execGetStateLight prints the state verified against the synced block
+// headers.
+func execGetStateLight(cmd *cobra.Command, addr, root []byte) {
+	ctx := context.Background()
+	lc, err := newLightClient(ctx)
+	if err != nil {
+		cmd.Printf("Failed: %s\n", err.Error())
+		return
+	}
+	no, err := lightBlockNo(lc, root)
+	if err != nil {
+		cmd.Printf("Failed: %s\n", err.Error())
+		return
+	}
+	st, err := lc.GetState(ctx, addr, no)
+	if err != nil {
+		cmd.Printf("Failed: %s\n", err.Error())
+		return
+	}
+	balance, err := jsonrpc.ConvertUnit(st.GetBalanceBigInt(), unit)
+	if err != nil {
+		cmd.Printf("Failed: %s\n", err.Error())
+		return
+	}
+	cmd.Printf(`{"account":"%s", "nonce":%d, "balance":"%s", "blockNo":%d, "verified":true}`+"\n",
+		address, st.GetNonce(), balance, no)
+}
+
+// parseBlock decodes a block number or a base58 encoded block hash. Both are
+// empty if s is empty, which means the best block.
+func parseBlock(s string) (types.BlockNo, []byte, error) {
+	if len(s) == 0 {
+		return 0, nil, nil
+	}
+	if no, err := strconv.ParseUint(s, 10, 64); err == nil {
+		return no, nil, nil
+	}
+	hash, err := base58.Decode(s)
+	if err != nil || len(hash) != types.HashIDLength {
+		return 0, nil, errors.New("invalid block: must be a block number or hash")
+	}
+	return 0, hash, nil
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/aergoio/aergo/types"
+	"github.com/golang/mock/gomock"
+	"github.com/mr-tron/base58/base58"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetStateAtBlockWithMock(t *testing.T) {
+	mock := initMock(t)
+	defer deinitMock()
+
+	testAddressString := "AmNL5neKQS2ZwRuBeqfcfHMLg3aSmGoefEh5bW8ozWxrtmxaGHZ3"
+	testAddress, _ := types.DecodeAddress(testAddressString)
+	testBlockHashString := "56Qy6MQei9KM13rqEq1jiJ7Da21Kcq9KdmYWcnPLtxS3"
+	testBlockHash, _ := base58.Decode(testBlockHashString)
+
+	mock.EXPECT().GetState(
+		gomock.Any(),
+		gomock.Eq(&types.AccountAtBlock{Value: testAddress, BlockNo: 10}),
+	).Return(&types.State{Nonce: 1}, nil).Times(1)
+	mock.EXPECT().GetState(
+		gomock.Any(),
+		gomock.Eq(&types.AccountAtBlock{Value: testAddress, BlockHash: testBlockHash}),
+	).Return(&types.State{Nonce: 2}, nil).Times(1)
+
+	output, err := executeCommand(rootCmd, "getstate", "--address", testAddressString, "--block", "10")
+	assert.NoError(t, err)
+	assert.Contains(t, output, `"nonce":1`)
+
+	output, err = executeCommand(rootCmd, "getstate", "--address", testAddressString, "--block", testBlockHashString)
+	assert.NoError(t, err)
+	assert.Contains(t, output, `"nonce":2`)
+
+	output, err = executeCommand(rootCmd, "getstate", "--address", testAddressString, "--block", "invalid")
+	assert.NoError(t, err)
+	assert.Contains(t, output, "invalid block")
+	block = ""
+}
+
+func TestQueryContractAtBlockWithMock(t *testing.T) {
+	mock := initMock(t)
+	defer deinitMock()
+
+	testAddressString := "AmNL5neKQS2ZwRuBeqfcfHMLg3aSmGoefEh5bW8ozWxrtmxaGHZ3"
+	testAddress, _ := types.DecodeAddress(testAddressString)
+
+	mock.EXPECT().QueryContract(
+		gomock.Any(),
+		gomock.Eq(&types.Query{ContractAddress: testAddress, Queryinfo: []byte(`{"Name":"get","Args":null}`), BlockNo: 10}),
+	).Return(&types.SingleBytes{Value: []byte("1")}, nil).Times(1)
+	mock.EXPECT().GetABI(
+		gomock.Any(),
+		gomock.Eq(&types.AccountAtBlock{Value: testAddress, BlockNo: 10}),
+	).Return(&types.ABI{Version: "0.2"}, nil).Times(1)
+
+	_, err := executeCommand(rootCmd, "contract", "query", testAddressString, "get", "--block", "10")
+	assert.NoError(t, err)
+
+	output, err := executeCommand(rootCmd, "contract", "abi", testAddressString, "--block", "10")
+	assert.NoError(t, err)
+	assert.Contains(t, output, "0.2")
+
+	_, err = executeCommand(rootCmd, "contract", "abi", testAddressString, "--block", "invalid")
+	assert.Error(t, err)
+	block = ""
+}
@@ -8,8 +8,8 @@ package cmd
 import (
 	"context"
 
-	"github.com/aergoio/aergo/cmd/aergocli/util"
 	aergorpc "github.com/aergoio/aergo/types"
+	"github.com/aergoio/aergo/types/jsonrpc"
 	"github.com/mr-tron/base58/base58"
 	"github.com/spf13/cobra"
 )
@@ -35,20 +35,20 @@ func execGetTX(cmd *cobra.Command, args []string) {
 		cmd.Printf("Failed decode: %s", err.Error())
 		return
 	}
-	payloadEncodingType := util.Base58
+	payloadEncodingType := jsonrpc.Base58
 	if rawPayload {
-		payloadEncodingType = util.Raw
+		payloadEncodingType = jsonrpc.Raw
 	}
 	msg, err := client.GetTX(context.Background(), &aergorpc.SingleBytes{Value: txHash})
 	if err == nil {
-		cmd.Println(util.ConvTxEx(msg, payloadEncodingType))
+		cmd.Println(jsonrpc.ConvTxEx(msg, payloadEncodingType))
 	} else {
 		msgblock, err := client.GetBlockTX(context.Background(), &aergorpc.SingleBytes{Value: txHash})
 		if err != nil {
 			cmd.Printf("Failed: %s", err.Error())
 			return
 		}
-		cmd.Println(util.ConvTxInBlockEx(msgblock, payloadEncodingType))
+		cmd.Println(jsonrpc.ConvTxInBlockEx(msgblock, payloadEncodingType))
 	}
 
 }
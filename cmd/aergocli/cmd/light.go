@@ -0,0 +1,99 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/aergoio/aergo/pkg/light"
+	"github.com/aergoio/aergo/types"
+	"github.com/mr-tron/base58/base58"
+)
+
+var (
+	lightMode  bool
+	trustedStr string
+	trustedBPs string
+)
+
+func init() {
+	rootCmd.PersistentFlags().BoolVar(&lightMode, "light", false, "verify the results of getstate, querystate and receipt against the synced block headers")
+	rootCmd.PersistentFlags().StringVar(&trustedStr, "trusted", "", "hash of the checkpoint block trusted by the light mode (default is the best block of the node)")
+	rootCmd.PersistentFlags().StringVar(&trustedBPs, "bps", "", "comma separated peer ids of the block producers trusted by the light mode (default is the current ones of the node)")
+}
+
+// newLightClient returns a light client synced to the best block of the
+// node.
+func newLightClient(ctx context.Context) (*light.Client, error) {
+	var cfg light.Config
+	if len(trustedStr) != 0 {
+		hash, err := base58.Decode(trustedStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode trusted block hash: %v", err.Error())
+		}
+		cfg.TrustedHash = hash
+	} else {
+		status, err := client.Blockchain(ctx, &types.Empty{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get best block: %v", err.Error())
+		}
+		log.Printf("warning: the best block from the node is trusted: %s\n", base58.Encode(status.GetBestBlockHash()))
+		cfg.TrustedHash = status.GetBestBlockHash()
+	}
+
+	if len(trustedBPs) != 0 {
+		for _, s := range strings.Split(trustedBPs, ",") {
+			id, err := types.IDB58Decode(strings.TrimSpace(s))
+			if err != nil {
+				return nil, fmt.Errorf("invalid peer id %s: %v", s, err.Error())
+			}
+			cfg.BPs = append(cfg.BPs, id)
+		}
+	} else {
+		ci, err := client.GetConsensusInfo(ctx, &types.Empty{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get consensus info: %v", err.Error())
+		}
+		if cfg.BPs, err = light.BPsOf(ci); err != nil {
+			return nil, fmt.Errorf("failed to get block producers: %v", err.Error())
+		}
+		log.Println("warning: the block producers from the node are trusted")
+	}
+
+	lc, err := light.New(ctx, client, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := lc.Sync(ctx); err != nil {
+		return nil, fmt.Errorf("failed to sync block headers: %v", err.Error())
+	}
+	return lc, nil
+}
+
+// lightBlockNo returns the number of the block whose state root is root, or
+// the best block if root is empty.
+func lightBlockNo(lc *light.Client, root []byte) (types.BlockNo, error) {
+	best := lc.Best()
+	if len(root) == 0 {
+		return best.BlockNo(), nil
+	}
+	for no := best.BlockNo(); ; no-- {
+		header, err := lc.Header(no)
+		if err != nil {
+			break
+		}
+		if string(header.GetHeader().GetBlocksRootHash()) == string(root) {
+			return no, nil
+		}
+		if no == 0 {
+			break
+		}
+	}
+	return 0, fmt.Errorf("no synced block of state root %s", base58.Encode(root))
+}
@@ -177,7 +177,7 @@ func (mr *MockAergoRPCServiceClientMockRecorder) ExportAccountKeystore(arg0, arg
 }
 
 // GetABI mocks base method
-func (m *MockAergoRPCServiceClient) GetABI(arg0 context.Context, arg1 *types.SingleBytes, arg2 ...grpc.CallOption) (*types.ABI, error) {
+func (m *MockAergoRPCServiceClient) GetABI(arg0 context.Context, arg1 *types.AccountAtBlock, arg2 ...grpc.CallOption) (*types.ABI, error) {
 	m.ctrl.T.Helper()
 	varargs := []interface{}{arg0, arg1}
 	for _, a := range arg2 {
@@ -456,6 +456,26 @@ func (mr *MockAergoRPCServiceClientMockRecorder) GetReceipt(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceipt", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).GetReceipt), varargs...)
 }
 
+// GetReceiptProof mocks base method
+func (m *MockAergoRPCServiceClient) GetReceiptProof(arg0 context.Context, arg1 *types.SingleBytes, arg2 ...grpc.CallOption) (*types.ReceiptProof, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetReceiptProof", varargs...)
+	ret0, _ := ret[0].(*types.ReceiptProof)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReceiptProof indicates an expected call of GetReceiptProof
+func (mr *MockAergoRPCServiceClientMockRecorder) GetReceiptProof(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceiptProof", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).GetReceiptProof), varargs...)
+}
+
 // GetServerInfo mocks base method
 func (m *MockAergoRPCServiceClient) GetServerInfo(arg0 context.Context, arg1 *types.KeyParams, arg2 ...grpc.CallOption) (*types.ServerInfo, error) {
 	m.ctrl.T.Helper()
@@ -497,7 +517,7 @@ func (mr *MockAergoRPCServiceClientMockRecorder) GetStaking(arg0, arg1 interface
 }
 
 // GetState mocks base method
-func (m *MockAergoRPCServiceClient) GetState(arg0 context.Context, arg1 *types.SingleBytes, arg2 ...grpc.CallOption) (*types.State, error) {
+func (m *MockAergoRPCServiceClient) GetState(arg0 context.Context, arg1 *types.AccountAtBlock, arg2 ...grpc.CallOption) (*types.State, error) {
 	m.ctrl.T.Helper()
 	varargs := []interface{}{arg0, arg1}
 	for _, a := range arg2 {
@@ -556,6 +576,46 @@ func (mr *MockAergoRPCServiceClientMockRecorder) GetTX(arg0, arg1 interface{}, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTX", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).GetTX), varargs...)
 }
 
+// GetTipStat mocks base method
+func (m *MockAergoRPCServiceClient) GetTipStat(arg0 context.Context, arg1 *types.Empty, arg2 ...grpc.CallOption) (*types.TipStat, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetTipStat", varargs...)
+	ret0, _ := ret[0].(*types.TipStat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTipStat indicates an expected call of GetTipStat
+func (mr *MockAergoRPCServiceClientMockRecorder) GetTipStat(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTipStat", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).GetTipStat), varargs...)
+}
+
+// GetTxProof mocks base method
+func (m *MockAergoRPCServiceClient) GetTxProof(arg0 context.Context, arg1 *types.SingleBytes, arg2 ...grpc.CallOption) (*types.TxProof, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetTxProof", varargs...)
+	ret0, _ := ret[0].(*types.TxProof)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTxProof indicates an expected call of GetTxProof
+func (mr *MockAergoRPCServiceClientMockRecorder) GetTxProof(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTxProof", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).GetTxProof), varargs...)
+}
+
 // GetVotes mocks base method
 func (m *MockAergoRPCServiceClient) GetVotes(arg0 context.Context, arg1 *types.VoteParams, arg2 ...grpc.CallOption) (*types.VoteList, error) {
 	m.ctrl.T.Helper()
@@ -676,6 +736,26 @@ func (mr *MockAergoRPCServiceClientMockRecorder) ListBlockStream(arg0, arg1 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBlockStream", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).ListBlockStream), varargs...)
 }
 
+// ListContractVersions mocks base method
+func (m *MockAergoRPCServiceClient) ListContractVersions(arg0 context.Context, arg1 *types.AccountAtBlock, arg2 ...grpc.CallOption) (*types.ContractVersionList, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListContractVersions", varargs...)
+	ret0, _ := ret[0].(*types.ContractVersionList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListContractVersions indicates an expected call of ListContractVersions
+func (mr *MockAergoRPCServiceClientMockRecorder) ListContractVersions(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListContractVersions", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).ListContractVersions), varargs...)
+}
+
 // ListEventStream mocks base method
 func (m *MockAergoRPCServiceClient) ListEventStream(arg0 context.Context, arg1 *types.FilterInfo, arg2 ...grpc.CallOption) (types.AergoRPCService_ListEventStreamClient, error) {
 	m.ctrl.T.Helper()
@@ -856,6 +936,26 @@ func (mr *MockAergoRPCServiceClientMockRecorder) SignTX(arg0, arg1 interface{},
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignTX", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).SignTX), varargs...)
 }
 
+// SimulateTx mocks base method
+func (m *MockAergoRPCServiceClient) SimulateTx(arg0 context.Context, arg1 *types.Tx, arg2 ...grpc.CallOption) (*types.Receipt, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SimulateTx", varargs...)
+	ret0, _ := ret[0].(*types.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SimulateTx indicates an expected call of SimulateTx
+func (mr *MockAergoRPCServiceClientMockRecorder) SimulateTx(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SimulateTx", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).SimulateTx), varargs...)
+}
+
 // UnlockAccount mocks base method
 func (m *MockAergoRPCServiceClient) UnlockAccount(arg0 context.Context, arg1 *types.Personal, arg2 ...grpc.CallOption) (*types.Account, error) {
 	m.ctrl.T.Helper()
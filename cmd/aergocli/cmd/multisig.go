@@ -0,0 +1,188 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"strconv"
+
+	"github.com/aergoio/aergo/account/key"
+	"github.com/aergoio/aergo/types"
+	"github.com/aergoio/aergo/types/jsonrpc"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/mr-tron/base58/base58"
+	"github.com/spf13/cobra"
+)
+
+var multisigCmd = &cobra.Command{
+	Use:   "multisig [flags] subcommand",
+	Short: "Multisig account command",
+}
+
+var threshold uint32
+var signers []string
+var jsonTxs []string
+
+func init() {
+	rootCmd.AddCommand(multisigCmd)
+	msSetCmd := &cobra.Command{
+		Use:   "set",
+		Short: "Set the signers and the threshold of an account",
+		Long: `Make the account a multisig account, whose transactions must be signed by at least
+--threshold of --signers. Setting --threshold to 0 without any signer turns it back to
+a normal account.`,
+		RunE:                  execMultisigSet,
+		DisableFlagsInUseLine: true,
+	}
+	msSetCmd.Flags().StringVar(&address, "address", "", "address of the account")
+	msSetCmd.MarkFlagRequired("address")
+	msSetCmd.Flags().Uint32Var(&threshold, "threshold", 0, "number of the signatures required")
+	msSetCmd.MarkFlagRequired("threshold")
+	msSetCmd.Flags().StringSliceVar(&signers, "signers", nil, "addresses of the signers")
+	msSetCmd.Flags().StringVar(&pw, "password", "", "password")
+
+	msSignCmd := &cobra.Command{
+		Use:   "sign",
+		Short: "Add a signature of a signer to a multisig transaction",
+		Long: `Add a signature of a signer to a transaction of a multisig account. The signatures
+already in the transaction are kept, so the transaction can be passed around the signers
+and sent by committx once it has enough signatures.`,
+		RunE:                  execMultisigSign,
+		DisableFlagsInUseLine: true,
+	}
+	msSignCmd.Flags().StringVar(&jsonTx, "jsontx", "", "transaction json to sign")
+	msSignCmd.MarkFlagRequired("jsontx")
+	msSignCmd.Flags().StringVar(&address, "address", "", "address of the signer in the keystore")
+	msSignCmd.Flags().StringVar(&pw, "password", "", "local account password")
+	msSignCmd.Flags().StringVar(&privKey, "key", "", "base58 encoded key of the signer")
+
+	msMergeCmd := &cobra.Command{
+		Use:                   "merge",
+		Short:                 "Merge the signatures of multisig transactions",
+		Long:                  "Merge the signatures of the copies of a multisig transaction signed by different signers.",
+		RunE:                  execMultisigMerge,
+		DisableFlagsInUseLine: true,
+	}
+	msMergeCmd.Flags().StringArrayVar(&jsonTxs, "jsontx", nil, "transaction json to merge (can be repeated)")
+	msMergeCmd.MarkFlagRequired("jsontx")
+
+	multisigCmd.AddCommand(msSetCmd, msSignCmd, msMergeCmd)
+}
+
+func execMultisigSet(cmd *cobra.Command, args []string) error {
+	account, err := types.DecodeAddress(address)
+	if err != nil {
+		return errors.New("Failed to parse --address flag (" + address + ")\n" + err.Error())
+	}
+	ci := types.CallInfo{Name: types.OpsetMultisig.Cmd()}
+	ci.Args = append(ci.Args, strconv.FormatUint(uint64(threshold), 10))
+	for _, signer := range signers {
+		ci.Args = append(ci.Args, signer)
+	}
+	if _, err := types.ParseMultisigArgs(ci.Args); err != nil {
+		return err
+	}
+	payload, err := json.Marshal(ci)
+	if err != nil {
+		return err
+	}
+	tx := &types.Tx{
+		Body: &types.TxBody{
+			Account:   account,
+			Recipient: []byte(types.AergoSystem),
+			Amount:    []byte{},
+			Payload:   payload,
+			Type:      types.TxType_GOVERNANCE,
+		},
+	}
+	cmd.Println(sendTX(cmd, tx, account))
+	return nil
+}
+
+func execMultisigSign(cmd *cobra.Command, args []string) error {
+	txs, err := jsonrpc.ParseBase58Tx([]byte(jsonTx))
+	if err != nil {
+		return errors.New("Failed to parse --jsontx flag\n" + err.Error())
+	}
+	if len(txs) != 1 {
+		return errors.New("--jsontx must be a transaction")
+	}
+	tx := txs[0]
+
+	var signKey *btcec.PrivateKey
+	if privKey != "" {
+		rawKey, err := base58.Decode(privKey)
+		if err != nil {
+			return errors.New("Failed to parse --key flag\n" + err.Error())
+		}
+		signKey, _ = btcec.PrivKeyFromBytes(btcec.S256(), rawKey)
+	} else {
+		if rootConfig.KeyStorePath == "" {
+			return errors.New("--key or the keystore is required")
+		}
+		addr, err := types.DecodeAddress(address)
+		if err != nil {
+			return errors.New("Failed to parse --address flag (" + address + ")\n" + err.Error())
+		}
+		if pw == "" {
+			if pw, err = getPasswd(cmd, false); err != nil {
+				return errors.New("Failed get password:" + err.Error())
+			}
+		}
+		ks := key.NewStore(os.ExpandEnv(rootConfig.KeyStorePath), 0)
+		defer ks.CloseStore()
+		if signKey, err = ks.GetKey(addr, pw); err != nil {
+			return err
+		}
+	}
+	if err := key.AddMultiSign(tx, signKey); err != nil {
+		return err
+	}
+	cmd.Println(jsonrpc.TxConvBase58Addr(tx))
+	return nil
+}
+
+func execMultisigMerge(cmd *cobra.Command, args []string) error {
+	var merged *types.Tx
+	ms := &types.MultiSign{}
+	for _, j := range jsonTxs {
+		txs, err := jsonrpc.ParseBase58Tx([]byte(j))
+		if err != nil {
+			return errors.New("Failed to parse --jsontx flag\n" + err.Error())
+		}
+		for _, tx := range txs {
+			if merged == nil {
+				merged = tx
+			} else if !sameTxBody(merged.Body, tx.Body) {
+				return errors.New("the transactions are not the same")
+			}
+			s, err := types.DecodeMultiSign(tx.Body.Sign)
+			if err != nil {
+				return err
+			}
+			ms.Merge(s)
+		}
+	}
+	if merged == nil {
+		return errors.New("no transaction to merge")
+	}
+	sign, err := ms.Encode()
+	if err != nil {
+		return err
+	}
+	merged.Body.Sign = sign
+	merged.Hash = merged.CalculateTxHash()
+	cmd.Println(jsonrpc.TxConvBase58Addr(merged))
+	return nil
+}
+
+// sameTxBody returns true if a and b are the same except the signatures.
+func sameTxBody(a, b *types.TxBody) bool {
+	return bytes.Equal(key.CalculateHashWithoutSign(a), key.CalculateHashWithoutSign(b))
+}
@@ -0,0 +1,116 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aergoio/aergo/pkg/light"
+	"github.com/aergoio/aergo/types"
+	"github.com/aergoio/aergo/types/jsonrpc"
+	"github.com/mr-tron/base58/base58"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	proofCmd := &cobra.Command{
+		Use:   "proof [flags] subcommand",
+		Short: "Get the merkle proofs of txs and receipts",
+		Long: `Get the merkle proof of a tx or a receipt in its block, and verify it against the
+header of the block. The header is trusted as returned by the node unless --light is set.`,
+	}
+	proofCmd.AddCommand(
+		&cobra.Command{
+			Use:   "tx [flags] tx_hash",
+			Short: "Get the merkle proof of a tx",
+			Args:  cobra.MinimumNArgs(1),
+			RunE:  execTxProof,
+		},
+		&cobra.Command{
+			Use:   "receipt [flags] tx_hash",
+			Short: "Get the merkle proof of the receipt of a tx",
+			Args:  cobra.MinimumNArgs(1),
+			RunE:  execReceiptProof,
+		},
+	)
+	rootCmd.AddCommand(proofCmd)
+}
+
+func execTxProof(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+	ctx := context.Background()
+	txHash, err := base58.Decode(args[0])
+	if err != nil {
+		return err
+	}
+	proof, err := client.GetTxProof(ctx, &types.SingleBytes{Value: txHash})
+	if err != nil {
+		return err
+	}
+	if lightMode {
+		lc, err := newLightClient(ctx)
+		if err != nil {
+			return err
+		}
+		err = lc.VerifyTxProof(proof)
+	} else {
+		var header *types.BlockHeader
+		if header, err = proofHeader(ctx, proof.GetBlockHash()); err == nil {
+			err = proof.Verify(header)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	cmd.Println(jsonrpc.ConvTxProof(proof))
+	return nil
+}
+
+func execReceiptProof(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+	ctx := context.Background()
+	txHash, err := base58.Decode(args[0])
+	if err != nil {
+		return err
+	}
+	proof, err := client.GetReceiptProof(ctx, &types.SingleBytes{Value: txHash})
+	if err != nil {
+		return err
+	}
+	if lightMode {
+		lc, err := newLightClient(ctx)
+		if err != nil {
+			return err
+		}
+		err = lc.VerifyReceiptProof(proof)
+	} else {
+		var header *types.BlockHeader
+		if header, err = proofHeader(ctx, proof.GetBlockHash()); err == nil {
+			var status *types.BlockchainStatus
+			if status, err = client.Blockchain(ctx, &types.Empty{}); err == nil {
+				err = proof.Verify(header, light.HardforkOf(status.GetChainInfo().GetId().GetMagic()))
+			}
+		}
+	}
+	if err != nil {
+		return err
+	}
+	cmd.Println(jsonrpc.ConvReceiptProof(proof))
+	return nil
+}
+
+// proofHeader returns the header of the block of hash from the node.
+func proofHeader(ctx context.Context, hash []byte) (*types.BlockHeader, error) {
+	meta, err := client.GetBlockMetadata(ctx, &types.SingleBytes{Value: hash})
+	if err != nil {
+		return nil, err
+	}
+	if meta.GetHeader() == nil {
+		return nil, errors.New("block header not found")
+	}
+	return meta.GetHeader(), nil
+}
@@ -32,7 +32,17 @@ func init() {
 				if err != nil {
 					log.Fatal(err)
 				}
-				msg, err := client.GetReceipt(context.Background(), &aergorpc.SingleBytes{Value: txHash})
+				var msg *aergorpc.Receipt
+				if lightMode {
+					ctx := context.Background()
+					lc, err := newLightClient(ctx)
+					if err != nil {
+						log.Fatal(err)
+					}
+					msg, err = lc.GetReceipt(ctx, txHash)
+				} else {
+					msg, err = client.GetReceipt(context.Background(), &aergorpc.SingleBytes{Value: txHash})
+				}
 				if err != nil {
 					log.Fatal(err)
 				}
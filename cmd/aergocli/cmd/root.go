@@ -50,6 +50,7 @@ var (
 
 	address    string
 	stateroot  string
+	block      string
 	proof      bool
 	compressed bool
 
@@ -11,6 +11,7 @@ import (
 
 	"github.com/aergoio/aergo/cmd/aergocli/util"
 	"github.com/aergoio/aergo/types"
+	"github.com/aergoio/aergo/types/jsonrpc"
 	"github.com/mr-tron/base58"
 	"github.com/spf13/cobra"
 )
@@ -22,6 +23,7 @@ var sendtxCmd = &cobra.Command{
 	RunE:  execSendTX,
 }
 var chainIdHash string
+var validAfter, validBefore uint64
 
 func init() {
 	rootCmd.AddCommand(sendtxCmd)
@@ -35,6 +37,8 @@ func init() {
 	sendtxCmd.Flags().StringVar(&chainIdHash, "chainidhash", "", "hash value of chain id in the block")
 	sendtxCmd.Flags().Uint64VarP(&gas, "gaslimit", "g", 0, "Gas limit")
 	sendtxCmd.Flags().StringVar(&pw, "password", "", "Password")
+	sendtxCmd.Flags().Uint64Var(&validAfter, "validafter", 0, "block number or unix time in nanoseconds from which the tx is valid")
+	sendtxCmd.Flags().Uint64Var(&validBefore, "validbefore", 0, "block number or unix time in nanoseconds until which the tx is valid")
 }
 
 func execSendTX(cmd *cobra.Command, args []string) error {
@@ -46,17 +50,19 @@ func execSendTX(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return errors.New("Wrong address in --to flag\n" + err.Error())
 	}
-	amountBigInt, err := util.ParseUnit(amount)
+	amountBigInt, err := jsonrpc.ParseUnit(amount)
 	if err != nil {
 		return errors.New("Wrong value in --amount flag\n" + err.Error())
 	}
 	tx := &types.Tx{Body: &types.TxBody{
-		Type:      types.TxType_TRANSFER,
-		Account:   account,
-		Recipient: recipient,
-		Amount:    amountBigInt.Bytes(),
-		Nonce:     nonce,
-		GasLimit:  gas,
+		Type:        types.TxType_TRANSFER,
+		Account:     account,
+		Recipient:   recipient,
+		Amount:      amountBigInt.Bytes(),
+		Nonce:       nonce,
+		GasLimit:    gas,
+		ValidAfter:  validAfter,
+		ValidBefore: validBefore,
 	}}
 	if chainIdHash != "" {
 		cid, err := base58.Decode(chainIdHash)
@@ -85,7 +91,7 @@ func sendTX(cmd *cobra.Command, tx *types.Tx, account []byte) string {
 			}
 		}
 		if tx.GetBody().GetNonce() == 0 {
-			state, err := client.GetState(context.Background(), &types.SingleBytes{Value: account})
+			state, err := client.GetState(context.Background(), &types.AccountAtBlock{Value: account})
 			if err != nil {
 				return err.Error()
 			}
@@ -7,8 +7,8 @@ import (
 
 	"github.com/aergoio/aergo/account/key"
 	crypto "github.com/aergoio/aergo/account/key/crypto"
-	"github.com/aergoio/aergo/cmd/aergocli/util"
 	"github.com/aergoio/aergo/types"
+	"github.com/aergoio/aergo/types/jsonrpc"
 	"github.com/btcsuite/btcd/btcec"
 	"github.com/mr-tron/base58/base58"
 	"github.com/spf13/cobra"
@@ -20,11 +20,22 @@ func init() {
 	signCmd.Flags().StringVar(&address, "address", "1", "address of account to use for signing")
 	signCmd.Flags().StringVar(&pw, "password", "", "local account password")
 	signCmd.Flags().StringVar(&privKey, "key", "", "base58 encoded key for sign")
+	signCmd.Flags().StringVar(&remoteSigner, "signer", "", "URL of the remote signer to sign with the key of --address")
+	signCmd.Flags().StringVar(&signerCACert, "signercacert", "", "CA certification file to authenticate the remote signer")
+	signCmd.Flags().StringVar(&signerCert, "signercert", "", "client certification file to authenticate to the remote signer")
+	signCmd.Flags().StringVar(&signerKey, "signerkey", "", "client key file to authenticate to the remote signer")
 	rootCmd.AddCommand(verifyCmd)
 	verifyCmd.Flags().StringVar(&jsonTx, "jsontx", "", "transaction list json to verify")
 	verifyCmd.Flags().BoolVar(&remote, "remote", false, "verify in the node")
 }
 
+var (
+	remoteSigner string
+	signerCACert string
+	signerCert   string
+	signerKey    string
+)
+
 var signCmd = &cobra.Command{
 	Use:    "signtx",
 	Short:  "Sign transaction",
@@ -36,7 +47,7 @@ var signCmd = &cobra.Command{
 			cmd.Printf("need to transaction json input")
 			return
 		}
-		param, err := util.ParseBase58TxBody([]byte(jsonTx))
+		param, err := jsonrpc.ParseBase58TxBody([]byte(jsonTx))
 		if err != nil {
 			cmd.Printf("Failed: %s\n", err.Error())
 			return
@@ -58,6 +69,23 @@ var signCmd = &cobra.Command{
 			}
 			cmd.Println(types.EncodeAddress(crypto.GenerateAddress(pubkey.ToECDSA())))
 			msg = tx
+		} else if remoteSigner != "" {
+			addr, err := types.DecodeAddress(address)
+			if err != nil {
+				cmd.Printf("Failed: %s\n", err.Error())
+				return
+			}
+			rs, err := key.NewRemoteSigner(remoteSigner, signerCACert, signerCert, signerKey)
+			if err != nil {
+				cmd.Printf("Failed: %s\n", err.Error())
+				return
+			}
+			tx := &types.Tx{Body: param}
+			if err = rs.SignTx(tx, addr); err != nil {
+				cmd.Printf("Failed: %s\n", err.Error())
+				return
+			}
+			msg = tx
 		} else if rootConfig.KeyStorePath == "" {
 			msg, err = client.SignTX(context.Background(), &types.Tx{Body: param})
 		} else {
@@ -89,7 +117,7 @@ var signCmd = &cobra.Command{
 		}
 
 		if nil == err && msg != nil {
-			cmd.Println(util.TxConvBase58Addr(msg))
+			cmd.Println(jsonrpc.TxConvBase58Addr(msg))
 		} else {
 			cmd.Printf("Failed: %s\n", err.Error())
 		}
@@ -105,7 +133,7 @@ var verifyCmd = &cobra.Command{
 			cmd.Printf("need to transaction json input")
 			return
 		}
-		param, err := util.ParseBase58Tx([]byte(jsonTx))
+		param, err := jsonrpc.ParseBase58Tx([]byte(jsonTx))
 		if err != nil {
 			cmd.Printf("Failed: %s\n", err.Error())
 			return
@@ -117,7 +145,7 @@ var verifyCmd = &cobra.Command{
 				return
 			}
 			if msg.Tx != nil {
-				cmd.Println(util.TxConvBase58Addr(msg.Tx))
+				cmd.Println(jsonrpc.TxConvBase58Addr(msg.Tx))
 			} else {
 				cmd.Println(msg.Error)
 			}
@@ -127,7 +155,7 @@ var verifyCmd = &cobra.Command{
 				cmd.Printf("Failed: %s\n", err.Error())
 				return
 			}
-			cmd.Println(util.TxConvBase58Addr(param[0]))
+			cmd.Println(jsonrpc.TxConvBase58Addr(param[0]))
 		}
 	},
 }
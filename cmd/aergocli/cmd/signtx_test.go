@@ -6,9 +6,9 @@ import (
 	"strings"
 	"testing"
 
-	"github.com/aergoio/aergo/cmd/aergocli/util"
 	"github.com/aergoio/aergo/cmd/aergocli/util/encoding/json"
 	"github.com/aergoio/aergo/types"
+	"github.com/aergoio/aergo/types/jsonrpc"
 	"github.com/mr-tron/base58/base58"
 	"github.com/stretchr/testify/assert"
 )
@@ -26,7 +26,7 @@ func TestSignWithKey(t *testing.T) {
 	assert.Equalf(t, types.AddressLength, len(addr), "wrong address length value = %s", output)
 
 	ouputjson := strings.Join(outputline[1:], "")
-	var tx util.InOutTx
+	var tx jsonrpc.InOutTx
 	err = json.Unmarshal([]byte(ouputjson), &tx)
 	assert.NoError(t, err, "should be success")
 
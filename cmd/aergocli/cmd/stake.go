@@ -8,8 +8,8 @@ package cmd
 import (
 	"encoding/json"
 	"errors"
-	"github.com/aergoio/aergo/cmd/aergocli/util"
 	"github.com/aergoio/aergo/types"
+	"github.com/aergoio/aergo/types/jsonrpc"
 	"github.com/spf13/cobra"
 )
 
@@ -46,7 +46,7 @@ func sendStake(cmd *cobra.Command, s bool) error {
 	} else {
 		ci.Name = types.Opunstake.Cmd()
 	}
-	amountBigInt, err := util.ParseUnit(amount)
+	amountBigInt, err := jsonrpc.ParseUnit(amount)
 	if err != nil {
 		return errors.New("Failed to parse --amount flag\n" + err.Error())
 	}
@@ -0,0 +1,35 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/aergoio/aergo/cmd/aergocli/util"
+	"github.com/aergoio/aergo/types"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(tipstatCmd)
+}
+
+var tipstatCmd = &cobra.Command{
+	Use:   "tipstat",
+	Short: "Print the distribution of priority tips in the mempool",
+	Long: `Print the distribution of the priority tips of the ready transactions in the mempool.
+A tip is the gas price of a transaction above the system gas price, which is paid to the
+block producer in addition to the fee since the hardfork version 3. The transactions with
+higher tips are included in a block first. The tips are per unit of gas in AER.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		msg, err := client.GetTipStat(context.Background(), &types.Empty{})
+		if err != nil {
+			cmd.Printf("Failed: %s\n", err.Error())
+			return
+		}
+		cmd.Println(util.ConvTipStatMsg(msg))
+	},
+}
@@ -0,0 +1,40 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/aergoio/aergo/types"
+)
+
+type InOutTipStat struct {
+	BlockVersion int32
+	GasPrice     string
+	Count        uint64
+	Tipped       uint64
+	Tips         map[string]string `json:",omitempty"`
+}
+
+func ConvTipStatMsg(msg *types.TipStat) string {
+	jsonout, err := json.MarshalIndent(convTipStat(msg), "", " ")
+	if err != nil {
+		return ""
+	}
+	return string(jsonout)
+}
+
+func convTipStat(msg *types.TipStat) *InOutTipStat {
+	out := &InOutTipStat{}
+	out.BlockVersion = msg.GetBlockVersion()
+	out.GasPrice = new(big.Int).SetBytes(msg.GetGasPrice()).String()
+	out.Count = msg.GetCount()
+	out.Tipped = msg.GetTipped()
+	if len(msg.GetTips()) > 0 {
+		out.Tips = make(map[string]string, len(msg.GetTips()))
+		for i, tip := range msg.GetTips() {
+			out.Tips[fmt.Sprintf("p%d", types.TipStatPercentiles[i])] = new(big.Int).SetBytes(tip).String()
+		}
+	}
+	return out
+}
@@ -0,0 +1,240 @@
+package lint
+
+// Node is a node of the syntax tree of a Lua chunk.
+type Node interface {
+	Position() Pos
+}
+
+// Expr is an expression.
+type Expr interface {
+	Node
+	expr()
+}
+
+// Stmt is a statement.
+type Stmt interface {
+	Node
+	stmt()
+}
+
+// Block is a list of statements.
+type Block struct {
+	Stmts []Stmt
+}
+
+type node struct {
+	Pos
+}
+
+func (n *node) Position() Pos {
+	return n.Pos
+}
+
+// expressions
+
+type (
+	NilExpr    struct{ node }
+	TrueExpr   struct{ node }
+	FalseExpr  struct{ node }
+	VarargExpr struct{ node }
+
+	NumberExpr struct {
+		node
+		Value string
+	}
+
+	StringExpr struct {
+		node
+		Value string
+	}
+
+	FunctionExpr struct {
+		node
+		Params   []string
+		IsVararg bool
+		Body     *Block
+	}
+
+	// TableField is a field of a table constructor, whose key is nil for
+	// a positional one.
+	TableField struct {
+		Key   Expr
+		Value Expr
+	}
+
+	TableExpr struct {
+		node
+		Fields []*TableField
+	}
+
+	BinaryExpr struct {
+		node
+		Op string
+		L  Expr
+		R  Expr
+	}
+
+	UnaryExpr struct {
+		node
+		Op string
+		X  Expr
+	}
+
+	ParenExpr struct {
+		node
+		X Expr
+	}
+
+	NameExpr struct {
+		node
+		Name string
+	}
+
+	// IndexExpr is x[key], or x.key when the key is a field name.
+	IndexExpr struct {
+		node
+		X     Expr
+		Key   Expr
+		Field bool
+	}
+
+	CallExpr struct {
+		node
+		Fn   Expr
+		Args []Expr
+	}
+
+	MethodCallExpr struct {
+		node
+		Recv   Expr
+		Method string
+		Args   []Expr
+	}
+)
+
+func (*NilExpr) expr()        {}
+func (*TrueExpr) expr()       {}
+func (*FalseExpr) expr()      {}
+func (*VarargExpr) expr()     {}
+func (*NumberExpr) expr()     {}
+func (*StringExpr) expr()     {}
+func (*FunctionExpr) expr()   {}
+func (*TableExpr) expr()      {}
+func (*BinaryExpr) expr()     {}
+func (*UnaryExpr) expr()      {}
+func (*ParenExpr) expr()      {}
+func (*NameExpr) expr()       {}
+func (*IndexExpr) expr()      {}
+func (*CallExpr) expr()       {}
+func (*MethodCallExpr) expr() {}
+
+// statements
+
+type (
+	AssignStmt struct {
+		node
+		Targets []Expr
+		Values  []Expr
+	}
+
+	LocalStmt struct {
+		node
+		Names  []string
+		Values []Expr
+	}
+
+	CallStmt struct {
+		node
+		Call Expr
+	}
+
+	DoStmt struct {
+		node
+		Body *Block
+	}
+
+	WhileStmt struct {
+		node
+		Cond Expr
+		Body *Block
+	}
+
+	RepeatStmt struct {
+		node
+		Body *Block
+		Cond Expr
+	}
+
+	// IfStmt has the conditions of if and elseif with their blocks, and
+	// the else block, which is nil without else.
+	IfStmt struct {
+		node
+		Conds  []Expr
+		Blocks []*Block
+		Else   *Block
+	}
+
+	NumericForStmt struct {
+		node
+		Name  string
+		Start Expr
+		Limit Expr
+		Step  Expr
+		Body  *Block
+	}
+
+	GenericForStmt struct {
+		node
+		Names []string
+		Exprs []Expr
+		Body  *Block
+	}
+
+	// FunctionStmt is function name() end, where the name is a NameExpr
+	// or an IndexExpr, and a method is declared with self.
+	FunctionStmt struct {
+		node
+		Name   Expr
+		Method string
+		Func   *FunctionExpr
+	}
+
+	LocalFunctionStmt struct {
+		node
+		Name string
+		Func *FunctionExpr
+	}
+
+	ReturnStmt struct {
+		node
+		Values []Expr
+	}
+
+	BreakStmt struct{ node }
+
+	GotoStmt struct {
+		node
+		Label string
+	}
+
+	LabelStmt struct {
+		node
+		Label string
+	}
+)
+
+func (*AssignStmt) stmt()        {}
+func (*LocalStmt) stmt()         {}
+func (*CallStmt) stmt()          {}
+func (*DoStmt) stmt()            {}
+func (*WhileStmt) stmt()         {}
+func (*RepeatStmt) stmt()        {}
+func (*IfStmt) stmt()            {}
+func (*NumericForStmt) stmt()    {}
+func (*GenericForStmt) stmt()    {}
+func (*FunctionStmt) stmt()      {}
+func (*LocalFunctionStmt) stmt() {}
+func (*ReturnStmt) stmt()        {}
+func (*BreakStmt) stmt()         {}
+func (*GotoStmt) stmt()          {}
+func (*LabelStmt) stmt()         {}
@@ -0,0 +1,348 @@
+package lint
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type tokenType int
+
+const (
+	tokEOF tokenType = iota
+	tokName
+	tokNumber
+	tokString
+	tokKeyword
+	tokOp
+)
+
+// Pos is a position in the source code.
+type Pos struct {
+	Line   int
+	Column int
+}
+
+type token struct {
+	typ   tokenType
+	value string
+	pos   Pos
+}
+
+func (t token) is(typ tokenType, value string) bool {
+	return t.typ == typ && t.value == value
+}
+
+func (t token) String() string {
+	switch t.typ {
+	case tokEOF:
+		return "<eof>"
+	case tokString:
+		return strconv.Quote(t.value)
+	}
+	return "'" + t.value + "'"
+}
+
+var keywords = map[string]bool{
+	"and": true, "break": true, "do": true, "else": true, "elseif": true,
+	"end": true, "false": true, "for": true, "function": true, "goto": true,
+	"if": true, "in": true, "local": true, "nil": true, "not": true,
+	"or": true, "repeat": true, "return": true, "then": true, "true": true,
+	"until": true, "while": true,
+}
+
+// the operators which are longer are matched first
+var operators = []string{
+	"...", "..", "==", "~=", "<=", ">=", "::",
+	"+", "-", "*", "/", "%", "^", "#", "<", ">", "=",
+	"(", ")", "{", "}", "[", "]", ";", ":", ",", ".",
+}
+
+// SyntaxError is an error in parsing the source code.
+type SyntaxError struct {
+	Pos
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%d:%d: %s", e.Line, e.Column, e.Msg)
+}
+
+type lexer struct {
+	src      string
+	off      int
+	line     int
+	col      int
+	comments map[int]string // the comments by the line where they end
+}
+
+func newLexer(src string) *lexer {
+	l := &lexer{
+		src:      src,
+		line:     1,
+		col:      1,
+		comments: make(map[int]string),
+	}
+	if strings.HasPrefix(src, "#") {
+		// skip the shebang line
+		for l.off < len(l.src) && l.src[l.off] != '\n' {
+			l.next()
+		}
+	}
+	return l
+}
+
+func (l *lexer) errorf(pos Pos, format string, args ...interface{}) {
+	panic(&SyntaxError{pos, fmt.Sprintf(format, args...)})
+}
+
+func (l *lexer) peekByte(n int) byte {
+	if l.off+n < len(l.src) {
+		return l.src[l.off+n]
+	}
+	return 0
+}
+
+func (l *lexer) next() byte {
+	c := l.src[l.off]
+	l.off++
+	if c == '\n' {
+		l.line++
+		l.col = 1
+	} else {
+		l.col++
+	}
+	return c
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isHexDigit(c byte) bool {
+	return isDigit(c) || ('a' <= c && c <= 'f') || ('A' <= c && c <= 'F')
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+func isNameChar(c byte) bool {
+	return isNameStart(c) || isDigit(c)
+}
+
+// longBracket returns the level of a long bracket at the offset, or -1 if
+// there isn't one.
+func (l *lexer) longBracket() int {
+	if l.peekByte(0) != '[' {
+		return -1
+	}
+	level := 0
+	for l.peekByte(level+1) == '=' {
+		level++
+	}
+	if l.peekByte(level+1) != '[' {
+		return -1
+	}
+	return level
+}
+
+func (l *lexer) readLongString(pos Pos, level int) string {
+	for i := 0; i < level+2; i++ {
+		l.next()
+	}
+	// the first newline is skipped
+	if l.peekByte(0) == '\r' {
+		l.next()
+	}
+	if l.peekByte(0) == '\n' {
+		l.next()
+	}
+	closing := "]" + strings.Repeat("=", level) + "]"
+	var b strings.Builder
+	for {
+		if l.off >= len(l.src) {
+			l.errorf(pos, "unfinished long string")
+		}
+		if strings.HasPrefix(l.src[l.off:], closing) {
+			for i := 0; i < len(closing); i++ {
+				l.next()
+			}
+			return b.String()
+		}
+		b.WriteByte(l.next())
+	}
+}
+
+func (l *lexer) skipSpaceAndComments() {
+	for l.off < len(l.src) {
+		c := l.peekByte(0)
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n' || c == '\f' || c == '\v':
+			l.next()
+		case c == '-' && l.peekByte(1) == '-':
+			pos := Pos{l.line, l.col}
+			l.next()
+			l.next()
+			var text string
+			if level := l.longBracket(); level >= 0 {
+				text = l.readLongString(pos, level)
+			} else {
+				start := l.off
+				for l.off < len(l.src) && l.src[l.off] != '\n' {
+					l.next()
+				}
+				text = l.src[start:l.off]
+			}
+			l.comments[l.line] += text
+		default:
+			return
+		}
+	}
+}
+
+func (l *lexer) scan() token {
+	l.skipSpaceAndComments()
+	pos := Pos{l.line, l.col}
+	if l.off >= len(l.src) {
+		return token{typ: tokEOF, pos: pos}
+	}
+
+	c := l.peekByte(0)
+	switch {
+	case isNameStart(c):
+		start := l.off
+		for l.off < len(l.src) && isNameChar(l.src[l.off]) {
+			l.next()
+		}
+		name := l.src[start:l.off]
+		if keywords[name] {
+			return token{tokKeyword, name, pos}
+		}
+		return token{tokName, name, pos}
+	case isDigit(c) || (c == '.' && isDigit(l.peekByte(1))):
+		return token{tokNumber, l.readNumber(), pos}
+	case c == '"' || c == '\'':
+		return token{tokString, l.readString(pos), pos}
+	case c == '[':
+		if level := l.longBracket(); level >= 0 {
+			return token{tokString, l.readLongString(pos, level), pos}
+		}
+	}
+
+	for _, op := range operators {
+		if strings.HasPrefix(l.src[l.off:], op) {
+			for i := 0; i < len(op); i++ {
+				l.next()
+			}
+			return token{tokOp, op, pos}
+		}
+	}
+	l.errorf(pos, "unexpected symbol near '%c'", c)
+	return token{}
+}
+
+func (l *lexer) readNumber() string {
+	start := l.off
+	if l.peekByte(0) == '0' && (l.peekByte(1) == 'x' || l.peekByte(1) == 'X') {
+		l.next()
+		l.next()
+		for isHexDigit(l.peekByte(0)) || l.peekByte(0) == '.' {
+			l.next()
+		}
+		if c := l.peekByte(0); c == 'p' || c == 'P' {
+			l.readExponent()
+		}
+	} else {
+		for isDigit(l.peekByte(0)) || l.peekByte(0) == '.' {
+			l.next()
+		}
+		if c := l.peekByte(0); c == 'e' || c == 'E' {
+			l.readExponent()
+		}
+	}
+	// the suffixes of the 64 bit integers and the imaginary numbers of LuaJIT
+	for {
+		c := l.peekByte(0)
+		if c != 'l' && c != 'L' && c != 'u' && c != 'U' && c != 'i' && c != 'I' {
+			break
+		}
+		l.next()
+	}
+	if isNameChar(l.peekByte(0)) {
+		l.errorf(Pos{l.line, l.col}, "malformed number near '%s'", l.src[start:l.off+1])
+	}
+	return l.src[start:l.off]
+}
+
+func (l *lexer) readExponent() {
+	l.next()
+	if c := l.peekByte(0); c == '+' || c == '-' {
+		l.next()
+	}
+	for isDigit(l.peekByte(0)) {
+		l.next()
+	}
+}
+
+func (l *lexer) readString(pos Pos) string {
+	quote := l.next()
+	var b strings.Builder
+	for {
+		if l.off >= len(l.src) {
+			l.errorf(pos, "unfinished string")
+		}
+		c := l.next()
+		switch c {
+		case quote:
+			return b.String()
+		case '\n':
+			l.errorf(pos, "unfinished string")
+		case '\\':
+			if l.off >= len(l.src) {
+				l.errorf(pos, "unfinished string")
+			}
+			e := l.next()
+			switch e {
+			case 'a':
+				b.WriteByte('\a')
+			case 'b':
+				b.WriteByte('\b')
+			case 'f':
+				b.WriteByte('\f')
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case 'v':
+				b.WriteByte('\v')
+			case 'x':
+				var v byte
+				for i := 0; i < 2 && isHexDigit(l.peekByte(0)); i++ {
+					d, _ := strconv.ParseUint(string(l.next()), 16, 8)
+					v = v*16 + byte(d)
+				}
+				b.WriteByte(v)
+			case 'z':
+				for l.off < len(l.src) && strings.IndexByte(" \t\r\n\f\v", l.peekByte(0)) >= 0 {
+					l.next()
+				}
+			default:
+				if isDigit(e) {
+					v := int(e - '0')
+					for i := 0; i < 2 && isDigit(l.peekByte(0)); i++ {
+						v = v*10 + int(l.next()-'0')
+					}
+					b.WriteByte(byte(v))
+				} else {
+					// \\, \", \', and the escaped newline
+					b.WriteByte(e)
+				}
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+}
@@ -0,0 +1,727 @@
+// Package lint checks Lua contracts for the common bugs, which the compiler
+// doesn't find.
+package lint
+
+import (
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Severities of issues
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+)
+
+// Rules of the checks
+const (
+	RuleSyntax           = "syntax"
+	RuleGlobalWrite      = "global-write"
+	RuleNondeterministic = "nondeterministic"
+	RuleUncheckedCall    = "unchecked-call"
+	RuleMissingPayable   = "missing-payable"
+	RuleUndefinedABI     = "undefined-abi"
+	RuleReentrancy       = "reentrancy"
+	RuleUnboundedLoop    = "unbounded-loop"
+)
+
+// Issue is a problem found in a contract.
+type Issue struct {
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Severity string `json:"severity"`
+	Rule     string `json:"rule"`
+	Message  string `json:"message"`
+}
+
+// the global names which are not available or not deterministic in contracts
+var nondeterministicGlobals = map[string]bool{
+	"os": true, "io": true, "debug": true, "collectgarbage": true,
+	"gcinfo": true, "newproxy": true, "load": true, "loadstring": true,
+	"loadfile": true, "dofile": true, "require": true, "module": true,
+}
+
+var nondeterministicFields = map[string]bool{
+	"math.random": true, "math.randomseed": true, "string.dump": true,
+}
+
+// the calls which run other contracts
+var externalCalls = map[string]bool{
+	"contract.call": true, "contract.delegatecall": true,
+	"contract.pcall": true, "contract.send": true,
+}
+
+var abiRegisters = map[string]bool{
+	"abi.register": true, "abi.register_view": true, "abi.payable": true,
+	"abi.fee_delegation": true,
+}
+
+// nolint comments disable all the rules or the rules listed on their lines,
+// e.g. -- nolint: global-write, reentrancy
+var nolintPattern = regexp.MustCompile(`\bnolint\b(?:\s*:\s*([\w\-, ]+))?`)
+
+// LintFile checks the contract in the file.
+func LintFile(path string) ([]*Issue, error) {
+	src, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Lint(path, string(src)), nil
+}
+
+// Lint checks the contract code, and returns the issues ordered by their
+// positions. The file is the name of the code in the issues.
+func Lint(file, src string) []*Issue {
+	block, comments, err := parse(src)
+	if err != nil {
+		syntaxErr := err.(*SyntaxError)
+		return []*Issue{{
+			File:     file,
+			Line:     syntaxErr.Line,
+			Column:   syntaxErr.Column,
+			Severity: SeverityError,
+			Rule:     RuleSyntax,
+			Message:  syntaxErr.Msg,
+		}}
+	}
+
+	l := &linter{
+		file:      file,
+		stateVars: make(map[string]string),
+		registers: make(map[string]map[string]Pos),
+		functions: make(map[string]*FunctionStmt),
+	}
+	l.declare(block)
+	l.checkABI()
+	l.pushScope()
+	l.block(block)
+	l.popScope()
+
+	issues := l.issues[:0]
+	for _, issue := range l.issues {
+		if !ignored(comments[issue.Line], issue.Rule) {
+			issues = append(issues, issue)
+		}
+	}
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Line != issues[j].Line {
+			return issues[i].Line < issues[j].Line
+		}
+		return issues[i].Column < issues[j].Column
+	})
+	return issues
+}
+
+func ignored(comment, rule string) bool {
+	m := nolintPattern.FindStringSubmatch(comment)
+	if m == nil {
+		return false
+	}
+	if strings.TrimSpace(m[1]) == "" {
+		return true
+	}
+	for _, r := range strings.Split(m[1], ",") {
+		if strings.TrimSpace(r) == rule {
+			return true
+		}
+	}
+	return false
+}
+
+type localVar struct {
+	pos    Pos
+	used   bool
+	status string // the call whose status is assigned to it
+}
+
+// function is the function being checked.
+type function struct {
+	parent   *function
+	name     string
+	external *Pos // the first call to another contract
+}
+
+type linter struct {
+	file   string
+	issues []*Issue
+
+	stateVars map[string]string         // the kinds of the state variables by their names
+	registers map[string]map[string]Pos // the functions registered by abi.register and others
+	functions map[string]*FunctionStmt  // the global functions
+
+	scopes []map[string]*localVar
+	fn     *function
+}
+
+func (l *linter) report(pos Pos, severity, rule, format string, args ...interface{}) {
+	l.issues = append(l.issues, &Issue{
+		File:     l.file,
+		Line:     pos.Line,
+		Column:   pos.Column,
+		Severity: severity,
+		Rule:     rule,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// declare finds the state variables, the global functions and the
+// registrations in the main chunk.
+func (l *linter) declare(block *Block) {
+	for _, stmt := range block.Stmts {
+		switch s := stmt.(type) {
+		case *FunctionStmt:
+			if name, ok := s.Name.(*NameExpr); ok {
+				l.functions[name.Name] = s
+			}
+		case *CallStmt:
+			call, ok := s.Call.(*CallExpr)
+			if !ok {
+				continue
+			}
+			fn := qualifiedName(call.Fn)
+			switch {
+			case fn == "state.var":
+				l.declareStateVars(call)
+			case abiRegisters[fn]:
+				if l.registers[fn] == nil {
+					l.registers[fn] = make(map[string]Pos)
+				}
+				for _, arg := range call.Args {
+					// a typed declaration is {fn, params = {...}, returns = {...}}
+					if table, ok := arg.(*TableExpr); ok && len(table.Fields) > 0 && table.Fields[0].Key == nil {
+						arg = table.Fields[0].Value
+					}
+					if name, ok := arg.(*NameExpr); ok {
+						l.registers[fn][name.Name] = name.Pos
+					}
+				}
+			}
+		}
+	}
+}
+
+func (l *linter) declareStateVars(call *CallExpr) {
+	if len(call.Args) != 1 {
+		return
+	}
+	table, ok := call.Args[0].(*TableExpr)
+	if !ok {
+		return
+	}
+	for _, field := range table.Fields {
+		key, ok := field.Key.(*StringExpr)
+		if !ok {
+			continue
+		}
+		kind := "state.value"
+		if value, ok := field.Value.(*CallExpr); ok {
+			if fn := qualifiedName(value.Fn); strings.HasPrefix(fn, "state.") {
+				kind = fn
+			}
+		}
+		l.stateVars[key.Value] = kind
+	}
+}
+
+func (l *linter) isPayable(name string) bool {
+	_, ok := l.registers["abi.payable"][name]
+	return ok
+}
+
+func (l *linter) isRegistered(name string) bool {
+	for fn, names := range l.registers {
+		if fn == "abi.fee_delegation" {
+			continue
+		}
+		if _, ok := names[name]; ok {
+			return true
+		}
+	}
+	return name == "constructor" || name == "default"
+}
+
+// checkABI checks the registered functions are defined, and the functions
+// receiving aergo are payable.
+func (l *linter) checkABI() {
+	for fn, names := range l.registers {
+		for name, pos := range names {
+			if _, ok := l.functions[name]; !ok {
+				l.report(pos, SeverityError, RuleUndefinedABI,
+					"%s: function %s is not defined in the main chunk", fn, name)
+			}
+		}
+	}
+	for name, f := range l.functions {
+		if !l.isRegistered(name) || l.isPayable(name) {
+			continue
+		}
+		if pos := findCall(f.Func.Body, "system.getAmount"); pos != nil {
+			l.report(*pos, SeverityError, RuleMissingPayable,
+				"function %s reads system.getAmount but it is not registered by abi.payable, so it can't receive aergo", name)
+		}
+	}
+}
+
+// findCall returns the position of the first call to the function in the
+// block, which includes the nested functions.
+func findCall(block *Block, fn string) *Pos {
+	var found *Pos
+	walk(block, func(n Node) bool {
+		if found != nil {
+			return false
+		}
+		if call, ok := n.(*CallExpr); ok && qualifiedName(call.Fn) == fn {
+			pos := call.Position()
+			found = &pos
+			return false
+		}
+		return true
+	})
+	return found
+}
+
+// qualifiedName returns the name of a global or a field of it, such as
+// contract.call, or an empty string for the other expressions.
+func qualifiedName(e Expr) string {
+	switch x := e.(type) {
+	case *NameExpr:
+		return x.Name
+	case *IndexExpr:
+		key, ok := x.Key.(*StringExpr)
+		if !ok {
+			return ""
+		}
+		if prefix := qualifiedName(x.X); prefix != "" {
+			return prefix + "." + key.Value
+		}
+	}
+	return ""
+}
+
+// scopes of local variables
+
+func (l *linter) pushScope() {
+	l.scopes = append(l.scopes, make(map[string]*localVar))
+}
+
+func (l *linter) popScope() {
+	scope := l.scopes[len(l.scopes)-1]
+	l.scopes = l.scopes[:len(l.scopes)-1]
+	for name, v := range scope {
+		if v.status != "" && !v.used {
+			l.report(v.pos, SeverityWarning, RuleUncheckedCall,
+				"the status of %s in %s is never checked", v.status, name)
+		}
+	}
+}
+
+func (l *linter) defineLocal(name string, pos Pos) *localVar {
+	v := &localVar{pos: pos}
+	l.scopes[len(l.scopes)-1][name] = v
+	return v
+}
+
+func (l *linter) lookup(name string) *localVar {
+	for i := len(l.scopes) - 1; i >= 0; i-- {
+		if v, ok := l.scopes[i][name]; ok {
+			return v
+		}
+	}
+	return nil
+}
+
+// stateVar returns the kind of the state variable referred by the
+// expression, or an empty string.
+func (l *linter) stateVar(e Expr) (string, string) {
+	name, ok := e.(*NameExpr)
+	if !ok || l.lookup(name.Name) != nil {
+		return "", ""
+	}
+	return name.Name, l.stateVars[name.Name]
+}
+
+// global returns the qualified name of the expression if it starts with a
+// global variable.
+func (l *linter) global(e Expr) string {
+	q := qualifiedName(e)
+	if q == "" {
+		return ""
+	}
+	if root := strings.SplitN(q, ".", 2)[0]; l.lookup(root) != nil {
+		return ""
+	}
+	return q
+}
+
+// statements
+
+func (l *linter) block(b *Block) {
+	for _, stmt := range b.Stmts {
+		l.stmt(stmt)
+	}
+}
+
+func (l *linter) scopedBlock(b *Block, locals ...string) {
+	l.pushScope()
+	for _, name := range locals {
+		v := l.defineLocal(name, Pos{})
+		v.used = true
+	}
+	l.block(b)
+	l.popScope()
+}
+
+func (l *linter) stmt(stmt Stmt) {
+	switch s := stmt.(type) {
+	case *AssignStmt:
+		for _, value := range s.Values {
+			l.expr(value)
+		}
+		for _, target := range s.Targets {
+			l.assign(target)
+		}
+	case *LocalStmt:
+		for _, value := range s.Values {
+			l.expr(value)
+		}
+		for i, name := range s.Names {
+			v := l.defineLocal(name, s.Pos)
+			if i == 0 && len(s.Values) > 0 {
+				if call, ok := s.Values[0].(*CallExpr); ok {
+					if fn := l.global(call.Fn); fn == "contract.pcall" || fn == "pcall" {
+						v.status = fn
+					}
+				}
+			}
+		}
+	case *CallStmt:
+		if call, ok := s.Call.(*CallExpr); ok {
+			switch fn := l.global(call.Fn); fn {
+			case "contract.pcall", "pcall":
+				l.report(s.Pos, SeverityWarning, RuleUncheckedCall,
+					"the status of %s is not checked, so a failure is ignored", fn)
+			case "contract.call", "contract.delegatecall":
+				l.report(s.Pos, SeverityInfo, RuleUncheckedCall,
+					"the result of %s is not used", fn)
+			}
+		}
+		l.expr(s.Call)
+	case *DoStmt:
+		l.scopedBlock(s.Body)
+	case *WhileStmt:
+		l.expr(s.Cond)
+		if name := l.readsState(s.Cond); name != "" {
+			l.report(s.Pos, SeverityWarning, RuleUnboundedLoop,
+				"the loop condition depends on the state variable %s, which can grow without bound", name)
+		}
+		l.scopedBlock(s.Body)
+	case *RepeatStmt:
+		// the condition can refer to the locals of the block
+		l.pushScope()
+		l.block(s.Body)
+		l.expr(s.Cond)
+		l.popScope()
+		if name := l.readsState(s.Cond); name != "" {
+			l.report(s.Pos, SeverityWarning, RuleUnboundedLoop,
+				"the loop condition depends on the state variable %s, which can grow without bound", name)
+		}
+	case *IfStmt:
+		for i, cond := range s.Conds {
+			l.expr(cond)
+			l.scopedBlock(s.Blocks[i])
+		}
+		if s.Else != nil {
+			l.scopedBlock(s.Else)
+		}
+	case *NumericForStmt:
+		l.expr(s.Start)
+		l.expr(s.Limit)
+		if s.Step != nil {
+			l.expr(s.Step)
+		}
+		if name := l.readsState(s.Limit); name != "" {
+			l.report(s.Pos, SeverityWarning, RuleUnboundedLoop,
+				"the loop bound depends on the state variable %s, which can grow without bound", name)
+		}
+		l.scopedBlock(s.Body, s.Name)
+	case *GenericForStmt:
+		for _, e := range s.Exprs {
+			l.expr(e)
+		}
+		l.checkIteration(s)
+		l.scopedBlock(s.Body, s.Names...)
+	case *FunctionStmt:
+		if name, ok := s.Name.(*NameExpr); ok {
+			if l.fn != nil && l.lookup(name.Name) == nil {
+				l.report(s.Pos, SeverityWarning, RuleGlobalWrite,
+					"function %s is defined as a global inside a function, which is not persisted", name.Name)
+			}
+			l.function(name.Name, s.Func)
+		} else {
+			l.expr(s.Name)
+			l.function(qualifiedName(s.Name), s.Func)
+		}
+	case *LocalFunctionStmt:
+		v := l.defineLocal(s.Name, s.Pos)
+		v.used = true
+		l.function(s.Name, s.Func)
+	case *ReturnStmt:
+		for _, value := range s.Values {
+			l.expr(value)
+		}
+	}
+}
+
+// assign checks an assignment to the target.
+func (l *linter) assign(target Expr) {
+	switch t := target.(type) {
+	case *NameExpr:
+		if v := l.lookup(t.Name); v != nil {
+			return
+		}
+		if kind, ok := l.stateVars[t.Name]; ok {
+			l.report(t.Pos, SeverityError, RuleGlobalWrite,
+				"assignment replaces the %s %s; use its methods or index it to update the state", kind, t.Name)
+			return
+		}
+		if l.fn != nil {
+			l.report(t.Pos, SeverityWarning, RuleGlobalWrite,
+				"assignment to the global %s in a function is not persisted; declare it in state.var or make it local", t.Name)
+		}
+	case *IndexExpr:
+		l.expr(t.X)
+		l.expr(t.Key)
+		if name, kind := l.stateVar(t.X); kind == "state.map" || kind == "state.array" {
+			l.stateWrite(t.Pos, name)
+		}
+	}
+}
+
+// stateWrite checks the state is not updated after calling another contract.
+func (l *linter) stateWrite(pos Pos, name string) {
+	if l.fn != nil && l.fn.external != nil {
+		l.report(pos, SeverityWarning, RuleReentrancy,
+			"the state variable %s is updated after the call to another contract at line %d, which can reenter this contract before the update",
+			name, l.fn.external.Line)
+	}
+}
+
+func (l *linter) function(name string, f *FunctionExpr) {
+	l.fn = &function{parent: l.fn, name: name}
+	l.pushScope()
+	for _, param := range f.Params {
+		v := l.defineLocal(param, f.Pos)
+		v.used = true
+	}
+	l.block(f.Body)
+	l.popScope()
+	l.fn = l.fn.parent
+}
+
+// checkIteration checks the loops over the state variables.
+func (l *linter) checkIteration(s *GenericForStmt) {
+	if len(s.Exprs) == 0 {
+		return
+	}
+	var iterated Expr
+	switch call := s.Exprs[0].(type) {
+	case *CallExpr:
+		if fn := l.global(call.Fn); (fn == "pairs" || fn == "ipairs") && len(call.Args) > 0 {
+			iterated = call.Args[0]
+		}
+	case *MethodCallExpr:
+		iterated = call.Recv
+	}
+	name, kind := l.stateVar(iterated)
+	switch kind {
+	case "state.map":
+		l.report(s.Pos, SeverityError, RuleUnboundedLoop,
+			"the state.map %s can't be iterated; keep its keys in a state.array to iterate them", name)
+	case "state.array":
+		l.report(s.Pos, SeverityWarning, RuleUnboundedLoop,
+			"the loop over the state.array %s has no bound, and its cost grows with the array", name)
+	}
+}
+
+// readsState returns the name of a state variable read by the expression.
+func (l *linter) readsState(e Expr) string {
+	var found string
+	walkExpr(e, func(n Node) bool {
+		if found != "" {
+			return false
+		}
+		var x Expr
+		switch v := n.(type) {
+		case *UnaryExpr:
+			if v.Op == "#" {
+				x = v.X
+			}
+		case *MethodCallExpr:
+			x = v.Recv
+		case *IndexExpr:
+			x = v.X
+		}
+		if name, kind := l.stateVar(x); kind != "" {
+			found = name
+			return false
+		}
+		return true
+	})
+	return found
+}
+
+// expressions
+
+func (l *linter) expr(e Expr) {
+	switch x := e.(type) {
+	case *NameExpr:
+		if v := l.lookup(x.Name); v != nil {
+			v.used = true
+		} else if nondeterministicGlobals[x.Name] {
+			l.report(x.Pos, SeverityError, RuleNondeterministic,
+				"%s is not available or not deterministic in contracts", x.Name)
+		}
+	case *IndexExpr:
+		if fn := l.global(x); nondeterministicFields[fn] {
+			l.report(x.X.Position(), SeverityError, RuleNondeterministic,
+				"%s is not available or not deterministic in contracts", fn)
+			return
+		}
+		l.expr(x.X)
+		l.expr(x.Key)
+	case *CallExpr:
+		l.expr(x.Fn)
+		for _, arg := range x.Args {
+			l.expr(arg)
+		}
+		if fn := l.global(x.Fn); externalCalls[fn] && l.fn != nil && l.fn.external == nil {
+			pos := x.Pos
+			l.fn.external = &pos
+		}
+	case *MethodCallExpr:
+		l.expr(x.Recv)
+		for _, arg := range x.Args {
+			l.expr(arg)
+		}
+		if name, kind := l.stateVar(x.Recv); kind != "" && (x.Method == "set" || x.Method == "append") {
+			l.stateWrite(x.Pos, name)
+		}
+	case *FunctionExpr:
+		l.function("", x)
+	case *TableExpr:
+		for _, field := range x.Fields {
+			if field.Key != nil {
+				l.expr(field.Key)
+			}
+			l.expr(field.Value)
+		}
+	case *BinaryExpr:
+		l.expr(x.L)
+		l.expr(x.R)
+	case *UnaryExpr:
+		l.expr(x.X)
+	case *ParenExpr:
+		l.expr(x.X)
+	}
+}
+
+// walk calls f for the nodes of the block in depth-first order, and skips
+// the children of a node when f returns false.
+func walk(b *Block, f func(Node) bool) {
+	for _, stmt := range b.Stmts {
+		walkStmt(stmt, f)
+	}
+}
+
+func walkStmt(stmt Stmt, f func(Node) bool) {
+	if !f(stmt) {
+		return
+	}
+	exprs := func(list []Expr) {
+		for _, e := range list {
+			walkExpr(e, f)
+		}
+	}
+	switch s := stmt.(type) {
+	case *AssignStmt:
+		exprs(s.Targets)
+		exprs(s.Values)
+	case *LocalStmt:
+		exprs(s.Values)
+	case *CallStmt:
+		walkExpr(s.Call, f)
+	case *DoStmt:
+		walk(s.Body, f)
+	case *WhileStmt:
+		walkExpr(s.Cond, f)
+		walk(s.Body, f)
+	case *RepeatStmt:
+		walk(s.Body, f)
+		walkExpr(s.Cond, f)
+	case *IfStmt:
+		for i, cond := range s.Conds {
+			walkExpr(cond, f)
+			walk(s.Blocks[i], f)
+		}
+		if s.Else != nil {
+			walk(s.Else, f)
+		}
+	case *NumericForStmt:
+		exprs([]Expr{s.Start, s.Limit})
+		if s.Step != nil {
+			walkExpr(s.Step, f)
+		}
+		walk(s.Body, f)
+	case *GenericForStmt:
+		exprs(s.Exprs)
+		walk(s.Body, f)
+	case *FunctionStmt:
+		walkExpr(s.Func, f)
+	case *LocalFunctionStmt:
+		walkExpr(s.Func, f)
+	case *ReturnStmt:
+		exprs(s.Values)
+	}
+}
+
+func walkExpr(e Expr, f func(Node) bool) {
+	if e == nil || !f(e) {
+		return
+	}
+	switch x := e.(type) {
+	case *FunctionExpr:
+		walk(x.Body, f)
+	case *TableExpr:
+		for _, field := range x.Fields {
+			if field.Key != nil {
+				walkExpr(field.Key, f)
+			}
+			walkExpr(field.Value, f)
+		}
+	case *BinaryExpr:
+		walkExpr(x.L, f)
+		walkExpr(x.R, f)
+	case *UnaryExpr:
+		walkExpr(x.X, f)
+	case *ParenExpr:
+		walkExpr(x.X, f)
+	case *IndexExpr:
+		walkExpr(x.X, f)
+		walkExpr(x.Key, f)
+	case *CallExpr:
+		walkExpr(x.Fn, f)
+		for _, arg := range x.Args {
+			walkExpr(arg, f)
+		}
+	case *MethodCallExpr:
+		walkExpr(x.Recv, f)
+		for _, arg := range x.Args {
+			walkExpr(arg, f)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func issueStrings(issues []*Issue) []string {
+	var s []string
+	for _, issue := range issues {
+		s = append(s, fmt.Sprintf("%d:%s:%s", issue.Line, issue.Severity, issue.Rule))
+	}
+	return s
+}
+
+func checkIssues(t *testing.T, src string, expected ...string) {
+	t.Helper()
+	actual := issueStrings(Lint("test.lua", src))
+	if strings.Join(actual, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected issues:\n%s\nbut got:\n%s", strings.Join(expected, "\n"), strings.Join(actual, "\n"))
+	}
+}
+
+func TestParse(t *testing.T) {
+	_, err := Parse(`
+local a, b = 1, 0x1fULL
+local s = [==[
+long ]] string]==] .. "\x41\065\z
+      b"
+function t.a.b:c(x, ...) return x^-2^3, #s, not x == nil, {1, [2]=2; k=3,} end
+for i = 10, 1, -1 do if i % 2 == 0 then break elseif i then goto continue else end ::continue:: end
+repeat local x = 1 until x > 0
+f{...}; f"s"; (f)()[1]:m().n = 1
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for src, msg := range map[string]string{
+		"x = ":              "1:5: unexpected symbol near <eof>",
+		"f() = 1":           "1:5: syntax error near '='",
+		"local s = 'abc":    "1:11: unfinished string",
+		"if x then\n":       "2:1: 'end' expected (to close 'if' at line 1) near <eof>",
+		"local 1 = 2":       "1:7: <name> expected near '1'",
+		"x = 3 y = 1 + + 2": "1:15: unexpected symbol near '+'",
+	} {
+		_, err := Parse(src)
+		if err == nil || err.Error() != msg {
+			t.Errorf("%q: expected %q, but got %v", src, msg, err)
+		}
+	}
+}
+
+func TestGlobalWrite(t *testing.T) {
+	checkIssues(t, `
+state.var { Count = state.value(), Names = state.map() }
+local cache = {}
+config = {}
+
+function inc(n)
+	local total = n
+	total = total + 1
+	Count = total
+	counter = total
+	cache[n] = total
+	Names[n] = "x"
+	local function helper() total = 0 end
+	function nested() end
+end
+`,
+		"9:error:global-write",
+		"10:warning:global-write",
+		"14:warning:global-write",
+	)
+}
+
+func TestNondeterministic(t *testing.T) {
+	checkIssues(t, `
+function f()
+	local t = os.time()
+	local r = math.random(10)
+	local x = math.floor(1.5)
+	local s = string.dump(f)
+	local g = loadstring("return 1")
+	local os = { time = function() return 1 end }
+	return os.time()
+end
+`,
+		"3:error:nondeterministic",
+		"4:error:nondeterministic",
+		"6:error:nondeterministic",
+		"7:error:nondeterministic",
+	)
+}
+
+func TestUncheckedCall(t *testing.T) {
+	checkIssues(t, `
+function f(addr)
+	contract.pcall(contract.call, addr, "a")
+	contract.call(addr, "b")
+	local ok = contract.pcall(contract.call, addr, "c")
+	local ok2, err = pcall(error, "d")
+	assert(ok2, err)
+	local res = contract.call(addr, "e")
+	return res
+end
+`,
+		"3:warning:unchecked-call",
+		"4:info:unchecked-call",
+		"5:warning:unchecked-call",
+	)
+}
+
+func TestPayable(t *testing.T) {
+	checkIssues(t, `
+function deposit() return system.getAmount() end
+function donate() return bignum.number(system.getAmount()) end
+function default() return system.getAmount() end
+function view() return 1 end
+abi.register(deposit, withdraw)
+abi.register_view(view)
+abi.payable(donate)
+`,
+		"2:error:missing-payable",
+		"4:error:missing-payable",
+		"6:error:undefined-abi",
+	)
+}
+
+func TestPayableTyped(t *testing.T) {
+	checkIssues(t, `
+function deposit() return system.getAmount() end
+function donate() return bignum.number(system.getAmount()) end
+function view() return 1 end
+abi.register({deposit, returns = {"bignum"}}, {withdraw, params = {"bignum"}})
+abi.register_view({view, returns = {"integer"}})
+abi.payable({donate, params = {}})
+`,
+		"2:error:missing-payable",
+		"5:error:undefined-abi",
+	)
+}
+
+func TestReentrancy(t *testing.T) {
+	checkIssues(t, `
+state.var { Balances = state.map(), Total = state.value(), Log = state.array() }
+
+function withdraw(amount)
+	local sender = system.getSender()
+	Total:set(Total:get() - amount)
+	contract.send(sender, amount)
+	Balances[sender] = Balances[sender] - amount
+	Log:append(sender)
+end
+
+function safe(amount)
+	local sender = system.getSender()
+	Balances[sender] = Balances[sender] - amount
+	contract.send(sender, amount)
+end
+`,
+		"8:warning:reentrancy",
+		"9:warning:reentrancy",
+	)
+}
+
+func TestUnboundedLoop(t *testing.T) {
+	checkIssues(t, `
+state.var { Holders = state.map(), List = state.array(), Count = state.value() }
+
+function f()
+	for k, v in pairs(Holders) do end
+	for i, v in List:ipairs() do end
+	for i = 1, #List do end
+	for i = 1, Count:get() do end
+	for i = 1, 10 do end
+	while Count:get() > 0 do end
+	local list = {}
+	for i, v in ipairs(list) do end
+end
+`,
+		"5:error:unbounded-loop",
+		"6:warning:unbounded-loop",
+		"7:warning:unbounded-loop",
+		"8:warning:unbounded-loop",
+		"10:warning:unbounded-loop",
+	)
+}
+
+func TestNolint(t *testing.T) {
+	checkIssues(t, `
+function f()
+	x = 1 -- nolint
+	y = os.time() -- nolint: global-write
+	z = 2 -- nolint: reentrancy
+end
+`,
+		"4:error:nondeterministic",
+		"5:warning:global-write",
+	)
+}
+
+func TestSyntaxIssue(t *testing.T) {
+	checkIssues(t, "function f(", "1:error:syntax")
+}
+
+func TestReport(t *testing.T) {
+	issues := Lint("a.lua", "function f() x = 1 end")
+
+	var text bytes.Buffer
+	if err := WriteText(&text, issues); err != nil {
+		t.Fatal(err)
+	}
+	expected := "a.lua:1:14: warning: assignment to the global x in a function is not persisted; declare it in state.var or make it local (global-write)\n"
+	if text.String() != expected {
+		t.Errorf("expected %q, but got %q", expected, text.String())
+	}
+
+	var out bytes.Buffer
+	if err := WriteJSON(&out, issues); err != nil {
+		t.Fatal(err)
+	}
+	var decoded []*Issue
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded) != 1 || *decoded[0] != *issues[0] {
+		t.Errorf("unexpected issues: %s", out.String())
+	}
+
+	out.Reset()
+	if err := WriteJSON(&out, nil); err != nil {
+		t.Fatal(err)
+	}
+	if strings.TrimSpace(out.String()) != "[]" {
+		t.Errorf("expected an empty array, but got %s", out.String())
+	}
+}
@@ -0,0 +1,487 @@
+package lint
+
+type parser struct {
+	lex  *lexer
+	tok  token
+	peek *token
+}
+
+// Parse parses a Lua chunk. It returns a *SyntaxError if the code is not
+// valid.
+func Parse(src string) (block *Block, err error) {
+	block, _, err = parse(src)
+	return block, err
+}
+
+func parse(src string) (block *Block, comments map[int]string, err error) {
+	p := &parser{lex: newLexer(src)}
+	defer func() {
+		if r := recover(); r != nil {
+			syntaxErr, ok := r.(*SyntaxError)
+			if !ok {
+				panic(r)
+			}
+			block, err = nil, syntaxErr
+		}
+	}()
+	p.advance()
+	block = p.block()
+	if p.tok.typ != tokEOF {
+		p.errorf("'<eof>' expected near %s", p.tok)
+	}
+	return block, p.lex.comments, nil
+}
+
+func (p *parser) errorf(format string, args ...interface{}) {
+	p.lex.errorf(p.tok.pos, format, args...)
+}
+
+func (p *parser) advance() {
+	if p.peek != nil {
+		p.tok = *p.peek
+		p.peek = nil
+		return
+	}
+	p.tok = p.lex.scan()
+}
+
+func (p *parser) lookahead() token {
+	if p.peek == nil {
+		t := p.lex.scan()
+		p.peek = &t
+	}
+	return *p.peek
+}
+
+func (p *parser) isKeyword(kw string) bool {
+	return p.tok.is(tokKeyword, kw)
+}
+
+func (p *parser) isOp(op string) bool {
+	return p.tok.is(tokOp, op)
+}
+
+func (p *parser) accept(typ tokenType, value string) bool {
+	if p.tok.is(typ, value) {
+		p.advance()
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(typ tokenType, value string) Pos {
+	pos := p.tok.pos
+	if !p.accept(typ, value) {
+		p.errorf("'%s' expected near %s", value, p.tok)
+	}
+	return pos
+}
+
+func (p *parser) expectMatch(typ tokenType, value, open string, line int) {
+	if p.accept(typ, value) {
+		return
+	}
+	if line == p.tok.pos.Line {
+		p.errorf("'%s' expected near %s", value, p.tok)
+	}
+	p.errorf("'%s' expected (to close '%s' at line %d) near %s", value, open, line, p.tok)
+}
+
+func (p *parser) name() string {
+	if p.tok.typ != tokName {
+		p.errorf("<name> expected near %s", p.tok)
+	}
+	name := p.tok.value
+	p.advance()
+	return name
+}
+
+func (p *parser) blockFollows() bool {
+	switch {
+	case p.tok.typ == tokEOF:
+		return true
+	case p.tok.typ == tokKeyword:
+		switch p.tok.value {
+		case "else", "elseif", "end", "until":
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) block() *Block {
+	b := &Block{}
+	for !p.blockFollows() {
+		if p.isKeyword("return") {
+			b.Stmts = append(b.Stmts, p.returnStmt())
+			break
+		}
+		if stmt := p.statement(); stmt != nil {
+			b.Stmts = append(b.Stmts, stmt)
+		}
+	}
+	return b
+}
+
+func (p *parser) returnStmt() Stmt {
+	s := &ReturnStmt{node: node{p.tok.pos}}
+	p.advance()
+	if !p.blockFollows() && !p.isOp(";") {
+		s.Values = p.exprList()
+	}
+	p.accept(tokOp, ";")
+	return s
+}
+
+func (p *parser) statement() Stmt {
+	pos := p.tok.pos
+	if p.tok.typ == tokKeyword {
+		switch p.tok.value {
+		case "if":
+			return p.ifStmt()
+		case "while":
+			p.advance()
+			cond := p.expr()
+			p.expect(tokKeyword, "do")
+			body := p.block()
+			p.expectMatch(tokKeyword, "end", "while", pos.Line)
+			return &WhileStmt{node{pos}, cond, body}
+		case "do":
+			p.advance()
+			body := p.block()
+			p.expectMatch(tokKeyword, "end", "do", pos.Line)
+			return &DoStmt{node{pos}, body}
+		case "for":
+			return p.forStmt()
+		case "repeat":
+			p.advance()
+			body := p.block()
+			p.expectMatch(tokKeyword, "until", "repeat", pos.Line)
+			return &RepeatStmt{node{pos}, body, p.expr()}
+		case "function":
+			return p.functionStmt()
+		case "local":
+			p.advance()
+			if p.accept(tokKeyword, "function") {
+				name := p.name()
+				return &LocalFunctionStmt{node{pos}, name, p.funcBody(pos)}
+			}
+			s := &LocalStmt{node: node{pos}}
+			s.Names = append(s.Names, p.name())
+			for p.accept(tokOp, ",") {
+				s.Names = append(s.Names, p.name())
+			}
+			if p.accept(tokOp, "=") {
+				s.Values = p.exprList()
+			}
+			return s
+		case "break":
+			p.advance()
+			return &BreakStmt{node{pos}}
+		case "goto":
+			p.advance()
+			return &GotoStmt{node{pos}, p.name()}
+		}
+	}
+	if p.accept(tokOp, ";") {
+		return nil
+	}
+	if p.accept(tokOp, "::") {
+		label := p.name()
+		p.expect(tokOp, "::")
+		return &LabelStmt{node{pos}, label}
+	}
+	return p.exprStmt()
+}
+
+func (p *parser) ifStmt() Stmt {
+	s := &IfStmt{node: node{p.tok.pos}}
+	line := p.tok.pos.Line
+	p.advance()
+	s.Conds = append(s.Conds, p.expr())
+	p.expect(tokKeyword, "then")
+	s.Blocks = append(s.Blocks, p.block())
+	for p.accept(tokKeyword, "elseif") {
+		s.Conds = append(s.Conds, p.expr())
+		p.expect(tokKeyword, "then")
+		s.Blocks = append(s.Blocks, p.block())
+	}
+	if p.accept(tokKeyword, "else") {
+		s.Else = p.block()
+	}
+	p.expectMatch(tokKeyword, "end", "if", line)
+	return s
+}
+
+func (p *parser) forStmt() Stmt {
+	pos := p.tok.pos
+	p.advance()
+	first := p.name()
+	if p.accept(tokOp, "=") {
+		s := &NumericForStmt{node: node{pos}, Name: first}
+		s.Start = p.expr()
+		p.expect(tokOp, ",")
+		s.Limit = p.expr()
+		if p.accept(tokOp, ",") {
+			s.Step = p.expr()
+		}
+		p.expect(tokKeyword, "do")
+		s.Body = p.block()
+		p.expectMatch(tokKeyword, "end", "for", pos.Line)
+		return s
+	}
+	s := &GenericForStmt{node: node{pos}, Names: []string{first}}
+	for p.accept(tokOp, ",") {
+		s.Names = append(s.Names, p.name())
+	}
+	p.expect(tokKeyword, "in")
+	s.Exprs = p.exprList()
+	p.expect(tokKeyword, "do")
+	s.Body = p.block()
+	p.expectMatch(tokKeyword, "end", "for", pos.Line)
+	return s
+}
+
+func (p *parser) functionStmt() Stmt {
+	pos := p.tok.pos
+	p.advance()
+	s := &FunctionStmt{node: node{pos}}
+	namePos := p.tok.pos
+	var name Expr = &NameExpr{node{namePos}, p.name()}
+	for p.isOp(".") {
+		p.advance()
+		keyPos := p.tok.pos
+		name = &IndexExpr{node{namePos}, name, &StringExpr{node{keyPos}, p.name()}, true}
+	}
+	if p.accept(tokOp, ":") {
+		s.Method = p.name()
+	}
+	s.Name = name
+	s.Func = p.funcBody(pos)
+	if s.Method != "" {
+		s.Func.Params = append([]string{"self"}, s.Func.Params...)
+	}
+	return s
+}
+
+func (p *parser) funcBody(pos Pos) *FunctionExpr {
+	f := &FunctionExpr{node: node{pos}}
+	p.expect(tokOp, "(")
+	if !p.isOp(")") {
+		for {
+			if p.accept(tokOp, "...") {
+				f.IsVararg = true
+				break
+			}
+			f.Params = append(f.Params, p.name())
+			if !p.accept(tokOp, ",") {
+				break
+			}
+		}
+	}
+	p.expect(tokOp, ")")
+	f.Body = p.block()
+	p.expectMatch(tokKeyword, "end", "function", pos.Line)
+	return f
+}
+
+func (p *parser) exprStmt() Stmt {
+	pos := p.tok.pos
+	e := p.suffixedExpr()
+	if p.isOp("=") || p.isOp(",") {
+		s := &AssignStmt{node: node{pos}, Targets: []Expr{e}}
+		for p.accept(tokOp, ",") {
+			s.Targets = append(s.Targets, p.suffixedExpr())
+		}
+		for _, target := range s.Targets {
+			switch target.(type) {
+			case *NameExpr, *IndexExpr:
+			default:
+				p.errorf("syntax error near %s", p.tok)
+			}
+		}
+		p.expect(tokOp, "=")
+		s.Values = p.exprList()
+		return s
+	}
+	switch e.(type) {
+	case *CallExpr, *MethodCallExpr:
+		return &CallStmt{node{pos}, e}
+	}
+	p.errorf("syntax error near %s", p.tok)
+	return nil
+}
+
+func (p *parser) exprList() []Expr {
+	list := []Expr{p.expr()}
+	for p.accept(tokOp, ",") {
+		list = append(list, p.expr())
+	}
+	return list
+}
+
+func (p *parser) primaryExpr() Expr {
+	pos := p.tok.pos
+	switch {
+	case p.tok.typ == tokName:
+		return &NameExpr{node{pos}, p.name()}
+	case p.isOp("("):
+		p.advance()
+		e := p.expr()
+		p.expectMatch(tokOp, ")", "(", pos.Line)
+		return &ParenExpr{node{pos}, e}
+	}
+	p.errorf("unexpected symbol near %s", p.tok)
+	return nil
+}
+
+func (p *parser) suffixedExpr() Expr {
+	e := p.primaryExpr()
+	for {
+		pos := p.tok.pos
+		switch {
+		case p.isOp("."):
+			p.advance()
+			keyPos := p.tok.pos
+			e = &IndexExpr{node{pos}, e, &StringExpr{node{keyPos}, p.name()}, true}
+		case p.isOp("["):
+			p.advance()
+			key := p.expr()
+			p.expect(tokOp, "]")
+			e = &IndexExpr{node{pos}, e, key, false}
+		case p.isOp(":"):
+			p.advance()
+			method := p.name()
+			e = &MethodCallExpr{node{pos}, e, method, p.callArgs()}
+		case p.isOp("(") || p.isOp("{") || p.tok.typ == tokString:
+			e = &CallExpr{node{pos}, e, p.callArgs()}
+		default:
+			return e
+		}
+	}
+}
+
+func (p *parser) callArgs() []Expr {
+	pos := p.tok.pos
+	switch {
+	case p.tok.typ == tokString:
+		s := &StringExpr{node{pos}, p.tok.value}
+		p.advance()
+		return []Expr{s}
+	case p.isOp("{"):
+		return []Expr{p.tableExpr()}
+	case p.isOp("("):
+		p.advance()
+		var args []Expr
+		if !p.isOp(")") {
+			args = p.exprList()
+		}
+		p.expectMatch(tokOp, ")", "(", pos.Line)
+		return args
+	}
+	p.errorf("function arguments expected near %s", p.tok)
+	return nil
+}
+
+func (p *parser) tableExpr() Expr {
+	pos := p.expect(tokOp, "{")
+	t := &TableExpr{node: node{pos}}
+	for !p.isOp("}") {
+		switch {
+		case p.isOp("["):
+			p.advance()
+			key := p.expr()
+			p.expect(tokOp, "]")
+			p.expect(tokOp, "=")
+			t.Fields = append(t.Fields, &TableField{key, p.expr()})
+		case p.tok.typ == tokName && p.lookahead().is(tokOp, "="):
+			key := &StringExpr{node{p.tok.pos}, p.name()}
+			p.advance()
+			t.Fields = append(t.Fields, &TableField{key, p.expr()})
+		default:
+			t.Fields = append(t.Fields, &TableField{nil, p.expr()})
+		}
+		if !p.accept(tokOp, ",") && !p.accept(tokOp, ";") {
+			break
+		}
+	}
+	p.expectMatch(tokOp, "}", "{", pos.Line)
+	return t
+}
+
+func (p *parser) simpleExpr() Expr {
+	pos := p.tok.pos
+	switch {
+	case p.tok.typ == tokNumber:
+		e := &NumberExpr{node{pos}, p.tok.value}
+		p.advance()
+		return e
+	case p.tok.typ == tokString:
+		e := &StringExpr{node{pos}, p.tok.value}
+		p.advance()
+		return e
+	case p.isKeyword("nil"):
+		p.advance()
+		return &NilExpr{node{pos}}
+	case p.isKeyword("true"):
+		p.advance()
+		return &TrueExpr{node{pos}}
+	case p.isKeyword("false"):
+		p.advance()
+		return &FalseExpr{node{pos}}
+	case p.isOp("..."):
+		p.advance()
+		return &VarargExpr{node{pos}}
+	case p.isOp("{"):
+		return p.tableExpr()
+	case p.isKeyword("function"):
+		p.advance()
+		return p.funcBody(pos)
+	}
+	return p.suffixedExpr()
+}
+
+// the left and the right priorities of the binary operators
+var binaryPriority = map[string][2]int{
+	"or": {1, 1}, "and": {2, 2},
+	"<": {3, 3}, ">": {3, 3}, "<=": {3, 3}, ">=": {3, 3}, "~=": {3, 3}, "==": {3, 3},
+	"..": {5, 4},
+	"+":  {6, 6}, "-": {6, 6},
+	"*": {7, 7}, "/": {7, 7}, "%": {7, 7},
+	"^": {10, 9},
+}
+
+const unaryPriority = 8
+
+func (p *parser) binaryOp() (string, bool) {
+	if p.tok.typ != tokOp && !p.isKeyword("and") && !p.isKeyword("or") {
+		return "", false
+	}
+	_, ok := binaryPriority[p.tok.value]
+	return p.tok.value, ok
+}
+
+func (p *parser) expr() Expr {
+	return p.subExpr(0)
+}
+
+func (p *parser) subExpr(limit int) Expr {
+	var e Expr
+	pos := p.tok.pos
+	if p.isKeyword("not") || p.isOp("-") || p.isOp("#") {
+		op := p.tok.value
+		p.advance()
+		e = &UnaryExpr{node{pos}, op, p.subExpr(unaryPriority)}
+	} else {
+		e = p.simpleExpr()
+	}
+	for {
+		op, ok := p.binaryOp()
+		if !ok || binaryPriority[op][0] <= limit {
+			return e
+		}
+		opPos := p.tok.pos
+		p.advance()
+		e = &BinaryExpr{node{opPos}, op, e, p.subExpr(binaryPriority[op][1])}
+	}
+}
@@ -0,0 +1,29 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// WriteText writes the issues in the format of file:line:column: severity:
+// message (rule), which the editors can jump to.
+func WriteText(w io.Writer, issues []*Issue) error {
+	for _, issue := range issues {
+		if _, err := fmt.Fprintf(w, "%s:%d:%d: %s: %s (%s)\n",
+			issue.File, issue.Line, issue.Column, issue.Severity, issue.Message, issue.Rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteJSON writes the issues as a JSON array.
+func WriteJSON(w io.Writer, issues []*Issue) error {
+	if issues == nil {
+		issues = []*Issue{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(issues)
+}
@@ -7,8 +7,11 @@ package main
 
 import (
 	"errors"
+	"fmt"
+	"io"
 	"os"
 
+	"github.com/aergoio/aergo/cmd/aergoluac/lint"
 	"github.com/aergoio/aergo/cmd/aergoluac/util"
 	"github.com/spf13/cobra"
 )
@@ -18,15 +21,19 @@ var (
 	abiFile string
 	payload bool
 	version bool
+	lintSrc bool
+	format  string
+	// whether the linter found issues, which makes the exit status 1
+	issuesFound bool
 )
 
 var githash = "No git hash provided"
 
 func init() {
 	rootCmd = &cobra.Command{
-		Use:   "aergoluac --payload srcfile\n  aergoluac --abi abifile srcfile bcfile",
+		Use:   "aergoluac --payload srcfile\n  aergoluac --abi abifile srcfile bcfile\n  aergoluac --lint [--format text|json] srcfile...",
 		Short: "Compile a lua contract",
-		Long:  "Compile a lua contract. This command makes a bytecode file and a ABI file or prints a payload data.",
+		Long:  "Compile a lua contract. This command makes a bytecode file and a ABI file or prints a payload data.\nWith --lint, it checks the contracts for the common bugs instead of compiling them.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var err error
 
@@ -34,6 +41,9 @@ func init() {
 				cmd.Printf("Aergoluac %s\n", githash)
 				return nil
 			}
+			if lintSrc {
+				return runLint(cmd, args)
+			}
 			if payload {
 				if len(args) == 0 {
 					err = util.DumpFromStdin()
@@ -52,10 +62,38 @@ func init() {
 	rootCmd.PersistentFlags().StringVarP(&abiFile, "abi", "a", "", "abi filename")
 	rootCmd.PersistentFlags().BoolVar(&payload, "payload", false, "print the compilation result consisting of bytecode and abi")
 	rootCmd.PersistentFlags().BoolVar(&version, "version", false, "print the version number of aergoluac")
+	rootCmd.PersistentFlags().BoolVar(&lintSrc, "lint", false, "check the contracts for the common bugs")
+	rootCmd.PersistentFlags().StringVar(&format, "format", "text", "output format of --lint: text or json")
+}
+
+func runLint(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return errors.New("1 or more arguments required: <srcfile>...")
+	}
+	var write func(w io.Writer, issues []*lint.Issue) error
+	switch format {
+	case "text":
+		write = lint.WriteText
+	case "json":
+		write = lint.WriteJSON
+	default:
+		return fmt.Errorf("unknown format: %s", format)
+	}
+
+	var issues []*lint.Issue
+	for _, path := range args {
+		found, err := lint.LintFile(path)
+		if err != nil {
+			return err
+		}
+		issues = append(issues, found...)
+	}
+	issuesFound = len(issues) > 0
+	return write(cmd.OutOrStdout(), issues)
 }
 
 func main() {
-	if err := rootCmd.Execute(); err != nil {
+	if err := rootCmd.Execute(); err != nil || issuesFound {
 		os.Exit(1)
 	}
 }
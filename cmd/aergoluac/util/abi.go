@@ -0,0 +1,76 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aergoio/aergo/types"
+)
+
+// abiTypes is the types declared by the typed abi.register and abi.event.
+type abiTypes struct {
+	Functions []struct {
+		Name    string   `json:"name"`
+		Params  []string `json:"params"`
+		Returns []string `json:"returns"`
+	} `json:"functions"`
+	Events []struct {
+		Name   string   `json:"name"`
+		Fields []string `json:"fields"`
+	} `json:"events"`
+}
+
+// addABITypes adds the declared types to the ABI generated by abi.generate.
+// The ABI is not changed if no types are declared.
+func addABITypes(rawAbi, rawTypes []byte) ([]byte, error) {
+	var decls abiTypes
+	if err := json.Unmarshal(rawTypes, &decls); err != nil {
+		return nil, err
+	}
+	if len(decls.Functions) == 0 && len(decls.Events) == 0 {
+		return rawAbi, nil
+	}
+
+	abi := new(types.ABI)
+	if err := json.Unmarshal(rawAbi, abi); err != nil {
+		return nil, err
+	}
+	for _, decl := range decls.Functions {
+		var f *types.Function
+		for _, fn := range abi.Functions {
+			if fn.Name == decl.Name {
+				f = fn
+				break
+			}
+		}
+		if f == nil {
+			return nil, fmt.Errorf("the typed function %s is not registered", decl.Name)
+		}
+		if decl.Params != nil {
+			if len(decl.Params) != len(f.Arguments) {
+				return nil, fmt.Errorf("the function %s has %d arguments, but %d types are declared",
+					decl.Name, len(f.Arguments), len(decl.Params))
+			}
+			for i, typ := range decl.Params {
+				f.Arguments[i].Type = typ
+			}
+		}
+		f.Returns = nil
+		for _, typ := range decl.Returns {
+			f.Returns = append(f.Returns, &types.FnArgument{Type: typ})
+		}
+	}
+	for _, decl := range decls.Events {
+		event := &types.EventSchema{Name: decl.Name}
+		for _, field := range decl.Fields {
+			arg := &types.FnArgument{Type: field}
+			if i := strings.IndexByte(field, ':'); i >= 0 {
+				arg.Name, arg.Type = field[:i], field[i+1:]
+			}
+			event.Arguments = append(event.Arguments, arg)
+		}
+		abi.Events = append(abi.Events, event)
+	}
+	return json.Marshal(abi)
+}
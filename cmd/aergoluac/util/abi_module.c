@@ -0,0 +1,308 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+#include <stdio.h>
+#include <string.h>
+#include <lualib.h>
+#include <lauxlib.h>
+#include <luajit.h>
+
+/* the registry table of the typed declarations, which has the arrays of
+ * the functions and the events in the declared order */
+#define ABI_TYPES "__abi_types__"
+
+static const char *base_types[] = {
+    "any", "bool", "number", "integer", "string", "address", "bignum", "table", NULL
+};
+
+/* a type is one of the base types, followed by [] for an array of it, and
+ * by ? if the value can be nil */
+static int is_valid_type(const char *type)
+{
+    size_t len = strlen(type);
+    int i;
+
+    if (len > 0 && type[len - 1] == '?')
+        len--;
+    while (len > 2 && type[len - 2] == '[' && type[len - 1] == ']')
+        len -= 2;
+    for (i = 0; base_types[i] != NULL; i++) {
+        if (strlen(base_types[i]) == len && strncmp(base_types[i], type, len) == 0)
+            return 1;
+    }
+    return 0;
+}
+
+/* check_types checks the field of the table at idx is a list of types, or
+ * of name:type for the fields of an event */
+static void check_types(lua_State *L, int idx, const char *field, const char *owner, int named)
+{
+    int i, n;
+    const char *type, *sep;
+
+    lua_getfield(L, idx, field);                    /* types */
+    if (lua_isnil(L, -1)) {
+        lua_pop(L, 1);
+        return;
+    }
+    if (!lua_istable(L, -1)) {
+        luaL_error(L, "bad " LUA_QS " of " LUA_QS ": table expected, got %s",
+                   field, owner, luaL_typename(L, -1));
+    }
+    n = lua_objlen(L, -1);
+    for (i = 1; i <= n; i++) {
+        lua_rawgeti(L, -1, i);                      /* types type */
+        if (!lua_isstring(L, -1) || lua_isnumber(L, -1)) {
+            luaL_error(L, "bad " LUA_QS " of " LUA_QS ": type string expected at %d", field, owner, i);
+        }
+        type = lua_tostring(L, -1);
+        if (named && (sep = strchr(type, ':')) != NULL) {
+            if (sep == type) {
+                luaL_error(L, "bad " LUA_QS " of " LUA_QS ": empty name at %d", field, owner, i);
+            }
+            type = sep + 1;
+        }
+        if (!is_valid_type(type)) {
+            luaL_error(L, "bad " LUA_QS " of " LUA_QS ": unknown type " LUA_QS, field, owner, type);
+        }
+        lua_pop(L, 1);                              /* types */
+    }
+    lua_pop(L, 1);
+}
+
+static void push_types_list(lua_State *L, const char *list)
+{
+    lua_getfield(L, LUA_REGISTRYINDEX, ABI_TYPES);  /* T */
+    lua_getfield(L, -1, list);                      /* T list */
+    lua_remove(L, -2);                              /* list */
+}
+
+/* global_name pushes the name of the global function at idx, or nil */
+static void global_name(lua_State *L, int idx)
+{
+    lua_pushnil(L);                                 /* nil */
+    while (lua_next(L, LUA_GLOBALSINDEX) != 0) {    /* key value */
+        if (lua_rawequal(L, -1, idx) && lua_type(L, -2) == LUA_TSTRING) {
+            lua_pop(L, 1);                          /* key */
+            return;
+        }
+        lua_pop(L, 1);                              /* key */
+    }
+    lua_pushnil(L);
+}
+
+/* declare_function records the types of a function declared by
+ * {fn, params = {...}, returns = {...}}, and replaces the declaration by fn */
+static void declare_function(lua_State *L, int idx)
+{
+    const char *name;
+
+    lua_rawgeti(L, idx, 1);                         /* fn */
+    if (!lua_isfunction(L, -1)) {
+        luaL_error(L, "bad argument #%d: function expected at the first of the table", idx);
+    }
+    global_name(L, lua_gettop(L));                  /* fn name */
+    if (lua_isnil(L, -1)) {
+        luaL_error(L, "bad argument #%d: the function is not global", idx);
+    }
+    name = lua_tostring(L, -1);
+    check_types(L, idx, "params", name, 0);
+    check_types(L, idx, "returns", name, 0);
+
+    push_types_list(L, "functions");                /* fn name list */
+    lua_createtable(L, 0, 3);                       /* fn name list decl */
+    lua_pushvalue(L, -3);
+    lua_setfield(L, -2, "name");
+    lua_getfield(L, idx, "params");
+    lua_setfield(L, -2, "params");
+    lua_getfield(L, idx, "returns");
+    lua_setfield(L, -2, "returns");
+    lua_rawseti(L, -2, lua_objlen(L, -2) + 1);      /* fn name list */
+    lua_pop(L, 2);                                  /* fn */
+    lua_replace(L, idx);
+}
+
+/* abi_register_typed calls the register function in its upvalue with the
+ * functions, after recording the types of the typed declarations */
+static int abi_register_typed(lua_State *L)
+{
+    int i, n = lua_gettop(L);
+
+    for (i = 1; i <= n; i++) {
+        if (lua_istable(L, i)) {
+            declare_function(L, i);
+        }
+    }
+    lua_pushvalue(L, lua_upvalueindex(1));
+    lua_insert(L, 1);
+    lua_call(L, n, LUA_MULTRET);
+    return lua_gettop(L);
+}
+
+/* abi.event(name, {"field:type", ...}) declares the schema of an event */
+static int abi_event(lua_State *L)
+{
+    const char *name = luaL_checkstring(L, 1);
+    int i, n;
+
+    luaL_checktype(L, 2, LUA_TTABLE);
+    lua_settop(L, 2);
+    lua_createtable(L, 0, 1);                       /* name fields decl */
+    lua_pushvalue(L, 2);
+    lua_setfield(L, -2, "fields");
+    check_types(L, 3, "fields", name, 1);
+
+    push_types_list(L, "events");                   /* name fields decl list */
+    n = lua_objlen(L, -1);
+    for (i = 1; i <= n; i++) {
+        lua_rawgeti(L, -1, i);
+        lua_getfield(L, -1, "name");
+        if (strcmp(lua_tostring(L, -1), name) == 0) {
+            luaL_error(L, "the event " LUA_QS " is already declared", name);
+        }
+        lua_pop(L, 2);
+    }
+    lua_pushvalue(L, 1);
+    lua_setfield(L, 3, "name");
+    lua_pushvalue(L, 3);
+    lua_rawseti(L, -2, n + 1);
+    return 0;
+}
+
+static void add_json_string(luaL_Buffer *b, const char *s)
+{
+    char esc[8];
+
+    luaL_addchar(b, '"');
+    for (; *s != '\0'; s++) {
+        unsigned char c = (unsigned char)*s;
+        if (c == '"' || c == '\\') {
+            luaL_addchar(b, '\\');
+            luaL_addchar(b, c);
+        } else if (c < 0x20) {
+            sprintf(esc, "\\u%04x", c);
+            luaL_addstring(b, esc);
+        } else {
+            luaL_addchar(b, c);
+        }
+    }
+    luaL_addchar(b, '"');
+}
+
+/* add_json_field adds the string field of the table at idx. The strings are
+ * held by the table, so they can be added after popped off the stack, which
+ * the buffer uses. */
+static void add_json_field(lua_State *L, luaL_Buffer *b, int idx, const char *field)
+{
+    const char *s;
+
+    lua_getfield(L, idx, field);
+    s = lua_tostring(L, -1);
+    lua_pop(L, 1);
+    add_json_string(b, s);
+}
+
+/* add_json_list adds the list field of the table at idx, which is kept in
+ * the slot next to idx while it is added */
+static void add_json_list(lua_State *L, luaL_Buffer *b, int idx, const char *field)
+{
+    const char *s;
+    int i, n;
+
+    lua_getfield(L, idx, field);
+    if (lua_isnil(L, -1)) {
+        lua_pop(L, 1);
+        luaL_addstring(b, "null");
+        return;
+    }
+    lua_replace(L, idx + 1);
+    luaL_addchar(b, '[');
+    n = lua_objlen(L, idx + 1);
+    for (i = 1; i <= n; i++) {
+        lua_rawgeti(L, idx + 1, i);
+        s = lua_tostring(L, -1);
+        lua_pop(L, 1);
+        if (i > 1)
+            luaL_addchar(b, ',');
+        add_json_string(b, s);
+    }
+    luaL_addchar(b, ']');
+}
+
+/* luac_abi_types pushes the typed declarations as a JSON string like
+ * {"functions":[{"name":"f","params":["address"],"returns":null}],
+ *  "events":[{"name":"e","fields":["from:address"]}]} */
+void luac_abi_types(lua_State *L)
+{
+    static const char *lists[] = {"functions", "events", NULL};
+    static const char *fields[][2] = {{"params", "returns"}, {"fields", NULL}};
+    luaL_Buffer b;
+    int base, i, j, k, n;
+
+    /* the slots of a list, a declaration and its list field, which are
+     * below the buffer */
+    lua_settop(L, lua_gettop(L) + 3);
+    base = lua_gettop(L) - 2;
+
+    luaL_buffinit(L, &b);
+    luaL_addchar(&b, '{');
+    for (i = 0; lists[i] != NULL; i++) {
+        push_types_list(L, lists[i]);
+        lua_replace(L, base);
+        if (i > 0)
+            luaL_addchar(&b, ',');
+        add_json_string(&b, lists[i]);
+        luaL_addstring(&b, ":[");
+        n = lua_objlen(L, base);
+        for (j = 1; j <= n; j++) {
+            lua_rawgeti(L, base, j);
+            lua_replace(L, base + 1);
+            if (j > 1)
+                luaL_addchar(&b, ',');
+            luaL_addstring(&b, "{\"name\":");
+            add_json_field(L, &b, base + 1, "name");
+            for (k = 0; k < 2 && fields[i][k] != NULL; k++) {
+                luaL_addchar(&b, ',');
+                add_json_string(&b, fields[i][k]);
+                luaL_addchar(&b, ':');
+                add_json_list(L, &b, base + 1, fields[i][k]);
+            }
+            luaL_addchar(&b, '}');
+        }
+        luaL_addchar(&b, ']');
+    }
+    luaL_addchar(&b, '}');
+    luaL_pushresult(&b);
+    lua_replace(L, base);
+    lua_settop(L, base);
+}
+
+int luac_open_abi_types(lua_State *L)
+{
+    static const char *registers[] = {"register", "register_view", "payable", NULL};
+    int i;
+
+    lua_createtable(L, 0, 2);
+    lua_newtable(L);
+    lua_setfield(L, -2, "functions");
+    lua_newtable(L);
+    lua_setfield(L, -2, "events");
+    lua_setfield(L, LUA_REGISTRYINDEX, ABI_TYPES);
+
+    lua_getglobal(L, "abi");                        /* abi */
+    for (i = 0; registers[i] != NULL; i++) {
+        lua_getfield(L, -1, registers[i]);          /* abi f */
+        if (lua_isnil(L, -1)) {
+            lua_pop(L, 1);
+            continue;
+        }
+        lua_pushcclosure(L, abi_register_typed, 1); /* abi typed_f */
+        lua_setfield(L, -2, registers[i]);          /* abi */
+    }
+    lua_pushcfunction(L, abi_event);
+    lua_setfield(L, -2, "event");
+    lua_pop(L, 1);
+    return 0;
+}
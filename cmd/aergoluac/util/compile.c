@@ -5,6 +5,7 @@
 #include <lauxlib.h>
 #include <luajit.h>
 #include "state_module.h"
+#include "abi_module.h"
 #include "_cgo_export.h"
 
 lua_State *luac_vm_newstate()
@@ -15,6 +16,7 @@ lua_State *luac_vm_newstate()
 	}
 	luaL_openlibs(L);
 	luac_open_state(L);
+	luac_open_abi_types(L);
 	return L;
 }
 
@@ -46,9 +48,11 @@ static int kpt_lua_Writer(struct lua_State *L, const void *p, size_t sz, void *u
 		if (!lua_isstring(L, -1)) { \
 		    return "empty ABI string"; \
 		} \
+		luac_abi_types(L); \
+		lua_remove(L, -3); \
     } while(0)
 
-const char *vm_compile(lua_State *L, const char *code, const char *byte, const char *abi)
+const char *vm_compile(lua_State *L, const char *code, const char *byte)
 {
 	FILE *f = NULL;
 
@@ -64,17 +68,12 @@ const char *vm_compile(lua_State *L, const char *code, const char *byte, const c
 		return lua_tostring(L, -1);
 	}
 	fclose(f);
+	return NULL;
+}
 
-	if (abi != NULL && strlen(abi) > 0) {
-		f = fopen(abi, "wb");
-		if (f == NULL) {
-		    return "cannot open a abi file";
-		}
-		GEN_ABI();
-		fwrite((char *)lua_tostring(L, -1), 1, lua_strlen(L, -1), f);
-		fclose(f);
-	}
-
+const char *vm_gen_abi(lua_State *L)
+{
+	GEN_ABI();              /* abi types */
 	return NULL;
 }
 
@@ -113,8 +112,7 @@ const char *vm_stringdump(lua_State *L)
 		return "empty bytecode";
 	}
 	lua_pushvalue(L, -2);   /* code dump code */
-    GEN_ABI();              /* code dump code abi */
-    lua_remove(L, -2);      /* code dump abi */
+    GEN_ABI();              /* code dump abi types */
 	return NULL;
 }
 
@@ -47,23 +47,31 @@ func Compile(L *C.lua_State, code string) (LuaCode, error) {
 	if errMsg := C.vm_stringdump(L); errMsg != nil {
 		return nil, errors.New(C.GoString(errMsg))
 	}
-	return dumpToBytes(L), nil
+	return dumpToBytes(L)
 }
 
 func CompileFromFile(srcFileName, outFileName, abiFileName string) error {
 	cSrcFileName := C.CString(srcFileName)
 	cOutFileName := C.CString(outFileName)
-	cAbiFileName := C.CString(abiFileName)
 	L := C.luac_vm_newstate()
 	defer C.free(unsafe.Pointer(cSrcFileName))
 	defer C.free(unsafe.Pointer(cOutFileName))
-	defer C.free(unsafe.Pointer(cAbiFileName))
 	defer C.luac_vm_close(L)
 
-	if errMsg := C.vm_compile(L, cSrcFileName, cOutFileName, cAbiFileName); errMsg != nil {
+	if errMsg := C.vm_compile(L, cSrcFileName, cOutFileName); errMsg != nil {
 		return errors.New(C.GoString(errMsg))
 	}
-	return nil
+	if len(abiFileName) == 0 {
+		return nil
+	}
+	if errMsg := C.vm_gen_abi(L); errMsg != nil {
+		return errors.New(C.GoString(errMsg))
+	}
+	abi, err := typedABI(L)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(abiFileName, abi, 0644)
 }
 
 func DumpFromFile(srcFileName string) error {
@@ -79,7 +87,11 @@ func DumpFromFile(srcFileName string) error {
 		return errors.New(C.GoString(errMsg))
 	}
 
-	fmt.Println(encoding.EncodeCode(dumpToBytes(L)))
+	code, err := dumpToBytes(L)
+	if err != nil {
+		return err
+	}
+	fmt.Println(encoding.EncodeCode(code))
 	return nil
 }
 
@@ -116,18 +128,37 @@ func DumpFromStdin() error {
 	if errMsg := C.vm_stringdump(L); errMsg != nil {
 		return errors.New(C.GoString(errMsg))
 	}
-	fmt.Println(encoding.EncodeCode(dumpToBytes(L)))
+	code, err := dumpToBytes(L)
+	if err != nil {
+		return err
+	}
+	fmt.Println(encoding.EncodeCode(code))
 	return nil
 }
 
-func dumpToBytes(L *C.lua_State) LuaCode {
+func dumpToBytes(L *C.lua_State) (LuaCode, error) {
+	var (
+		c  *C.char
+		lc C.size_t
+	)
+	c = C.lua_tolstring(L, -3, &lc)
+	abi, err := typedABI(L)
+	if err != nil {
+		return nil, err
+	}
+	return NewLuaCode(C.GoBytes(unsafe.Pointer(c), C.int(lc)), abi), nil
+}
+
+// typedABI returns the ABI with the declared types, which are on the top of
+// the stack after the ABI.
+func typedABI(L *C.lua_State) ([]byte, error) {
 	var (
-		c, a *C.char
-		lc, la C.size_t
+		a, t   *C.char
+		la, lt C.size_t
 	)
-	c = C.lua_tolstring(L, -2, &lc)
-	a = C.lua_tolstring(L, -1, &la)
-	return NewLuaCode(C.GoBytes(unsafe.Pointer(c), C.int(lc)), C.GoBytes(unsafe.Pointer(a), C.int(la)))
+	a = C.lua_tolstring(L, -2, &la)
+	t = C.lua_tolstring(L, -1, &lt)
+	return addABITypes(C.GoBytes(unsafe.Pointer(a), C.int(la)), C.GoBytes(unsafe.Pointer(t), C.int(lt)))
 }
 
 type LuaCode []byte
@@ -21,6 +21,7 @@ import (
 	"github.com/aergoio/aergo/consensus"
 	"github.com/aergoio/aergo/consensus/impl"
 	"github.com/aergoio/aergo/internal/common"
+	"github.com/aergoio/aergo/internal/metrics"
 	"github.com/aergoio/aergo/mempool"
 	"github.com/aergoio/aergo/p2p"
 	"github.com/aergoio/aergo/pkg/component"
@@ -58,6 +59,7 @@ var (
 	configFilePath string
 	enableTestmode bool
 	useTestnet     bool
+	profileBlock   uint64
 
 	verbose bool
 
@@ -73,6 +75,7 @@ func init() {
 	localFlags.SortFlags = false
 	localFlags.BoolVar(&useTestnet, "testnet", false, "use Aergo TestNet; this only affects if there's no genesis block")
 	localFlags.BoolVar(&enableTestmode, "testmode", false, "enable unsafe test mode (skips certain validations); can NOT use with --testnet")
+	localFlags.Uint64Var(&profileBlock, "profileblock", 0, "replay the block in verify mode and write the coverage and the gas profile of its contract executions to the temporary directory")
 
 	fs := rootCmd.PersistentFlags()
 	fs.StringVar(&homePath, "home", "", "path of aergo home")
@@ -95,6 +98,10 @@ func initConfig() {
 	if useTestnet {
 		cfg.UseTestnet = true
 	}
+	if profileBlock != 0 {
+		cfg.Blockchain.VerifyBlock = profileBlock
+		cfg.Blockchain.ProfileBlock = profileBlock
+	}
 	if cfg.EnableTestmode && cfg.UseTestnet {
 		fmt.Println("Turn off test mode for Aergo Public Chains")
 		os.Exit(1)
@@ -145,11 +152,29 @@ func rootRun(cmd *cobra.Command, args []string) {
 		}()
 	}
 
+	if cfg.EnableMetrics {
+		port := cfg.MetricsPort
+		if port <= 0 {
+			port = config.GetDefaultMetricsPort()
+		}
+		svrlog.Info().Msgf("Enable Metrics on: %d/metrics", port)
+		go func() {
+			mux := http.NewServeMux()
+			mux.Handle("/metrics", metrics.Handler())
+			err := http.ListenAndServe(fmt.Sprintf("0.0.0.0:%d", port), mux)
+			svrlog.Info().Err(err).Msg("Run Metrics Server")
+		}()
+	}
+
 	if cfg.EnableTestmode {
 		svrlog.Warn().Msgf("Running with unsafe test mode. Turn off test mode for production use!")
 	}
 
 	p2pkey.InitNodeInfo(&cfg.BaseConfig, cfg.P2P, githash, svrlog)
+	if err := p2pkey.InitBlockSigner(cfg.Consensus); err != nil {
+		svrlog.Error().Err(err).Msg("Failed to init the block signer.")
+		os.Exit(1)
+	}
 
 	compMng := component.NewComponentHub()
 
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/aergoio/aergo-actor/actor"
+	"github.com/aergoio/aergo-lib/log"
+	"github.com/aergoio/aergo/chain"
+	"github.com/aergoio/aergo/consensus/impl"
+	"github.com/aergoio/aergo/mempool"
+	"github.com/aergoio/aergo/message"
+	"github.com/aergoio/aergo/p2p"
+	"github.com/aergoio/aergo/p2p/p2pkey"
+	"github.com/aergoio/aergo/pkg/component"
+	"github.com/aergoio/aergo/rpc"
+	"github.com/spf13/cobra"
+)
+
+const importBlockTimeout = time.Minute
+
+var (
+	exportFrom     uint64
+	exportTo       uint64
+	exportCompress bool
+)
+
+func init() {
+	exportChain.Flags().Uint64Var(&exportFrom, "from", 1, "number of the first block to export")
+	exportChain.Flags().Uint64Var(&exportTo, "to", 0, "number of the last block to export (default: the best block)")
+	exportChain.Flags().BoolVar(&exportCompress, "compress", false, "compress the file by gzip")
+
+	rootCmd.AddCommand(exportChain, importChain)
+}
+
+var exportChain = &cobra.Command{
+	Use:   "export <file>",
+	Short: "Export the blocks and receipts of the chain to a file",
+	Long: `Export the blocks and receipts of the chain to a file.
+The server must not be running on the same data directory. A chain can be
+exported in several files by block ranges and imported in the same order.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if _, err := os.Stat(cfg.DataDir); err != nil {
+			fmt.Printf("cannot access %s (error:%s)\n", cfg.DataDir, err)
+			return
+		}
+
+		core, err := chain.NewCore(cfg.DbType, cfg.DataDir, false, 0)
+		if err != nil {
+			fmt.Printf("fail to init a blockchain core (error:%s)\n", err)
+			return
+		}
+		defer core.Close()
+
+		if core.GetGenesisInfo() == nil {
+			fmt.Printf("genesis block is not initialized in %s\n", cfg.DataDir)
+			return
+		}
+
+		f, err := os.Create(args[0])
+		if err != nil {
+			fmt.Printf("fail to create %s (error:%s)\n", args[0], err)
+			return
+		}
+		defer f.Close()
+
+		if err := core.ExportChain(f, exportFrom, exportTo, exportCompress, cfg.Hardfork); err != nil {
+			fmt.Printf("fail to export the chain (error:%s)\n", err)
+			return
+		}
+		fmt.Printf("chain is exported to %s\n", args[0])
+	},
+}
+
+var importChain = &cobra.Command{
+	Use:   "import <file>...",
+	Short: "Import the blocks of export files into the chain",
+	Long: `Import the blocks of export files into the chain.
+The blocks are validated and executed as if they were received from a peer.
+The blocks already in the chain are skipped, so an interrupted import can be
+resumed by running it again. The genesis block must be initialized in advance
+by 'aergosvr init' for a private chain.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		svrlog = log.NewLogger("asvr")
+
+		// The blocks are not produced nor propagated during the import.
+		cfg.Consensus.EnableBp = false
+		p2pkey.InitNodeInfo(&cfg.BaseConfig, cfg.P2P, githash, svrlog)
+
+		compMng := component.NewComponentHub()
+
+		chainSvc := chain.NewChainService(cfg)
+		mpoolSvc := mempool.NewMemPoolService(cfg, chainSvc)
+		rpcSvc := rpc.NewRPC(cfg, chainSvc, githash)
+		p2pSvc := p2p.NewP2P(cfg, chainSvc)
+
+		compMng.Register(chainSvc, mpoolSvc,
+			newIdleService(message.RPCSvc), newIdleService(message.P2PSvc), newIdleService(message.SyncerSvc))
+
+		if _, err := impl.New(cfg, compMng, chainSvc, p2pSvc, rpcSvc); err != nil {
+			fmt.Printf("fail to init consensus (error:%s)\n", err)
+			return
+		}
+
+		compMng.Start()
+		defer compMng.Stop()
+
+		for _, path := range args {
+			n, err := importFile(compMng, chainSvc, path)
+			if err != nil {
+				fmt.Printf("fail to import %s (error:%s)\n", path, err)
+				return
+			}
+			fmt.Printf("%d blocks are imported from %s\n", n, path)
+		}
+	},
+}
+
+func importFile(hub *component.ComponentHub, cs *chain.ChainService, path string) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	er, err := chain.NewChainExportReader(f)
+	if err != nil {
+		return 0, err
+	}
+	if !bytes.Equal(er.Header().GenesisHash, cs.GetGenesisInfo().Block().BlockHash()) {
+		return 0, fmt.Errorf("genesis block mismatch")
+	}
+
+	var imported int
+	for {
+		eb, err := er.Next()
+		if err == io.EOF {
+			return imported, nil
+		} else if err != nil {
+			return imported, err
+		}
+		block := eb.GetBlock()
+
+		best, err := cs.GetBestBlock()
+		if err != nil {
+			return imported, err
+		}
+		if block.BlockNo() <= best.BlockNo() {
+			hash, err := cs.GetHashByNo(block.BlockNo())
+			if err != nil {
+				return imported, err
+			}
+			if !bytes.Equal(hash, block.BlockHash()) {
+				return imported, fmt.Errorf("block %d conflicts with the chain", block.BlockNo())
+			}
+			continue
+		}
+
+		result, err := hub.RequestFutureResult(message.ChainSvc, &message.AddBlock{Block: block, IsSync: true}, importBlockTimeout, "import")
+		if err != nil {
+			return imported, err
+		}
+		if err = result.(*message.AddBlockRsp).Err; err != nil {
+			return imported, err
+		}
+		// an orphan block is not connected without an error
+		if best, err = cs.GetBestBlock(); err != nil {
+			return imported, err
+		} else if !bytes.Equal(best.BlockHash(), block.BlockHash()) {
+			return imported, fmt.Errorf("block %d is not connected to the chain", block.BlockNo())
+		}
+		imported++
+	}
+}
+
+// idleService stands for a service which is not run during the import and
+// discards the messages sent to it.
+type idleService struct {
+	*component.BaseComponent
+}
+
+func newIdleService(name string) *idleService {
+	svc := &idleService{}
+	svc.BaseComponent = component.NewBaseComponent(name, svc, log.NewLogger(name))
+	return svc
+}
+
+func (svc *idleService) BeforeStart() {}
+
+func (svc *idleService) AfterStart() {}
+
+func (svc *idleService) BeforeStop() {}
+
+func (svc *idleService) Statistics() *map[string]interface{} {
+	return nil
+}
+
+func (svc *idleService) Receive(context actor.Context) {}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/aergoio/aergo/chain"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(reindexEvents)
+}
+
+var reindexEvents = &cobra.Command{
+	Use:   "reindex-events",
+	Short: "Rebuild the contract event index of an existing data directory",
+	Long: `Rebuild the contract event index of an existing data directory.
+Set 'eventindex = true' in the [blockchain] section of the configuration to
+keep the index up to date while the server is running.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if _, err := os.Stat(cfg.DataDir); err != nil {
+			fmt.Printf("cannot access %s (error:%s)\n", cfg.DataDir, err)
+			return
+		}
+
+		core, err := chain.NewCore(cfg.DbType, cfg.DataDir, false, 0)
+		if err != nil {
+			fmt.Printf("fail to init a blockchain core (error:%s)\n", err)
+			return
+		}
+		defer core.Close()
+
+		if core.GetGenesisInfo() == nil {
+			fmt.Printf("genesis block is not initialized in %s\n", cfg.DataDir)
+			return
+		}
+
+		if err := core.RebuildEventIndex(cfg.Hardfork); err != nil {
+			fmt.Printf("fail to rebuild the event index (error:%s)\n", err)
+			return
+		}
+
+		if !cfg.Blockchain.EventIndex {
+			fmt.Println("warning: eventindex is disabled in the configuration. the index will be discarded at the next start")
+		}
+		fmt.Printf("event index is rebuilt in %s\n", cfg.DataDir)
+	},
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/aergoio/aergo-lib/log"
 	"github.com/aergoio/aergo/cmd/brick/context"
+	"github.com/aergoio/aergo/cmd/brick/dap"
 	"github.com/aergoio/aergo/cmd/brick/exec"
 	prompt "github.com/c-bata/go-prompt"
 )
@@ -85,6 +86,8 @@ func main() {
 		fmt.Fprintln(flag.CommandLine.Output(), "Usage:")
 		fmt.Fprintf(flag.CommandLine.Output(), "  %s [-p]\n", os.Args[0])
 		fmt.Fprintf(flag.CommandLine.Output(), "  %s [-p] [-v] [-w] <filename>\n", os.Args[0])
+		fmt.Fprintf(flag.CommandLine.Output(), "  %s [-p] dap [<address>]\n", os.Args[0])
+		fmt.Fprintf(flag.CommandLine.Output(), "  %s [-p] [-v] test [-junit <filename>] [-json <filename>] [-coverprofile <filename>] [<path>...]\n", os.Args[0])
 		flag.PrintDefaults()
 	}
 	verbose := flag.Bool("v", false, "verbose output (only batch)")
@@ -111,6 +114,23 @@ func main() {
 			prompt.OptionTitle("Aergo Brick: Dummy Virtual Machine"),
 		)
 		p.Run()
+	} else if flag.Arg(0) == "dap" {
+		// serve the debug adapter protocol for IDEs
+		address := "127.0.0.1:4711"
+		if flag.NArg() > 1 {
+			address = flag.Arg(1)
+		}
+		if err := dap.Serve(address); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			exitCode = 1
+		}
+	} else if flag.Arg(0) == "test" {
+		// run brick files as tests for CI
+		if *verbose {
+			exec.EnableVerbose()
+		}
+
+		exitCode = runTests(flag.Args()[1:])
 	} else {
 		// call batch executor
 		cmd := "batch"
@@ -126,3 +146,54 @@ func main() {
 		exitCode = exec.GetBatchErrorCount()
 	}
 }
+
+func runTests(args []string) int {
+	testFlags := flag.NewFlagSet("test", flag.ContinueOnError)
+	junit := testFlags.String("junit", "", "write a JUnit XML report to the file")
+	jsonReport := testFlags.String("json", "", "write a JSON report to the file")
+	coverProfile := testFlags.String("coverprofile", "", "write the line coverage of contracts to the file in the lcov format")
+	if err := testFlags.Parse(args); err != nil {
+		return 2
+	}
+
+	paths := testFlags.Args()
+	if len(paths) == 0 {
+		paths = []string{"./..."}
+	}
+
+	if *coverProfile != "" {
+		exec.StartCoverage()
+	}
+	suites, err := exec.RunTests(paths)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if *coverProfile != "" {
+		if err := exec.WriteCoverage(*coverProfile); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+	}
+
+	if *junit != "" {
+		if err := exec.WriteJUnitReport(*junit, suites); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+	}
+	if *jsonReport != "" {
+		if err := exec.WriteJSONReport(*jsonReport, suites); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+	}
+
+	for _, suite := range suites {
+		if suite.FailedCases() > 0 {
+			return 1
+		}
+	}
+	return 0
+}
@@ -51,6 +51,8 @@ func Close() {
 }
 
 func Reset() {
+	// release the previous chain not to leave its databases
+	Close()
 	Open(privateNet)
 }
 
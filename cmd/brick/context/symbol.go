@@ -11,6 +11,10 @@ var (
 	ExpectedErrSymbol  = "<expected_err>"
 	FunctionSymbol     = "<function>"
 	CommandSymbol      = "[command]"
+	AssertionSymbol    = "<assertion>"
+	SnapshotSymbol     = "<snapshot>"
+	CaseSymbol         = "<case>"
+	ProfileSymbol      = "<profile>"
 )
 
 // reprenestation and description map of all symbols
@@ -25,4 +29,8 @@ func init() {
 	Symbols[ExpectedSymbol] = "expected result"
 	Symbols[ExpectedErrSymbol] = "expected error"
 	Symbols[FunctionSymbol] = "smart contract function name"
+	Symbols[AssertionSymbol] = "kind of assertion"
+	Symbols[SnapshotSymbol] = "name of a chain snapshot"
+	Symbols[CaseSymbol] = "name of a test case"
+	Symbols[ProfileSymbol] = "profiling action"
 }
@@ -0,0 +1,169 @@
+// Package dap serves the Debug Adapter Protocol for the debugger of brick,
+// which lets IDEs debug contracts run by brick files.
+package dap
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"sync"
+)
+
+// message is a request from the client. The responses and the events are
+// sent to it as response and event.
+type message struct {
+	Seq       int             `json:"seq"`
+	Type      string          `json:"type"`
+	Command   string          `json:"command"`
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+}
+
+type response struct {
+	Seq        int         `json:"seq"`
+	Type       string      `json:"type"`
+	RequestSeq int         `json:"request_seq"`
+	Success    bool        `json:"success"`
+	Command    string      `json:"command"`
+	Message    string      `json:"message,omitempty"`
+	Body       interface{} `json:"body,omitempty"`
+}
+
+type event struct {
+	Seq   int         `json:"seq"`
+	Type  string      `json:"type"`
+	Event string      `json:"event"`
+	Body  interface{} `json:"body,omitempty"`
+}
+
+// conn reads and writes the messages, which have the header of the content
+// length followed by the content in JSON.
+type conn struct {
+	r *textproto.Reader
+
+	mu  sync.Mutex
+	w   io.Writer
+	seq int
+}
+
+func newConn(rw io.ReadWriter) *conn {
+	return &conn{
+		r: textproto.NewReader(bufio.NewReader(rw)),
+		w: rw,
+	}
+}
+
+func (c *conn) read() (*message, error) {
+	header, err := c.r.ReadMIMEHeader()
+	if err != nil {
+		return nil, err
+	}
+	length, err := strconv.Atoi(header.Get("Content-Length"))
+	if err != nil || length <= 0 {
+		return nil, fmt.Errorf("invalid content length: %s", header.Get("Content-Length"))
+	}
+	content := make([]byte, length)
+	if _, err = io.ReadFull(c.r.R, content); err != nil {
+		return nil, err
+	}
+	var msg message
+	if err = json.Unmarshal(content, &msg); err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
+
+func (c *conn) write(v interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.seq++
+	switch msg := v.(type) {
+	case *response:
+		msg.Seq, msg.Type = c.seq, "response"
+	case *event:
+		msg.Seq, msg.Type = c.seq, "event"
+	}
+	content, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if _, err = fmt.Fprintf(c.w, "Content-Length: %d\r\n\r\n", len(content)); err != nil {
+		return err
+	}
+	_, err = c.w.Write(content)
+	return err
+}
+
+func (c *conn) respond(req *message, body interface{}, err error) error {
+	resp := &response{
+		RequestSeq: req.Seq,
+		Success:    err == nil,
+		Command:    req.Command,
+		Body:       body,
+	}
+	if err != nil {
+		resp.Message = err.Error()
+		resp.Body = nil
+	}
+	return c.write(resp)
+}
+
+func (c *conn) event(name string, body interface{}) error {
+	return c.write(&event{Event: name, Body: body})
+}
+
+// the arguments and the bodies of the messages
+
+type launchArguments struct {
+	Program     string `json:"program"`
+	StopOnEntry bool   `json:"stopOnEntry"`
+}
+
+type source struct {
+	Name string `json:"name,omitempty"`
+	Path string `json:"path,omitempty"`
+}
+
+type sourceBreakpoint struct {
+	Line uint64 `json:"line"`
+}
+
+type setBreakpointsArguments struct {
+	Source      source             `json:"source"`
+	Breakpoints []sourceBreakpoint `json:"breakpoints"`
+	Lines       []uint64           `json:"lines"`
+}
+
+type breakpoint struct {
+	Verified bool   `json:"verified"`
+	Line     uint64 `json:"line"`
+}
+
+type stackFrame struct {
+	ID     int     `json:"id"`
+	Name   string  `json:"name"`
+	Source *source `json:"source,omitempty"`
+	Line   uint64  `json:"line"`
+	Column int     `json:"column"`
+}
+
+type scope struct {
+	Name               string `json:"name"`
+	VariablesReference int    `json:"variablesReference"`
+	Expensive          bool   `json:"expensive"`
+}
+
+type variable struct {
+	Name               string `json:"name"`
+	Value              string `json:"value"`
+	Type               string `json:"type,omitempty"`
+	VariablesReference int    `json:"variablesReference"`
+}
+
+type thread struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
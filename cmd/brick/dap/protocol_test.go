@@ -0,0 +1,160 @@
+package dap
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"testing"
+)
+
+// testMessage is any message sent by the server.
+type testMessage struct {
+	Seq        int             `json:"seq"`
+	Type       string          `json:"type"`
+	RequestSeq int             `json:"request_seq"`
+	Success    bool            `json:"success"`
+	Command    string          `json:"command"`
+	Message    string          `json:"message"`
+	Event      string          `json:"event"`
+	Body       json.RawMessage `json:"body"`
+}
+
+func writeTestMessage(w io.Writer, v interface{}) error {
+	content, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "Content-Length: %d\r\n\r\n%s", len(content), content)
+	return err
+}
+
+func readTestMessage(r *textproto.Reader) (*testMessage, error) {
+	header, err := r.ReadMIMEHeader()
+	if err != nil {
+		return nil, err
+	}
+	length, err := strconv.Atoi(header.Get("Content-Length"))
+	if err != nil {
+		return nil, err
+	}
+	content := make([]byte, length)
+	if _, err = io.ReadFull(r.R, content); err != nil {
+		return nil, err
+	}
+	var msg testMessage
+	if err = json.Unmarshal(content, &msg); err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
+
+type testReadWriter struct {
+	io.Reader
+	io.Writer
+}
+
+func TestConnRead(t *testing.T) {
+	var in bytes.Buffer
+	writeTestMessage(&in, map[string]interface{}{
+		"seq":       1,
+		"type":      "request",
+		"command":   "setBreakpoints",
+		"arguments": map[string]interface{}{"lines": []int{3, 5}},
+	})
+	// the other header fields are ignored
+	in.WriteString("Content-Type: application/vscode-jsonrpc; charset=utf-8\r\n")
+	writeTestMessage(&in, map[string]interface{}{"seq": 2, "type": "request", "command": "threads"})
+
+	c := newConn(testReadWriter{&in, &bytes.Buffer{}})
+	msg, err := c.read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Seq != 1 || msg.Type != "request" || msg.Command != "setBreakpoints" {
+		t.Errorf("unexpected message: %+v", msg)
+	}
+	var args setBreakpointsArguments
+	if err = json.Unmarshal(msg.Arguments, &args); err != nil {
+		t.Fatal(err)
+	}
+	if len(args.Lines) != 2 || args.Lines[0] != 3 || args.Lines[1] != 5 {
+		t.Errorf("unexpected arguments: %s", msg.Arguments)
+	}
+
+	msg, err = c.read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Seq != 2 || msg.Command != "threads" || msg.Arguments != nil {
+		t.Errorf("unexpected message: %+v", msg)
+	}
+
+	if _, err = c.read(); err != io.EOF {
+		t.Errorf("expected %v, but got %v", io.EOF, err)
+	}
+}
+
+func TestConnReadInvalid(t *testing.T) {
+	for _, input := range []string{
+		"Content-Length: x\r\n\r\n{}",
+		"Content-Length: 0\r\n\r\n",
+		"Content-Type: application/json\r\n\r\n{}",
+		"Content-Length: 10\r\n\r\n{}",
+		"Content-Length: 2\r\n\r\n[]",
+	} {
+		c := newConn(testReadWriter{bytes.NewBufferString(input), &bytes.Buffer{}})
+		if _, err := c.read(); err == nil {
+			t.Errorf("expected an error of %q", input)
+		}
+	}
+}
+
+func TestConnWrite(t *testing.T) {
+	var out bytes.Buffer
+	c := newConn(testReadWriter{&bytes.Buffer{}, &out})
+
+	req := &message{Seq: 7, Type: "request", Command: "threads"}
+	if err := c.respond(req, map[string]interface{}{"threads": []thread{{ID: 1, Name: "brick"}}}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.event("stopped", map[string]interface{}{"reason": "breakpoint"}); err != nil {
+		t.Fatal(err)
+	}
+	req = &message{Seq: 8, Type: "request", Command: "continue"}
+	failure := errors.New("the program is not paused")
+	if err := c.respond(req, map[string]interface{}{"allThreadsContinued": true}, failure); err != nil {
+		t.Fatal(err)
+	}
+
+	r := textproto.NewReader(bufio.NewReader(&out))
+	msg, err := readTestMessage(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Seq != 1 || msg.Type != "response" || msg.RequestSeq != 7 || !msg.Success || msg.Command != "threads" ||
+		string(msg.Body) != `{"threads":[{"id":1,"name":"brick"}]}` {
+		t.Errorf("unexpected response: %+v", msg)
+	}
+
+	msg, err = readTestMessage(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Seq != 2 || msg.Type != "event" || msg.Event != "stopped" || string(msg.Body) != `{"reason":"breakpoint"}` {
+		t.Errorf("unexpected event: %+v", msg)
+	}
+
+	// the body of a failed request is not sent
+	msg, err = readTestMessage(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Seq != 3 || msg.RequestSeq != 8 || msg.Success || msg.Message != failure.Error() || msg.Body != nil {
+		t.Errorf("unexpected response: %+v", msg)
+	}
+}
@@ -0,0 +1,11 @@
+// +build !Debug
+
+package dap
+
+import "errors"
+
+// Serve serves the clients in the debug mode only, in which the contracts
+// can be debugged.
+func Serve(address string) error {
+	return errors.New("the debug adapter needs brick built in the debug mode")
+}
@@ -0,0 +1,422 @@
+// +build Debug
+
+package dap
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"path/filepath"
+	"sync"
+
+	"github.com/aergoio/aergo-lib/log"
+	"github.com/aergoio/aergo/cmd/brick/exec"
+	"github.com/aergoio/aergo/contract"
+)
+
+var logger = log.NewLogger("brick")
+
+// the program of brick runs as the only thread
+const threadID = 1
+
+var errNotPaused = errors.New("the program is not paused")
+
+// Serve listens on the address, and serves the clients one by one. Each
+// client launches a brick file on a new chain, and debugs the contracts.
+func Serve(address string) error {
+	l, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+	defer l.Close()
+
+	logger.Info().Str("address", l.Addr().String()).Msg("wait for a debug adapter client")
+	for {
+		nc, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		logger.Info().Str("client", nc.RemoteAddr().String()).Msg("start a debug session")
+		s := newSession(nc)
+		if err := s.serve(); err != nil && err != io.EOF {
+			logger.Error().Err(err).Msg("debug session failed")
+		}
+		nc.Close()
+		s.wait()
+		logger.Info().Msg("end the debug session")
+	}
+}
+
+type session struct {
+	conn *conn
+
+	launch     *launchArguments
+	configured bool
+	done       chan struct{} // closed when the program ends
+
+	mu       sync.Mutex
+	stopped  bool
+	inspects chan func(in *contract.DebugInspector)
+	resumes  chan contract.DebugResume
+}
+
+func newSession(rw io.ReadWriter) *session {
+	return &session{
+		conn:     newConn(rw),
+		inspects: make(chan func(in *contract.DebugInspector)),
+		resumes:  make(chan contract.DebugResume),
+	}
+}
+
+func (s *session) serve() error {
+	defer s.detach()
+
+	for {
+		req, err := s.conn.read()
+		if err != nil {
+			return err
+		}
+		if req.Type != "request" {
+			continue
+		}
+		body, err := s.handle(req)
+		if err = s.conn.respond(req, body, err); err != nil {
+			return err
+		}
+		if err = s.after(req); err != nil {
+			return err
+		}
+		if req.Command == "disconnect" || req.Command == "terminate" {
+			return nil
+		}
+	}
+}
+
+// handle handles a request, and returns the body of the response.
+func (s *session) handle(req *message) (interface{}, error) {
+	switch req.Command {
+	case "initialize":
+		return map[string]interface{}{
+			"supportsConfigurationDoneRequest": true,
+			"supportsEvaluateForHovers":        true,
+			"supportsTerminateRequest":         true,
+		}, nil
+
+	case "launch":
+		var args launchArguments
+		if err := json.Unmarshal(req.Arguments, &args); err != nil {
+			return nil, err
+		}
+		if args.Program == "" {
+			return nil, errors.New("no program to launch")
+		}
+		s.launch = &args
+		return nil, nil
+
+	case "configurationDone":
+		s.configured = true
+		return nil, nil
+
+	case "setBreakpoints":
+		var args setBreakpointsArguments
+		if err := json.Unmarshal(req.Arguments, &args); err != nil {
+			return nil, err
+		}
+		lines := args.Lines
+		if args.Breakpoints != nil {
+			lines = nil
+			for _, bp := range args.Breakpoints {
+				lines = append(lines, bp.Line)
+			}
+		}
+		contract.SetSourceBreakPoints(args.Source.Path, lines)
+		breakpoints := make([]breakpoint, len(lines))
+		for i, line := range lines {
+			breakpoints[i] = breakpoint{Verified: true, Line: line}
+		}
+		return map[string]interface{}{"breakpoints": breakpoints}, nil
+
+	case "setExceptionBreakpoints":
+		return nil, nil
+
+	case "threads":
+		return map[string]interface{}{
+			"threads": []thread{{ID: threadID, Name: "brick"}},
+		}, nil
+
+	case "stackTrace":
+		return s.stackTrace()
+
+	case "scopes":
+		var args struct {
+			FrameID int `json:"frameId"`
+		}
+		if err := json.Unmarshal(req.Arguments, &args); err != nil {
+			return nil, err
+		}
+		return s.scopes(args.FrameID)
+
+	case "variables":
+		var args struct {
+			VariablesReference int `json:"variablesReference"`
+		}
+		if err := json.Unmarshal(req.Arguments, &args); err != nil {
+			return nil, err
+		}
+		return s.variables(args.VariablesReference)
+
+	case "evaluate":
+		var args struct {
+			Expression string `json:"expression"`
+			FrameID    int    `json:"frameId"`
+		}
+		if err := json.Unmarshal(req.Arguments, &args); err != nil {
+			return nil, err
+		}
+		return s.evaluate(args.FrameID, args.Expression)
+
+	case "continue", "next", "stepIn", "stepOut":
+		if !s.isStopped() {
+			return nil, errNotPaused
+		}
+		if req.Command == "continue" {
+			return map[string]interface{}{"allThreadsContinued": true}, nil
+		}
+		return nil, nil
+
+	case "pause":
+		contract.PauseDebug("pause")
+		return nil, nil
+
+	case "disconnect", "terminate":
+		return nil, nil
+	}
+
+	return nil, errors.New("unsupported request: " + req.Command)
+}
+
+// after acts on a request after it is responded, so that the events caused
+// by it follow the response.
+func (s *session) after(req *message) error {
+	switch req.Command {
+	case "initialize":
+		return s.conn.event("initialized", nil)
+	case "launch", "configurationDone":
+		if s.launch != nil && s.configured && s.done == nil {
+			s.run()
+		}
+	case "continue":
+		s.resume(contract.DebugContinue)
+	case "next":
+		s.resume(contract.DebugStepOver)
+	case "stepIn":
+		s.resume(contract.DebugStepIn)
+	case "stepOut":
+		s.resume(contract.DebugStepOut)
+	}
+	return nil
+}
+
+// run runs the program on a new chain in another goroutine, which calls
+// Stopped when a contract is paused.
+func (s *session) run() {
+	s.done = make(chan struct{})
+	contract.SetDebugRemote(s)
+	if s.launch.StopOnEntry {
+		contract.PauseDebug("entry")
+	}
+	program, _ := filepath.Abs(s.launch.Program)
+
+	go func() {
+		defer close(s.done)
+
+		exec.Execute("reset", "")
+		errs := exec.GetBatchErrorCount()
+		exec.Execute("batch", program)
+		exitCode := exec.GetBatchErrorCount() - errs
+
+		contract.SetDebugRemote(nil)
+		_ = s.conn.event("exited", map[string]interface{}{"exitCode": exitCode})
+		_ = s.conn.event("terminated", nil)
+	}()
+}
+
+// wait waits for the program to end.
+func (s *session) wait() {
+	if s.done != nil {
+		<-s.done
+	}
+}
+
+// detach lets the program run to the end without the debugger.
+func (s *session) detach() {
+	contract.SetDebugRemote(nil)
+	s.resume(contract.DebugDetach)
+}
+
+func (s *session) isStopped() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stopped
+}
+
+func (s *session) setStopped(stopped bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stopped = stopped
+}
+
+func (s *session) resume(r contract.DebugResume) {
+	if s.isStopped() {
+		s.resumes <- r
+	}
+}
+
+// Stopped reports the stop to the client, and inspects the contracts for
+// it until it resumes the program.
+func (s *session) Stopped(stop *contract.DebugStop, in *contract.DebugInspector) contract.DebugResume {
+	s.setStopped(true)
+	defer s.setStopped(false)
+
+	body := map[string]interface{}{
+		"reason":            stop.Reason,
+		"threadId":          threadID,
+		"allThreadsStopped": true,
+	}
+	switch stop.Reason {
+	case "watch":
+		body["reason"] = "data breakpoint"
+		body["description"] = "watch expression: " + stop.Watch
+	case "breakpoint":
+		body["description"] = "breakpoint at " + stop.Contract
+	}
+	if err := s.conn.event("stopped", body); err != nil {
+		return contract.DebugDetach
+	}
+
+	for {
+		select {
+		case f := <-s.inspects:
+			f(in)
+		case r := <-s.resumes:
+			return r
+		}
+	}
+}
+
+// inspect runs f by the goroutine running the paused program.
+func (s *session) inspect(f func(in *contract.DebugInspector)) error {
+	if !s.isStopped() {
+		return errNotPaused
+	}
+	done := make(chan struct{})
+	s.inspects <- func(in *contract.DebugInspector) {
+		defer close(done)
+		f(in)
+	}
+	<-done
+	return nil
+}
+
+func (s *session) stackTrace() (interface{}, error) {
+	var frames []*contract.DebugFrame
+	var err error
+	if ierr := s.inspect(func(in *contract.DebugInspector) {
+		frames, err = in.Frames()
+	}); ierr != nil {
+		return nil, ierr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	stackFrames := make([]stackFrame, len(frames))
+	for i, frame := range frames {
+		stackFrames[i] = stackFrame{
+			ID:     frame.ID,
+			Name:   frame.Name,
+			Line:   frame.Line,
+			Column: 1,
+		}
+		if frame.Source != "" {
+			stackFrames[i].Source = &source{
+				Name: filepath.Base(frame.Source),
+				Path: filepath.FromSlash(frame.Source),
+			}
+		} else {
+			stackFrames[i].Name = frame.Name + " (" + frame.Contract + ")"
+		}
+	}
+	return map[string]interface{}{
+		"stackFrames": stackFrames,
+		"totalFrames": len(stackFrames),
+	}, nil
+}
+
+func (s *session) scopes(frameID int) (interface{}, error) {
+	var scopes []*contract.DebugScope
+	var err error
+	if ierr := s.inspect(func(in *contract.DebugInspector) {
+		scopes, err = in.Scopes(frameID)
+	}); ierr != nil {
+		return nil, ierr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]scope, len(scopes))
+	for i, sc := range scopes {
+		result[i] = scope{
+			Name:               sc.Name,
+			VariablesReference: sc.Ref,
+			// the state variables are read from the state db
+			Expensive: sc.Name == "State",
+		}
+	}
+	return map[string]interface{}{"scopes": result}, nil
+}
+
+func (s *session) variables(ref int) (interface{}, error) {
+	var vars []*contract.DebugVariable
+	var err error
+	if ierr := s.inspect(func(in *contract.DebugInspector) {
+		vars, err = in.Variables(ref)
+	}); ierr != nil {
+		return nil, ierr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]variable, len(vars))
+	for i, v := range vars {
+		result[i] = variable{
+			Name:               v.Name,
+			Value:              v.Value,
+			Type:               v.Type,
+			VariablesReference: v.Ref,
+		}
+	}
+	return map[string]interface{}{"variables": result}, nil
+}
+
+func (s *session) evaluate(frameID int, exp string) (interface{}, error) {
+	var v *contract.DebugVariable
+	var err error
+	if ierr := s.inspect(func(in *contract.DebugInspector) {
+		v, err = in.Evaluate(frameID, exp)
+	}); ierr != nil {
+		return nil, ierr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"result":             v.Value,
+		"type":               v.Type,
+		"variablesReference": v.Ref,
+	}, nil
+}
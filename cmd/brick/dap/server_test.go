@@ -0,0 +1,254 @@
+// +build Debug
+
+package dap
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aergoio/aergo/cmd/brick/context"
+)
+
+// testClient is a client of a session served over a pipe.
+type testClient struct {
+	t    *testing.T
+	conn net.Conn
+	seq  int
+	msgs chan *testMessage
+}
+
+func newTestClient(t *testing.T) (*testClient, *session, chan error) {
+	client, server := net.Pipe()
+	s := newSession(server)
+	served := make(chan error, 1)
+	go func() {
+		served <- s.serve()
+		server.Close()
+	}()
+
+	c := &testClient{t: t, conn: client, msgs: make(chan *testMessage, 16)}
+	go func() {
+		defer close(c.msgs)
+		r := textproto.NewReader(bufio.NewReader(client))
+		for {
+			msg, err := readTestMessage(r)
+			if err != nil {
+				return
+			}
+			c.msgs <- msg
+		}
+	}()
+	return c, s, served
+}
+
+func (c *testClient) request(command string, args interface{}) {
+	c.t.Helper()
+	c.seq++
+	req := map[string]interface{}{"seq": c.seq, "type": "request", "command": command}
+	if args != nil {
+		req["arguments"] = args
+	}
+	if err := writeTestMessage(c.conn, req); err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+func (c *testClient) next() *testMessage {
+	c.t.Helper()
+	select {
+	case msg, ok := <-c.msgs:
+		if !ok {
+			c.t.Fatal("the session is closed")
+		}
+		return msg
+	case <-time.After(30 * time.Second):
+		c.t.Fatal("no message from the session")
+	}
+	return nil
+}
+
+// response returns the response of the last request, whose body is decoded
+// to body.
+func (c *testClient) response(command string, body interface{}) *testMessage {
+	c.t.Helper()
+	msg := c.next()
+	if msg.Type != "response" || msg.RequestSeq != c.seq || msg.Command != command {
+		c.t.Fatalf("expected the response of %s, but got %+v", command, msg)
+	}
+	if body != nil && msg.Success {
+		if err := json.Unmarshal(msg.Body, body); err != nil {
+			c.t.Fatal(err)
+		}
+	}
+	return msg
+}
+
+func (c *testClient) event(name string, body interface{}) {
+	c.t.Helper()
+	msg := c.next()
+	if msg.Type != "event" || msg.Event != name {
+		c.t.Fatalf("expected the event %s, but got %+v", name, msg)
+	}
+	if body != nil {
+		if err := json.Unmarshal(msg.Body, body); err != nil {
+			c.t.Fatal(err)
+		}
+	}
+}
+
+func TestSessionProtocol(t *testing.T) {
+	context.Open(false)
+	defer context.Close()
+
+	dir, err := ioutil.TempDir("", "dap")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	source, err := filepath.Abs("../example/hello.lua")
+	if err != nil {
+		t.Fatal(err)
+	}
+	program := filepath.Join(dir, "hello.brick")
+	err = ioutil.WriteFile(program, []byte("inject bj 10000000000\n"+
+		"deploy bj 0 helloctr `"+source+"`\n"+
+		"call bj 0 helloctr set_name `[\"aergo\"]`\n"+
+		"query helloctr hello `[]` `\"hello aergo\"`\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, s, served := newTestClient(t)
+
+	var capabilities map[string]bool
+	c.request("initialize", map[string]interface{}{"adapterID": "brick"})
+	if !c.response("initialize", &capabilities).Success || !capabilities["supportsConfigurationDoneRequest"] {
+		t.Errorf("unexpected capabilities: %v", capabilities)
+	}
+	c.event("initialized", nil)
+
+	// the line of Name:set(name) in set_name
+	var breakpoints struct {
+		Breakpoints []breakpoint `json:"breakpoints"`
+	}
+	c.request("setBreakpoints", map[string]interface{}{
+		"source":      map[string]interface{}{"path": source},
+		"breakpoints": []map[string]interface{}{{"line": 17}},
+	})
+	c.response("setBreakpoints", &breakpoints)
+	if len(breakpoints.Breakpoints) != 1 || breakpoints.Breakpoints[0] != (breakpoint{Verified: true, Line: 17}) {
+		t.Errorf("unexpected breakpoints: %+v", breakpoints)
+	}
+
+	c.request("launch", map[string]interface{}{})
+	if c.response("launch", nil).Success {
+		t.Error("expected a failure of launch without a program")
+	}
+	c.request("launch", map[string]interface{}{"program": program})
+	c.response("launch", nil)
+
+	// the program is not run until configured, so it can't be resumed
+	c.request("continue", map[string]interface{}{"threadId": threadID})
+	if msg := c.response("continue", nil); msg.Success || msg.Message != errNotPaused.Error() {
+		t.Errorf("unexpected response: %+v", msg)
+	}
+
+	c.request("configurationDone", nil)
+	c.response("configurationDone", nil)
+
+	var stopped map[string]interface{}
+	c.event("stopped", &stopped)
+	if stopped["reason"] != "breakpoint" || stopped["threadId"] != float64(threadID) {
+		t.Errorf("unexpected stop: %v", stopped)
+	}
+
+	var trace struct {
+		StackFrames []stackFrame `json:"stackFrames"`
+	}
+	c.request("stackTrace", map[string]interface{}{"threadId": threadID})
+	c.response("stackTrace", &trace)
+	if len(trace.StackFrames) == 0 {
+		t.Fatal("no stack frame")
+	}
+	top := trace.StackFrames[0]
+	if top.Line != 17 || top.Source == nil || top.Source.Path != source {
+		t.Errorf("unexpected frame: %+v", top)
+	}
+
+	var scopes struct {
+		Scopes []scope `json:"scopes"`
+	}
+	c.request("scopes", map[string]interface{}{"frameId": top.ID})
+	c.response("scopes", &scopes)
+	if len(scopes.Scopes) != 3 || scopes.Scopes[0].Name != "Locals" || !scopes.Scopes[2].Expensive {
+		t.Fatalf("unexpected scopes: %+v", scopes)
+	}
+
+	var variables struct {
+		Variables []variable `json:"variables"`
+	}
+	c.request("variables", map[string]interface{}{"variablesReference": scopes.Scopes[0].VariablesReference})
+	c.response("variables", &variables)
+	found := false
+	for _, v := range variables.Variables {
+		if v.Name == "name" {
+			found = true
+			if v.Value != `"aergo"` || v.Type != "string" || v.VariablesReference != 0 {
+				t.Errorf("unexpected variable: %+v", v)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("no local variable of name: %+v", variables)
+	}
+
+	var result map[string]interface{}
+	c.request("evaluate", map[string]interface{}{"expression": "name .. '!'", "frameId": top.ID})
+	c.response("evaluate", &result)
+	if result["result"] != `"aergo!"` {
+		t.Errorf("unexpected result: %v", result)
+	}
+
+	c.request("variables", map[string]interface{}{"variablesReference": 1000})
+	if c.response("variables", nil).Success {
+		t.Error("expected a failure of an invalid reference")
+	}
+
+	var continued map[string]bool
+	c.request("continue", map[string]interface{}{"threadId": threadID})
+	c.response("continue", &continued)
+	if !continued["allThreadsContinued"] {
+		t.Errorf("unexpected response: %v", continued)
+	}
+
+	var exited struct {
+		ExitCode int `json:"exitCode"`
+	}
+	c.event("exited", &exited)
+	if exited.ExitCode != 0 {
+		t.Errorf("expected the exit code 0, but got %d", exited.ExitCode)
+	}
+	c.event("terminated", nil)
+
+	// inspecting the program after its end fails
+	c.request("stackTrace", map[string]interface{}{"threadId": threadID})
+	if c.response("stackTrace", nil).Success {
+		t.Error("expected a failure of the ended program")
+	}
+
+	c.request("disconnect", nil)
+	c.response("disconnect", nil)
+	if err = <-served; err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	s.wait()
+	c.conn.Close()
+}
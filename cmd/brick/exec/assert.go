@@ -0,0 +1,248 @@
+package exec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/rs/zerolog"
+
+	"github.com/aergoio/aergo/cmd/brick/context"
+	"github.com/aergoio/aergo/types"
+)
+
+// the hash of the last tx made by call, deploy or send, whose events are
+// checked by assert event
+var lastTxHash []byte
+
+var assertions = []string{"query", "balance", "event", "error"}
+
+func init() {
+	registerExec(&assert{})
+	for _, kind := range assertions {
+		Index(context.AssertionSymbol, kind)
+	}
+}
+
+type assert struct{}
+
+type assertion struct {
+	kind    string
+	args    []context.Chunk
+	cmd     string // command of assert error
+	cmdArgs string
+}
+
+func (c *assert) Command() string {
+	return "assert"
+}
+
+func (c *assert) Syntax() string {
+	return fmt.Sprintf("%s", context.AssertionSymbol)
+}
+
+func (c *assert) Usage() string {
+	return "assert query <contract_name> <func_name> `[query_json_str]` `<expected_query_result>` | " +
+		"assert balance <account_name> <expected_balance> | " +
+		"assert event <event_name> `[expected_event_args]` | " +
+		"assert error `<expected_error_str>` <command>"
+}
+
+func (c *assert) Describe() string {
+	return "assert a query result, a balance, an event of the last tx or an error of a command"
+}
+
+func (c *assert) Validate(args string) error {
+
+	// is chain is loaded?
+	if context.Get() == nil {
+		return fmt.Errorf("load chain first")
+	}
+
+	a, err := c.parse(args)
+	if err != nil {
+		return err
+	}
+
+	if a.kind == "error" {
+		executor := GetExecutor(a.cmd)
+		if executor == nil {
+			return fmt.Errorf("command not found: %s", a.cmd)
+		}
+	}
+
+	return nil
+}
+
+func (c *assert) parse(args string) (*assertion, error) {
+	kind, rest := context.ParseFirstWord(args)
+	a := &assertion{kind: kind}
+
+	switch kind {
+	case "query":
+		a.args = context.SplitSpaceAndAccent(rest, false)
+		if len(a.args) != 4 {
+			return nil, fmt.Errorf("need 4 arguments. usage: %s", c.Usage())
+		}
+	case "balance":
+		a.args = context.SplitSpaceAndAccent(rest, false)
+		if len(a.args) != 2 {
+			return nil, fmt.Errorf("need 2 arguments. usage: %s", c.Usage())
+		}
+		if _, success := new(big.Int).SetString(a.args[1].Text, 10); success == false {
+			return nil, fmt.Errorf("fail to parse number %s", a.args[1].Text)
+		}
+	case "event":
+		a.args = context.SplitSpaceAndAccent(rest, false)
+		if len(a.args) < 1 {
+			return nil, fmt.Errorf("need at least 1 argument. usage: %s", c.Usage())
+		} else if len(a.args) > 2 {
+			return nil, fmt.Errorf("too many arguments. usage: %s", c.Usage())
+		}
+	case "error":
+		// the expected error is followed by a command line, which is kept
+		// as it is
+		end := -1
+		if strings.HasPrefix(rest, "`") {
+			end = strings.Index(rest[1:], "`")
+		}
+		if end == -1 {
+			return nil, fmt.Errorf("need an expected error in accents. usage: %s", c.Usage())
+		}
+		a.args = []context.Chunk{{Accent: true, Text: rest[1 : end+1]}}
+		a.cmd, a.cmdArgs = context.ParseFirstWord(rest[end+2:])
+		if len(a.cmd) == 0 {
+			return nil, fmt.Errorf("need a command. usage: %s", c.Usage())
+		}
+	default:
+		return nil, fmt.Errorf("unknown assertion %q. usage: %s", kind, c.Usage())
+	}
+
+	return a, nil
+}
+
+func (c *assert) Run(args string) (string, uint64, []*types.Event, error) {
+	a, _ := c.parse(args)
+
+	var err error
+	switch a.kind {
+	case "query":
+		err = c.assertQuery(a.args[0].Text, a.args[1].Text, a.args[2].Text, a.args[3].Text)
+	case "balance":
+		err = c.assertBalance(a.args[0].Text, a.args[1].Text)
+	case "event":
+		expectedArgs := ""
+		if len(a.args) == 2 {
+			expectedArgs = a.args[1].Text
+		}
+		err = c.assertEvent(a.args[0].Text, expectedArgs)
+	case "error":
+		err = c.assertError(a.args[0].Text, a.cmd, a.cmdArgs)
+	}
+	if err != nil {
+		return "", 0, nil, err
+	}
+
+	return fmt.Sprintf("assert %s successfully", a.kind), 0, nil, nil
+}
+
+func (c *assert) assertQuery(contractName, funcName, queryCode, expectedResult string) error {
+	formattedQuery := fmt.Sprintf("{\"name\":\"%s\",\"args\":%s}", funcName, queryCode)
+
+	_, result, err := context.Get().QueryOnly(contractName, formattedQuery, "")
+	if err != nil {
+		return err
+	}
+	if !jsonEqual(expectedResult, result) {
+		return fmt.Errorf("query assertion fail. Expected: %s, Actual: %s", expectedResult, result)
+	}
+
+	Index(context.ExpectedSymbol, expectedResult)
+
+	return nil
+}
+
+func (c *assert) assertBalance(accountName, expectedBalance string) error {
+	state, err := context.Get().GetAccountState(accountName)
+	if err != nil {
+		return err
+	}
+
+	expected, _ := new(big.Int).SetString(expectedBalance, 10)
+	balance := new(big.Int).SetBytes(state.GetBalance())
+	if expected.Cmp(balance) != 0 {
+		return fmt.Errorf("balance assertion fail. Expected: %s, Actual: %s", expected, balance)
+	}
+
+	return nil
+}
+
+func (c *assert) assertEvent(eventName, expectedArgs string) error {
+	if lastTxHash == nil {
+		return fmt.Errorf("there is no tx to assert events")
+	}
+
+	var actual []string
+	for _, event := range context.Get().GetEvents(lastTxHash) {
+		if event.GetEventName() == eventName &&
+			(expectedArgs == "" || jsonEqual(expectedArgs, event.GetJsonArgs())) {
+			return nil
+		}
+		actual = append(actual, fmt.Sprintf("%s%s", event.GetEventName(), event.GetJsonArgs()))
+	}
+
+	return fmt.Errorf("event assertion fail. Expected: %s%s, Actual: [%s]",
+		eventName, expectedArgs, strings.Join(actual, ", "))
+}
+
+func (c *assert) assertError(expectedError, cmd, args string) error {
+	executor := GetExecutor(cmd)
+
+	// turn off log of the expected failure
+	logLevel := zerolog.GlobalLevel()
+	zerolog.SetGlobalLevel(zerolog.ErrorLevel)
+	err := executor.Validate(args)
+	if err == nil {
+		_, _, _, err = executor.Run(args)
+	}
+	zerolog.SetGlobalLevel(logLevel)
+
+	if err == nil {
+		return fmt.Errorf("no error, expected: %s", expectedError)
+	}
+	if !strings.Contains(err.Error(), expectedError) {
+		return fmt.Errorf("error assertion fail. Expected: %s, Actual: %s", expectedError, err.Error())
+	}
+
+	Index(context.ExpectedErrSymbol, expectedError)
+
+	return nil
+}
+
+// jsonEqual compares two json texts by their values, so that the spaces and
+// the order of object keys don't matter. Texts which are not json are
+// compared as they are.
+func jsonEqual(expected, actual string) bool {
+	var e, a interface{}
+
+	if decodeJSON(expected, &e) != nil || decodeJSON(actual, &a) != nil {
+		return strings.TrimSpace(expected) == strings.TrimSpace(actual)
+	}
+
+	return reflect.DeepEqual(e, a)
+}
+
+func decodeJSON(text string, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewBufferString(text))
+	decoder.UseNumber()
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+	if decoder.More() {
+		return fmt.Errorf("trailing data after json")
+	}
+	return nil
+}
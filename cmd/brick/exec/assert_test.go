@@ -0,0 +1,71 @@
+package exec
+
+import (
+	"testing"
+)
+
+func TestJSONEqual(t *testing.T) {
+	for _, test := range []struct {
+		expected, actual string
+		equal            bool
+	}{
+		{`{"a": 1, "b": [1, "x"]}`, `{"b":[1,"x"],"a":1}`, true},
+		{`"hello world"`, ` "hello world"`, true},
+		{`12345678901234567890`, `12345678901234567890`, true},
+		{`12345678901234567890`, `12345678901234567891`, false},
+		{`{"a": 1}`, `{"a": "1"}`, false},
+		{`[1, 2]`, `[2, 1]`, false},
+		// the texts which are not json are compared as they are
+		{`hello`, ` hello `, true},
+		{`hello`, `"hello"`, false},
+		{`1 2`, `1`, false},
+	} {
+		if jsonEqual(test.expected, test.actual) != test.equal {
+			t.Errorf("jsonEqual(%s, %s) must be %v", test.expected, test.actual, test.equal)
+		}
+	}
+}
+
+func TestAssertParse(t *testing.T) {
+	c := &assert{}
+
+	for _, args := range []string{
+		"query helloctr hello `[]`",
+		"balance bj",
+		"balance bj 1e2",
+		"event",
+		"event transfer `[]` `[]`",
+		"error not found function",
+		"error `not found function`",
+		"height 3",
+	} {
+		if _, err := c.parse(args); err == nil {
+			t.Errorf("expected an error of %q", args)
+		}
+	}
+
+	a, err := c.parse("query helloctr hello `[]` `\"hello world\"`")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.kind != "query" || len(a.args) != 4 || a.args[3].Text != `"hello world"` {
+		t.Errorf("unexpected assertion: %+v", a)
+	}
+
+	a, err = c.parse("event transfer `[\"bj\", 10]`")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.kind != "event" || len(a.args) != 2 || a.args[1].Text != `["bj", 10]` {
+		t.Errorf("unexpected assertion: %+v", a)
+	}
+
+	// the command of an error assertion is kept as it is
+	a, err = c.parse("error `not found function` call bj 0 helloctr set_title `[\"aergo\"]`")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.args[0].Text != "not found function" || a.cmd != "call" || a.cmdArgs != "bj 0 helloctr set_title `[\"aergo\"]`" {
+		t.Errorf("unexpected assertion: %+v", a)
+	}
+}
@@ -132,6 +132,8 @@ func (c *deployContract) Run(args string) (string, uint64, []*types.Event, error
 	if err != nil {
 		return "", 0, nil, err
 	}
+	lastTxHash = tx.Hash()
+	addContractSource(contractName, defPath)
 
 	Index(context.ContractSymbol, contractName)
 	Index(context.AccountSymbol, contractName)
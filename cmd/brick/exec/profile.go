@@ -0,0 +1,172 @@
+package exec
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aergoio/aergo/cmd/brick/context"
+	"github.com/aergoio/aergo/contract"
+	"github.com/aergoio/aergo/types"
+)
+
+var (
+	lastProfile *contract.Profile
+
+	// names and source files of the deployed contracts by their addresses
+	contractNames = make(map[string]string)
+	contractPaths = make(map[string]string)
+)
+
+var profileActions = []string{"start", "stop", "report", "lcov", "folded", "gas"}
+
+func init() {
+	registerExec(&profile{})
+	for _, action := range profileActions {
+		Index(context.ProfileSymbol, action)
+	}
+}
+
+func addContractSource(contractName, defPath string) {
+	address := contract.StrToAddress(contractName)
+	contractNames[address] = contractName
+	if strings.HasPrefix(defPath, "http") {
+		contractPaths[address] = defPath
+	} else if absPath, err := filepath.Abs(defPath); err == nil {
+		contractPaths[address] = absPath
+	}
+}
+
+func contractName(address string) string {
+	if name, ok := contractNames[address]; ok {
+		return name
+	}
+	return address
+}
+
+func contractPath(address string) string {
+	if path, ok := contractPaths[address]; ok {
+		return path
+	}
+	return address
+}
+
+type profile struct{}
+
+func (c *profile) Command() string {
+	return "profile"
+}
+
+func (c *profile) Syntax() string {
+	return fmt.Sprintf("%s %s", context.ProfileSymbol, context.PathSymbol)
+}
+
+func (c *profile) Usage() string {
+	return "profile start | stop | report | lcov <lcov_file_path> | folded <folded_file_path> | gas <folded_file_path>"
+}
+
+func (c *profile) Describe() string {
+	return "profile the line coverage and the instructions and gas of contract functions"
+}
+
+func (c *profile) Validate(args string) error {
+	_, _, err := c.parse(args)
+
+	return err
+}
+
+func (c *profile) parse(args string) (string, string, error) {
+	splitArgs := context.SplitSpaceAndAccent(args, false)
+	if len(splitArgs) < 1 {
+		return "", "", fmt.Errorf("need an action. usage: %s", c.Usage())
+	}
+
+	action := splitArgs[0].Text
+	switch action {
+	case "start", "stop", "report":
+		if len(splitArgs) != 1 {
+			return "", "", fmt.Errorf("too many arguments. usage: %s", c.Usage())
+		}
+		return action, "", nil
+	case "lcov", "folded", "gas":
+		if len(splitArgs) != 2 {
+			return "", "", fmt.Errorf("need a file path. usage: %s", c.Usage())
+		}
+		return action, splitArgs[1].Text, nil
+	}
+
+	return "", "", fmt.Errorf("unknown action %s. usage: %s", action, c.Usage())
+}
+
+func (c *profile) Run(args string) (string, uint64, []*types.Event, error) {
+	action, path, _ := c.parse(args)
+
+	switch action {
+	case "start":
+		lastProfile = contract.StartProfile()
+		return "start profiling", 0, nil, nil
+	case "stop":
+		if contract.StopProfile() == nil {
+			return "", 0, nil, fmt.Errorf("profiling is not started")
+		}
+		return "stop profiling", 0, nil, nil
+	}
+
+	if lastProfile == nil {
+		return "", 0, nil, fmt.Errorf("no profile. start profiling first")
+	}
+
+	if action == "report" {
+		var report strings.Builder
+		if err := lastProfile.WriteFunctions(&report, contractName); err != nil {
+			return "", 0, nil, err
+		}
+		return report.String(), 0, nil, nil
+	}
+
+	err := writeProfile(path, func(w io.Writer) error {
+		switch action {
+		case "lcov":
+			return lastProfile.WriteLcov(w, contractPath)
+		case "folded":
+			return lastProfile.WriteFolded(w, false, contractName)
+		default:
+			return lastProfile.WriteFolded(w, true, contractName)
+		}
+	})
+	if err != nil {
+		return "", 0, nil, err
+	}
+
+	return fmt.Sprintf("write the profile to %s", path), 0, nil, nil
+}
+
+func writeProfile(path string, write func(w io.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err = write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// StartCoverage starts profiling for the coverage of tests.
+func StartCoverage() {
+	lastProfile = contract.StartProfile()
+}
+
+// WriteCoverage stops profiling and writes the coverage in the lcov format.
+func WriteCoverage(path string) error {
+	contract.StopProfile()
+	if lastProfile == nil {
+		return fmt.Errorf("no profile")
+	}
+	return writeProfile(path, func(w io.Writer) error {
+		return lastProfile.WriteLcov(w, contractPath)
+	})
+}
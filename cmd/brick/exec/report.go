@@ -0,0 +1,116 @@
+package exec
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+type jsonReport struct {
+	Tests    int          `json:"tests"`
+	Failures int          `json:"failures"`
+	Suites   []*TestSuite `json:"suites"`
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func countTests(suites []*TestSuite) (int, int) {
+	tests, failures := 0, 0
+	for _, suite := range suites {
+		tests += len(suite.Cases)
+		failures += suite.FailedCases()
+	}
+	return tests, failures
+}
+
+// WriteJSONReport writes the results of brick tests to a file in JSON.
+func WriteJSONReport(path string, suites []*TestSuite) error {
+	report := jsonReport{Suites: suites}
+	report.Tests, report.Failures = countTests(suites)
+
+	out, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, out, 0644)
+}
+
+// WriteJUnitReport writes the results of brick tests to a file in the JUnit
+// XML format, which CI services read.
+func WriteJUnitReport(path string, suites []*TestSuite) error {
+	report := junitTestSuites{}
+	report.Tests, report.Failures = countTests(suites)
+
+	var total float64
+	for _, suite := range suites {
+		total += suite.Time
+		junitSuite := junitTestSuite{
+			Name:     suite.Name,
+			Tests:    len(suite.Cases),
+			Failures: suite.FailedCases(),
+			Time:     fmt.Sprintf("%.3f", suite.Time),
+		}
+		for _, tc := range suite.Cases {
+			junitCase := junitTestCase{
+				Name:      tc.Name,
+				ClassName: suite.Name,
+				Time:      fmt.Sprintf("%.3f", tc.Time),
+			}
+			if tc.Failed() {
+				var text strings.Builder
+				for _, failure := range tc.Failures {
+					if failure.Line == 0 {
+						text.WriteString(fmt.Sprintf("%s: %s\n", suite.Name, failure.Message))
+						continue
+					}
+					text.WriteString(fmt.Sprintf("%s:%d: %s\n\t%s\n", suite.Name, failure.Line, failure.Command, failure.Message))
+				}
+				junitCase.Failure = &junitFailure{
+					Message: tc.Failures[0].Message,
+					Type:    "brick",
+					Text:    text.String(),
+				}
+			}
+			junitSuite.Cases = append(junitSuite.Cases, junitCase)
+		}
+		report.Suites = append(report.Suites, junitSuite)
+	}
+	report.Time = fmt.Sprintf("%.3f", total)
+
+	out, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, append([]byte(xml.Header), out...), 0644)
+}
@@ -0,0 +1,111 @@
+package exec
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func testSuites() []*TestSuite {
+	return []*TestSuite{
+		{
+			Name: "contracts/hello_test.brick",
+			Time: 0.5,
+			Cases: []*TestCase{
+				{Name: "hello", Steps: 2, Time: 0.2},
+				{Name: "set a name", Steps: 3, Time: 0.3, Failures: []TestFailure{
+					{Line: 12, Command: "assert balance bj 100", Message: "balance assertion fail. Expected: 100, Actual: 90"},
+					{Message: "fail in an included batch"},
+				}},
+			},
+		},
+		{
+			Name:  "contracts/token_test.brick",
+			Time:  0.25,
+			Cases: []*TestCase{{Name: "token_test", Steps: 1, Time: 0.25}},
+		},
+	}
+}
+
+func readTestReport(t *testing.T, write func(path string, suites []*TestSuite) error) []byte {
+	dir, err := ioutil.TempDir("", "brick")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "report")
+	if err = write(path, testSuites()); err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestWriteJSONReport(t *testing.T) {
+	data := readTestReport(t, WriteJSONReport)
+
+	var report jsonReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatal(err)
+	}
+	if report.Tests != 3 || report.Failures != 1 {
+		t.Errorf("expected 3 tests and 1 failure, but got %d and %d", report.Tests, report.Failures)
+	}
+	if !reflect.DeepEqual(testSuites(), report.Suites) {
+		t.Errorf("unexpected suites: %s", data)
+	}
+
+	// a passed case has no failures in the report
+	if strings.Count(string(data), `"failures": [`) != 1 {
+		t.Errorf("expected the failures of only the failed case: %s", data)
+	}
+}
+
+func TestWriteJUnitReport(t *testing.T) {
+	data := readTestReport(t, WriteJUnitReport)
+	if !strings.HasPrefix(string(data), xml.Header) {
+		t.Errorf("expected the xml header: %s", data)
+	}
+
+	var report junitTestSuites
+	if err := xml.Unmarshal(data, &report); err != nil {
+		t.Fatal(err)
+	}
+	if report.Tests != 3 || report.Failures != 1 || report.Time != "0.750" || len(report.Suites) != 2 {
+		t.Fatalf("unexpected report: %s", data)
+	}
+
+	suite := report.Suites[0]
+	if suite.Name != "contracts/hello_test.brick" || suite.Tests != 2 || suite.Failures != 1 ||
+		suite.Time != "0.500" || len(suite.Cases) != 2 {
+		t.Fatalf("unexpected suite: %+v", suite)
+	}
+	passed := junitTestCase{Name: "hello", ClassName: "contracts/hello_test.brick", Time: "0.200"}
+	if !reflect.DeepEqual(passed, suite.Cases[0]) {
+		t.Errorf("expected %+v, but got %+v", passed, suite.Cases[0])
+	}
+	failure := &junitFailure{
+		Message: "balance assertion fail. Expected: 100, Actual: 90",
+		Type:    "brick",
+		Text: "contracts/hello_test.brick:12: assert balance bj 100\n" +
+			"\tbalance assertion fail. Expected: 100, Actual: 90\n" +
+			"contracts/hello_test.brick: fail in an included batch\n",
+	}
+	if !reflect.DeepEqual(failure, suite.Cases[1].Failure) {
+		t.Errorf("expected %+v, but got %+v", failure, suite.Cases[1].Failure)
+	}
+
+	suite = report.Suites[1]
+	if suite.Tests != 1 || suite.Failures != 0 || suite.Cases[0].Failure != nil {
+		t.Errorf("unexpected suite: %+v", suite)
+	}
+}
@@ -34,5 +34,7 @@ func (c *resetChain) Validate(args string) error {
 func (c *resetChain) Run(args string) (string, uint64, []*types.Event, error) {
 	context.Reset()
 	resetContractInfoInterface()
+	resetSnapshots()
+	lastTxHash = nil
 	return "reset a dummy chain successfully", 0, nil, nil
 }
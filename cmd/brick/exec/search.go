@@ -107,6 +107,9 @@ func searchAbiHint(contractName, funcName string) map[string]string {
 			argsHint := "`["
 			for i, funcArg := range contractFunc.GetArguments() {
 				argsHint += funcArg.Name
+				if funcArg.Type != "" {
+					argsHint += ":" + funcArg.Type
+				}
 				if i+1 != len(contractFunc.GetArguments()) {
 					argsHint += ", "
 				}
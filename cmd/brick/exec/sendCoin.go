@@ -81,6 +81,8 @@ func (c *sendCoin) Run(args string) (string, uint64, []*types.Event, error) {
 		return "", 0, nil, err
 	}
 
+	lastTxHash = tx.Hash()
+
 	Index(context.AccountSymbol, receiverName)
 
 	return "send aergo successfully",
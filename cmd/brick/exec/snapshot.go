@@ -0,0 +1,157 @@
+package exec
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/aergoio/aergo/cmd/brick/context"
+	"github.com/aergoio/aergo/types"
+)
+
+func init() {
+	registerExec(&snapshot{})
+	registerExec(&restore{})
+}
+
+// chainPoint is a block of the dummy chain, to which a snapshot is restored
+// by undoing the blocks after it.
+type chainPoint struct {
+	blockNo   types.BlockNo
+	blockHash []byte
+}
+
+var snapshots = make(map[string]chainPoint)
+
+func resetSnapshots() {
+	snapshots = make(map[string]chainPoint)
+	delete(index, context.SnapshotSymbol)
+}
+
+// =========== snapshot ==============
+type snapshot struct{}
+
+func (c *snapshot) Command() string {
+	return "snapshot"
+}
+
+func (c *snapshot) Syntax() string {
+	return fmt.Sprintf("%s", context.SnapshotSymbol)
+}
+
+func (c *snapshot) Usage() string {
+	return "snapshot <snapshot_name>"
+}
+
+func (c *snapshot) Describe() string {
+	return "save the current state of the chain with a name"
+}
+
+func (c *snapshot) Validate(args string) error {
+
+	// is chain is loaded?
+	if context.Get() == nil {
+		return fmt.Errorf("load chain first")
+	}
+
+	_, err := c.parse(args)
+
+	return err
+}
+
+func (c *snapshot) parse(args string) (string, error) {
+	splitArgs := context.SplitSpaceAndAccent(args, false)
+	if len(splitArgs) != 1 {
+		return "", fmt.Errorf("invalid format. usage: %s", c.Usage())
+	}
+
+	return splitArgs[0].Text, nil
+}
+
+func (c *snapshot) Run(args string) (string, uint64, []*types.Event, error) {
+	name, _ := c.parse(args)
+
+	blockNo := context.Get().BestBlockNo()
+	block, err := context.Get().GetBlockByNo(blockNo)
+	if err != nil {
+		return "", 0, nil, err
+	}
+	snapshots[name] = chainPoint{
+		blockNo:   blockNo,
+		blockHash: block.BlockHash(),
+	}
+
+	Index(context.SnapshotSymbol, name)
+
+	return "save a snapshot successfully", 0, nil, nil
+}
+
+// =========== restore ==============
+type restore struct{}
+
+func (c *restore) Command() string {
+	return "restore"
+}
+
+func (c *restore) Syntax() string {
+	return fmt.Sprintf("%s", context.SnapshotSymbol)
+}
+
+func (c *restore) Usage() string {
+	return "restore <snapshot_name>"
+}
+
+func (c *restore) Describe() string {
+	return "restore the chain to a snapshot by undoing the blocks after it"
+}
+
+func (c *restore) Validate(args string) error {
+
+	// is chain is loaded?
+	if context.Get() == nil {
+		return fmt.Errorf("load chain first")
+	}
+
+	_, err := c.parse(args)
+
+	return err
+}
+
+func (c *restore) parse(args string) (chainPoint, error) {
+	splitArgs := context.SplitSpaceAndAccent(args, false)
+	if len(splitArgs) != 1 {
+		return chainPoint{}, fmt.Errorf("invalid format. usage: %s", c.Usage())
+	}
+
+	point, ok := snapshots[splitArgs[0].Text]
+	if !ok {
+		return chainPoint{}, fmt.Errorf("snapshot not found: %s", splitArgs[0].Text)
+	}
+
+	return point, nil
+}
+
+func (c *restore) Run(args string) (string, uint64, []*types.Event, error) {
+	point, _ := c.parse(args)
+
+	// the snapshot is lost if its block is undone
+	chain := context.Get()
+	if chain.BestBlockNo() < point.blockNo {
+		return "", 0, nil, fmt.Errorf("snapshot is not in the chain: %s", args)
+	}
+	block, err := chain.GetBlockByNo(point.blockNo)
+	if err != nil {
+		return "", 0, nil, err
+	}
+	if !bytes.Equal(block.BlockHash(), point.blockHash) {
+		return "", 0, nil, fmt.Errorf("snapshot is not in the chain: %s", args)
+	}
+
+	for chain.BestBlockNo() > point.blockNo {
+		if err := chain.DisConnectBlock(); err != nil {
+			return "", 0, nil, err
+		}
+	}
+	lastTxHash = nil
+
+	return "restore a snapshot successfully", 0, nil, nil
+}
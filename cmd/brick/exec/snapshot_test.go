@@ -0,0 +1,75 @@
+package exec
+
+import (
+	"testing"
+
+	"github.com/aergoio/aergo/cmd/brick/context"
+)
+
+func runCommand(cmd, args string) error {
+	executor := GetExecutor(cmd)
+	if err := executor.Validate(args); err != nil {
+		return err
+	}
+	_, _, _, err := executor.Run(args)
+	return err
+}
+
+func TestRestoreSnapshot(t *testing.T) {
+	defer openTestChain(t)()
+
+	run := func(cmd, args string) {
+		t.Helper()
+		if err := runCommand(cmd, args); err != nil {
+			t.Fatalf("%s %s: %v", cmd, args, err)
+		}
+	}
+	runError := func(cmd, args, expected string) {
+		t.Helper()
+		if err := runCommand(cmd, args); err == nil || err.Error() != expected {
+			t.Errorf("%s %s: expected %q, but got %v", cmd, args, expected, err)
+		}
+	}
+	checkHeight := func(expected uint64) {
+		t.Helper()
+		if no := context.Get().BestBlockNo(); no != expected {
+			t.Errorf("expected the best block %d, but got %d", expected, no)
+		}
+	}
+
+	run("reset", "")
+	run("inject", "bj 100")
+	run("snapshot", "first")
+	first := context.Get().BestBlockNo()
+	run("inject", "ej 100")
+	run("inject", "fj 100")
+	run("snapshot", "second")
+	second := context.Get().BestBlockNo()
+
+	run("restore", "first")
+	checkHeight(first)
+	run("assert", "balance bj 100")
+	run("assert", "balance ej 0")
+
+	// the blocks of the second snapshot are undone
+	runError("restore", "second", "snapshot is not in the chain: second")
+
+	// the chain grows back to the height of the second snapshot by other
+	// blocks, which are not the ones of the snapshot
+	run("inject", "ej 200")
+	run("inject", "fj 200")
+	checkHeight(second)
+	runError("restore", "second", "snapshot is not in the chain: second")
+	run("assert", "balance ej 200")
+
+	// a snapshot can be restored more than once
+	run("restore", "first")
+	run("restore", "first")
+	checkHeight(first)
+
+	runError("restore", "third", "snapshot not found: third")
+
+	// the snapshots are of the chain before a reset
+	run("reset", "")
+	runError("restore", "first", "snapshot not found: first")
+}
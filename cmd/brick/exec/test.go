@@ -0,0 +1,263 @@
+package exec
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aergoio/aergo/cmd/brick/context"
+	"github.com/aergoio/aergo/types"
+	"github.com/rs/zerolog"
+)
+
+func init() {
+	registerExec(&testCase{})
+}
+
+// TestSuite is the result of a brick file run as a test.
+type TestSuite struct {
+	Name  string      `json:"name"`
+	Time  float64     `json:"time"` // in seconds
+	Cases []*TestCase `json:"cases"`
+}
+
+// TestCase is the result of the commands after a case command, or of the
+// whole file without it.
+type TestCase struct {
+	Name     string        `json:"name"`
+	Steps    int           `json:"steps"`
+	Time     float64       `json:"time"` // in seconds
+	Failures []TestFailure `json:"failures,omitempty"`
+}
+
+// TestFailure is a command failed in a test case.
+type TestFailure struct {
+	Line    int    `json:"line"`
+	Command string `json:"command"`
+	Message string `json:"message"`
+}
+
+func (tc *TestCase) Failed() bool {
+	return len(tc.Failures) > 0
+}
+
+func (ts *TestSuite) FailedCases() int {
+	failed := 0
+	for _, tc := range ts.Cases {
+		if tc.Failed() {
+			failed++
+		}
+	}
+	return failed
+}
+
+// =========== case ==============
+type testCase struct{}
+
+func (c *testCase) Command() string {
+	return "case"
+}
+
+func (c *testCase) Syntax() string {
+	return fmt.Sprintf("%s", context.CaseSymbol)
+}
+
+func (c *testCase) Usage() string {
+	return "case <case_name>"
+}
+
+func (c *testCase) Describe() string {
+	return "start a test case, which is reported separately by test"
+}
+
+func (c *testCase) Validate(args string) error {
+	if len(caseName(args)) == 0 {
+		return fmt.Errorf("need a case name. usage: %s", c.Usage())
+	}
+	return nil
+}
+
+func (c *testCase) Run(args string) (string, uint64, []*types.Event, error) {
+	return fmt.Sprintf("test case: %s", caseName(args)), 0, nil, nil
+}
+
+func caseName(args string) string {
+	return strings.Trim(strings.TrimSpace(args), "`")
+}
+
+// RunTests runs brick files as tests, each on a new chain, and returns their
+// results. A directory path includes the brick files in it, and a path ending
+// with "/..." includes the ones in its subdirectories too.
+func RunTests(paths []string) ([]*TestSuite, error) {
+	files, err := findTestFiles(paths)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no brick file to test")
+	}
+
+	// batches included by test files are run as nested ones, which don't
+	// print the final result and reset the error count
+	b := GetExecutor("batch").(*batch)
+	b.level++
+
+	// set highest log level to turn off verbose
+	if false == verboseBatch {
+		zerolog.SetGlobalLevel(zerolog.ErrorLevel)
+	}
+	defer func() {
+		b.level--
+		batchErrorCount = 0
+		zerolog.SetGlobalLevel(zerolog.DebugLevel)
+	}()
+
+	var suites []*TestSuite
+	for _, file := range files {
+		suite := runTestFile(b, file)
+		suites = append(suites, suite)
+
+		result := "ok  "
+		if suite.FailedCases() > 0 {
+			result = "FAIL"
+		}
+		fmt.Printf("%s\t%s\t%.3fs\n", result, suite.Name, suite.Time)
+	}
+
+	return suites, nil
+}
+
+func runTestFile(b *batch, path string) *TestSuite {
+	start := time.Now()
+	suite := &TestSuite{Name: path}
+
+	tc := &TestCase{Name: strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))}
+	caseStart := start
+	endCase := func() {
+		tc.Time = time.Since(caseStart).Seconds()
+		suite.Cases = append(suite.Cases, tc)
+		printTestCase(path, tc)
+	}
+
+	cmdLines, err := b.readBatchFile(path)
+	if err != nil {
+		tc.Failures = append(tc.Failures, TestFailure{Message: err.Error()})
+		endCase()
+		suite.Time = time.Since(start).Seconds()
+		return suite
+	}
+
+	// each file is tested on a new chain
+	if _, _, _, err := GetExecutor("reset").Run(""); err != nil {
+		tc.Failures = append(tc.Failures, TestFailure{Message: err.Error()})
+	}
+
+	named := false
+	for i, line := range cmdLines {
+		cmd, args := context.ParseFirstWord(line)
+		if len(cmd) == 0 || context.Comment == cmd {
+			continue
+		}
+		if cmd == "case" && len(caseName(args)) != 0 {
+			// the commands before the first case are kept as a case only
+			// when they fail
+			if named || tc.Failed() {
+				endCase()
+			}
+			named = true
+			tc = &TestCase{Name: caseName(args)}
+			caseStart = time.Now()
+			continue
+		}
+
+		tc.Steps++
+		errCount := batchErrorCount
+		Broker(line)
+		if batchErrorCount > errCount {
+			msg := "fail in an included batch"
+			if letBatchKnowErr != nil {
+				msg = letBatchKnowErr.Error()
+			}
+			tc.Failures = append(tc.Failures, TestFailure{
+				Line:    i + 1,
+				Command: strings.TrimSpace(line),
+				Message: msg,
+			})
+			letBatchKnowErr = nil
+		}
+	}
+	endCase()
+
+	suite.Time = time.Since(start).Seconds()
+	return suite
+}
+
+func printTestCase(path string, tc *TestCase) {
+	if !tc.Failed() {
+		if verboseBatch {
+			fmt.Printf("--- PASS: %s (%.3fs)\n", tc.Name, tc.Time)
+		}
+		return
+	}
+
+	fmt.Printf("--- FAIL: %s (%.3fs)\n", tc.Name, tc.Time)
+	for _, failure := range tc.Failures {
+		if failure.Line == 0 {
+			fmt.Printf("    %s: %s\n", path, failure.Message)
+			continue
+		}
+		fmt.Printf("    %s:%d: %s\n        %s\n", path, failure.Line, failure.Command, failure.Message)
+	}
+}
+
+func findTestFiles(paths []string) ([]string, error) {
+	var files []string
+
+	for _, path := range paths {
+		if strings.HasPrefix(path, "http") {
+			files = append(files, path)
+			continue
+		}
+
+		recursive := false
+		if path == "..." || strings.HasSuffix(path, "/...") {
+			recursive = true
+			path = strings.TrimSuffix(path, "...")
+			if path == "" {
+				path = "."
+			}
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+
+		err = filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				if !recursive && file != path {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if filepath.Ext(file) == ".brick" {
+				files = append(files, file)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return files, nil
+}
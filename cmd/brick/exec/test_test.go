@@ -0,0 +1,186 @@
+package exec
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aergoio/aergo/cmd/brick/context"
+)
+
+// openTestChain opens a dummy chain in the directory of brick, where the
+// paths of the example files are relative to.
+func openTestChain(t *testing.T) func() {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = os.Chdir(".."); err != nil {
+		t.Fatal(err)
+	}
+	context.Open(false)
+	return func() {
+		context.Close()
+		os.Chdir(wd)
+	}
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRunTestsExample(t *testing.T) {
+	defer openTestChain(t)()
+
+	suites, err := RunTests([]string{"example/hello_test.brick"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(suites) != 1 {
+		t.Fatalf("expected 1 suite, but got %d", len(suites))
+	}
+	suite := suites[0]
+	if suite.Name != "example/hello_test.brick" {
+		t.Errorf("unexpected suite name: %s", suite.Name)
+	}
+
+	var names []string
+	for _, tc := range suite.Cases {
+		names = append(names, tc.Name)
+		if tc.Failed() {
+			t.Errorf("case %q failed: %v", tc.Name, tc.Failures)
+		}
+	}
+	expected := []string{"hello to the world", "set a name", "send aergo", "restore the name", "reject an unknown function"}
+	if !reflect.DeepEqual(expected, names) {
+		t.Fatalf("expected cases %v, but got %v", expected, names)
+	}
+	if suite.Cases[1].Steps != 2 {
+		t.Errorf("expected 2 steps, but got %d", suite.Cases[1].Steps)
+	}
+}
+
+func TestRunTestsFailure(t *testing.T) {
+	defer openTestChain(t)()
+	dir, err := ioutil.TempDir("", "brick")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "fail_test.brick")
+	writeTestFile(t, path, "inject bj 10000000000\n"+
+		"assert balance bj 1\n"+
+		"\n"+
+		"case deploy\n"+
+		"deploy bj 0 helloctr `./example/hello.lua`\n"+
+		"assert query helloctr hello `[]` `\"hello world\"`\n"+
+		"\n"+
+		"case wrong name\n"+
+		"assert query helloctr hello `[]` `\"hello aergo\"`\n"+
+		"assert error `not found function` call bj 0 helloctr set_name `[\"aergo\"]`\n")
+
+	suites, err := RunTests([]string{dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(suites) != 1 || suites[0].Name != path {
+		t.Fatalf("expected the suite of %s, but got %v", path, suites)
+	}
+	suite := suites[0]
+	if len(suite.Cases) != 3 {
+		t.Fatalf("expected 3 cases, but got %d", len(suite.Cases))
+	}
+	if suite.FailedCases() != 2 {
+		t.Errorf("expected 2 failed cases, but got %d", suite.FailedCases())
+	}
+
+	checkFailure := func(f TestFailure, line int, command, message string) {
+		t.Helper()
+		if f.Line != line || f.Command != command || !strings.Contains(f.Message, message) {
+			t.Errorf("expected a failure of %q at line %d with %q, but got %+v", command, line, message, f)
+		}
+	}
+
+	// the commands before the first case are kept as a case of the file name
+	// since they fail
+	tc := suite.Cases[0]
+	if tc.Name != "fail_test" || tc.Steps != 2 || len(tc.Failures) != 1 {
+		t.Fatalf("unexpected case: %+v", tc)
+	}
+	checkFailure(tc.Failures[0], 2, "assert balance bj 1", "balance assertion fail")
+
+	tc = suite.Cases[1]
+	if tc.Name != "deploy" || tc.Failed() {
+		t.Errorf("unexpected case: %+v", tc)
+	}
+
+	tc = suite.Cases[2]
+	if tc.Name != "wrong name" || len(tc.Failures) != 2 {
+		t.Fatalf("unexpected case: %+v", tc)
+	}
+	checkFailure(tc.Failures[0], 9, "assert query helloctr hello `[]` `\"hello aergo\"`", "query assertion fail")
+	checkFailure(tc.Failures[1], 10, "assert error `not found function` call bj 0 helloctr set_name `[\"aergo\"]`",
+		"no error, expected: not found function")
+
+	// the errors of the run are not left to the following commands
+	if GetBatchErrorCount() != 0 {
+		t.Errorf("expected no batch error, but got %d", GetBatchErrorCount())
+	}
+}
+
+func TestRunTestsNoFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "brick")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if _, err = RunTests([]string{dir}); err == nil || err.Error() != "no brick file to test" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestFindTestFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "brick")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	a := filepath.Join(dir, "a_test.brick")
+	b := filepath.Join(dir, "sub", "b_test.brick")
+	c := filepath.Join(dir, "sub", "c.lua")
+	writeTestFile(t, a, "")
+	writeTestFile(t, b, "")
+	writeTestFile(t, c, "")
+
+	for _, test := range []struct {
+		paths    []string
+		expected []string
+	}{
+		{[]string{dir}, []string{a}},
+		{[]string{dir + "/..."}, []string{a, b}},
+		// a file is included even if it's not of the extension
+		{[]string{c, a}, []string{c, a}},
+	} {
+		files, err := findTestFiles(test.paths)
+		if err != nil {
+			t.Errorf("unexpected error of %v: %v", test.paths, err)
+		} else if !reflect.DeepEqual(test.expected, files) {
+			t.Errorf("expected %v of %v, but got %v", test.expected, test.paths, files)
+		}
+	}
+
+	if _, err = findTestFiles([]string{filepath.Join(dir, "none")}); err == nil {
+		t.Error("expected an error of a missing path")
+	}
+}
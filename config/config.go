@@ -6,13 +6,20 @@
 package config
 
 import (
+	"fmt"
 	"runtime"
+	"strconv"
+	"strings"
 
 	"github.com/aergoio/aergo-lib/config"
+	"github.com/aergoio/aergo/internal/enc"
 	"github.com/aergoio/aergo/types"
 )
 
-const defaultDumpPort = 7070
+const (
+	defaultDumpPort    = 7070
+	defaultMetricsPort = 9110
+)
 
 type ServerContext struct {
 	config.BaseContext
@@ -63,6 +70,8 @@ func (ctx *ServerContext) GetDefaultBaseConfig() BaseConfig {
 		ProfilePort:    6060,
 		EnableDump:     false,
 		DumpPort:       GetDefaultDumpPort(),
+		EnableMetrics:  false,
+		MetricsPort:    GetDefaultMetricsPort(),
 		EnableTestmode: false,
 		Personal:       true,
 		AuthDir:        ctx.ExpandPathEnv("$HOME/auth"),
@@ -75,6 +84,7 @@ func (ctx *ServerContext) GetDefaultRPCConfig() *RPCConfig {
 		NetServicePort:  7845,
 		NetServiceTrace: false,
 		NSKey:           "",
+		NSJsonRPCPort:   0,
 	}
 }
 
@@ -138,6 +148,9 @@ func (ctx *ServerContext) GetDefaultBlockchainConfig() *BlockchainConfig {
 		NumWorkers:       runtime.NumCPU(),
 		NumLStateClosers: GetDefaultNumLStateClosers(),
 		CloseLimit:       GetDefaultCloseLimit(),
+		EventIndex:       false,
+		StateKeep:        0,
+		StatePruneIntvl:  10000,
 	}
 }
 
@@ -148,6 +161,8 @@ func (ctx *ServerContext) GetDefaultMempoolConfig() *MempoolConfig {
 		FadeoutPeriod:  types.DefaultEvictPeriod,
 		VerifierNumber: runtime.NumCPU(),
 		DumpFilePath:   ctx.ExpandPathEnv("$HOME/mempool.dump"),
+		EnableReplace:  true,
+		ReplaceBump:    types.DefaultReplaceBump,
 	}
 }
 
@@ -184,3 +199,29 @@ func (ctx *ServerContext) GetDefaultSQLConfig() *SQLConfig {
 func GetDefaultDumpPort() int {
 	return defaultDumpPort
 }
+
+// GetDefaultMetricsPort return the default port of the metrics endpoint.
+func GetDefaultMetricsPort() int {
+	return defaultMetricsPort
+}
+
+// SnapshotCheckpoint returns the number and the hash of the trusted block of
+// the snapshot sync. It returns a nil hash if the snapshot sync is disabled.
+func (c *BlockchainConfig) SnapshotCheckpoint() (types.BlockNo, []byte, error) {
+	if c == nil || c.SnapshotBlock == "" {
+		return 0, nil, nil
+	}
+	i := strings.IndexByte(c.SnapshotBlock, ':')
+	if i < 0 {
+		return 0, nil, fmt.Errorf("invalid snapshot block %q: <number>:<hash> expected", c.SnapshotBlock)
+	}
+	no, err := strconv.ParseUint(c.SnapshotBlock[:i], 10, 64)
+	if err != nil || no == 0 {
+		return 0, nil, fmt.Errorf("invalid snapshot block number %q", c.SnapshotBlock[:i])
+	}
+	hash, err := enc.ToBytes(c.SnapshotBlock[i+1:])
+	if err != nil || len(hash) != types.HashIDLength {
+		return 0, nil, fmt.Errorf("invalid snapshot block hash %q", c.SnapshotBlock[i+1:])
+	}
+	return no, hash, nil
+}
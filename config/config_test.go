@@ -5,10 +5,13 @@
 package config
 
 import (
+	"bytes"
 	"io/ioutil"
 	"path"
 	"testing"
 
+	"github.com/aergoio/aergo/internal/enc"
+	"github.com/aergoio/aergo/types"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -40,3 +43,32 @@ func TestSetParamConfPath(t *testing.T) {
 	// compare each other
 	assert.Equal(t, defaultConf.(*Config), &loadedConf)
 }
+
+func TestSnapshotCheckpoint(t *testing.T) {
+	hash := enc.ToString(bytes.Repeat([]byte{1}, types.HashIDLength))
+	tests := []struct {
+		block string
+		no    types.BlockNo
+		err   bool
+	}{
+		{"", 0, false},
+		{"100:" + hash, 100, false},
+		{"100", 0, true},
+		{"0:" + hash, 0, true},
+		{"x:" + hash, 0, true},
+		{"100:abc", 0, true},
+	}
+	for _, test := range tests {
+		c := &BlockchainConfig{SnapshotBlock: test.block}
+		no, h, err := c.SnapshotCheckpoint()
+		if test.err {
+			if err == nil {
+				t.Errorf("%q: expected an error", test.block)
+			}
+			continue
+		}
+		if err != nil || no != test.no || (test.block != "") != (h != nil) {
+			t.Errorf("%q: unexpected result: %d, %x, %v", test.block, no, h, err)
+		}
+	}
+}
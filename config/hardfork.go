@@ -9,6 +9,7 @@ package config
 
 import (
 	"fmt"
+	"math"
 	"strconv"
 
 	"github.com/aergoio/aergo/types"
@@ -34,6 +35,16 @@ func isFork(forkBlkNo, currBlkNo types.BlockNo) bool {
 	return forkBlkNo <= currBlkNo
 }
 
+// forkNo returns the block number of a fork version recorded in the chain db.
+// A version which is not recorded was unknown to the node that ran the chain,
+// so it is regarded as not activated.
+func (c HardforkDbConfig) forkNo(version string) types.BlockNo {
+	if bno, ok := c[version]; ok {
+		return bno
+	}
+	return math.MaxUint64
+}
+
 func checkOlderNode(maxVer uint64, latest types.BlockNo, dbCfg HardforkDbConfig) error {
 	for k, bno := range dbCfg {
 		ver, err := strconv.ParseUint(k[1:], 10, 64)
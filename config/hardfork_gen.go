@@ -12,21 +12,31 @@ import (
 var (
 	MainNetHardforkConfig = &HardforkConfig{
 		V2: types.BlockNo(19611555),
+		V3: types.BlockNo(18446744073709551615),
+		V4: types.BlockNo(18446744073709551615),
 	}
 	TestNetHardforkConfig = &HardforkConfig{
 		V2: types.BlockNo(18714241),
+		V3: types.BlockNo(18446744073709551615),
+		V4: types.BlockNo(18446744073709551615),
 	}
 	AllEnabledHardforkConfig = &HardforkConfig{
 		V2: types.BlockNo(0),
+		V3: types.BlockNo(0),
+		V4: types.BlockNo(0),
 	}
 )
 
 const hardforkConfigTmpl = `[hardfork]
 v2 = "{{.Hardfork.V2}}"
+v3 = "{{.Hardfork.V3}}"
+v4 = "{{.Hardfork.V4}}"
 `
 
 type HardforkConfig struct {
 	V2 types.BlockNo `mapstructure:"v2" description:"a block number of the hardfork version 2"`
+	V3 types.BlockNo `mapstructure:"v3" description:"a block number of the hardfork version 3"`
+	V4 types.BlockNo `mapstructure:"v4" description:"a block number of the hardfork version 4"`
 }
 
 type HardforkDbConfig map[string]types.BlockNo
@@ -35,14 +45,28 @@ func (c *HardforkConfig) IsV2Fork(h types.BlockNo) bool {
 	return isFork(c.V2, h)
 }
 
+func (c *HardforkConfig) IsV3Fork(h types.BlockNo) bool {
+	return isFork(c.V3, h)
+}
+
+func (c *HardforkConfig) IsV4Fork(h types.BlockNo) bool {
+	return isFork(c.V4, h)
+}
+
 func (c *HardforkConfig) CheckCompatibility(dbCfg HardforkDbConfig, h types.BlockNo) error {
 	if err := c.validate(); err != nil {
 		return err
 	}
-	if (isFork(c.V2, h) || isFork(dbCfg["V2"], h)) && c.V2 != dbCfg["V2"] {
-		return newForkError("V2", h, c.V2, dbCfg["V2"])
+	if (isFork(c.V2, h) || isFork(dbCfg.forkNo("V2"), h)) && c.V2 != dbCfg.forkNo("V2") {
+		return newForkError("V2", h, c.V2, dbCfg.forkNo("V2"))
+	}
+	if (isFork(c.V3, h) || isFork(dbCfg.forkNo("V3"), h)) && c.V3 != dbCfg.forkNo("V3") {
+		return newForkError("V3", h, c.V3, dbCfg.forkNo("V3"))
+	}
+	if (isFork(c.V4, h) || isFork(dbCfg.forkNo("V4"), h)) && c.V4 != dbCfg.forkNo("V4") {
+		return newForkError("V4", h, c.V4, dbCfg.forkNo("V4"))
 	}
-	return checkOlderNode(2, h, dbCfg)
+	return checkOlderNode(4, h, dbCfg)
 }
 
 func (c *HardforkConfig) Version(h types.BlockNo) int32 {
@@ -67,8 +67,8 @@ func (c *HardforkConfig) CheckCompatibility(dbCfg HardforkDbConfig, h types.Bloc
 		return err
 	}
 {{- range .Hardforks}}
-	if (isFork(c.V{{.Version}}, h) || isFork(dbCfg["V{{.Version}}"], h)) && c.V{{.Version}} != dbCfg["V{{.Version}}"] {
-		return newForkError("V{{.Version}}", h, c.V{{.Version}}, dbCfg["V{{.Version}}"])
+	if (isFork(c.V{{.Version}}, h) || isFork(dbCfg.forkNo("V{{.Version}}"), h)) && c.V{{.Version}} != dbCfg.forkNo("V{{.Version}}") {
+		return newForkError("V{{.Version}}", h, c.V{{.Version}}, dbCfg.forkNo("V{{.Version}}"))
 	}
 {{- end}}
 	return checkOlderNode({{.MaxVersion}}, h, dbCfg)
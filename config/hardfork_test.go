@@ -72,11 +72,15 @@ v2 = "9223"
 func TestCompatibility(t *testing.T) {
 	cfg := readConfig(`
 [hardfork]
-v2 = "9223"`,
+v2 = "9223"
+v3 = "10000"
+v4 = "11000"`,
 	)
 	dbCfg, _ := readDbConfig(`
 {
-	"V2": 18446744073709551615
+	"V2": 18446744073709551615,
+	"V3": 18446744073709551615,
+	"V4": 18446744073709551615
 }`,
 	)
 	err := cfg.CheckCompatibility(dbCfg, 10)
@@ -117,31 +121,57 @@ v2 = "9223"`,
 		t.Error(`the expected error: the fork "V2" is incompatible: latest block(9500), node(9223), and chain(9221)`)
 	}
 
+	dbCfg, _ = readDbConfig(`
+{
+	"V2": 9223
+}`,
+	)
+	err = cfg.CheckCompatibility(dbCfg, 9500)
+	if err != nil {
+		t.Error(err)
+	}
+
+	dbCfg, _ = readDbConfig(`
+{
+	"V2": 9223
+}`,
+	)
+	err = cfg.CheckCompatibility(dbCfg, 10000)
+	if err == nil {
+		t.Error(`the expected error: the fork "V3" is incompatible: latest block(10000), node(10000), and chain(18446744073709551615)`)
+	}
+
 	dbCfg, _ = readDbConfig(`
 {
 	"V2": 9223,
-	"V3": 10000
+	"V3": 10000,
+	"V4": 11000,
+	"V5": 10000
 }`,
 	)
 	err = cfg.CheckCompatibility(dbCfg, 10000)
 	if err == nil {
-		t.Error(`the expected error: the fork "V3" is incompatible: latest block(10000), node(0), and chain(10000)`)
+		t.Error(`the expected error: the fork "V5" is incompatible: latest block(10000), node(0), and chain(10000)`)
 	}
 
 	dbCfg, _ = readDbConfig(`
 {
 	"V2": 9223,
-	"V3": 10000
+	"V3": 10000,
+	"V4": 11000,
+	"V5": 10000
 }`,
 	)
 	err = cfg.CheckCompatibility(dbCfg, 10001)
 	if err == nil {
-		t.Error(`the expected error: the fork "V3" is incompatible: latest block(10000), node(0), and chain(10000)`)
+		t.Error(`the expected error: the fork "V5" is incompatible: latest block(10000), node(0), and chain(10000)`)
 	}
 
 	dbCfg, _ = readDbConfig(`
 {
 	"V2": 9223,
+	"V3": 10000,
+	"V4": 11000,
 	"VV": 10000
 }`,
 	)
@@ -158,7 +188,8 @@ func TestVersion(t *testing.T) {
 	cfg := readConfig(`
 [hardfork]
 v2 = "9223"
-v3 = "10000"`,
+v3 = "10000"
+v4 = "11000"`,
 	)
 	tests := []struct {
 		name string
@@ -185,13 +216,26 @@ v3 = "10000"`,
 			9322,
 			2,
 		},
-		/*
-			{
-				"greater v3",
-				19322,
-				3,
-			},
-		*/
+		{
+			"equal v3",
+			10000,
+			3,
+		},
+		{
+			"greater v3",
+			10322,
+			3,
+		},
+		{
+			"equal v4",
+			11000,
+			4,
+		},
+		{
+			"greater v4",
+			19322,
+			4,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -35,6 +35,8 @@ type BaseConfig struct {
 	ProfilePort    int    `mapstructure:"profileport" description:"profile port (default:6060)"`
 	EnableDump     bool   `mapstructure:"enabledump" description:"enable dump feature for debugging"`
 	DumpPort       int    `mapstructure:"dumpport" description:"dump port (default:7070)"`
+	EnableMetrics  bool   `mapstructure:"enablemetrics" description:"enable the prometheus metrics endpoint"`
+	MetricsPort    int    `mapstructure:"metricsport" description:"metrics port (default:9110)"`
 	EnableTestmode bool   `mapstructure:"enabletestmode" description:"enable unsafe test mode"`
 	UseTestnet     bool   `mapstructure:"usetestnet" description:"need description"`
 	Personal       bool   `mapstructure:"personal" description:"enable personal account service"`
@@ -54,6 +56,8 @@ type RPCConfig struct {
 	NSKey       string `mapstructure:"nskey" description:"Private Key file for RPC or REST API"`
 	NSCACert    string `mapstructure:"nscacert" description:"CA Certificate file for RPC or REST API"`
 	NSAllowCORS bool   `mapstructure:"nsallowcors" description:"Allow CORS to RPC or REST API"`
+	// JSON-RPC gateway
+	NSJsonRPCPort int `mapstructure:"nsjsonrpcport" description:"JSON-RPC over HTTP and WebSocket service port (0 to disable)"`
 }
 
 // P2PConfig defines configurations for p2p service
@@ -106,10 +110,15 @@ type BlockchainConfig struct {
 	ZeroFee          bool   `mapstructure:"zerofee" description:"enable zero-fee mode(deprecated)"`
 	VerifyOnly       bool   `mapstructure:"verifyonly" description:"In verify only mode, server verifies block chain of disk. server never modifies block chain'"`
 	StateTrace       uint64 `mapstructure:"statetrace" description:"dump trace of setting state"`
+	ProfileBlock     uint64 `mapstructure:"profileblock" description:"write the coverage and the gas profile of the contracts executed in the given block to the temporary directory"`
 	VerifyBlock      uint64 `mapstructure:"verifyblock" description:"In verify only mode, server verifies given block of disk. server never modifies block chain'"`
 	NumWorkers       int    `mapstructure:"numworkers" description:"maximum worker count for chainservice"`
 	NumLStateClosers int    `mapstructure:"numclosers" description:"maximum LuaVM state closer count for chainservice"`
 	CloseLimit       int    `mapstructure:"closelimit" description:"number of LuaVM states which a LuaVM state closer closes at one time"`
+	EventIndex       bool   `mapstructure:"eventindex" description:"maintain an on-disk index of contract events to speed up event queries"`
+	StateKeep        uint64 `mapstructure:"statekeep" description:"number of the latest block states to keep (0: keep all the states)"`
+	StatePruneIntvl  uint64 `mapstructure:"statepruneinterval" description:"number of blocks connected between state prunings"`
+	SnapshotBlock    string `mapstructure:"snapshotblock" description:"start an empty chain from the state snapshot of the trusted block given as <number>:<hash> instead of replaying all the blocks (empty: replay all the blocks)"`
 }
 
 // MempoolConfig defines configurations for mempool service
@@ -119,6 +128,8 @@ type MempoolConfig struct {
 	FadeoutPeriod  int    `mapstructure:"fadeoutperiod" description:"time period for evict transactions(in hour)"`
 	VerifierNumber int    `mapstructure:"verifiers" description:"number of concurrent verifier"`
 	DumpFilePath   string `mapstructure:"dumpfilepath" description:"file path for recording mempool at process termintation"`
+	EnableReplace  bool   `mapstructure:"enablereplace" description:"Enable replacing a pooled transaction by one with the same nonce and a higher gas price"`
+	ReplaceBump    uint32 `mapstructure:"replacebump" description:"minimum tip increase (in percent) for a replacing transaction"`
 }
 
 // ConsensusConfig defines configurations for consensus service
@@ -127,6 +138,11 @@ type ConsensusConfig struct {
 	BlockInterval       int64       `mapstructure:"blockinterval" description:"block production interval (sec)"`
 	Raft                *RaftConfig `mapstructure:"raft"`
 	NoTimeoutTxEviction bool        `mapstructure:"notte" description:"disable timeout tx eviction"`
+	Signer              string      `mapstructure:"signer" description:"URL of the remote signer which signs the produced blocks. the node key is used if empty"`
+	SignerAddress       string      `mapstructure:"signeraddress" description:"address of the block producer key in the remote signer"`
+	SignerCACert        string      `mapstructure:"signercacert" description:"CA certificate file to authenticate the remote signer"`
+	SignerCert          string      `mapstructure:"signercert" description:"client certificate file to authenticate to the remote signer"`
+	SignerKey           string      `mapstructure:"signerkey" description:"client key file to authenticate to the remote signer"`
 }
 
 type RaftConfig struct {
@@ -157,7 +173,11 @@ type MonitorConfig struct {
 
 // Account defines configurations for account service
 type AccountConfig struct {
-	UnlockTimeout uint `mapstructure:"unlocktimeout" description:"lock automatically after timeout (sec)"`
+	UnlockTimeout uint   `mapstructure:"unlocktimeout" description:"lock automatically after timeout (sec)"`
+	Signer        string `mapstructure:"signer" description:"URL of the remote signer which signs the transactions. the keystore is used if empty"`
+	SignerCACert  string `mapstructure:"signercacert" description:"CA certificate file to authenticate the remote signer"`
+	SignerCert    string `mapstructure:"signercert" description:"client certificate file to authenticate to the remote signer"`
+	SignerKey     string `mapstructure:"signerkey" description:"client key file to authenticate to the remote signer"`
 }
 
 type SQLConfig struct {
@@ -186,6 +206,8 @@ enableprofile = {{.BaseConfig.EnableProfile}}
 profileport = {{.BaseConfig.ProfilePort}}
 enabledump = {{.BaseConfig.EnableDump}}
 dumpport = {{.BaseConfig.DumpPort}}
+enablemetrics = {{.BaseConfig.EnableMetrics}}
+metricsport = {{.BaseConfig.MetricsPort}}
 personal = {{.BaseConfig.Personal}}
 authdir = "{{.BaseConfig.AuthDir}}"
 
@@ -198,6 +220,7 @@ nscert = "{{.RPC.NSCert}}"
 nskey = "{{.RPC.NSKey}}"
 nscacert = "{{.RPC.NSCACert}}"
 nsallowcors = {{.RPC.NSAllowCORS}}
+nsjsonrpcport = {{.RPC.NSJsonRPCPort}}
 
 [p2p]
 # Set address and port to which the inbound peers connect, and don't set loopback address or private network unless used in local network 
@@ -238,6 +261,10 @@ forceresetheight = "{{.Blockchain.ForceResetHeight}}"
 numworkers = "{{.Blockchain.NumWorkers}}"
 numclosers = "{{.Blockchain.NumLStateClosers}}"
 closelimit = "{{.Blockchain.CloseLimit}}"
+eventindex = {{.Blockchain.EventIndex}}
+statekeep = {{.Blockchain.StateKeep}}
+statepruneinterval = {{.Blockchain.StatePruneIntvl}}
+snapshotblock = "{{.Blockchain.SnapshotBlock}}"
 
 [mempool]
 showmetrics = {{.Mempool.ShowMetrics}}
@@ -245,10 +272,17 @@ enablefadeout = {{.Mempool.EnableFadeout}}
 fadeoutperiod = {{.Mempool.FadeoutPeriod}}
 verifiers = {{.Mempool.VerifierNumber}}
 dumpfilepath = "{{.Mempool.DumpFilePath}}"
+enablereplace = {{.Mempool.EnableReplace}}
+replacebump = {{.Mempool.ReplaceBump}}
 
 [consensus]
 enablebp = {{.Consensus.EnableBp}}
 blockinterval = {{.Consensus.BlockInterval}}
+signer = "{{.Consensus.Signer}}"
+signeraddress = "{{.Consensus.SignerAddress}}"
+signercacert = "{{.Consensus.SignerCACert}}"
+signercert = "{{.Consensus.SignerCert}}"
+signerkey = "{{.Consensus.SignerKey}}"
 
 [monitor]
 protocol = "{{.Monitor.ServerProtocol}}"
@@ -256,6 +290,10 @@ endpoint = "{{.Monitor.ServerEndpoint}}"
 
 [account]
 unlocktimeout = "{{.Account.UnlockTimeout}}"
+signer = "{{.Account.Signer}}"
+signercacert = "{{.Account.SignerCACert}}"
+signercert = "{{.Account.SignerCert}}"
+signerkey = "{{.Account.SignerKey}}"
 
 [auth]
 enablelocalconf = "{{.Auth.EnableLocalConf}}"
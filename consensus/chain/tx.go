@@ -83,6 +83,23 @@ func newBlockLimitOp(maxBlockBodySize uint32) TxOpFn {
 	})
 }
 
+// executableTXs drops the txs whose time locks don't allow them to be included
+// in the block of bi. The mempool already holds them back, but its clock may
+// differ from the timestamp of the block.
+func executableTXs(txs []types.Transaction, bi *types.BlockHeaderInfo) []types.Transaction {
+	res := txs[:0]
+	for _, tx := range txs {
+		if err := tx.GetBody().CheckTimeLock(bi.No, bi.Ts); err != nil {
+			if logger.IsDebugEnabled() {
+				logger.Debug().Err(err).Str("hash", enc.ToString(tx.GetHash())).Msg("skip time-locked tx")
+			}
+			continue
+		}
+		res = append(res, tx)
+	}
+	return res
+}
+
 // Lock aquires the chain lock in a blocking mode.
 func Lock() {
 	chain.InAddBlock <- struct{}{}
@@ -123,7 +140,7 @@ func (g *BlockGenerator) GatherTXs() ([]types.Transaction, error) {
 	}
 	defer Unlock()
 
-	txIn := g.fetchTXs(g.hs, g.maxBlockBodySize)
+	txIn := executableTXs(g.fetchTXs(g.hs, g.maxBlockBodySize), g.bi)
 	nCand = len(txIn)
 
 	txRes := make([]types.Transaction, 0, nCand)
@@ -199,7 +216,7 @@ func (g *BlockGenerator) GatherTXs() ([]types.Transaction, error) {
 		return nil, err
 	}
 
-	if err := contract.SaveRecoveryPoint(bState); err != nil {
+	if err := contract.SaveRecoveryPoint(bState, g.bi); err != nil {
 		return nil, err
 	}
 
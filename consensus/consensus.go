@@ -108,6 +108,8 @@ type AergoRaftAccessor interface {
 	GetMemberByPeerID(peerID types.PeerID) *Member
 }
 
+// ConsensusType identifies a consensus implementation. The built-in ones are
+// listed below and the others are assigned by RegisterType.
 type ConsensusType int
 
 const (
@@ -116,11 +118,14 @@ const (
 	ConsensusSBP
 )
 
+// ConsensusName is the names of the consensus types indexed by ConsensusType.
 var ConsensusName = []string{"dpos", "raft", "sbp"}
 var ConsensusTypes = map[string]ConsensusType{"dpos": ConsensusDPOS, "raft": ConsensusRAFT, "sbp": ConsensusSBP}
 
 var CurConsensusType ConsensusType
 
+var clusterTypes = map[ConsensusType]bool{ConsensusRAFT: true}
+
 func IsRaftName(consensus string) bool {
 	return ConsensusName[ConsensusRAFT] == strings.ToLower(consensus)
 }
@@ -140,6 +145,12 @@ func UseDpos() bool {
 	return CurConsensusType == ConsensusDPOS
 }
 
+// UseCluster returns true if the block producers of the current consensus are
+// a cluster whose membership is changed by the enterprise ChangeCluster tx.
+func UseCluster() bool {
+	return clusterTypes[CurConsensusType]
+}
+
 // ChainConsensus includes chainstatus and validation API.
 type ChainConsensus interface {
 	ChainConsensusCluster
@@ -159,6 +170,12 @@ type ChainConsensus interface {
 	Info() string
 }
 
+// BFTAccessor is implemented by a BFT consensus to receive the messages of
+// the validators from the p2p layer.
+type BFTAccessor interface {
+	HandleBFTMessage(from types.PeerID, msg *types.BFTMessage) error
+}
+
 type ChainConsensusCluster interface {
 	MakeConfChangeProposal(req *types.MembershipChange) (*ConfChangePropose, error)
 }
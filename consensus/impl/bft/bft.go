@@ -0,0 +1,486 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package bft
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aergoio/aergo-lib/log"
+	bc "github.com/aergoio/aergo/chain"
+	"github.com/aergoio/aergo/config"
+	"github.com/aergoio/aergo/consensus"
+	"github.com/aergoio/aergo/consensus/chain"
+	"github.com/aergoio/aergo/contract"
+	"github.com/aergoio/aergo/contract/enterprise"
+	"github.com/aergoio/aergo/internal/enc"
+	"github.com/aergoio/aergo/message"
+	"github.com/aergoio/aergo/p2p/p2pkey"
+	"github.com/aergoio/aergo/pkg/component"
+	"github.com/aergoio/aergo/state"
+	"github.com/aergoio/aergo/types"
+	"github.com/golang/protobuf/proto"
+)
+
+const (
+	inboxMax      = 1024
+	minTickPeriod = 50 * time.Millisecond
+)
+
+var logger *log.Logger
+
+var (
+	ErrInvalidConsensusName = errors.New("invalid consensus name")
+	ErrInboxFull            = errors.New("bft message queue is full")
+)
+
+func init() {
+	logger = log.NewLogger("bft")
+}
+
+// ReceiptReader reads the receipts of a connected block.
+type ReceiptReader interface {
+	GetReceipts(blockHash []byte, blockNo types.BlockNo) (*types.Receipts, error)
+}
+
+type txExec struct {
+	execTx bc.TxExecFn
+}
+
+func newTxExec(ccc consensus.ChainConsensusCluster, cdb consensus.ChainDB, bi *types.BlockHeaderInfo) chain.TxOp {
+	// Block hash not determined yet
+	return &txExec{
+		execTx: bc.NewTxExecutor(ccc, cdb, bi, contract.BlockFactory),
+	}
+}
+
+func (te *txExec) Apply(bState *state.BlockState, tx types.Transaction) error {
+	err := te.execTx(bState, tx)
+	return err
+}
+
+// BFT is a Tendermint-style consensus for a permissioned network. The
+// validators propose, prevote and precommit a block of each height in rounds
+// and the block is final once it is committed, so no fork happens. The
+// validators are the enterprise BPs of genesis and changed by the enterprise
+// ChangeCluster tx like raft.
+type BFT struct {
+	*component.ComponentHub
+	consensus.ChainDB
+	receipts ReceiptReader
+
+	jobQueue         chan interface{}
+	inbox            chan *types.BFTMessage
+	quit             chan interface{}
+	blockInterval    time.Duration
+	maxBlockBodySize uint32
+	txOp             chain.TxOp
+	sdb              *state.ChainStateDB
+	bv               types.BlockVersionner
+	enableBp         bool
+	self             types.PeerID
+	signer           types.BlockSigner
+	signVerifier     *bc.SignVerifier
+
+	vals *validators
+	rs   *roundState
+	// pending is the certificates to be saved with the next block connected.
+	pending []*types.BFTCommit
+
+	mu sync.RWMutex
+	// lastCommit is the certificate of the block committed last by this
+	// node.
+	lastCommit *types.BFTCommit
+	status     *status
+}
+
+// GetName returns the name of the consensus.
+func GetName() string {
+	return "bft"
+}
+
+// GetConstructor build and returns consensus.Constructor from New function.
+func GetConstructor(cfg *config.Config, hub *component.ComponentHub, cdb consensus.ChainDB,
+	sdb *state.ChainStateDB, receipts ReceiptReader) consensus.Constructor {
+	return func() (consensus.Consensus, error) {
+		return New(cfg, hub, cdb, sdb, receipts)
+	}
+}
+
+// New returns a BFT consensus.
+func New(cfg *config.Config, hub *component.ComponentHub, cdb consensus.ChainDB,
+	sdb *state.ChainStateDB, receipts ReceiptReader) (*BFT, error) {
+	vals, err := loadValidators(cdb.Get(validatorsKey))
+	if err != nil {
+		return nil, err
+	}
+	if vals == nil {
+		initial, err := genesisValidators(cdb.GetGenesisInfo())
+		if err != nil {
+			return nil, err
+		}
+		vals = newValidators(initial)
+	}
+
+	bft := &BFT{
+		ComponentHub:     hub,
+		ChainDB:          cdb,
+		receipts:         receipts,
+		jobQueue:         make(chan interface{}, 1),
+		inbox:            make(chan *types.BFTMessage, inboxMax),
+		quit:             make(chan interface{}),
+		blockInterval:    consensus.BlockInterval,
+		maxBlockBodySize: chain.MaxBlockBodySize(),
+		sdb:              sdb,
+		bv:               cfg.Hardfork,
+		enableBp:         cfg.Consensus.EnableBp,
+		self:             p2pkey.BlockProducerID(),
+		signer:           p2pkey.BlockSigner(),
+		signVerifier:     bc.NewSignVerifier(nil, sdb, bc.VerifierCount, false),
+		vals:             vals,
+		status:           &status{},
+	}
+	bft.txOp = chain.NewCompTxOp(
+		chain.TxOpFn(func(bState *state.BlockState, txIn types.Transaction) error {
+			select {
+			case <-bft.quit:
+				return chain.ErrQuit
+			default:
+				return nil
+			}
+		}),
+	)
+	return bft, nil
+}
+
+// Ticker returns a time.Ticker for the main consensus loop. It's also used to
+// check the timeouts of the rounds.
+func (bft *BFT) Ticker() *time.Ticker {
+	period := bft.blockInterval / 10
+	if period < minTickPeriod {
+		period = minTickPeriod
+	}
+	return time.NewTicker(period)
+}
+
+// QueueJob sends the best block to jq.
+func (bft *BFT) QueueJob(now time.Time, jq chan<- interface{}) {
+	if b, _ := bft.GetBestBlock(); b != nil {
+		select {
+		case jq <- b:
+		default:
+		}
+	}
+}
+
+func (bft *BFT) GetType() consensus.ConsensusType {
+	t, _ := consensus.TypeByName(GetName())
+	return t
+}
+
+// IsTransactionValid checks the onsensus level validity of a transaction
+func (bft *BFT) IsTransactionValid(tx *types.Tx) bool {
+	// BFT has no tx valid check.
+	return true
+}
+
+// VerifyTimestamp checks the validity of the block timestamp.
+func (bft *BFT) VerifyTimestamp(*types.Block) bool {
+	// BFT don't need to check timestamp.
+	return true
+}
+
+// VerifySign checks the consensus level validity of a block.
+func (bft *BFT) VerifySign(block *types.Block) error {
+	valid, err := block.VerifySign()
+	if !valid || err != nil {
+		return &consensus.ErrorConsensus{Msg: "bad block signature", Err: err}
+	}
+	return nil
+}
+
+// IsBlockValid checks that block is produced by a validator and it has the
+// certificate of its parent.
+func (bft *BFT) IsBlockValid(block *types.Block, bestBlock *types.Block) error {
+	bpID, err := block.BPID()
+	if err != nil {
+		return &consensus.ErrorConsensus{Msg: "bad public key in block", Err: err}
+	}
+	if !bft.vals.at(block.BlockNo()).has(bpID) {
+		return &consensus.ErrorConsensus{Msg: "block producer is not a validator: " + types.IDB58Encode(bpID)}
+	}
+	if _, err := bft.parentCommit(block); err != nil {
+		return &consensus.ErrorConsensus{Msg: "bad commit certificate of parent block", Err: err}
+	}
+	return nil
+}
+
+// parentCommit returns the verified certificate of the parent of block. The
+// children of genesis have no certificate.
+func (bft *BFT) parentCommit(block *types.Block) (*types.BFTCommit, error) {
+	no := block.BlockNo()
+	if no <= 1 {
+		return nil, nil
+	}
+	commit, err := types.DecodeBFTCommit(block.GetHeader().GetConsensus())
+	if err != nil {
+		return nil, err
+	}
+	if commit.GetHeight() != no-1 || !bytes.Equal(commit.GetBlockHash(), block.GetHeader().GetPrevBlockHash()) {
+		return nil, types.ErrBFTCommitInvalid
+	}
+	if err := commit.Verify(bft.vals.at(no-1).ids(), block.GetHeader().GetChainID()); err != nil {
+		return nil, err
+	}
+	return commit, nil
+}
+
+// QuitChan returns the channel from which consensus-related goroutines check
+// when shutdown is initiated.
+func (bft *BFT) QuitChan() chan interface{} {
+	return bft.quit
+}
+
+// Update keeps the certificates of block and its parent and applies the
+// validator changes of the ChangeCluster txs succeeded in block. The new
+// validators decide the blocks after block.
+func (bft *BFT) Update(block *types.Block) {
+	if commit, err := bft.parentCommit(block); err == nil && commit != nil {
+		bft.pending = append(bft.pending, commit)
+	}
+	if commit := bft.getLastCommit(); commit != nil && bytes.Equal(commit.GetBlockHash(), block.BlockHash()) {
+		bft.pending = append(bft.pending, commit)
+	}
+
+	for _, req := range bft.clusterChanges(block) {
+		if err := bft.vals.apply(block.BlockNo(), req); err != nil {
+			logger.Warn().Err(err).Str("change", req.ToString()).Uint64("no", block.BlockNo()).Msg("skip validator change")
+			continue
+		}
+		logger.Info().Str("change", req.ToString()).Uint64("from", block.BlockNo()+1).Msg("validators changed")
+	}
+}
+
+// clusterChanges returns the requests of the succeeded ChangeCluster txs in
+// block.
+func (bft *BFT) clusterChanges(block *types.Block) []*types.MembershipChange {
+	var (
+		reqs     []*types.MembershipChange
+		receipts []*types.Receipt
+	)
+	for i, tx := range block.GetBody().GetTxs() {
+		body := tx.GetBody()
+		if string(body.GetRecipient()) != types.AergoEnterprise {
+			continue
+		}
+		var ci types.CallInfo
+		if err := json.Unmarshal(body.GetPayload(), &ci); err != nil || ci.Name != enterprise.ChangeCluster {
+			continue
+		}
+		if receipts == nil {
+			if bft.receipts == nil {
+				return nil
+			}
+			rs, err := bft.receipts.GetReceipts(block.BlockHash(), block.BlockNo())
+			if err != nil {
+				logger.Error().Err(err).Uint64("no", block.BlockNo()).Msg("failed to read receipts of ChangeCluster")
+				return nil
+			}
+			receipts = rs.Get()
+		}
+		if i >= len(receipts) || receipts[i].GetStatus() != "SUCCESS" {
+			continue
+		}
+		req, err := enterprise.ValidateChangeCluster(ci, block.BlockNo())
+		if err != nil {
+			continue
+		}
+		reqs = append(reqs, req.(*types.MembershipChange))
+	}
+	return reqs
+}
+
+// Save writes the validators and the certificates kept by Update.
+func (bft *BFT) Save(tx consensus.TxWriter) error {
+	for _, commit := range bft.pending {
+		if err := writeCommit(tx, commit); err != nil {
+			return err
+		}
+	}
+	bft.pending = nil
+
+	bft.vals.Lock()
+	defer bft.vals.Unlock()
+	if !bft.vals.dirty {
+		return nil
+	}
+	data, err := bft.vals.marshal()
+	if err != nil {
+		return err
+	}
+	tx.Set(validatorsKey, data)
+	bft.vals.dirty = false
+	return nil
+}
+
+// BlockFactory returns bft itself.
+func (bft *BFT) BlockFactory() consensus.BlockFactory {
+	return bft
+}
+
+// NeedReorganization returns false since a committed block is final.
+func (bft *BFT) NeedReorganization(rootNo types.BlockNo) bool {
+	return false
+}
+
+// JobQueue returns the queue for block production triggering.
+func (bft *BFT) JobQueue() chan<- interface{} {
+	return bft.jobQueue
+}
+
+// HandleBFTMessage queues msg received from a peer.
+func (bft *BFT) HandleBFTMessage(from types.PeerID, msg *types.BFTMessage) error {
+	select {
+	case bft.inbox <- msg:
+		return nil
+	default:
+		return ErrInboxFull
+	}
+}
+
+// broadcast sends msg to the other validators through p2p.
+func (bft *BFT) broadcast(msg *types.BFTMessage) {
+	bft.Tell(message.P2PSvc, &message.SendBFT{Body: msg})
+}
+
+// Info returns the status of the rounds and the last commit.
+func (bft *BFT) Info() string {
+	info := consensus.NewInfo(GetName())
+	b, err := json.Marshal(bft.getStatus())
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to marshal bft status")
+	} else {
+		m := json.RawMessage(b)
+		info.Status = &m
+	}
+	return info.AsJSON()
+}
+
+func (bft *BFT) ConsensusInfo() *types.ConsensusInfo {
+	ci := &types.ConsensusInfo{Type: GetName()}
+	if b, err := json.Marshal(bft.getStatus()); err == nil {
+		ci.Info = string(b)
+	}
+
+	type validatorInfo struct {
+		Name   string
+		ID     string
+		PeerID string
+		Addr   string
+	}
+	for _, m := range bft.vals.latest().Members {
+		v := &validatorInfo{Name: m.Name, ID: idToString(m.ID), PeerID: types.IDB58Encode(types.PeerID(m.PeerID)), Addr: m.Address}
+		if b, err := json.Marshal(v); err == nil {
+			ci.Bps = append(ci.Bps, string(b))
+		}
+	}
+	return ci
+}
+
+var dummyRaft consensus.DummyRaftAccessor
+
+func (bft *BFT) RaftAccessor() consensus.AergoRaftAccessor {
+	return &dummyRaft
+}
+
+func (bft *BFT) NeedNotify() bool {
+	return true
+}
+
+func (bft *BFT) HasWAL() bool {
+	return false
+}
+
+func (bft *BFT) IsForkEnable() bool {
+	return false
+}
+
+func (bft *BFT) IsConnectedBlock(block *types.Block) bool {
+	_, err := bft.ChainDB.GetBlock(block.BlockHash())
+	if err == nil {
+		return true
+	}
+
+	return false
+}
+
+func (bft *BFT) ConfChange(req *types.MembershipChange) (*consensus.Member, error) {
+	return nil, consensus.ErrNotSupportedMethod
+}
+
+func (bft *BFT) ConfChangeInfo(requestID uint64) (*types.ConfChangeProgress, error) {
+	return nil, consensus.ErrNotSupportedMethod
+}
+
+// MakeConfChangeProposal checks the validator change requested by the
+// ChangeCluster tx against the current validators. The change is applied by
+// Update once the block including the tx is connected.
+func (bft *BFT) MakeConfChangeProposal(req *types.MembershipChange) (*consensus.ConfChangePropose, error) {
+	if err := bft.vals.latest().check(req); err != nil {
+		return nil, err
+	}
+	return &consensus.ConfChangePropose{Ctx: context.Background()}, nil
+}
+
+// ClusterInfo returns the current validators.
+func (bft *BFT) ClusterInfo(bestBlockHash []byte) *types.GetClusterInfoResponse {
+	resp := &types.GetClusterInfoResponse{MbrAttrs: bft.vals.latest().Members}
+	if best, err := bft.GetBestBlock(); err == nil {
+		resp.ChainID = best.GetHeader().GetChainID()
+		resp.BestBlockNo = best.BlockNo()
+	}
+	return resp
+}
+
+// ValidateGenesis checks that genesis has the valid enterprise BPs as the
+// validators.
+func ValidateGenesis(genesis *types.Genesis) error {
+	if strings.ToLower(genesis.ID.Consensus) != GetName() {
+		return ErrInvalidConsensusName
+	}
+	if _, err := genesisValidators(genesis); err != nil {
+		logger.Error().Err(err).Msg("failed to parse validators of Genesis block")
+		return err
+	}
+	return nil
+}
+
+func commitKey(blockHash []byte) []byte {
+	return append([]byte("bft.commit."), blockHash...)
+}
+
+func writeCommit(tx consensus.TxWriter, commit *types.BFTCommit) error {
+	data, err := proto.Marshal(commit)
+	if err != nil {
+		return err
+	}
+	tx.Set(commitKey(commit.GetBlockHash()), data)
+	return nil
+}
+
+// GetCommit returns the certificate of the block of blockHash.
+func (bft *BFT) GetCommit(blockHash []byte) (*types.BFTCommit, error) {
+	data := bft.ChainDB.Get(commitKey(blockHash))
+	if len(data) == 0 {
+		return nil, errors.New("no commit certificate of block " + enc.ToString(blockHash))
+	}
+	return types.DecodeBFTCommit(data)
+}
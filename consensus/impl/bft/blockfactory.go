@@ -0,0 +1,511 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package bft
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	bc "github.com/aergoio/aergo/chain"
+	"github.com/aergoio/aergo/consensus/chain"
+	"github.com/aergoio/aergo/contract"
+	"github.com/aergoio/aergo/contract/system"
+	"github.com/aergoio/aergo/internal/enc"
+	"github.com/aergoio/aergo/state"
+	"github.com/aergoio/aergo/types"
+	"github.com/golang/protobuf/proto"
+)
+
+const connectTimeout = 300 * time.Second
+
+var (
+	errNoLastCommit   = errors.New("no commit certificate of the best block")
+	errNotProposer    = errors.New("proposal of a non-proposer")
+	errProposalHeight = errors.New("proposal of another height")
+	errProposalBlock  = errors.New("proposal mismatches its block")
+	errProposalTxSign = errors.New("proposal has a transaction of a bad signature")
+)
+
+// Start runs the rounds of the BFT consensus. Each height starts when the
+// best block changes and ends when a block is committed and connected.
+func (bft *BFT) Start() {
+	defer logger.Info().Msg("shutdown initiated. stop the service")
+
+	for {
+		select {
+		case e := <-bft.jobQueue:
+			if best, ok := e.(*types.Block); ok {
+				bft.tick(best, time.Now())
+			}
+		case msg := <-bft.inbox:
+			if bft.rs == nil {
+				continue
+			}
+			if err := bft.handleMessage(msg, time.Now()); err != nil {
+				logger.Debug().Err(err).Msg("drop bft message")
+			}
+		case <-bft.quit:
+			return
+		}
+	}
+}
+
+// timeout returns the duration of a step in round, which grows by the round
+// so that the validators eventually agree after a network delay.
+func (bft *BFT) timeout(round uint32) time.Duration {
+	return bft.blockInterval + time.Duration(round)*bft.blockInterval/2
+}
+
+// isValidator returns true if this node votes for the blocks at height.
+func (bft *BFT) isValidator(height types.BlockNo) bool {
+	return bft.enableBp && bft.vals.at(height).has(bft.self)
+}
+
+func (bft *BFT) tick(best *types.Block, now time.Time) {
+	if bft.rs == nil || best.BlockNo()+1 != bft.rs.height {
+		bft.newHeight(best, now)
+	}
+
+	rs := bft.rs
+	switch {
+	case rs.step == stepPropose && !now.Before(rs.start) && rs.proposals[rs.round] == nil &&
+		bft.vals.at(rs.height).proposer(rs.height, rs.round) == bft.self && bft.isValidator(rs.height):
+		bft.propose(now)
+	case now.Before(rs.deadline):
+	case rs.step == stepPropose:
+		logger.Debug().Uint64("height", rs.height).Uint32("round", rs.round).Msg("proposal timed out")
+		bft.prevote(nil, now)
+	case rs.step == stepPrevote:
+		bft.precommit(nil, now)
+	case rs.step == stepPrecommit:
+		bft.enterRound(rs.round+1, now)
+	case rs.step == stepCommit:
+		// The committed block failed to connect. Try again.
+		hash := bft.getLastCommit().GetBlockHash()
+		if p := rs.block(hash); p != nil {
+			rs.deadline = now.Add(bft.timeout(rs.round))
+			bft.connect(p)
+		}
+	}
+}
+
+// newHeight starts the rounds of the block after best.
+func (bft *BFT) newHeight(best *types.Block, now time.Time) {
+	var lastCommit *types.BFTCommit
+	if best.BlockNo() > 0 {
+		if c := bft.getLastCommit(); c != nil && bytes.Equal(c.GetBlockHash(), best.BlockHash()) {
+			lastCommit = c
+		} else if c, err := bft.GetCommit(best.BlockHash()); err == nil {
+			lastCommit = c
+		} else {
+			logger.Warn().Err(err).Uint64("best", best.BlockNo()).Msg("can't propose without the certificate of the best block")
+		}
+	}
+
+	bft.rs = newRoundState(best, now, lastCommit)
+	bft.enterRound(0, now.Add(bft.blockInterval))
+}
+
+// enterRound starts round at start, when its proposer proposes a block.
+func (bft *BFT) enterRound(round uint32, start time.Time) {
+	rs := bft.rs
+	rs.round = round
+	rs.start = start
+	rs.setStep(stepPropose, start.Add(bft.timeout(round)))
+	bft.updateStatus()
+
+	logger.Debug().Uint64("height", rs.height).Uint32("round", round).
+		Str("proposer", types.IDB58Encode(bft.vals.at(rs.height).proposer(rs.height, round))).Msg("enter round")
+
+	if hash := rs.proposals[round]; hash != nil && !start.After(time.Now()) {
+		bft.prevote(bft.prevoteHash(hash), time.Now())
+	}
+}
+
+// propose broadcasts the locked block or a new block as the proposal of the
+// current round.
+func (bft *BFT) propose(now time.Time) {
+	rs := bft.rs
+
+	p := rs.block(rs.lockedHash)
+	if p == nil {
+		block, blockState, err := bft.generateBlock(rs.parent, rs.lastCommit, now)
+		if err != nil {
+			logger.Info().Err(err).Uint64("height", rs.height).Uint32("round", rs.round).Msg("failed to produce block")
+			bft.prevote(nil, now)
+			return
+		}
+		p = &proposal{block: block, state: blockState}
+		rs.blocks[string(block.BlockHash())] = p
+	}
+
+	vote := &types.BFTVote{Type: types.BFTVoteType_PROPOSAL, Height: rs.height, Round: rs.round, BlockHash: p.block.BlockHash()}
+	if err := vote.SignWith(bft.signer, rs.parent.GetHeader().GetChainID()); err != nil {
+		logger.Error().Err(err).Msg("failed to sign proposal")
+		return
+	}
+	rs.proposals[rs.round] = vote.BlockHash
+	bft.broadcast(&types.BFTMessage{Vote: vote, Block: p.block})
+
+	logger.Info().Uint64("no", rs.height).Uint32("round", rs.round).Str("hash", p.block.ID()).Msg("block proposed")
+
+	bft.prevote(bft.prevoteHash(vote.BlockHash), now)
+}
+
+func (bft *BFT) generateBlock(parent *types.Block, lastCommit *types.BFTCommit, now time.Time) (*types.Block, *state.BlockState, error) {
+	var (
+		commit []byte
+		err    error
+	)
+	if parent.BlockNo() > 0 {
+		if lastCommit == nil {
+			return nil, nil, errNoLastCommit
+		}
+		if commit, err = proto.Marshal(lastCommit); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	bi := types.NewBlockHeaderInfoFromPrevBlock(parent, now.UnixNano(), bft.bv)
+	txOp := chain.NewCompTxOp(bft.txOp, newTxExec(bft, bft.ChainDB, bi))
+	blockState := bft.sdb.NewBlockState(
+		parent.GetHeader().GetBlocksRootHash(),
+		state.SetPrevBlockHash(parent.BlockHash()),
+	)
+	blockState.SetGasPrice(system.GetGasPriceFromState(blockState))
+	blockState.Receipts().SetHardFork(bft.bv, bi.No)
+	blockState.SetConsensus(commit)
+
+	block, err := chain.NewBlockGenerator(bft, bi, blockState, txOp, false).GenerateBlock()
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = block.Sign(bft.signer); err != nil {
+		return nil, nil, err
+	}
+
+	logger.Info().Str("blockProducer", types.IDB58Encode(bft.self)).
+		Str("sroot", enc.ToString(block.GetHeader().GetBlocksRootHash())).
+		Uint64("no", block.BlockNo()).
+		Str("hash", block.ID()).
+		Msg("block produced")
+
+	return block, blockState, nil
+}
+
+// prevoteHash returns the block to prevote when hash is proposed. A locked
+// validator keeps voting its locked block.
+func (bft *BFT) prevoteHash(hash []byte) []byte {
+	if bft.rs.lockedHash != nil {
+		return bft.rs.lockedHash
+	}
+	return hash
+}
+
+func (bft *BFT) prevote(hash []byte, now time.Time) {
+	rs := bft.rs
+	rs.setStep(stepPrevote, now.Add(bft.timeout(rs.round)))
+	bft.vote(types.BFTVoteType_PREVOTE, hash)
+	bft.updateStatus()
+	bft.checkVotes(rs.round, now)
+}
+
+func (bft *BFT) precommit(hash []byte, now time.Time) {
+	rs := bft.rs
+	if hash != nil {
+		rs.lockedRound = int64(rs.round)
+		rs.lockedHash = hash
+	}
+	rs.setStep(stepPrecommit, now.Add(bft.timeout(rs.round)))
+	bft.vote(types.BFTVoteType_PRECOMMIT, hash)
+	bft.updateStatus()
+	bft.checkVotes(rs.round, now)
+}
+
+// vote signs and broadcasts a vote of the current round, if this node is a
+// validator.
+func (bft *BFT) vote(t types.BFTVoteType, hash []byte) {
+	rs := bft.rs
+	if !bft.isValidator(rs.height) {
+		return
+	}
+	vote := &types.BFTVote{Type: t, Height: rs.height, Round: rs.round, BlockHash: hash}
+	if err := vote.SignWith(bft.signer, rs.parent.GetHeader().GetChainID()); err != nil {
+		logger.Error().Err(err).Str("type", t.String()).Msg("failed to sign vote")
+		return
+	}
+	rs.addVote(vote)
+	bft.broadcast(&types.BFTMessage{Vote: vote})
+}
+
+// checkVotes moves the steps by the votes of round.
+func (bft *BFT) checkVotes(round uint32, now time.Time) {
+	rs := bft.rs
+	if rs.step == stepCommit {
+		return
+	}
+	quorum := bft.vals.at(rs.height).quorum()
+
+	if hash, ok := rs.votes(types.BFTVoteType_PRECOMMIT, round).major(quorum); ok {
+		if len(hash) > 0 {
+			if p := rs.block(hash); p != nil {
+				bft.commit(rs.commitOf(round, hash), p, now)
+			}
+			// Otherwise, the proposal is missed. Wait for the commit
+			// broadcast by the other validators.
+			return
+		}
+		if round == rs.round {
+			bft.enterRound(round+1, now)
+			return
+		}
+	}
+
+	hash, ok := rs.votes(types.BFTVoteType_PREVOTE, round).major(quorum)
+	if !ok {
+		return
+	}
+	if int64(round) > rs.lockedRound && !bytes.Equal(hash, rs.lockedHash) {
+		// A quorum agreed on another block after this node locked.
+		rs.lockedRound = -1
+		rs.lockedHash = nil
+	}
+	if round != rs.round || rs.step != stepPrevote {
+		return
+	}
+	if len(hash) > 0 && rs.block(hash) != nil {
+		bft.precommit(hash, now)
+	} else {
+		bft.precommit(nil, now)
+	}
+}
+
+// commit connects the block of p certified by commit, and broadcasts them
+// for the validators which missed the precommits.
+func (bft *BFT) commit(commit *types.BFTCommit, p *proposal, now time.Time) {
+	rs := bft.rs
+	rs.setStep(stepCommit, now.Add(bft.timeout(rs.round)))
+	bft.setLastCommit(commit)
+	bft.updateStatus()
+
+	logger.Info().Uint64("no", commit.GetHeight()).Uint32("round", commit.GetRound()).
+		Str("hash", p.block.ID()).Int("votes", len(commit.GetVotes())).Msg("block committed")
+
+	bft.broadcast(&types.BFTMessage{Block: p.block, Commit: commit})
+	bft.connect(p)
+}
+
+func (bft *BFT) connect(p *proposal) {
+	if err := chain.ConnectBlock(bft, p.block, p.state, connectTimeout); err != nil {
+		logger.Error().Err(err).Uint64("no", p.block.BlockNo()).Msg("failed to connect committed block")
+	}
+}
+
+func (bft *BFT) handleMessage(msg *types.BFTMessage, now time.Time) error {
+	if msg.GetCommit() != nil {
+		return bft.handleCommit(msg.GetCommit(), msg.GetBlock(), now)
+	}
+
+	rs := bft.rs
+	vote := msg.GetVote()
+	if vote == nil || vote.GetHeight() != rs.height {
+		return nil
+	}
+	vs := bft.vals.at(rs.height)
+	if !vs.has(types.PeerID(vote.GetValidator())) {
+		return types.ErrBFTNotValidator
+	}
+	if err := vote.VerifySign(rs.parent.GetHeader().GetChainID()); err != nil {
+		return err
+	}
+
+	switch vote.GetType() {
+	case types.BFTVoteType_PROPOSAL:
+		if err := bft.handleProposal(vote, msg.GetBlock()); err != nil {
+			return err
+		}
+	case types.BFTVoteType_PREVOTE, types.BFTVoteType_PRECOMMIT:
+		if !rs.addVote(vote) {
+			return nil
+		}
+	default:
+		return types.ErrBFTInvalidVote
+	}
+
+	round := vote.GetRound()
+	if round > rs.round && rs.step != stepCommit && rs.voters(round) > vs.size()-vs.quorum() {
+		// At least a correct validator is in the later round.
+		bft.enterRound(round, now)
+	}
+	if round == rs.round && rs.step == stepPropose && vote.GetType() == types.BFTVoteType_PROPOSAL &&
+		!now.Before(rs.start) {
+		bft.prevote(bft.prevoteHash(vote.GetBlockHash()), now)
+		return nil
+	}
+	bft.checkVotes(round, now)
+	return nil
+}
+
+func (bft *BFT) handleProposal(vote *types.BFTVote, block *types.Block) error {
+	rs := bft.rs
+	if rs.proposals[vote.GetRound()] != nil {
+		return nil
+	}
+	if types.PeerID(vote.GetValidator()) != bft.vals.at(rs.height).proposer(rs.height, vote.GetRound()) {
+		return errNotProposer
+	}
+	if block == nil || !bytes.Equal(block.BlockHash(), vote.GetBlockHash()) {
+		return errProposalBlock
+	}
+	if p := rs.block(block.BlockHash()); p == nil {
+		blockState, err := bft.validateBlock(block)
+		if err != nil {
+			return err
+		}
+		rs.blocks[string(block.BlockHash())] = &proposal{block: block, state: blockState}
+	}
+	rs.proposals[vote.GetRound()] = vote.GetBlockHash()
+	return nil
+}
+
+// handleCommit connects block certified by commit, which this node missed.
+func (bft *BFT) handleCommit(commit *types.BFTCommit, block *types.Block, now time.Time) error {
+	rs := bft.rs
+	if commit.GetHeight() != rs.height || rs.step == stepCommit {
+		return nil
+	}
+	if block == nil || !bytes.Equal(block.BlockHash(), commit.GetBlockHash()) {
+		return types.ErrBFTCommitInvalid
+	}
+	if err := commit.Verify(bft.vals.at(rs.height).ids(), rs.parent.GetHeader().GetChainID()); err != nil {
+		return err
+	}
+	p := rs.block(block.BlockHash())
+	if p == nil {
+		// Even a certified block is executed, since it can't be reverted
+		// after it's connected.
+		blockState, err := bft.validateBlock(block)
+		if err != nil {
+			return err
+		}
+		p = &proposal{block: block, state: blockState}
+	}
+	bft.commit(commit, p, now)
+	return nil
+}
+
+// validateBlock checks a block proposed for the current height and executes
+// its transactions on the state of the parent, so that a block is never
+// prevoted unless its state and receipts match its header. It returns the
+// block state to be committed when the block is connected.
+func (bft *BFT) validateBlock(block *types.Block) (*state.BlockState, error) {
+	rs := bft.rs
+	if block.BlockNo() != rs.height {
+		return nil, errProposalHeight
+	}
+	if !bytes.Equal(block.GetHeader().GetPrevBlockHash(), rs.parent.BlockHash()) || !block.ValidChildOf(rs.parent) {
+		return nil, fmt.Errorf("%v: parent %s", errProposalBlock, block.PrevID())
+	}
+	if err := bft.VerifySign(block); err != nil {
+		return nil, err
+	}
+	if err := bft.IsBlockValid(block, rs.parent); err != nil {
+		return nil, err
+	}
+
+	bi := types.NewBlockHeaderInfoFromPrevBlock(rs.parent, block.GetHeader().GetTimestamp(), bft.bv)
+	if !bytes.Equal(block.GetHeader().GetChainID(), bi.ChainId) {
+		return nil, fmt.Errorf("%v: chain id version %d", errProposalBlock, types.DecodeChainIdVersion(block.GetHeader().GetChainID()))
+	}
+	txs := block.GetBody().GetTxs()
+	if !bytes.Equal(block.GetHeader().GetTxsRootHash(), types.CalculateTxsRootHash(txs)) {
+		return nil, bc.ErrorBlockVerifyTxRoot
+	}
+	bft.signVerifier.RequestVerifyTxs(&types.TxList{Txs: txs})
+	if failed, _ := bft.signVerifier.WaitDone(); failed {
+		return nil, errProposalTxSign
+	}
+	return bft.executeBlock(block, bi)
+}
+
+// executeBlock executes the transactions of block like the chain service
+// does when it connects a block, and checks the state and the receipts
+// against the header.
+func (bft *BFT) executeBlock(block *types.Block, bi *types.BlockHeaderInfo) (*state.BlockState, error) {
+	defer contract.CloseDatabase()
+
+	rs := bft.rs
+	blockState := bft.sdb.NewBlockState(
+		rs.parent.GetHeader().GetBlocksRootHash(),
+		state.SetPrevBlockHash(rs.parent.BlockHash()),
+	)
+	blockState.SetGasPrice(system.GetGasPriceFromState(blockState))
+	blockState.Receipts().SetHardFork(bft.bv, bi.No)
+	blockState.SetConsensus(block.GetHeader().GetConsensus())
+
+	execTx := bc.NewTxExecutor(bft, bft.ChainDB, bi, contract.BlockFactory)
+	for _, tx := range block.GetBody().GetTxs() {
+		if err := execTx(blockState, types.NewTransaction(tx)); err != nil {
+			return nil, err
+		}
+	}
+	if err := bc.SendBlockReward(blockState, block.GetHeader().GetCoinbaseAccount()); err != nil {
+		return nil, err
+	}
+	if err := contract.SaveRecoveryPoint(blockState, bi); err != nil {
+		return nil, err
+	}
+	if err := blockState.Update(); err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(blockState.GetRoot(), block.GetHeader().GetBlocksRootHash()) {
+		return nil, bc.ErrorBlockVerifyStateRoot
+	}
+	if !bytes.Equal(blockState.Receipts().MerkleRoot(), block.GetHeader().GetReceiptsRootHash()) {
+		return nil, bc.ErrorBlockVerifyReceiptRoot
+	}
+	return blockState, nil
+}
+
+func (bft *BFT) getLastCommit() *types.BFTCommit {
+	bft.mu.RLock()
+	defer bft.mu.RUnlock()
+	return bft.lastCommit
+}
+
+func (bft *BFT) setLastCommit(commit *types.BFTCommit) {
+	bft.mu.Lock()
+	defer bft.mu.Unlock()
+	bft.lastCommit = commit
+}
+
+func (bft *BFT) updateStatus() {
+	rs := bft.rs
+	vs := bft.vals.at(rs.height)
+	s := &status{
+		Height:     rs.height,
+		Round:      rs.round,
+		Step:       rs.step.String(),
+		Proposer:   types.IDB58Encode(vs.proposer(rs.height, rs.round)),
+		Validators: vs.size(),
+	}
+
+	bft.mu.Lock()
+	defer bft.mu.Unlock()
+	if bft.lastCommit != nil {
+		s.LastCommit = enc.ToString(bft.lastCommit.GetBlockHash())
+	}
+	bft.status = s
+}
+
+func (bft *BFT) getStatus() *status {
+	bft.mu.RLock()
+	defer bft.mu.RUnlock()
+	return bft.status
+}
@@ -0,0 +1,172 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package bft
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/aergoio/aergo/state"
+	"github.com/aergoio/aergo/types"
+)
+
+type roundStep int
+
+const (
+	stepPropose roundStep = iota
+	stepPrevote
+	stepPrecommit
+	stepCommit
+)
+
+var stepName = [...]string{"propose", "prevote", "precommit", "commit"}
+
+func (s roundStep) String() string {
+	return stepName[s]
+}
+
+// voteSet is the votes of a type in a round, indexed by the validators.
+type voteSet map[types.PeerID]*types.BFTVote
+
+// count returns the number of the votes for hash.
+func (vs voteSet) count(hash []byte) int {
+	n := 0
+	for _, v := range vs {
+		if bytes.Equal(v.GetBlockHash(), hash) {
+			n++
+		}
+	}
+	return n
+}
+
+// major returns the block hash voted by at least quorum votes.
+func (vs voteSet) major(quorum int) ([]byte, bool) {
+	counts := make(map[string]int)
+	for _, v := range vs {
+		h := string(v.GetBlockHash())
+		counts[h]++
+		if counts[h] >= quorum {
+			return v.GetBlockHash(), true
+		}
+	}
+	return nil, false
+}
+
+// proposal is a block proposed in a round. state is the block state after
+// the block is executed by this node, which is committed when it's connected.
+type proposal struct {
+	block *types.Block
+	state *state.BlockState
+}
+
+// roundState is the progress of the decision of the block at height. It's
+// only accessed by the goroutine of the block factory.
+type roundState struct {
+	height types.BlockNo
+	round  uint32
+	step   roundStep
+	// deadline is the time when the current step times out.
+	deadline time.Time
+	// start is the time when the proposer of the round proposes.
+	start time.Time
+
+	// blocks is the proposed blocks of this height by their hash.
+	blocks    map[string]*proposal
+	proposals map[uint32][]byte
+	prevotes  map[uint32]voteSet
+	precommit map[uint32]voteSet
+
+	// lockedRound and lockedHash is the block which this node precommitted.
+	// It prevotes only the locked block in the later rounds unless a quorum
+	// prevotes another one.
+	lockedRound int64
+	lockedHash  []byte
+
+	// parent is the best block and lastCommit is its certificate.
+	parent     *types.Block
+	lastCommit *types.BFTCommit
+}
+
+func newRoundState(parent *types.Block, start time.Time, lastCommit *types.BFTCommit) *roundState {
+	return &roundState{
+		height:      parent.BlockNo() + 1,
+		parent:      parent,
+		start:       start,
+		blocks:      make(map[string]*proposal),
+		proposals:   make(map[uint32][]byte),
+		prevotes:    make(map[uint32]voteSet),
+		precommit:   make(map[uint32]voteSet),
+		lockedRound: -1,
+		lastCommit:  lastCommit,
+	}
+}
+
+func (rs *roundState) votes(t types.BFTVoteType, round uint32) voteSet {
+	m := rs.prevotes
+	if t == types.BFTVoteType_PRECOMMIT {
+		m = rs.precommit
+	}
+	vs, exist := m[round]
+	if !exist {
+		vs = make(voteSet)
+		m[round] = vs
+	}
+	return vs
+}
+
+// addVote keeps v. It returns false if the validator already voted in the
+// round.
+func (rs *roundState) addVote(v *types.BFTVote) bool {
+	vs := rs.votes(v.GetType(), v.GetRound())
+	id := types.PeerID(v.GetValidator())
+	if _, exist := vs[id]; exist {
+		return false
+	}
+	vs[id] = v
+	return true
+}
+
+// voters returns the number of the validators which voted in round.
+func (rs *roundState) voters(round uint32) int {
+	ids := make(map[types.PeerID]bool)
+	for id := range rs.prevotes[round] {
+		ids[id] = true
+	}
+	for id := range rs.precommit[round] {
+		ids[id] = true
+	}
+	return len(ids)
+}
+
+func (rs *roundState) block(hash []byte) *proposal {
+	return rs.blocks[string(hash)]
+}
+
+func (rs *roundState) setStep(step roundStep, deadline time.Time) {
+	rs.step = step
+	rs.deadline = deadline
+}
+
+// commitOf returns the certificate made of the precommits for hash in round.
+func (rs *roundState) commitOf(round uint32, hash []byte) *types.BFTCommit {
+	commit := &types.BFTCommit{Height: rs.height, Round: round, BlockHash: hash}
+	for _, v := range rs.precommit[round] {
+		if bytes.Equal(v.GetBlockHash(), hash) {
+			commit.Votes = append(commit.Votes, v)
+		}
+	}
+	return commit
+}
+
+// status is the progress of the rounds reported by Info.
+type status struct {
+	Height     types.BlockNo `json:"height"`
+	Round      uint32        `json:"round"`
+	Step       string        `json:"step"`
+	Proposer   string        `json:"proposer,omitempty"`
+	Validators int           `json:"validators"`
+	LastCommit string        `json:"lastCommit,omitempty"`
+}
@@ -0,0 +1,239 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package bft
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/aergoio/aergo/types"
+)
+
+var (
+	validatorsKey = []byte("bft.validators")
+
+	ErrNoValidator        = errors.New("no validator in genesis")
+	ErrValidatorExist     = errors.New("validator already exists")
+	ErrValidatorNotExist  = errors.New("validator does not exist")
+	ErrLastValidator      = errors.New("can't remove the last validator")
+	ErrInvalidMemberAttrs = errors.New("invalid validator attributes")
+)
+
+func idToString(id uint64) string {
+	return fmt.Sprintf("%x", id)
+}
+
+// validatorSet is the validators which decide the blocks from the block
+// number From until the next set. The members are sorted by their IDs.
+type validatorSet struct {
+	From    types.BlockNo       `json:"from"`
+	Members []*types.MemberAttr `json:"members"`
+}
+
+func (vs *validatorSet) size() int {
+	return len(vs.Members)
+}
+
+func (vs *validatorSet) ids() []types.PeerID {
+	ids := make([]types.PeerID, len(vs.Members))
+	for i, m := range vs.Members {
+		ids[i] = types.PeerID(m.PeerID)
+	}
+	return ids
+}
+
+func (vs *validatorSet) has(peerID types.PeerID) bool {
+	return vs.indexOf(types.BFTValidatorID(peerID)) >= 0
+}
+
+func (vs *validatorSet) indexOf(id uint64) int {
+	for i, m := range vs.Members {
+		if m.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// proposer returns the validator which proposes the block at height and
+// round. It rotates the validators by both of them.
+func (vs *validatorSet) proposer(height types.BlockNo, round uint32) types.PeerID {
+	if len(vs.Members) == 0 {
+		return types.PeerID("")
+	}
+	idx := (height + uint64(round)) % uint64(len(vs.Members))
+	return types.PeerID(vs.Members[idx].PeerID)
+}
+
+func (vs *validatorSet) quorum() int {
+	return types.BFTQuorum(vs.size())
+}
+
+// check returns an error if req can't be applied to vs.
+func (vs *validatorSet) check(req *types.MembershipChange) error {
+	attr := req.GetAttr()
+	if attr == nil {
+		return ErrInvalidMemberAttrs
+	}
+	switch req.GetType() {
+	case types.MembershipChangeType_ADD_MEMBER:
+		if len(attr.PeerID) == 0 || attr.Name == "" {
+			return ErrInvalidMemberAttrs
+		}
+		if _, err := types.PeerID(attr.PeerID).ExtractPublicKey(); err != nil {
+			return fmt.Errorf("%v: %s", ErrInvalidMemberAttrs, err.Error())
+		}
+		if vs.has(types.PeerID(attr.PeerID)) {
+			return ErrValidatorExist
+		}
+		for _, m := range vs.Members {
+			if m.Name == attr.Name {
+				return ErrValidatorExist
+			}
+		}
+	case types.MembershipChangeType_REMOVE_MEMBER:
+		if vs.indexOf(attr.ID) < 0 {
+			return ErrValidatorNotExist
+		}
+		if len(vs.Members) == 1 {
+			return ErrLastValidator
+		}
+	default:
+		return fmt.Errorf("unknown membership change type: %v", req.GetType())
+	}
+	return nil
+}
+
+// apply returns the validator set changed by req, which starts at from.
+func (vs *validatorSet) apply(from types.BlockNo, req *types.MembershipChange) (*validatorSet, error) {
+	if err := vs.check(req); err != nil {
+		return nil, err
+	}
+
+	next := &validatorSet{From: from}
+	attr := req.GetAttr()
+	for _, m := range vs.Members {
+		if req.GetType() == types.MembershipChangeType_REMOVE_MEMBER && m.ID == attr.ID {
+			continue
+		}
+		next.Members = append(next.Members, m)
+	}
+	if req.GetType() == types.MembershipChangeType_ADD_MEMBER {
+		next.add(&types.MemberAttr{Name: attr.Name, Address: attr.Address, PeerID: attr.PeerID})
+	}
+	return next, nil
+}
+
+func (vs *validatorSet) add(m *types.MemberAttr) {
+	m.ID = types.BFTValidatorID(types.PeerID(m.PeerID))
+	vs.Members = append(vs.Members, m)
+	sort.Slice(vs.Members, func(i, j int) bool { return vs.Members[i].ID < vs.Members[j].ID })
+}
+
+// genesisValidators returns the validator set of the enterprise BPs in
+// genesis, which starts at the block 1.
+func genesisValidators(genesis *types.Genesis) (*validatorSet, error) {
+	if len(genesis.EnterpriseBPs) == 0 {
+		return nil, ErrNoValidator
+	}
+	vs := &validatorSet{From: 1}
+	for _, bp := range genesis.EnterpriseBPs {
+		peerID, err := types.IDB58Decode(bp.PeerID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid peer id of validator %s: %s", bp.Name, err.Error())
+		}
+		if _, err := types.ParseMultiaddr(bp.Address); err != nil {
+			return nil, fmt.Errorf("invalid address of validator %s: %s", bp.Name, err.Error())
+		}
+		req := &types.MembershipChange{
+			Type: types.MembershipChangeType_ADD_MEMBER,
+			Attr: &types.MemberAttr{Name: bp.Name, Address: bp.Address, PeerID: []byte(peerID)},
+		}
+		if err := vs.check(req); err != nil {
+			return nil, fmt.Errorf("invalid validator %s: %s", bp.Name, err.Error())
+		}
+		vs.add(req.Attr)
+	}
+	return vs, nil
+}
+
+// validators is the history of the validator set. The certificate of a block
+// is verified by the set of its block number, so the former sets are kept
+// for the nodes which sync the past blocks.
+type validators struct {
+	sync.RWMutex
+	sets  []*validatorSet
+	dirty bool
+}
+
+func newValidators(initial *validatorSet) *validators {
+	return &validators{sets: []*validatorSet{initial}}
+}
+
+// loadValidators restores the history of the validator set from data. It
+// returns nil if data is empty.
+func loadValidators(data []byte) (*validators, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var sets []*validatorSet
+	if err := json.Unmarshal(data, &sets); err != nil {
+		return nil, err
+	}
+	if len(sets) == 0 {
+		return nil, ErrNoValidator
+	}
+	return &validators{sets: sets}, nil
+}
+
+func (v *validators) marshal() ([]byte, error) {
+	return json.Marshal(v.sets)
+}
+
+// at returns the validator set of the block of no.
+func (v *validators) at(no types.BlockNo) *validatorSet {
+	v.RLock()
+	defer v.RUnlock()
+	return v.setAt(no)
+}
+
+func (v *validators) setAt(no types.BlockNo) *validatorSet {
+	for i := len(v.sets) - 1; i > 0; i-- {
+		if v.sets[i].From <= no {
+			return v.sets[i]
+		}
+	}
+	return v.sets[0]
+}
+
+func (v *validators) latest() *validatorSet {
+	v.RLock()
+	defer v.RUnlock()
+	return v.sets[len(v.sets)-1]
+}
+
+// apply changes the validator set by req from the block after no. A set of
+// a later block is dropped, which happens if the blocks are applied again
+// after recovery.
+func (v *validators) apply(no types.BlockNo, req *types.MembershipChange) error {
+	v.Lock()
+	defer v.Unlock()
+
+	from := no + 1
+	for len(v.sets) > 1 && v.sets[len(v.sets)-1].From >= from {
+		v.sets = v.sets[:len(v.sets)-1]
+	}
+	next, err := v.sets[len(v.sets)-1].apply(from, req)
+	if err != nil {
+		return err
+	}
+	v.sets = append(v.sets, next)
+	v.dirty = true
+	return nil
+}
@@ -0,0 +1,119 @@
+package bft
+
+import (
+	"testing"
+
+	"github.com/aergoio/aergo/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testPeerIDStrs = []string{
+	"16Uiu2HAkvaAMCHkd9hZ6hQkdDLKoXP4eLJSqkMF1YqkSNy5v9SVn",
+	"16Uiu2HAmJqEp9f9WAbzFxkLrnHnW4EuUDM69xkCDPF26HmNCsib6",
+	"16Uiu2HAmA2ysmFxoQ37sk1Zk2sMrPysqTmwYAFrACyf3LtP3gxpJ",
+	"16Uiu2HAmQti7HLHC9rXqkeABtauv2YsCPG3Uo1WLqbXmbuxpbjmF",
+}
+
+func testGenesis(n int) *types.Genesis {
+	genesis := &types.Genesis{ID: types.ChainID{Consensus: GetName()}}
+	for i := 0; i < n; i++ {
+		genesis.EnterpriseBPs = append(genesis.EnterpriseBPs, types.EnterpriseBP{
+			Name:    "bp" + string(rune('1'+i)),
+			Address: "/ip4/127.0.0.1/tcp/1100" + string(rune('1'+i)),
+			PeerID:  testPeerIDStrs[i],
+		})
+	}
+	return genesis
+}
+
+func TestGenesisValidators(t *testing.T) {
+	vs, err := genesisValidators(testGenesis(3))
+	require.NoError(t, err)
+	assert.Equal(t, types.BlockNo(1), vs.From)
+	assert.Equal(t, 3, vs.size())
+	assert.Equal(t, 3, vs.quorum())
+	for i := 1; i < vs.size(); i++ {
+		assert.True(t, vs.Members[i-1].ID < vs.Members[i].ID, "sorted by id")
+	}
+
+	_, err = genesisValidators(&types.Genesis{})
+	assert.Equal(t, ErrNoValidator, err)
+
+	dup := testGenesis(2)
+	dup.EnterpriseBPs[1].PeerID = dup.EnterpriseBPs[0].PeerID
+	_, err = genesisValidators(dup)
+	assert.Error(t, err)
+
+	assert.NoError(t, ValidateGenesis(testGenesis(1)))
+	raft := testGenesis(1)
+	raft.ID.Consensus = "raft"
+	assert.Equal(t, ErrInvalidConsensusName, ValidateGenesis(raft))
+}
+
+func TestValidatorProposer(t *testing.T) {
+	vs, err := genesisValidators(testGenesis(4))
+	require.NoError(t, err)
+
+	ids := vs.ids()
+	assert.Equal(t, ids[1], vs.proposer(1, 0))
+	assert.Equal(t, ids[2], vs.proposer(1, 1))
+	assert.Equal(t, ids[2], vs.proposer(2, 0))
+	assert.Equal(t, ids[0], vs.proposer(3, 1))
+}
+
+func TestValidatorsApply(t *testing.T) {
+	initial, err := genesisValidators(testGenesis(3))
+	require.NoError(t, err)
+	vals := newValidators(initial)
+
+	newPeerID, err := types.IDB58Decode(testPeerIDStrs[3])
+	require.NoError(t, err)
+	add := &types.MembershipChange{
+		Type: types.MembershipChangeType_ADD_MEMBER,
+		Attr: &types.MemberAttr{Name: "bp4", Address: "/ip4/127.0.0.1/tcp/11004", PeerID: []byte(newPeerID)},
+	}
+	require.NoError(t, vals.apply(10, add))
+	assert.True(t, vals.dirty)
+	assert.Equal(t, 3, vals.at(10).size())
+	assert.Equal(t, 4, vals.at(11).size())
+	assert.True(t, vals.latest().has(newPeerID))
+	assert.Equal(t, ErrValidatorExist, vals.apply(12, add))
+
+	remove := &types.MembershipChange{
+		Type: types.MembershipChangeType_REMOVE_MEMBER,
+		Attr: &types.MemberAttr{ID: types.BFTValidatorID(newPeerID)},
+	}
+	require.NoError(t, vals.apply(20, remove))
+	assert.Equal(t, 4, vals.at(20).size())
+	assert.Equal(t, 3, vals.at(21).size())
+	assert.Equal(t, ErrValidatorNotExist, vals.apply(22, remove))
+
+	data, err := vals.marshal()
+	require.NoError(t, err)
+	loaded, err := loadValidators(data)
+	require.NoError(t, err)
+	assert.Equal(t, 3, len(loaded.sets))
+	assert.Equal(t, 4, loaded.at(15).size())
+
+	// A change applied again after recovery replaces the later sets.
+	require.NoError(t, loaded.apply(10, add))
+	assert.Equal(t, 2, len(loaded.sets))
+	assert.Equal(t, 4, loaded.at(100).size())
+
+	empty, err := loadValidators(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, empty)
+}
+
+func TestLastValidator(t *testing.T) {
+	initial, err := genesisValidators(testGenesis(1))
+	require.NoError(t, err)
+	vals := newValidators(initial)
+
+	remove := &types.MembershipChange{
+		Type: types.MembershipChangeType_REMOVE_MEMBER,
+		Attr: &types.MemberAttr{ID: initial.Members[0].ID},
+	}
+	assert.Equal(t, ErrLastValidator, vals.apply(1, remove))
+}
@@ -25,7 +25,6 @@ import (
 	"github.com/aergoio/aergo/state"
 	"github.com/aergoio/aergo/types"
 	"github.com/davecgh/go-spew/spew"
-	"github.com/libp2p/go-libp2p-core/crypto"
 )
 
 const (
@@ -57,7 +56,7 @@ type BlockFactory struct {
 	quit             <-chan interface{}
 	maxBlockBodySize uint32
 	ID               string
-	privKey          crypto.PrivKey
+	signer           types.BlockSigner
 	txOp             chain.TxOp
 	sdb              *state.ChainStateDB
 	bv               types.BlockVersionner
@@ -81,8 +80,8 @@ func NewBlockFactory(
 		bpTimeoutC:       make(chan struct{}, 1),
 		maxBlockBodySize: chain.MaxBlockBodySize(),
 		quit:             quitC,
-		ID:               p2pkey.NodeSID(),
-		privKey:          p2pkey.NodePrivKey(),
+		ID:               p2pkey.BlockProducerSID(),
+		signer:           p2pkey.BlockSigner(),
 		sdb:              sdb,
 		bv:               bv,
 		noTTE:            noTTE,
@@ -252,7 +251,7 @@ func (bf *BlockFactory) generateBlock(bpi *bpInfo, lpbNo types.BlockNo) (block *
 
 	block.SetConfirms(block.BlockNo() - lpbNo)
 
-	if err = block.Sign(bf.privKey); err != nil {
+	if err = block.Sign(bf.signer); err != nil {
 		return nil, nil, err
 	}
 
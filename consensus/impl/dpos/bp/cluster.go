@@ -269,6 +269,18 @@ func snapBlockNo(blockNo types.BlockNo) types.BlockNo {
 	return (blockNo/getElectionPeriod() - 1) * getElectionPeriod()
 }
 
+// RefBlockNo returns the number of the block whose state elects the BPs of
+// the block of blockNo. The BPs are the ones of genesis if it's 0.
+func RefBlockNo(blockNo types.BlockNo) types.BlockNo {
+	return snapBlockNo(blockNo)
+}
+
+// ElectionPeriod returns the number of the blocks, after which the BPs are
+// elected again.
+func ElectionPeriod() types.BlockNo {
+	return getElectionPeriod()
+}
+
 func isSnapPeriod(blockNo types.BlockNo) bool {
 	// The current snapshot period is the total BP count.
 	return blockNo%getElectionPeriod() == 0
@@ -267,7 +267,7 @@ func (dpos *DPoS) QuitChan() chan interface{} {
 }
 
 func (dpos *DPoS) bpid() types.PeerID {
-	return p2pkey.NodeID()
+	return p2pkey.BlockProducerID()
 }
 
 // VerifyTimestamp checks the validity of the block timestamp.
@@ -53,7 +53,7 @@ func newLibStatus(confirmsRequired uint16) *libStatus {
 		Prpsd:            make(proposed),
 		Lib:              &blockInfo{},
 		confirms:         list.New(),
-		bpid:             p2pkey.NodeSID(),
+		bpid:             p2pkey.BlockProducerSID(),
 		confirmsRequired: confirmsRequired,
 	}
 }
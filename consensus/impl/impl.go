@@ -6,14 +6,9 @@
 package impl
 
 import (
-	"strings"
-
 	"github.com/aergoio/aergo/chain"
 	"github.com/aergoio/aergo/config"
 	"github.com/aergoio/aergo/consensus"
-	"github.com/aergoio/aergo/consensus/impl/dpos"
-	"github.com/aergoio/aergo/consensus/impl/raftv2"
-	"github.com/aergoio/aergo/consensus/impl/sbp"
 	"github.com/aergoio/aergo/p2p"
 	"github.com/aergoio/aergo/p2p/p2pcommon"
 	"github.com/aergoio/aergo/pkg/component"
@@ -50,29 +45,25 @@ func New(cfg *config.Config, hub *component.ComponentHub, cs *chain.ChainService
 
 func newConsensus(cfg *config.Config, hub *component.ComponentHub,
 	cs *chain.ChainService, pa p2pcommon.PeerAccessor) (consensus.Consensus, error) {
-	cdb := cs.CDB()
-	sdb := cs.SDB()
-
-	impl := map[string]consensus.Constructor{
-		dpos.GetName():   dpos.GetConstructor(cfg, hub, cdb, sdb),              // DPoS
-		sbp.GetName():    sbp.GetConstructor(cfg, hub, cdb, sdb),               // Simple BP
-		raftv2.GetName(): raftv2.GetConstructor(cfg, hub, cs.WalDB(), sdb, pa), // Raft BP
+	name := cs.CDB().GetGenesisInfo().ConsensusType()
+	e, err := lookup(name)
+	if err != nil {
+		return nil, err
 	}
 
-	consensus.SetCurConsensus(cdb.GetGenesisInfo().ConsensusType())
-	return impl[cdb.GetGenesisInfo().ConsensusType()]()
+	consensus.SetCurConsensus(name)
+	return e.New(cfg, hub, cs, pa)
 }
 
-type GenesisValidator func(genesis *types.Genesis) error
-
+// ValidateGenesis checks genesis by the validator of the consensus engine
+// selected by it.
 func ValidateGenesis(genesis *types.Genesis) error {
-	name := strings.ToLower(genesis.ConsensusType())
-
-	validators := map[string]GenesisValidator{
-		dpos.GetName():   dpos.ValidateGenesis,   // DPoS
-		sbp.GetName():    sbp.ValidateGenesis,    // Simple BP
-		raftv2.GetName(): raftv2.ValidateGenesis, // Raft BP
+	e, err := lookup(genesis.ConsensusType())
+	if err != nil {
+		return err
 	}
-
-	return validators[name](genesis)
+	if e.ValidateGenesis == nil {
+		return nil
+	}
+	return e.ValidateGenesis(genesis)
 }
@@ -24,7 +24,6 @@ import (
 	"github.com/aergoio/aergo/pkg/component"
 	"github.com/aergoio/aergo/state"
 	"github.com/aergoio/aergo/types"
-	"github.com/libp2p/go-libp2p-core/crypto"
 )
 
 var (
@@ -129,7 +128,7 @@ type BlockFactory struct {
 
 	maxBlockBodySize uint32
 	ID               string
-	privKey          crypto.PrivKey
+	signer           types.BlockSigner
 	txOp             chain.TxOp
 	sdb              *state.ChainStateDB
 	prevBlock        *types.Block // best block of last job
@@ -166,8 +165,8 @@ func New(cfg *config.Config, hub *component.ComponentHub, cdb consensus.ChainWAL
 		bpTimeoutC:       make(chan struct{}, 1),
 		quit:             make(chan interface{}),
 		maxBlockBodySize: chain.MaxBlockBodySize(),
-		ID:               p2pkey.NodeSID(),
-		privKey:          p2pkey.NodePrivKey(),
+		ID:               p2pkey.BlockProducerSID(),
+		signer:           p2pkey.BlockSigner(),
 		sdb:              sdb,
 		bv:               cfg.Hardfork,
 	}
@@ -524,7 +523,7 @@ func (bf *BlockFactory) generateBlock(work *Work) (*types.Block, *state.BlockSta
 		return nil, nil, err
 	}
 
-	if err = block.Sign(bf.privKey); err != nil {
+	if err = block.Sign(bf.signer); err != nil {
 		logger.Error().Err(err).Msg("failed to sign in block")
 		return nil, nil, err
 	}
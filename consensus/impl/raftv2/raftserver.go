@@ -34,6 +34,7 @@ import (
 	"time"
 
 	"github.com/aergoio/aergo/consensus"
+	"github.com/aergoio/aergo/internal/metrics"
 	"github.com/aergoio/aergo/types"
 
 	"github.com/aergoio/etcd/etcdserver/stats"
@@ -1296,6 +1297,7 @@ func (rs *raftServer) WaitStartup() {
 // updateTerm is called only by raftserver. so it doesn't have lock.
 func (rs *raftServer) updateTerm(term uint64) {
 	rs.curTerm = term
+	metrics.RaftTerm.Set(float64(term))
 }
 
 func (rs *raftServer) updateLeader(softState *raftlib.SoftState) {
@@ -1313,6 +1315,13 @@ func (rs *raftServer) updateLeader(softState *raftlib.SoftState) {
 		rs.leaderStatus.IsLeader = rs.checkLeader()
 		rs.leaderStatus.leaderChanged++
 
+		metrics.RaftLeaderChanges.Inc()
+		if rs.leaderStatus.IsLeader {
+			metrics.RaftIsLeader.Set(1)
+		} else {
+			metrics.RaftIsLeader.Set(0)
+		}
+
 		logger.Info().Uint64("term", rs.curTerm).Str("ID", EtcdIDToString(rs.ID())).Str("leader", EtcdIDToString(softState.Lead)).Msg("leader changed")
 	} else {
 		logger.Info().Uint64("term", rs.curTerm).Str("ID", EtcdIDToString(rs.ID())).Str("leader", EtcdIDToString(softState.Lead)).Msg("soft state leader unchanged")
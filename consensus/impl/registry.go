@@ -0,0 +1,130 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package impl
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aergoio/aergo/chain"
+	"github.com/aergoio/aergo/config"
+	"github.com/aergoio/aergo/consensus"
+	"github.com/aergoio/aergo/consensus/impl/bft"
+	"github.com/aergoio/aergo/consensus/impl/dpos"
+	"github.com/aergoio/aergo/consensus/impl/raftv2"
+	"github.com/aergoio/aergo/consensus/impl/sbp"
+	"github.com/aergoio/aergo/p2p/p2pcommon"
+	"github.com/aergoio/aergo/pkg/component"
+	"github.com/aergoio/aergo/types"
+)
+
+// Factory makes a consensus engine from the components of the node.
+type Factory func(cfg *config.Config, hub *component.ComponentHub,
+	cs *chain.ChainService, pa p2pcommon.PeerAccessor) (consensus.Consensus, error)
+
+// GenesisValidator checks the consensus-specific part of a genesis block.
+type GenesisValidator func(genesis *types.Genesis) error
+
+// Engine is a consensus implementation, which is selected by the consensus
+// type of the genesis block.
+type Engine struct {
+	// New makes the engine. It is required.
+	New Factory
+	// ValidateGenesis is called before a genesis block is written. It may be
+	// nil if the engine has no requirement.
+	ValidateGenesis GenesisValidator
+	// FormatInfo renders the ConsensusInfo of the engine for the users. The
+	// default formatter is used if nil.
+	FormatInfo consensus.InfoFormatter
+	// Cluster is true if the block producers of the engine are changed by
+	// the enterprise ChangeCluster tx.
+	Cluster bool
+}
+
+var (
+	enginesMu sync.RWMutex
+	engines   = map[string]*Engine{}
+)
+
+func init() {
+	Register(dpos.GetName(), Engine{
+		New: func(cfg *config.Config, hub *component.ComponentHub, cs *chain.ChainService, pa p2pcommon.PeerAccessor) (consensus.Consensus, error) {
+			return dpos.GetConstructor(cfg, hub, cs.CDB(), cs.SDB())()
+		},
+		ValidateGenesis: dpos.ValidateGenesis,
+	})
+	Register(sbp.GetName(), Engine{
+		New: func(cfg *config.Config, hub *component.ComponentHub, cs *chain.ChainService, pa p2pcommon.PeerAccessor) (consensus.Consensus, error) {
+			return sbp.GetConstructor(cfg, hub, cs.CDB(), cs.SDB())()
+		},
+		ValidateGenesis: sbp.ValidateGenesis,
+	})
+	Register(raftv2.GetName(), Engine{
+		New: func(cfg *config.Config, hub *component.ComponentHub, cs *chain.ChainService, pa p2pcommon.PeerAccessor) (consensus.Consensus, error) {
+			return raftv2.GetConstructor(cfg, hub, cs.WalDB(), cs.SDB(), pa)()
+		},
+		ValidateGenesis: raftv2.ValidateGenesis,
+		Cluster:         true,
+	})
+	Register(bft.GetName(), Engine{
+		New: func(cfg *config.Config, hub *component.ComponentHub, cs *chain.ChainService, pa p2pcommon.PeerAccessor) (consensus.Consensus, error) {
+			return bft.GetConstructor(cfg, hub, cs.CDB(), cs.SDB(), cs)()
+		},
+		ValidateGenesis: bft.ValidateGenesis,
+		Cluster:         true,
+	})
+}
+
+// Register makes the consensus engine e available by name, which is matched
+// case-insensitively against the consensus type of the genesis block. An
+// engine out of this tree is registered from an init function of a package
+// linked to the node. Register panics if e.New is nil or name is already
+// registered.
+func Register(name string, e Engine) {
+	name = strings.ToLower(name)
+	if e.New == nil {
+		panic("consensus: Register engine without constructor: " + name)
+	}
+
+	enginesMu.Lock()
+	defer enginesMu.Unlock()
+	if _, dup := engines[name]; dup {
+		panic("consensus: Register called twice for engine " + name)
+	}
+	engines[name] = &e
+	if e.Cluster {
+		consensus.RegisterClusterType(name)
+	} else {
+		consensus.RegisterType(name)
+	}
+	if e.FormatInfo != nil {
+		consensus.RegisterInfoFormatter(name, e.FormatInfo)
+	}
+}
+
+// Engines returns the names of the registered consensus engines.
+func Engines() []string {
+	enginesMu.RLock()
+	defer enginesMu.RUnlock()
+	names := make([]string, 0, len(engines))
+	for name := range engines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func lookup(name string) (*Engine, error) {
+	enginesMu.RLock()
+	e, exist := engines[strings.ToLower(name)]
+	enginesMu.RUnlock()
+	if !exist {
+		return nil, fmt.Errorf("unknown consensus type: %s (registered: %s)", name, strings.Join(Engines(), ", "))
+	}
+	return e, nil
+}
@@ -0,0 +1,97 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package consensus
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aergoio/aergo/types"
+)
+
+// InfoFormatter renders the ConsensusInfo of a consensus implementation as
+// JSON for the users.
+type InfoFormatter func(ci *types.ConsensusInfo) ([]byte, error)
+
+var infoFormatters = map[string]InfoFormatter{}
+
+// RegisterType adds name to the consensus types which a genesis block can
+// select and returns its ConsensusType. Registering a registered name returns
+// the same type. It must be called during the initialization of the program,
+// before any chain is loaded.
+func RegisterType(name string) ConsensusType {
+	name = strings.ToLower(name)
+	if t, exist := ConsensusTypes[name]; exist {
+		return t
+	}
+	t := ConsensusType(len(ConsensusName))
+	ConsensusName = append(ConsensusName, name)
+	ConsensusTypes[name] = t
+	return t
+}
+
+// RegisterClusterType registers name like RegisterType and marks it as a
+// consensus of which the block producers are changed by the enterprise
+// ChangeCluster tx.
+func RegisterClusterType(name string) ConsensusType {
+	t := RegisterType(name)
+	clusterTypes[t] = true
+	return t
+}
+
+// TypeByName returns the ConsensusType registered as name.
+func TypeByName(name string) (ConsensusType, bool) {
+	t, exist := ConsensusTypes[strings.ToLower(name)]
+	return t, exist
+}
+
+// String returns the registered name of t.
+func (t ConsensusType) String() string {
+	if int(t) < 0 || int(t) >= len(ConsensusName) {
+		return fmt.Sprintf("ConsensusType(%d)", int(t))
+	}
+	return ConsensusName[t]
+}
+
+// RegisterInfoFormatter sets the formatter of the ConsensusInfo of the
+// consensus type name. DefaultInfoFormatter is used for a type without its
+// own formatter.
+func RegisterInfoFormatter(name string, f InfoFormatter) {
+	infoFormatters[strings.ToLower(name)] = f
+}
+
+// FormatInfo renders ci by the formatter registered for its type.
+func FormatInfo(ci *types.ConsensusInfo) ([]byte, error) {
+	if f, exist := infoFormatters[strings.ToLower(ci.GetType())]; exist && f != nil {
+		return f(ci)
+	}
+	return DefaultInfoFormatter(ci)
+}
+
+// DefaultInfoFormatter renders ci as a JSON object of which Info and Bps are
+// embedded as they are, since they are JSON made by the consensus.
+func DefaultInfoFormatter(ci *types.ConsensusInfo) ([]byte, error) {
+	type outInfo struct {
+		Type string             `json:",omitempty"`
+		Info *json.RawMessage   `json:",omitempty"`
+		Bps  []*json.RawMessage `json:",omitempty"`
+	}
+
+	out := &outInfo{Type: ci.GetType()}
+	if len(ci.GetInfo()) > 0 {
+		info := json.RawMessage(ci.GetInfo())
+		out.Info = &info
+	}
+	if len(ci.GetBps()) > 0 {
+		out.Bps = make([]*json.RawMessage, len(ci.GetBps()))
+		for i, bp := range ci.GetBps() {
+			b := json.RawMessage(bp)
+			out.Bps[i] = &b
+		}
+	}
+	return json.Marshal(out)
+}
@@ -0,0 +1,53 @@
+package consensus
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/aergoio/aergo/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterType(t *testing.T) {
+	assert.Equal(t, ConsensusDPOS, RegisterType("dpos"), "built-in")
+	assert.Equal(t, ConsensusRAFT, RegisterType("RAFT"), "case-insensitive")
+
+	poa := RegisterType("poa")
+	assert.Equal(t, ConsensusType(len(ConsensusName)-1), poa)
+	assert.Equal(t, poa, RegisterType("poa"), "registered twice")
+	assert.Equal(t, "poa", poa.String())
+
+	got, exist := TypeByName("PoA")
+	assert.True(t, exist)
+	assert.Equal(t, poa, got)
+	_, exist = TypeByName("unknown")
+	assert.False(t, exist)
+
+	SetCurConsensus("poa")
+	assert.False(t, UseDpos())
+	assert.False(t, UseRaft())
+	assert.False(t, UseCluster())
+
+	RegisterClusterType("pbft")
+	SetCurConsensus("pbft")
+	assert.True(t, UseCluster())
+	SetCurConsensus("raft")
+	assert.True(t, UseCluster())
+	SetCurConsensus("dpos")
+	assert.False(t, UseCluster())
+}
+
+func TestFormatInfo(t *testing.T) {
+	ci := &types.ConsensusInfo{Type: "sbp", Info: `{"Status":"ok"}`, Bps: []string{`{"id":1}`}}
+	out, err := FormatInfo(ci)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"Type":"sbp","Info":{"Status":"ok"},"Bps":[{"id":1}]}`, string(out), "default")
+
+	RegisterInfoFormatter("fmttest", func(ci *types.ConsensusInfo) ([]byte, error) {
+		return json.Marshal(map[string]int{"bps": len(ci.GetBps())})
+	})
+	ci.Type = "FmtTest"
+	out, err = FormatInfo(ci)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"bps":1}`, string(out), "registered")
+}
@@ -0,0 +1,58 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+#include <lualib.h>
+#include <lauxlib.h>
+#include <luajit.h>
+#include "abi_module.h"
+
+/* The types declared by abi.register and abi.event are checked and put in
+ * the ABI by aergoluac. The contract only needs the functions of the typed
+ * declarations, {fn, params = {...}, returns = {...}}, and the arguments of
+ * a call are checked against the ABI before the contract is called. */
+
+static int abi_register_typed(lua_State *L)
+{
+    int i, n = lua_gettop(L);
+
+    for (i = 1; i <= n; i++) {
+        if (lua_istable(L, i)) {
+            lua_rawgeti(L, i, 1);
+            lua_replace(L, i);
+        }
+    }
+    lua_pushvalue(L, lua_upvalueindex(1));
+    lua_insert(L, 1);
+    lua_call(L, n, LUA_MULTRET);
+    return lua_gettop(L);
+}
+
+static int abi_event(lua_State *L)
+{
+    luaL_checkstring(L, 1);
+    luaL_checktype(L, 2, LUA_TTABLE);
+    return 0;
+}
+
+int luaopen_abi_types(lua_State *L)
+{
+    static const char *registers[] = {"register", "register_view", "payable", NULL};
+    int i;
+
+    lua_getglobal(L, "abi");                        /* abi */
+    for (i = 0; registers[i] != NULL; i++) {
+        lua_getfield(L, -1, registers[i]);          /* abi f */
+        if (lua_isnil(L, -1)) {
+            lua_pop(L, 1);
+            continue;
+        }
+        lua_pushcclosure(L, abi_register_typed, 1); /* abi typed_f */
+        lua_setfield(L, -2, registers[i]);          /* abi */
+    }
+    lua_pushcfunction(L, abi_event);
+    lua_setfield(L, -2, "event");
+    lua_pop(L, 1);
+    return 0;
+}
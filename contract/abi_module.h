@@ -0,0 +1,13 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+#ifndef _ABI_MODULE_H
+#define _ABI_MODULE_H
+
+#include <lua.h>
+
+extern int luaopen_abi_types(lua_State *L);
+
+#endif /* _ABI_MODULE_H */
@@ -49,6 +49,13 @@ const (
 	MaxVmService
 )
 
+// Simulation is the service of the transactions executed against a throw-away
+// block state, e.g. to estimate the gas. Such a transaction runs in its own
+// VM context like a query, but writes the SQL database of a contract like the
+// block execution. The caller rolls back the SQL transactions by
+// CloseDatabase.
+const Simulation = -1
+
 func init() {
 	loadReqCh = make(chan *preLoadReq, 10)
 	preLoadInfos[BlockFactory].replyCh = make(chan *loadedReply, 4)
@@ -90,9 +97,11 @@ func Execute(
 
 	var gasLimit uint64
 	if useGas(bi.Version) {
+		// the tip for the gas limit must be covered by the balance as well
+		gasPrice := new(big.Int).Add(bs.GasPrice, txBody.GetTipPrice(bs.GasPrice, bi.Version))
 		if isFeeDelegation {
 			balance := new(big.Int).Sub(receiver.Balance(), usedFee)
-			gasLimit = fee.MaxGasLimit(balance, bs.GasPrice)
+			gasLimit = fee.MaxGasLimit(balance, gasPrice)
 			if gasLimit == 0 {
 				err = newVmError(types.ErrNotEnoughGas)
 				return
@@ -101,7 +110,7 @@ func Execute(
 			gasLimit = txBody.GetGasLimit()
 			if gasLimit == 0 {
 				balance := new(big.Int).Sub(sender.Balance(), usedFee)
-				gasLimit = fee.MaxGasLimit(balance, bs.GasPrice)
+				gasLimit = fee.MaxGasLimit(balance, gasPrice)
 				if gasLimit == 0 {
 					err = newVmError(types.ErrNotEnoughGas)
 					return
@@ -122,15 +131,19 @@ func Execute(
 		return
 	}
 	if receiver.IsRedeploy() {
-		if err = checkRedeploy(sender, receiver, contractState); err != nil {
-			return
+		// From the hardfork V3, the upgrade authority is checked by Create.
+		if !useCodeVersions(bi.No) {
+			if err = checkRedeploy(sender, receiver, contractState); err != nil {
+				return
+			}
 		}
 		bs.RemoveCache(receiver.AccountID())
 	}
 
 	var ex *executor
 
-	if !receiver.IsDeploy() && preLoadInfos[preLoadService].requestedTx == tx {
+	simulation := preLoadService == Simulation
+	if !receiver.IsDeploy() && !simulation && preLoadInfos[preLoadService].requestedTx == tx {
 		replyCh := preLoadInfos[preLoadService].replyCh
 		for {
 			preload := <-replyCh
@@ -154,6 +167,10 @@ func Execute(
 		ctx := newVmContext(bs, cdb, sender, receiver, contractState, sender.ID(),
 			tx.GetHash(), bi, "", true, false, receiver.RP(),
 			preLoadService, txBody.GetAmountBigInt(), gasLimit, isFeeDelegation)
+		if simulation {
+			setQueryContext(ctx)
+			defer releaseQueryContext(ctx)
+		}
 
 		if receiver.IsDeploy() {
 			rv, events, ctrFee, err = Create(contractState, txBody.Payload, receiver.ID(), ctx)
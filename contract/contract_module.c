@@ -391,6 +391,25 @@ static int moduleEvent(lua_State *L)
 	return 0;
 }
 
+static int moduleSetUpgradeAuthority(lua_State *L)
+{
+	char *address = "";
+	char *errStr;
+	int service = getLuaExecContext(L);
+
+    lua_gasuse(L, 500);
+
+	if (!lua_isnoneornil(L, 1)) {
+		address = (char *)luaL_checkstring(L, 1);
+	}
+	errStr = luaSetUpgradeAuthority(L, service, address);
+	if (errStr != NULL) {
+	    strPushAndRelease(L, errStr);
+	    luaL_throwerror(L);
+	}
+	return 0;
+}
+
 static int governance(lua_State *L, char type) {
 	char *ret;
 	int service = getLuaExecContext(L);
@@ -502,6 +521,11 @@ static const luaL_Reg contract_lib[] = {
 	{NULL, NULL}
 };
 
+static const luaL_Reg contract_v3_lib[] = {
+	{"setUpgradeAuthority", moduleSetUpgradeAuthority},
+	{NULL, NULL}
+};
+
 int luaopen_contract(lua_State *L)
 {
 	luaL_register(L, contract_str, contract_lib);
@@ -528,3 +552,14 @@ int luaopen_contract(lua_State *L)
 	lua_pop(L, 1);
 	return 1;
 }
+
+/* luaopen_contract_v3 adds the functions of the hardfork V3 to the contract
+ * module. It's called before the contract is loaded, since the hardfork
+ * version is not known when the state is created. */
+int luaopen_contract_v3(lua_State *L)
+{
+	lua_getglobal(L, contract_str);
+	luaL_register(L, NULL, contract_v3_lib);
+	lua_pop(L, 1);
+	return 0;
+}
@@ -3,6 +3,7 @@
 
 #include "lua.h"
 extern int luaopen_contract(lua_State *L);
+extern int luaopen_contract_v3(lua_State *L);
 
 #endif /* _CONTRACT_MODULE_H */
 
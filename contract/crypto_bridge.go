@@ -0,0 +1,366 @@
+package contract
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/big"
+
+	"github.com/aergoio/aergo/internal/common"
+	"github.com/aergoio/aergo/pkg/trie"
+	"github.com/aergoio/aergo/types"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/crypto/bn256"
+)
+
+// The primitives of the cross-chain bridges: the proofs of the Aergo state
+// trie, the public key recovery of secp256k1 signatures, the aggregate
+// signatures on the bn256 curve and the decoding of Ethereum RLP and block
+// headers. The byte arguments are raw or "0x" prefixed hex strings.
+
+const (
+	rlpMaxDepth   = 16
+	blsSigLen     = 64
+	blsPubKeyLen  = 128
+	ecrecoverLen  = 65
+	digestLen     = 32
+	ethAddressLen = 20
+)
+
+var (
+	errRlpDecode    = errors.New("invalid rlp encoding")
+	errRlpDepth     = errors.New("too deep rlp list")
+	errEthHeader    = errors.New("invalid ethereum block header")
+	errBlsSignature = errors.New("invalid bls signature")
+	errBlsPubKey    = errors.New("invalid bls public key")
+	errDigest       = errors.New("digest must be 32 bytes")
+	errRecoverSig   = errors.New("signature must be 65 bytes of r, s and v")
+
+	// bn256P is the prime of the base field of the bn256 curve.
+	bn256P, _ = new(big.Int).SetString("65000549695646603732796438742359905742825358107623003571877145026864184071783", 10)
+	bn256B    = big.NewInt(3)
+	bn256G2   = new(bn256.G2).ScalarBaseMult(big.NewInt(1))
+)
+
+// verifyAergoAccountProof checks that state, the protobuf encoded account
+// state, is the state of address in the Aergo state trie of root. ap is the
+// uncompressed audit path of the account.
+func verifyAergoAccountProof(root []byte, address string, state []byte, ap [][]byte) (bool, error) {
+	addr, err := types.DecodeAddress(address)
+	if err != nil {
+		return false, err
+	}
+	id := types.ToAccountID(addr)
+	return trie.NewTrie(root, common.Hasher, nil).VerifyInclusion(ap, id[:], common.Hasher(state)), nil
+}
+
+// verifyAergoVarProof checks that value is the value of the storage key in
+// the storage trie of a contract of storageRoot. key is the key as stored,
+// e.g. "_sv_name" for the state variable name, and value is the value as
+// stored, the JSON encoded Lua value.
+func verifyAergoVarProof(storageRoot, key, value []byte, ap [][]byte) bool {
+	return trie.NewTrie(storageRoot, common.Hasher, nil).VerifyInclusion(ap, common.Hasher(key), common.Hasher(value))
+}
+
+// decodeAergoState returns the JSON object of the protobuf encoded account
+// state, whose balance is a bignum.
+func decodeAergoState(data []byte) (string, error) {
+	var st types.State
+	if err := proto.Unmarshal(data, &st); err != nil {
+		return "", err
+	}
+	return marshalLuaJSON(map[string]interface{}{
+		"nonce":       st.GetNonce(),
+		"balance":     luaBignum(new(big.Int).SetBytes(st.GetBalance())),
+		"codeHash":    hexString(st.GetCodeHash()),
+		"storageRoot": hexString(st.GetStorageRoot()),
+	})
+}
+
+// ecrecover returns the Aergo and the Ethereum address of the signer of
+// digest. sig is r, s and v, where v is the recovery id, 0 or 1, or 27 or 28
+// as Ethereum does.
+func ecrecover(digest, sig []byte) (string, string, error) {
+	if len(digest) != digestLen {
+		return "", "", errDigest
+	}
+	if len(sig) != ecrecoverLen {
+		return "", "", errRecoverSig
+	}
+	v := sig[64]
+	if v >= 27 {
+		v -= 27
+	}
+	if v > 1 {
+		return "", "", errRecoverSig
+	}
+	compact := make([]byte, ecrecoverLen)
+	compact[0] = 27 + v
+	copy(compact[1:], sig[:64])
+	pub, _, err := btcec.RecoverCompact(btcec.S256(), compact, digest)
+	if err != nil {
+		return "", "", err
+	}
+	ethAddr := keccak256(pub.SerializeUncompressed()[1:])[32-ethAddressLen:]
+	return types.EncodeAddress(pub.SerializeCompressed()), hexString(ethAddr), nil
+}
+
+// blsHashToPoint maps msg to a point of G1 by try-and-increment from its
+// keccak256 hash.
+func blsHashToPoint(msg []byte) *bn256.G1 {
+	x := new(big.Int).SetBytes(keccak256(msg))
+	x.Mod(x, bn256P)
+	one := big.NewInt(1)
+	for {
+		// y^2 = x^3 + 3
+		y2 := new(big.Int).Exp(x, bn256B, bn256P)
+		y2.Add(y2, bn256B).Mod(y2, bn256P)
+		if y := new(big.Int).ModSqrt(y2, bn256P); y != nil {
+			buf := make([]byte, blsSigLen)
+			xb, yb := x.Bytes(), y.Bytes()
+			copy(buf[32-len(xb):32], xb)
+			copy(buf[blsSigLen-len(yb):], yb)
+			if p, ok := new(bn256.G1).Unmarshal(buf); ok {
+				return p
+			}
+		}
+		x.Add(x, one).Mod(x, bn256P)
+	}
+}
+
+// blsAggregate returns the sum of sigs, the aggregate signature of them.
+func blsAggregate(sigs [][]byte) ([]byte, error) {
+	if len(sigs) == 0 {
+		return nil, errBlsSignature
+	}
+	var agg *bn256.G1
+	for _, s := range sigs {
+		if len(s) != blsSigLen {
+			return nil, errBlsSignature
+		}
+		p, ok := new(bn256.G1).Unmarshal(s)
+		if !ok {
+			return nil, errBlsSignature
+		}
+		if agg == nil {
+			agg = p
+		} else {
+			agg.Add(agg, p)
+		}
+	}
+	return agg.Marshal(), nil
+}
+
+// blsVerify checks that sig is the aggregate signature of msg by all of
+// pubKeys. The public keys must have been proven to be possessed by the
+// signers, or a rogue key can forge an aggregate signature.
+func blsVerify(msg, sig []byte, pubKeys [][]byte) (bool, error) {
+	if len(sig) != blsSigLen {
+		return false, errBlsSignature
+	}
+	s, ok := new(bn256.G1).Unmarshal(sig)
+	if !ok {
+		return false, errBlsSignature
+	}
+	if len(pubKeys) == 0 {
+		return false, errBlsPubKey
+	}
+	var agg *bn256.G2
+	for _, k := range pubKeys {
+		if len(k) != blsPubKeyLen {
+			return false, errBlsPubKey
+		}
+		p, ok := new(bn256.G2).Unmarshal(k)
+		if !ok {
+			return false, errBlsPubKey
+		}
+		if agg == nil {
+			agg = p
+		} else {
+			agg.Add(agg, p)
+		}
+	}
+	lhs := bn256.Pair(s, bn256G2).Marshal()
+	rhs := bn256.Pair(blsHashToPoint(msg), agg).Marshal()
+	return bytes.Equal(lhs, rhs), nil
+}
+
+// rlpDecode decodes data of a single RLP item, which is a []byte for a
+// string or a []interface{} for a list.
+func rlpDecode(data []byte) (interface{}, error) {
+	item, rest, err := rlpDecodeItem(data, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, errRlpDecode
+	}
+	return item, nil
+}
+
+func rlpDecodeItem(data []byte, depth int) (interface{}, []byte, error) {
+	if len(data) == 0 {
+		return nil, nil, errRlpDecode
+	}
+	var (
+		prefix  = data[0]
+		isList  = prefix >= 0xc0
+		start   uint64
+		itemLen uint64
+	)
+	switch {
+	case prefix <= 0x7f:
+		return data[:1], data[1:], nil
+	case prefix <= 0xb7:
+		start, itemLen = 1, uint64(prefix-0x80)
+	case prefix <= 0xbf:
+		lenLen := int(prefix - 0xb7)
+		l, err := rlpDecodeLen(data[1:], lenLen)
+		if err != nil {
+			return nil, nil, err
+		}
+		start, itemLen = uint64(1+lenLen), l
+	case prefix <= 0xf7:
+		start, itemLen = 1, uint64(prefix-0xc0)
+	default:
+		lenLen := int(prefix - 0xf7)
+		l, err := rlpDecodeLen(data[1:], lenLen)
+		if err != nil {
+			return nil, nil, err
+		}
+		start, itemLen = uint64(1+lenLen), l
+	}
+	if itemLen > uint64(len(data))-start {
+		return nil, nil, errRlpDecode
+	}
+	end := start + itemLen
+	if !isList {
+		return data[start:end], data[end:], nil
+	}
+	if depth >= rlpMaxDepth {
+		return nil, nil, errRlpDepth
+	}
+	list := make([]interface{}, 0)
+	for elems := data[start:end]; len(elems) > 0; {
+		var (
+			elem interface{}
+			err  error
+		)
+		elem, elems, err = rlpDecodeItem(elems, depth+1)
+		if err != nil {
+			return nil, nil, err
+		}
+		list = append(list, elem)
+	}
+	return list, data[end:], nil
+}
+
+func rlpDecodeLen(data []byte, lenLen int) (uint64, error) {
+	if lenLen > 8 || len(data) < lenLen || data[0] == 0 {
+		return 0, errRlpDecode
+	}
+	var l uint64
+	for _, b := range data[:lenLen] {
+		l = l<<8 | uint64(b)
+	}
+	return l, nil
+}
+
+// rlpDecodeJSON returns the JSON array, or string, of the RLP data, whose
+// strings are hex encoded.
+func rlpDecodeJSON(data []byte) (string, error) {
+	item, err := rlpDecode(data)
+	if err != nil {
+		return "", err
+	}
+	return marshalLuaJSON(rlpToHex(item))
+}
+
+func rlpToHex(item interface{}) interface{} {
+	switch v := item.(type) {
+	case []byte:
+		return hexString(v)
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i, e := range v {
+			list[i] = rlpToHex(e)
+		}
+		return list
+	}
+	return nil
+}
+
+// ethHeaderFields are the fields of an Ethereum block header in order. The
+// fields after nonce are added by the hard forks of Ethereum and optional.
+var ethHeaderFields = []string{
+	"parentHash", "sha3Uncles", "miner", "stateRoot", "transactionsRoot",
+	"receiptsRoot", "logsBloom", "difficulty", "number", "gasLimit", "gasUsed",
+	"timestamp", "extraData", "mixHash", "nonce", "baseFeePerGas",
+	"withdrawalsRoot", "blobGasUsed", "excessBlobGas", "parentBeaconBlockRoot",
+	"requestsHash",
+}
+
+const ethHeaderMinFields = 15
+
+// ethNumberFields are decoded as numbers, and the others as hex strings.
+// The big ones are decoded as bignums.
+var ethNumberFields = map[string]bool{
+	"number": false, "gasLimit": false, "gasUsed": false, "timestamp": false,
+	"blobGasUsed": false, "excessBlobGas": false,
+	"difficulty": true, "baseFeePerGas": true,
+}
+
+// ethHeaderJSON returns the JSON object of the RLP encoded Ethereum block
+// header, with its hash.
+func ethHeaderJSON(data []byte) (string, error) {
+	item, err := rlpDecode(data)
+	if err != nil {
+		return "", err
+	}
+	fields, ok := item.([]interface{})
+	if !ok || len(fields) < ethHeaderMinFields || len(fields) > len(ethHeaderFields) {
+		return "", errEthHeader
+	}
+	header := map[string]interface{}{
+		"hash": hexString(keccak256(data)),
+	}
+	for i, f := range fields {
+		b, ok := f.([]byte)
+		if !ok {
+			return "", errEthHeader
+		}
+		name := ethHeaderFields[i]
+		isBig, isNumber := ethNumberFields[name]
+		switch {
+		case isBig:
+			header[name] = luaBignum(new(big.Int).SetBytes(b))
+		case isNumber:
+			if len(b) > 7 {
+				return "", errEthHeader
+			}
+			header[name] = new(big.Int).SetBytes(b).Uint64()
+		default:
+			header[name] = hexString(b)
+		}
+	}
+	return marshalLuaJSON(header)
+}
+
+func hexString(b []byte) string {
+	return "0x" + hex.EncodeToString(b)
+}
+
+// luaBignum is the JSON object which is decoded as a bignum in Lua.
+func luaBignum(n *big.Int) map[string]string {
+	return map[string]string{"_bignum": n.String()}
+}
+
+func marshalLuaJSON(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -0,0 +1,250 @@
+package contract
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"math/big"
+	"sort"
+	"testing"
+
+	"github.com/aergoio/aergo/internal/common"
+	"github.com/aergoio/aergo/pkg/trie"
+	"github.com/aergoio/aergo/types"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/crypto/bn256"
+)
+
+func TestVerifyAergoProof(t *testing.T) {
+	addr := make([]byte, types.AddressLength)
+	addr[0] = 0x02
+	st := &types.State{Nonce: 3, Balance: big.NewInt(1000).Bytes(), StorageRoot: common.Hasher([]byte("root"))}
+	stBytes, err := proto.Marshal(st)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id := types.ToAccountID(addr)
+	keys := [][]byte{id[:], common.Hasher([]byte("a")), common.Hasher([]byte("b"))}
+	values := [][]byte{common.Hasher(stBytes), common.Hasher([]byte("1")), common.Hasher([]byte("2"))}
+	sort.Sort(trieKV{keys, values})
+	tr := trie.NewTrie(nil, common.Hasher, nil)
+	root, err := tr.Update(keys, values)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ap, included, _, _, err := tr.MerkleProof(id[:])
+	if err != nil || !included {
+		t.Fatalf("failed to get proof: %v", err)
+	}
+
+	ok, err := verifyAergoAccountProof(root, types.EncodeAddress(addr), stBytes, ap)
+	if err != nil || !ok {
+		t.Errorf("failed to verify the account proof: %v", err)
+	}
+	st.Nonce++
+	forged, _ := proto.Marshal(st)
+	if ok, _ := verifyAergoAccountProof(root, types.EncodeAddress(addr), forged, ap); ok {
+		t.Error("verified a forged state")
+	}
+	if _, err := verifyAergoAccountProof(root, "invalid address", stBytes, ap); err == nil {
+		t.Error("accepted an invalid address")
+	}
+
+	ap, _, _, _, _ = tr.MerkleProof(common.Hasher([]byte("a")))
+	if !verifyAergoVarProof(root, []byte("a"), []byte("1"), ap) {
+		t.Error("failed to verify the variable proof")
+	}
+	if verifyAergoVarProof(root, []byte("a"), []byte("2"), ap) {
+		t.Error("verified a forged variable")
+	}
+
+	decoded, err := decodeAergoState(stBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(decoded), &m); err != nil {
+		t.Fatal(err)
+	}
+	if m["nonce"].(float64) != 3 || m["balance"].(map[string]interface{})["_bignum"] != "1000" ||
+		m["storageRoot"] != hexString(st.StorageRoot) {
+		t.Errorf("unexpected state: %s", decoded)
+	}
+}
+
+type trieKV struct {
+	keys, values [][]byte
+}
+
+func (kv trieKV) Len() int           { return len(kv.keys) }
+func (kv trieKV) Less(i, j int) bool { return bytes.Compare(kv.keys[i], kv.keys[j]) < 0 }
+func (kv trieKV) Swap(i, j int) {
+	kv.keys[i], kv.keys[j] = kv.keys[j], kv.keys[i]
+	kv.values[i], kv.values[j] = kv.values[j], kv.values[i]
+}
+
+func TestEcrecover(t *testing.T) {
+	key, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := keccak256([]byte("message"))
+	compact, err := btcec.SignCompact(btcec.S256(), key, digest, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig := append(append([]byte{}, compact[1:]...), compact[0]-27-4)
+
+	aergoAddr, ethAddr, err := ecrecover(digest, sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if aergoAddr != types.EncodeAddress(key.PubKey().SerializeCompressed()) {
+		t.Errorf("unexpected aergo address: %s", aergoAddr)
+	}
+	if ethAddr != hexString(keccak256(key.PubKey().SerializeUncompressed()[1:])[12:]) {
+		t.Errorf("unexpected ethereum address: %s", ethAddr)
+	}
+
+	sig[64] += 27
+	if a, _, err := ecrecover(digest, sig); err != nil || a != aergoAddr {
+		t.Errorf("failed to recover with v of ethereum: %v", err)
+	}
+	sig[64] = 2
+	if _, _, err := ecrecover(digest, sig); err != errRecoverSig {
+		t.Errorf("accepted an invalid recovery id: %v", err)
+	}
+	if _, _, err := ecrecover(digest[1:], sig); err != errDigest {
+		t.Errorf("accepted an invalid digest: %v", err)
+	}
+}
+
+func TestBls(t *testing.T) {
+	msg := []byte("block 100")
+	var sigs, pubKeys [][]byte
+	for i := 0; i < 3; i++ {
+		sk, pk, err := bn256.RandomG2(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sigs = append(sigs, new(bn256.G1).ScalarMult(blsHashToPoint(msg), sk).Marshal())
+		pubKeys = append(pubKeys, pk.Marshal())
+	}
+
+	if ok, err := blsVerify(msg, sigs[0], pubKeys[:1]); err != nil || !ok {
+		t.Errorf("failed to verify a signature: %v", err)
+	}
+	agg, err := blsAggregate(sigs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := blsVerify(msg, agg, pubKeys); err != nil || !ok {
+		t.Errorf("failed to verify the aggregate signature: %v", err)
+	}
+	if ok, _ := blsVerify(msg, agg, pubKeys[:2]); ok {
+		t.Error("verified the aggregate signature by a part of the signers")
+	}
+	if ok, _ := blsVerify([]byte("block 101"), agg, pubKeys); ok {
+		t.Error("verified the aggregate signature of another message")
+	}
+	if _, err := blsVerify(msg, agg[1:], pubKeys); err != errBlsSignature {
+		t.Errorf("accepted an invalid signature: %v", err)
+	}
+	if _, err := blsAggregate(nil); err != errBlsSignature {
+		t.Errorf("aggregated no signature: %v", err)
+	}
+}
+
+func TestRlpDecode(t *testing.T) {
+	tests := []struct {
+		data string
+		json string
+		err  bool
+	}{
+		{"0x83646f67", `"0x646f67"`, false},
+		{"0xc88363617483646f67", `["0x636174","0x646f67"]`, false},
+		{"0xc7c0c1c0c3c0c1c0", `[[],[[]],[[],[[]]]]`, false},
+		{"0x80", `"0x"`, false},
+		{"0x0f", `"0x0f"`, false},
+		{"0x8364", "", true},
+		{"0xc88363617483646f", "", true},
+		{"0x83646f6700", "", true},
+		{"0xb900", "", true},
+	}
+	for _, test := range tests {
+		j, err := rlpDecodeJSON(toBytes(test.data))
+		if test.err {
+			if err == nil {
+				t.Errorf("decoded invalid rlp %s: %s", test.data, j)
+			}
+			continue
+		}
+		if err != nil || j != test.json {
+			t.Errorf("rlp %s: expected %s, got %s (%v)", test.data, test.json, j, err)
+		}
+	}
+
+	deep := []byte{0xc0}
+	for i := 0; i < rlpMaxDepth; i++ {
+		deep = rlpList{rlpRaw(deep)}.rlpEncode()
+	}
+	if _, err := rlpDecode(deep); err != errRlpDepth {
+		t.Errorf("decoded too deep rlp: %v", err)
+	}
+}
+
+// rlpRaw is an encoded rlp item.
+type rlpRaw []byte
+
+func (r rlpRaw) rlpEncode() []byte {
+	return r
+}
+
+func TestEthHeader(t *testing.T) {
+	fields := rlpList{
+		rlpString(bytes.Repeat([]byte{1}, 32)), // parentHash
+		rlpString(bytes.Repeat([]byte{2}, 32)), // sha3Uncles
+		rlpString(bytes.Repeat([]byte{3}, 20)), // miner
+		rlpString(bytes.Repeat([]byte{4}, 32)), // stateRoot
+		rlpString(bytes.Repeat([]byte{5}, 32)), // transactionsRoot
+		rlpString(bytes.Repeat([]byte{6}, 32)), // receiptsRoot
+		rlpString(make([]byte, 256)),           // logsBloom
+		rlpString(nil),                         // difficulty
+		rlpString(toBinary(17000000)),          // number
+		rlpString(toBinary(30000000)),          // gasLimit
+		rlpString(toBinary(12345)),             // gasUsed
+		rlpString(toBinary(1680000000)),        // timestamp
+		rlpString([]byte("extra")),             // extraData
+		rlpString(bytes.Repeat([]byte{7}, 32)), // mixHash
+		rlpString(make([]byte, 8)),             // nonce
+		rlpString(big.NewInt(1e12).Bytes()),    // baseFeePerGas
+	}
+	data := fields.rlpEncode()
+	j, err := ethHeaderJSON(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var h map[string]interface{}
+	if err := json.Unmarshal([]byte(j), &h); err != nil {
+		t.Fatal(err)
+	}
+	if h["hash"] != hexString(keccak256(data)) ||
+		h["stateRoot"] != hexString(bytes.Repeat([]byte{4}, 32)) ||
+		h["receiptsRoot"] != hexString(bytes.Repeat([]byte{6}, 32)) ||
+		h["number"].(float64) != 17000000 || h["timestamp"].(float64) != 1680000000 ||
+		h["difficulty"].(map[string]interface{})["_bignum"] != "0" ||
+		h["baseFeePerGas"].(map[string]interface{})["_bignum"] != "1000000000000" {
+		t.Errorf("unexpected header: %s", j)
+	}
+
+	if _, err := ethHeaderJSON(fields[:14].rlpEncode()); err != errEthHeader {
+		t.Errorf("decoded a header of too few fields: %v", err)
+	}
+	listField := append(rlpList{}, fields...)
+	listField[8] = rlpList{}
+	if _, err := ethHeaderJSON(listField.rlpEncode()); err != errEthHeader {
+		t.Errorf("decoded a header of a list field: %v", err)
+	}
+}
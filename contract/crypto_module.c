@@ -150,6 +150,220 @@ static int crypto_keccak256(lua_State *L)
 	return 1;
 }
 
+static struct proof *get_bytes_args(lua_State *L, int from, int argc, size_t *n)
+{
+    struct proof *args;
+    int i;
+
+    *n = 0;
+    if (argc < from) {
+        return NULL;
+    }
+    *n = argc - from + 1;
+    args = (struct proof *)malloc(sizeof(struct proof) * (*n));
+    for (i = from; i <= argc; ++i) {
+        if (lua_type(L, i) != LUA_TSTRING) {
+            free(args);
+            luaL_typerror(L, i, lua_typename(L, LUA_TSTRING));
+        }
+        args[i-from].data = (char *)lua_tolstring(L, i, &args[i-from].len);
+    }
+    return args;
+}
+
+static int crypto_verifyAergoAccount(lua_State *L)
+{
+    int argc = lua_gettop(L);
+    char *root, *addr, *state;
+    size_t rootLen, stateLen, nProof;
+    struct proof *proof;
+    struct luaCryptoVerifyAergoAccount_return ret;
+    const int proofIndex = 4;
+
+    luaL_checktype(L, 1, LUA_TSTRING);
+    luaL_checktype(L, 2, LUA_TSTRING);
+    luaL_checktype(L, 3, LUA_TSTRING);
+    lua_gasuse(L, 5000);
+    lua_gasuse_mul(L, 100, argc);
+    root = (char *)lua_tolstring(L, 1, &rootLen);
+    addr = (char *)lua_tostring(L, 2);
+    state = (char *)lua_tolstring(L, 3, &stateLen);
+    proof = get_bytes_args(L, proofIndex, argc, &nProof);
+
+    ret = luaCryptoVerifyAergoAccount(root, rootLen, addr, state, stateLen, proof, nProof);
+    if (proof != NULL) {
+        free(proof);
+    }
+    if (ret.r1 != NULL) {
+        strPushAndRelease(L, ret.r1);
+        lua_error(L);
+    }
+    lua_pushboolean(L, ret.r0);
+    return 1;
+}
+
+static int crypto_verifyAergoVar(lua_State *L)
+{
+    int argc = lua_gettop(L);
+    char *root, *key, *value;
+    size_t rootLen, keyLen, valueLen, nProof;
+    struct proof *proof;
+    int b;
+    const int proofIndex = 4;
+
+    luaL_checktype(L, 1, LUA_TSTRING);
+    luaL_checktype(L, 2, LUA_TSTRING);
+    luaL_checktype(L, 3, LUA_TSTRING);
+    lua_gasuse(L, 5000);
+    lua_gasuse_mul(L, 100, argc);
+    root = (char *)lua_tolstring(L, 1, &rootLen);
+    key = (char *)lua_tolstring(L, 2, &keyLen);
+    value = (char *)lua_tolstring(L, 3, &valueLen);
+    proof = get_bytes_args(L, proofIndex, argc, &nProof);
+
+    b = luaCryptoVerifyAergoVar(root, rootLen, key, keyLen, value, valueLen, proof, nProof);
+    if (proof != NULL) {
+        free(proof);
+    }
+    lua_pushboolean(L, b);
+    return 1;
+}
+
+static int push_json_result(lua_State *L, char *json, char *err)
+{
+    if (err != NULL) {
+        strPushAndRelease(L, err);
+        lua_error(L);
+    }
+    minus_inst_count(L, strlen(json));
+    if (lua_util_json_to_lua(L, json, false) != 0) {
+        free(json);
+        luaL_error(L, "crypto: can't convert the result to lua");
+    }
+    free(json);
+    return 1;
+}
+
+static int crypto_decodeAergoState(lua_State *L)
+{
+    size_t len;
+    char *data;
+    struct luaCryptoDecodeAergoState_return ret;
+
+    lua_gasuse(L, 500);
+    luaL_checktype(L, 1, LUA_TSTRING);
+    data = (char *)lua_tolstring(L, 1, &len);
+
+    ret = luaCryptoDecodeAergoState(data, len);
+    return push_json_result(L, ret.r0, ret.r1);
+}
+
+static int crypto_ecrecover(lua_State *L)
+{
+    size_t digestLen, sigLen;
+    char *digest, *sig;
+    struct luaCryptoEcrecover_return ret;
+    int service = getLuaExecContext(L);
+
+    lua_gasuse(L, 5000);
+    luaL_checktype(L, 1, LUA_TSTRING);
+    luaL_checktype(L, 2, LUA_TSTRING);
+    digest = (char *)lua_tolstring(L, 1, &digestLen);
+    sig = (char *)lua_tolstring(L, 2, &sigLen);
+
+    ret = luaCryptoEcrecover(L, service, digest, digestLen, sig, sigLen);
+    if (ret.r2 != NULL) {
+        strPushAndRelease(L, ret.r2);
+        lua_error(L);
+    }
+    strPushAndRelease(L, ret.r0);
+    strPushAndRelease(L, ret.r1);
+    return 2;
+}
+
+static int crypto_blsAggregate(lua_State *L)
+{
+    int argc = lua_gettop(L);
+    size_t nSigs;
+    struct proof *sigs;
+    struct luaCryptoBlsAggregate_return ret;
+
+    lua_gasuse(L, 1000);
+    lua_gasuse_mul(L, 500, argc);
+    sigs = get_bytes_args(L, 1, argc, &nSigs);
+
+    ret = luaCryptoBlsAggregate(sigs, nSigs);
+    if (sigs != NULL) {
+        free(sigs);
+    }
+    if (ret.r1 != NULL) {
+        strPushAndRelease(L, ret.r1);
+        lua_error(L);
+    }
+    strPushAndRelease(L, ret.r0);
+    return 1;
+}
+
+static int crypto_blsVerify(lua_State *L)
+{
+    int argc = lua_gettop(L);
+    char *msg, *sig;
+    size_t msgLen, sigLen, nPubKeys;
+    struct proof *pubKeys;
+    struct luaCryptoBlsVerify_return ret;
+    int service = getLuaExecContext(L);
+    const int pubKeyIndex = 3;
+
+    luaL_checktype(L, 1, LUA_TSTRING);
+    luaL_checktype(L, 2, LUA_TSTRING);
+    lua_gasuse(L, 100000);
+    lua_gasuse_mul(L, 1000, argc);
+    msg = (char *)lua_tolstring(L, 1, &msgLen);
+    sig = (char *)lua_tolstring(L, 2, &sigLen);
+    pubKeys = get_bytes_args(L, pubKeyIndex, argc, &nPubKeys);
+
+    ret = luaCryptoBlsVerify(L, service, msg, msgLen, sig, sigLen, pubKeys, nPubKeys);
+    if (pubKeys != NULL) {
+        free(pubKeys);
+    }
+    if (ret.r1 != NULL) {
+        strPushAndRelease(L, ret.r1);
+        lua_error(L);
+    }
+    lua_pushboolean(L, ret.r0);
+    return 1;
+}
+
+static int crypto_rlpDecode(lua_State *L)
+{
+    size_t len;
+    char *data;
+    struct luaCryptoRlpDecode_return ret;
+
+    luaL_checktype(L, 1, LUA_TSTRING);
+    data = (char *)lua_tolstring(L, 1, &len);
+    lua_gasuse(L, 500);
+    lua_gasuse_mul(L, 2, len);
+
+    ret = luaCryptoRlpDecode(data, len);
+    return push_json_result(L, ret.r0, ret.r1);
+}
+
+static int crypto_ethHeader(lua_State *L)
+{
+    size_t len;
+    char *data;
+    struct luaCryptoEthHeader_return ret;
+
+    luaL_checktype(L, 1, LUA_TSTRING);
+    data = (char *)lua_tolstring(L, 1, &len);
+    lua_gasuse(L, 1000);
+    lua_gasuse_mul(L, 2, len);
+
+    ret = luaCryptoEthHeader(data, len);
+    return push_json_result(L, ret.r0, ret.r1);
+}
+
 static const luaL_Reg crypto_lib[] = {
 	{"sha256", crypto_sha256},
 	{"ecverify", crypto_ecverify},
@@ -158,9 +372,32 @@ static const luaL_Reg crypto_lib[] = {
 	{NULL, NULL}
 };
 
+static const luaL_Reg crypto_v3_lib[] = {
+	{"verifyAergoAccount", crypto_verifyAergoAccount},
+	{"verifyAergoVar", crypto_verifyAergoVar},
+	{"decodeAergoState", crypto_decodeAergoState},
+	{"ecrecover", crypto_ecrecover},
+	{"blsAggregate", crypto_blsAggregate},
+	{"blsVerify", crypto_blsVerify},
+	{"rlpDecode", crypto_rlpDecode},
+	{"ethHeader", crypto_ethHeader},
+	{NULL, NULL}
+};
+
 int luaopen_crypto(lua_State *L)
 {
 	luaL_register(L, "crypto", crypto_lib);
 	lua_pop(L, 1);
 	return 1;
 }
+
+/* luaopen_crypto_v3 adds the functions of the hardfork V3 to the crypto
+ * module. It's called before the contract is loaded, since the hardfork
+ * version is not known when the state is created. */
+int luaopen_crypto_v3(lua_State *L)
+{
+	lua_getglobal(L, "crypto");
+	luaL_register(L, NULL, crypto_v3_lib);
+	lua_pop(L, 1);
+	return 0;
+}
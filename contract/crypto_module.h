@@ -3,5 +3,6 @@
 
 #include "lua.h"
 extern int luaopen_crypto(lua_State *L);
+extern int luaopen_crypto_v3(lua_State *L);
 
 #endif /* _CRYPTO_MODULE_H */
\ No newline at end of file
@@ -1,6 +1,7 @@
 // +build Debug
 
 #include <stdlib.h>
+#include <string.h>
 #include "lua.h"
 
 #include "lualib.h"
@@ -121,6 +122,71 @@ static int get_watchpoint_lua(lua_State *L) {
     return 1;
 }
 
+static int remote_enabled_lua(lua_State *L) {
+    lua_pushboolean(L, CRemoteEnabled());
+
+    return 1;
+}
+
+static int remote_line_lua(lua_State *L) {
+    const char* contract_id_hex = luaL_checkstring (L, 1);
+    double line = luaL_checknumber (L, 2);
+    double level = luaL_checknumber (L, 3);
+    double watch = luaL_optnumber (L, 4, 0);
+
+    CRemoteLine(L, luaL_service(L), (char *)contract_id_hex, line, level, watch);
+
+    return 0;
+}
+
+static int remote_frame_lua(lua_State *L) {
+    double level = luaL_checknumber (L, 1);
+    const char* name = luaL_checkstring (L, 2);
+    const char* contract_id_hex = luaL_checkstring (L, 3);
+    double line = luaL_checknumber (L, 4);
+
+    CRemoteFrame(level, (char *)name, (char *)contract_id_hex, line);
+
+    return 0;
+}
+
+static int remote_var_lua(lua_State *L) {
+    const char* key = luaL_checkstring (L, 1);
+    const char* name = luaL_checkstring (L, 2);
+    const char* type_name = luaL_checkstring (L, 3);
+    const char* value = luaL_checkstring (L, 4);
+    int has_fields = lua_toboolean (L, 5);
+
+    CRemoteVar((char *)key, (char *)name, (char *)type_name, (char *)value, has_fields);
+
+    return 0;
+}
+
+// call a function of the debugger for a remote debugger with the hook
+// disabled. it returns the error message, which must be freed
+char *vm_remote_call(lua_State *L, const char *fname, int level, const char *arg)
+{
+    lua_Hook hook = lua_gethook(L);
+    int mask = lua_gethookmask(L);
+    int count = lua_gethookcount(L);
+    char *err = NULL;
+
+    lua_sethook(L, NULL, 0, 0);
+    lua_getglobal(L, "__debugger");
+    lua_getfield(L, -1, fname);
+    lua_remove(L, -2);
+    lua_pushinteger(L, level);
+    lua_pushstring(L, arg);
+    if (lua_pcall(L, 2, 0, 0) != 0) {
+        const char *msg = lua_tostring(L, -1);
+        err = strdup(msg != NULL ? msg : "unknown error");
+        lua_pop(L, 1);
+    }
+    lua_sethook(L, hook, mask, count);
+
+    return err;
+}
+
 const char* vm_set_debug_hook(lua_State *L)
 {
     lua_pushcfunction(L, get_contract_info_lua);
@@ -148,6 +214,15 @@ const char* vm_set_debug_hook(lua_State *L)
     lua_setglobal(L, "__reset_watchpoints");
     lua_pushcfunction(L, len_watchpoints_lua);
     lua_setglobal(L, "__len_watchpoints");
+
+    lua_pushcfunction(L, remote_enabled_lua);
+    lua_setglobal(L, "__remote_enabled");
+    lua_pushcfunction(L, remote_line_lua);
+    lua_setglobal(L, "__remote_line");
+    lua_pushcfunction(L, remote_frame_lua);
+    lua_setglobal(L, "__remote_frame");
+    lua_pushcfunction(L, remote_var_lua);
+    lua_setglobal(L, "__remote_var");
     
     char* code = (char *)GetDebuggerCode();
     luaL_loadstring(L, code);
@@ -6,5 +6,6 @@
 #include "lua.h"
 
 const char *vm_set_debug_hook(lua_State *L);
+char *vm_remote_call(lua_State *L, const char *fname, int level, const char *arg);
 
 #endif
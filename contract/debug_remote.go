@@ -0,0 +1,417 @@
+// +build Debug
+
+package contract
+
+/*
+#include <stdlib.h>
+#include "debug.h"
+*/
+import "C"
+import (
+	"errors"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+// DebugRemote is a debugger front end, e.g. a debug adapter of IDEs, which
+// controls the execution of contracts instead of the debugger console.
+type DebugRemote interface {
+	// Stopped is called when the execution is paused, and returns how to
+	// resume it. It is called by the goroutine executing the contract, and
+	// the inspector must be used by it until Stopped returns.
+	Stopped(stop *DebugStop, in *DebugInspector) DebugResume
+}
+
+// DebugStop is the position where the execution is paused, and why.
+type DebugStop struct {
+	Reason   string // entry, pause, breakpoint, watch or step
+	Contract string
+	Source   string
+	Line     uint64
+	Watch    string
+}
+
+// DebugResume is how to resume the paused execution.
+type DebugResume int
+
+const (
+	DebugContinue DebugResume = iota
+	DebugStepIn
+	DebugStepOver
+	DebugStepOut
+	// DebugDetach detaches the remote debugger and continues
+	DebugDetach
+)
+
+// DebugFrame is a call frame of a contract function. The frames of the
+// contracts which call other contracts follow the ones of the callees.
+type DebugFrame struct {
+	ID       int
+	Name     string
+	Contract string
+	Source   string
+	Line     uint64
+}
+
+// DebugScope is a group of variables of a frame.
+type DebugScope struct {
+	Name string
+	Ref  int
+}
+
+// DebugVariable is a variable or a field of a table. Ref refers to the
+// fields of a table, and it is 0 for the other types.
+type DebugVariable struct {
+	Name  string
+	Type  string
+	Value string
+	Ref   int
+}
+
+// debugPos is the position of a line in the nested contract calls, which
+// are compared by the call depth and then by the Lua stack level.
+type debugPos struct {
+	depth int
+	level int
+}
+
+func (p debugPos) less(o debugPos) bool {
+	return p.depth < o.depth || (p.depth == o.depth && p.level < o.level)
+}
+
+var (
+	debugMu          sync.Mutex
+	debugRemote      DebugRemote
+	debugPause       string
+	debugSourcePoint = make(map[string]map[uint64]bool)
+
+	debugStep    DebugResume
+	debugStepPos debugPos
+	// the Lua states of the contracts in the nested calls by the call depth
+	debugStates []*LState
+
+	debugCollector *debugCollection
+)
+
+// SetDebugRemote attaches a remote debugger, or detaches it if r is nil.
+func SetDebugRemote(r DebugRemote) {
+	debugMu.Lock()
+	defer debugMu.Unlock()
+	debugRemote = r
+	debugPause = ""
+	debugStep = DebugContinue
+}
+
+// PauseDebug pauses the execution at the next line of contracts with the
+// reason, which is reported to the remote debugger.
+func PauseDebug(reason string) {
+	debugMu.Lock()
+	defer debugMu.Unlock()
+	debugPause = reason
+}
+
+// SetSourceBreakPoints replaces the breakpoints of the contracts deployed
+// from the source file.
+func SetSourceBreakPoints(path string, lines []uint64) {
+	debugMu.Lock()
+	defer debugMu.Unlock()
+	path = sourceKey(path)
+	if len(lines) == 0 {
+		delete(debugSourcePoint, path)
+		return
+	}
+	points := make(map[uint64]bool)
+	for _, line := range lines {
+		points[line] = true
+	}
+	debugSourcePoint[path] = points
+}
+
+func sourceKey(path string) string {
+	if absPath, err := filepath.Abs(path); err == nil {
+		path = absPath
+	}
+	return filepath.ToSlash(path)
+}
+
+func getSrcPath(contract_id_hex string) string {
+	if info, ok := contract_info_map[contract_id_hex]; ok {
+		return info.src_path
+	}
+	return ""
+}
+
+func hasSourceBreakPoint(contract_id_hex string, line uint64) bool {
+	path := getSrcPath(contract_id_hex)
+	if path == "" {
+		return false
+	}
+	debugMu.Lock()
+	defer debugMu.Unlock()
+	return debugSourcePoint[path][line]
+}
+
+//export CRemoteEnabled
+func CRemoteEnabled() C.int {
+	debugMu.Lock()
+	defer debugMu.Unlock()
+	if debugRemote == nil {
+		return C.int(0)
+	}
+	return C.int(1)
+}
+
+//export CRemoteLine
+func CRemoteLine(L *LState, service C.int, contract_id_hex_c *C.char, line_c C.double, level_c C.double, watch_c C.double) {
+	ctx := contexts[service]
+	if ctx == nil {
+		return
+	}
+	contract_id_hex := C.GoString(contract_id_hex_c)
+	line := uint64(line_c)
+	pos := debugPos{int(ctx.callDepth), int(level_c)}
+	if pos.depth < 1 {
+		pos.depth = 1
+	}
+	for len(debugStates) < pos.depth-1 {
+		debugStates = append(debugStates, nil)
+	}
+	debugStates = append(debugStates[:pos.depth-1], L)
+
+	debugMu.Lock()
+	r := debugRemote
+	reason := debugPause
+	debugPause = ""
+	debugMu.Unlock()
+	if r == nil {
+		return
+	}
+
+	stop := &DebugStop{Line: line, Source: getSrcPath(contract_id_hex)}
+	if addr, err := HexAddrToBase58Addr(contract_id_hex); err == nil {
+		stop.Contract = addr
+	}
+	switch {
+	case reason != "":
+	case watch_c > 0:
+		reason = "watch"
+		stop.Watch = getWatchPoint(int(watch_c))
+	case HasBreakPoint(contract_id_hex, line) || hasSourceBreakPoint(contract_id_hex, line):
+		reason = "breakpoint"
+	case debugStep == DebugStepIn:
+		reason = "step"
+	case debugStep == DebugStepOver && !debugStepPos.less(pos):
+		reason = "step"
+	case debugStep == DebugStepOut && pos.less(debugStepPos):
+		reason = "step"
+	}
+	if reason == "" {
+		return
+	}
+	stop.Reason = reason
+
+	in := &DebugInspector{depth: pos.depth}
+	resume := r.Stopped(stop, in)
+	in.depth = 0
+
+	debugStep = resume
+	debugStepPos = pos
+	if resume == DebugDetach {
+		SetDebugRemote(nil)
+	}
+}
+
+type debugCollection struct {
+	frames []*debugFrameRef
+	vars   []*debugVarRef
+}
+
+type debugFrameRef struct {
+	DebugFrame
+	L     *LState
+	level int
+}
+
+type debugVarRef struct {
+	DebugVariable
+	frame int
+	path  []string
+}
+
+//export CRemoteFrame
+func CRemoteFrame(level_c C.double, name_c *C.char, contract_id_hex_c *C.char, line_c C.double) {
+	if debugCollector == nil {
+		return
+	}
+	contract_id_hex := C.GoString(contract_id_hex_c)
+	frame := &debugFrameRef{level: int(level_c)}
+	frame.Name = C.GoString(name_c)
+	frame.Source = getSrcPath(contract_id_hex)
+	frame.Line = uint64(line_c)
+	if addr, err := HexAddrToBase58Addr(contract_id_hex); err == nil {
+		frame.Contract = addr
+	}
+	debugCollector.frames = append(debugCollector.frames, frame)
+}
+
+//export CRemoteVar
+func CRemoteVar(key_c *C.char, name_c *C.char, type_c *C.char, value_c *C.char, has_fields_c C.int) {
+	if debugCollector == nil {
+		return
+	}
+	v := &debugVarRef{}
+	v.Name = C.GoString(name_c)
+	v.Type = C.GoString(type_c)
+	v.Value = C.GoString(value_c)
+	if has_fields_c != 0 {
+		v.path = []string{C.GoString(key_c)}
+	}
+	debugCollector.vars = append(debugCollector.vars, v)
+}
+
+// DebugInspector inspects the paused contracts for a remote debugger.
+type DebugInspector struct {
+	depth  int
+	frames []*debugFrameRef
+	vars   []*debugVarRef
+}
+
+var errDebugResumed = errors.New("the execution is not paused")
+
+func (in *DebugInspector) remoteCall(L *LState, fname string, level int, arg string) (*debugCollection, error) {
+	collector := &debugCollection{}
+	debugCollector = collector
+	defer func() {
+		debugCollector = nil
+	}()
+
+	cFname := C.CString(fname)
+	cArg := C.CString(arg)
+	cErrMsg := C.vm_remote_call(L, cFname, C.int(level), cArg)
+	C.free(unsafe.Pointer(cFname))
+	C.free(unsafe.Pointer(cArg))
+	if cErrMsg != nil {
+		errMsg := C.GoString(cErrMsg)
+		C.free(unsafe.Pointer(cErrMsg))
+		return nil, errors.New(errMsg)
+	}
+	return collector, nil
+}
+
+// Frames returns the call frames from the innermost one.
+func (in *DebugInspector) Frames() ([]*DebugFrame, error) {
+	if in.depth == 0 {
+		return nil, errDebugResumed
+	}
+	if in.frames == nil {
+		in.frames = []*debugFrameRef{}
+		for i := in.depth - 1; i >= 0 && i < len(debugStates); i-- {
+			L := debugStates[i]
+			if L == nil {
+				continue
+			}
+			collected, err := in.remoteCall(L, "remote_frames", 0, "")
+			if err != nil {
+				return nil, err
+			}
+			for _, frame := range collected.frames {
+				frame.L = L
+				frame.ID = len(in.frames)
+				in.frames = append(in.frames, frame)
+			}
+		}
+	}
+	frames := make([]*DebugFrame, len(in.frames))
+	for i, frame := range in.frames {
+		frames[i] = &frame.DebugFrame
+	}
+	return frames, nil
+}
+
+func (in *DebugInspector) frame(id int) (*debugFrameRef, error) {
+	if _, err := in.Frames(); err != nil {
+		return nil, err
+	}
+	if id < 0 || id >= len(in.frames) {
+		return nil, errors.New("invalid frame")
+	}
+	return in.frames[id], nil
+}
+
+func (in *DebugInspector) addRef(v *debugVarRef) *DebugVariable {
+	if v.path != nil {
+		in.vars = append(in.vars, v)
+		v.Ref = len(in.vars)
+	}
+	return &v.DebugVariable
+}
+
+// Scopes returns the local variables, the upvalues and the state variables
+// of the frame.
+func (in *DebugInspector) Scopes(frameID int) ([]*DebugScope, error) {
+	if _, err := in.frame(frameID); err != nil {
+		return nil, err
+	}
+	var scopes []*DebugScope
+	for _, scope := range []string{"Locals", "Upvalues", "State"} {
+		v := in.addRef(&debugVarRef{frame: frameID, path: []string{strings.ToLower(scope)}})
+		scopes = append(scopes, &DebugScope{Name: scope, Ref: v.Ref})
+	}
+	return scopes, nil
+}
+
+// Variables returns the variables of a scope or the fields of a table.
+func (in *DebugInspector) Variables(ref int) ([]*DebugVariable, error) {
+	if in.depth == 0 {
+		return nil, errDebugResumed
+	}
+	if ref < 1 || ref > len(in.vars) {
+		return nil, errors.New("invalid variable reference")
+	}
+	parent := in.vars[ref-1]
+	frame, err := in.frame(parent.frame)
+	if err != nil {
+		return nil, err
+	}
+	collected, err := in.remoteCall(frame.L, "remote_vars", frame.level, strings.Join(parent.path, "\x1f"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(collected.vars, func(i, j int) bool {
+		return collected.vars[i].Name < collected.vars[j].Name
+	})
+	vars := make([]*DebugVariable, len(collected.vars))
+	for i, v := range collected.vars {
+		v.frame = parent.frame
+		if v.path != nil {
+			v.path = append(append([]string{}, parent.path...), v.path...)
+		}
+		vars[i] = in.addRef(v)
+	}
+	return vars, nil
+}
+
+// Evaluate evaluates the expression or runs the statement in the frame.
+// Assigning to the local variables doesn't change them.
+func (in *DebugInspector) Evaluate(frameID int, exp string) (*DebugVariable, error) {
+	frame, err := in.frame(frameID)
+	if err != nil {
+		return nil, err
+	}
+	collected, err := in.remoteCall(frame.L, "remote_eval", frame.level, exp)
+	if err != nil {
+		return nil, err
+	}
+	if len(collected.vars) == 0 {
+		return &DebugVariable{Name: exp, Type: "nil", Value: "nil"}, nil
+	}
+	v := collected.vars[0]
+	v.frame = frameID
+	if v.path != nil {
+		v.path = append([]string{"eval"}, v.path...)
+	}
+	return in.addRef(v), nil
+}
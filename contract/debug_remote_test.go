@@ -0,0 +1,188 @@
+// +build Debug
+
+package contract
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDebugPosLess(t *testing.T) {
+	tests := []struct {
+		p, o debugPos
+		less bool
+	}{
+		{debugPos{1, 2}, debugPos{1, 3}, true},
+		{debugPos{1, 3}, debugPos{1, 2}, false},
+		{debugPos{1, 2}, debugPos{1, 2}, false},
+		{debugPos{1, 5}, debugPos{2, 1}, true},
+		{debugPos{2, 1}, debugPos{1, 5}, false},
+	}
+	for _, test := range tests {
+		if less := test.p.less(test.o); less != test.less {
+			t.Errorf("%v.less(%v) = %v, expected %v", test.p, test.o, less, test.less)
+		}
+	}
+}
+
+func TestSourceBreakPoints(t *testing.T) {
+	id := PlainStrToHexAddr("breakpoints")
+	UpdateContractInfo(id, "example/contract.lua")
+	defer ResetContractInfo()
+
+	// the relative and the absolute paths are the same source
+	path, err := filepath.Abs("example/contract.lua")
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetSourceBreakPoints(path, []uint64{3, 5})
+	for line, expected := range map[uint64]bool{1: false, 3: true, 4: false, 5: true} {
+		if hasSourceBreakPoint(id, line) != expected {
+			t.Errorf("the breakpoint at line %d is expected to be %v", line, expected)
+		}
+	}
+
+	SetSourceBreakPoints("example/contract.lua", []uint64{4})
+	if hasSourceBreakPoint(id, 3) || !hasSourceBreakPoint(id, 4) {
+		t.Error("the breakpoints are not replaced")
+	}
+
+	SetSourceBreakPoints(path, nil)
+	if hasSourceBreakPoint(id, 4) {
+		t.Error("the breakpoints are not cleared")
+	}
+	if hasSourceBreakPoint(PlainStrToHexAddr("unknown"), 4) {
+		t.Error("a contract without the source has a breakpoint")
+	}
+}
+
+// recordingRemote records the stops, and resumes them one by one.
+type recordingRemote struct {
+	stops   []DebugStop
+	resumes []DebugResume
+	inspect func(stop *DebugStop, in *DebugInspector)
+}
+
+func (r *recordingRemote) Stopped(stop *DebugStop, in *DebugInspector) DebugResume {
+	r.stops = append(r.stops, *stop)
+	if r.inspect != nil {
+		r.inspect(stop, in)
+	}
+	if len(r.resumes) == 0 {
+		return DebugContinue
+	}
+	resume := r.resumes[0]
+	r.resumes = r.resumes[1:]
+	return resume
+}
+
+func TestDebugRemote(t *testing.T) {
+	code := `state.var { Name = state.value() }
+function set_name(name)
+  local greeting = "hello " .. name
+  Name:set(greeting)
+end
+abi.register(set_name)`
+
+	bc, err := LoadDummyChain()
+	if err != nil {
+		t.Errorf("failed to create dummy chain: %v", err)
+	}
+	defer bc.Release()
+
+	err = bc.ConnectBlock(
+		NewLuaTxAccount("ktlee", 100000000000000000),
+		NewLuaTxDef("ktlee", "debugee", 0, code),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path, err := filepath.Abs("debugee.lua")
+	if err != nil {
+		t.Fatal(err)
+	}
+	UpdateContractInfo(PlainStrToHexAddr("debugee"), path)
+	defer ResetContractInfo()
+	SetSourceBreakPoints(path, []uint64{3})
+	defer SetSourceBreakPoints(path, nil)
+
+	locals := make(map[int]map[string]DebugVariable)
+	r := &recordingRemote{
+		resumes: []DebugResume{DebugStepOver, DebugContinue},
+		inspect: func(stop *DebugStop, in *DebugInspector) {
+			frames, err := in.Frames()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(frames) == 0 || frames[0].Line != stop.Line || frames[0].Source != filepath.ToSlash(path) {
+				t.Fatalf("unexpected frames: %v", frames)
+			}
+			scopes, err := in.Scopes(frames[0].ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(scopes) != 3 || scopes[0].Name != "Locals" {
+				t.Fatalf("unexpected scopes: %v", scopes)
+			}
+			vars, err := in.Variables(scopes[0].Ref)
+			if err != nil {
+				t.Fatal(err)
+			}
+			locals[int(stop.Line)] = make(map[string]DebugVariable)
+			for _, v := range vars {
+				locals[int(stop.Line)][v.Name] = *v
+			}
+			if _, err := in.Variables(len(scopes) + 100); err == nil {
+				t.Error("expected an error of an invalid reference")
+			}
+			if stop.Line == 4 {
+				v, err := in.Evaluate(frames[0].ID, "#greeting")
+				if err != nil {
+					t.Fatal(err)
+				}
+				if v.Type != "number" || v.Value != "11" {
+					t.Errorf("unexpected result: %v", v)
+				}
+			}
+		},
+	}
+	SetDebugRemote(r)
+	defer SetDebugRemote(nil)
+
+	err = bc.ConnectBlock(
+		NewLuaTxCall("ktlee", "debugee", 0, `{"Name":"set_name", "Args":["aergo"]}`),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(r.stops) != 2 {
+		t.Fatalf("expected 2 stops, but got %v", r.stops)
+	}
+	if stop := r.stops[0]; stop.Reason != "breakpoint" || stop.Line != 3 || stop.Source != filepath.ToSlash(path) {
+		t.Errorf("unexpected stop: %v", stop)
+	}
+	if stop := r.stops[1]; stop.Reason != "step" || stop.Line != 4 {
+		t.Errorf("unexpected stop: %v", stop)
+	}
+	if v := locals[3]["name"]; v.Type != "string" || v.Value != `"aergo"` {
+		t.Errorf("unexpected local variable: %v", v)
+	}
+	if v := locals[4]["greeting"]; v.Value != `"hello aergo"` {
+		t.Errorf("unexpected local variable: %v", v)
+	}
+
+	// the detached remote is not told of the breakpoints
+	SetDebugRemote(nil)
+	r.stops = nil
+	err = bc.ConnectBlock(
+		NewLuaTxCall("ktlee", "debugee", 0, `{"Name":"set_name", "Args":["brick"]}`),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.stops) != 0 {
+		t.Errorf("a detached remote is stopped: %v", r.stops)
+	}
+}
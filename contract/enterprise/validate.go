@@ -164,7 +164,7 @@ func ValidateEnterpriseTx(tx *types.TxBody, sender *state.V,
 		}
 
 	case ChangeCluster:
-		if !consensus.UseRaft() {
+		if !consensus.UseCluster() {
 			return nil, ErrNotSupportedMethod
 		}
 
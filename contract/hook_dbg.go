@@ -307,18 +307,21 @@ func CDelWatchPoint(idx_c C.double) {
 	}
 }
 
-//export CGetWatchPoint
-func CGetWatchPoint(idx_c C.int) *C.char {
-	idx := int(idx_c)
+func getWatchPoint(idx int) string {
 	var i int = 0
 	for e := watchpoints.Front(); e != nil; e = e.Next() {
 		i++
 		if i == idx {
-			return C.CString(e.Value.(string))
+			return e.Value.(string)
 		}
 	}
 
-	return C.CString("")
+	return ""
+}
+
+//export CGetWatchPoint
+func CGetWatchPoint(idx_c C.int) *C.char {
+	return C.CString(getWatchPoint(int(idx_c)))
 }
 
 //export CLenWatchPoints
@@ -1306,6 +1309,173 @@ func GetDebuggerCode() *C.char {
 
 	end
 
+	--}}}
+	--{{{  remote debugging
+	--A remote debugger, e.g. a debug adapter of IDEs, controls the execution
+	--instead of debugger_loop. It is told each line by __remote_line, and it
+	--calls the remote_* functions with the hook disabled to inspect a paused
+	--contract, which report the frames and the variables by __remote_frame
+	--and __remote_var.
+	local sep = string.char(31)
+	local eval_results = {}
+	local eval_count = 0
+
+	local function is_contract(source)
+		return source ~= nil and string.find(source, '^@?%x+$') ~= nil
+	end
+
+	local function contract_id(source)
+		if string.find(source, '@') == 1 then
+			return string.sub(source, 2)
+		end
+		return source
+	end
+
+	--keys are prefixed with their types to be passed in a path
+	local function encode_key(k)
+		if type(k) == 'number' then
+			return 'n'..tostring(k)
+		elseif type(k) == 'string' then
+			return 's'..k
+		end
+		return nil
+	end
+
+	local function decode_key(k)
+		if string.sub(k,1,1) == 'n' then
+			return tonumber(string.sub(k,2))
+		end
+		return string.sub(k,2)
+	end
+
+	local function report_var(key, name, value)
+		local s
+		if type(value) == 'string' then
+			s = string.format('%q', value)
+		else
+			s = tostring(value)
+		end
+		__remote_var(key or '', tostring(name), type(value), s, type(value) == 'table' and key ~= nil)
+	end
+
+	local function remote_line(vars, contract_id_hex, line)
+		local idx = 0
+		for index, value in pairs(__list_watchpoints()) do
+			local func = loadstring('return(' .. value .. ')')
+			if func ~= nil then
+				setfenv(func, vars)
+				local status, res = pcall(func)
+				if status and res then
+					idx = index
+					break
+				end
+			end
+		end
+		eval_results = {}
+		eval_count = 0
+		__remote_line(contract_id_hex, line, stack_level[current_thread], idx)
+	end
+
+	--the root table of a scope of the frame at level, relative to the caller
+	local function remote_root(level, scope)
+		level = level + 1
+		local t = {}
+		local ar = debug.getinfo(level, 'f')
+		if not ar then return t end
+		if scope == 'locals' then
+			local i = 1
+			while true do
+				local name, value = debug.getlocal(level, i)
+				if not name then break end
+				if string.sub(name,1,1) ~= '(' then    --NB: ignoring internal control variables
+					t[name] = value
+				end
+				i = i + 1
+			end
+		elseif scope == 'upvalues' and ar.func then
+			local i = 1
+			while true do
+				local name, value = debug.getupvalue(ar.func, i)
+				if not name then break end
+				if string.sub(name,1,1) ~= '(' then
+					t[name] = value
+				end
+				i = i + 1
+			end
+		elseif scope == 'state' then
+			--state variables are read from the contract state
+			local reg = debug.getregistry()
+			local env = ar.func and getfenv(ar.func) or _g
+			for name, v in pairs(env) do
+				local mt = type(v) == 'userdata' and getmetatable(v)
+				if mt and mt == reg['__state_value__'] then
+					t[name] = v:get()
+				elseif mt and mt == reg['__state_array__'] then
+					local arr = {}
+					for i = 1, math.min(#v, 100) do
+						arr[i] = v[i]
+					end
+					t[name] = arr
+				elseif mt and mt == reg['__state_map__'] then
+					t[name] = 'state.map'
+				end
+			end
+		elseif scope == 'eval' then
+			t = eval_results
+		end
+		return t
+	end
+
+	function __debugger.remote_frames()
+		local i = 1
+		while true do
+			local ar = debug.getinfo(i, 'nSl')
+			if not ar then break end
+			if ar.what ~= 'C' and is_contract(ar.source) then
+				__remote_frame(i, ar.name or ar.what, contract_id(ar.source), ar.currentline)
+			end
+			i = i + 1
+		end
+	end
+
+	function __debugger.remote_vars(level, path)
+		local keys = {}
+		for key in string.gmatch(path, '[^'..sep..']+') do
+			table.insert(keys, key)
+		end
+		local v = remote_root(level, table.remove(keys, 1))
+		for _, key in ipairs(keys) do
+			if type(v) ~= 'table' then return end
+			v = v[decode_key(key)]
+		end
+		if type(v) ~= 'table' then return end
+		for k, value in pairs(v) do
+			report_var(encode_key(k), k, value)
+		end
+	end
+
+	function __debugger.remote_eval(level, exp)
+		local ar = debug.getinfo(level, 'f')
+		if not ar then error('invalid frame', 0) end
+		local env = remote_root(level, 'upvalues')
+		for name, value in pairs(remote_root(level, 'locals')) do
+			env[name] = value
+		end
+		if ar.func then
+			setmetatable(env, { __index = getfenv(ar.func), __newindex = getfenv(ar.func) })
+		end
+		local func, err = loadstring('return ' .. exp)
+		if not func then
+			func, err = loadstring(exp)
+			if not func then error(err, 0) end
+		end
+		setfenv(func, env)
+		local res = {pcall(func)}
+		if not res[1] then error(tostring(res[2]), 0) end
+		eval_count = eval_count + 1
+		eval_results[eval_count] = res[2]
+		report_var('n'..eval_count, exp, res[2])
+	end
 	--}}}
 	--{{{  local function debug_hook(event, line, level, thread)
 	local function debug_hook(event, line, level, thread)
@@ -1321,6 +1491,10 @@ func GetDebuggerCode() *C.char {
 			end
 			
 			local vars,contract_id_hex,contract_id_base58,line = capture_vars(level,1,line)
+			if __remote_enabled() then
+				remote_line(vars, contract_id_hex, line)
+				return
+			end
 			local stop, ev, idx = false, events.STEP, 0
 			while true do
 				for index, value in pairs(__list_watchpoints()) do
@@ -0,0 +1,168 @@
+#include <string.h>
+#include "vm.h"
+#include "profile.h"
+#include "_cgo_export.h"
+
+/* registry key of the profiler state */
+static char prof_key;
+
+typedef struct {
+	lua_Hook hook;          /* the hook replaced by the profiler */
+	int mask;
+	int count;
+	int ticks;              /* instructions after the replaced hook is called */
+	int inst;               /* instructions not reported yet */
+	unsigned long long gas; /* remaining gas at the last report */
+	const char *source;     /* chunk name of the contract code */
+} prof_state;
+
+static prof_state *get_prof_state(lua_State *L)
+{
+	prof_state *ps;
+
+	lua_pushlightuserdata(L, &prof_key);
+	lua_rawget(L, LUA_REGISTRYINDEX);
+	ps = (prof_state *)lua_touserdata(L, -1);
+	lua_pop(L, 1);
+	return ps;
+}
+
+static int stack_depth(lua_State *L)
+{
+	lua_Debug ar;
+	int depth = 0;
+
+	while (lua_getstack(L, depth, &ar)) {
+		depth++;
+	}
+	return depth;
+}
+
+/* report the instructions and the gas used after the last report with the
+ * event, to which they are attributed by the call stack before it */
+static void report_event(lua_State *L, prof_state *ps, int event, lua_Debug *ar)
+{
+	unsigned long long used = 0;
+	const char *name = NULL;
+	int line = 0;
+	int depth = 0;
+
+	if (lua_usegas(L)) {
+		unsigned long long gas = lua_gasget(L);
+		if (gas < ps->gas) {
+			used = ps->gas - gas;
+		}
+		ps->gas = gas;
+	}
+	if (ar != NULL) {
+		lua_getinfo(L, "nS", ar);
+		name = ar->name;
+		if (ps->source != NULL && ar->source == ps->source) {
+			line = ar->linedefined;
+		}
+		depth = stack_depth(L);
+	}
+	luaProfileEvent(L, luaL_service(L), event, depth, (char *)name, line, ps->inst, used);
+	ps->inst = 0;
+}
+
+static void profile_hook(lua_State *L, lua_Debug *ar)
+{
+	prof_state *ps = get_prof_state(L);
+
+	if (ps == NULL) {
+		return;
+	}
+	switch (ar->event) {
+	case LUA_HOOKCOUNT:
+		ps->inst++;
+		if (ps->hook != NULL && (ps->mask & LUA_MASKCOUNT) != 0 && ++ps->ticks >= ps->count) {
+			ps->ticks = 0;
+			ps->hook(L, ar);
+		}
+		break;
+	case LUA_HOOKLINE:
+		lua_getinfo(L, "S", ar);
+		if (ps->source != NULL && ar->source == ps->source) {
+			luaProfileLine(L, luaL_service(L), ar->currentline, 1);
+		}
+		break;
+	default:
+		report_event(L, ps, ar->event, ar);
+	}
+}
+
+/* declare the global functions of the contract code and their lines, which
+ * are reported as not executed unless they are hit */
+static void profile_code(lua_State *L, prof_state *ps)
+{
+	lua_Debug ar;
+	int service = luaL_service(L);
+
+	lua_pushnil(L);
+	while (lua_next(L, LUA_GLOBALSINDEX) != 0) {
+		if (lua_type(L, -2) == LUA_TSTRING && lua_isfunction(L, -1) && !lua_iscfunction(L, -1)) {
+			lua_pushvalue(L, -1);
+			lua_getinfo(L, ">SL", &ar);
+			if (ar.source == ps->source) {
+				luaProfileFunction(L, service, (char *)lua_tostring(L, -3), ar.linedefined);
+				lua_pushnil(L);
+				while (lua_next(L, -2) != 0) {
+					luaProfileLine(L, service, (int)lua_tointeger(L, -2), 0);
+					lua_pop(L, 1);
+				}
+			}
+			lua_pop(L, 1);
+		}
+		lua_pop(L, 1);
+	}
+}
+
+/* wrap the current hook of L with the profiler before calling fname, whose
+ * chunk is regarded as the contract code */
+void vm_set_profile_hook(lua_State *L, char *fname)
+{
+	prof_state *ps;
+	lua_Debug ar;
+
+	ar.source = NULL;
+	lua_getfield(L, LUA_GLOBALSINDEX, fname);
+	if (lua_isfunction(L, -1) && !lua_iscfunction(L, -1)) {
+		lua_getinfo(L, ">S", &ar);
+	} else {
+		lua_pop(L, 1);
+	}
+
+	lua_pushlightuserdata(L, &prof_key);
+	ps = (prof_state *)lua_newuserdata(L, sizeof(prof_state));
+	memset(ps, 0, sizeof(prof_state));
+	ps->hook = lua_gethook(L);
+	ps->mask = lua_gethookmask(L);
+	ps->count = lua_gethookcount(L);
+	ps->source = ar.source;
+	if (lua_usegas(L)) {
+		ps->gas = lua_gasget(L);
+	}
+	lua_rawset(L, LUA_REGISTRYINDEX);
+
+	if (ps->source != NULL) {
+		profile_code(L, ps);
+	}
+	lua_sethook(L, profile_hook, LUA_MASKCALL | LUA_MASKRET | LUA_MASKLINE | LUA_MASKCOUNT, 1);
+}
+
+/* report the rest and restore the hook wrapped by the profiler */
+void vm_unset_profile_hook(lua_State *L)
+{
+	prof_state *ps = get_prof_state(L);
+
+	if (ps == NULL) {
+		return;
+	}
+	report_event(L, ps, -1, NULL);
+	lua_sethook(L, ps->hook, ps->mask, ps->count);
+
+	lua_pushlightuserdata(L, &prof_key);
+	lua_pushnil(L);
+	lua_rawset(L, LUA_REGISTRYINDEX);
+}
@@ -0,0 +1,451 @@
+package contract
+
+/*
+#include <stdlib.h>
+#include "profile.h"
+*/
+import "C"
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"unsafe"
+
+	"github.com/aergoio/aergo/types"
+)
+
+var (
+	// ProfileBlockNo is the number of the block whose contract executions
+	// are profiled by the chain service, and the reports are written to the
+	// temporary directory by WriteBlockProfile. It is typically used with the
+	// block verification to replay a block.
+	ProfileBlockNo uint64
+
+	profileMu     sync.Mutex
+	activeProfile *Profile
+	blockProfile  *Profile
+)
+
+// Profile is the line coverage and the instructions and the gas used by the
+// functions of the contracts executed while profiling.
+type Profile struct {
+	mu        sync.Mutex
+	contracts map[string]*contractProfile
+	stacks    map[string]*ProfileCost
+}
+
+// ProfileCost is the consumption of a function or a call stack. The
+// instructions and the gas don't include the ones of the callees.
+type ProfileCost struct {
+	Calls        uint64
+	Instructions uint64
+	Gas          uint64
+}
+
+type contractProfile struct {
+	lines     map[int]uint64
+	functions map[string]*functionProfile
+}
+
+type functionProfile struct {
+	ProfileCost
+	line int // the line where it is defined, 0 if not in the contract code
+}
+
+// profileRun is a call to a contract function being profiled. The runs of
+// the nested contract calls are chained by parent.
+type profileRun struct {
+	profile  *Profile
+	parent   *profileRun
+	contract string
+	entry    string // the name of the function called first
+	stack    []string
+}
+
+func NewProfile() *Profile {
+	return &Profile{
+		contracts: make(map[string]*contractProfile),
+		stacks:    make(map[string]*ProfileCost),
+	}
+}
+
+// StartProfile starts profiling all the contract calls and deployments, and
+// returns the profile.
+func StartProfile() *Profile {
+	profileMu.Lock()
+	defer profileMu.Unlock()
+	activeProfile = NewProfile()
+	return activeProfile
+}
+
+// StopProfile stops profiling and returns the profile.
+func StopProfile() *Profile {
+	profileMu.Lock()
+	defer profileMu.Unlock()
+	p := activeProfile
+	activeProfile = nil
+	return p
+}
+
+func getProfile(blockNo types.BlockNo, service int) *Profile {
+	profileMu.Lock()
+	defer profileMu.Unlock()
+	if activeProfile != nil {
+		return activeProfile
+	}
+	// a block is executed by the block factory too, when it is produced
+	if ProfileBlockNo != 0 && ProfileBlockNo == blockNo && service == ChainService {
+		if blockProfile == nil {
+			blockProfile = NewProfile()
+		}
+		return blockProfile
+	}
+	return nil
+}
+
+// WriteBlockProfile writes the reports of the contract executions of the
+// block blockNo if it is profiled. The chain service calls it once the whole
+// block is executed, and the next execution of the block is profiled anew.
+func WriteBlockProfile(blockNo types.BlockNo) {
+	profileMu.Lock()
+	p := blockProfile
+	if ProfileBlockNo == 0 || ProfileBlockNo != blockNo {
+		p = nil
+	}
+	blockProfile = nil
+	profileMu.Unlock()
+	if p == nil {
+		return
+	}
+
+	write := func(ext string, f func(w io.Writer) error) {
+		path := fmt.Sprintf("%s%s%d.%s", os.TempDir(), string(os.PathSeparator), blockNo, ext)
+		file, err := os.Create(path)
+		if err == nil {
+			err = f(file)
+			_ = file.Close()
+		}
+		if err != nil {
+			ctrLgr.Error().Err(err).Str("path", path).Msg("failed to write the profile")
+		}
+	}
+	write("lcov", func(w io.Writer) error { return p.WriteLcov(w, nil) })
+	write("folded", func(w io.Writer) error { return p.WriteFolded(w, false, nil) })
+	write("gas.folded", func(w io.Writer) error { return p.WriteFolded(w, true, nil) })
+	write("functions", func(w io.Writer) error { return p.WriteFunctions(w, nil) })
+}
+
+func (ce *executor) startProfile() {
+	if ce.ctx.profile == nil {
+		return
+	}
+	entry := ce.fname
+	if !ce.isAutoload {
+		// the function is called by abi.call
+		entry = "abi.call"
+	}
+	ce.ctx.profileRun = &profileRun{
+		profile:  ce.ctx.profile,
+		parent:   ce.ctx.profileRun,
+		contract: types.EncodeAddress(ce.ctx.curContract.contractId),
+		entry:    entry,
+	}
+	fname := C.CString(ce.fname)
+	C.vm_set_profile_hook(ce.L, fname)
+	C.free(unsafe.Pointer(fname))
+}
+
+func (ce *executor) stopProfile() {
+	if ce.ctx.profile == nil || ce.ctx.profileRun == nil {
+		return
+	}
+	C.vm_unset_profile_hook(ce.L)
+	ce.ctx.profileRun = ce.ctx.profileRun.parent
+}
+
+func (p *Profile) contract(address string) *contractProfile {
+	cp, ok := p.contracts[address]
+	if !ok {
+		cp = &contractProfile{
+			lines:     make(map[int]uint64),
+			functions: make(map[string]*functionProfile),
+		}
+		p.contracts[address] = cp
+	}
+	return cp
+}
+
+func (cp *contractProfile) function(name string, line int) *functionProfile {
+	f, ok := cp.functions[name]
+	if !ok {
+		f = &functionProfile{}
+		cp.functions[name] = f
+	}
+	if f.line == 0 {
+		f.line = line
+	}
+	return f
+}
+
+// functionAt returns the name of the contract function defined at the line.
+func (cp *contractProfile) functionAt(line int) string {
+	for name, f := range cp.functions {
+		if f.line == line {
+			return name
+		}
+	}
+	return ""
+}
+
+// folded returns the call stack of the run in the folded format, in which
+// the frames are separated by semicolons.
+func (r *profileRun) folded() string {
+	frames := make([]string, len(r.stack))
+	for i, frame := range r.stack {
+		frames[i] = r.contract + ":" + frame
+	}
+	folded := strings.Join(frames, ";")
+	if r.parent != nil {
+		return r.parent.folded() + ";" + folded
+	}
+	return folded
+}
+
+func (r *profileRun) addCost(inst, gas uint64) {
+	if len(r.stack) == 0 {
+		return
+	}
+	f := r.profile.contract(r.contract).function(r.stack[len(r.stack)-1], 0)
+	f.Instructions += inst
+	f.Gas += gas
+
+	folded := r.folded()
+	cost, ok := r.profile.stacks[folded]
+	if !ok {
+		cost = &ProfileCost{}
+		r.profile.stacks[folded] = cost
+	}
+	cost.Instructions += inst
+	cost.Gas += gas
+}
+
+func (r *profileRun) event(event, depth int, name string, line int, inst, gas uint64) {
+	p := r.profile
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	r.addCost(inst, gas)
+	if depth < 1 {
+		return
+	}
+	if depth-1 < len(r.stack) {
+		r.stack = r.stack[:depth-1]
+	}
+	if event != C.LUA_HOOKCALL {
+		return
+	}
+	// a contract function is named as it is declared, not as it is called
+	if line > 0 {
+		if declared := p.contract(r.contract).functionAt(line); declared != "" {
+			name = declared
+		}
+	}
+	if name == "" {
+		if depth == 1 {
+			name = r.entry
+		} else if line > 0 {
+			name = fmt.Sprintf("function@%d", line)
+		} else {
+			name = "?"
+		}
+	}
+	r.stack = append(r.stack, name)
+	p.contract(r.contract).function(name, line).Calls++
+}
+
+//export luaProfileEvent
+func luaProfileEvent(L *LState, service C.int, event C.int, depth C.int, name *C.char, line C.int, inst C.int, gas C.ulonglong) {
+	ctx := contexts[service]
+	if ctx == nil || ctx.profileRun == nil {
+		return
+	}
+	ctx.profileRun.event(int(event), int(depth), C.GoString(name), int(line), uint64(inst), uint64(gas))
+}
+
+//export luaProfileLine
+func luaProfileLine(L *LState, service C.int, line C.int, hits C.int) {
+	ctx := contexts[service]
+	if ctx == nil || ctx.profileRun == nil {
+		return
+	}
+	r := ctx.profileRun
+	r.profile.mu.Lock()
+	r.profile.contract(r.contract).lines[int(line)] += uint64(hits)
+	r.profile.mu.Unlock()
+}
+
+//export luaProfileFunction
+func luaProfileFunction(L *LState, service C.int, name *C.char, line C.int) {
+	ctx := contexts[service]
+	if ctx == nil || ctx.profileRun == nil {
+		return
+	}
+	r := ctx.profileRun
+	r.profile.mu.Lock()
+	r.profile.contract(r.contract).function(C.GoString(name), int(line))
+	r.profile.mu.Unlock()
+}
+
+func (p *Profile) sortedContracts() []string {
+	addresses := make([]string, 0, len(p.contracts))
+	for address := range p.contracts {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+	return addresses
+}
+
+func resolve(f func(string) string, address string) string {
+	if f == nil {
+		return address
+	}
+	return f(address)
+}
+
+// WriteLcov writes the line coverage of the contracts in the lcov format.
+// srcPath returns the source file of a contract address, which is the
+// address itself if it is nil.
+func (p *Profile) WriteLcov(w io.Writer, srcPath func(address string) string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, address := range p.sortedContracts() {
+		cp := p.contracts[address]
+		var b strings.Builder
+
+		b.WriteString("TN:\n")
+		b.WriteString(fmt.Sprintf("SF:%s\n", resolve(srcPath, address)))
+
+		var names []string
+		for name, f := range cp.functions {
+			if f.line > 0 {
+				names = append(names, name)
+			}
+		}
+		sort.Slice(names, func(i, j int) bool {
+			return cp.functions[names[i]].line < cp.functions[names[j]].line
+		})
+		hitFunctions := 0
+		for _, name := range names {
+			b.WriteString(fmt.Sprintf("FN:%d,%s\n", cp.functions[name].line, name))
+		}
+		for _, name := range names {
+			calls := cp.functions[name].Calls
+			if calls > 0 {
+				hitFunctions++
+			}
+			b.WriteString(fmt.Sprintf("FNDA:%d,%s\n", calls, name))
+		}
+		b.WriteString(fmt.Sprintf("FNF:%d\nFNH:%d\n", len(names), hitFunctions))
+
+		lines := make([]int, 0, len(cp.lines))
+		for line := range cp.lines {
+			lines = append(lines, line)
+		}
+		sort.Ints(lines)
+		hitLines := 0
+		for _, line := range lines {
+			if cp.lines[line] > 0 {
+				hitLines++
+			}
+			b.WriteString(fmt.Sprintf("DA:%d,%d\n", line, cp.lines[line]))
+		}
+		b.WriteString(fmt.Sprintf("LF:%d\nLH:%d\nend_of_record\n", len(lines), hitLines))
+
+		if _, err := io.WriteString(w, b.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteFolded writes the instructions, or the gas if gas is true, used by
+// the call stacks in the folded format of the flame graph tools. name
+// returns the name of a contract address in the frames.
+func (p *Profile) WriteFolded(w io.Writer, gas bool, name func(address string) string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stacks := make([]string, 0, len(p.stacks))
+	for stack := range p.stacks {
+		stacks = append(stacks, stack)
+	}
+	sort.Strings(stacks)
+
+	for _, stack := range stacks {
+		value := p.stacks[stack].Instructions
+		if gas {
+			value = p.stacks[stack].Gas
+		}
+		if value == 0 {
+			continue
+		}
+		if name != nil {
+			frames := strings.Split(stack, ";")
+			for i, frame := range frames {
+				if sep := strings.Index(frame, ":"); sep != -1 {
+					frames[i] = name(frame[:sep]) + frame[sep:]
+				}
+			}
+			stack = strings.Join(frames, ";")
+		}
+		if _, err := fmt.Fprintf(w, "%s %d\n", stack, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteFunctions writes the calls, the instructions and the gas of the
+// functions in a table ordered by the gas and the instructions.
+func (p *Profile) WriteFunctions(w io.Writer, name func(address string) string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	type row struct {
+		contract, function string
+		cost               ProfileCost
+	}
+	var rows []row
+	for address, cp := range p.contracts {
+		for function, f := range cp.functions {
+			if f.Calls == 0 && f.Instructions == 0 {
+				continue
+			}
+			rows = append(rows, row{resolve(name, address), function, f.ProfileCost})
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].cost.Gas != rows[j].cost.Gas {
+			return rows[i].cost.Gas > rows[j].cost.Gas
+		}
+		if rows[i].cost.Instructions != rows[j].cost.Instructions {
+			return rows[i].cost.Instructions > rows[j].cost.Instructions
+		}
+		return rows[i].contract+rows[i].function < rows[j].contract+rows[j].function
+	})
+
+	if _, err := fmt.Fprintf(w, "%-20s %-24s %10s %14s %14s\n", "contract", "function", "calls", "instructions", "gas"); err != nil {
+		return err
+	}
+	for _, r := range rows {
+		if _, err := fmt.Fprintf(w, "%-20s %-24s %10d %14d %14d\n",
+			r.contract, r.function, r.cost.Calls, r.cost.Instructions, r.cost.Gas); err != nil {
+			return err
+		}
+	}
+	return nil
+}
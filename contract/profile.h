@@ -0,0 +1,9 @@
+#ifndef _PROFILE_H
+#define _PROFILE_H
+
+#include "lua.h"
+
+void vm_set_profile_hook(lua_State *L, char *fname);
+void vm_unset_profile_hook(lua_State *L);
+
+#endif /* _PROFILE_H */
@@ -5,14 +5,22 @@ package contract
 */
 import "C"
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/binary"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/aergoio/aergo/internal/enc"
 
@@ -22,9 +30,12 @@ import (
 )
 
 var (
-	ErrDBOpen = errors.New("failed to open the sql database")
-	ErrUndo   = errors.New("failed to undo the sql database")
-	ErrFindRp = errors.New("cannot find a recovery point")
+	ErrDBOpen   = errors.New("failed to open the sql database")
+	ErrUndo     = errors.New("failed to undo the sql database")
+	ErrFindRp   = errors.New("cannot find a recovery point")
+	ErrDBName   = errors.New("invalid sql database name")
+	ErrNoDBHash = errors.New("the hash of the sql database is not committed")
+	ErrDBHash   = errors.New("the sql database does not match the committed hash")
 
 	database = &sqlDatabase{}
 	load     sync.Once
@@ -35,9 +46,14 @@ var (
 	queryConnLock sync.Mutex
 )
 
+// sqlHashKey is the key of the hash of the SQL database in the contract
+// storage. It is not accessible to the contracts, whose keys are prefixed.
+var sqlHashKey = []byte("_sql_hash_")
+
 const (
 	statesqlDriver = "statesql"
 	queryDriver    = "query"
+	sqlFileExt     = ".db"
 )
 
 type sqlDatabase struct {
@@ -127,9 +143,16 @@ func CloseDatabase() {
 	}
 }
 
-func SaveRecoveryPoint(bs *state.BlockState) error {
+// SaveRecoveryPoint commits the SQL databases changed in the block and saves
+// their recovery points to the contract states. From the hardfork V3, the
+// hash of the content of each database is also committed to the contract
+// storage, so that the database can be verified by the state root. The block
+// of the hardfork commits the hashes of all the databases, including those
+// not changed since they were created before it.
+func SaveRecoveryPoint(bs *state.BlockState, bi *types.BlockHeaderInfo) error {
 	defer CloseDatabase()
 
+	hashed := make(map[string]bool)
 	for id, db := range database.DBs {
 		if db.tx != nil {
 			err := db.tx.commit()
@@ -156,9 +179,240 @@ func SaveRecoveryPoint(bs *state.BlockState) error {
 				if err != nil {
 					return err
 				}
+				if bi.Version >= 3 {
+					if err = saveDatabaseHash(bs, db, &receiverChange); err != nil {
+						return err
+					}
+					hashed[id] = true
+				}
 			}
 		}
 	}
+	if isV3ForkBlock(bi) {
+		return saveDatabaseFileHashes(bs, hashed)
+	}
+	return nil
+}
+
+// isV3ForkBlock reports whether the block of bi is the first block of the
+// hardfork V3.
+func isV3ForkBlock(bi *types.BlockHeaderInfo) bool {
+	if bi.Version < 3 {
+		return false
+	}
+	return bi.No == 0 || HardforkConfig.Version(bi.No-1) < 3
+}
+
+func saveDatabaseHash(bs *state.BlockState, db *litetree, st *types.State) error {
+	hash, err := databaseHash(db)
+	if err != nil {
+		return err
+	}
+	cs, err := bs.OpenContractState(db.accountID, st)
+	if err != nil {
+		return err
+	}
+	if err = cs.SetData(sqlHashKey, hash); err != nil {
+		return err
+	}
+	return bs.StageContractState(cs)
+}
+
+// saveDatabaseFileHashes commits the hashes of the databases of the data
+// directory, except those of hashed, at the recovery points of their
+// contracts. It is used at the hardfork V3 for the databases created before
+// it, which would never have a hash otherwise until they are changed.
+func saveDatabaseFileHashes(bs *state.BlockState, hashed map[string]bool) error {
+	names, err := DatabaseNames()
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if hashed[name] {
+			continue
+		}
+		b, err := enc.ToBytes(name)
+		if err != nil || len(b) != types.HashIDLength {
+			sqlLgr.Warn().Str("db_name", name).Msg("skip the SQL DB of an invalid name")
+			continue
+		}
+		id := types.AccountID(types.ToHashID(b))
+		st, err := bs.GetAccountState(id)
+		if err != nil {
+			return err
+		}
+		if st.SqlRecoveryPoint == 0 {
+			continue
+		}
+		path, _ := DatabaseFile(name)
+		hash, err := databaseFileHash(path, st.SqlRecoveryPoint)
+		if err != nil {
+			return err
+		}
+		cs, err := bs.OpenContractState(id, st)
+		if err != nil {
+			return err
+		}
+		if err = cs.SetData(sqlHashKey, hash); err != nil {
+			return err
+		}
+		if err = bs.StageContractState(cs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// VerifyDatabaseFile verifies the SQL database file of path, downloaded for
+// the contract cs, against the hash committed to its storage. The database is
+// verified at the recovery point of cs, so the commits following it are not
+// verified, but they are reverted when the database is opened.
+func VerifyDatabaseFile(path string, cs *state.ContractState) error {
+	expected, err := cs.GetData(sqlHashKey)
+	if err != nil {
+		return err
+	}
+	if len(expected) == 0 {
+		return ErrNoDBHash
+	}
+
+	hash, err := databaseFileHash(path, cs.SqlRecoveryPoint)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(hash, expected) {
+		return ErrDBHash
+	}
+	return nil
+}
+
+// databaseFileHash returns the hash of the SQL database file of path at the
+// recovery point rp.
+func databaseFileHash(path string, rp uint64) ([]byte, error) {
+	queryConnLock.Lock()
+	defer queryConnLock.Unlock()
+
+	db, err := sql.Open(queryDriver, fmt.Sprintf("file:%s?branches=on&_query_only=true", path))
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	c, err := db.Conn(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+	if _, err = c.ExecContext(context.Background(), fmt.Sprintf("pragma branch=master.%d", rp)); err != nil {
+		return nil, err
+	}
+	return databaseHash(c)
+}
+
+type sqlQueryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// databaseHash returns the hash of the schema and the rows of a database,
+// which does not depend on how they are stored in the file. The rows of a
+// table are read in the order of its rowid or primary key.
+func databaseHash(db sqlQueryer) ([]byte, error) {
+	h := sha256.New()
+	rows, err := db.QueryContext(context.Background(),
+		"select type, name, tbl_name, ifnull(sql, '') from sqlite_master order by type, name")
+	if err != nil {
+		return nil, err
+	}
+	var tables []string
+	for rows.Next() {
+		var typ, name, tblName, stmt string
+		if err = rows.Scan(&typ, &name, &tblName, &stmt); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		_ = writeSQLValues(h, typ, name, tblName, stmt)
+		if typ == "table" && !strings.HasPrefix(strings.ToUpper(stmt), "CREATE VIRTUAL") {
+			tables = append(tables, name)
+		}
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, table := range tables {
+		rows, err := db.QueryContext(context.Background(),
+			fmt.Sprintf(`select * from "%s" not indexed`, strings.ReplaceAll(table, `"`, `""`)))
+		if err != nil {
+			return nil, err
+		}
+		if err = hashRows(h, rows); err != nil {
+			return nil, err
+		}
+	}
+	return h.Sum(nil), nil
+}
+
+func hashRows(w io.Writer, rows *sql.Rows) error {
+	defer rows.Close()
+	cols, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	values := make([]interface{}, len(cols))
+	ptrs := make([]interface{}, len(cols))
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+	for rows.Next() {
+		if err = rows.Scan(ptrs...); err != nil {
+			return err
+		}
+		if err = writeSQLValues(w, values...); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// writeSQLValues writes the values to w, each of them with its type and its
+// length, so that different rows are never written as the same bytes.
+func writeSQLValues(w io.Writer, values ...interface{}) error {
+	var buf [9]byte
+	for _, value := range values {
+		var data []byte
+		buf = [9]byte{}
+		switch v := value.(type) {
+		case nil:
+			buf[0] = 0
+		case int64:
+			buf[0] = 1
+			binary.BigEndian.PutUint64(buf[1:], uint64(v))
+		case float64:
+			buf[0] = 2
+			binary.BigEndian.PutUint64(buf[1:], math.Float64bits(v))
+		case bool:
+			buf[0] = 3
+			if v {
+				buf[8] = 1
+			}
+		case []byte:
+			buf[0] = 4
+			data = v
+		case string:
+			buf[0] = 5
+			data = []byte(v)
+		case time.Time:
+			buf[0] = 6
+			data = []byte(v.UTC().Format(time.RFC3339Nano))
+		default:
+			return fmt.Errorf("unexpected sql value type %T", value)
+		}
+		if buf[0] >= 4 {
+			binary.BigEndian.PutUint64(buf[1:], uint64(len(data)))
+		}
+		w.Write(buf[:])
+		w.Write(data)
+	}
 	return nil
 }
 
@@ -210,11 +464,64 @@ func conn(dbName string) (*litetree, error) {
 	return openDB(dbName)
 }
 
+// DatabaseNames returns the names of the SQL databases of the contracts.
+func DatabaseNames() ([]string, error) {
+	files, err := ioutil.ReadDir(database.DataDir)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != sqlFileExt {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(f.Name(), sqlFileExt))
+	}
+	return names, nil
+}
+
+// DatabaseFile returns the path of the file of the SQL database of dbName,
+// which is the encoded account id of a contract.
+func DatabaseFile(dbName string) (string, error) {
+	if b, err := enc.ToBytes(dbName); err != nil || len(b) != types.HashIDLength {
+		return "", ErrDBName
+	}
+	return filepath.Join(database.DataDir, dbName+sqlFileExt), nil
+}
+
+// ReadDatabaseFile reads the file of the SQL database of dbName from offset.
+// It returns the number of bytes read to buf and the size of the file.
+//
+// The file may be read while it is written, but the commits of a database are
+// never modified after they are written, so the commits up to the recovery
+// point of a past state are intact.
+func ReadDatabaseFile(dbName string, offset int64, buf []byte) (int, int64, error) {
+	path, err := DatabaseFile(dbName)
+	if err != nil {
+		return 0, 0, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return 0, 0, err
+	}
+	n, err := f.ReadAt(buf, offset)
+	if err == io.EOF {
+		err = nil
+	}
+	return n, info.Size(), err
+}
+
 func dataSrc(dbName string) string {
 	return fmt.Sprintf(
-		"file:%s/%s.db?branches=on&max_db_size=%d",
+		"file:%s/%s%s?branches=on&max_db_size=%d",
 		database.DataDir,
 		dbName,
+		sqlFileExt,
 		int64(maxSQLDBSize*1024*1024))
 }
 
@@ -23,6 +23,7 @@ type SystemContext struct {
 	Staked    *types.Staking
 	Vote      *types.Vote // voting
 	Proposal  *Proposal   // voting
+	Multisig  *types.Multisig
 	Sender    *state.V
 	Receiver  *state.V
 
@@ -62,10 +63,11 @@ func newSysCmd(account []byte, txBody *types.TxBody, sender, receiver *state.V,
 	scs *state.ContractState, blockInfo *types.BlockHeaderInfo) (sysCmd, error) {
 
 	cmds := map[types.OpSysTx]sysCmdCtor{
-		types.OpvoteBP:    newVoteCmd,
-		types.OpvoteDAO: newVoteCmd,
-		types.Opstake:     newStakeCmd,
-		types.Opunstake:   newUnstakeCmd,
+		types.OpvoteBP:      newVoteCmd,
+		types.OpvoteDAO:     newVoteCmd,
+		types.Opstake:       newStakeCmd,
+		types.Opunstake:     newUnstakeCmd,
+		types.OpsetMultisig: newSetMultisigCmd,
 	}
 
 	context, err := newSystemContext(account, txBody, sender, receiver, scs, blockInfo)
@@ -0,0 +1,69 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+package system
+
+import (
+	"encoding/json"
+
+	"github.com/aergoio/aergo/state"
+	"github.com/aergoio/aergo/types"
+	"github.com/golang/protobuf/proto"
+)
+
+var multisigKey = []byte("multisig")
+
+type setMultisigCmd struct {
+	*SystemContext
+}
+
+func newSetMultisigCmd(ctx *SystemContext) (sysCmd, error) {
+	return &setMultisigCmd{SystemContext: ctx}, nil
+}
+
+func (c *setMultisigCmd) run() (*types.Event, error) {
+	if err := setMultisig(c.scs, c.Sender.ID(), c.Multisig); err != nil {
+		return nil, err
+	}
+	signers := make([]string, len(c.Multisig.Signers))
+	for i, signer := range c.Multisig.Signers {
+		signers[i] = types.EncodeAddress(signer)
+	}
+	args, err := json.Marshal([]interface{}{types.EncodeAddress(c.Sender.ID()), c.Multisig.Threshold, signers})
+	if err != nil {
+		return nil, err
+	}
+	return &types.Event{
+		ContractAddress: c.Receiver.ID(),
+		EventIdx:        0,
+		EventName:       "setMultisig",
+		JsonArgs:        string(args),
+	}, nil
+}
+
+func setMultisig(scs *state.ContractState, who []byte, ms *types.Multisig) error {
+	key := append(multisigKey, who...)
+	if ms.GetThreshold() == 0 {
+		return scs.DeleteData(key)
+	}
+	data, err := proto.Marshal(ms)
+	if err != nil {
+		return err
+	}
+	return scs.SetData(key, data)
+}
+
+// GetMultisig returns the signers of the multisig account address, or nil if
+// address is not a multisig account.
+func GetMultisig(scs *state.ContractState, address []byte) (*types.Multisig, error) {
+	data, err := scs.GetData(append(multisigKey, address...))
+	if err != nil || len(data) == 0 {
+		return nil, err
+	}
+	ms := &types.Multisig{}
+	if err = proto.Unmarshal(data, ms); err != nil {
+		return nil, err
+	}
+	return ms, nil
+}
@@ -0,0 +1,58 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package system
+
+import (
+	"testing"
+
+	"github.com/aergoio/aergo/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetMultisig(t *testing.T) {
+	scs, sender, receiver := initTest(t)
+	defer deinitTest()
+
+	const (
+		signer1 = "AmPNYHyzyh9zweLwDyuoiUuTVCdrdksxkRWDjVJS76WQLExa2Jr4"
+		signer2 = "AmLt7Z3y2XTu7YS8KHNuyKM2QAszpFHSX77FLKEt7FAuRW7GEhj7"
+	)
+	tx := &types.Tx{
+		Body: &types.TxBody{
+			Account: sender.ID(),
+			Amount:  []byte{},
+			Payload: []byte(`{"Name":"v1setMultisig", "Args":["2", "` + signer1 + `", "` + signer2 + `"]}`),
+		},
+	}
+
+	_, err := ValidateSystemTx(sender.ID(), tx.GetBody(), sender, scs, &types.BlockHeaderInfo{Version: 2})
+	assert.Error(t, err, "not supported before v3")
+
+	blockInfo := &types.BlockHeaderInfo{Version: 3}
+	cmd, err := newSysCmd(sender.ID(), tx.GetBody(), sender, receiver, scs, blockInfo)
+	assert.NoError(t, err, "should be success")
+	event, err := cmd.run()
+	assert.NoError(t, err, "should be success")
+	assert.Equal(t, "setMultisig", event.EventName, "event name")
+
+	ms, err := GetMultisig(scs, sender.ID())
+	assert.NoError(t, err, "should be success")
+	assert.Equal(t, uint32(2), ms.GetThreshold(), "threshold")
+	assert.Len(t, ms.GetSigners(), 2, "signers")
+
+	tx.Body.Payload = []byte(`{"Name":"v1setMultisig", "Args":["3", "` + signer1 + `", "` + signer2 + `"]}`)
+	_, err = ValidateSystemTx(sender.ID(), tx.GetBody(), sender, scs, blockInfo)
+	assert.Error(t, err, "threshold more than the signers")
+
+	tx.Body.Payload = []byte(`{"Name":"v1setMultisig", "Args":["0"]}`)
+	cmd, err = newSysCmd(sender.ID(), tx.GetBody(), sender, receiver, scs, blockInfo)
+	assert.NoError(t, err, "should be success")
+	_, err = cmd.run()
+	assert.NoError(t, err, "should be success")
+	ms, err = GetMultisig(scs, sender.ID())
+	assert.NoError(t, err, "should be success")
+	assert.Nil(t, ms, "multisig should be cleared")
+}
@@ -45,6 +45,12 @@ func genParamKey(id string) []byte {
 	return []byte("param\\" + strings.ToUpper(id))
 }
 
+// BpCountKey returns the storage key of the number of the BPs. The default
+// number, which is the number of the BPs in genesis, is not stored.
+func BpCountKey() []byte {
+	return genParamKey(bpCount.ID())
+}
+
 func loadParam(g dataGetter) parameters {
 	ret := map[string]*big.Int{}
 	for i := sysParamIndex(0); i < sysParamMax; i++ {
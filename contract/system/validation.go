@@ -47,6 +47,18 @@ func ValidateSystemTx(account []byte, txBody *types.TxBody, sender *state.V,
 			return nil, err
 		}
 		context.Staked = staked
+	case types.OpsetMultisig:
+		if blockInfo.Version < 3 {
+			return nil, fmt.Errorf("not supported operation")
+		}
+		if txBody.GetAmountBigInt().Sign() != 0 {
+			return nil, types.ErrTxInvalidAmount
+		}
+		ms, err := types.ParseMultisigArgs(ci.Args)
+		if err != nil {
+			return nil, err
+		}
+		context.Multisig = ms
 	case types.OpvoteDAO:
 		if blockInfo.Version < 2 {
 			return nil, fmt.Errorf("not supported operation")
@@ -376,6 +376,26 @@ func GetRankers(ar AccountStateReader) ([]string, error) {
 	return bps, nil
 }
 
+// BpRankingKey returns the storage key of the votes for the BPs in the
+// descending order of the amounts, from which the BPs are elected.
+func BpRankingKey() []byte {
+	return append(append([]byte{}, sortKey...), defaultVoteKey...)
+}
+
+// RankersOf returns the IDs of the top n rankers in data, which is the value
+// of BpRankingKey.
+func RankersOf(data []byte, n int) []string {
+	vl := deserializeVoteList(data, false)
+	if n < len(vl.Votes) {
+		vl.Votes = vl.Votes[:n]
+	}
+	bps := make([]string, 0, len(vl.Votes))
+	for _, v := range vl.Votes {
+		bps = append(bps, enc.ToString(v.Candidate))
+	}
+	return bps
+}
+
 func GetParam(proposalID string) *big.Int {
 	return systemParams.getLastParam(proposalID)
 }
@@ -0,0 +1,124 @@
+package contract
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/aergoio/aergo/state"
+	"github.com/aergoio/aergo/types"
+	"github.com/golang/protobuf/proto"
+)
+
+// A contract is upgraded by redeploy. From the hardfork V3, the code versions
+// of a contract are recorded, the redeploy is allowed to the upgrade
+// authority of the contract, which is the creator unless the contract sets
+// another, and the __upgrade function of the new code is called instead of
+// the constructor with the old version and the deploy arguments.
+
+const upgradeHook = "__upgrade"
+
+var (
+	upgradeAuthorityMetaKey = []byte("UpgradeAuthority")
+	codeVersionsMetaKey     = []byte("CodeVersions")
+
+	// noUpgradeAuthority is the upgrade authority of the contract which is
+	// not upgradable. It can't be an encoded address.
+	noUpgradeAuthority = []byte{0}
+)
+
+func useCodeVersions(blockNo types.BlockNo) bool {
+	return HardforkConfig.IsV3Fork(blockNo)
+}
+
+// GetCodeVersions returns the code versions of a contract. The contract
+// deployed before the hardfork V3 has the version 1 of an unknown block.
+func GetCodeVersions(contractState *state.ContractState) (*types.ContractVersionList, error) {
+	data, err := contractState.GetData(codeVersionsMetaKey)
+	if err != nil {
+		return nil, err
+	}
+	versions := &types.ContractVersionList{}
+	if len(data) > 0 {
+		if err := proto.Unmarshal(data, versions); err != nil {
+			return nil, err
+		}
+	} else if codeHash := contractState.State.GetCodeHash(); len(codeHash) > 0 {
+		versions.Versions = []*types.ContractVersion{{Version: 1, CodeHash: codeHash}}
+	}
+	return versions, nil
+}
+
+// addCodeVersion appends the current code of a contract to its versions and
+// returns the new version.
+func addCodeVersion(contractState *state.ContractState, versions *types.ContractVersionList, ctx *vmContext) (uint64, error) {
+	version := uint64(1)
+	if latest := versions.Latest(); latest != nil {
+		version = latest.GetVersion() + 1
+	}
+	versions.Versions = append(versions.Versions, &types.ContractVersion{
+		Version:  version,
+		CodeHash: contractState.State.GetCodeHash(),
+		BlockNo:  ctx.blockInfo.No,
+		TxHash:   ctx.txHash,
+	})
+	data, err := proto.Marshal(versions)
+	if err != nil {
+		return 0, err
+	}
+	return version, contractState.SetData(codeVersionsMetaKey, data)
+}
+
+// getUpgradeAuthority returns the encoded address of the account allowed to
+// redeploy a contract.
+func getUpgradeAuthority(contractState *state.ContractState) ([]byte, error) {
+	authority, err := contractState.GetData(upgradeAuthorityMetaKey)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Equal(authority, noUpgradeAuthority) {
+		return nil, types.ErrNotUpgradable
+	}
+	if len(authority) > 0 {
+		return authority, nil
+	}
+	return contractState.GetData(creatorMetaKey)
+}
+
+// checkUpgradeAuthority checks that the contract of contractState exists and
+// the sender of ctx is its upgrade authority.
+func checkUpgradeAuthority(contractState *state.ContractState, ctx *vmContext) error {
+	if ctx.isNewReceiver || len(contractState.State.GetCodeHash()) == 0 {
+		receiverAddr := types.EncodeAddress(ctx.curContract.contractId)
+		ctrLgr.Warn().Str("error", "not found contract").Str("contract", receiverAddr).Msg("redeploy")
+		return newVmError(fmt.Errorf("not found contract %s", receiverAddr))
+	}
+	authority, err := getUpgradeAuthority(contractState)
+	if err == types.ErrNotUpgradable {
+		return newVmError(err)
+	}
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(authority, []byte(types.EncodeAddress(ctx.curContract.sender))) {
+		return newVmError(types.ErrNotUpgradeAuthority)
+	}
+	return nil
+}
+
+// setUpgradeAuthority sets the upgrade authority of a contract to the
+// account of address, or makes the contract not upgradable if address is
+// empty.
+func setUpgradeAuthority(contractState *state.ContractState, address string) error {
+	if len(address) == 0 {
+		return contractState.SetData(upgradeAuthorityMetaKey, noUpgradeAuthority)
+	}
+	id, err := types.DecodeAddress(address)
+	if err != nil {
+		return err
+	}
+	if len(id) != types.AddressLength {
+		return errors.New("invalid address: " + address)
+	}
+	return contractState.SetData(upgradeAuthorityMetaKey, []byte(types.EncodeAddress(id)))
+}
@@ -7,6 +7,7 @@
 #include "db_module.h"
 #include "state_module.h"
 #include "crypto_module.h"
+#include "abi_module.h"
 #include "util.h"
 #include "lgmp.h"
 #include "_cgo_export.h"
@@ -106,6 +107,11 @@ const char *vm_loadcall(lua_State *L)
 {
     int err;
 
+    if (vm_is_hardfork(L, 3)) {
+        luaopen_abi_types(L);
+        luaopen_crypto_v3(L);
+        luaopen_contract_v3(L);
+    }
     if (lua_usegas(L)) {
         lua_enablegas(L);
 		vm_set_timeout_hook(L);
@@ -362,6 +368,16 @@ int vm_instcount(lua_State *L)
     }
 }
 
+/* vm_instused returns the number of the instructions run after the count hook
+ * is set, which is counted from the hardfork V2. */
+int vm_instused(lua_State *L)
+{
+    if (lua_usegas(L) || !vm_is_hardfork(L, 2))
+        return 0;
+
+    return luaL_tminstcount(L);
+}
+
 void vm_setinstcount(lua_State *L, int count)
 {
     if (lua_usegas(L))
@@ -35,6 +35,7 @@ import (
 	"unsafe"
 
 	"github.com/aergoio/aergo-lib/log"
+	"github.com/aergoio/aergo/account/key"
 	luacUtil "github.com/aergoio/aergo/cmd/aergoluac/util"
 	"github.com/aergoio/aergo/fee"
 	"github.com/aergoio/aergo/internal/enc"
@@ -49,9 +50,14 @@ const (
 	dbUpdateMaxLimit     = fee.StateDbMaxUpdateSize
 	MaxCallDepth         = 5
 	checkFeeDelegationFn = "check_delegation"
+	checkAccountFn       = "check_account"
 	constructor          = "constructor"
 )
 
+// checkAccountMaxInstLimit is small, since check_account runs for every tx of
+// a contract account before its fee is charged.
+const checkAccountMaxInstLimit = C.int(100000)
+
 var (
 	maxContext     int
 	ctrLgr         *log.Logger
@@ -92,6 +98,10 @@ type vmContext struct {
 	isQuery           bool
 	nestedView        int32
 	isFeeDelegation   bool
+	isRedeploy        bool
+	isNewReceiver     bool
+	profile           *Profile
+	profileRun        *profileRun
 	service           C.int
 	callState         map[types.AccountID]*callState
 	lastRecoveryEntry *recoveryEntry
@@ -181,6 +191,8 @@ func newVmContext(blockState *state.BlockState, cdb ChainAccessor, sender, recie
 		gasLimit:        gasLimit,
 		remainedGas:     gasLimit,
 		isFeeDelegation: feeDelegation,
+		isRedeploy:      reciever.IsRedeploy(),
+		isNewReceiver:   reciever.IsNew(),
 	}
 	ctx.callState = make(map[types.AccountID]*callState)
 	ctx.callState[reciever.AccountID()] = cs
@@ -190,6 +202,7 @@ func newVmContext(blockState *state.BlockState, cdb ChainAccessor, sender, recie
 	if TraceBlockNo != 0 && TraceBlockNo == ctx.blockInfo.No {
 		ctx.traceFile = getTraceFile(ctx.blockInfo.No, txHash)
 	}
+	ctx.profile = getProfile(ctx.blockInfo.No, service)
 
 	return ctx
 }
@@ -200,11 +213,19 @@ func newVmContextQuery(
 	receiverId []byte,
 	contractState *state.ContractState,
 	rp uint64,
+	block *types.Block,
 ) (*vmContext, error) {
 	cs := &callState{ctrState: contractState, curState: contractState.State}
-	bb, err := cdb.GetBestBlock()
-	if err != nil {
-		return nil, err
+	// A query at a past block sees the block as the current one.
+	ts := time.Now().UnixNano()
+	if block != nil {
+		ts = block.GetHeader().GetTimestamp()
+	} else {
+		bb, err := cdb.GetBestBlock()
+		if err != nil {
+			return nil, err
+		}
+		block = bb
 	}
 	ctx := &vmContext{
 		curContract: newContractInfo(cs, nil, receiverId, rp, big.NewInt(0)),
@@ -212,11 +233,11 @@ func newVmContextQuery(
 		cdb:         cdb,
 		confirmed:   true,
 		blockInfo: &types.BlockHeaderInfo{
-			No:            bb.BlockNo(),
-			Ts:            time.Now().UnixNano(),
-			Version:       HardforkConfig.Version(bb.BlockNo()),
+			No:      block.BlockNo(),
+			Ts:      ts,
+			Version: HardforkConfig.Version(block.BlockNo()),
 		},
-		isQuery:     true,
+		isQuery: true,
 	}
 	ctx.callState = make(map[types.AccountID]*callState)
 	ctx.callState[types.ToAccountID(receiverId)] = cs
@@ -400,6 +421,13 @@ func newExecutor(
 			ctrLgr.Debug().Err(ce.err).Str("contract", types.EncodeAddress(contractId)).Msg("check payable function")
 			return ce
 		}
+		if ctx.blockInfo.Version >= 3 {
+			if err = f.CheckArgs(ci.Args); err != nil {
+				ce.preErr = err
+				ctrLgr.Debug().Err(err).Str("contract", types.EncodeAddress(contractId)).Msg("check arguments")
+				return ce
+			}
+		}
 		ce.isView = f.View
 		ce.fname = f.Name
 		ce.numArgs = C.int(len(ci.Args) + 1)
@@ -541,7 +569,16 @@ func (ce *executor) call(instLimit C.int, target *LState) C.int {
 		return 0
 	}
 	if ce.isAutoload {
-		if loaded := vmAutoload(ce.L, ce.fname); !loaded {
+		loaded := vmAutoload(ce.L, ce.fname)
+		if !loaded && ce.fname == upgradeHook {
+			// Without the hook, the new code is initialized by the
+			// constructor as deployed.
+			ce.fname = constructor
+			ce.ci.Args = ce.ci.Args[1:]
+			ce.numArgs = C.int(len(ce.ci.Args))
+			loaded = vmAutoload(ce.L, ce.fname)
+		}
+		if !loaded {
 			if ce.fname != constructor {
 				ce.err = errors.New(fmt.Sprintf("contract autoload failed %s : %s",
 					types.EncodeAddress(ce.ctx.curContract.contractId), ce.fname))
@@ -561,8 +598,11 @@ func (ce *executor) call(instLimit C.int, target *LState) C.int {
 		return 0
 	}
 	ce.setCountHook(instLimit)
+	ce.startProfile()
 	nret := C.int(0)
-	if cErrMsg := C.vm_pcall(ce.L, ce.numArgs, &nret); cErrMsg != nil {
+	cErrMsg := C.vm_pcall(ce.L, ce.numArgs, &nret)
+	ce.stopProfile()
+	if cErrMsg != nil {
 		errMsg := C.GoString(cErrMsg)
 		if C.luaL_hassyserror(ce.L) != C.int(0) {
 			ce.err = newVmSystemError(errors.New(errMsg))
@@ -698,8 +738,10 @@ func (ce *executor) rollbackToSavepoint() error {
 }
 
 func (ce *executor) closeQuerySql() error {
-	ctx := ce.ctx
+	return closeQuerySql(ce.ctx)
+}
 
+func closeQuerySql(ctx *vmContext) error {
 	if ctx == nil || ctx.callState == nil {
 		return nil
 	}
@@ -980,13 +1022,38 @@ func Create(
 	if ctrLgr.IsDebugEnabled() {
 		ctrLgr.Debug().Str("contract", types.EncodeAddress(contractAddress)).Msg("deploy")
 	}
+	var (
+		versions   *types.ContractVersionList
+		oldVersion uint64
+		err        error
+	)
+	upgrade := ctx.isRedeploy && useCodeVersions(ctx.blockInfo.No)
+	if upgrade {
+		if err = checkUpgradeAuthority(contractState, ctx); err != nil {
+			return "", nil, ctx.usedFee(), err
+		}
+	}
+	if useCodeVersions(ctx.blockInfo.No) {
+		if versions, err = GetCodeVersions(contractState); err != nil {
+			return "", nil, ctx.usedFee(), err
+		}
+		oldVersion = versions.Latest().GetVersion()
+	}
 	contract, args, err := setContract(contractState, contractAddress, code)
 	if err != nil {
 		return "", nil, ctx.usedFee(), err
 	}
-	err = contractState.SetData(creatorMetaKey, []byte(types.EncodeAddress(ctx.curContract.sender)))
-	if err != nil {
-		return "", nil, ctx.usedFee(), err
+	// The upgrade authority can be other than the creator.
+	if !upgrade {
+		err = contractState.SetData(creatorMetaKey, []byte(types.EncodeAddress(ctx.curContract.sender)))
+		if err != nil {
+			return "", nil, ctx.usedFee(), err
+		}
+	}
+	if versions != nil {
+		if _, err = addCodeVersion(contractState, versions, ctx); err != nil {
+			return "", nil, ctx.usedFee(), err
+		}
 	}
 	var ci types.CallInfo
 	if len(args) > 0 {
@@ -996,6 +1063,17 @@ func Create(
 			return string(errMsg), nil, ctx.usedFee(), nil
 		}
 	}
+	if ctx.blockInfo.Version >= 3 {
+		if f, _ := resolveFunction(contractState, ctx.bs, constructor, true); f != nil {
+			if err = f.CheckArgs(ci.Args); err != nil {
+				errMsg, _ := json.Marshal("constructor call error:" + err.Error())
+				return string(errMsg), nil, ctx.usedFee(), nil
+			}
+		}
+	}
+	if upgrade {
+		ci.Args = append([]interface{}{float64(oldVersion)}, ci.Args...)
+	}
 
 	contexts[ctx.service] = ctx
 
@@ -1008,6 +1086,9 @@ func Create(
 
 	ce := newExecutor(contract, contractAddress, ctx, &ci, ctx.curContract.amount, true, false, contractState)
 	defer ce.close()
+	if upgrade {
+		ce.fname = upgradeHook
+	}
 
 	ce.call(callMaxInstLimit, nil)
 	err = ce.err
@@ -1082,7 +1163,21 @@ func setQueryContext(ctx *vmContext) {
 	}
 }
 
+// releaseQueryContext frees the slot of the context taken by setQueryContext.
+func releaseQueryContext(ctx *vmContext) {
+	querySync.Lock()
+	contexts[ctx.service] = nil
+	querySync.Unlock()
+}
+
 func Query(contractAddress []byte, bs *state.BlockState, cdb ChainAccessor, contractState *state.ContractState, queryInfo []byte) (res []byte, err error) {
+	return QueryAt(contractAddress, bs, cdb, contractState, queryInfo, nil)
+}
+
+// QueryAt executes a query function against the contract state at block. The
+// SQL database of the contract is read at the recovery point saved in the
+// contract state. The best block is used if block is nil.
+func QueryAt(contractAddress []byte, bs *state.BlockState, cdb ChainAccessor, contractState *state.ContractState, queryInfo []byte, block *types.Block) (res []byte, err error) {
 	var ci types.CallInfo
 	contract := getContract(contractState, bs)
 	if contract != nil {
@@ -1099,7 +1194,7 @@ func Query(contractAddress []byte, bs *state.BlockState, cdb ChainAccessor, cont
 	}
 
 	var ctx *vmContext
-	ctx, err = newVmContextQuery(bs, cdb, contractAddress, contractState, contractState.SqlRecoveryPoint)
+	ctx, err = newVmContextQuery(bs, cdb, contractAddress, contractState, contractState.SqlRecoveryPoint, block)
 	if err != nil {
 		return
 	}
@@ -1158,7 +1253,7 @@ func CheckFeeDelegation(contractAddress []byte, bs *state.BlockState, cdb ChainA
 	}
 
 	var ctx *vmContext
-	ctx, err = newVmContextQuery(bs, cdb, contractAddress, contractState, contractState.SqlRecoveryPoint)
+	ctx, err = newVmContextQuery(bs, cdb, contractAddress, contractState, contractState.SqlRecoveryPoint, nil)
 	if err != nil {
 		return
 	}
@@ -1192,6 +1287,98 @@ func CheckFeeDelegation(contractAddress []byte, bs *state.BlockState, cdb ChainA
 	return nil
 }
 
+// CheckContractAccount checks tx sent by a contract account. Instead of the
+// signature of a key, tx is validated by the check_account function of the
+// contract, which must be registered as a view function and return true to
+// accept tx. It's called as
+//
+//   check_account(hash, sign, recipient, amount, payload)
+//
+// where hash is the hex string of the tx hash without the signature and sign
+// is the hex string of the Sign field, so the contract can verify it by
+// crypto.ecverify with the keys of its own choice. The call is bounded by
+// checkAccountMaxInstLimit, and it returns the fee for the instructions run.
+func CheckContractAccount(contractAddress []byte, bs *state.BlockState, cdb ChainAccessor,
+	contractState *state.ContractState, tx *types.Tx) (checkFee *big.Int, err error) {
+	abi, err := GetABI(contractState, bs)
+	if err != nil {
+		return nil, err
+	}
+	var found *types.Function
+	for _, f := range abi.Functions {
+		if f.Name == checkAccountFn {
+			found = f
+			break
+		}
+	}
+	if found == nil || !found.View {
+		return nil, types.ErrNotContractAccount
+	}
+
+	contract := getContract(contractState, bs)
+	if contract == nil {
+		addr := types.EncodeAddress(contractAddress)
+		ctrLgr.Warn().Str("error", "not found contract").Str("contract", addr).Msg("checkContractAccount")
+		return nil, fmt.Errorf("not found contract %s", addr)
+	}
+
+	var ctx *vmContext
+	ctx, err = newVmContextQuery(bs, cdb, contractAddress, contractState, contractState.SqlRecoveryPoint, nil)
+	if err != nil {
+		return
+	}
+	txBody := tx.GetBody()
+	ctx.origin = contractAddress
+	ctx.txHash = tx.GetHash()
+	ctx.curContract.sender = contractAddress
+
+	setQueryContext(ctx)
+	if ctrLgr.IsDebugEnabled() {
+		ctrLgr.Debug().Str("abi", checkAccountFn).Str("contract", types.EncodeAddress(contractAddress)).Msg("checkContractAccount")
+	}
+	recipient := string(txBody.GetRecipient())
+	if len(txBody.GetRecipient()) == types.AddressLength {
+		recipient = types.EncodeAddress(txBody.GetRecipient())
+	}
+	ci := types.CallInfo{
+		Name: checkAccountFn,
+		Args: []interface{}{
+			"0x" + hex.EncodeToString(key.CalculateHashWithoutSign(txBody)),
+			"0x" + hex.EncodeToString(txBody.GetSign()),
+			recipient,
+			txBody.GetAmountBigInt().String(),
+			string(txBody.GetPayload()),
+		},
+	}
+	ce := newExecutor(contract, contractAddress, ctx, &ci, ctx.curContract.amount, false, false, contractState)
+	defer ce.close()
+	defer func() {
+		if dbErr := ce.rollbackToSavepoint(); dbErr != nil {
+			err = dbErr
+		}
+	}()
+	ce.call(checkAccountMaxInstLimit, nil)
+
+	contexts[ctx.service] = nil
+	if ce.err != nil {
+		return nil, ce.err
+	}
+	if ce.jsonRet != "true" {
+		return nil, types.ErrNotAllowedContractAccount
+	}
+	return checkAccountFee(uint64(C.vm_instused(ce.L)), bs.GasPrice), nil
+}
+
+// checkAccountFee returns the fee for the instructions run by check_account,
+// which is charged to the contract account once its tx is included in a
+// block.
+func checkAccountFee(instUsed uint64, gasPrice *big.Int) *big.Int {
+	if fee.IsZeroFee() {
+		return fee.NewZeroFee()
+	}
+	return new(big.Int).Mul(new(big.Int).SetUint64(instUsed), gasPrice)
+}
+
 func getCode(contractState *state.ContractState, bs *state.BlockState) ([]byte, error) {
 	var code []byte
 	var err error
@@ -923,17 +923,140 @@ func luaCryptoVerifyProof(
 	k, _ := luaCryptoToBytes(key, keyLen)
 	v := luaCryptoRlpToBytes(value)
 	h, _ := luaCryptoToBytes(hash, hashLen)
+	if verifyEthStorageProof(k, v, h, luaCryptoProofToBytes(proof, nProof)) {
+		return C.int(1)
+	}
+	return C.int(0)
+}
+
+func luaCryptoProofToBytes(proof unsafe.Pointer, nProof C.int) [][]byte {
+	if nProof == 0 {
+		return nil
+	}
 	cProof := (*[1 << 30]C.struct_proof)(proof)[:nProof:nProof]
 	bProof := make([][]byte, int(nProof))
 	for i, p := range cProof {
 		bProof[i], _ = luaCryptoToBytes(p.data, C.int(p.len))
 	}
-	if verifyEthStorageProof(k, v, h, bProof) {
+	return bProof
+}
+
+//export luaCryptoVerifyAergoAccount
+func luaCryptoVerifyAergoAccount(
+	root unsafe.Pointer, rootLen C.int,
+	address *C.char,
+	state unsafe.Pointer, stateLen C.int,
+	proof unsafe.Pointer, nProof C.int,
+) (C.int, *C.char) {
+	r, _ := luaCryptoToBytes(root, rootLen)
+	st, _ := luaCryptoToBytes(state, stateLen)
+	ok, err := verifyAergoAccountProof(r, C.GoString(address), st, luaCryptoProofToBytes(proof, nProof))
+	if err != nil {
+		return -1, C.CString("[Contract.LuaCryptoVerifyAergoAccount] invalid address: " + err.Error())
+	}
+	if ok {
+		return C.int(1), nil
+	}
+	return C.int(0), nil
+}
+
+//export luaCryptoVerifyAergoVar
+func luaCryptoVerifyAergoVar(
+	root unsafe.Pointer, rootLen C.int,
+	key unsafe.Pointer, keyLen C.int,
+	value unsafe.Pointer, valueLen C.int,
+	proof unsafe.Pointer, nProof C.int,
+) C.int {
+	r, _ := luaCryptoToBytes(root, rootLen)
+	k, _ := luaCryptoToBytes(key, keyLen)
+	v, _ := luaCryptoToBytes(value, valueLen)
+	if verifyAergoVarProof(r, k, v, luaCryptoProofToBytes(proof, nProof)) {
 		return C.int(1)
 	}
 	return C.int(0)
 }
 
+//export luaCryptoDecodeAergoState
+func luaCryptoDecodeAergoState(data unsafe.Pointer, dataLen C.int) (*C.char, *C.char) {
+	d, _ := luaCryptoToBytes(data, dataLen)
+	st, err := decodeAergoState(d)
+	if err != nil {
+		return nil, C.CString("[Contract.LuaCryptoDecodeAergoState] invalid state: " + err.Error())
+	}
+	return C.CString(st), nil
+}
+
+//export luaCryptoEcrecover
+func luaCryptoEcrecover(L *LState, service C.int, digest unsafe.Pointer, digestLen C.int, sig unsafe.Pointer, sigLen C.int) (*C.char, *C.char, *C.char) {
+	ctx := contexts[service]
+	if ctx == nil {
+		return nil, nil, C.CString("[Contract.LuaCryptoEcrecover] not found contract state")
+	}
+	setInstMinusCount(ctx, L, 10000)
+
+	d, _ := luaCryptoToBytes(digest, digestLen)
+	s, _ := luaCryptoToBytes(sig, sigLen)
+	aergoAddr, ethAddr, err := ecrecover(d, s)
+	if err != nil {
+		return nil, nil, C.CString("[Contract.LuaCryptoEcrecover] " + err.Error())
+	}
+	return C.CString(aergoAddr), C.CString(ethAddr), nil
+}
+
+//export luaCryptoBlsAggregate
+func luaCryptoBlsAggregate(sigs unsafe.Pointer, nSigs C.int) (*C.char, *C.char) {
+	agg, err := blsAggregate(luaCryptoProofToBytes(sigs, nSigs))
+	if err != nil {
+		return nil, C.CString("[Contract.LuaCryptoBlsAggregate] " + err.Error())
+	}
+	return C.CString(hexString(agg)), nil
+}
+
+//export luaCryptoBlsVerify
+func luaCryptoBlsVerify(
+	L *LState, service C.int,
+	msg unsafe.Pointer, msgLen C.int,
+	sig unsafe.Pointer, sigLen C.int,
+	pubKeys unsafe.Pointer, nPubKeys C.int,
+) (C.int, *C.char) {
+	ctx := contexts[service]
+	if ctx == nil {
+		return -1, C.CString("[Contract.LuaCryptoBlsVerify] not found contract state")
+	}
+	setInstMinusCount(ctx, L, 100000+10000*nPubKeys)
+
+	m, _ := luaCryptoToBytes(msg, msgLen)
+	s, _ := luaCryptoToBytes(sig, sigLen)
+	ok, err := blsVerify(m, s, luaCryptoProofToBytes(pubKeys, nPubKeys))
+	if err != nil {
+		return -1, C.CString("[Contract.LuaCryptoBlsVerify] " + err.Error())
+	}
+	if ok {
+		return C.int(1), nil
+	}
+	return C.int(0), nil
+}
+
+//export luaCryptoRlpDecode
+func luaCryptoRlpDecode(data unsafe.Pointer, dataLen C.int) (*C.char, *C.char) {
+	d, _ := luaCryptoToBytes(data, dataLen)
+	j, err := rlpDecodeJSON(d)
+	if err != nil {
+		return nil, C.CString("[Contract.LuaCryptoRlpDecode] " + err.Error())
+	}
+	return C.CString(j), nil
+}
+
+//export luaCryptoEthHeader
+func luaCryptoEthHeader(data unsafe.Pointer, dataLen C.int) (*C.char, *C.char) {
+	d, _ := luaCryptoToBytes(data, dataLen)
+	j, err := ethHeaderJSON(d)
+	if err != nil {
+		return nil, C.CString("[Contract.LuaCryptoEthHeader] " + err.Error())
+	}
+	return C.CString(j), nil
+}
+
 //export luaCryptoKeccak256
 func luaCryptoKeccak256(data unsafe.Pointer, dataLen C.int) (unsafe.Pointer, int) {
 	d, isHex := luaCryptoToBytes(data, dataLen)
@@ -1136,6 +1259,11 @@ func luaDeployContract(
 	if err != nil {
 		return -1, C.CString("[Contract.LuaDeployContract]:" + err.Error())
 	}
+	if useCodeVersions(ctx.blockInfo.No) {
+		if _, err = addCodeVersion(contractState, &types.ContractVersionList{}, ctx); err != nil {
+			return -1, C.CString("[Contract.LuaDeployContract]:" + err.Error())
+		}
+	}
 
 	refreshGas(ctx, L)
 	ce := newExecutor(runCode, newContract.ID(), ctx, &ci, amountBig, true, false, contractState)
@@ -1228,6 +1356,24 @@ func luaEvent(L *LState, service C.int, eventName *C.char, args *C.char) *C.char
 	return nil
 }
 
+//export luaSetUpgradeAuthority
+func luaSetUpgradeAuthority(L *LState, service C.int, address *C.char) *C.char {
+	ctx := contexts[service]
+	if ctx == nil {
+		return C.CString("[Contract.LuaSetUpgradeAuthority] contract state not found")
+	}
+	if ctx.isQuery == true || ctx.nestedView > 0 {
+		return C.CString("[Contract.LuaSetUpgradeAuthority] upgrade authority not permitted in query")
+	}
+	if !useCodeVersions(ctx.blockInfo.No) {
+		return C.CString("[Contract.LuaSetUpgradeAuthority] not supported before the hardfork V3")
+	}
+	if err := setUpgradeAuthority(ctx.curContract.callState.ctrState, C.GoString(address)); err != nil {
+		return C.CString("[Contract.LuaSetUpgradeAuthority] " + err.Error())
+	}
+	return nil
+}
+
 //export luaIsContract
 func luaIsContract(L *LState, service C.int, contractId *C.char) (C.int, *C.char) {
 	ctx := contexts[service]
@@ -337,7 +337,8 @@ func hash(id uint64) []byte {
 
 type luaTxDef struct {
 	luaTxContractCommon
-	cErr error
+	cErr     error
+	redeploy bool
 }
 
 var _ LuaTxTester = (*luaTxDef)(nil)
@@ -367,7 +368,16 @@ func newTxId() uint64 {
 	return luaTxId
 }
 
+func NewLuaTxRedeploy(sender, contract string, amount uint64, code string) *luaTxDef {
+	l := NewLuaTxDef(sender, contract, amount, code)
+	l.redeploy = true
+	return l
+}
+
 func (l *luaTxDef) okMsg() string {
+	if l.redeploy {
+		return "RECREATED"
+	}
 	return "CREATED"
 }
 
@@ -466,6 +476,10 @@ func (l *luaTxDef) run(bs *state.BlockState, bc *DummyChain, bi *types.BlockHead
 	return contractFrame(l, bs, bc, receiptTx,
 		func(sender, contract *state.V, contractId types.AccountID, eContractState *state.ContractState) (string, []*types.Event, *big.Int, error) {
 			contract.State().SqlRecoveryPoint = 1
+			if l.redeploy {
+				contract.SetRedeploy()
+				bs.RemoveCache(contractId)
+			}
 
 			ctx := newVmContext(bs, nil, sender, contract, eContractState, sender.ID(), l.Hash(), bi, "", true,
 				false, contract.State().SqlRecoveryPoint, BlockFactory, l.amount(), math.MaxUint64, false)
@@ -567,6 +581,30 @@ func (l *luaTxCall) okMsg() string {
 	return "SUCCESS"
 }
 
+// Simulate executes the call of l as Execute does for the Simulation service,
+// against a throw-away block state, and returns its result.
+func (bc *DummyChain) Simulate(l *luaTxCall) (string, error) {
+	blockState := bc.newBState()
+	tx := bc.BeginReceiptTx()
+	defer tx.Discard()
+	defer CloseDatabase()
+
+	var rv string
+	err := contractFrame(l, blockState, bc, tx,
+		func(sender, contract *state.V, contractId types.AccountID, eContractState *state.ContractState) (string, []*types.Event, *big.Int, error) {
+			ctx := newVmContext(blockState, bc, sender, contract, eContractState, sender.ID(), l.Hash(),
+				types.NewBlockHeaderInfo(bc.cBlock), "", true, false, contract.State().SqlRecoveryPoint,
+				Simulation, l.amount(), math.MaxUint64, l.feeDelegate)
+			setQueryContext(ctx)
+			defer releaseQueryContext(ctx)
+			ret, evs, ctrFee, err := Call(eContractState, l.code(), l.contract(), ctx)
+			rv = ret
+			return ret, evs, ctrFee, err
+		},
+	)
+	return rv, err
+}
+
 func (bc *DummyChain) ConnectBlock(txs ...LuaTxTester) error {
 	blockState := bc.newBState()
 	tx := bc.BeginReceiptTx()
@@ -586,7 +624,7 @@ func (bc *DummyChain) ConnectBlock(txs ...LuaTxTester) error {
 			return err
 		}
 	}
-	err := SaveRecoveryPoint(blockState)
+	err := SaveRecoveryPoint(blockState, types.NewBlockHeaderInfo(bc.cBlock))
 	if err != nil {
 		return err
 	}
@@ -676,10 +714,34 @@ func (bc *DummyChain) QueryOnly(contract, queryInfo string, expectedErr string)
 	return false, string(rv), nil
 }
 
+// CheckContractAccount runs the check_account function of contract for tx
+// sent by it and returns the fee for it.
+func (bc *DummyChain) CheckContractAccount(contract string, tx *types.Tx) (*big.Int, error) {
+	cState, err := bc.sdb.GetStateDB().OpenContractStateAccount(types.ToAccountID(strHash(contract)))
+	if err != nil {
+		return nil, err
+	}
+	return CheckContractAccount(strHash(contract), bc.newBState(), bc, cState, tx)
+}
+
 func StrToAddress(name string) string {
 	return types.EncodeAddress(strHash(name))
 }
 
+// SetHardForkVersion makes the blocks of the chain have version, which is
+// the latest one by default.
+func SetHardForkVersion(version int32) func(dc *DummyChain) {
+	return func(dc *DummyChain) {
+		hf := *config.AllEnabledHardforkConfig
+		for v, no := range []*types.BlockNo{&hf.V2, &hf.V3, &hf.V4} {
+			if int32(v+2) > version {
+				*no = math.MaxUint64
+			}
+		}
+		HardforkConfig = &hf
+	}
+}
+
 func OnPubNet(dc *DummyChain) {
 	flushLState := func() {
 		for i := 0; i <= lStateMaxSize; i++ {
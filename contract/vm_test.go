@@ -2,17 +2,26 @@ package contract
 
 import (
 	"bytes"
+	"crypto/rand"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"math/big"
 	"os"
 	"strconv"
 	"strings"
 	"testing"
 
+	"github.com/aergoio/aergo/account/key"
+	"github.com/aergoio/aergo/config"
+	"github.com/aergoio/aergo/internal/common"
+	"github.com/aergoio/aergo/pkg/trie"
+	"github.com/aergoio/aergo/state"
 	"github.com/aergoio/aergo/types"
+	"github.com/btcsuite/btcd/btcec"
+	"golang.org/x/crypto/bn256"
 )
 
 const (
@@ -928,6 +937,68 @@ abi.register(init, add, addFail, get)`
 	}
 }
 
+func TestSqlVmSimulation(t *testing.T) {
+	bc, err := LoadDummyChain()
+	if err != nil {
+		t.Errorf("failed to create test database: %v", err)
+	}
+	defer bc.Release()
+
+	definition := `
+function init()
+    db.exec("create table if not exists total(n int)")
+	db.exec("insert into total values (0)")
+end
+
+function add(n)
+	local stmt = db.prepare("update total set n = n + ?")
+	stmt:exec(n)
+	return get()
+end
+
+function get()
+	local rs = db.query("select n from total")
+	rs:next()
+	n = rs:get()
+	return n
+end
+abi.register(init, add, get)`
+
+	err = bc.ConnectBlock(
+		NewLuaTxAccount("ktlee", 100000000000000000),
+		NewLuaTxDef("ktlee", "sim", 0, definition),
+		NewLuaTxCall("ktlee", "sim", 0, `{"Name":"init"}`),
+		NewLuaTxCall("ktlee", "sim", 0, `{"Name":"add", "Args":[1]}`),
+	)
+	if err != nil {
+		t.Error(err)
+	}
+
+	// the simulation writes the database, but the changes are rolled back
+	rv, err := bc.Simulate(NewLuaTxCall("ktlee", "sim", 0, `{"Name":"add", "Args":[2]}`))
+	if err != nil {
+		t.Error(err)
+	}
+	if rv != "3" {
+		t.Errorf("simulation: expected 3, got %s", rv)
+	}
+	err = bc.Query("sim", `{"Name":"get"}`, "", "1")
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = bc.ConnectBlock(
+		NewLuaTxCall("ktlee", "sim", 0, `{"Name":"add", "Args":[4]}`),
+	)
+	if err != nil {
+		t.Error(err)
+	}
+	err = bc.Query("sim", `{"Name":"get"}`, "", "5")
+	if err != nil {
+		t.Error(err)
+	}
+}
+
 func TestSqlVmDateTime(t *testing.T) {
 	bc, err := LoadDummyChain()
 	if err != nil {
@@ -5093,6 +5164,104 @@ func TestLuaCryptoVerifyProof(t *testing.T) {
 	}
 }
 
+func TestLuaCryptoBridge(t *testing.T) {
+	bc, err := LoadDummyChain(OnPubNet)
+	if err != nil {
+		t.Errorf("failed to create test database: %v", err)
+	}
+	defer bc.Release()
+
+	definition := `
+	function ecrecover(digest, sig)
+		local aergo, eth = crypto.ecrecover(digest, sig)
+		return {aergo, eth}
+	end
+
+	function blsVerify(msg, sig1, sig2, pk1, pk2)
+		return crypto.blsVerify(msg, crypto.blsAggregate(sig1, sig2), pk1, pk2)
+	end
+
+	function verifyAergoVar(root, key, value, ...)
+		return crypto.verifyAergoVar(root, key, value, ...)
+	end
+
+	function rlpDecode(rlp)
+		return crypto.rlpDecode(rlp)
+	end
+
+	function ethHeaderNumber(rlp)
+		return crypto.ethHeader(rlp).number
+	end
+
+	abi.register(ecrecover, blsVerify, verifyAergoVar, rlpDecode, ethHeaderNumber)`
+
+	err = bc.ConnectBlock(
+		NewLuaTxAccount("ktlee", 100000000000000000),
+		NewLuaTxDef("ktlee", "bridge", 0, definition),
+	)
+	if err != nil {
+		t.Error(err)
+	}
+
+	key, _ := btcec.NewPrivateKey(btcec.S256())
+	digest := keccak256([]byte("transfer"))
+	compact, _ := btcec.SignCompact(btcec.S256(), key, digest, true)
+	sig := append(append([]byte{}, compact[1:]...), compact[0]-4)
+	err = bc.Query("bridge",
+		fmt.Sprintf(`{"Name":"ecrecover", "Args":["%s", "%s"]}`, hexString(digest), hexString(sig)), "",
+		fmt.Sprintf(`["%s","%s"]`, types.EncodeAddress(key.PubKey().SerializeCompressed()),
+			hexString(keccak256(key.PubKey().SerializeUncompressed()[1:])[12:])))
+	if err != nil {
+		t.Error(err)
+	}
+
+	msg := []byte("block 100")
+	args := []string{`"` + hexString(msg) + `"`}
+	var pubKeys []string
+	for i := 0; i < 2; i++ {
+		sk, pk, _ := bn256.RandomG2(rand.Reader)
+		args = append(args, `"`+hexString(new(bn256.G1).ScalarMult(blsHashToPoint(msg), sk).Marshal())+`"`)
+		pubKeys = append(pubKeys, `"`+hexString(pk.Marshal())+`"`)
+	}
+	args = append(args, pubKeys...)
+	err = bc.Query("bridge", `{"Name":"blsVerify", "Args":[`+strings.Join(args, ",")+`]}`, "", `true`)
+	if err != nil {
+		t.Error(err)
+	}
+
+	tr := trie.NewTrie(nil, common.Hasher, nil)
+	root, _ := tr.Update([][]byte{common.Hasher([]byte("_sv_name"))}, [][]byte{common.Hasher([]byte(`"aergo"`))})
+	ap, _, _, _, _ := tr.MerkleProof(common.Hasher([]byte("_sv_name")))
+	args = []string{`"` + hexString(root) + `"`, `"_sv_name"`, `"\"aergo\""`}
+	for _, p := range ap {
+		args = append(args, `"`+hexString(p)+`"`)
+	}
+	err = bc.Query("bridge", `{"Name":"verifyAergoVar", "Args":[`+strings.Join(args, ",")+`]}`, "", `true`)
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = bc.Query("bridge", `{"Name":"rlpDecode", "Args":["0xc88363617483646f67"]}`, "", `["0x636174","0x646f67"]`)
+	if err != nil {
+		t.Error(err)
+	}
+	err = bc.Query("bridge", `{"Name":"rlpDecode", "Args":["0xc88363617483646f"]}`, "invalid rlp encoding", "")
+	if err != nil {
+		t.Error(err)
+	}
+
+	header := make(rlpList, ethHeaderMinFields)
+	for i := range header {
+		header[i] = rlpString(nil)
+	}
+	header[8] = rlpString(toBinary(17000000))
+	err = bc.Query("bridge",
+		fmt.Sprintf(`{"Name":"ethHeaderNumber", "Args":["%s"]}`, hexString(header.rlpEncode())), "", `17000000`)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
 func TestMultiArray(t *testing.T) {
 	bc, err := LoadDummyChain()
 	if err != nil {
@@ -6179,4 +6348,601 @@ abi.register(get)`
 	}
 }
 
+func TestContractAccount(t *testing.T) {
+	bc, err := LoadDummyChain()
+	if err != nil {
+		t.Errorf("failed to create test database: %v", err)
+	}
+	defer bc.Release()
+
+	definition := `
+state.var {
+	owner = state.value()
+}
+
+function constructor(o)
+	owner:set(o)
+end
+
+function check_account(hash, sign, recipient, amount, payload)
+	-- a daily limit would be kept in the state in the same way
+	if bignum.number(amount) > bignum.number("1000") then
+		return false
+	end
+	return crypto.ecverify(hash, sign, owner:get())
+end
+
+abi.register_view(check_account)`
+
+	ownerKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	owner := types.EncodeAddress(ownerKey.PubKey().SerializeCompressed())
+	err = bc.ConnectBlock(
+		NewLuaTxAccount("ktlee", 100000000000000000),
+		NewLuaTxDef("ktlee", "wallet", 0, definition).Constructor(fmt.Sprintf(`["%s"]`, owner)),
+		NewLuaTxDef("ktlee", "plain", 0, helloCode),
+	)
+	if err != nil {
+		t.Error(err)
+	}
+
+	newTx := func(sender string, amount int64) *types.Tx {
+		return &types.Tx{Body: &types.TxBody{
+			Account:   strHash(sender),
+			Recipient: strHash("ktlee"),
+			Amount:    big.NewInt(amount).Bytes(),
+			Nonce:     1,
+			Type:      types.TxType_TRANSFER,
+		}}
+	}
+
+	tx := newTx("wallet", 100)
+	if err = key.SignTx(tx, ownerKey); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = bc.CheckContractAccount("wallet", tx); err != nil {
+		t.Error(err)
+	}
+
+	tx.Body.Amount = big.NewInt(101).Bytes()
+	if _, err = bc.CheckContractAccount("wallet", tx); err != types.ErrNotAllowedContractAccount {
+		t.Errorf("expected: %v, but got: %v", types.ErrNotAllowedContractAccount, err)
+	}
+
+	tx = newTx("wallet", 2000)
+	if err = key.SignTx(tx, ownerKey); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = bc.CheckContractAccount("wallet", tx); err != types.ErrNotAllowedContractAccount {
+		t.Errorf("expected: %v, but got: %v", types.ErrNotAllowedContractAccount, err)
+	}
+
+	if _, err = bc.CheckContractAccount("plain", newTx("plain", 1)); err != types.ErrNotContractAccount {
+		t.Errorf("expected: %v, but got: %v", types.ErrNotContractAccount, err)
+	}
+}
+
+func TestContractUpgrade(t *testing.T) {
+	bc, err := LoadDummyChain()
+	if err != nil {
+		t.Errorf("failed to create test database: %v", err)
+	}
+	defer bc.Release()
+
+	v1 := `
+state.var {
+	value = state.value(),
+	oldVersion = state.value()
+}
+
+function constructor(v)
+	value:set(v)
+	oldVersion:set(0)
+end
+
+function get()
+	return {value:get(), oldVersion:get()}
+end
+
+function setAuthority(address)
+	contract.setUpgradeAuthority(address)
+end
+
+abi.register(setAuthority)
+abi.register_view(get)`
+
+	v2 := `
+state.var {
+	value = state.value(),
+	oldVersion = state.value()
+}
+
+function constructor(v)
+	value:set(v)
+	oldVersion:set(0)
+end
+
+function __upgrade(old, v)
+	value:set(value:get() + v)
+	oldVersion:set(old)
+end
+
+function get()
+	return {value:get(), oldVersion:get()}
+end
+
+function setAuthority(address)
+	contract.setUpgradeAuthority(address)
+end
+
+abi.register(setAuthority)
+abi.register_view(get)`
+
+	err = bc.ConnectBlock(
+		NewLuaTxAccount("ktlee", 100000000000000000),
+		NewLuaTxAccount("other", 100000000000000000),
+		NewLuaTxDef("ktlee", "upgrade", 0, v1).Constructor("[10]"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = bc.ConnectBlock(
+		NewLuaTxRedeploy("ktlee", "upgrade", 0, v2).Constructor("[5]"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = bc.Query("upgrade", `{"Name":"get"}`, "", "[15,1]")
+	if err != nil {
+		t.Error(err)
+	}
+
+	cState, err := bc.sdb.GetStateDB().OpenContractStateAccount(types.ToAccountID(strHash("upgrade")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	versions, err := GetCodeVersions(cState)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions.Versions) != 2 || versions.Latest().Version != 2 ||
+		versions.Latest().BlockNo != bc.BestBlockNo() ||
+		!bytes.Equal(versions.Latest().CodeHash, cState.State.GetCodeHash()) {
+		t.Errorf("unexpected versions: %v", versions)
+	}
+
+	err = bc.ConnectBlock(
+		NewLuaTxRedeploy("other", "upgrade", 0, v1).Constructor("[1]"),
+	)
+	if err == nil || !strings.Contains(err.Error(), types.ErrNotUpgradeAuthority.Error()) {
+		t.Errorf("expected: %v, but got: %v", types.ErrNotUpgradeAuthority, err)
+	}
+
+	// Without __upgrade, the constructor initializes the new code.
+	err = bc.ConnectBlock(
+		NewLuaTxCall("ktlee", "upgrade", 0, fmt.Sprintf(`{"Name":"setAuthority", "Args":["%s"]}`, StrToAddress("other"))),
+		NewLuaTxRedeploy("other", "upgrade", 0, v1).Constructor("[1]"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = bc.Query("upgrade", `{"Name":"get"}`, "", "[1,0]")
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = bc.ConnectBlock(
+		NewLuaTxCall("other", "upgrade", 0, `{"Name":"setAuthority", "Args":[null]}`),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = bc.ConnectBlock(
+		NewLuaTxRedeploy("other", "upgrade", 0, v2).Constructor("[1]"),
+	)
+	if err == nil || !strings.Contains(err.Error(), types.ErrNotUpgradable.Error()) {
+		t.Errorf("expected: %v, but got: %v", types.ErrNotUpgradable, err)
+	}
+}
+
+func TestContractProfile(t *testing.T) {
+	bc, err := LoadDummyChain()
+	if err != nil {
+		t.Errorf("failed to create test database: %v", err)
+	}
+	defer bc.Release()
+
+	code := `
+state.var {
+	count = state.value()
+}
+
+function add(n)
+	return (count:get() or 0) + n
+end
+
+function inc(n)
+	count:set(add(n))
+end
+
+function unused()
+	return 0
+end
+
+abi.register(inc, unused)`
+
+	err = bc.ConnectBlock(
+		NewLuaTxAccount("ktlee", 100),
+		NewLuaTxDef("ktlee", "profile", 0, code),
+	)
+	if err != nil {
+		t.Error(err)
+	}
+
+	p := StartProfile()
+	err = bc.ConnectBlock(
+		NewLuaTxCall("ktlee", "profile", 0, `{"Name":"inc", "Args":[1]}`),
+		NewLuaTxCall("ktlee", "profile", 0, `{"Name":"inc", "Args":[2]}`),
+	)
+	if StopProfile() != p {
+		t.Error("unexpected profile")
+	}
+	if err != nil {
+		t.Error(err)
+	}
+
+	var lcov strings.Builder
+	if err = p.WriteLcov(&lcov, nil); err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{"FNDA:2,inc", "FNDA:2,add", "FNDA:0,unused", "DA:11,2", "end_of_record"} {
+		if !strings.Contains(lcov.String(), expected) {
+			t.Errorf("%s is not found in the lcov profile:\n%s", expected, lcov.String())
+		}
+	}
+
+	var folded strings.Builder
+	if err = p.WriteFolded(&folded, false, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(folded.String(), ":inc;") {
+		t.Errorf("the call stack of inc is not found in the folded profile:\n%s", folded.String())
+	}
+}
+
+func TestTypedABI(t *testing.T) {
+	bc, err := LoadDummyChain()
+	if err != nil {
+		t.Errorf("failed to create test database: %v", err)
+	}
+	defer bc.Release()
+
+	src := `
+state.var { balances = state.map() }
+
+function constructor(supply)
+	balances[system.getCreator()] = supply
+end
+
+function transfer(to, amount, memo)
+	local sender = system.getSender()
+	balances[sender] = balances[sender] - amount
+	balances[to] = (balances[to] or bignum.number(0)) + amount
+	contract.event("transfer", sender, to, amount)
+	return true
+end
+
+function balanceOf(owner)
+	return balances[owner] or bignum.number(0)
+end
+
+abi.event("transfer", {"from:address", "to:address", "amount:bignum"})
+abi.register({transfer, params = {"address", "bignum", "string?"}, returns = {"bool"}})
+abi.register_view({balanceOf, params = {"address"}, returns = {"bignum"}})
+abi.register({constructor, params = {"bignum"}})`
+
+	to := StrToAddress("other")
+	err = bc.ConnectBlock(
+		NewLuaTxAccount("ktlee", 100000000000000000),
+		NewLuaTxDef("ktlee", "typed", 0, src).Constructor(`[{"_bignum":"1000"}]`),
+		NewLuaTxCall("ktlee", "typed", 0, fmt.Sprintf(`{"Name":"transfer", "Args":["%s", "100"]}`, to)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = bc.Query("typed", fmt.Sprintf(`{"Name":"balanceOf", "Args":["%s"]}`, to), "", `{"_bignum":"100"}`)
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = bc.ConnectBlock(
+		NewLuaTxCall("ktlee", "typed", 0, `{"Name":"transfer", "Args":["nobody!", "100"]}`).
+			Fail("bad argument #1 to 'transfer': address expected, got string"),
+		NewLuaTxCall("ktlee", "typed", 0, fmt.Sprintf(`{"Name":"transfer", "Args":["%s", 1.5]}`, to)).
+			Fail("bad argument #2 to 'transfer': bignum expected, got number"),
+		NewLuaTxCall("ktlee", "typed", 0, fmt.Sprintf(`{"Name":"transfer", "Args":["%s", "1", "memo", 1]}`, to)).
+			Fail("too many arguments to 'transfer'"),
+	)
+	if err != nil {
+		t.Error(err)
+	}
+	err = bc.Query("typed", `{"Name":"balanceOf", "Args":[1]}`, "address expected, got number")
+	if err != nil {
+		t.Error(err)
+	}
+
+	abi, err := bc.GetABI("typed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range abi.Functions {
+		if f.Name != "transfer" {
+			continue
+		}
+		if len(f.Arguments) != 3 || f.Arguments[0].Type != "address" || f.Arguments[2].Type != "string?" ||
+			len(f.Returns) != 1 || f.Returns[0].Type != "bool" {
+			t.Errorf("unexpected types of transfer: %v", f)
+		}
+	}
+	if len(abi.Events) != 1 || abi.Events[0].Name != "transfer" || len(abi.Events[0].Arguments) != 3 ||
+		abi.Events[0].Arguments[2].Name != "amount" || abi.Events[0].Arguments[2].Type != "bignum" {
+		t.Errorf("unexpected events: %v", abi.Events)
+	}
+}
+
+func TestSqlDatabaseHash(t *testing.T) {
+	bc, err := LoadDummyChain()
+	if err != nil {
+		t.Errorf("failed to create test database: %v", err)
+	}
+	defer bc.Release()
+
+	definition := `
+function init()
+	db.exec("create table if not exists items(id integer primary key, name text)")
+end
+
+function add(name)
+	db.exec("insert into items(name) values ('" .. name .. "')")
+end
+
+abi.register(add)`
+
+	err = bc.ConnectBlock(
+		NewLuaTxAccount("ktlee", 100000000000000000),
+		NewLuaTxDef("ktlee", "items1", 0, definition),
+		NewLuaTxDef("ktlee", "items2", 0, definition),
+		NewLuaTxDef("ktlee", "nosql", 0, "function f() end abi.register(f)"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = bc.ConnectBlock(
+		NewLuaTxCall("ktlee", "items1", 0, `{"Name":"add", "Args":["a"]}`),
+		NewLuaTxCall("ktlee", "items2", 0, `{"Name":"add", "Args":["b"]}`),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	openState := func(name string) *state.ContractState {
+		cs, err := bc.sdb.GetStateDB().OpenContractStateAccount(types.ToAccountID(strHash(name)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return cs
+	}
+	path, err := DatabaseFile(types.ToAccountID(strHash("items1")).String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = VerifyDatabaseFile(path, openState("items1")); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err = VerifyDatabaseFile(path, openState("items2")); err != ErrDBHash {
+		t.Errorf("expected %v, but got %v", ErrDBHash, err)
+	}
+	if err = VerifyDatabaseFile(path, openState("nosql")); err != ErrNoDBHash {
+		t.Errorf("expected %v, but got %v", ErrNoDBHash, err)
+	}
+}
+
+func TestSqlDatabaseHashAtV3Fork(t *testing.T) {
+	bc, err := LoadDummyChain()
+	if err != nil {
+		t.Errorf("failed to create test database: %v", err)
+	}
+	defer bc.Release()
+	HardforkConfig = &config.HardforkConfig{V2: 0, V3: 3, V4: math.MaxUint64}
+
+	definition := `
+function init()
+	db.exec("create table if not exists items(id integer primary key, name text)")
+	db.exec("insert into items(name) values ('a')")
+end
+
+function add(name)
+	db.exec("insert into items(name) values ('" .. name .. "')")
+end
+
+abi.register(add)`
+
+	// The contracts are created and changed before the hardfork V3.
+	err = bc.ConnectBlock(
+		NewLuaTxAccount("ktlee", 100000000000000000),
+		NewLuaTxDef("ktlee", "items1", 0, definition),
+		NewLuaTxDef("ktlee", "items2", 0, definition),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = bc.ConnectBlock(NewLuaTxCall("ktlee", "items2", 0, `{"Name":"add", "Args":["b"]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	name := types.ToAccountID(strHash("items1")).String()
+	path, err := DatabaseFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// download copies the database file as the snapshot sync downloads it.
+	download := func() string {
+		buf := make([]byte, 4096)
+		var data []byte
+		for {
+			n, size, err := ReadDatabaseFile(name, int64(len(data)), buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			data = append(data, buf[:n]...)
+			if int64(len(data)) >= size {
+				break
+			}
+		}
+		downloaded := path + ".part"
+		if err := ioutil.WriteFile(downloaded, data, 0600); err != nil {
+			t.Fatal(err)
+		}
+		return downloaded
+	}
+	openState := func(name string) *state.ContractState {
+		cs, err := bc.sdb.GetStateDB().OpenContractStateAccount(types.ToAccountID(strHash(name)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return cs
+	}
+	downloaded := download()
+	if err = VerifyDatabaseFile(downloaded, openState("items1")); err != ErrNoDBHash {
+		t.Errorf("expected %v, but got %v", ErrNoDBHash, err)
+	}
+
+	// The block of the hardfork commits the hashes of the databases, even if
+	// they are not changed by the block.
+	err = bc.ConnectBlock(NewLuaTxAccount("user1", 100000000000000000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	downloaded = download()
+	if err = VerifyDatabaseFile(downloaded, openState("items1")); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err = VerifyDatabaseFile(downloaded, openState("items2")); err != ErrDBHash {
+		t.Errorf("expected %v, but got %v", ErrDBHash, err)
+	}
+
+	// The hashes stay committed to the following blocks.
+	err = bc.ConnectBlock(NewLuaTxCall("ktlee", "items2", 0, `{"Name":"add", "Args":["c"]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = VerifyDatabaseFile(downloaded, openState("items1")); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestCryptoV3Functions(t *testing.T) {
+	src := `
+function has()
+	local names = {"verifyAergoAccount", "verifyAergoVar", "decodeAergoState", "ecrecover",
+		"blsAggregate", "blsVerify", "rlpDecode", "ethHeader"}
+	local found = {}
+	for _, name in ipairs(names) do
+		table.insert(found, crypto[name] ~= nil)
+	end
+	return found, crypto.sha256 ~= nil
+end
+abi.register(has)`
+
+	for version, expected := range map[int32]string{
+		2: `[[false,false,false,false,false,false,false,false],true]`,
+		3: `[[true,true,true,true,true,true,true,true],true]`,
+	} {
+		bc, err := LoadDummyChain(SetHardForkVersion(version))
+		if err != nil {
+			t.Errorf("failed to create test database: %v", err)
+		}
+		err = bc.ConnectBlock(
+			NewLuaTxAccount("ktlee", 100000000000000000),
+			NewLuaTxDef("ktlee", "crypto", 0, src),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = bc.Query("crypto", `{"Name":"has"}`, "", expected); err != nil {
+			t.Errorf("version %d: %v", version, err)
+		}
+		bc.Release()
+	}
+}
+
+func TestContractRedeployBeforeV3(t *testing.T) {
+	bc, err := LoadDummyChain(SetHardForkVersion(2))
+	if err != nil {
+		t.Errorf("failed to create test database: %v", err)
+	}
+	defer bc.Release()
+
+	src := `
+state.var {
+	value = state.value()
+}
+
+function constructor(v)
+	value:set(v)
+end
+
+function get()
+	return value:get()
+end
+
+function hasSetUpgradeAuthority()
+	return contract.setUpgradeAuthority ~= nil
+end
+
+abi.register_view(get, hasSetUpgradeAuthority)`
+
+	err = bc.ConnectBlock(
+		NewLuaTxAccount("ktlee", 100000000000000000),
+		NewLuaTxAccount("other", 100000000000000000),
+		NewLuaTxDef("ktlee", "redeploy", 0, src).Constructor("[10]"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = bc.Query("redeploy", `{"Name":"hasSetUpgradeAuthority"}`, "", "false")
+	if err != nil {
+		t.Error(err)
+	}
+
+	// The new account has no contract to redeploy.
+	err = bc.ConnectBlock(
+		NewLuaTxRedeploy("ktlee", "unknown", 0, src).Constructor("[1]"),
+	)
+	if err == nil || !strings.Contains(err.Error(), "not found contract") {
+		t.Errorf("expected: not found contract, but got: %v", err)
+	}
+	err = bc.ConnectBlock(
+		NewLuaTxRedeploy("other", "redeploy", 0, src).Constructor("[1]"),
+	)
+	if err == nil || !strings.Contains(err.Error(), types.ErrCreatorNotMatch.Error()) {
+		t.Errorf("expected: %v, but got: %v", types.ErrCreatorNotMatch, err)
+	}
+
+	// The constructor initializes the redeployed code.
+	err = bc.ConnectBlock(
+		NewLuaTxRedeploy("ktlee", "redeploy", 0, src).Constructor("[1]"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = bc.Query("redeploy", `{"Name":"get"}`, "", "1")
+	if err != nil {
+		t.Error(err)
+	}
+}
+
 // end of test-cases
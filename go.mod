@@ -25,6 +25,7 @@ require (
 	github.com/gogo/protobuf v1.3.0
 	github.com/golang/mock v1.3.1
 	github.com/golang/protobuf v1.3.3
+	github.com/gorilla/websocket v1.4.1
 	github.com/grpc-ecosystem/grpc-opentracing v0.0.0-20180507213350-8e809c8a8645
 	github.com/hashicorp/golang-lru v0.5.1
 	github.com/improbable-eng/grpc-web v0.9.6
@@ -49,7 +50,7 @@ require (
 	github.com/orcaman/concurrent-map v0.0.0-20190314100340-2693aad1ed75 // indirect
 	github.com/pkg/errors v0.9.1
 	github.com/pkg/term v0.0.0-20190109203006-aa71e9d9e942 // indirect
-	github.com/prometheus/client_golang v1.0.0 // indirect
+	github.com/prometheus/client_golang v1.0.0
 	github.com/rs/cors v1.6.0 // indirect
 	github.com/rs/zerolog v1.16.1-0.20191111091419-e709c5d91e35
 	github.com/sanity-io/litter v1.2.0
@@ -1,8 +1,10 @@
 package merkle
 
 import (
-	"github.com/minio/sha256-simd"
+	"bytes"
 	"hash"
+
+	"github.com/minio/sha256-simd"
 )
 
 type MerkleEntry interface {
@@ -88,3 +90,70 @@ func CalculateMerkleTree(entries []MerkleEntry) [][]byte {
 
 	return merkles
 }
+
+// CalculateMerkleProof returns the audit path of the entry at idx, which is
+// the hashes of the siblings from the leaf to the root. It returns nil if idx
+// is out of the entries.
+func CalculateMerkleProof(entries []MerkleEntry, idx int) [][]byte {
+	if idx < 0 || idx >= len(entries) {
+		return nil
+	}
+	merkles := CalculateMerkleTree(entries)
+
+	path := [][]byte{}
+	// the nodes of each height are stored after those of the lower height
+	offset := 0
+	for width := (len(merkles) + 1) / 2; width > 1; width /= 2 {
+		// a missing right sibling is already filled by the left one
+		path = append(path, merkles[offset+(idx^1)])
+		offset += width
+		idx /= 2
+	}
+	return path
+}
+
+// VerifyMerkleProof checks that leaf is the entry at idx of the merkle tree
+// of count entries of root by the audit path made by CalculateMerkleProof.
+// Since a missing right node is filled by a copy of its left sibling, the
+// copies would verify at the indexes after the last entry (CVE-2012-2459).
+// Thus idx must be less than count, and a right node can't be a copy.
+func VerifyMerkleProof(root, leaf []byte, idx, count int, path [][]byte) bool {
+	if idx < 0 || idx >= count || len(path) != merkleDepth(count) {
+		return false
+	}
+	hasher := sha256.New()
+	h := leaf
+	// width is the number of the nodes at the height, without the copies
+	width := count
+	for _, sibling := range path {
+		hasher.Reset()
+		if idx%2 == 0 {
+			// the last node at the height is paired with its copy
+			if idx == width-1 && !bytes.Equal(sibling, h) {
+				return false
+			}
+			hasher.Write(h)
+			hasher.Write(sibling)
+		} else {
+			if bytes.Equal(sibling, h) {
+				return false
+			}
+			hasher.Write(sibling)
+			hasher.Write(h)
+		}
+		h = hasher.Sum(nil)
+		idx /= 2
+		width = (width + 1) / 2
+	}
+	return bytes.Equal(h, root)
+}
+
+// merkleDepth returns the length of the audit paths of the merkle tree of
+// count entries.
+func merkleDepth(count int) int {
+	depth := 0
+	for 1<<uint(depth) < count {
+		depth++
+	}
+	return depth
+}
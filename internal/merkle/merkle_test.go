@@ -178,3 +178,68 @@ func BenchmarkMerkle10000Tx(b *testing.B) {
 		CalculateMerkleTree(tms)
 	}
 }
+
+func TestMerkleProof(t *testing.T) {
+	for _, count := range []int{1, 2, 3, 5, 8, 10} {
+		entries := make([]MerkleEntry, count)
+		for i := range entries {
+			h := sha256.Sum256([]byte{byte(i)})
+			entries[i] = &testME{hash: h[:]}
+		}
+		root := CalculateMerkleRoot(entries)
+
+		for i, entry := range entries {
+			path := CalculateMerkleProof(entries, i)
+			assert.NotNil(t, path)
+			assert.True(t, VerifyMerkleProof(root, entry.GetHash(), i, count, path), "count=%d idx=%d", count, i)
+			assert.False(t, VerifyMerkleProof(root, entry.GetHash(), i+1<<uint(len(path)), count, path), "count=%d idx=%d", count, i)
+			assert.False(t, VerifyMerkleProof(root, entry.GetHash(), i, count<<1, path), "count=%d idx=%d", count, i)
+			if count > 1 {
+				assert.False(t, VerifyMerkleProof(root, entries[(i+1)%count].GetHash(), i, count, path), "count=%d idx=%d", count, i)
+			}
+		}
+		assert.Nil(t, CalculateMerkleProof(entries, count))
+	}
+}
+
+func TestMerkleProofOfCopies(t *testing.T) {
+	// the missing right nodes are the copies of their left siblings, so the
+	// path of the last entry also hashes to the root at some indexes after it
+	for _, count := range []int{3, 5, 6, 7, 10} {
+		entries := make([]MerkleEntry, count)
+		for i := range entries {
+			h := sha256.Sum256([]byte{byte(i)})
+			entries[i] = &testME{hash: h[:]}
+		}
+		root := CalculateMerkleRoot(entries)
+		last := entries[count-1].GetHash()
+		path := CalculateMerkleProof(entries, count-1)
+
+		copies := 0
+		for idx := count; idx < 1<<uint(len(path)); idx++ {
+			h := last
+			for i, sibling := range path {
+				h = nextHash(h, sibling, idx>>uint(i))
+			}
+			if !bytes.Equal(h, root) {
+				continue
+			}
+			copies++
+			assert.False(t, VerifyMerkleProof(root, last, idx, count, path), "count=%d idx=%d", count, idx)
+			assert.False(t, VerifyMerkleProof(root, last, idx, idx+1, path), "count=%d idx=%d", count, idx)
+		}
+		assert.NotZero(t, copies, "count=%d", count)
+	}
+}
+
+func nextHash(h, sibling []byte, idx int) []byte {
+	hasher := sha256.New()
+	if idx%2 == 0 {
+		hasher.Write(h)
+		hasher.Write(sibling)
+	} else {
+		hasher.Write(sibling)
+		hasher.Write(h)
+	}
+	return hasher.Sum(nil)
+}
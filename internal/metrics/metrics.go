@@ -0,0 +1,96 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+// Package metrics defines the metrics of the node, which are exposed in the
+// Prometheus text format by Handler. The components update the metrics where
+// the measured events happen, so a metric costs a few atomic operations even
+// if the endpoint is disabled.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "aergo"
+
+var registry = prometheus.NewRegistry()
+
+// chain
+var (
+	BlockHeight = newGauge("chain", "block_height",
+		"Number of the best block.")
+	BlockProcessTime = newHistogram("chain", "block_process_seconds",
+		"Time to execute and connect a block.",
+		prometheus.ExponentialBuckets(0.005, 2, 14))
+	TxExecuteTime = newHistogram("chain", "tx_execute_seconds",
+		"Time to execute a transaction.",
+		prometheus.ExponentialBuckets(0.0001, 2, 16))
+)
+
+// mempool
+var (
+	MempoolTxs = newGauge("mempool", "txs",
+		"Number of the transactions in the mempool including the orphans.")
+	MempoolOrphans = newGauge("mempool", "orphans",
+		"Number of the orphan transactions in the mempool, whose nonces are not continuous.")
+)
+
+// p2p
+var (
+	P2PPeers = newGauge("p2p", "peers",
+		"Number of the connected peers.")
+	P2PReceivedBytes = newCounter("p2p", "received_bytes_total",
+		"Total bytes received from the peers.")
+	P2PSentBytes = newCounter("p2p", "sent_bytes_total",
+		"Total bytes sent to the peers.")
+)
+
+// raft
+var (
+	RaftTerm = newGauge("raft", "term",
+		"Current term of the raft cluster.")
+	RaftIsLeader = newGauge("raft", "is_leader",
+		"1 if the node is the leader of the raft cluster, 0 otherwise.")
+	RaftLeaderChanges = newCounter("raft", "leader_changes_total",
+		"Number of the leader changes seen by the node.")
+)
+
+// contract
+var (
+	ContractGasUsed = newCounter("contract", "gas_used_total",
+		"Total gas used by the executed transactions.")
+)
+
+func newGauge(subsystem, name, help string) prometheus.Gauge {
+	g := prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace, Subsystem: subsystem, Name: name, Help: help,
+	})
+	registry.MustRegister(g)
+	return g
+}
+
+func newCounter(subsystem, name, help string) prometheus.Counter {
+	c := prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace, Subsystem: subsystem, Name: name, Help: help,
+	})
+	registry.MustRegister(c)
+	return c
+}
+
+func newHistogram(subsystem, name, help string, buckets []float64) prometheus.Histogram {
+	h := prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace, Subsystem: subsystem, Name: name, Help: help, Buckets: buckets,
+	})
+	registry.MustRegister(h)
+	return h
+}
+
+// Handler returns the HTTP handler serving the metrics.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
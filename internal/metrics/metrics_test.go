@@ -0,0 +1,32 @@
+package metrics
+
+import (
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandler(t *testing.T) {
+	BlockHeight.Set(42)
+	P2PReceivedBytes.Add(100)
+	BlockProcessTime.Observe(0.01)
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, err := ioutil.ReadAll(rec.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"aergo_chain_block_height 42",
+		"aergo_p2p_received_bytes_total 100",
+		"aergo_chain_block_process_seconds_count 1",
+		"# TYPE aergo_mempool_txs gauge",
+		"# TYPE aergo_raft_leader_changes_total counter",
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("%q is not found in the metrics", want)
+		}
+	}
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/aergoio/aergo/fee"
 	"github.com/aergoio/aergo/internal/common"
 	"github.com/aergoio/aergo/internal/enc"
+	"github.com/aergoio/aergo/internal/metrics"
 	"github.com/aergoio/aergo/message"
 	"github.com/aergoio/aergo/pkg/component"
 	"github.com/aergoio/aergo/state"
@@ -167,8 +168,10 @@ func (mp *MemPool) monitor() {
 		select {
 		// Log current counts on mempool
 		case <-showmetric.C:
+			l, o := mp.Size()
+			metrics.MempoolTxs.Set(float64(l))
+			metrics.MempoolOrphans.Set(float64(o))
 			if mp.cfg.Mempool.ShowMetrics {
-				l, o := mp.Size()
 				mp.Info().Int("len", l).Int("orphan", o).Int("acc", len(mp.pool)).Msg("mempool metrics")
 			}
 			// Evict old enough transactions
@@ -176,6 +179,7 @@ func (mp *MemPool) monitor() {
 			if mp.cfg.Mempool.EnableFadeout {
 				mp.evictTransactions()
 			}
+			mp.evictExpiredTxs()
 
 			// Graceful quit
 		case <-mp.quit:
@@ -290,6 +294,9 @@ func (mp *MemPool) Receive(context actor.Context) {
 		}
 		context.Respond(&message.MemPoolTxRsp{Data: b})
 
+	case *message.MemPoolTipStat:
+		context.Respond(&message.MemPoolTipStatRsp{Stat: mp.tipStat()})
+
 	case *actor.Started:
 		mp.loadTxs() // FIXME :work-around for actor settled
 
@@ -316,22 +323,32 @@ func (mp *MemPool) get(maxBlockBodySize uint32) ([]types.Transaction, error) {
 	start := time.Now()
 	mp.RLock()
 	defer mp.RUnlock()
-	count := 0
+	var txs []types.Transaction
+	blockNo, ts := mp.nextBlockTime()
+	if version := mp.nextBlockVersion(); version >= 3 {
+		txs = mp.getByTip(maxBlockBodySize, system.GetGasPrice(), version, blockNo, ts)
+	} else {
+		txs = mp.getByAccount(maxBlockBodySize, blockNo, ts)
+	}
+	elapsed := time.Since(start)
+	mp.Debug().Str("elapsed", elapsed.String()).Int("len", mp.length).Int("orphan", mp.orphan).Int("count", len(txs)).Msg("total tx returned")
+	return txs, nil
+}
+
+// getByAccount gathers the ready txs account by account.
+func (mp *MemPool) getByAccount(maxBlockBodySize uint32, blockNo types.BlockNo, ts int64) []types.Transaction {
 	size := 0
 	txs := make([]types.Transaction, 0)
 Gather:
 	for _, list := range mp.pool {
-		for _, tx := range list.Get() {
+		for _, tx := range executable(list.Get(), blockNo, ts) {
 			if size += proto.Size(tx.GetTx()); uint32(size) > maxBlockBodySize {
 				break Gather
 			}
 			txs = append(txs, tx)
-			count++
 		}
 	}
-	elapsed := time.Since(start)
-	mp.Debug().Str("elapsed", elapsed.String()).Int("len", mp.length).Int("orphan", mp.orphan).Int("count", count).Msg("total tx returned")
-	return txs, nil
+	return txs
 }
 
 // check existence.
@@ -366,6 +383,14 @@ func (mp *MemPool) put(tx types.Transaction) error {
 	}
 	defer mp.releaseMemPoolList(list)
 	diff, err := list.Put(tx)
+	if err == types.ErrSameNonceAlreadyInMempool && mp.cfg.Mempool.EnableReplace {
+		var replaced types.Transaction
+		if replaced, err = list.Replace(tx, mp.cfg.Mempool.ReplaceBump); err == nil {
+			mp.cache.Delete(types.ToTxID(replaced.GetHash()))
+			mp.length--
+			mp.Debug().Str("old", enc.ToString(replaced.GetHash())).Str("new", enc.ToString(tx.GetHash())).Msg("tx replaced")
+		}
+	}
 	if err != nil {
 		mp.Error().Err(err).Msg("fail to put at a mempool list")
 		return err
@@ -549,26 +574,59 @@ func (mp *MemPool) verifyTx(tx types.Transaction) error {
 	if err != nil {
 		return err
 	}
-	if !tx.GetTx().NeedNameVerify() {
-		err = key.VerifyTx(tx.GetTx())
-		if err != nil {
-			return err
-		}
-	} else {
+	address := tx.GetBody().GetAccount()
+	if tx.GetTx().NeedNameVerify() {
 		mp.RLock()
-		account := mp.getAddress(tx.GetBody().GetAccount())
+		address = mp.getAddress(address)
 		mp.RUnlock()
-		err = key.VerifyTxWithAddress(tx.GetTx(), account)
-		if err != nil {
-			return err
-		}
-		if !tx.SetVerifedAccount(account) {
-			mp.Warn().Str("account", string(account)).Msg("could not set verified account")
-		}
+	}
+	mp.RLock()
+	multisig := mp.getMultisig(address)
+	isContract := mp.isContract(address)
+	mp.RUnlock()
+	switch {
+	case multisig != nil:
+		err = key.VerifyTxWithMultisig(tx.GetTx(), multisig)
+	case isContract:
+		// a contract account checks its txs by itself in validateTx
+	case tx.GetTx().NeedNameVerify():
+		err = key.VerifyTxWithAddress(tx.GetTx(), address)
+	default:
+		err = key.VerifyTx(tx.GetTx())
+	}
+	if err != nil {
+		return err
+	}
+	if tx.GetTx().NeedNameVerify() && !tx.SetVerifedAccount(address) {
+		mp.Warn().Str("account", string(address)).Msg("could not set verified account")
 	}
 	return nil
 }
 
+// getMultisig returns the signer set of the multisig account, or nil if
+// address is not a multisig account.
+func (mp *MemPool) getMultisig(address []byte) *types.Multisig {
+	if mp.testConfig || len(address) != types.AddressLength {
+		return nil
+	}
+	systemState, err := mp.getAccountState([]byte(types.AergoSystem))
+	if err != nil {
+		mp.Error().Msgf("failed to get state %s", types.AergoSystem)
+		return nil
+	}
+	scs, err := mp.stateDB.OpenContractState(types.ToAccountID([]byte(types.AergoSystem)), systemState)
+	if err != nil {
+		mp.Error().Msgf("failed to open contract %s", types.AergoSystem)
+		return nil
+	}
+	multisig, err := system.GetMultisig(scs, address)
+	if err != nil {
+		mp.Error().Err(err).Str("account", types.EncodeAddress(address)).Msg("failed to get multisig")
+		return nil
+	}
+	return multisig
+}
+
 func (mp *MemPool) getAddress(account []byte) []byte {
 	return mp.getNameDest(account, false)
 }
@@ -602,6 +660,38 @@ func (mp *MemPool) getNameDest(account []byte, owner bool) []byte {
 	return name.GetAddress(scs, account)
 }
 
+// isContract returns true if address is a contract, which may be the sender
+// of txs as a contract account.
+func (mp *MemPool) isContract(address []byte) bool {
+	if len(address) == 0 {
+		return false
+	}
+	st, err := mp.getAccountState(address)
+	if err != nil {
+		return false
+	}
+	return len(st.GetCodeHash()) > 0
+}
+
+// checkContractAccount requests to run the check_account function of the
+// contract account sending tx.
+func (mp *MemPool) checkContractAccount(tx types.Transaction, account []byte) error {
+	if mp.nextBlockVersion() < 3 {
+		return types.ErrSignNotMatch
+	}
+	if mp.testConfig {
+		return getContractAccountMock(types.ToAccountID(account).String())(tx.GetTx())
+	}
+	rsp, err := mp.RequestToFuture(message.ChainSvc,
+		&message.CheckContractAccount{Contract: account, Tx: tx.GetTx()},
+		time.Second).Result()
+	if err != nil {
+		mp.Error().Err(err).Msg("failed to checkContractAccount")
+		return err
+	}
+	return rsp.(message.CheckContractAccountRsp).Err
+}
+
 func (mp *MemPool) nextBlockVersion() int32 {
 	return mp.cfg.Hardfork.Version(mp.bestBlockInfo.No + 1)
 }
@@ -614,6 +704,12 @@ func (mp *MemPool) validateTx(tx types.Transaction, account types.Address) error
 	if !mp.whitelist.Check(types.EncodeAddress(account)) {
 		return types.ErrTxNotAllowedAccount
 	}
+	if err := tx.GetBody().ValidateTimeLock(mp.nextBlockVersion()); err != nil {
+		return err
+	}
+	if tx.GetBody().IsExpired(mp.nextBlockTime()) {
+		return types.ErrTxExpired
+	}
 	ns, err := mp.getAccountState(account)
 	if err != nil {
 		return err
@@ -622,6 +718,11 @@ func (mp *MemPool) validateTx(tx types.Transaction, account types.Address) error
 	if err != nil && err != types.ErrTxNonceToohigh {
 		return err
 	}
+	if len(ns.GetCodeHash()) > 0 {
+		if cErr := mp.checkContractAccount(tx, account); cErr != nil {
+			return cErr
+		}
+	}
 
 	//NOTE: don't overwrite err, if err == ErrTxNonceToohigh
 	//because err should be ErrNonceToohigh if following validation has passed
@@ -804,7 +905,11 @@ func (mp *MemPool) getAccountState(acc []byte) (*types.State, error) {
 		bal := getBalanceByAccMock(strAcc)
 		nonce := getNonceByAccMock(strAcc)
 		//mp.Error().Str("acc:", strAcc).Int("nonce", int(nonce)).Msg("")
-		return &types.State{Balance: new(big.Int).SetUint64(bal).Bytes(), Nonce: nonce}, nil
+		st := &types.State{Balance: new(big.Int).SetUint64(bal).Bytes(), Nonce: nonce}
+		if getContractAccountMock(strAcc) != nil {
+			st.CodeHash = aid[:]
+		}
+		return st, nil
 	}
 
 	state, err := mp.stateDB.GetAccountState(types.ToAccountID(acc))
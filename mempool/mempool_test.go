@@ -6,6 +6,7 @@ package mempool
 
 import (
 	"encoding/binary"
+	"math"
 	"math/big"
 	"math/rand"
 	"os"
@@ -17,6 +18,8 @@ import (
 
 	crypto "github.com/aergoio/aergo/account/key/crypto"
 	"github.com/aergoio/aergo/config"
+	"github.com/aergoio/aergo/contract"
+	"github.com/aergoio/aergo/contract/system"
 	"github.com/aergoio/aergo/types"
 	"github.com/btcsuite/btcd/btcec"
 	"github.com/stretchr/testify/assert"
@@ -136,6 +139,21 @@ func genTx(acc int, rec int, nonce uint64, amount uint64) types.Transaction {
 	return types.NewTransaction(&tx)
 }
 
+func genPricedTx(acc int, rec int, nonce uint64, amount uint64, price uint64) types.Transaction {
+	tx := genTx(acc, rec, nonce, amount).GetTx()
+	tx.Body.GasPrice = new(big.Int).SetUint64(price).Bytes()
+	tx.Hash = tx.CalculateTxHash()
+	return types.NewTransaction(tx)
+}
+
+func genTimeLockedTx(acc int, rec int, nonce uint64, after uint64, before uint64) types.Transaction {
+	tx := genTx(acc, rec, nonce, 0).GetTx()
+	tx.Body.ValidAfter = after
+	tx.Body.ValidBefore = before
+	tx.Hash = tx.CalculateTxHash()
+	return types.NewTransaction(tx)
+}
+
 /*
 func TestTxSize(t *testing.T) {
 	initTest(t)
@@ -641,6 +659,149 @@ func TestRemoveTx(t *testing.T) {
 	assert.Equal(t, 3, pool.orphan, "orphan")
 }
 
+func TestReplaceTx(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+
+	base := system.GetGasPrice().Uint64()
+	old := genPricedTx(0, 0, 1, 0, base+100)
+	assert.NoError(t, pool.put(old), "put")
+	assert.NoError(t, pool.put(genTx(0, 0, 3, 0)), "put")
+	assert.Equal(t, 1, pool.orphan, "orphan")
+
+	err := pool.put(genPricedTx(0, 1, 1, 0, base+109))
+	assert.Equal(t, types.ErrTxReplaceUnderpriced, err, "underpriced")
+
+	replacing := genPricedTx(0, 1, 1, 0, base+110)
+	assert.NoError(t, pool.put(replacing), "replace")
+	assert.Equal(t, 2, pool.length, "length")
+	assert.Equal(t, 1, pool.orphan, "orphan")
+	assert.Nil(t, pool.exist(old.GetHash()), "replaced tx")
+	assert.NotNil(t, pool.exist(replacing.GetHash()), "replacing tx")
+
+	pool.cfg.Mempool.EnableReplace = false
+	err = pool.put(genPricedTx(0, 0, 1, 0, base+1000))
+	assert.Equal(t, types.ErrSameNonceAlreadyInMempool, err, "replace disabled")
+	pool.cfg.Mempool.EnableReplace = true
+
+	hardfork := *pool.cfg.Hardfork
+	hardfork.V3 = math.MaxUint64
+	pool.cfg.Hardfork = &hardfork
+	err = pool.put(genPricedTx(0, 0, 1, 0, base+1000))
+	assert.Equal(t, types.ErrTxReplaceUnderpriced, err, "no tip before the hardfork")
+}
+
+func TestContractAccountLoop(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+
+	bc, err := contract.LoadDummyChain()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer bc.Release()
+	err = bc.ConnectBlock(
+		contract.NewLuaTxAccount("ktlee", 100000000000000000),
+		contract.NewLuaTxDef("ktlee", "wallet", 0, `
+function check_account(hash, sign, recipient, amount, payload)
+	while true do end
+end
+abi.register_view(check_account)`),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lock.Lock()
+	contractAccount[types.ToAccountID(accs[0]).String()] = func(tx *types.Tx) error {
+		_, err := bc.CheckContractAccount("wallet", tx)
+		return err
+	}
+	lock.Unlock()
+
+	err = pool.put(genTx(0, 1, 1, 0))
+	if assert.Error(t, err, "looping check_account") {
+		assert.Contains(t, err.Error(), "exceeded the maximum instruction count")
+	}
+	assert.Equal(t, 0, pool.length, "length")
+}
+
+func TestGetByTip(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+
+	base := system.GetGasPrice().Uint64()
+	txs := []types.Transaction{
+		genPricedTx(1, 0, 1, 0, base+50),
+		genPricedTx(0, 0, 1, 0, base+1),
+		genPricedTx(0, 0, 2, 0, base+100),
+		genPricedTx(2, 0, 1, 0, 0),
+	}
+	for _, tx := range txs {
+		assert.NoError(t, pool.put(tx), "put")
+	}
+
+	ret, err := pool.get(maxBlockBodySize)
+	assert.NoError(t, err, "get")
+	if assert.Equal(t, len(txs), len(ret), "count") {
+		for i, tx := range txs {
+			assert.True(t, sameTx(tx.GetTx(), ret[i].GetTx()), "order at %d", i)
+		}
+	}
+
+	stat := pool.tipStat()
+	assert.Equal(t, uint64(4), stat.Count, "count")
+	assert.Equal(t, uint64(3), stat.Tipped, "tipped")
+	assert.Equal(t, uint64(0), new(big.Int).SetBytes(stat.Tips[0]).Uint64(), "min")
+	assert.Equal(t, uint64(100), new(big.Int).SetBytes(stat.Tips[len(stat.Tips)-1]).Uint64(), "max")
+
+	hardfork := *pool.cfg.Hardfork
+	hardfork.V3 = math.MaxUint64
+	pool.cfg.Hardfork = &hardfork
+	assert.Equal(t, uint64(0), pool.tipStat().Tipped, "tipped before the hardfork")
+}
+
+func TestTimeLockedTx(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+
+	hardfork := *pool.cfg.Hardfork
+	hardfork.V4 = 0
+	pool.cfg.Hardfork = &hardfork
+
+	next := pool.bestBlockInfo.No + 1
+	txs := []types.Transaction{
+		genTx(0, 0, 1, 0),
+		genTimeLockedTx(0, 0, 2, next+10, 0),
+		genTx(0, 0, 3, 0),
+		genTimeLockedTx(1, 0, 1, 0, next+5),
+	}
+	for _, tx := range txs {
+		assert.NoError(t, pool.put(tx), "put")
+	}
+	assert.Equal(t, types.ErrTxExpired, pool.put(genTimeLockedTx(2, 0, 1, 0, next)), "expired")
+
+	l, o := pool.Size()
+	assert.Equal(t, 4, l, "len")
+	assert.Equal(t, 0, o, "time-locked txs are not orphans")
+
+	ret, err := pool.get(maxBlockBodySize)
+	assert.NoError(t, err, "get")
+	assert.True(t, sameTxs([]types.Transaction{txs[0], txs[3]}, ret), "pending txs are held")
+
+	pool.bestBlockInfo = &types.BlockHeaderInfo{No: next + 9}
+	ret, _ = pool.get(maxBlockBodySize)
+	assert.True(t, sameTxs(txs[:3], ret), "the expired tx is not returned")
+
+	pool.evictExpiredTxs()
+	l, o = pool.Size()
+	assert.Equal(t, 3, l, "len after eviction")
+	assert.Equal(t, 0, o, "orphan after eviction")
+
+	hardfork.V4 = math.MaxUint64
+	assert.Equal(t, types.ErrTxInvalidTimeLock, pool.put(genTimeLockedTx(2, 0, 1, next+20, 0)), "before the hardfork")
+}
+
 func TestMemPool_GetAddress(t *testing.T) {
 	t.Skip("skip test since underlying env is not capable to test this single method")
 	initTest(t)
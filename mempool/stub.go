@@ -60,6 +60,8 @@ var (
 	balance       = map[string]uint64{}
 	nonce         = map[string]uint64{}
 	bestBlockInfo = &types.BlockHeaderInfo{No: 1}
+	// contractAccount maps the contract accounts to their check_account
+	contractAccount = map[string]func(tx *types.Tx) error{}
 )
 
 func initStubData() {
@@ -68,7 +70,7 @@ func initStubData() {
 	balance = map[string]uint64{}
 	nonce = map[string]uint64{}
 	bestBlockInfo = &types.BlockHeaderInfo{No: 1}
-
+	contractAccount = map[string]func(tx *types.Tx) error{}
 }
 func getNonceByAccMock(acc string) uint64 {
 	lock.Lock()
@@ -89,6 +91,12 @@ func getBalanceByAccMock(acc string) uint64 {
 	return balance[acc]
 }
 
+func getContractAccountMock(acc string) func(tx *types.Tx) error {
+	lock.Lock()
+	defer lock.Unlock()
+	return contractAccount[acc]
+}
+
 func getCurrentBestBlockNoMock() types.BlockID {
 	return types.ToBlockID(nil)
 }
@@ -0,0 +1,65 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package mempool
+
+import (
+	"time"
+
+	"github.com/aergoio/aergo/internal/enc"
+	"github.com/aergoio/aergo/types"
+)
+
+// executable returns the leading txs of ready which can be included in the
+// block of blockNo and ts. A tx whose time lock is not reached yet is held as
+// pending along with its successors, which keeps the nonce order of the
+// account, and it is neither an orphan nor evicted until it expires.
+func executable(ready []types.Transaction, blockNo types.BlockNo, ts int64) []types.Transaction {
+	for i, tx := range ready {
+		if tx.GetBody().CheckTimeLock(blockNo, ts) != nil {
+			return ready[:i]
+		}
+	}
+	return ready
+}
+
+// nextBlockTime returns the block number and the timestamp which the time
+// locks of the txs are checked against for the next block.
+func (mp *MemPool) nextBlockTime() (types.BlockNo, int64) {
+	return mp.bestBlockInfo.No + 1, time.Now().UnixNano()
+}
+
+// evictExpiredTxs removes the txs which can't be included in any block since
+// their time locks expired. The successors of an expired tx become orphans.
+func (mp *MemPool) evictExpiredTxs() {
+	mp.Lock()
+	defer mp.Unlock()
+
+	blockNo, ts := mp.nextBlockTime()
+	total := 0
+	for _, list := range mp.pool {
+		var expired []types.Transaction
+		for _, tx := range list.GetAll() {
+			if tx.GetBody().IsExpired(blockNo, ts) {
+				expired = append(expired, tx)
+			}
+		}
+		for _, tx := range expired {
+			newOrphan, removed := list.RemoveTx(tx.GetTx())
+			if removed == nil {
+				continue
+			}
+			mp.orphan += newOrphan
+			mp.cache.Delete(types.ToTxID(tx.GetHash()))
+			mp.length--
+			total++
+			mp.Debug().Str("txhash", enc.ToString(tx.GetHash())).Msg("evict expired tx")
+		}
+		mp.releaseMemPoolList(list)
+	}
+	if total > 0 {
+		mp.Info().Int("num", total).Msg("evict expired transactions")
+	}
+}
@@ -0,0 +1,92 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package mempool
+
+import (
+	"container/heap"
+	"math/big"
+	"sort"
+
+	"github.com/aergoio/aergo/contract/system"
+	"github.com/aergoio/aergo/types"
+	"github.com/golang/protobuf/proto"
+)
+
+// tipQueue is a max heap of the ready txs of accounts ordered by the tip of
+// the first tx of each account.
+type tipQueue []*tipEntry
+
+type tipEntry struct {
+	txs []types.Transaction
+	tip *big.Int
+}
+
+func (q tipQueue) Len() int           { return len(q) }
+func (q tipQueue) Less(i, j int) bool { return q[i].tip.Cmp(q[j].tip) > 0 }
+func (q tipQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+
+func (q *tipQueue) Push(x interface{}) {
+	*q = append(*q, x.(*tipEntry))
+}
+
+func (q *tipQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	e := old[n-1]
+	*q = old[:n-1]
+	return e
+}
+
+// getByTip gathers the ready txs in the descending order of their tips. The
+// txs of an account keep the nonce order, so a tx competes with the txs of
+// the other accounts only after all its predecessors are picked.
+func (mp *MemPool) getByTip(maxBlockBodySize uint32, gasPrice *big.Int, version int32,
+	blockNo types.BlockNo, ts int64) []types.Transaction {
+	q := make(tipQueue, 0, len(mp.pool))
+	for _, list := range mp.pool {
+		if ready := executable(list.Get(), blockNo, ts); len(ready) > 0 {
+			q = append(q, &tipEntry{txs: ready, tip: ready[0].GetBody().GetTipPrice(gasPrice, version)})
+		}
+	}
+	heap.Init(&q)
+
+	size := 0
+	txs := make([]types.Transaction, 0)
+	for q.Len() > 0 {
+		e := q[0]
+		tx := e.txs[0]
+		if size += proto.Size(tx.GetTx()); uint32(size) > maxBlockBodySize {
+			break
+		}
+		txs = append(txs, tx)
+		if e.txs = e.txs[1:]; len(e.txs) > 0 {
+			e.tip = e.txs[0].GetBody().GetTipPrice(gasPrice, version)
+			heap.Fix(&q, 0)
+		} else {
+			heap.Pop(&q)
+		}
+	}
+	return txs
+}
+
+// tipStat returns the distribution of the tips of the ready txs for the next
+// block.
+func (mp *MemPool) tipStat() *types.TipStat {
+	mp.RLock()
+	defer mp.RUnlock()
+
+	gasPrice := system.GetGasPrice()
+	version := mp.nextBlockVersion()
+	blockNo, ts := mp.nextBlockTime()
+	var tips []*big.Int
+	for _, list := range mp.pool {
+		for _, tx := range executable(list.Get(), blockNo, ts) {
+			tips = append(tips, tx.GetBody().GetTipPrice(gasPrice, version))
+		}
+	}
+	sort.Slice(tips, func(i, j int) bool { return tips[i].Cmp(tips[j]) < 0 })
+	return types.NewTipStat(version, gasPrice, tips)
+}
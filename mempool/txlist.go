@@ -7,6 +7,7 @@ package mempool
 
 import (
 	"bytes"
+	"math/big"
 	"sort"
 	"sync"
 	"time"
@@ -126,6 +127,39 @@ func (tl *txList) Put(tx types.Transaction) (int, error) {
 	return oldCnt - newCnt, nil
 }
 
+// Replace swaps the transaction which has the same nonce as tx for tx. tx
+// must offer a tip higher than the one of the pooled transaction by at least
+// bump percent. Since the tip is not paid before the hardfork version 3, no
+// transaction is replaced until then. It returns the replaced transaction.
+func (tl *txList) Replace(tx types.Transaction, bump uint32) (types.Transaction, error) {
+	tl.Lock()
+	defer tl.Unlock()
+
+	index, found := tl.search(tx)
+	if !found {
+		return nil, types.ErrTxNotFound
+	}
+	old := tl.list[index]
+	if !replaceable(old, tx, bump, system.GetGasPrice(), tl.mp.nextBlockVersion()) {
+		return nil, types.ErrTxReplaceUnderpriced
+	}
+	tl.list[index] = tx
+
+	tl.lastTime = time.Now()
+	return old, nil
+}
+
+func replaceable(old, tx types.Transaction, bump uint32, gasPrice *big.Int, version int32) bool {
+	oldTip := old.GetBody().GetTipPrice(gasPrice, version)
+	newTip := tx.GetBody().GetTipPrice(gasPrice, version)
+	if newTip.Cmp(oldTip) <= 0 {
+		return false
+	}
+	// newTip >= oldTip * (100 + bump) / 100
+	minTip := new(big.Int).Mul(oldTip, big.NewInt(100+int64(bump)))
+	return new(big.Int).Mul(newTip, big.NewInt(100)).Cmp(minTip) >= 0
+}
+
 func (tl *txList) FilterByState(st *types.State) (int, []types.Transaction) {
 	tl.Lock()
 	defer tl.Unlock()
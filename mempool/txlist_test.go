@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/aergoio/aergo/config"
+	"github.com/aergoio/aergo/contract/system"
 
 	"github.com/aergoio/aergo/fee"
 	"github.com/aergoio/aergo/types"
@@ -248,3 +249,33 @@ func TestListRemoveTx(t *testing.T) {
 	assert.Equal(t, nine.GetTx().GetHash(), tx.GetHash(), "removed tx")
 	mpl.Put(six)
 }
+
+func TestListReplace(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+	mpl := newTxList(nil, NewState(0, 0), dummyMempool)
+
+	base := system.GetGasPrice().Uint64()
+	one := genPricedTx(0, 0, uint64(1), 0, 0)
+	two := genPricedTx(0, 0, uint64(2), 0, base+1000)
+	mpl.Put(one)
+	mpl.Put(two)
+
+	_, err := mpl.Replace(genPricedTx(0, 1, uint64(3), 0, base+1000), 10)
+	assert.Equal(t, types.ErrTxNotFound, err, "no tx to replace")
+	_, err = mpl.Replace(genPricedTx(0, 1, uint64(1), 0, base), 10)
+	assert.Equal(t, types.ErrTxReplaceUnderpriced, err, "no tip")
+	_, err = mpl.Replace(genPricedTx(0, 1, uint64(2), 0, base+1099), 10)
+	assert.Equal(t, types.ErrTxReplaceUnderpriced, err, "bump not enough")
+
+	newTwo := genPricedTx(0, 1, uint64(2), 0, base+1100)
+	replaced, err := mpl.Replace(newTwo, 10)
+	assert.NoError(t, err, "replace")
+	assert.Equal(t, two.GetHash(), replaced.GetHash(), "replaced tx")
+	assert.Equal(t, 2, mpl.Len(), "ready")
+	assert.Equal(t, newTwo.GetHash(), mpl.Get()[1].GetHash(), "replacing tx")
+
+	newOne := genPricedTx(0, 1, uint64(1), 0, base+1)
+	_, err = mpl.Replace(newOne, 10)
+	assert.NoError(t, err, "any tip replaces a tx without tip")
+}
@@ -46,8 +46,15 @@ type AddBlockRsp struct {
 	BlockHash []byte
 	Err       error
 }
+
+// GetState is a request for the state of an account. The state at the block
+// of BlockHash or BlockNo is returned if either of them is set, otherwise the
+// state at the best block is returned. GetABI, GetQuery, GetStaking and
+// GetNameInfo specify the block in the same way.
 type GetState struct {
-	Account []byte
+	Account   []byte
+	BlockNo   types.BlockNo
+	BlockHash []byte
 }
 type GetStateRsp struct {
 	Account []byte
@@ -80,22 +87,80 @@ type GetReceiptRsp struct {
 	Err     error
 }
 
+type GetTxProof struct {
+	TxHash []byte
+}
+type GetTxProofRsp struct {
+	Proof *types.TxProof
+	Err   error
+}
+
+type GetReceiptProof struct {
+	TxHash []byte
+}
+type GetReceiptProofRsp struct {
+	Proof *types.ReceiptProof
+	Err   error
+}
+
 type GetABI struct {
-	Contract []byte
+	Contract  []byte
+	BlockNo   types.BlockNo
+	BlockHash []byte
 }
 type GetABIRsp struct {
 	ABI *types.ABI
 	Err error
 }
 
+// GetContractVersions is a request for the code versions of a contract.
+type GetContractVersions struct {
+	Contract  []byte
+	BlockNo   types.BlockNo
+	BlockHash []byte
+}
+type GetContractVersionsRsp struct {
+	Versions *types.ContractVersionList
+	Err      error
+}
+
 type GetQuery struct {
 	Contract  []byte
 	Queryinfo []byte
+	BlockNo   types.BlockNo
+	BlockHash []byte
 }
 type GetQueryRsp struct {
 	Result []byte
 	Err    error
 }
+
+// SimulateTx executes Tx against the state of the best block as if it were
+// included in the next block. Nothing is written to the chain.
+type SimulateTx struct {
+	Tx *types.Tx
+}
+type SimulateTxRsp struct {
+	Receipt *types.Receipt
+	Err     error
+}
+
+// ReadStateSnapshot reads a part of the state snapshot requested by a peer.
+type ReadStateSnapshot struct {
+	Req *types.GetStateSnapshotRequest
+}
+type ReadStateSnapshotRsp struct {
+	Rsp *types.GetStateSnapshotResponse
+}
+
+// InstallSnapshot makes Block, whose state is imported from a snapshot, the
+// best block of the chain.
+type InstallSnapshot struct {
+	Block *types.Block
+}
+type InstallSnapshotRsp struct {
+	Err error
+}
 type GetStateQuery struct {
 	ContractAddress []byte
 	StorageKeys     [][]byte
@@ -135,7 +200,9 @@ type GetAccountVoteRsp struct {
 }
 
 type GetStaking struct {
-	Addr []byte
+	Addr      []byte
+	BlockNo   types.BlockNo
+	BlockHash []byte
 }
 
 type GetStakingRsp struct {
@@ -144,8 +211,9 @@ type GetStakingRsp struct {
 }
 
 type GetNameInfo struct {
-	Name    string
-	BlockNo types.BlockNo
+	Name      string
+	BlockNo   types.BlockNo
+	BlockHash []byte
 }
 
 type GetNameInfoRsp struct {
@@ -216,3 +284,14 @@ type CheckFeeDelegation struct {
 type CheckFeeDelegationRsp struct {
 	Err error
 }
+
+// CheckContractAccount requests to run the check_account function of the
+// contract account sending Tx.
+type CheckContractAccount struct {
+	Contract []byte
+	Tx       *types.Tx
+}
+
+type CheckContractAccountRsp struct {
+	Err error
+}
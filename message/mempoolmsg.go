@@ -93,6 +93,16 @@ type MemPoolTx struct {
 
 type MemPoolTxRsp MemPoolTxStatRsp
 
+// MemPoolTipStat is interface of MemPool service for retrieving the
+// distribution of the priority tips of the ready transactions
+type MemPoolTipStat struct {
+}
+
+// MemPoolTipStatRsp defines struct of result for MemPoolTipStat
+type MemPoolTipStatRsp struct {
+	Stat *types.TipStat
+}
+
 // MemPoolDelRsp defines struct of result for MemPoolDel
 type MemPoolDelRsp struct {
 	Err error
@@ -184,6 +184,19 @@ type GetHashByNoRsp struct {
 	Err       error
 }
 
+// GetStateSnapshot requests a part of a state snapshot to the peer ToWhom.
+// The response is sent to ReplyC, which must be buffered.
+type GetStateSnapshot struct {
+	ToWhom types.PeerID
+	Req    *types.GetStateSnapshotRequest
+	ReplyC chan *GetStateSnapshotRsp
+}
+
+type GetStateSnapshotRsp struct {
+	Rsp *types.GetStateSnapshotResponse
+	Err error
+}
+
 type GetSelf struct {
 }
 
@@ -224,6 +237,11 @@ type SendRaftRsp struct {
 	Err error
 }
 
+// SendBFT broadcasts a message of the BFT consensus to the connected peers.
+type SendBFT struct {
+	Body *types.BFTMessage
+}
+
 type P2PWhiteListConfEnableEvent struct {
 	Name string
 	On   bool
@@ -19,6 +19,11 @@ type FinderResult struct {
 	Err      error
 }
 
+type SnapshotResult struct {
+	Seq   uint64
+	Block *types.Block
+}
+
 //HashDownloader
 type SyncStop struct {
 	Seq     uint64
@@ -122,6 +122,20 @@ func (p2ps *P2P) GetBlockHashByNo(context actor.Context, msg *message.GetHashByN
 	receiver.StartGet()
 }
 
+// GetStateSnapshot sends a request of a part of a state snapshot to the peer.
+func (p2ps *P2P) GetStateSnapshot(context actor.Context, msg *message.GetStateSnapshot) {
+	peerID := msg.ToWhom
+
+	remotePeer, exists := p2ps.pm.GetPeer(peerID)
+	if !exists {
+		p2ps.Warn().Str(p2putil.LogPeerID, p2putil.ShortForm(peerID)).Str(p2putil.LogProtoID, p2pcommon.GetStateSnapshotRequest.String()).Msg("Invalid peerID")
+		msg.ReplyC <- &message.GetStateSnapshotRsp{Err: message.PeerNotFoundError}
+		return
+	}
+	receiver := NewStateSnapshotReceiver(remotePeer, msg, fetchTimeOut)
+	receiver.StartGet()
+}
+
 // NotifyNewBlock send notice message of new block to a peer
 func (p2ps *P2P) NotifyNewBlock(blockNotice message.NotifyNewBlock) bool {
 	req := &types.NewBlockNotice{
@@ -255,6 +269,16 @@ func (p2ps *P2P) SendRaftMessage(context actor.Context, msg *message.SendRaft) {
 	// return success
 }
 
+// SendBFTMessage broadcasts a message of the BFT consensus to the running
+// peers. The validators are expected to be connected to each other.
+func (p2ps *P2P) SendBFTMessage(context actor.Context, msg *message.SendBFT) {
+	mo := p2ps.mf.NewMsgRequestOrder(false, p2pcommon.BFTMessage, msg.Body)
+	for _, neighbor := range p2ps.pm.GetPeers() {
+		if neighbor != nil && neighbor.State() == types.RUNNING {
+			neighbor.SendMessage(mo)
+		}
+	}
+}
 
 func (p2ps *P2P) SendIssueCertMessage(context actor.Context, msg message.IssueAgentCertificate) {
 	peerID := msg.ProducerID
@@ -9,6 +9,7 @@ import (
 	"bytes"
 	"fmt"
 	"github.com/aergoio/aergo-lib/log"
+	"github.com/aergoio/aergo/internal/metrics"
 	"github.com/aergoio/aergo/p2p/p2putil"
 	"github.com/aergoio/aergo/types"
 	"sync"
@@ -82,6 +83,7 @@ func (mm *metricsManager) NewMetric(pid types.PeerID, manNum uint32) *PeerMetric
 	}
 	peerMetric := &PeerMetric{mm: mm, PeerID: pid, seq: manNum, InMetric:NewExponentMetric5(mm.interval), OutMetric:NewExponentMetric5(mm.interval), Since:time.Now()}
 	mm.metricsMap[pid] = peerMetric
+	metrics.P2PPeers.Set(float64(len(mm.metricsMap)))
 	return peerMetric
 }
 
@@ -98,6 +100,7 @@ func (mm *metricsManager) Remove(pid types.PeerID, manNum uint32) *PeerMetric {
 		atomic.AddInt64(&mm.deadTotalIn, metric.totalIn)
 		atomic.AddInt64(&mm.deadTotalOut, metric.totalOut)
 		delete(mm.metricsMap, pid)
+		metrics.P2PPeers.Set(float64(len(mm.metricsMap)))
 		return metric
 	}
 }
@@ -6,6 +6,7 @@
 package metric
 
 import (
+	"github.com/aergoio/aergo/internal/metrics"
 	"github.com/aergoio/aergo/p2p/p2pcommon"
 	"github.com/aergoio/aergo/types"
 	"sync/atomic"
@@ -30,11 +31,13 @@ var _ p2pcommon.MsgIOListener = (*PeerMetric)(nil)
 func (m *PeerMetric) OnRead(protocol p2pcommon.SubProtocol, read int) {
 	atomic.AddInt64(&m.totalIn, int64(read))
 	m.InMetric.AddBytes(read)
+	metrics.P2PReceivedBytes.Add(float64(read))
 }
 
 func (m *PeerMetric) OnWrite(protocol p2pcommon.SubProtocol, write int) {
 	atomic.AddInt64(&m.totalOut, int64(write))
 	m.OutMetric.AddBytes(write)
+	metrics.P2PSentBytes.Add(float64(write))
 }
 
 func (m *PeerMetric) TotalIn() int64 {
@@ -256,6 +256,8 @@ func (p2ps *P2P) Receive(context actor.Context) {
 		p2ps.GetBlockHashes(context, msg)
 	case *message.GetHashByNo:
 		p2ps.GetBlockHashByNo(context, msg)
+	case *message.GetStateSnapshot:
+		p2ps.GetStateSnapshot(context, msg)
 	case *message.NotifyNewBlock:
 		if msg.Produced {
 			p2ps.NotifyBlockProduced(*msg)
@@ -297,6 +299,8 @@ func (p2ps *P2P) Receive(context actor.Context) {
 		clusterReceiver.StartGet()
 	case *message.SendRaft:
 		p2ps.SendRaftMessage(context, msg)
+	case *message.SendBFT:
+		p2ps.SendBFTMessage(context, msg)
 	case *message.RaftClusterEvent:
 		p2ps.Logger.Debug().Array("added", p2putil.NewLogPeerIdsMarshaller(msg.BPAdded, 10)).Array("removed", p2putil.NewLogPeerIdsMarshaller(msg.BPRemoved, 10)).Msg("bp changed")
 		p2ps.prm.UpdateBP(msg.BPAdded, msg.BPRemoved)
@@ -422,6 +426,8 @@ func (p2ps *P2P) insertHandlers(peer p2pcommon.RemotePeer) {
 	peer.AddMessageHandler(p2pcommon.GetHashesResponse, subproto.NewGetHashesRespHandler(p2ps.pm, peer, logger, p2ps))
 	peer.AddMessageHandler(p2pcommon.GetHashByNoRequest, subproto.NewGetHashByNoReqHandler(p2ps.pm, peer, logger, p2ps))
 	peer.AddMessageHandler(p2pcommon.GetHashByNoResponse, subproto.NewGetHashByNoRespHandler(p2ps.pm, peer, logger, p2ps))
+	peer.AddMessageHandler(p2pcommon.GetStateSnapshotRequest, subproto.NewStateSnapshotReqHandler(p2ps.pm, peer, logger, p2ps))
+	peer.AddMessageHandler(p2pcommon.GetStateSnapshotResponse, subproto.NewStateSnapshotRespHandler(p2ps.pm, peer, logger, p2ps))
 
 	// TxHandlers
 	peer.AddMessageHandler(p2pcommon.GetTXsRequest, subproto.WithTimeLog(subproto.NewTxReqHandler(p2ps.pm, p2ps.sm, peer, logger, p2ps), p2ps.Logger, zerolog.DebugLevel))
@@ -445,6 +451,9 @@ func (p2ps *P2P) insertHandlers(peer p2pcommon.RemotePeer) {
 	peer.AddMessageHandler(p2pcommon.GetClusterResponse, subproto.NewGetClusterRespHandler(p2ps.pm, peer, logger, p2ps))
 	peer.AddMessageHandler(p2pcommon.RaftWrapperMessage, subproto.NewRaftWrapperHandler(p2ps.pm, peer, logger, p2ps, p2ps.consacc))
 
+	// BFT support
+	peer.AddMessageHandler(p2pcommon.BFTMessage, subproto.NewBFTMessageHandler(p2ps.pm, peer, logger, p2ps, p2ps.consacc))
+
 	// certificate
 	peer.AddMessageHandler(p2pcommon.IssueCertificateRequest, subproto.NewIssueCertReqHandler(p2ps.pm, p2ps.cm, peer, logger, p2ps))
 	peer.AddMessageHandler(p2pcommon.IssueCertificateResponse, subproto.NewIssueCertRespHandler(p2ps.pm, p2ps.cm, peer, logger, p2ps))
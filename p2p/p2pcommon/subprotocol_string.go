@@ -10,7 +10,9 @@ const (
 	_SubProtocol_name_2 = "NewBlockNoticeGetAncestorRequestGetAncestorResponseGetHashesRequestGetHashesResponseGetHashByNoRequestGetHashByNoResponse"
 	_SubProtocol_name_3 = "GetTXsRequestGetTXsResponseNewTxNotice"
 	_SubProtocol_name_4 = "BlockProducedNotice"
-	_SubProtocol_name_5 = "GetClusterRequestGetClusterResponseRaftWrapperMessage"
+	_SubProtocol_name_5 = "GetStateSnapshotRequestGetStateSnapshotResponse"
+	_SubProtocol_name_6 = "GetClusterRequestGetClusterResponseRaftWrapperMessage"
+	_SubProtocol_name_7 = "BFTMessage"
 )
 
 var (
@@ -18,7 +20,8 @@ var (
 	_SubProtocol_index_1 = [...]uint8{0, 16, 33, 55, 78}
 	_SubProtocol_index_2 = [...]uint8{0, 14, 32, 51, 67, 84, 102, 121}
 	_SubProtocol_index_3 = [...]uint8{0, 13, 27, 38}
-	_SubProtocol_index_5 = [...]uint8{0, 17, 35, 53}
+	_SubProtocol_index_5 = [...]uint8{0, 23, 47}
+	_SubProtocol_index_6 = [...]uint8{0, 17, 35, 53}
 )
 
 func (i SubProtocol) String() string {
@@ -37,9 +40,14 @@ func (i SubProtocol) String() string {
 		return _SubProtocol_name_3[_SubProtocol_index_3[i]:_SubProtocol_index_3[i+1]]
 	case i == 48:
 		return _SubProtocol_name_4
+	case 64 <= i && i <= 65:
+		i -= 64
+		return _SubProtocol_name_5[_SubProtocol_index_5[i]:_SubProtocol_index_5[i+1]]
 	case 12545 <= i && i <= 12547:
 		i -= 12545
-		return _SubProtocol_name_5[_SubProtocol_index_5[i]:_SubProtocol_index_5[i+1]]
+		return _SubProtocol_name_6[_SubProtocol_index_6[i]:_SubProtocol_index_6[i+1]]
+	case i == 12800:
+		return _SubProtocol_name_7
 	default:
 		return "SubProtocol(" + strconv.FormatInt(int64(i), 10) + ")"
 	}
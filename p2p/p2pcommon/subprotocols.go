@@ -46,6 +46,12 @@ const (
 	BlockProducedNotice SubProtocol = 0x030 + iota
 )
 
+// subprotocols for the state snapshot sync
+const (
+	GetStateSnapshotRequest SubProtocol = 0x040 + iota
+	GetStateSnapshotResponse
+)
+
 const (
 	_ SubProtocol = 0x3100 + iota
 	GetClusterRequest
@@ -53,4 +59,8 @@ const (
 	RaftWrapperMessage  //
 )
 
+const (
+	BFTMessage SubProtocol = 0x3200 + iota
+)
+
 //go:generate stringer -type=SubProtocol
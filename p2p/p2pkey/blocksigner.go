@@ -0,0 +1,68 @@
+/*
+ * @file
+ * @copyright defined in aergo/LICENSE.txt
+ */
+
+package p2pkey
+
+import (
+	"errors"
+
+	"github.com/aergoio/aergo/account/key"
+	"github.com/aergoio/aergo/config"
+	"github.com/aergoio/aergo/internal/enc"
+	"github.com/aergoio/aergo/types"
+)
+
+// InitBlockSigner sets the signer of the blocks produced by the node. The
+// blocks are signed by the key in the remote signer if it's configured, so
+// the block producer is identified by that key instead of the node key.
+// Caution: this must be called after InitNodeInfo.
+func InitBlockSigner(cfg *config.ConsensusConfig) error {
+	if cfg.Signer == "" {
+		return nil
+	}
+	if cfg.SignerAddress == "" {
+		return errors.New("signeraddress is required to use the remote signer")
+	}
+	addr, err := types.DecodeAddress(cfg.SignerAddress)
+	if err != nil {
+		return err
+	}
+	rs, err := key.NewRemoteSigner(cfg.Signer, cfg.SignerCACert, cfg.SignerCert, cfg.SignerKey)
+	if err != nil {
+		return err
+	}
+	signer, err := rs.BlockSigner(addr)
+	if err != nil {
+		return err
+	}
+	id, err := types.IDFromPublicKey(signer.GetPublic())
+	if err != nil {
+		return err
+	}
+	ni.bpSigner = signer
+	ni.bpID = id
+	ni.bpSID = enc.ToString([]byte(id))
+	return nil
+}
+
+// BlockSigner returns the signer of the blocks produced by the node.
+func BlockSigner() types.BlockSigner {
+	return ni.bpSigner
+}
+
+// BlockProducerID returns the id of the block producer, which is the node id
+// unless the blocks are signed by the remote signer.
+func BlockProducerID() types.PeerID {
+	return ni.bpID
+}
+
+// BlockProducerSID returns the string representation of the block producer
+// id.
+func BlockProducerSID() string {
+	if ni == nil {
+		return ""
+	}
+	return ni.bpSID
+}
@@ -24,6 +24,10 @@ type nodeInfo struct {
 	pubKey  crypto.PubKey
 	privKey crypto.PrivKey
 
+	bpSigner types.BlockSigner
+	bpID     types.PeerID
+	bpSID    string
+
 	version   string
 	startTime time.Time
 
@@ -75,6 +79,9 @@ func InitNodeInfo(baseCfg *config.BaseConfig, p2pCfg *config.P2PConfig, version
 		sid:       enc.ToString([]byte(id)),
 		pubKey:    pub,
 		privKey:   priv,
+		bpSigner:  priv,
+		bpID:      id,
+		bpSID:     enc.ToString([]byte(id)),
 		version:   version,
 		startTime: time.Now(),
 	}
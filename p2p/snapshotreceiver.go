@@ -0,0 +1,66 @@
+/*
+ * @file
+ * @copyright defined in aergo/LICENSE.txt
+ */
+
+package p2p
+
+import (
+	"time"
+
+	"github.com/aergoio/aergo/message"
+	"github.com/aergoio/aergo/p2p/p2pcommon"
+	"github.com/aergoio/aergo/types"
+)
+
+// StateSnapshotReceiver sends a p2p getStateSnapshotRequest to the target peer and passes the response to the reply
+// channel of the actor message. It doesn't reply if the timeout expired.
+type StateSnapshotReceiver struct {
+	requestID p2pcommon.MsgID
+
+	peer p2pcommon.RemotePeer
+	req  *message.GetStateSnapshot
+
+	timeout  time.Time
+	finished bool
+}
+
+func NewStateSnapshotReceiver(peer p2pcommon.RemotePeer, req *message.GetStateSnapshot, ttl time.Duration) *StateSnapshotReceiver {
+	timeout := time.Now().Add(ttl)
+	return &StateSnapshotReceiver{peer: peer, req: req, timeout: timeout}
+}
+
+func (sr *StateSnapshotReceiver) StartGet() {
+	mo := sr.peer.MF().NewMsgRequestOrderWithReceiver(sr.ReceiveResp, p2pcommon.GetStateSnapshotRequest, sr.req.Req)
+	sr.requestID = mo.GetMsgID()
+	sr.peer.SendMessage(mo)
+}
+
+// ReceiveResp must be called just in read go routine
+func (sr *StateSnapshotReceiver) ReceiveResp(msg p2pcommon.Message, msgBody p2pcommon.MessageBody) (ret bool) {
+	ret = true
+	defer sr.peer.ConsumeRequest(sr.requestID)
+	// timeout
+	if sr.finished || sr.timeout.Before(time.Now()) {
+		// silently ignore already finished job
+		sr.finished = true
+		return
+	}
+	sr.finished = true
+
+	body := msgBody.(*types.GetStateSnapshotResponse)
+	rsp := &message.GetStateSnapshotRsp{Rsp: body}
+	if body.Status != types.ResultStatus_OK {
+		rsp.Err = message.RemotePeerFailError
+	}
+	sr.reply(rsp)
+	return
+}
+
+func (sr *StateSnapshotReceiver) reply(rsp *message.GetStateSnapshotRsp) {
+	select {
+	case sr.req.ReplyC <- rsp:
+	default:
+		// the requester gave up
+	}
+}
@@ -0,0 +1,71 @@
+/*
+ * @file
+ * @copyright defined in aergo/LICENSE.txt
+ */
+
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aergoio/aergo/message"
+	"github.com/aergoio/aergo/p2p/p2pcommon"
+	"github.com/aergoio/aergo/p2p/p2pmock"
+	"github.com/aergoio/aergo/types"
+	"github.com/golang/mock/gomock"
+)
+
+func TestStateSnapshotReceiver_ReceiveResp(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name        string
+		ttl         time.Duration
+		blkInterval time.Duration
+		rspStatus   types.ResultStatus
+
+		// to verify
+		sentResp  int
+		respError bool
+	}{
+		{"TSingleResp", time.Minute, 0, types.ResultStatus_OK, 1, false},
+		{"TRemoteFail", time.Minute, 0, types.ResultStatus_INTERNAL, 1, true},
+		{"TMissing", time.Minute, 0, types.ResultStatus_NOT_FOUND, 1, true},
+		{"TTimeout", time.Millisecond * 10, time.Millisecond * 20, types.ResultStatus_OK, 0, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockMF := p2pmock.NewMockMoFactory(ctrl)
+			mockPeer := p2pmock.NewMockRemotePeer(ctrl)
+			mockPeer.EXPECT().MF().Return(mockMF)
+			mockMo := createDummyMo(ctrl)
+			mockPeer.EXPECT().ConsumeRequest(gomock.Any()).Times(1)
+			mockPeer.EXPECT().SendMessage(gomock.Any())
+			mockMF.EXPECT().NewMsgRequestOrderWithReceiver(gomock.Any(), p2pcommon.GetStateSnapshotRequest, gomock.Any()).Return(mockMo)
+
+			req := &message.GetStateSnapshot{ToWhom: dummyPeerID, Req: &types.GetStateSnapshotRequest{Kind: types.SnapshotKind_SNAPSHOT_LEAVES},
+				ReplyC: make(chan *message.GetStateSnapshotRsp, 1)}
+			sr := NewStateSnapshotReceiver(mockPeer, req, test.ttl)
+			sr.StartGet()
+
+			msg := p2pcommon.NewSimpleMsgVal(p2pcommon.GetStateSnapshotResponse, sampleMsgID)
+			body := &types.GetStateSnapshotResponse{Status: test.rspStatus}
+			if test.blkInterval > 0 {
+				time.Sleep(test.blkInterval)
+			}
+			sr.ReceiveResp(msg, body)
+
+			if len(req.ReplyC) != test.sentResp {
+				t.Fatalf("Wrong reply count %d, want %d", len(req.ReplyC), test.sentResp)
+			}
+			if test.sentResp > 0 {
+				rsp := <-req.ReplyC
+				if (rsp.Err != nil) != test.respError {
+					t.Fatalf("Wrong error (have %v)", rsp.Err)
+				}
+			}
+		})
+	}
+}
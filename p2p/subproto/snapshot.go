@@ -0,0 +1,85 @@
+/*
+ * @file
+ * @copyright defined in aergo/LICENSE.txt
+ */
+
+package subproto
+
+import (
+	"github.com/aergoio/aergo-lib/log"
+	"github.com/aergoio/aergo/message"
+	"github.com/aergoio/aergo/p2p/p2pcommon"
+	"github.com/aergoio/aergo/p2p/p2putil"
+	"github.com/aergoio/aergo/types"
+)
+
+type stateSnapshotRequestHandler struct {
+	BaseMsgHandler
+	asyncHelper
+}
+
+var _ p2pcommon.MessageHandler = (*stateSnapshotRequestHandler)(nil)
+
+type stateSnapshotResponseHandler struct {
+	BaseMsgHandler
+}
+
+var _ p2pcommon.MessageHandler = (*stateSnapshotResponseHandler)(nil)
+
+// NewStateSnapshotReqHandler creates handler for GetStateSnapshotRequest
+func NewStateSnapshotReqHandler(pm p2pcommon.PeerManager, peer p2pcommon.RemotePeer, logger *log.Logger, actor p2pcommon.ActorService) *stateSnapshotRequestHandler {
+	bh := &stateSnapshotRequestHandler{BaseMsgHandler: BaseMsgHandler{protocol: p2pcommon.GetStateSnapshotRequest, pm: pm, peer: peer, actor: actor, logger: logger}, asyncHelper: newAsyncHelper()}
+	return bh
+}
+
+func (bh *stateSnapshotRequestHandler) ParsePayload(rawbytes []byte) (p2pcommon.MessageBody, error) {
+	return p2putil.UnmarshalAndReturn(rawbytes, &types.GetStateSnapshotRequest{})
+}
+
+func (bh *stateSnapshotRequestHandler) Handle(msg p2pcommon.Message, msgBody p2pcommon.MessageBody) {
+	remotePeer := bh.peer
+	data := msgBody.(*types.GetStateSnapshotRequest)
+	p2putil.DebugLogReceive(bh.logger, bh.protocol, msg.ID().String(), remotePeer, data)
+	if bh.issue() {
+		go bh.handleSnapshotReq(msg, data)
+	} else {
+		resp := &types.GetStateSnapshotResponse{Status: types.ResultStatus_RESOURCE_EXHAUSTED}
+		remotePeer.SendMessage(remotePeer.MF().NewMsgResponseOrder(msg.ID(), p2pcommon.GetStateSnapshotResponse, resp))
+	}
+}
+
+func (bh *stateSnapshotRequestHandler) handleSnapshotReq(msg p2pcommon.Message, data *types.GetStateSnapshotRequest) {
+	defer bh.release()
+	remotePeer := bh.peer
+
+	var resp *types.GetStateSnapshotResponse
+	rawResponse, err := bh.actor.CallRequestDefaultTimeout(message.ChainSvc, &message.ReadStateSnapshot{Req: data})
+	if err != nil {
+		bh.logger.Info().Err(err).Str(p2putil.LogOrgReqID, msg.ID().String()).Msg("failed to read state snapshot")
+		resp = &types.GetStateSnapshotResponse{Status: types.ResultStatus_INTERNAL}
+	} else {
+		resp = rawResponse.(message.ReadStateSnapshotRsp).Rsp
+	}
+	remotePeer.SendMessage(remotePeer.MF().NewMsgResponseOrder(msg.ID(), p2pcommon.GetStateSnapshotResponse, resp))
+}
+
+// NewStateSnapshotRespHandler creates handler for GetStateSnapshotResponse
+func NewStateSnapshotRespHandler(pm p2pcommon.PeerManager, peer p2pcommon.RemotePeer, logger *log.Logger, actor p2pcommon.ActorService) *stateSnapshotResponseHandler {
+	bh := &stateSnapshotResponseHandler{BaseMsgHandler: BaseMsgHandler{protocol: p2pcommon.GetStateSnapshotResponse, pm: pm, peer: peer, actor: actor, logger: logger}}
+	return bh
+}
+
+func (bh *stateSnapshotResponseHandler) ParsePayload(rawbytes []byte) (p2pcommon.MessageBody, error) {
+	return p2putil.UnmarshalAndReturn(rawbytes, &types.GetStateSnapshotResponse{})
+}
+
+func (bh *stateSnapshotResponseHandler) Handle(msg p2pcommon.Message, msgBody p2pcommon.MessageBody) {
+	data := msgBody.(*types.GetStateSnapshotResponse)
+	p2putil.DebugLogReceiveResponse(bh.logger, bh.protocol, msg.ID().String(), msg.OriginalID().String(), bh.peer, data)
+
+	// locate request data and remove it if found
+	if !bh.peer.GetReceiver(msg.OriginalID())(msg, data) {
+		// ignore dangling response
+		bh.peer.ConsumeRequest(msg.OriginalID())
+	}
+}
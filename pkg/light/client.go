@@ -0,0 +1,673 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+// Package light implements a light client of aergo. It syncs the block
+// headers from a remote node and validates them from a trusted block, then
+// verifies the states and the receipts returned by the node against the
+// roots of the validated headers.
+package light
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/aergoio/aergo/config"
+	"github.com/aergoio/aergo/consensus/impl/dpos/bp"
+	"github.com/aergoio/aergo/contract/enterprise"
+	"github.com/aergoio/aergo/contract/system"
+	"github.com/aergoio/aergo/types"
+)
+
+const (
+	// maxFetchSize is the number of headers fetched by a request, which is
+	// the limit of ListBlockHeaders.
+	maxFetchSize = 1000
+	// DefaultMaxHeaders is the number of the recent headers kept by a client
+	// unless Config.MaxHeaders is given.
+	DefaultMaxHeaders = 10000
+)
+
+var (
+	ErrNoBP            = errors.New("no trusted block producer")
+	ErrNoTrustedBlock  = errors.New("trusted block hash is required")
+	ErrTrustedBlock    = errors.New("block mismatches the trusted hash")
+	ErrNotSynced       = errors.New("block header is not synced")
+	ErrBadHeader       = errors.New("invalid block header")
+	ErrBadParent       = errors.New("block is not a child of the previous header")
+	ErrBadBlockSign    = errors.New("bad signature of block producer")
+	ErrUnknownBP       = errors.New("block produced by an untrusted block producer")
+	ErrBadBlockCommit  = errors.New("bad commit certificate of the previous block")
+	ErrBadBodyRootHash = errors.New("block body mismatches the txs root hash of its header")
+	ErrBadReceipt      = errors.New("receipt of another tx")
+)
+
+// Config is the trust anchor of a light client.
+type Config struct {
+	// TrustedHash is the hash of the block from which the headers are
+	// validated. It's typically a recent checkpoint obtained from a trusted
+	// source.
+	TrustedHash []byte
+	// BPs is the block producers of the trusted block, which are the
+	// validators for bft. For dpos and bft, the changes after the trusted
+	// block are tracked from the synced blocks. Otherwise, they must be
+	// updated by SetBPs.
+	BPs []types.PeerID
+	// BPCount is the default number of the BPs elected by dpos, which is the
+	// number of the BPs in genesis. It's the number of BPs if 0.
+	BPCount int
+	// Consensus is the consensus type of the chain. It's taken from the
+	// chain id of the trusted block if empty.
+	Consensus string
+	// Hardfork is the hardfork config of the chain, which changes the
+	// receipt format. It's chosen from the chain id of the trusted block if
+	// nil.
+	Hardfork types.BlockVersionner
+	// MaxHeaders is the number of the recent headers kept in memory, which
+	// is DefaultMaxHeaders if 0. The older headers are dropped, and the
+	// proofs of their blocks can't be verified.
+	MaxHeaders int
+}
+
+// bpSet is the block producers of the blocks from the block number from
+// until the next set.
+type bpSet struct {
+	from types.BlockNo
+	ids  map[types.PeerID]bool
+}
+
+func newBPSet(from types.BlockNo, bps []types.PeerID) *bpSet {
+	set := &bpSet{from: from, ids: make(map[types.PeerID]bool, len(bps))}
+	for _, id := range bps {
+		set.ids[id] = true
+	}
+	return set
+}
+
+func (set *bpSet) list() []types.PeerID {
+	bps := make([]types.PeerID, 0, len(set.ids))
+	for id := range set.ids {
+		bps = append(bps, id)
+	}
+	return bps
+}
+
+// Client is a light client. The headers are synced by Sync and the proofs
+// are verified against the synced headers only.
+type Client struct {
+	rpc        types.AergoRPCServiceClient
+	bv         types.BlockVersionner
+	consensus  string
+	bpCount    int
+	maxHeaders int
+
+	mu      sync.RWMutex
+	bpSets  []*bpSet
+	headers []*types.Block
+}
+
+// New returns a light client of the node served by rpc. The trusted block is
+// fetched and checked against cfg.TrustedHash.
+func New(ctx context.Context, rpc types.AergoRPCServiceClient, cfg Config) (*Client, error) {
+	if len(cfg.TrustedHash) == 0 {
+		return nil, ErrNoTrustedBlock
+	}
+	if len(cfg.BPs) == 0 {
+		return nil, ErrNoBP
+	}
+
+	trusted, err := rpc.GetBlockMetadata(ctx, &types.SingleBytes{Value: cfg.TrustedHash})
+	if err != nil {
+		return nil, err
+	}
+	header := &types.Block{Header: trusted.GetHeader()}
+	if header.GetHeader() == nil || !bytes.Equal(header.BlockHash(), cfg.TrustedHash) {
+		return nil, ErrTrustedBlock
+	}
+	id := types.NewChainID()
+	if err := id.Read(header.GetHeader().GetChainID()); err != nil {
+		return nil, fmt.Errorf("%v: %s", ErrBadHeader, err.Error())
+	}
+
+	c := &Client{
+		rpc:        rpc,
+		bv:         cfg.Hardfork,
+		consensus:  strings.ToLower(cfg.Consensus),
+		bpCount:    cfg.BPCount,
+		maxHeaders: cfg.MaxHeaders,
+		bpSets:     []*bpSet{newBPSet(header.BlockNo(), cfg.BPs)},
+		headers:    []*types.Block{header},
+	}
+	if c.bv == nil {
+		c.bv = HardforkOf(id.Magic)
+	}
+	if c.consensus == "" {
+		c.consensus = strings.ToLower(id.Consensus)
+	}
+	if c.bpCount == 0 {
+		c.bpCount = len(cfg.BPs)
+	}
+	if c.maxHeaders == 0 {
+		c.maxHeaders = DefaultMaxHeaders
+	}
+	if c.consensus == "dpos" {
+		// The state of the first block of the election period is needed to
+		// elect the BPs of the next period.
+		if period := bp.ElectionPeriod(); c.maxHeaders <= int(period) {
+			c.maxHeaders = int(period) + 1
+		}
+		if err := c.fetchAncestors(ctx, header.BlockNo()-header.BlockNo()%bp.ElectionPeriod()); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// HardforkOf returns the hardfork config of the chain of magic.
+func HardforkOf(magic string) types.BlockVersionner {
+	switch magic {
+	case types.GetMainNetGenesis().ID.Magic:
+		return config.MainNetHardforkConfig
+	case types.GetTestNetGenesis().ID.Magic:
+		return config.TestNetHardforkConfig
+	default:
+		return config.AllEnabledHardforkConfig
+	}
+}
+
+// BPsOf returns the block producers listed in ci, which is the consensus info
+// of dpos, raft or bft.
+func BPsOf(ci *types.ConsensusInfo) ([]types.PeerID, error) {
+	var bps []types.PeerID
+	for _, bp := range ci.GetBps() {
+		var info struct {
+			PeerID string
+		}
+		if err := json.Unmarshal([]byte(bp), &info); err != nil {
+			return nil, err
+		}
+		id, err := types.IDB58Decode(info.PeerID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid peer id of bp %s: %s", info.PeerID, err.Error())
+		}
+		bps = append(bps, id)
+	}
+	if len(bps) == 0 {
+		return nil, ErrNoBP
+	}
+	return bps, nil
+}
+
+// SetBPs replaces the trusted block producers of the blocks after the best
+// one.
+func (c *Client) SetBPs(bps []types.PeerID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.setBPs(c.best().BlockNo()+1, bps)
+}
+
+// setBPs adds the block producers from the block of from. The ones set for
+// the later blocks are dropped.
+func (c *Client) setBPs(from types.BlockNo, bps []types.PeerID) {
+	for len(c.bpSets) > 1 && c.bpSets[len(c.bpSets)-1].from >= from {
+		c.bpSets = c.bpSets[:len(c.bpSets)-1]
+	}
+	c.bpSets = append(c.bpSets, newBPSet(from, bps))
+}
+
+// bpsAt returns the block producers of the block of no.
+func (c *Client) bpsAt(no types.BlockNo) *bpSet {
+	for i := len(c.bpSets) - 1; i > 0; i-- {
+		if c.bpSets[i].from <= no {
+			return c.bpSets[i]
+		}
+	}
+	return c.bpSets[0]
+}
+
+// Best returns the header of the last validated block.
+func (c *Client) Best() *types.Block {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.best()
+}
+
+func (c *Client) best() *types.Block {
+	return c.headers[len(c.headers)-1]
+}
+
+// Header returns the validated header of the block of no. Only the last
+// MaxHeaders headers are kept.
+func (c *Client) Header(no types.BlockNo) (*types.Block, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	first := c.headers[0].BlockNo()
+	if no < first || no-first >= uint64(len(c.headers)) {
+		return nil, ErrNotSynced
+	}
+	return c.headers[no-first], nil
+}
+
+// HeaderByHash returns the validated header of the block of hash.
+func (c *Client) HeaderByHash(hash []byte, no types.BlockNo) (*types.Block, error) {
+	header, err := c.Header(no)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(header.BlockHash(), hash) {
+		return nil, ErrNotSynced
+	}
+	return header, nil
+}
+
+// Sync fetches and validates the headers up to the best block of the node.
+// It returns the number of the last validated block.
+func (c *Client) Sync(ctx context.Context) (types.BlockNo, error) {
+	status, err := c.rpc.Blockchain(ctx, &types.Empty{})
+	if err != nil {
+		return 0, err
+	}
+	return c.SyncTo(ctx, status.GetBestHeight())
+}
+
+// SyncTo fetches and validates the headers up to the block of target.
+func (c *Client) SyncTo(ctx context.Context, target types.BlockNo) (types.BlockNo, error) {
+	for {
+		best := c.Best()
+		if best.BlockNo() >= target {
+			return best.BlockNo(), nil
+		}
+
+		size := target - best.BlockNo()
+		if size > maxFetchSize {
+			size = maxFetchSize
+		}
+		list, err := c.rpc.ListBlockHeaders(ctx, &types.ListParams{
+			Height: best.BlockNo() + size,
+			Size:   uint32(size),
+			Asc:    true,
+		})
+		if err != nil {
+			return best.BlockNo(), err
+		}
+		if len(list.GetBlocks()) == 0 {
+			return best.BlockNo(), ErrNotSynced
+		}
+		for _, b := range list.GetBlocks() {
+			if err := c.append(ctx, b); err != nil {
+				return c.Best().BlockNo(), fmt.Errorf("block %d: %s", b.GetHeader().GetBlockNo(), err.Error())
+			}
+		}
+	}
+}
+
+// fetchAncestors fetches the headers from the block of from to the trusted
+// block, which are validated by their hashes linked to the trusted block.
+func (c *Client) fetchAncestors(ctx context.Context, from types.BlockNo) error {
+	trusted := c.headers[0]
+	if from >= trusted.BlockNo() {
+		return nil
+	}
+	size := trusted.BlockNo() - from
+	list, err := c.rpc.ListBlockHeaders(ctx, &types.ListParams{
+		Height: trusted.BlockNo() - 1,
+		Size:   uint32(size),
+		Asc:    true,
+	})
+	if err != nil {
+		return err
+	}
+	if uint64(len(list.GetBlocks())) != size {
+		return ErrNotSynced
+	}
+	headers := make([]*types.Block, 0, size+1)
+	for _, b := range list.GetBlocks() {
+		headers = append(headers, &types.Block{Header: b.GetHeader()})
+	}
+	headers = append(headers, trusted)
+	for i := len(headers) - 1; i > 0; i-- {
+		if headers[i-1].GetHeader() == nil ||
+			headers[i-1].BlockNo()+1 != headers[i].BlockNo() ||
+			!bytes.Equal(headers[i].GetHeader().GetPrevBlockHash(), headers[i-1].BlockHash()) {
+			return ErrBadParent
+		}
+	}
+	c.headers = headers
+	return nil
+}
+
+// append validates the header of b and adds it after the best header. The
+// changes of the block producers by the block are tracked for dpos and bft.
+func (c *Client) append(ctx context.Context, b *types.Block) error {
+	// The hash sent by the node is ignored and calculated from the header.
+	header := &types.Block{Header: b.GetHeader()}
+	if header.GetHeader() == nil {
+		return ErrBadHeader
+	}
+	if c.consensus == "dpos" {
+		if err := c.electBPs(ctx, header.BlockNo()); err != nil {
+			return err
+		}
+	}
+	if err := c.validate(c.Best(), header); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.headers = append(c.headers, header)
+	if len(c.headers) > c.maxHeaders {
+		c.headers = append([]*types.Block(nil), c.headers[len(c.headers)-c.maxHeaders:]...)
+	}
+	// The parent of the first header is still validated by its set.
+	for len(c.bpSets) > 1 && c.bpSets[1].from <= c.headers[0].BlockNo() {
+		c.bpSets = c.bpSets[1:]
+	}
+	c.mu.Unlock()
+
+	if c.consensus == "bft" {
+		return c.trackValidators(ctx, header)
+	}
+	return nil
+}
+
+// validate checks that header is produced by a trusted block producer after
+// parent.
+func (c *Client) validate(parent, header *types.Block) error {
+	if header.BlockNo() != parent.BlockNo()+1 ||
+		!bytes.Equal(header.GetHeader().GetPrevBlockHash(), parent.BlockHash()) ||
+		!header.ValidChildOf(parent) {
+		return ErrBadParent
+	}
+	if valid, err := header.VerifySign(); err != nil || !valid {
+		return ErrBadBlockSign
+	}
+	bpID, err := header.BPID()
+	if err != nil {
+		return ErrBadBlockSign
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if !c.bpsAt(header.BlockNo()).ids[bpID] {
+		return ErrUnknownBP
+	}
+	if c.consensus == "bft" && parent.BlockNo() > 0 {
+		if err := c.verifyCommit(parent, header); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// electBPs sets the BPs elected for the block of no, if it starts an
+// election period of dpos. They're read from the state of the block which
+// started the previous period, by the verified proof.
+func (c *Client) electBPs(ctx context.Context, no types.BlockNo) error {
+	ref := bp.RefBlockNo(no)
+	if no%bp.ElectionPeriod() != 0 || ref == 0 {
+		return nil
+	}
+	header, err := c.Header(ref)
+	if err != nil {
+		return fmt.Errorf("state of the block %d electing the BPs: %s", ref, err.Error())
+	}
+	root := header.GetHeader().GetBlocksRootHash()
+	countKey := types.GetHashID(system.BpCountKey())
+	rankingKey := types.GetHashID(system.BpRankingKey())
+	keys := [][]byte{countKey[:], rankingKey[:]}
+	result, err := c.rpc.QueryContractState(ctx, &types.StateQuery{
+		ContractAddress: []byte(types.AergoSystem),
+		StorageKeys:     keys,
+		Root:            root,
+		Compressed:      true,
+	})
+	if err != nil {
+		return err
+	}
+	if err := VerifyStateQueryProof(root, []byte(types.AergoSystem), keys, result); err != nil {
+		return err
+	}
+
+	n := c.bpCount
+	if count := result.GetVarProofs()[0].GetValue(); len(count) > 0 {
+		n = int(new(big.Int).SetBytes(count).Uint64())
+	}
+	var bps []types.PeerID
+	for _, s := range system.RankersOf(result.GetVarProofs()[1].GetValue(), n) {
+		id, err := types.IDB58Decode(s)
+		if err != nil {
+			// The BPs are not changed as dpos does.
+			return nil
+		}
+		bps = append(bps, id)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.setBPs(no, bps)
+	return nil
+}
+
+// trackValidators applies the validator changes by the ChangeCluster txs
+// succeeded in the block of header, which are effective from the next
+// block. The block body and the receipts are verified against header.
+func (c *Client) trackValidators(ctx context.Context, header *types.Block) error {
+	if bytes.Equal(header.GetHeader().GetTxsRootHash(), types.CalculateTxsRootHash(nil)) {
+		return nil
+	}
+	block, err := c.rpc.GetBlock(ctx, &types.SingleBytes{Value: header.BlockHash()})
+	if err != nil {
+		return err
+	}
+	txs := block.GetBody().GetTxs()
+	if !bytes.Equal(types.CalculateTxsRootHash(txs), header.GetHeader().GetTxsRootHash()) {
+		return ErrBadBodyRootHash
+	}
+
+	changes := make(map[int]types.CallInfo)
+	for i, tx := range txs {
+		body := tx.GetBody()
+		if string(body.GetRecipient()) != types.AergoEnterprise {
+			continue
+		}
+		var ci types.CallInfo
+		if err := json.Unmarshal(body.GetPayload(), &ci); err != nil || ci.Name != enterprise.ChangeCluster {
+			continue
+		}
+		changes[i] = ci
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	receipts, err := c.blockReceipts(ctx, header, txs, nil)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	bps := c.bpsAt(header.BlockNo()).list()
+	for i := range txs {
+		ci, ok := changes[i]
+		if !ok || receipts[i].GetStatus() != "SUCCESS" {
+			continue
+		}
+		req, err := enterprise.ValidateChangeCluster(ci, header.BlockNo())
+		if err != nil {
+			continue
+		}
+		bps = applyChange(bps, req.(*types.MembershipChange))
+	}
+	c.setBPs(header.BlockNo()+1, bps)
+	return nil
+}
+
+// applyChange returns the validators changed by req. The invalid changes are
+// skipped as the bft consensus does.
+func applyChange(bps []types.PeerID, req *types.MembershipChange) []types.PeerID {
+	attr := req.GetAttr()
+	if attr == nil {
+		return bps
+	}
+	switch req.GetType() {
+	case types.MembershipChangeType_ADD_MEMBER:
+		id := types.PeerID(attr.PeerID)
+		if _, err := id.ExtractPublicKey(); err != nil {
+			return bps
+		}
+		for _, v := range bps {
+			if v == id {
+				return bps
+			}
+		}
+		return append(bps, id)
+	case types.MembershipChangeType_REMOVE_MEMBER:
+		if len(bps) == 1 {
+			return bps
+		}
+		for i, v := range bps {
+			if types.BFTValidatorID(v) == attr.ID {
+				return append(bps[:i:i], bps[i+1:]...)
+			}
+		}
+	}
+	return bps
+}
+
+// verifyCommit checks the certificate of parent carried by header, which is
+// signed by the validators of the bft consensus.
+func (c *Client) verifyCommit(parent, header *types.Block) error {
+	commit, err := types.DecodeBFTCommit(header.GetHeader().GetConsensus())
+	if err != nil || commit.GetHeight() != parent.BlockNo() ||
+		!bytes.Equal(commit.GetBlockHash(), parent.BlockHash()) {
+		return ErrBadBlockCommit
+	}
+	validators := c.bpsAt(parent.BlockNo()).list()
+	if err := commit.Verify(validators, header.GetHeader().GetChainID()); err != nil {
+		return fmt.Errorf("%v: %s", ErrBadBlockCommit, err.Error())
+	}
+	return nil
+}
+
+// GetState returns the verified state of address at the block of no. The
+// state of an absent account is empty.
+func (c *Client) GetState(ctx context.Context, address []byte, no types.BlockNo) (*types.State, error) {
+	header, err := c.Header(no)
+	if err != nil {
+		return nil, err
+	}
+	proof, err := c.rpc.GetStateAndProof(ctx, &types.AccountAndRoot{
+		Account:    address,
+		Root:       header.GetHeader().GetBlocksRootHash(),
+		Compressed: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := VerifyAccountProof(header.GetHeader().GetBlocksRootHash(), address, proof); err != nil {
+		return nil, err
+	}
+	if !proof.GetInclusion() {
+		return &types.State{}, nil
+	}
+	return proof.GetState(), nil
+}
+
+// QueryContractState returns the verified variables of contract at the block
+// of no. storageKeys are the hashed keys of the variables as in
+// types.StateQuery.
+func (c *Client) QueryContractState(ctx context.Context, contract []byte, storageKeys [][]byte, no types.BlockNo) (*types.StateQueryProof, error) {
+	header, err := c.Header(no)
+	if err != nil {
+		return nil, err
+	}
+	root := header.GetHeader().GetBlocksRootHash()
+	result, err := c.rpc.QueryContractState(ctx, &types.StateQuery{
+		ContractAddress: contract,
+		StorageKeys:     storageKeys,
+		Root:            root,
+		Compressed:      true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := VerifyStateQueryProof(root, contract, storageKeys, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetReceipt returns the verified receipt of the tx of txHash. Since the
+// receipts root of a block covers all its receipts, the block body and the
+// receipts of the other txs in the block are fetched to verify it.
+func (c *Client) GetReceipt(ctx context.Context, txHash []byte) (*types.Receipt, error) {
+	receipt, err := c.rpc.GetReceipt(ctx, &types.SingleBytes{Value: txHash})
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(receipt.GetTxHash(), txHash) {
+		return nil, ErrBadReceipt
+	}
+	header, err := c.HeaderByHash(receipt.GetBlockHash(), receipt.GetBlockNo())
+	if err != nil {
+		return nil, err
+	}
+	block, err := c.rpc.GetBlock(ctx, &types.SingleBytes{Value: header.BlockHash()})
+	if err != nil {
+		return nil, err
+	}
+	txs := block.GetBody().GetTxs()
+	if !bytes.Equal(types.CalculateTxsRootHash(txs), header.GetHeader().GetTxsRootHash()) {
+		return nil, ErrBadBodyRootHash
+	}
+	if _, err := c.blockReceipts(ctx, header, txs, receipt); err != nil {
+		return nil, err
+	}
+	return receipt, nil
+}
+
+// blockReceipts returns the receipts of txs in the block of header, which
+// are verified against its receipts root. known is the receipt of one of txs
+// fetched already, or nil.
+func (c *Client) blockReceipts(ctx context.Context, header *types.Block, txs []*types.Tx, known *types.Receipt) ([]*types.Receipt, error) {
+	var err error
+	receipts := make([]*types.Receipt, len(txs))
+	for i, tx := range txs {
+		if known != nil && bytes.Equal(tx.GetHash(), known.GetTxHash()) {
+			receipts[i] = known
+			continue
+		}
+		if receipts[i], err = c.rpc.GetReceipt(ctx, &types.SingleBytes{Value: tx.GetHash()}); err != nil {
+			return nil, err
+		}
+	}
+	if err := VerifyReceipts(header.GetHeader(), receipts, c.bv); err != nil {
+		return nil, err
+	}
+	return receipts, nil
+}
+
+// VerifyTxProof checks that the tx of proof is included in a synced block.
+func (c *Client) VerifyTxProof(proof *types.TxProof) error {
+	header, err := c.HeaderByHash(proof.GetBlockHash(), proof.GetBlockNo())
+	if err != nil {
+		return err
+	}
+	return proof.Verify(header.GetHeader())
+}
+
+// VerifyReceiptProof checks that the receipt of proof is included in a synced
+// block.
+func (c *Client) VerifyReceiptProof(proof *types.ReceiptProof) error {
+	header, err := c.HeaderByHash(proof.GetBlockHash(), proof.GetBlockNo())
+	if err != nil {
+		return err
+	}
+	return proof.Verify(header.GetHeader(), c.bv)
+}
@@ -0,0 +1,215 @@
+package light
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aergoio/aergo/state"
+	"github.com/aergoio/aergo/types"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// testNode serves the blocks and the states of a chain. The methods not used
+// by the light client panic.
+type testNode struct {
+	types.AergoRPCServiceClient
+	blocks []*types.Block
+	states *state.StateDB
+}
+
+func (n *testNode) GetBlockMetadata(ctx context.Context, in *types.SingleBytes, opts ...grpc.CallOption) (*types.BlockMetadata, error) {
+	for _, b := range n.blocks {
+		if string(b.BlockHash()) == string(in.Value) {
+			return &types.BlockMetadata{Hash: b.BlockHash(), Header: b.GetHeader()}, nil
+		}
+	}
+	return nil, ErrNotSynced
+}
+
+func (n *testNode) Blockchain(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*types.BlockchainStatus, error) {
+	best := n.blocks[len(n.blocks)-1]
+	return &types.BlockchainStatus{
+		BestBlockHash: best.BlockHash(),
+		BestHeight:    best.BlockNo(),
+		ChainInfo:     &types.ChainInfo{Id: &types.ChainId{Magic: "light.test", Consensus: "sbp"}},
+	}, nil
+}
+
+func (n *testNode) ListBlockHeaders(ctx context.Context, in *types.ListParams, opts ...grpc.CallOption) (*types.BlockHeaderList, error) {
+	return &types.BlockHeaderList{Blocks: n.blocks[in.Height-uint64(in.Size)+1 : in.Height+1]}, nil
+}
+
+func (n *testNode) GetStateAndProof(ctx context.Context, in *types.AccountAndRoot, opts ...grpc.CallOption) (*types.AccountProof, error) {
+	return n.states.GetAccountAndProof(accountID(in.Account), in.Root, in.Compressed)
+}
+
+func genTestChain(t *testing.T, priv crypto.PrivKey, n int, root []byte) []*types.Block {
+	privs := make([]crypto.PrivKey, n)
+	for i := range privs {
+		privs[i] = priv
+	}
+	return genTestChainBy(t, privs, root)
+}
+
+// genTestChainBy returns a chain whose block of i is signed by privs[i-1].
+func genTestChainBy(t *testing.T, privs []crypto.PrivKey, root []byte) []*types.Block {
+	cid, err := types.GetTestGenesis().ID.Bytes()
+	require.NoError(t, err)
+	genesis := types.NewBlock(&types.BlockHeaderInfo{ChainId: cid}, root, &types.Receipts{}, nil, nil, nil)
+
+	blocks := []*types.Block{genesis}
+	for i, priv := range privs {
+		prev := blocks[i]
+		prev.BlockHash()
+		bi := types.NewBlockHeaderInfoFromPrevBlock(prev, int64(i+1), types.DummyBlockVersionner(0))
+		b := types.NewBlock(bi, root, &types.Receipts{}, nil, nil, nil)
+		require.NoError(t, b.Sign(priv))
+		blocks = append(blocks, b)
+	}
+	return blocks
+}
+
+func genBP(t *testing.T) (crypto.PrivKey, types.PeerID) {
+	priv, pub, err := crypto.GenerateKeyPair(crypto.Secp256k1, 256)
+	require.NoError(t, err)
+	id, err := types.IDFromPublicKey(pub)
+	require.NoError(t, err)
+	return priv, id
+}
+
+func TestClientSync(t *testing.T) {
+	_, states, deinit := initStateDB(t)
+	defer deinit()
+	priv, bp := genBP(t)
+	node := &testNode{blocks: genTestChain(t, priv, 5, states.GetRoot()), states: states}
+	ctx := context.Background()
+
+	_, err := New(ctx, node, Config{BPs: []types.PeerID{bp}})
+	assert.Equal(t, ErrNoTrustedBlock, err)
+	_, err = New(ctx, node, Config{TrustedHash: node.blocks[0].BlockHash()})
+	assert.Equal(t, ErrNoBP, err)
+
+	c, err := New(ctx, node, Config{TrustedHash: node.blocks[0].BlockHash(), BPs: []types.PeerID{bp}})
+	require.NoError(t, err)
+	_, err = c.Header(1)
+	assert.Equal(t, ErrNotSynced, err)
+
+	best, err := c.SyncTo(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, types.BlockNo(2), best)
+	best, err = c.Sync(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, types.BlockNo(5), best)
+
+	header, err := c.HeaderByHash(node.blocks[3].BlockHash(), 3)
+	require.NoError(t, err)
+	assert.Equal(t, types.BlockNo(3), header.BlockNo())
+	_, err = c.HeaderByHash(node.blocks[3].BlockHash(), 4)
+	assert.Equal(t, ErrNotSynced, err)
+
+	st, err := c.GetState(ctx, testAccount, best)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), st.GetNonce())
+	st, err = c.GetState(ctx, testAbsent, best)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), st.GetNonce())
+	_, err = c.GetState(ctx, testAccount, best+1)
+	assert.Equal(t, ErrNotSynced, err)
+}
+
+func TestClientBadHeader(t *testing.T) {
+	priv, bp := genBP(t)
+	ctx := context.Background()
+
+	other, _ := genBP(t)
+	node := &testNode{blocks: genTestChain(t, other, 3, nil)}
+	c, err := New(ctx, node, Config{TrustedHash: node.blocks[0].BlockHash(), BPs: []types.PeerID{bp}})
+	require.NoError(t, err)
+	_, err = c.Sync(ctx)
+	assert.Contains(t, err.Error(), ErrUnknownBP.Error())
+	assert.Equal(t, types.BlockNo(0), c.Best().BlockNo())
+
+	node = &testNode{blocks: genTestChain(t, priv, 3, nil)}
+	_, err = New(ctx, node, Config{TrustedHash: []byte("unknown"), BPs: []types.PeerID{bp}})
+	assert.Error(t, err)
+
+	// A modified header fails the signature check of the block producer.
+	node.blocks[2].Header.Timestamp++
+	c, err = New(ctx, node, Config{TrustedHash: node.blocks[0].BlockHash(), BPs: []types.PeerID{bp}})
+	require.NoError(t, err)
+	best, err := c.Sync(ctx)
+	assert.Contains(t, err.Error(), ErrBadBlockSign.Error())
+	assert.Equal(t, types.BlockNo(1), best)
+}
+
+func TestClientRecentHeaders(t *testing.T) {
+	priv, bp := genBP(t)
+	node := &testNode{blocks: genTestChain(t, priv, 5, nil)}
+	ctx := context.Background()
+
+	c, err := New(ctx, node, Config{TrustedHash: node.blocks[1].BlockHash(), BPs: []types.PeerID{bp}, MaxHeaders: 3})
+	require.NoError(t, err)
+	// The consensus is taken from the chain id of the trusted block.
+	assert.Equal(t, "sbp", c.consensus)
+	_, err = c.Header(0)
+	assert.Equal(t, ErrNotSynced, err)
+
+	best, err := c.Sync(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, types.BlockNo(5), best)
+	_, err = c.Header(2)
+	assert.Equal(t, ErrNotSynced, err)
+	for no := types.BlockNo(3); no <= best; no++ {
+		header, err := c.Header(no)
+		require.NoError(t, err)
+		assert.Equal(t, node.blocks[no].BlockHash(), header.BlockHash())
+	}
+}
+
+func TestClientBPChange(t *testing.T) {
+	priv1, bp1 := genBP(t)
+	priv2, bp2 := genBP(t)
+	node := &testNode{blocks: genTestChainBy(t, []crypto.PrivKey{priv1, priv1, priv2, priv2}, nil)}
+	ctx := context.Background()
+
+	c, err := New(ctx, node, Config{TrustedHash: node.blocks[0].BlockHash(), BPs: []types.PeerID{bp1}})
+	require.NoError(t, err)
+	best, err := c.Sync(ctx)
+	assert.Contains(t, err.Error(), ErrUnknownBP.Error())
+	assert.Equal(t, types.BlockNo(2), best)
+
+	// The new BPs are applied to the blocks after the best one only.
+	c.SetBPs([]types.PeerID{bp2})
+	best, err = c.Sync(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, types.BlockNo(4), best)
+	assert.True(t, c.bpsAt(2).ids[bp1])
+	assert.False(t, c.bpsAt(2).ids[bp2])
+	assert.True(t, c.bpsAt(3).ids[bp2])
+}
+
+func TestApplyChange(t *testing.T) {
+	_, bp1 := genBP(t)
+	_, bp2 := genBP(t)
+	bps := []types.PeerID{bp1}
+
+	add := func(id types.PeerID) *types.MembershipChange {
+		return &types.MembershipChange{Type: types.MembershipChangeType_ADD_MEMBER, Attr: &types.MemberAttr{PeerID: []byte(id)}}
+	}
+	remove := func(id types.PeerID) *types.MembershipChange {
+		return &types.MembershipChange{Type: types.MembershipChangeType_REMOVE_MEMBER, Attr: &types.MemberAttr{ID: types.BFTValidatorID(id)}}
+	}
+
+	assert.Equal(t, []types.PeerID{bp1}, applyChange(bps, add(types.PeerID("invalid"))))
+	assert.Equal(t, []types.PeerID{bp1}, applyChange(bps, add(bp1)))
+	bps = applyChange(bps, add(bp2))
+	assert.Equal(t, []types.PeerID{bp1, bp2}, bps)
+
+	bps = applyChange(bps, remove(bp1))
+	assert.Equal(t, []types.PeerID{bp2}, bps)
+	// The last validator isn't removed.
+	assert.Equal(t, []types.PeerID{bp2}, applyChange(bps, remove(bp2)))
+}
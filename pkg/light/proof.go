@@ -0,0 +1,144 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package light
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/aergoio/aergo/internal/common"
+	"github.com/aergoio/aergo/pkg/trie"
+	"github.com/aergoio/aergo/types"
+	"github.com/golang/protobuf/proto"
+	"github.com/willf/bloom"
+)
+
+var (
+	ErrBadProof         = errors.New("invalid merkle proof")
+	ErrProofKey         = errors.New("proof of another key")
+	ErrBadReceiptsRoot  = errors.New("receipts mismatch the receipts root hash of block header")
+	ErrVarProofMismatch = errors.New("variable proofs mismatch the queried keys")
+)
+
+// VerifyAccountProof checks that proof proves the state of address, or its
+// absence, in the state trie of root.
+func VerifyAccountProof(root, address []byte, proof *types.AccountProof) error {
+	if proof == nil {
+		return ErrBadProof
+	}
+	var value []byte
+	if proof.GetInclusion() {
+		if proof.GetState() == nil {
+			return ErrBadProof
+		}
+		data, err := proto.Marshal(proof.GetState())
+		if err != nil {
+			return err
+		}
+		value = common.Hasher(data)
+	}
+	id := types.ToAccountID(address)
+	if !verifyTrieProof(root, id[:], value, proof.GetInclusion(), proof.GetProofKey(), proof.GetProofVal(),
+		proof.GetBitmap(), proof.GetAuditPath(), int(proof.GetHeight())) {
+		return ErrBadProof
+	}
+	return nil
+}
+
+// VerifyVarProof checks that proof proves the value of a contract variable,
+// or its absence, in the storage trie of storageRoot. The key of the proof
+// is the hashed key of the variable.
+func VerifyVarProof(storageRoot []byte, proof *types.ContractVarProof) error {
+	if proof == nil {
+		return ErrBadProof
+	}
+	var value []byte
+	if proof.GetInclusion() {
+		value = common.Hasher(proof.GetValue())
+	}
+	if !verifyTrieProof(storageRoot, proof.GetKey(), value, proof.GetInclusion(), proof.GetProofKey(), proof.GetProofVal(),
+		proof.GetBitmap(), proof.GetAuditPath(), int(proof.GetHeight())) {
+		return ErrBadProof
+	}
+	return nil
+}
+
+// VerifyStateQueryProof checks the result of QueryContractState for contract
+// and storageKeys in the state trie of root.
+func VerifyStateQueryProof(root, contract []byte, storageKeys [][]byte, result *types.StateQueryProof) error {
+	cp := result.GetContractProof()
+	if !bytes.Equal(cp.GetKey(), contract) {
+		// The name of a contract isn't verifiable.
+		return ErrProofKey
+	}
+	if err := VerifyAccountProof(root, contract, cp); err != nil {
+		return err
+	}
+	if !cp.GetInclusion() {
+		if len(result.GetVarProofs()) != 0 {
+			return ErrVarProofMismatch
+		}
+		return nil
+	}
+
+	if len(result.GetVarProofs()) != len(storageKeys) {
+		return ErrVarProofMismatch
+	}
+	for i, vp := range result.GetVarProofs() {
+		if !bytes.Equal(vp.GetKey(), storageKeys[i]) {
+			return ErrProofKey
+		}
+		if err := VerifyVarProof(cp.GetState().GetStorageRoot(), vp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// VerifyReceipts checks that receipts are all the receipts of the block of
+// header in order. bv decides the format of the receipts by the block number.
+func VerifyReceipts(header *types.BlockHeader, receipts []*types.Receipt, bv types.BlockVersionner) error {
+	rs := &types.Receipts{}
+	rs.SetHardFork(bv, header.GetBlockNo())
+	for _, r := range receipts {
+		if len(r.GetEvents()) == 0 {
+			continue
+		}
+		// The bloom filter of the block is merged from those of the
+		// receipts, which is one of the merkle entries.
+		rBloom := bloom.New(types.BloomBitBits, types.BloomHashKNum)
+		for _, e := range r.GetEvents() {
+			rBloom.Add(e.GetContractAddress())
+			rBloom.Add([]byte(e.GetEventName()))
+		}
+		if err := rs.MergeBloom(rBloom); err != nil {
+			return err
+		}
+	}
+	rs.Set(receipts)
+
+	if !bytes.Equal(rs.MerkleRoot(), header.GetReceiptsRootHash()) {
+		return ErrBadReceiptsRoot
+	}
+	return nil
+}
+
+// verifyTrieProof checks an inclusion or a non-inclusion proof of the trie of
+// root. A proof is compressed if it has the bitmap.
+func verifyTrieProof(root, key, value []byte, included bool, proofKey, proofVal, bitmap []byte, ap [][]byte, height int) bool {
+	t := trie.NewTrie(root, common.Hasher, nil)
+	compressed := len(bitmap) > 0
+	switch {
+	case included && compressed:
+		return t.VerifyInclusionC(bitmap, key, value, ap, height)
+	case included:
+		return t.VerifyInclusion(ap, key, value)
+	case compressed:
+		return t.VerifyNonInclusionC(ap, height, bitmap, key, proofVal, proofKey)
+	default:
+		return t.VerifyNonInclusion(ap, key, proofVal, proofKey)
+	}
+}
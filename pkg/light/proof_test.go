@@ -0,0 +1,129 @@
+package light
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/aergoio/aergo-lib/db"
+	"github.com/aergoio/aergo/internal/common"
+	"github.com/aergoio/aergo/state"
+	"github.com/aergoio/aergo/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testAccount  = []byte("test_account")
+	testContract = []byte("test_contract")
+	testAbsent   = []byte("test_absent")
+)
+
+func initStateDB(t *testing.T) (*state.ChainStateDB, *state.StateDB, func()) {
+	dir, err := ioutil.TempDir("", "light")
+	require.NoError(t, err)
+	sdb := state.NewChainStateDB()
+	require.NoError(t, sdb.Init(string(db.BadgerImpl), dir, nil, false))
+	deinit := func() {
+		_ = sdb.Close()
+		_ = os.RemoveAll(dir)
+	}
+
+	states := sdb.GetStateDB()
+	require.NoError(t, states.PutState(types.ToAccountID(testAccount), &types.State{Nonce: 1, Balance: []byte{100}}))
+	cs, err := states.OpenContractStateAccount(types.ToAccountID(testContract))
+	require.NoError(t, err)
+	require.NoError(t, cs.SetData([]byte("_sv_name"), []byte(`"aergo"`)))
+	require.NoError(t, states.StageContractState(cs))
+	require.NoError(t, states.Update())
+	require.NoError(t, states.Commit())
+	return sdb, states, deinit
+}
+
+func accountID(address []byte) []byte {
+	id := types.ToAccountID(address)
+	return id[:]
+}
+
+func TestVerifyAccountProof(t *testing.T) {
+	_, states, deinit := initStateDB(t)
+	defer deinit()
+	root := states.GetRoot()
+
+	for _, compressed := range []bool{false, true} {
+		proof, err := states.GetAccountAndProof(accountID(testAccount), root, compressed)
+		require.NoError(t, err)
+		assert.True(t, proof.Inclusion)
+		assert.NoError(t, VerifyAccountProof(root, testAccount, proof), "compressed %v", compressed)
+
+		assert.Equal(t, ErrBadProof, VerifyAccountProof(root, testContract, proof), "another account")
+		proof.State.Nonce++
+		assert.Equal(t, ErrBadProof, VerifyAccountProof(root, testAccount, proof), "modified state")
+
+		absent, err := states.GetAccountAndProof(accountID(testAbsent), root, compressed)
+		require.NoError(t, err)
+		assert.False(t, absent.Inclusion)
+		assert.NoError(t, VerifyAccountProof(root, testAbsent, absent), "compressed %v", compressed)
+		assert.Equal(t, ErrBadProof, VerifyAccountProof(root, testAccount, absent), "existing account")
+	}
+	assert.Equal(t, ErrBadProof, VerifyAccountProof(root, testAccount, nil))
+}
+
+func TestVerifyStateQueryProof(t *testing.T) {
+	_, states, deinit := initStateDB(t)
+	defer deinit()
+	root := states.GetRoot()
+	nameKey := common.Hasher([]byte("_sv_name"))
+	absentKey := common.Hasher([]byte("_sv_absent"))
+
+	for _, compressed := range []bool{false, true} {
+		cp, err := states.GetAccountAndProof(accountID(testContract), root, compressed)
+		require.NoError(t, err)
+		cp.Key = testContract
+		storageRoot := cp.GetState().GetStorageRoot()
+		var vps []*types.ContractVarProof
+		for _, key := range [][]byte{nameKey, absentKey} {
+			vp, err := states.GetVarAndProof(key, storageRoot, compressed)
+			require.NoError(t, err)
+			vp.Key = key
+			vps = append(vps, vp)
+		}
+		result := &types.StateQueryProof{ContractProof: cp, VarProofs: vps}
+		assert.True(t, vps[0].Inclusion)
+		assert.Equal(t, []byte(`"aergo"`), vps[0].Value)
+		assert.False(t, vps[1].Inclusion)
+
+		keys := [][]byte{nameKey, absentKey}
+		assert.NoError(t, VerifyStateQueryProof(root, testContract, keys, result), "compressed %v", compressed)
+		assert.Equal(t, ErrProofKey, VerifyStateQueryProof(root, testAccount, keys, result))
+		assert.Equal(t, ErrProofKey, VerifyStateQueryProof(root, testContract, [][]byte{absentKey, nameKey}, result))
+		assert.Equal(t, ErrVarProofMismatch, VerifyStateQueryProof(root, testContract, keys[:1], result))
+
+		vps[0].Value = []byte(`"forged"`)
+		assert.Equal(t, ErrBadProof, VerifyStateQueryProof(root, testContract, keys, result), "modified value")
+	}
+}
+
+func TestVerifyReceipts(t *testing.T) {
+	_, states, deinit := initStateDB(t)
+	defer deinit()
+	bv := types.DummyBlockVersionner(0)
+	receipts := []*types.Receipt{
+		{ContractAddress: testContract, Status: "SUCCESS", TxHash: []byte("tx1"),
+			Events: []*types.Event{{ContractAddress: testContract, EventName: "transfer"}}},
+		{ContractAddress: testContract, Status: "ERROR", TxHash: []byte("tx2")},
+	}
+
+	// The receipts are added as the block producer does.
+	bs := state.NewBlockState(states)
+	bs.Receipts().SetHardFork(bv, 1)
+	for _, r := range receipts {
+		require.NoError(t, bs.AddReceipt(r))
+	}
+	header := &types.BlockHeader{BlockNo: 1, ReceiptsRootHash: bs.Receipts().MerkleRoot()}
+
+	assert.NoError(t, VerifyReceipts(header, receipts, bv))
+	assert.Equal(t, ErrBadReceiptsRoot, VerifyReceipts(header, receipts[:1], bv), "missing receipt")
+	receipts[1].Status = "SUCCESS"
+	assert.Equal(t, ErrBadReceiptsRoot, VerifyReceipts(header, receipts, bv), "modified receipt")
+}
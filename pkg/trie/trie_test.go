@@ -342,6 +342,109 @@ func TestTrieStageUpdates(t *testing.T) {
 	os.RemoveAll(".aergo")
 }
 
+func TestTrieWalk(t *testing.T) {
+	dbPath := path.Join(".aergo", "db")
+	if _, err := os.Stat(dbPath); os.IsNotExist(err) {
+		_ = os.MkdirAll(dbPath, 0711)
+	}
+	st := db.NewDB(db.BadgerImpl, dbPath)
+
+	smt := NewTrie(nil, common.Hasher, st)
+	keys := getFreshData(100, 32)
+	values := getFreshData(100, 32)
+	root, _ := smt.Update(keys, values)
+	smt.Commit()
+	smt.db.liveCache = make(map[Hash][][]byte)
+
+	leaves := make(map[string][]byte)
+	nodes := 0
+	err := smt.Walk(root, func(node []byte) bool {
+		if !smt.TrieRootExists(node) {
+			t.Fatal("walked node is not stored in db")
+		}
+		nodes++
+		return true
+	}, func(key, value []byte) error {
+		leaves[string(key)] = value
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(leaves) != len(keys) {
+		t.Fatalf("expected %d leaves, got %d", len(keys), len(leaves))
+	}
+	for i, key := range keys {
+		if !bytes.Equal(leaves[string(key)], values[i]) {
+			t.Fatal("walked leaf has a wrong value")
+		}
+	}
+
+	// skipping the root visits nothing else
+	visited := 0
+	smt.Walk(root, func(node []byte) bool {
+		visited++
+		return false
+	}, func(key, value []byte) error {
+		t.Fatal("leaf visited under a skipped node")
+		return nil
+	})
+	if visited != 1 || nodes <= 1 {
+		t.Fatalf("unexpected node visits: %d, %d", visited, nodes)
+	}
+	st.Close()
+	os.RemoveAll(".aergo")
+}
+
+func TestTrieWalkLeaves(t *testing.T) {
+	dbPath := path.Join(".aergo", "db")
+	if _, err := os.Stat(dbPath); os.IsNotExist(err) {
+		_ = os.MkdirAll(dbPath, 0711)
+	}
+	st := db.NewDB(db.BadgerImpl, dbPath)
+
+	smt := NewTrie(nil, common.Hasher, st)
+	keys := getFreshData(100, 32)
+	values := getFreshData(100, 32)
+	root, _ := smt.Update(keys, values)
+	smt.Commit()
+
+	var walked [][]byte
+	err := smt.WalkLeaves(root, nil, func(key, value []byte) (bool, error) {
+		walked = append(walked, key)
+		return true, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(walked) != len(keys) {
+		t.Fatalf("expected %d leaves, got %d", len(keys), len(walked))
+	}
+	for i := 1; i < len(walked); i++ {
+		if bytes.Compare(walked[i-1], walked[i]) >= 0 {
+			t.Fatal("leaves are not walked in the order of the keys")
+		}
+	}
+
+	// resume from a key in the middle
+	from := walked[42]
+	var resumed [][]byte
+	smt.WalkLeaves(root, from, func(key, value []byte) (bool, error) {
+		resumed = append(resumed, key)
+		return len(resumed) < 10, nil
+	})
+	if len(resumed) != 10 {
+		t.Fatalf("expected 10 leaves, got %d", len(resumed))
+	}
+	for i, key := range resumed {
+		if !bytes.Equal(key, walked[42+i]) {
+			t.Fatal("resumed walk returned a wrong leaf")
+		}
+	}
+	st.Close()
+	os.RemoveAll(".aergo")
+}
+
 func TestTrieRevert(t *testing.T) {
 	dbPath := path.Join(".aergo", "db")
 	if _, err := os.Stat(dbPath); os.IsNotExist(err) {
@@ -185,3 +185,80 @@ func (s *Trie) Stash(rollbackCache bool) error {
 	}
 	return nil
 }
+
+// Walk traverses the trie of the given root in depth first order. visitNode is
+// called with the key of every node stored in the db (the root of a batch);
+// the subtree of the node is skipped if it returns false. visitLeaf is called
+// with the key and the value of every leaf reached.
+func (s *Trie) Walk(root []byte, visitNode func(node []byte) bool, visitLeaf func(key, value []byte) error) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	s.atomicUpdate = false
+	return s.walk(root, nil, 0, s.TrieHeight, visitNode, visitLeaf)
+}
+
+// walk visits the nodes and the leaves of the subtree of root
+func (s *Trie) walk(root []byte, batch [][]byte, iBatch, height int,
+	visitNode func(node []byte) bool, visitLeaf func(key, value []byte) error) error {
+	if len(root) == 0 {
+		return nil
+	}
+	if height%4 == 0 && !visitNode(root[:HashLength]) {
+		return nil
+	}
+	// Fetch the children of the node
+	batch, iBatch, lnode, rnode, isShortcut, err := s.loadChildren(root, height, iBatch, batch)
+	if err != nil {
+		return err
+	}
+	if isShortcut {
+		return visitLeaf(lnode[:HashLength], rnode[:HashLength])
+	}
+	if err := s.walk(lnode, batch, 2*iBatch+1, height-1, visitNode, visitLeaf); err != nil {
+		return err
+	}
+	return s.walk(rnode, batch, 2*iBatch+2, height-1, visitNode, visitLeaf)
+}
+
+// WalkLeaves visits the leaves of the trie of the given root whose keys are
+// greater than or equal to from, in the ascending order of the keys. All the
+// leaves are visited if from is nil. The walk stops when visitLeaf returns
+// false or an error.
+func (s *Trie) WalkLeaves(root, from []byte, visitLeaf func(key, value []byte) (bool, error)) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	s.atomicUpdate = false
+	_, err := s.walkLeaves(root, from, nil, 0, s.TrieHeight, visitLeaf)
+	return err
+}
+
+// walkLeaves visits the leaves of the subtree of root. from is nil if all the
+// keys of the subtree are greater than the bound. It returns false if the walk
+// must stop.
+func (s *Trie) walkLeaves(root, from []byte, batch [][]byte, iBatch, height int,
+	visitLeaf func(key, value []byte) (bool, error)) (bool, error) {
+	if len(root) == 0 {
+		return true, nil
+	}
+	// Fetch the children of the node
+	batch, iBatch, lnode, rnode, isShortcut, err := s.loadChildren(root, height, iBatch, batch)
+	if err != nil {
+		return false, err
+	}
+	if isShortcut {
+		key := lnode[:HashLength]
+		if from != nil && bytes.Compare(key, from) < 0 {
+			return true, nil
+		}
+		return visitLeaf(key, rnode[:HashLength])
+	}
+	lfrom, rfrom := from, []byte(nil)
+	if from != nil && bitIsSet(from, s.TrieHeight-height) {
+		// every key of the left subtree is less than the bound
+		lnode, rfrom = nil, from
+	}
+	if cont, err := s.walkLeaves(lnode, lfrom, batch, 2*iBatch+1, height-1, visitLeaf); !cont || err != nil {
+		return cont, err
+	}
+	return s.walkLeaves(rnode, rfrom, batch, 2*iBatch+2, height-1, visitLeaf)
+}
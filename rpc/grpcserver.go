@@ -648,13 +648,24 @@ func (rpc *AergoRPCService) CommitTX(ctx context.Context, in *types.TxList) (*ty
 	}
 }
 
+// blockRef specifies the block whose state is queried. The state of the best
+// block is queried if it is empty.
+type blockRef struct {
+	no   types.BlockNo
+	hash []byte
+}
+
 // GetState handle rpc request getstate
-func (rpc *AergoRPCService) GetState(ctx context.Context, in *types.SingleBytes) (*types.State, error) {
+func (rpc *AergoRPCService) GetState(ctx context.Context, in *types.AccountAtBlock) (*types.State, error) {
+	return rpc.getState(ctx, in.Value, blockRef{no: in.BlockNo, hash: in.BlockHash})
+}
+
+func (rpc *AergoRPCService) getState(ctx context.Context, account []byte, at blockRef) (*types.State, error) {
 	if err := rpc.checkAuth(ctx, ReadBlockChain); err != nil {
 		return nil, err
 	}
 	result, err := rpc.hub.RequestFuture(message.ChainSvc,
-		&message.GetState{Account: in.Value}, defaultActorTimeout, "rpc.(*AergoRPCService).GetState").Result()
+		&message.GetState{Account: account, BlockNo: at.no, BlockHash: at.hash}, defaultActorTimeout, "rpc.(*AergoRPCService).GetState").Result()
 	if err != nil {
 		return nil, err
 	}
@@ -978,15 +989,19 @@ func (rpc *AergoRPCService) GetAccountVotes(ctx context.Context, in *types.Accou
 
 //GetStaking handle rpc request getstaking
 func (rpc *AergoRPCService) GetStaking(ctx context.Context, in *types.AccountAddress) (*types.Staking, error) {
+	return rpc.getStaking(ctx, in.Value, blockRef{})
+}
+
+func (rpc *AergoRPCService) getStaking(ctx context.Context, addr []byte, at blockRef) (*types.Staking, error) {
 	if err := rpc.checkAuth(ctx, ReadBlockChain); err != nil {
 		return nil, err
 	}
 	var err error
 	var result interface{}
 
-	if len(in.Value) <= types.AddressLength {
+	if len(addr) <= types.AddressLength {
 		result, err = rpc.hub.RequestFuture(message.ChainSvc,
-			&message.GetStaking{Addr: in.Value}, defaultActorTimeout, "rpc.(*AergoRPCService).GetStaking").Result()
+			&message.GetStaking{Addr: addr, BlockNo: at.no, BlockHash: at.hash}, defaultActorTimeout, "rpc.(*AergoRPCService).GetStaking").Result()
 		if err != nil {
 			return nil, err
 		}
@@ -1001,11 +1016,15 @@ func (rpc *AergoRPCService) GetStaking(ctx context.Context, in *types.AccountAdd
 }
 
 func (rpc *AergoRPCService) GetNameInfo(ctx context.Context, in *types.Name) (*types.NameInfo, error) {
+	return rpc.getNameInfo(ctx, in.Name, blockRef{no: in.BlockNo})
+}
+
+func (rpc *AergoRPCService) getNameInfo(ctx context.Context, name string, at blockRef) (*types.NameInfo, error) {
 	if err := rpc.checkAuth(ctx, ReadBlockChain); err != nil {
 		return nil, err
 	}
 	result, err := rpc.hub.RequestFuture(message.ChainSvc,
-		&message.GetNameInfo{Name: in.Name, BlockNo: in.BlockNo}, defaultActorTimeout, "rpc.(*AergoRPCService).GetName").Result()
+		&message.GetNameInfo{Name: name, BlockNo: at.no, BlockHash: at.hash}, defaultActorTimeout, "rpc.(*AergoRPCService).GetName").Result()
 	if err != nil {
 		return nil, err
 	}
@@ -1035,12 +1054,58 @@ func (rpc *AergoRPCService) GetReceipt(ctx context.Context, in *types.SingleByte
 	return rsp.Receipt, rsp.Err
 }
 
-func (rpc *AergoRPCService) GetABI(ctx context.Context, in *types.SingleBytes) (*types.ABI, error) {
+// GetTxProof returns the tx of the hash with the merkle proof of its
+// inclusion in the block.
+func (rpc *AergoRPCService) GetTxProof(ctx context.Context, in *types.SingleBytes) (*types.TxProof, error) {
+	if err := rpc.checkAuth(ctx, ReadBlockChain); err != nil {
+		return nil, err
+	}
+	result, err := rpc.hub.RequestFuture(message.ChainSvc,
+		&message.GetTxProof{TxHash: in.Value}, defaultActorTimeout, "rpc.(*AergoRPCService).GetTxProof").Result()
+	if err != nil {
+		return nil, err
+	}
+	rsp, ok := result.(message.GetTxProofRsp)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "internal type (%v) error", reflect.TypeOf(result))
+	}
+	if rsp.Err != nil {
+		return nil, status.Error(codes.NotFound, rsp.Err.Error())
+	}
+	return rsp.Proof, nil
+}
+
+// GetReceiptProof returns the receipt of the tx of the hash with the merkle
+// proof of its inclusion in the block.
+func (rpc *AergoRPCService) GetReceiptProof(ctx context.Context, in *types.SingleBytes) (*types.ReceiptProof, error) {
+	if err := rpc.checkAuth(ctx, ReadBlockChain); err != nil {
+		return nil, err
+	}
+	result, err := rpc.hub.RequestFuture(message.ChainSvc,
+		&message.GetReceiptProof{TxHash: in.Value}, defaultActorTimeout, "rpc.(*AergoRPCService).GetReceiptProof").Result()
+	if err != nil {
+		return nil, err
+	}
+	rsp, ok := result.(message.GetReceiptProofRsp)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "internal type (%v) error", reflect.TypeOf(result))
+	}
+	if rsp.Err != nil {
+		return nil, status.Error(codes.NotFound, rsp.Err.Error())
+	}
+	return rsp.Proof, nil
+}
+
+func (rpc *AergoRPCService) GetABI(ctx context.Context, in *types.AccountAtBlock) (*types.ABI, error) {
+	return rpc.getABI(ctx, in.Value, blockRef{no: in.BlockNo, hash: in.BlockHash})
+}
+
+func (rpc *AergoRPCService) getABI(ctx context.Context, contract []byte, at blockRef) (*types.ABI, error) {
 	if err := rpc.checkAuth(ctx, ReadBlockChain); err != nil {
 		return nil, err
 	}
 	result, err := rpc.hub.RequestFuture(message.ChainSvc,
-		&message.GetABI{Contract: in.Value}, defaultActorTimeout, "rpc.(*AergoRPCService).GetABI").Result()
+		&message.GetABI{Contract: contract, BlockNo: at.no, BlockHash: at.hash}, defaultActorTimeout, "rpc.(*AergoRPCService).GetABI").Result()
 	if err != nil {
 		return nil, err
 	}
@@ -1051,12 +1116,34 @@ func (rpc *AergoRPCService) GetABI(ctx context.Context, in *types.SingleBytes) (
 	return rsp.ABI, rsp.Err
 }
 
+// ListContractVersions returns the code versions of a contract and the
+// blocks from which they are active.
+func (rpc *AergoRPCService) ListContractVersions(ctx context.Context, in *types.AccountAtBlock) (*types.ContractVersionList, error) {
+	return rpc.listContractVersions(ctx, in.Value, blockRef{no: in.BlockNo, hash: in.BlockHash})
+}
+
+func (rpc *AergoRPCService) listContractVersions(ctx context.Context, contract []byte, at blockRef) (*types.ContractVersionList, error) {
+	if err := rpc.checkAuth(ctx, ReadBlockChain); err != nil {
+		return nil, err
+	}
+	result, err := rpc.hub.RequestFuture(message.ChainSvc,
+		&message.GetContractVersions{Contract: contract, BlockNo: at.no, BlockHash: at.hash}, defaultActorTimeout, "rpc.(*AergoRPCService).ListContractVersions").Result()
+	if err != nil {
+		return nil, err
+	}
+	rsp, ok := result.(message.GetContractVersionsRsp)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "internal type (%v) error", reflect.TypeOf(result))
+	}
+	return rsp.Versions, rsp.Err
+}
+
 func (rpc *AergoRPCService) QueryContract(ctx context.Context, in *types.Query) (*types.SingleBytes, error) {
 	if err := rpc.checkAuth(ctx, ReadBlockChain); err != nil {
 		return nil, err
 	}
 	result, err := rpc.hub.RequestFuture(message.ChainSvc,
-		&message.GetQuery{Contract: in.ContractAddress, Queryinfo: in.Queryinfo}, defaultActorTimeout, "rpc.(*AergoRPCService).QueryContract").Result()
+		&message.GetQuery{Contract: in.ContractAddress, Queryinfo: in.Queryinfo, BlockNo: in.BlockNo, BlockHash: in.BlockHash}, defaultActorTimeout, "rpc.(*AergoRPCService).QueryContract").Result()
 	if err != nil {
 		return nil, err
 	}
@@ -1067,6 +1154,48 @@ func (rpc *AergoRPCService) QueryContract(ctx context.Context, in *types.Query)
 	return &types.SingleBytes{Value: rsp.Result}, rsp.Err
 }
 
+// SimulateTx executes a transaction against the state of the best block
+// without sending it, and returns the receipt it would have in the next block.
+func (rpc *AergoRPCService) SimulateTx(ctx context.Context, in *types.Tx) (*types.Receipt, error) {
+	if err := rpc.checkAuth(ctx, ReadBlockChain); err != nil {
+		return nil, err
+	}
+	if in.GetBody() == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "tx body is required")
+	}
+	result, err := rpc.hub.RequestFuture(message.ChainSvc,
+		&message.SimulateTx{Tx: in}, defaultActorTimeout, "rpc.(*AergoRPCService).SimulateTx").Result()
+	if err != nil {
+		return nil, err
+	}
+	rsp, ok := result.(message.SimulateTxRsp)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "internal type (%v) error", reflect.TypeOf(result))
+	}
+	if rsp.Err != nil {
+		return nil, status.Error(codes.FailedPrecondition, rsp.Err.Error())
+	}
+	return rsp.Receipt, nil
+}
+
+// GetTipStat returns the distribution of the priority tips of the ready
+// transactions in the mempool.
+func (rpc *AergoRPCService) GetTipStat(ctx context.Context, in *types.Empty) (*types.TipStat, error) {
+	if err := rpc.checkAuth(ctx, ReadBlockChain); err != nil {
+		return nil, err
+	}
+	result, err := rpc.hub.RequestFuture(message.MemPoolSvc,
+		&message.MemPoolTipStat{}, defaultActorTimeout, "rpc.(*AergoRPCService).GetTipStat").Result()
+	if err != nil {
+		return nil, err
+	}
+	rsp, ok := result.(*message.MemPoolTipStatRsp)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "internal type (%v) error", reflect.TypeOf(result))
+	}
+	return rsp.Stat, nil
+}
+
 // QueryContractState queries the state of a contract state variable without executing a contract function.
 func (rpc *AergoRPCService) QueryContractState(ctx context.Context, in *types.StateQuery) (*types.StateQueryProof, error) {
 	if err := rpc.checkAuth(ctx, ReadBlockChain); err != nil {
@@ -1104,6 +1233,12 @@ func (rpc *AergoRPCService) ListEventStream(in *types.FilterInfo, stream types.A
 		return err
 	}
 
+	if in.Blockfrom != 0 || in.RecentBlockCnt > 0 {
+		if err := rpc.sendPastEvents(in, stream); err != nil {
+			return err
+		}
+	}
+
 	eventStream := &EventStream{in, stream}
 	rpc.eventStreamLock.Lock()
 	rpc.eventStream[eventStream] = eventStream
@@ -1120,6 +1255,29 @@ func (rpc *AergoRPCService) ListEventStream(in *types.FilterInfo, stream types.A
 	}
 }
 
+// sendPastEvents sends the events already in the chain before the new events
+// are streamed. The events are looked up from the event index if it is enabled.
+func (rpc *AergoRPCService) sendPastEvents(in *types.FilterInfo, stream types.AergoRPCService_ListEventStreamServer) error {
+	result, err := rpc.hub.RequestFuture(message.ChainSvc,
+		&message.ListEvents{Filter: in}, defaultActorTimeout, "rpc.(*AergoRPCService).ListEventStream").Result()
+	if err != nil {
+		return err
+	}
+	rsp, ok := result.(*message.ListEventsRsp)
+	if !ok {
+		return status.Errorf(codes.Internal, "internal type (%v) error", reflect.TypeOf(result))
+	}
+	if rsp.Err != nil {
+		return rsp.Err
+	}
+	for _, event := range rsp.Events {
+		if err := stream.Send(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (rpc *AergoRPCService) BroadcastToEventStream(events []*types.Event) error {
 	var err error
 	rpc.eventStreamLock.RLock()
@@ -0,0 +1,876 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/aergoio/aergo/config"
+	"github.com/aergoio/aergo/internal/enc"
+	"github.com/aergoio/aergo/types"
+	"github.com/aergoio/aergo/types/jsonrpc"
+	"github.com/gorilla/websocket"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// The JSON-RPC gateway serves a subset of AergoRPCService as JSON-RPC 2.0
+// methods over HTTP and WebSocket. Every method is mapped onto the gRPC
+// handler of the same function, so that the permission checks of the gRPC API
+// apply as they are. Hashes and payloads are encoded in base58 and addresses
+// in the aergo address format, like the outputs of aergocli.
+const (
+	jsonRPCVersion = "2.0"
+
+	jsonRPCMaxRequestSize = 1 << 24
+	jsonRPCWriteTimeout   = 10 * time.Second
+
+	// jsonRPCNotification is the method name of the notifications sent to
+	// WebSocket subscribers.
+	jsonRPCNotification = "aergo_subscription"
+)
+
+// Error codes defined by the JSON-RPC 2.0 specification.
+const (
+	jsonRPCParseError     = -32700
+	jsonRPCInvalidRequest = -32600
+	jsonRPCMethodNotFound = -32601
+	jsonRPCInvalidParams  = -32602
+	jsonRPCServerError    = -32000
+)
+
+var errJSONRPCSubscriptionNotSupported = errors.New("subscription is only available over WebSocket")
+
+type jsonRPCRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type jsonRPCResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *jsonRPCError   `json:"error,omitempty"`
+}
+
+type jsonRPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *jsonRPCError) Error() string {
+	return e.Message
+}
+
+type jsonRPCNotificationParams struct {
+	Subscription string      `json:"subscription"`
+	Result       interface{} `json:"result"`
+}
+
+type jsonRPCNotificationMsg struct {
+	JSONRPC string                    `json:"jsonrpc"`
+	Method  string                    `json:"method"`
+	Params  jsonRPCNotificationParams `json:"params"`
+}
+
+func invalidParams(format string, args ...interface{}) *jsonRPCError {
+	return &jsonRPCError{Code: jsonRPCInvalidParams, Message: fmt.Sprintf(format, args...)}
+}
+
+// toJSONRPCError converts an error returned by the gRPC handlers.
+func toJSONRPCError(err error) *jsonRPCError {
+	if e, ok := err.(*jsonRPCError); ok {
+		return e
+	}
+	if s, ok := status.FromError(err); ok {
+		return &jsonRPCError{Code: jsonRPCServerError, Message: s.Message()}
+	}
+	return &jsonRPCError{Code: jsonRPCServerError, Message: err.Error()}
+}
+
+type jsonRPCHandler func(s *jsonRPCService, ctx context.Context, c *jsonRPCConn, params []json.RawMessage) (interface{}, error)
+
+var jsonRPCMethods = map[string]jsonRPCHandler{
+	"aergo_blockchain":           (*jsonRPCService).blockchain,
+	"aergo_getBlock":             (*jsonRPCService).getBlock,
+	"aergo_getTx":                (*jsonRPCService).getTx,
+	"aergo_getReceipt":           (*jsonRPCService).getReceipt,
+	"aergo_getTxProof":           (*jsonRPCService).getTxProof,
+	"aergo_getReceiptProof":      (*jsonRPCService).getReceiptProof,
+	"aergo_sendTx":               (*jsonRPCService).sendTx,
+	"aergo_simulateTx":           (*jsonRPCService).simulateTx,
+	"aergo_getTipStat":           (*jsonRPCService).getTipStat,
+	"aergo_getState":             (*jsonRPCService).getState,
+	"aergo_getABI":               (*jsonRPCService).getABI,
+	"aergo_listContractVersions": (*jsonRPCService).listContractVersions,
+	"aergo_getStaking":           (*jsonRPCService).getStaking,
+	"aergo_getNameInfo":          (*jsonRPCService).getNameInfo,
+	"aergo_queryContract":        (*jsonRPCService).queryContract,
+	"aergo_listEvents":           (*jsonRPCService).listEvents,
+	"aergo_subscribe":            (*jsonRPCService).subscribe,
+	"aergo_unsubscribe":          (*jsonRPCService).unsubscribe,
+}
+
+// jsonRPCService handles JSON-RPC requests by calling AergoRPCService.
+type jsonRPCService struct {
+	rpc       *AergoRPCService
+	allowCORS bool
+	upgrader  websocket.Upgrader
+	subID     uint64
+}
+
+func newJSONRPCServer(rpc *AergoRPCService, cfg *config.RPCConfig) (*http.Server, error) {
+	s := &jsonRPCService{
+		rpc:       rpc,
+		allowCORS: cfg.NSAllowCORS,
+	}
+	if cfg.NSAllowCORS {
+		s.upgrader.CheckOrigin = func(r *http.Request) bool { return true }
+	}
+
+	server := &http.Server{
+		Handler:        s,
+		ReadTimeout:    4 * time.Second,
+		WriteTimeout:   jsonRPCWriteTimeout,
+		MaxHeaderBytes: 1 << 20,
+	}
+	if cfg.NSEnableTLS {
+		// never fall back to plaintext, which would expose the credentials
+		// meant for TLS
+		tlsConfig, err := newServerTLSConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		server.TLSConfig = tlsConfig
+	}
+	return server, nil
+}
+
+// Serve JSON-RPC gateway over TCP
+func (ns *RPC) serveJSONRPC() {
+	ipAddr := net.ParseIP(ns.conf.RPC.NetServiceAddr)
+	if ipAddr == nil {
+		panic("Wrong IP address format in RPC.NetServiceAddr")
+	}
+
+	addr := fmt.Sprintf("%s:%d", ipAddr, ns.conf.RPC.NSJsonRPCPort)
+
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		panic(err)
+	}
+
+	ns.Info().Msg(fmt.Sprintf("Starting JSON-RPC server listening on %s, with TLS: %v", addr, ns.jsonRPCServer.TLSConfig != nil))
+
+	if ns.jsonRPCServer.TLSConfig != nil {
+		err = ns.jsonRPCServer.ServeTLS(l, "", "")
+	} else {
+		err = ns.jsonRPCServer.Serve(l)
+	}
+	if err != nil && err != http.ErrServerClosed {
+		panic(err)
+	}
+}
+
+// peerContext attaches the address and TLS state of the client to ctx in the
+// same way as gRPC, so that checkAuth works for the JSON-RPC requests.
+func peerContext(ctx context.Context, r *http.Request) context.Context {
+	p := &peer.Peer{}
+	if addr, err := net.ResolveTCPAddr("tcp", r.RemoteAddr); err == nil {
+		p.Addr = addr
+	}
+	if r.TLS != nil {
+		p.AuthInfo = credentials.TLSInfo{State: *r.TLS}
+	}
+	return peer.NewContext(ctx, p)
+}
+
+func (s *jsonRPCService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.allowCORS {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+		w.Header().Set("Access-Control-Allow-Methods", "POST, GET, OPTIONS")
+	}
+
+	switch {
+	case r.Method == http.MethodOptions:
+		w.WriteHeader(http.StatusOK)
+	case r.Method == http.MethodGet && websocket.IsWebSocketUpgrade(r):
+		s.serveWebSocket(w, r)
+	case r.Method == http.MethodPost:
+		s.servePost(w, r)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *jsonRPCService) servePost(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, jsonRPCMaxRequestSize+1))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(body) > jsonRPCMaxRequestSize {
+		http.Error(w, "request too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	out := s.handleMessage(peerContext(r.Context(), r), nil, body)
+	if out == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(out)
+}
+
+func (s *jsonRPCService) serveWebSocket(w http.ResponseWriter, r *http.Request) {
+	ws, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		logger.Debug().Err(err).Msg("failed to upgrade json-rpc connection")
+		return
+	}
+	// The deadline set by the http server is not needed for a long-lived
+	// connection.
+	ws.SetReadDeadline(time.Time{})
+	ws.SetReadLimit(jsonRPCMaxRequestSize)
+
+	ctx, cancel := context.WithCancel(peerContext(context.Background(), r))
+	c := &jsonRPCConn{ws: ws, subs: map[string]context.CancelFunc{}}
+	defer func() {
+		cancel()
+		ws.Close()
+	}()
+
+	for {
+		_, msg, err := ws.ReadMessage()
+		if err != nil {
+			return
+		}
+		if out := s.handleMessage(ctx, c, msg); out != nil {
+			if err := c.write(out); err != nil {
+				return
+			}
+		}
+		c.startSubscriptions()
+	}
+}
+
+// handleMessage handles a single request or a batch of requests. It returns
+// nil if no response is needed.
+func (s *jsonRPCService) handleMessage(ctx context.Context, c *jsonRPCConn, msg []byte) interface{} {
+	var batch []json.RawMessage
+	if err := json.Unmarshal(msg, &batch); err != nil {
+		var req jsonRPCRequest
+		if err := json.Unmarshal(msg, &req); err != nil {
+			return &jsonRPCResponse{JSONRPC: jsonRPCVersion,
+				Error: &jsonRPCError{Code: jsonRPCParseError, Message: err.Error()}}
+		}
+		return s.handleRequest(ctx, c, &req)
+	}
+
+	if len(batch) == 0 {
+		return &jsonRPCResponse{JSONRPC: jsonRPCVersion,
+			Error: &jsonRPCError{Code: jsonRPCInvalidRequest, Message: "empty batch"}}
+	}
+	var rsps []*jsonRPCResponse
+	for _, raw := range batch {
+		var req jsonRPCRequest
+		if err := json.Unmarshal(raw, &req); err != nil {
+			rsps = append(rsps, &jsonRPCResponse{JSONRPC: jsonRPCVersion,
+				Error: &jsonRPCError{Code: jsonRPCInvalidRequest, Message: err.Error()}})
+			continue
+		}
+		if rsp := s.handleRequest(ctx, c, &req); rsp != nil {
+			rsps = append(rsps, rsp)
+		}
+	}
+	if len(rsps) == 0 {
+		return nil
+	}
+	return rsps
+}
+
+func (s *jsonRPCService) handleRequest(ctx context.Context, c *jsonRPCConn, req *jsonRPCRequest) *jsonRPCResponse {
+	rsp := &jsonRPCResponse{JSONRPC: jsonRPCVersion, ID: req.ID}
+
+	if req.JSONRPC != jsonRPCVersion || len(req.Method) == 0 {
+		rsp.Error = &jsonRPCError{Code: jsonRPCInvalidRequest, Message: "invalid request"}
+		return rsp
+	}
+
+	handler, exist := jsonRPCMethods[req.Method]
+	if !exist {
+		rsp.Error = &jsonRPCError{Code: jsonRPCMethodNotFound, Message: "method not found: " + req.Method}
+		return rsp
+	}
+
+	var params []json.RawMessage
+	if len(req.Params) != 0 && string(req.Params) != "null" {
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			rsp.Error = invalidParams("params must be an array")
+			return rsp
+		}
+	}
+
+	result, err := handler(s, ctx, c, params)
+	if req.ID == nil {
+		// notification
+		return nil
+	}
+	if err != nil {
+		rsp.Error = toJSONRPCError(err)
+	} else {
+		rsp.Result = result
+	}
+	return rsp
+}
+
+// param decodes the i-th parameter into v. It returns false if the parameter
+// is omitted.
+func param(params []json.RawMessage, i int, v interface{}) (bool, error) {
+	if i >= len(params) || string(params[i]) == "null" {
+		return false, nil
+	}
+	if err := json.Unmarshal(params[i], v); err != nil {
+		return true, invalidParams("invalid parameter %d: %s", i, err.Error())
+	}
+	return true, nil
+}
+
+func requiredParam(params []json.RawMessage, i int, v interface{}) error {
+	exist, err := param(params, i, v)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return invalidParams("missing parameter %d", i)
+	}
+	return nil
+}
+
+func hashParam(params []json.RawMessage, i int) ([]byte, error) {
+	var s string
+	if err := requiredParam(params, i, &s); err != nil {
+		return nil, err
+	}
+	hash, err := enc.ToBytes(s)
+	if err != nil {
+		return nil, invalidParams("invalid hash: %s", err.Error())
+	}
+	return hash, nil
+}
+
+// blockParam decodes the block number or the block hash of the i-th
+// parameter. An empty blockRef is returned if the parameter is omitted.
+func blockParam(params []json.RawMessage, i int) (blockRef, error) {
+	var no uint64
+	if exist, err := param(params, i, &no); !exist || err == nil {
+		return blockRef{no: no}, nil
+	}
+	hash, err := hashParam(params, i)
+	if err != nil {
+		return blockRef{}, invalidParams("invalid block: parameter %d must be a block number or hash", i)
+	}
+	return blockRef{hash: hash}, nil
+}
+
+func addressParam(params []json.RawMessage, i int) ([]byte, error) {
+	var s string
+	if err := requiredParam(params, i, &s); err != nil {
+		return nil, err
+	}
+	addr, err := types.DecodeAddress(s)
+	if err != nil {
+		return nil, invalidParams("invalid address: %s", err.Error())
+	}
+	return addr, nil
+}
+
+func (s *jsonRPCService) blockchain(ctx context.Context, c *jsonRPCConn, params []json.RawMessage) (interface{}, error) {
+	status, err := s.rpc.Blockchain(ctx, &types.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(jsonrpc.ConvBlockchainStatus(status)), nil
+}
+
+// getBlock returns the block of the given hash or block number.
+func (s *jsonRPCService) getBlock(ctx context.Context, c *jsonRPCConn, params []json.RawMessage) (interface{}, error) {
+	if len(params) == 0 {
+		return nil, invalidParams("missing parameter 0")
+	}
+	at, err := blockParam(params, 0)
+	if err != nil {
+		return nil, err
+	}
+	in := types.SingleBytes{Value: at.hash}
+	if len(at.hash) == 0 {
+		in.Value = types.BlockNoToBytes(at.no)
+	}
+	block, err := s.rpc.GetBlock(ctx, &in)
+	if err != nil {
+		return nil, err
+	}
+	return jsonrpc.ConvBlock(block), nil
+}
+
+// getTx returns the tx of the given hash from the mempool or the chain.
+func (s *jsonRPCService) getTx(ctx context.Context, c *jsonRPCConn, params []json.RawMessage) (interface{}, error) {
+	hash, err := hashParam(params, 0)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := s.rpc.GetTX(ctx, &types.SingleBytes{Value: hash})
+	if err == nil {
+		return jsonrpc.ConvTx(tx), nil
+	}
+	txInBlock, err := s.rpc.GetBlockTX(ctx, &types.SingleBytes{Value: hash})
+	if err != nil {
+		return nil, err
+	}
+	return jsonrpc.ConvTxInBlock(txInBlock), nil
+}
+
+func (s *jsonRPCService) getReceipt(ctx context.Context, c *jsonRPCConn, params []json.RawMessage) (interface{}, error) {
+	hash, err := hashParam(params, 0)
+	if err != nil {
+		return nil, err
+	}
+	return s.rpc.GetReceipt(ctx, &types.SingleBytes{Value: hash})
+}
+
+// getTxProof returns a tx with the merkle proof of its inclusion in the
+// block.
+func (s *jsonRPCService) getTxProof(ctx context.Context, c *jsonRPCConn, params []json.RawMessage) (interface{}, error) {
+	hash, err := hashParam(params, 0)
+	if err != nil {
+		return nil, err
+	}
+	proof, err := s.rpc.GetTxProof(ctx, &types.SingleBytes{Value: hash})
+	if err != nil {
+		return nil, err
+	}
+	return jsonrpc.ConvTxProof(proof), nil
+}
+
+// getReceiptProof returns a receipt with the merkle proof of its inclusion
+// in the block.
+func (s *jsonRPCService) getReceiptProof(ctx context.Context, c *jsonRPCConn, params []json.RawMessage) (interface{}, error) {
+	hash, err := hashParam(params, 0)
+	if err != nil {
+		return nil, err
+	}
+	proof, err := s.rpc.GetReceiptProof(ctx, &types.SingleBytes{Value: hash})
+	if err != nil {
+		return nil, err
+	}
+	return jsonrpc.ConvReceiptProof(proof), nil
+}
+
+// sendTx commits a signed tx in the same json format as aergocli.
+func (s *jsonRPCService) sendTx(ctx context.Context, c *jsonRPCConn, params []json.RawMessage) (interface{}, error) {
+	if len(params) == 0 {
+		return nil, invalidParams("missing parameter 0")
+	}
+	txs, err := jsonrpc.ParseBase58Tx(params[0])
+	if err != nil {
+		return nil, invalidParams("invalid tx: %s", err.Error())
+	}
+	if len(txs) != 1 {
+		return nil, invalidParams("only a single tx is allowed")
+	}
+	result, err := s.rpc.SendTX(ctx, txs[0])
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"hash":   enc.ToString(result.Hash),
+		"error":  result.Error.String(),
+		"detail": result.Detail,
+	}, nil
+}
+
+// simulateTx executes a tx in the same json format as aergocli without
+// sending it and returns its receipt.
+func (s *jsonRPCService) simulateTx(ctx context.Context, c *jsonRPCConn, params []json.RawMessage) (interface{}, error) {
+	if len(params) == 0 {
+		return nil, invalidParams("missing parameter 0")
+	}
+	txs, err := jsonrpc.ParseBase58Tx(params[0])
+	if err != nil {
+		return nil, invalidParams("invalid tx: %s", err.Error())
+	}
+	if len(txs) != 1 {
+		return nil, invalidParams("only a single tx is allowed")
+	}
+	return s.rpc.SimulateTx(ctx, txs[0])
+}
+
+// getTipStat returns the distribution of the priority tips of the ready txs
+// in the mempool. The tips are per unit of gas in aer.
+func (s *jsonRPCService) getTipStat(ctx context.Context, c *jsonRPCConn, params []json.RawMessage) (interface{}, error) {
+	stat, err := s.rpc.GetTipStat(ctx, &types.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	tips := make(map[string]string, len(stat.GetTips()))
+	for i, tip := range stat.GetTips() {
+		tips[fmt.Sprintf("p%d", types.TipStatPercentiles[i])] = new(big.Int).SetBytes(tip).String()
+	}
+	return map[string]interface{}{
+		"blockVersion": stat.GetBlockVersion(),
+		"gasPrice":     new(big.Int).SetBytes(stat.GetGasPrice()).String(),
+		"count":        stat.GetCount(),
+		"tipped":       stat.GetTipped(),
+		"tips":         tips,
+	}, nil
+}
+
+// getState returns the state of an account. The state at a past block is
+// returned if the block number or hash is given as the second parameter.
+func (s *jsonRPCService) getState(ctx context.Context, c *jsonRPCConn, params []json.RawMessage) (interface{}, error) {
+	addr, err := addressParam(params, 0)
+	if err != nil {
+		return nil, err
+	}
+	at, err := blockParam(params, 1)
+	if err != nil {
+		return nil, err
+	}
+	state, err := s.rpc.getState(ctx, addr, at)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"account": types.EncodeAddress(addr),
+		"nonce":   state.GetNonce(),
+		"balance": new(big.Int).SetBytes(state.GetBalance()).String(),
+	}, nil
+}
+
+func (s *jsonRPCService) getABI(ctx context.Context, c *jsonRPCConn, params []json.RawMessage) (interface{}, error) {
+	addr, err := addressParam(params, 0)
+	if err != nil {
+		return nil, err
+	}
+	at, err := blockParam(params, 1)
+	if err != nil {
+		return nil, err
+	}
+	return s.rpc.getABI(ctx, addr, at)
+}
+
+// listContractVersions returns the code versions of a contract.
+func (s *jsonRPCService) listContractVersions(ctx context.Context, c *jsonRPCConn, params []json.RawMessage) (interface{}, error) {
+	addr, err := addressParam(params, 0)
+	if err != nil {
+		return nil, err
+	}
+	at, err := blockParam(params, 1)
+	if err != nil {
+		return nil, err
+	}
+	versions, err := s.rpc.listContractVersions(ctx, addr, at)
+	if err != nil {
+		return nil, err
+	}
+	return jsonrpc.ConvContractVersions(versions), nil
+}
+
+func (s *jsonRPCService) getStaking(ctx context.Context, c *jsonRPCConn, params []json.RawMessage) (interface{}, error) {
+	addr, err := addressParam(params, 0)
+	if err != nil {
+		return nil, err
+	}
+	at, err := blockParam(params, 1)
+	if err != nil {
+		return nil, err
+	}
+	staking, err := s.rpc.getStaking(ctx, addr, at)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"account": types.EncodeAddress(addr),
+		"amount":  staking.GetAmountBigInt().String(),
+		"when":    staking.GetWhen(),
+	}, nil
+}
+
+func (s *jsonRPCService) getNameInfo(ctx context.Context, c *jsonRPCConn, params []json.RawMessage) (interface{}, error) {
+	var name string
+	if err := requiredParam(params, 0, &name); err != nil {
+		return nil, err
+	}
+	at, err := blockParam(params, 1)
+	if err != nil {
+		return nil, err
+	}
+	info, err := s.rpc.getNameInfo(ctx, name, at)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"name":        info.GetName().GetName(),
+		"owner":       types.EncodeAddress(info.GetOwner()),
+		"destination": types.EncodeAddress(info.GetDestination()),
+	}, nil
+}
+
+// queryContract calls a query function of a contract. The params are the
+// contract address, the function name, the arguments and optionally the block
+// at which the query is executed.
+func (s *jsonRPCService) queryContract(ctx context.Context, c *jsonRPCConn, params []json.RawMessage) (interface{}, error) {
+	addr, err := addressParam(params, 0)
+	if err != nil {
+		return nil, err
+	}
+	var ci types.CallInfo
+	if err := requiredParam(params, 1, &ci.Name); err != nil {
+		return nil, err
+	}
+	if _, err := param(params, 2, &ci.Args); err != nil {
+		return nil, err
+	}
+	at, err := blockParam(params, 3)
+	if err != nil {
+		return nil, err
+	}
+	queryInfo, err := json.Marshal(ci)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.rpc.QueryContract(ctx, &types.Query{ContractAddress: addr, Queryinfo: queryInfo, BlockNo: at.no, BlockHash: at.hash})
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Value) == 0 {
+		return json.RawMessage("null"), nil
+	}
+	return json.RawMessage(result.Value), nil
+}
+
+// jsonRPCFilter is the json form of types.FilterInfo.
+type jsonRPCFilter struct {
+	Address        string          `json:"address"`
+	EventName      string          `json:"eventName"`
+	BlockFrom      uint64          `json:"blockFrom"`
+	BlockTo        uint64          `json:"blockTo"`
+	Desc           bool            `json:"desc"`
+	ArgFilter      json.RawMessage `json:"argFilter"`
+	RecentBlockCnt int32           `json:"recentBlockCnt"`
+}
+
+func filterParam(params []json.RawMessage, i int) (*types.FilterInfo, error) {
+	var f jsonRPCFilter
+	if err := requiredParam(params, i, &f); err != nil {
+		return nil, err
+	}
+	addr, err := types.DecodeAddress(f.Address)
+	if err != nil {
+		return nil, invalidParams("invalid address: %s", err.Error())
+	}
+	filter := &types.FilterInfo{
+		ContractAddress: addr,
+		EventName:       f.EventName,
+		Blockfrom:       f.BlockFrom,
+		Blockto:         f.BlockTo,
+		Desc:            f.Desc,
+		RecentBlockCnt:  f.RecentBlockCnt,
+	}
+	if len(f.ArgFilter) != 0 && string(f.ArgFilter) != "null" {
+		filter.ArgFilter = f.ArgFilter
+	}
+	return filter, nil
+}
+
+func (s *jsonRPCService) listEvents(ctx context.Context, c *jsonRPCConn, params []json.RawMessage) (interface{}, error) {
+	filter, err := filterParam(params, 0)
+	if err != nil {
+		return nil, err
+	}
+	list, err := s.rpc.ListEvents(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return list.GetEvents(), nil
+}
+
+// subscribe starts a stream of new blocks ("newBlock") or contract events
+// ("events", filter). The stream is registered to AergoRPCService in the same
+// way as ListBlockStream and ListEventStream of the gRPC API.
+func (s *jsonRPCService) subscribe(ctx context.Context, c *jsonRPCConn, params []json.RawMessage) (interface{}, error) {
+	if c == nil {
+		return nil, errJSONRPCSubscriptionNotSupported
+	}
+	if err := s.rpc.checkAuth(ctx, ReadBlockChain); err != nil {
+		return nil, err
+	}
+
+	var kind string
+	if err := requiredParam(params, 0, &kind); err != nil {
+		return nil, err
+	}
+
+	id := "0x" + strconv.FormatUint(atomic.AddUint64(&s.subID, 1), 16)
+	var start func(stream *jsonRPCStream)
+
+	switch kind {
+	case "newBlock":
+		start = func(stream *jsonRPCStream) {
+			s.rpc.ListBlockStream(&types.Empty{}, &jsonRPCBlockStream{stream})
+		}
+	case "events":
+		filter, err := filterParam(params, 1)
+		if err != nil {
+			return nil, err
+		}
+		if err := filter.ValidateCheck(0); err != nil {
+			return nil, invalidParams(err.Error())
+		}
+		if _, err := filter.GetExArgFilter(); err != nil {
+			return nil, invalidParams(err.Error())
+		}
+		start = func(stream *jsonRPCStream) {
+			if err := s.rpc.ListEventStream(filter, &jsonRPCEventStream{stream}); err != nil {
+				logger.Warn().Err(err).Str("id", stream.id).Msg("json-rpc event subscription stopped")
+				c.unsubscribe(stream.id)
+			}
+		}
+	default:
+		return nil, invalidParams("unknown subscription: %s", kind)
+	}
+
+	c.subscribe(ctx, id, start)
+	return id, nil
+}
+
+func (s *jsonRPCService) unsubscribe(ctx context.Context, c *jsonRPCConn, params []json.RawMessage) (interface{}, error) {
+	if c == nil {
+		return nil, errJSONRPCSubscriptionNotSupported
+	}
+	var id string
+	if err := requiredParam(params, 0, &id); err != nil {
+		return nil, err
+	}
+	return c.unsubscribe(id), nil
+}
+
+// jsonRPCConn is a WebSocket connection of the JSON-RPC gateway.
+type jsonRPCConn struct {
+	ws        *websocket.Conn
+	writeLock sync.Mutex
+
+	subLock sync.Mutex
+	subs    map[string]context.CancelFunc
+	pending []func()
+}
+
+func (c *jsonRPCConn) write(v interface{}) error {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	c.ws.SetWriteDeadline(time.Now().Add(jsonRPCWriteTimeout))
+	return c.ws.WriteJSON(v)
+}
+
+func (c *jsonRPCConn) notify(id string, result interface{}) error {
+	return c.write(&jsonRPCNotificationMsg{
+		JSONRPC: jsonRPCVersion,
+		Method:  jsonRPCNotification,
+		Params:  jsonRPCNotificationParams{Subscription: id, Result: result},
+	})
+}
+
+// subscribe registers a subscription. The stream is started by
+// startSubscriptions after the subscription id is sent to the client.
+func (c *jsonRPCConn) subscribe(ctx context.Context, id string, start func(stream *jsonRPCStream)) {
+	ctx, cancel := context.WithCancel(ctx)
+	stream := &jsonRPCStream{ctx: ctx, conn: c, id: id}
+
+	c.subLock.Lock()
+	defer c.subLock.Unlock()
+	c.subs[id] = cancel
+	c.pending = append(c.pending, func() { go start(stream) })
+}
+
+func (c *jsonRPCConn) startSubscriptions() {
+	c.subLock.Lock()
+	pending := c.pending
+	c.pending = nil
+	c.subLock.Unlock()
+
+	for _, start := range pending {
+		start()
+	}
+}
+
+func (c *jsonRPCConn) unsubscribe(id string) bool {
+	c.subLock.Lock()
+	defer c.subLock.Unlock()
+
+	cancel, exist := c.subs[id]
+	if !exist {
+		return false
+	}
+	cancel()
+	delete(c.subs, id)
+	return true
+}
+
+// jsonRPCStream implements grpc.ServerStream to register a subscription as a
+// stream of AergoRPCService.
+type jsonRPCStream struct {
+	ctx  context.Context
+	conn *jsonRPCConn
+	id   string
+}
+
+func (s *jsonRPCStream) SetHeader(metadata.MD) error  { return nil }
+func (s *jsonRPCStream) SendHeader(metadata.MD) error { return nil }
+func (s *jsonRPCStream) SetTrailer(metadata.MD)       {}
+func (s *jsonRPCStream) Context() context.Context     { return s.ctx }
+func (s *jsonRPCStream) RecvMsg(m interface{}) error  { return io.EOF }
+
+func (s *jsonRPCStream) SendMsg(m interface{}) error {
+	if err := s.ctx.Err(); err != nil {
+		return err
+	}
+	return s.conn.notify(s.id, m)
+}
+
+type jsonRPCBlockStream struct {
+	*jsonRPCStream
+}
+
+func (s *jsonRPCBlockStream) Send(block *types.Block) error {
+	return s.SendMsg(jsonrpc.ConvBlock(block))
+}
+
+type jsonRPCEventStream struct {
+	*jsonRPCStream
+}
+
+func (s *jsonRPCEventStream) Send(event *types.Event) error {
+	return s.SendMsg(event)
+}
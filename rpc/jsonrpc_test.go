@@ -0,0 +1,147 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+package rpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aergoio/aergo/config"
+	"github.com/aergoio/aergo/message"
+	"github.com/aergoio/aergo/message/messagemock"
+	"github.com/aergoio/aergo/p2p/p2pmock"
+	"github.com/aergoio/aergo/types"
+	"github.com/golang/mock/gomock"
+	"github.com/mr-tron/base58/base58"
+	"github.com/stretchr/testify/assert"
+)
+
+type testJSONRPCResponse struct {
+	ID     json.RawMessage
+	Result json.RawMessage
+	Error  *jsonRPCError
+}
+
+func postJSONRPC(t *testing.T, server *httptest.Server, body string) (int, []byte) {
+	rsp, err := http.Post(server.URL, "application/json", bytes.NewBufferString(body))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer rsp.Body.Close()
+
+	buf := new(bytes.Buffer)
+	buf.ReadFrom(rsp.Body)
+	return rsp.StatusCode, buf.Bytes()
+}
+
+func newTestJSONRPCServer(t *testing.T, rpc *AergoRPCService) *httptest.Server {
+	server, err := newJSONRPCServer(rpc, &config.RPCConfig{})
+	assert.NoError(t, err)
+	return httptest.NewServer(server.Handler)
+}
+
+func TestJSONRPC_TLSNotAvailable(t *testing.T) {
+	rpc := &AergoRPCService{hub: hubStub}
+	server, err := newJSONRPCServer(rpc, &config.RPCConfig{NSEnableTLS: true,
+		NSCert: "no_such_cert", NSKey: "no_such_key", NSCACert: "no_such_ca"})
+	assert.Error(t, err, "should not serve in plaintext")
+	assert.Nil(t, server)
+}
+
+func TestJSONRPC_GetTx(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMsgHelper := messagemock.NewHelper(ctrl)
+	mockActorHelper := p2pmock.NewMockActorService(ctrl)
+
+	dummyTxBody := types.TxBody{Account: dummyWalletAddress, Amount: new(big.Int).SetUint64(4332).Bytes(),
+		Recipient: dummyWalletAddress2, Payload: dummyPayload}
+	sampleTx := &types.Tx{Hash: dummyTxHash, Body: &dummyTxBody}
+	mockActorHelper.EXPECT().CallRequestDefaultTimeout(message.MemPoolSvc, gomock.Any()).Return(message.MemPoolGetRsp{}, nil)
+	mockMsgHelper.EXPECT().ExtractTxFromResponse(gomock.AssignableToTypeOf(message.MemPoolGetRsp{})).Return(sampleTx, nil)
+
+	rpc := &AergoRPCService{hub: hubStub, actorHelper: mockActorHelper, msgHelper: mockMsgHelper}
+	server := newTestJSONRPCServer(t, rpc)
+	defer server.Close()
+
+	code, body := postJSONRPC(t, server,
+		`{"jsonrpc":"2.0","id":1,"method":"aergo_getTx","params":["`+base58.Encode(dummyTxHash)+`"]}`)
+	assert.Equal(t, http.StatusOK, code)
+
+	var rsp testJSONRPCResponse
+	assert.NoError(t, json.Unmarshal(body, &rsp))
+	assert.Nil(t, rsp.Error)
+	assert.Equal(t, "1", string(rsp.ID))
+
+	var tx struct{ Hash string }
+	assert.NoError(t, json.Unmarshal(rsp.Result, &tx))
+	assert.Equal(t, base58.Encode(dummyTxHash), tx.Hash)
+}
+
+func TestJSONRPC_Errors(t *testing.T) {
+	rpc := &AergoRPCService{hub: hubStub}
+	server := newTestJSONRPCServer(t, rpc)
+	defer server.Close()
+
+	tests := []struct {
+		name string
+		body string
+		code int
+	}{
+		{"parse", `{"jsonrpc":"2.0",`, jsonRPCParseError},
+		{"version", `{"jsonrpc":"1.0","id":1,"method":"aergo_getTx"}`, jsonRPCInvalidRequest},
+		{"method", `{"jsonrpc":"2.0","id":1,"method":"aergo_unknown"}`, jsonRPCMethodNotFound},
+		{"params", `{"jsonrpc":"2.0","id":1,"method":"aergo_getTx","params":{}}`, jsonRPCInvalidParams},
+		{"missing", `{"jsonrpc":"2.0","id":1,"method":"aergo_getState","params":[]}`, jsonRPCInvalidParams},
+		{"address", `{"jsonrpc":"2.0","id":1,"method":"aergo_getState","params":["xxx"]}`, jsonRPCInvalidParams},
+		{"subscribe", `{"jsonrpc":"2.0","id":1,"method":"aergo_subscribe","params":["newBlock"]}`, jsonRPCServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, body := postJSONRPC(t, server, tt.body)
+			var rsp testJSONRPCResponse
+			assert.NoError(t, json.Unmarshal(body, &rsp))
+			if assert.NotNil(t, rsp.Error) {
+				assert.Equal(t, tt.code, rsp.Error.Code)
+			}
+		})
+	}
+
+	// batch of a notification and a request
+	_, body := postJSONRPC(t, server,
+		`[{"jsonrpc":"2.0","method":"aergo_unknown"},{"jsonrpc":"2.0","id":"a","method":"aergo_unknown"}]`)
+	var rsps []testJSONRPCResponse
+	assert.NoError(t, json.Unmarshal(body, &rsps))
+	if assert.Len(t, rsps, 1) {
+		assert.Equal(t, `"a"`, string(rsps[0].ID))
+	}
+
+	// no response for a notification
+	code, _ := postJSONRPC(t, server, `{"jsonrpc":"2.0","method":"aergo_unknown"}`)
+	assert.Equal(t, http.StatusNoContent, code)
+}
+
+func TestJSONRPC_Permission(t *testing.T) {
+	rpc := &AergoRPCService{hub: hubStub}
+	rpc.setClientAuth(&types.EnterpriseConfig{On: true, Values: []string{"cert:R"}})
+
+	server := newTestJSONRPCServer(t, rpc)
+	defer server.Close()
+
+	// A client without a certificate is rejected by checkAuth.
+	_, body := postJSONRPC(t, server,
+		`{"jsonrpc":"2.0","id":1,"method":"aergo_getTx","params":["`+base58.Encode(dummyTxHash)+`"]}`)
+	var rsp testJSONRPCResponse
+	assert.NoError(t, json.Unmarshal(body, &rsp))
+	if assert.NotNil(t, rsp.Error) {
+		assert.Equal(t, jsonRPCServerError, rsp.Error.Code)
+		assert.Equal(t, "unexpected peer transport credentials", rsp.Error.Message)
+	}
+}
@@ -48,6 +48,7 @@ type RPC struct {
 	grpcWebServer *grpcweb.WrappedGrpcServer
 	actualServer  *AergoRPCService
 	httpServer    *http.Server
+	jsonRPCServer *http.Server
 
 	ca      types.ChainAccessor
 	version string
@@ -88,26 +89,9 @@ func NewRPC(cfg *config.Config, chainAccessor types.ChainAccessor, version strin
 	}
 
 	if cfg.RPC.NSEnableTLS {
-		certificate, err := tls.LoadX509KeyPair(cfg.RPC.NSCert, cfg.RPC.NSKey)
-		if err != nil {
-			logger.Error().Err(err).Msg("could not load server key pair")
-		}
-		certPool := x509.NewCertPool()
-		ca, err := ioutil.ReadFile(cfg.RPC.NSCACert)
-		if err != nil {
-			logger.Error().Err(err).Msg("could not read CA cert")
-		}
-		if ok := certPool.AppendCertsFromPEM(ca); !ok {
-			logger.Error().Bool("AppendCertsFromPEM", ok).Msg("failed to append server cert")
-			err = fmt.Errorf("failed to append server cert")
-		}
+		tlsConfig, err := newServerTLSConfig(cfg.RPC)
 		if err == nil {
-			creds := credentials.NewTLS(&tls.Config{
-				ClientAuth:   tls.RequireAndVerifyClientCert,
-				Certificates: []tls.Certificate{certificate},
-				ClientCAs:    certPool,
-			})
-			opts = append(opts, grpc.Creds(creds))
+			opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
 			logger.Info().Str("cert", cfg.RPC.NSCert).Str("key", cfg.RPC.NSKey).Msg("grpc with TLS")
 		}
 	}
@@ -142,9 +126,44 @@ func NewRPC(cfg *config.Config, chainAccessor types.ChainAccessor, version strin
 		MaxHeaderBytes: 1 << 20,
 	}
 
+	if cfg.RPC.NSJsonRPCPort > 0 {
+		jsonRPCServer, err := newJSONRPCServer(actualServer, cfg.RPC)
+		if err != nil {
+			logger.Error().Err(err).Msg("JSON-RPC gateway is not started since TLS is not available")
+		} else {
+			rpcsvc.jsonRPCServer = jsonRPCServer
+		}
+	}
+
 	return rpcsvc
 }
 
+// newServerTLSConfig returns the TLS configuration of the RPC service, which
+// requires client certificates signed by the configured CA.
+func newServerTLSConfig(cfg *config.RPCConfig) (*tls.Config, error) {
+	certificate, err := tls.LoadX509KeyPair(cfg.NSCert, cfg.NSKey)
+	if err != nil {
+		logger.Error().Err(err).Msg("could not load server key pair")
+	}
+	certPool := x509.NewCertPool()
+	ca, err := ioutil.ReadFile(cfg.NSCACert)
+	if err != nil {
+		logger.Error().Err(err).Msg("could not read CA cert")
+	}
+	if ok := certPool.AppendCertsFromPEM(ca); !ok {
+		logger.Error().Bool("AppendCertsFromPEM", ok).Msg("failed to append server cert")
+		err = fmt.Errorf("failed to append server cert")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		Certificates: []tls.Certificate{certificate},
+		ClientCAs:    certPool,
+	}, nil
+}
+
 func (ns *RPC) SetHub(hub *component.ComponentHub) {
 	ns.actualServer.hub = hub
 	ns.BaseComponent.SetHub(hub)
@@ -161,11 +180,17 @@ func (ns *RPC) BeforeStart() {
 
 func (ns *RPC) AfterStart() {
 	go ns.serve()
+	if ns.jsonRPCServer != nil {
+		go ns.serveJSONRPC()
+	}
 }
 
 // Stop stops rpc service.
 func (ns *RPC) BeforeStop() {
 	ns.httpServer.Close()
+	if ns.jsonRPCServer != nil {
+		ns.jsonRPCServer.Close()
+	}
 	ns.grpcServer.Stop()
 }
 
@@ -396,7 +421,7 @@ func convertError(err error) types.CommitStatus {
 		return types.CommitStatus_TX_INVALID_FORMAT
 	case types.ErrInsufficientBalance:
 		return types.CommitStatus_TX_INSUFFICIENT_BALANCE
-	case types.ErrSameNonceAlreadyInMempool:
+	case types.ErrSameNonceAlreadyInMempool, types.ErrTxReplaceUnderpriced:
 		return types.CommitStatus_TX_HAS_SAME_NONCE
 	default:
 		//logger.Info().Str("hash", err.Error()).Msg("RPC encountered unconvertable error")
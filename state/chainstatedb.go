@@ -3,6 +3,7 @@ package state
 import (
 	"fmt"
 	"math/big"
+	"path"
 	"sync"
 
 	"github.com/aergoio/aergo-lib/db"
@@ -16,6 +17,7 @@ type ChainStateDB struct {
 	sync.RWMutex
 	states   *StateDB
 	store    db.DB
+	guard    *pruneGuard
 	testmode bool
 }
 
@@ -31,6 +33,7 @@ func (sdb *ChainStateDB) Clone() *ChainStateDB {
 
 	newSdb := &ChainStateDB{
 		store:  sdb.store,
+		guard:  sdb.guard,
 		states: sdb.GetStateDB().Clone(),
 	}
 	return newSdb
@@ -45,7 +48,9 @@ func (sdb *ChainStateDB) Init(dbType string, dataDir string, bestBlock *types.Bl
 	// init db
 	if sdb.store == nil {
 		dbPath := common.PathMkdirAll(dataDir, stateName)
-		sdb.store = db.NewDB(db.ImplType(dbType), dbPath)
+		sdb.guard = newPruneGuard(db.NewDB(db.ImplType(dbType), dbPath),
+			db.ImplType(dbType), path.Join(dataDir, pruneMarkName))
+		sdb.store = sdb.guard
 	}
 
 	// init trie
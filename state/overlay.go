@@ -0,0 +1,115 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package state
+
+import (
+	"sync"
+
+	"github.com/aergoio/aergo-lib/db"
+)
+
+// overlayStore keeps the writes in memory on top of a read-only store. It
+// lets transactions be executed against the states of the chain without
+// writing anything to the disk.
+type overlayStore struct {
+	db.DB
+	lock    sync.RWMutex
+	entries map[string][]byte // nil value for a deleted key
+}
+
+func newOverlayStore(store db.DB) *overlayStore {
+	return &overlayStore{
+		DB:      store,
+		entries: make(map[string][]byte),
+	}
+}
+
+func (o *overlayStore) Set(key, value []byte) {
+	o.lock.Lock()
+	o.entries[string(key)] = append([]byte{}, value...)
+	o.lock.Unlock()
+}
+
+func (o *overlayStore) Delete(key []byte) {
+	o.lock.Lock()
+	o.entries[string(key)] = nil
+	o.lock.Unlock()
+}
+
+func (o *overlayStore) Get(key []byte) []byte {
+	o.lock.RLock()
+	value, exist := o.entries[string(key)]
+	o.lock.RUnlock()
+	if exist {
+		return value
+	}
+	return o.DB.Get(key)
+}
+
+func (o *overlayStore) Exist(key []byte) bool {
+	return len(o.Get(key)) != 0
+}
+
+func (o *overlayStore) NewTx() db.Transaction {
+	return &overlayBatch{store: o}
+}
+
+func (o *overlayStore) NewBulk() db.Bulk {
+	return &overlayBatch{store: o}
+}
+
+func (o *overlayStore) Close() {
+	// the underlying store is not owned by the overlay
+}
+
+type overlayOp struct {
+	key   []byte
+	value []byte
+	del   bool
+}
+
+// overlayBatch implements both db.Transaction and db.Bulk.
+type overlayBatch struct {
+	store *overlayStore
+	ops   []overlayOp
+}
+
+func (b *overlayBatch) Set(key, value []byte) {
+	b.ops = append(b.ops, overlayOp{key: key, value: value})
+}
+
+func (b *overlayBatch) Delete(key []byte) {
+	b.ops = append(b.ops, overlayOp{key: key, del: true})
+}
+
+func (b *overlayBatch) Commit() {
+	for _, op := range b.ops {
+		if op.del {
+			b.store.Delete(op.key)
+		} else {
+			b.store.Set(op.key, op.value)
+		}
+	}
+	b.ops = nil
+}
+
+func (b *overlayBatch) Discard() {
+	b.ops = nil
+}
+
+func (b *overlayBatch) Flush() {
+	b.Commit()
+}
+
+func (b *overlayBatch) DiscardLast() {
+	b.Discard()
+}
+
+// OpenTempStateDB returns a statedb of the given state root hash, whose
+// changes are kept in memory and never written to the state store.
+func (sdb *ChainStateDB) OpenTempStateDB(root []byte) *StateDB {
+	return NewStateDB(newOverlayStore(sdb.store), root, sdb.testmode)
+}
@@ -0,0 +1,32 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenTempStateDB(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+
+	root := stateDB.GetRoot()
+	tempDB := chainStateDB.OpenTempStateDB(root)
+
+	err := tempDB.PutState(testAccount, &testStates[0])
+	assert.NoError(t, err, "put state")
+	contractState, err := tempDB.OpenContractStateAccount(testAccount)
+	assert.NoError(t, err, "open contract state")
+	assert.NoError(t, contractState.SetCode([]byte("test_code")), "set code")
+	assert.NoError(t, tempDB.Update(), "update statedb")
+	assert.NoError(t, tempDB.Commit(), "commit statedb")
+
+	// the changes are visible in the temporary statedb only
+	assert.True(t, tempDB.HasMarker(tempDB.GetRoot()))
+	assert.False(t, stateDB.HasMarker(tempDB.GetRoot()))
+	assert.Empty(t, chainStateDB.store.Get(contractState.State.CodeHash))
+
+	st, err := chainStateDB.OpenNewStateDB(root).GetState(testAccount)
+	assert.NoError(t, err, "get state")
+	assert.Nil(t, st)
+}
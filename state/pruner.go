@@ -0,0 +1,359 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package state
+
+import (
+	"errors"
+	"os"
+	"sync"
+
+	"github.com/aergoio/aergo-lib/db"
+	"github.com/aergoio/aergo/internal/common"
+	"github.com/aergoio/aergo/pkg/trie"
+	"github.com/aergoio/aergo/types"
+)
+
+// The states are stored in a content addressed way: a trie node is keyed by
+// its hash, and an account state or a contract variable is keyed by the hash
+// of its value. So a key may be shared by the states of many blocks and the
+// key of a pruned state may be written again by a new block at any time.
+//
+// A pruning is done by mark and sweep:
+//
+//  1. mark: every trie node and value reachable from the retained roots
+//     (including the storage tries of the contracts) is collected as live.
+//  2. collect: every trie node and value reachable from the pruned roots but
+//     not live is collected as dead.
+//  3. sweep: the dead keys and the markers of the pruned roots are deleted.
+//
+// The live and dead keys are as many as the nodes of the whole state, so they
+// are kept in a temporary database instead of the memory, which is removed
+// when the pruning is finished.
+//
+// A pruning runs in the background while new blocks are connected. The keys
+// written to the store in the meantime are recorded by pruneGuard and never
+// swept. The contract codes are never deleted.
+//
+// NOTE: the states of the blocks which were reorganized out of the main
+// chain are not reachable from the pruned roots, so they are not collected.
+
+var (
+	errPruneRunning     = errors.New("state pruning is already running")
+	errPruneUnsupported = errors.New("state store does not support pruning")
+)
+
+const (
+	pruneSweepBatch = 10000
+
+	pruneMarkName   = stateName + ".mark"
+	pruneMarkLive   = byte('l')
+	pruneMarkDead   = byte('d')
+	pruneMarkBuffer = 10000
+)
+
+// pruneGuard wraps the store of the states to record the keys written while a
+// pruning is running.
+//
+// Its mutex is a leaf lock: it's held only to record a written key and, by the
+// pruning, to sweep a batch of keys, and no other lock is acquired while it's
+// held. So the writers, i.e. the chain service committing a block, never wait
+// for the pruning to acquire another lock, and may open any number of
+// transactions and bulks at once. A key can't be written and swept at the
+// same time: a key is recorded before it's written, so either the sweep sees
+// it recorded and skips it, or it's written after the sweep deleted it.
+type pruneGuard struct {
+	db.DB
+	markImpl db.ImplType
+	markDir  string
+	mutex    sync.Mutex
+	written  map[string]struct{}
+}
+
+func newPruneGuard(store db.DB, markImpl db.ImplType, markDir string) *pruneGuard {
+	return &pruneGuard{DB: store, markImpl: markImpl, markDir: markDir}
+}
+
+func (g *pruneGuard) record(key []byte) {
+	g.mutex.Lock()
+	if g.written != nil {
+		g.written[string(key)] = struct{}{}
+	}
+	g.mutex.Unlock()
+}
+
+// startRecord starts to record the written keys. It returns false if a
+// pruning is already running.
+func (g *pruneGuard) startRecord() bool {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if g.written != nil {
+		return false
+	}
+	g.written = make(map[string]struct{})
+	return true
+}
+
+func (g *pruneGuard) stopRecord() {
+	g.mutex.Lock()
+	g.written = nil
+	g.mutex.Unlock()
+}
+
+func (g *pruneGuard) Set(key, value []byte) {
+	g.record(key)
+	g.DB.Set(key, value)
+}
+
+func (g *pruneGuard) NewTx() db.Transaction {
+	return &guardedTx{Transaction: g.DB.NewTx(), guard: g}
+}
+
+func (g *pruneGuard) NewBulk() db.Bulk {
+	return &guardedBulk{Bulk: g.DB.NewBulk(), guard: g}
+}
+
+// sweep deletes the keys except for those written during the pruning.
+func (g *pruneGuard) sweep(keys [][]byte) int {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	var deleted int
+	bulk := g.DB.NewBulk()
+	for _, key := range keys {
+		if _, exist := g.written[string(key)]; exist {
+			continue
+		}
+		bulk.Delete(key)
+		deleted++
+	}
+	bulk.Flush()
+	return deleted
+}
+
+type guardedTx struct {
+	db.Transaction
+	guard *pruneGuard
+}
+
+func (tx *guardedTx) Set(key, value []byte) {
+	tx.guard.record(key)
+	tx.Transaction.Set(key, value)
+}
+
+type guardedBulk struct {
+	db.Bulk
+	guard *pruneGuard
+}
+
+func (bulk *guardedBulk) Set(key, value []byte) {
+	bulk.guard.record(key)
+	bulk.Bulk.Set(key, value)
+}
+
+// markSet is a set of the keys of the states kept in the temporary database
+// of a pruning. The keys added lately are buffered to be written in a bulk.
+type markSet struct {
+	store   db.DB
+	prefix  byte
+	pending map[types.HashID]struct{}
+}
+
+func newMarkSet(store db.DB, prefix byte) *markSet {
+	return &markSet{
+		store:   store,
+		prefix:  prefix,
+		pending: make(map[types.HashID]struct{}),
+	}
+}
+
+func (s *markSet) key(id types.HashID) []byte {
+	return append([]byte{s.prefix}, id[:]...)
+}
+
+func (s *markSet) has(key []byte) bool {
+	id := types.ToHashID(key)
+	if _, exist := s.pending[id]; exist {
+		return true
+	}
+	return s.store.Exist(s.key(id))
+}
+
+// add adds key to the set. It returns false if key is already in the set.
+func (s *markSet) add(key []byte) bool {
+	if s.has(key) {
+		return false
+	}
+	s.pending[types.ToHashID(key)] = struct{}{}
+	if len(s.pending) >= pruneMarkBuffer {
+		s.flush()
+	}
+	return true
+}
+
+func (s *markSet) flush() {
+	if len(s.pending) == 0 {
+		return
+	}
+	bulk := s.store.NewBulk()
+	for id := range s.pending {
+		bulk.Set(s.key(id), []byte{})
+	}
+	bulk.Flush()
+	s.pending = make(map[types.HashID]struct{})
+}
+
+// each calls fn with every key in the set.
+func (s *markSet) each(fn func(key []byte)) {
+	s.flush()
+	for iter := s.store.Iterator([]byte{s.prefix}, []byte{s.prefix + 1}); iter.Valid(); iter.Next() {
+		fn(iter.Key()[1:])
+	}
+}
+
+// Pruner deletes the states which are not reachable from the retained state
+// roots.
+type Pruner struct {
+	guard *pruneGuard
+	marks db.DB
+	live  *markSet
+	dead  *markSet
+}
+
+// NewPruner starts a pruning. From now on, the keys written to the state
+// store are protected from the pruning. It must be called before collecting
+// the state roots to retain, so that any state root appearing later is built
+// on a retained one.
+func (sdb *ChainStateDB) NewPruner() (*Pruner, error) {
+	if sdb.guard == nil {
+		return nil, errPruneUnsupported
+	}
+	if !sdb.guard.startRecord() {
+		return nil, errPruneRunning
+	}
+	// the marks left by an interrupted pruning are useless
+	if err := os.RemoveAll(sdb.guard.markDir); err != nil {
+		sdb.guard.stopRecord()
+		return nil, err
+	}
+	marks := db.NewDB(sdb.guard.markImpl, common.PathMkdirAll(sdb.guard.markDir))
+	return &Pruner{
+		guard: sdb.guard,
+		marks: marks,
+		live:  newMarkSet(marks, pruneMarkLive),
+		dead:  newMarkSet(marks, pruneMarkDead),
+	}, nil
+}
+
+// Close finishes the pruning and removes its marks. A new pruning can be
+// started after that.
+func (p *Pruner) Close() {
+	p.marks.Close()
+	if err := os.RemoveAll(p.guard.markDir); err != nil {
+		logger.Warn().Err(err).Str("dir", p.guard.markDir).Msg("failed to remove the marks of state pruning")
+	}
+	p.guard.stopRecord()
+}
+
+// Run deletes the states of the pruned roots except for the parts shared with
+// the states of the retained roots. It returns the number of deleted keys.
+func (p *Pruner) Run(retained, pruned [][]byte) (int, error) {
+	for _, root := range retained {
+		if err := p.mark(root); err != nil {
+			return 0, err
+		}
+	}
+
+	var markers [][]byte
+	for _, root := range pruned {
+		if len(root) == 0 {
+			continue
+		}
+		if p.live.has(root) {
+			continue
+		}
+		markers = append(markers, common.Hasher(root))
+	}
+	// delete the markers at first to make the pruned states unavailable
+	// before deleting their nodes.
+	deleted := p.guard.sweep(markers)
+
+	for _, root := range pruned {
+		if err := p.collect(root); err != nil {
+			// it may be partially pruned by an interrupted pruning
+			logger.Debug().Err(err).Msg("skip unavailable state while pruning")
+		}
+	}
+
+	keys := make([][]byte, 0, pruneSweepBatch)
+	p.dead.each(func(key []byte) {
+		keys = append(keys, append([]byte{}, key...))
+		if len(keys) == pruneSweepBatch {
+			deleted += p.guard.sweep(keys)
+			keys = keys[:0]
+		}
+	})
+	deleted += p.guard.sweep(keys)
+
+	return deleted, nil
+}
+
+func (p *Pruner) newTrie() *trie.Trie {
+	return trie.NewTrie(nil, common.Hasher, p.guard.DB)
+}
+
+func (p *Pruner) loadState(key []byte) (*types.State, error) {
+	st := &types.State{}
+	if err := loadData(p.guard.DB, key, st); err != nil {
+		return nil, err
+	}
+	return st, nil
+}
+
+// mark collects the keys reachable from root as live.
+func (p *Pruner) mark(root []byte) error {
+	visitValue := func(_, value []byte) error {
+		p.live.add(value)
+		return nil
+	}
+	return p.newTrie().Walk(root, p.live.add, func(_, value []byte) error {
+		if !p.live.add(value) {
+			return nil
+		}
+		st, err := p.loadState(value)
+		if err != nil {
+			return err
+		}
+		if len(st.CodeHash) != 0 {
+			// protect the code from being swept as a value of the same hash
+			p.live.add(st.CodeHash)
+		}
+		return p.newTrie().Walk(common.Compactz(st.StorageRoot), p.live.add, visitValue)
+	})
+}
+
+// collect collects the keys reachable from root but not live as dead.
+func (p *Pruner) collect(root []byte) error {
+	isGarbage := func(key []byte) bool {
+		if p.live.has(key) {
+			return false
+		}
+		return p.dead.add(key)
+	}
+	visitValue := func(_, value []byte) error {
+		isGarbage(value)
+		return nil
+	}
+	return p.newTrie().Walk(root, isGarbage, func(_, value []byte) error {
+		if !isGarbage(value) {
+			return nil
+		}
+		st, err := p.loadState(value)
+		if err != nil {
+			return err
+		}
+		return p.newTrie().Walk(common.Compactz(st.StorageRoot), isGarbage, visitValue)
+	})
+}
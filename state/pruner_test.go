@@ -0,0 +1,179 @@
+package state
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/aergoio/aergo/internal/common"
+	"github.com/aergoio/aergo/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func countStoreKeys() int {
+	var n int
+	for iter := chainStateDB.store.Iterator(nil, nil); iter.Valid(); iter.Next() {
+		n++
+	}
+	return n
+}
+
+func TestPrunerRun(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+
+	contractID := types.ToAccountID([]byte("test_contract"))
+	testKey := []byte("test_key")
+
+	var roots [][]byte
+	for i := 0; i < 10; i++ {
+		err := stateDB.PutState(testAccount, &types.State{Nonce: uint64(i + 1)})
+		assert.NoError(t, err, "put state")
+
+		contractState, err := stateDB.OpenContractStateAccount(contractID)
+		assert.NoError(t, err, "open contract state")
+		assert.NoError(t, contractState.SetData(testKey, []byte(fmt.Sprintf("value_%d", i))), "set data")
+		assert.NoError(t, stateDB.StageContractState(contractState), "stage contract state")
+
+		assert.NoError(t, stateDB.Update(), "update statedb")
+		assert.NoError(t, stateDB.Commit(), "commit statedb")
+		roots = append(roots, stateDB.GetRoot())
+	}
+	before := countStoreKeys()
+
+	pruner, err := chainStateDB.NewPruner()
+	assert.NoError(t, err, "new pruner")
+	_, err = chainStateDB.NewPruner()
+	assert.Equal(t, errPruneRunning, err)
+
+	deleted, err := pruner.Run(roots[8:], roots[:8])
+	assert.NoError(t, err, "run pruner")
+	pruner.Close()
+	_, err = os.Stat(path.Join("test", pruneMarkName))
+	assert.True(t, os.IsNotExist(err), "marks should be removed")
+	assert.True(t, deleted > 0)
+	assert.Equal(t, before-deleted, countStoreKeys())
+
+	for i, root := range roots {
+		assert.Equal(t, i >= 8, stateDB.HasMarker(root), "marker of root %d", i)
+	}
+
+	// the retained states are intact
+	for i := 8; i < 10; i++ {
+		states := chainStateDB.OpenNewStateDB(roots[i])
+		st, err := states.GetAccountState(testAccount)
+		assert.NoError(t, err, "get account state")
+		assert.Equal(t, uint64(i+1), st.GetNonce())
+
+		contractState, err := states.OpenContractStateAccount(contractID)
+		assert.NoError(t, err, "open contract state")
+		value, err := contractState.GetData(testKey)
+		assert.NoError(t, err, "get data")
+		assert.Equal(t, []byte(fmt.Sprintf("value_%d", i)), value)
+	}
+
+	// a pruned state is no more available
+	_, err = chainStateDB.OpenNewStateDB(roots[0]).GetAccountState(testAccount)
+	assert.Error(t, err)
+
+	// a new pruning can be started after the previous one is closed
+	pruner, err = chainStateDB.NewPruner()
+	assert.NoError(t, err, "new pruner")
+	_, err = pruner.Run(roots[9:], roots[8:9])
+	assert.NoError(t, err, "run pruner")
+	pruner.Close()
+	assert.False(t, stateDB.HasMarker(roots[8]))
+	assert.True(t, stateDB.HasMarker(roots[9]))
+}
+
+func TestPruneGuard(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+
+	guard := chainStateDB.guard
+	before := common.Hasher([]byte("written before"))
+	during := common.Hasher([]byte("written during"))
+	chainStateDB.store.Set(before, []byte{1})
+
+	assert.True(t, guard.startRecord())
+	assert.False(t, guard.startRecord())
+
+	bulk := chainStateDB.store.NewBulk()
+	bulk.Set(during, []byte{1})
+	bulk.Flush()
+
+	assert.Equal(t, 1, guard.sweep([][]byte{before, during}))
+	assert.False(t, chainStateDB.store.Exist(before))
+	assert.True(t, chainStateDB.store.Exist(during))
+
+	guard.stopRecord()
+	assert.True(t, guard.startRecord())
+	guard.stopRecord()
+}
+
+// TestPruneGuardLockOrder checks that the writers of the states, which may
+// open many transactions and bulks at once like the chain service, never
+// wait for a sweep running concurrently.
+func TestPruneGuardLockOrder(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+
+	guard := chainStateDB.guard
+	assert.True(t, guard.startRecord())
+	defer guard.stopRecord()
+
+	var keys [][]byte
+	for i := 0; i < pruneSweepBatch; i++ {
+		keys = append(keys, common.Hasher([]byte(fmt.Sprintf("swept %d", i))))
+	}
+	written := common.Hasher([]byte("written"))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		tx := chainStateDB.store.NewTx()
+		swept := make(chan int)
+		go func() { swept <- guard.sweep(append(keys, written)) }()
+		chainStateDB.store.Set(common.Hasher([]byte("set")), []byte{1})
+		bulk := chainStateDB.store.NewBulk()
+		bulk.Set(written, []byte{1})
+		bulk.Flush()
+		tx.Set(common.Hasher([]byte("tx")), []byte{1})
+		tx.Commit()
+		<-swept
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("writers are blocked by the sweep")
+	}
+	assert.True(t, chainStateDB.store.Exist(written))
+}
+
+func TestMarkSet(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+
+	pruner, err := chainStateDB.NewPruner()
+	assert.NoError(t, err, "new pruner")
+	defer pruner.Close()
+
+	key := common.Hasher([]byte("key"))
+	assert.True(t, pruner.live.add(key))
+	assert.False(t, pruner.live.add(key))
+	pruner.live.flush()
+	assert.True(t, pruner.live.has(key))
+	assert.False(t, pruner.dead.has(key), "sets should be separate")
+
+	for i := 0; i < pruneMarkBuffer; i++ {
+		pruner.dead.add(common.Hasher([]byte(fmt.Sprintf("key %d", i))))
+	}
+	var n int
+	pruner.dead.each(func(key []byte) {
+		assert.True(t, pruner.dead.has(key))
+		n++
+	})
+	assert.Equal(t, pruneMarkBuffer, n)
+}
@@ -0,0 +1,141 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package state
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/aergoio/aergo/internal/common"
+	"github.com/aergoio/aergo/pkg/trie"
+)
+
+// A state snapshot is transferred as the leaves of the tries: the account
+// trie of a block and the storage tries of its contracts. A leaf is sent with
+// the value it refers to, and the receiver rebuilds the trie from the leaves,
+// so that the snapshot is verified by the root of the rebuilt trie.
+
+var (
+	ErrSnapshotInvalid = errors.New("invalid state snapshot")
+	ErrSnapshotRoot    = errors.New("state snapshot does not match the root")
+)
+
+// GetSnapshotLeaves returns the leaves of the trie of root whose keys are
+// greater than after, in the ascending order of the keys, with the values
+// they refer to. At most maxLeaves leaves are returned, and the leaves are
+// cut if their total size exceeds maxSize. more is true if there are leaves
+// left.
+func (sdb *ChainStateDB) GetSnapshotLeaves(root, after []byte, maxLeaves, maxSize int) (keys, values [][]byte, more bool, err error) {
+	var size int
+	err = trie.NewTrie(nil, common.Hasher, sdb.store).WalkLeaves(common.Compactz(root), after, func(key, value []byte) (bool, error) {
+		if bytes.Equal(key, after) {
+			return true, nil
+		}
+		if len(keys) >= maxLeaves || size >= maxSize {
+			more = true
+			return false, nil
+		}
+		data := sdb.store.Get(value)
+		if len(data) == 0 {
+			return false, ErrSnapshotInvalid
+		}
+		keys = append(keys, key)
+		values = append(values, data)
+		size += len(key) + len(data)
+		return true, nil
+	})
+	return
+}
+
+// GetSnapshotValues returns the values stored by the given hashes, such as
+// the contract codes. The value of a missing hash is nil.
+func (sdb *ChainStateDB) GetSnapshotValues(hashes [][]byte) [][]byte {
+	values := make([][]byte, len(hashes))
+	for i, hash := range hashes {
+		if data := sdb.store.Get(hash); len(data) != 0 {
+			values[i] = data
+		}
+	}
+	return values
+}
+
+// ImportSnapshotValue stores a value received by its hash after verifying it.
+func (sdb *ChainStateDB) ImportSnapshotValue(hash, value []byte) error {
+	if !bytes.Equal(common.Hasher(value), hash) {
+		return ErrSnapshotInvalid
+	}
+	sdb.store.Set(hash, value)
+	return nil
+}
+
+// MarkSnapshot marks the state of root as finalized after all of its parts
+// are imported, so that it can be opened as the state of a block.
+func (sdb *ChainStateDB) MarkSnapshot(root []byte) {
+	sdb.store.Set(common.Hasher(root), stateMarker)
+}
+
+// TrieImporter rebuilds a trie from its leaves received in the ascending
+// order of the keys.
+type TrieImporter struct {
+	sdb  *ChainStateDB
+	trie *trie.Trie
+	root []byte
+	last []byte
+}
+
+// NewTrieImporter returns an importer of the trie of root.
+func (sdb *ChainStateDB) NewTrieImporter(root []byte) *TrieImporter {
+	return &TrieImporter{
+		sdb:  sdb,
+		trie: trie.NewTrie(nil, common.Hasher, sdb.store),
+		root: common.Compactz(root),
+	}
+}
+
+// Last returns the key of the last imported leaf.
+func (ti *TrieImporter) Last() []byte {
+	return ti.last
+}
+
+// Add imports the leaves following the previously imported ones. values are
+// the values which the leaves refer to. The trie nodes are written to the
+// store at each call.
+func (ti *TrieImporter) Add(keys, values [][]byte) error {
+	if len(keys) != len(values) {
+		return ErrSnapshotInvalid
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	hashes := make([][]byte, len(values))
+	for i, key := range keys {
+		if len(key) != trie.HashLength || bytes.Compare(key, ti.last) <= 0 || len(values[i]) == 0 {
+			return ErrSnapshotInvalid
+		}
+		ti.last = key
+		hashes[i] = common.Hasher(values[i])
+	}
+
+	bulk := ti.sdb.store.NewBulk()
+	for i, hash := range hashes {
+		bulk.Set(hash, values[i])
+	}
+	if _, err := ti.trie.Update(keys, hashes); err != nil {
+		bulk.DiscardLast()
+		return err
+	}
+	ti.trie.StageUpdates(bulk)
+	bulk.Flush()
+	return nil
+}
+
+// Finish verifies the rebuilt trie against the root.
+func (ti *TrieImporter) Finish() error {
+	if !bytes.Equal(ti.trie.Root, ti.root) {
+		return ErrSnapshotRoot
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package state
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/aergoio/aergo-lib/db"
+	"github.com/aergoio/aergo/types"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotImport(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+
+	contractID := types.ToAccountID([]byte("test_contract"))
+	for i := 0; i < 30; i++ {
+		id := types.ToAccountID([]byte(fmt.Sprintf("account_%d", i)))
+		assert.NoError(t, stateDB.PutState(id, &types.State{Nonce: uint64(i + 1)}), "put state")
+	}
+	contractState, err := stateDB.OpenContractStateAccount(contractID)
+	assert.NoError(t, err, "open contract state")
+	assert.NoError(t, contractState.SetCode([]byte("test_code")), "set code")
+	for i := 0; i < 20; i++ {
+		key := []byte(fmt.Sprintf("key_%d", i))
+		assert.NoError(t, contractState.SetData(key, []byte(fmt.Sprintf("value_%d", i))), "set data")
+	}
+	assert.NoError(t, stateDB.PutState(contractID, contractState.State), "put contract state")
+	assert.NoError(t, stateDB.StageContractState(contractState), "stage contract state")
+	assert.NoError(t, stateDB.Update(), "update statedb")
+	assert.NoError(t, stateDB.Commit(), "commit statedb")
+	root := stateDB.GetRoot()
+
+	target := NewChainStateDB()
+	assert.NoError(t, target.Init(string(db.BadgerImpl), "test_snapshot", nil, false))
+	defer func() {
+		_ = target.Close()
+		_ = os.RemoveAll("test_snapshot")
+	}()
+
+	importTrie := func(root []byte) [][]byte {
+		var values [][]byte
+		importer := target.NewTrieImporter(root)
+		for {
+			keys, chunk, more, err := chainStateDB.GetSnapshotLeaves(root, importer.Last(), 7, 1<<20)
+			assert.NoError(t, err, "get snapshot leaves")
+			assert.NoError(t, importer.Add(keys, chunk), "add leaves")
+			values = append(values, chunk...)
+			if !more {
+				break
+			}
+		}
+		assert.NoError(t, importer.Finish(), "finish import")
+		return values
+	}
+
+	for _, value := range importTrie(root) {
+		st := &types.State{}
+		assert.NoError(t, proto.Unmarshal(value, st), "unmarshal state")
+		if len(st.StorageRoot) != 0 {
+			importTrie(st.StorageRoot)
+		}
+		if len(st.CodeHash) != 0 {
+			code := chainStateDB.GetSnapshotValues([][]byte{st.CodeHash})[0]
+			assert.NoError(t, target.ImportSnapshotValue(st.CodeHash, code), "import code")
+		}
+	}
+	target.MarkSnapshot(root)
+
+	imported := target.OpenNewStateDB(root)
+	assert.True(t, imported.HasMarker(root))
+	st, err := imported.GetAccountState(types.ToAccountID([]byte("account_7")))
+	assert.NoError(t, err, "get account state")
+	assert.Equal(t, uint64(8), st.GetNonce())
+
+	importedContract, err := imported.OpenContractStateAccount(contractID)
+	assert.NoError(t, err, "open contract state")
+	code, err := importedContract.GetCode()
+	assert.NoError(t, err, "get code")
+	assert.Equal(t, []byte("test_code"), code)
+	value, err := importedContract.GetData([]byte("key_13"))
+	assert.NoError(t, err, "get data")
+	assert.Equal(t, []byte("value_13"), value)
+
+	// a tampered leaf is detected by the root
+	keys, values, _, err := chainStateDB.GetSnapshotLeaves(root, nil, 100, 1<<20)
+	assert.NoError(t, err, "get snapshot leaves")
+	values[3] = []byte("tampered")
+	importer := target.NewTrieImporter(root)
+	assert.NoError(t, importer.Add(keys, values))
+	assert.Equal(t, ErrSnapshotRoot, importer.Finish())
+
+	// the leaves must follow the previous ones
+	assert.Equal(t, ErrSnapshotInvalid, importer.Add(keys[:1], values[:1]))
+	assert.Equal(t, ErrSnapshotInvalid, target.ImportSnapshotValue(keys[0], []byte("tampered")))
+}
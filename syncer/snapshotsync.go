@@ -0,0 +1,330 @@
+package syncer
+
+import (
+	"bytes"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/aergoio/aergo/contract"
+	"github.com/aergoio/aergo/internal/enc"
+	"github.com/aergoio/aergo/message"
+	"github.com/aergoio/aergo/pkg/component"
+	"github.com/aergoio/aergo/state"
+	"github.com/aergoio/aergo/types"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+)
+
+// SnapshotSync downloads the state of a trusted block from the sync peer, so
+// that an empty chain starts from that block instead of the genesis block.
+// The block is verified by its hash given in the configuration, the account
+// trie by the BlocksRootHash of the block, the storage tries by the storage
+// roots in the account states and the SQL databases by the hashes committed
+// in the contract storages.
+type SnapshotSync struct {
+	compRequester component.IComponentRequester
+	sdb           *state.ChainStateDB
+
+	blockNo   types.BlockNo
+	blockHash []byte
+
+	ctx types.SyncContext
+
+	quitCh chan interface{}
+
+	dfltTimeout time.Duration
+
+	isRunning bool
+	waitGroup *sync.WaitGroup
+}
+
+var snapshotMaxHashes = 256
+
+var (
+	ErrSnapshotQuit    = errors.New("snapshot sync quit")
+	ErrSnapshotTimeout = errors.New("snapshot sync timeout")
+	ErrSnapshotBlock   = errors.New("invalid snapshot block")
+	ErrSnapshotSQL     = errors.New("invalid snapshot sql database")
+)
+
+func newSnapshotSync(ctx *types.SyncContext, compRequester component.IComponentRequester, sdb *state.ChainStateDB,
+	blockNo types.BlockNo, blockHash []byte, cfg *SyncerConfig) *SnapshotSync {
+	ss := &SnapshotSync{ctx: *ctx, compRequester: compRequester, sdb: sdb, blockNo: blockNo, blockHash: blockHash}
+
+	ss.dfltTimeout = cfg.fetchTimeOut
+	ss.quitCh = make(chan interface{})
+
+	return ss
+}
+
+func (ss *SnapshotSync) start() {
+	ss.waitGroup = &sync.WaitGroup{}
+	ss.waitGroup.Add(1)
+	ss.isRunning = true
+
+	run := func() {
+		defer RecoverSyncer(NameSnapshotSync, ss.GetSeq(), ss.compRequester, func() { ss.waitGroup.Done() })
+
+		logger.Info().Uint64("no", ss.blockNo).Msg("start to download state snapshot")
+
+		block, err := ss.download(ss.blockNo)
+		if err != nil {
+			logger.Error().Err(err).Msg("quit snapshot sync")
+			stopSyncer(ss.compRequester, ss.GetSeq(), NameSnapshotSync, err)
+			return
+		}
+
+		ss.compRequester.TellTo(message.SyncerSvc, &message.SnapshotResult{Seq: ss.GetSeq(), Block: block})
+		logger.Info().Msg("stopped snapshot sync successfully")
+	}
+
+	go run()
+}
+
+func (ss *SnapshotSync) stop() {
+	if ss == nil {
+		return
+	}
+
+	if ss.isRunning {
+		logger.Debug().Msg("snapshot sync closed quitChannel")
+
+		close(ss.quitCh)
+		ss.isRunning = false
+	}
+
+	ss.waitGroup.Wait()
+}
+
+func (ss *SnapshotSync) GetSeq() uint64 {
+	return ss.ctx.Seq
+}
+
+func (ss *SnapshotSync) download(blockNo types.BlockNo) (*types.Block, error) {
+	block, err := ss.getBlock(blockNo)
+	if err != nil {
+		return nil, err
+	}
+	root := block.GetHeader().GetBlocksRootHash()
+
+	var storageRoots, codeHashes [][]byte
+	sqlAccounts := make(map[types.AccountID]bool)
+
+	err = ss.importTrie(root, func(key, value []byte) error {
+		st := &types.State{}
+		if err := proto.Unmarshal(value, st); err != nil {
+			return err
+		}
+		if len(st.GetStorageRoot()) != 0 {
+			storageRoots = append(storageRoots, st.GetStorageRoot())
+		}
+		if len(st.GetCodeHash()) != 0 {
+			codeHashes = append(codeHashes, st.GetCodeHash())
+		}
+		if st.GetSqlRecoveryPoint() > 0 {
+			sqlAccounts[types.AccountID(types.ToHashID(key))] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	logger.Info().Int("contracts", len(storageRoots)).Msg("account trie of snapshot downloaded")
+
+	for _, storageRoot := range storageRoots {
+		if err = ss.importTrie(storageRoot, nil); err != nil {
+			return nil, err
+		}
+	}
+	if err = ss.importValues(codeHashes); err != nil {
+		return nil, err
+	}
+	// The hashes of the SQL databases are committed from the hardfork V3.
+	if len(sqlAccounts) != 0 && types.DecodeChainIdVersion(block.GetHeader().GetChainID()) < 3 {
+		logger.Error().Uint64("no", blockNo).Msg("sql databases of snapshot before the hardfork V3 cannot be verified")
+		return nil, ErrSnapshotSQL
+	}
+	if err = ss.importSQLDatabases(ss.sdb.OpenNewStateDB(root), sqlAccounts); err != nil {
+		return nil, err
+	}
+
+	ss.sdb.MarkSnapshot(root)
+
+	result, err := ss.compRequester.RequestToFutureResult(message.ChainSvc, &message.InstallSnapshot{Block: block}, ss.dfltTimeout, "SnapshotSync/install")
+	if err != nil {
+		return nil, err
+	}
+	if err = result.(message.InstallSnapshotRsp).Err; err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+func (ss *SnapshotSync) request(req *types.GetStateSnapshotRequest) (*types.GetStateSnapshotResponse, error) {
+	replyC := make(chan *message.GetStateSnapshotRsp, 1)
+	ss.compRequester.TellTo(message.P2PSvc, &message.GetStateSnapshot{ToWhom: ss.ctx.PeerID, Req: req, ReplyC: replyC})
+
+	timer := time.NewTimer(ss.dfltTimeout)
+	defer timer.Stop()
+
+	select {
+	case rsp := <-replyC:
+		if rsp.Err != nil {
+			return nil, rsp.Err
+		}
+		return rsp.Rsp, nil
+	case <-timer.C:
+		return nil, ErrSnapshotTimeout
+	case <-ss.quitCh:
+		return nil, ErrSnapshotQuit
+	}
+}
+
+func (ss *SnapshotSync) getBlock(blockNo types.BlockNo) (*types.Block, error) {
+	rsp, err := ss.request(&types.GetStateSnapshotRequest{Kind: types.SnapshotKind_SNAPSHOT_BLOCK, BlockNo: blockNo})
+	if err != nil {
+		return nil, err
+	}
+
+	block := &types.Block{}
+	if err = proto.Unmarshal(rsp.Data, block); err != nil {
+		return nil, err
+	}
+	hash := block.GetHash()
+	block.Hash = nil
+	if block.BlockNo() != blockNo || !bytes.Equal(hash, ss.blockHash) || !bytes.Equal(hash, block.BlockHash()) ||
+		!bytes.Equal(block.GetHeader().GetTxsRootHash(), types.CalculateTxsRootHash(block.GetBody().GetTxs())) {
+		return nil, ErrSnapshotBlock
+	}
+	return block, nil
+}
+
+// importTrie downloads the leaves of the trie of root and rebuilds it. visit
+// is called with each leaf after the leaves are added.
+func (ss *SnapshotSync) importTrie(root []byte, visit func(key, value []byte) error) error {
+	importer := ss.sdb.NewTrieImporter(root)
+	for {
+		rsp, err := ss.request(&types.GetStateSnapshotRequest{Kind: types.SnapshotKind_SNAPSHOT_LEAVES, Root: root, After: importer.Last()})
+		if err != nil {
+			return err
+		}
+		if err = importer.Add(rsp.Keys, rsp.Values); err != nil {
+			return err
+		}
+		if visit != nil {
+			for i, key := range rsp.Keys {
+				if err = visit(key, rsp.Values[i]); err != nil {
+					return err
+				}
+			}
+		}
+		if !rsp.HasNext {
+			break
+		}
+		if len(rsp.Keys) == 0 {
+			return state.ErrSnapshotInvalid
+		}
+	}
+	return importer.Finish()
+}
+
+func (ss *SnapshotSync) importValues(hashes [][]byte) error {
+	for len(hashes) > 0 {
+		n := len(hashes)
+		if n > snapshotMaxHashes {
+			n = snapshotMaxHashes
+		}
+		rsp, err := ss.request(&types.GetStateSnapshotRequest{Kind: types.SnapshotKind_SNAPSHOT_VALUES, Hashes: hashes[:n]})
+		if err != nil {
+			return err
+		}
+		if len(rsp.Values) != n {
+			return state.ErrSnapshotInvalid
+		}
+		for i, value := range rsp.Values {
+			if err = ss.sdb.ImportSnapshotValue(hashes[i], value); err != nil {
+				return err
+			}
+		}
+		hashes = hashes[n:]
+	}
+	return nil
+}
+
+// importSQLDatabases downloads the SQL databases of the contracts in accounts.
+// A database may contain the commits following the snapshot block, which are
+// reverted to the recovery point of the contract when it is opened.
+func (ss *SnapshotSync) importSQLDatabases(sdb *state.StateDB, accounts map[types.AccountID]bool) error {
+	rsp, err := ss.request(&types.GetStateSnapshotRequest{Kind: types.SnapshotKind_SNAPSHOT_SQL_LIST})
+	if err != nil {
+		return err
+	}
+	contracts := make(map[string]*state.ContractState)
+	for _, name := range rsp.Names {
+		id, err := enc.ToBytes(name)
+		if err != nil || len(id) != types.HashIDLength {
+			return ErrSnapshotSQL
+		}
+		aid := types.AccountID(types.ToHashID(id))
+		if accounts[aid] {
+			if contracts[name], err = sdb.OpenContractStateAccount(aid); err != nil {
+				return err
+			}
+		}
+	}
+	if len(contracts) != len(accounts) {
+		return ErrSnapshotSQL
+	}
+
+	for name, cs := range contracts {
+		if err = ss.importSQLDatabase(name, cs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// importSQLDatabase downloads the SQL database of the contract cs and installs
+// it if its content at the recovery point matches the committed hash.
+func (ss *SnapshotSync) importSQLDatabase(name string, cs *state.ContractState) error {
+	path, err := contract.DatabaseFile(name)
+	if err != nil {
+		return err
+	}
+	tmpPath := path + ".part"
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpPath)
+
+	var offset uint64
+	for {
+		rsp, err := ss.request(&types.GetStateSnapshotRequest{Kind: types.SnapshotKind_SNAPSHOT_SQL_FILE, Name: name, Offset: offset})
+		if err != nil {
+			f.Close()
+			return err
+		}
+		if _, err = f.Write(rsp.Data); err != nil {
+			f.Close()
+			return err
+		}
+		offset += uint64(len(rsp.Data))
+		if !rsp.HasNext {
+			break
+		}
+		if len(rsp.Data) == 0 {
+			f.Close()
+			return ErrSnapshotSQL
+		}
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	if err = contract.VerifyDatabaseFile(tmpPath, cs); err != nil {
+		logger.Error().Err(err).Str("name", name).Msg("invalid sql database of snapshot")
+		return ErrSnapshotSQL
+	}
+	return os.Rename(tmpPath, path)
+}
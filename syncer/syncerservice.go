@@ -8,6 +8,7 @@ import (
 	"github.com/aergoio/aergo-lib/log"
 	cfg "github.com/aergoio/aergo/config"
 	"github.com/aergoio/aergo/pkg/component"
+	"github.com/aergoio/aergo/state"
 
 	"fmt"
 	"reflect"
@@ -34,6 +35,7 @@ type Syncer struct {
 	finder       *Finder
 	hashFetcher  *HashFetcher
 	blockFetcher *BlockFetcher
+	snapshotSync *SnapshotSync
 
 	compRequester component.IComponentRequester //for test
 }
@@ -71,6 +73,7 @@ var (
 	NameHashFetcher    = "HashFetcher"
 	NameBlockFetcher   = "BlockFetcher"
 	NameBlockProcessor = "BlockProcessor"
+	NameSnapshotSync   = "SnapshotSync"
 	SyncerCfg          = &SyncerConfig{
 		maxHashReqSize:   DfltHashReqSize,
 		maxBlockReqSize:  DfltBlockFetchSize,
@@ -131,10 +134,12 @@ func (syncer *Syncer) Reset(err error) {
 	if syncer.isRunning {
 		logger.Info().Uint64("targetNo", syncer.ctx.TargetNo).Msg("syncer stop#1")
 
+		syncer.snapshotSync.stop()
 		syncer.finder.stop()
 		syncer.hashFetcher.stop()
 		syncer.blockFetcher.stop()
 
+		syncer.snapshotSync = nil
 		syncer.finder = nil
 		syncer.hashFetcher = nil
 		syncer.blockFetcher = nil
@@ -191,6 +196,7 @@ func (syncer *Syncer) Receive(context actor.Context) {
 		switch context.Message().(type) {
 		case *message.GetSyncAncestorRsp,
 			*message.FinderResult,
+			*message.SnapshotResult,
 			*message.GetHashesRsp,
 			*message.GetHashByNoRsp,
 			*message.GetBlockChunks,
@@ -223,6 +229,9 @@ func (syncer *Syncer) verifySeq(inmsg interface{}) bool {
 	case *message.FinderResult:
 		seq = msg.Seq
 		match = isMatch(seq)
+	case *message.SnapshotResult:
+		seq = msg.Seq
+		match = isMatch(seq)
 	case *message.GetHashesRsp:
 		seq = msg.Seq
 		match = isMatch(seq)
@@ -272,6 +281,8 @@ func (syncer *Syncer) handleMessage(inmsg interface{}) {
 			syncer.Reset(err)
 			logger.Error().Err(err).Msg("FinderResult failed")
 		}
+	case *message.SnapshotResult:
+		syncer.handleSnapshotResult(msg)
 	case *message.GetHashesRsp:
 		syncer.hashFetcher.GetHahsesRsp(msg)
 
@@ -348,12 +359,53 @@ func (syncer *Syncer) handleSyncStart(msg *message.SyncStart) error {
 	syncer.ctx = types.NewSyncCtx(syncer.GetSeq(), msg.PeerID, msg.TargetNo, bestBlockNo, msg.NotifyC)
 	syncer.isRunning = true
 
+	if sdb, no, hash := syncer.snapshotStateDB(bestBlockNo, msg.TargetNo); sdb != nil {
+		syncer.snapshotSync = newSnapshotSync(syncer.ctx, syncer.getCompRequester(), sdb, no, hash, syncer.syncerCfg)
+		syncer.snapshotSync.start()
+		return nil
+	}
+
 	syncer.finder = newFinder(syncer.ctx, syncer.getCompRequester(), syncer.chain, syncer.syncerCfg)
 	syncer.finder.start()
 
 	return err
 }
 
+// snapshotStateDB returns the state db to import a state snapshot with the
+// number and the hash of the trusted snapshot block if the snapshot sync is
+// enabled and the chain is empty.
+func (syncer *Syncer) snapshotStateDB(bestNo, targetNo types.BlockNo) (*state.ChainStateDB, types.BlockNo, []byte) {
+	if syncer.cfg == nil || bestNo != 0 {
+		return nil, 0, nil
+	}
+	no, hash, err := syncer.cfg.Blockchain.SnapshotCheckpoint()
+	if err != nil {
+		logger.Error().Err(err).Msg("snapshot sync disabled")
+		return nil, 0, nil
+	}
+	if hash == nil || targetNo < no {
+		return nil, 0, nil
+	}
+	accessor, ok := syncer.chain.(interface{ SDB() *state.ChainStateDB })
+	if !ok {
+		return nil, 0, nil
+	}
+	return accessor.SDB(), no, hash
+}
+
+// handleSnapshotResult continues the block sync from the installed snapshot
+// block.
+func (syncer *Syncer) handleSnapshotResult(msg *message.SnapshotResult) {
+	logger.Info().Uint64("no", msg.Block.BlockNo()).Msg("syncer received snapshot result message")
+
+	syncer.snapshotSync.stop()
+	syncer.snapshotSync = nil
+
+	syncer.ctx.BestNo = msg.Block.BlockNo()
+	syncer.finder = newFinder(syncer.ctx, syncer.getCompRequester(), syncer.chain, syncer.syncerCfg)
+	syncer.finder.start()
+}
+
 func (syncer *Syncer) handleAncestorRsp(msg *message.GetSyncAncestorRsp) {
 	var ancestorNo uint64
 
@@ -8,8 +8,8 @@ import (
 	"io/ioutil"
 	"os"
 
-	"github.com/aergoio/aergo/cmd/aergocli/util"
 	"github.com/aergoio/aergo/types"
+	"github.com/aergoio/aergo/types/jsonrpc"
 	"github.com/golang/protobuf/proto"
 	"github.com/spf13/cobra"
 )
@@ -53,7 +53,7 @@ func runPrintCmd(cmd *cobra.Command, args []string) {
 	reader := bufio.NewReader(file)
 
 	var count int
-	var out []*util.InOutTx
+	var out []*jsonrpc.InOutTx
 	for {
 		buf := types.Tx{}
 		byteInt := make([]byte, 4)
@@ -83,7 +83,7 @@ func runPrintCmd(cmd *cobra.Command, args []string) {
 		count++
 		//mp.put(types.NewTransaction(&buf)) // nolint: errcheck
 
-		out = append(out, util.ConvTx(types.NewTransaction(&buf).GetTx()))
+		out = append(out, jsonrpc.ConvTx(types.NewTransaction(&buf).GetTx()))
 	}
 	b, e := json.MarshalIndent(out, "", " ")
 	if e == nil {
@@ -109,7 +109,7 @@ func runGenCmd(cmd *cobra.Command, args []string) {
 	if err != nil {
 		cmd.Println("error: failed to read source file", err.Error())
 	}
-	txlist, err := util.ParseBase58Tx(b)
+	txlist, err := jsonrpc.ParseBase58Tx(b)
 	for _, v := range txlist {
 		var total_data []byte
 		data, err := proto.Marshal(v)
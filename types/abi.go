@@ -0,0 +1,194 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// The base types of the typed ABI. A type is a base type, followed by [] for
+// an array of it, and by ? if the value can be nil or omitted.
+const (
+	ABITypeAny     = "any"
+	ABITypeBool    = "bool"
+	ABITypeNumber  = "number"
+	ABITypeInteger = "integer"
+	ABITypeString  = "string"
+	ABITypeAddress = "address"
+	ABITypeBignum  = "bignum"
+	ABITypeTable   = "table"
+)
+
+// ABIVarargName is the name of the variable arguments of a function, whose
+// type applies to all the remaining arguments.
+const ABIVarargName = "..."
+
+// IsTyped returns if the types of the arguments of the function are
+// declared.
+func (m *Function) IsTyped() bool {
+	for _, arg := range m.GetArguments() {
+		if arg.Type != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckArgs checks the arguments of a call to the function conform to the
+// declared types. It accepts any arguments if the types are not declared.
+func (m *Function) CheckArgs(args []interface{}) error {
+	if !m.IsTyped() {
+		return nil
+	}
+	params := m.Arguments
+	for i, param := range params {
+		if param.Name == ABIVarargName {
+			for j := i; j < len(args); j++ {
+				if err := checkArg(param.Type, args[j]); err != nil {
+					return fmt.Errorf("bad argument #%d to '%s': %s", j+1, m.Name, err)
+				}
+			}
+			return nil
+		}
+		var arg interface{}
+		if i < len(args) {
+			arg = args[i]
+		} else if !isOptionalType(param.Type) {
+			return fmt.Errorf("bad argument #%d to '%s': %s expected, got no value", i+1, m.Name, param.Type)
+		}
+		if err := checkArg(param.Type, arg); err != nil {
+			return fmt.Errorf("bad argument #%d to '%s': %s", i+1, m.Name, err)
+		}
+	}
+	if len(args) > len(params) {
+		return fmt.Errorf("too many arguments to '%s': %d expected, got %d", m.Name, len(params), len(args))
+	}
+	return nil
+}
+
+func isOptionalType(typ string) bool {
+	return typ == "" || typ == ABITypeAny || strings.HasSuffix(typ, "?")
+}
+
+func checkArg(typ string, arg interface{}) error {
+	if typ == "" || typ == ABITypeAny {
+		return nil
+	}
+	if strings.HasSuffix(typ, "?") {
+		if arg == nil {
+			return nil
+		}
+		typ = typ[:len(typ)-1]
+	}
+	if strings.HasSuffix(typ, "[]") {
+		arr, ok := arg.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s expected, got %s", typ, argTypeName(arg))
+		}
+		for i, elem := range arr {
+			if err := checkArg(typ[:len(typ)-2], elem); err != nil {
+				return fmt.Errorf("element #%d: %s", i+1, err)
+			}
+		}
+		return nil
+	}
+
+	var ok bool
+	switch typ {
+	case ABITypeBool:
+		_, ok = arg.(bool)
+	case ABITypeNumber:
+		ok = isNumber(arg)
+	case ABITypeInteger:
+		ok = isInteger(arg)
+	case ABITypeString:
+		_, ok = arg.(string)
+	case ABITypeAddress:
+		if s, isString := arg.(string); isString {
+			_, err := DecodeAddress(s)
+			ok = err == nil && len(s) > 0
+		}
+	case ABITypeBignum:
+		ok = isBignum(arg)
+	case ABITypeTable:
+		switch arg.(type) {
+		case []interface{}, map[string]interface{}:
+			ok = true
+		}
+	default:
+		return fmt.Errorf("unknown type %s", typ)
+	}
+	if !ok {
+		return fmt.Errorf("%s expected, got %s", typ, argTypeName(arg))
+	}
+	return nil
+}
+
+func isNumber(arg interface{}) bool {
+	switch v := arg.(type) {
+	case float64:
+		return true
+	case json.Number:
+		_, err := v.Float64()
+		return err == nil
+	}
+	return false
+}
+
+func isInteger(arg interface{}) bool {
+	switch v := arg.(type) {
+	case float64:
+		return v == float64(int64(v))
+	case json.Number:
+		_, err := v.Int64()
+		return err == nil
+	}
+	return false
+}
+
+// isBignum returns if the argument is converted to a bignum, which is
+// {"_bignum":"<integer>"}, a string of an integer or an integer.
+func isBignum(arg interface{}) bool {
+	switch v := arg.(type) {
+	case map[string]interface{}:
+		if len(v) != 1 {
+			return false
+		}
+		for key, value := range v {
+			s, ok := value.(string)
+			return ok && strings.EqualFold(key, "_bignum") && isIntegerString(s)
+		}
+	case string:
+		return isIntegerString(v)
+	}
+	return isInteger(arg)
+}
+
+func isIntegerString(s string) bool {
+	_, ok := new(big.Int).SetString(s, 0)
+	return ok
+}
+
+func argTypeName(arg interface{}) string {
+	switch arg.(type) {
+	case nil:
+		return "nil"
+	case bool:
+		return ABITypeBool
+	case float64, json.Number:
+		return ABITypeNumber
+	case string:
+		return ABITypeString
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return ABITypeTable
+	}
+	return fmt.Sprintf("%T", arg)
+}
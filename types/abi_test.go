@@ -0,0 +1,62 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestFunctionCheckArgs(t *testing.T) {
+	transfer := &Function{
+		Name: "transfer",
+		Arguments: []*FnArgument{
+			{Name: "to", Type: "address"},
+			{Name: "amount", Type: "bignum"},
+			{Name: "memo", Type: "string?"},
+		},
+	}
+	batch := &Function{
+		Name: "batch",
+		Arguments: []*FnArgument{
+			{Name: "count", Type: "integer"},
+			{Name: "...", Type: "address[]"},
+		},
+	}
+	untyped := &Function{
+		Name:      "f",
+		Arguments: []*FnArgument{{Name: "a"}},
+	}
+	address := "AmhNNBNY7XFk4p5ym4CJf8nTcRTEHjWzAeXJfhP71244CjBCAQU3"
+
+	tests := []struct {
+		f    *Function
+		args string
+		err  string
+	}{
+		{transfer, `["` + address + `", {"_bignum":"100"}]`, ""},
+		{transfer, `["` + address + `", "0x64", "memo"]`, ""},
+		{transfer, `["aergo.system", 100, null]`, ""},
+		{transfer, `["` + address + `x", "1"]`, "bad argument #1 to 'transfer': address expected, got string"},
+		{transfer, `["` + address + `", 1.5]`, "bad argument #2 to 'transfer': bignum expected, got number"},
+		{transfer, `["` + address + `", {"_bignum":"abc"}]`, "bad argument #2 to 'transfer': bignum expected, got table"},
+		{transfer, `["` + address + `"]`, "bad argument #2 to 'transfer': bignum expected, got no value"},
+		{transfer, `["` + address + `", "1", "", 1]`, "too many arguments to 'transfer': 3 expected, got 4"},
+		{batch, `[2, ["` + address + `"], []]`, ""},
+		{batch, `[2.5]`, "bad argument #1 to 'batch': integer expected, got number"},
+		{batch, `[1, [1]]`, "bad argument #2 to 'batch': element #1: address expected, got number"},
+		{untyped, `[1, 2, 3]`, ""},
+	}
+	for _, test := range tests {
+		var args []interface{}
+		if err := json.Unmarshal([]byte(test.args), &args); err != nil {
+			t.Fatal(err)
+		}
+		err := test.f.CheckArgs(args)
+		if test.err == "" {
+			if err != nil {
+				t.Errorf("%s%s: unexpected error: %v", test.f.Name, test.args, err)
+			}
+		} else if err == nil || err.Error() != test.err {
+			t.Errorf("%s%s: expected %q, but got %v", test.f.Name, test.args, test.err, err)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package types
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// The BFT consensus decides a block of each height by the rounds of a
+// proposal and two steps of votes among the validators, like Tendermint. A
+// block is committed when more than two thirds of the validators precommit
+// it in a round. The precommits are collected into a BFTCommit, which is the
+// certificate of the block. The certificate of a block is put in the
+// Consensus field of the header of the next block.
+
+var (
+	ErrBFTInvalidVote   = errors.New("invalid bft vote")
+	ErrBFTNotValidator  = errors.New("bft vote of a non-validator")
+	ErrBFTBadSignature  = errors.New("bad signature of bft vote")
+	ErrBFTNoQuorum      = errors.New("bft commit lacks a quorum of precommits")
+	ErrBFTCommitInvalid = errors.New("invalid bft commit")
+)
+
+// BFTValidatorID returns the ID of a validator, by which it's removed by the
+// ChangeCluster tx. It's derived from the peer ID, so that every node agrees
+// on it.
+func BFTValidatorID(peerID PeerID) uint64 {
+	h := sha256.Sum256([]byte(peerID))
+	return binary.BigEndian.Uint64(h[:8])
+}
+
+// BFTQuorum returns the least number of the votes which is more than two
+// thirds of n validators.
+func BFTQuorum(n int) int {
+	if n <= 0 {
+		return 1
+	}
+	return n - (n-1)/3
+}
+
+// SignBytes returns the digest of the vote which is signed by the validator.
+// It includes chainID, the chain id of a block header excluding its version,
+// so that a vote is never valid on another chain.
+func (m *BFTVote) SignBytes(chainID []byte) []byte {
+	var buf bytes.Buffer
+	if len(chainID) > chainIdStartOffsetWithoutVersion {
+		buf.Write(chainID[chainIdStartOffsetWithoutVersion:])
+	}
+	binary.Write(&buf, binary.LittleEndian, int32(m.GetType()))
+	binary.Write(&buf, binary.LittleEndian, m.GetHeight())
+	binary.Write(&buf, binary.LittleEndian, m.GetRound())
+	buf.Write(m.GetBlockHash())
+	digest := sha256.Sum256(buf.Bytes())
+	return digest[:]
+}
+
+// VoteSigner is a BlockSigner which signs the vote itself instead of its sign
+// bytes, like a HeaderSigner does the block header.
+type VoteSigner interface {
+	SignVote(m *BFTVote, chainID []byte) ([]byte, error)
+}
+
+// SignWith signs the vote on the chain of chainID by signer, whose id becomes
+// the validator of the vote.
+func (m *BFTVote) SignWith(signer BlockSigner, chainID []byte) error {
+	id, err := IDFromPublicKey(signer.GetPublic())
+	if err != nil {
+		return err
+	}
+	m.Validator = []byte(id)
+	var sig []byte
+	if vs, ok := signer.(VoteSigner); ok {
+		sig, err = vs.SignVote(m, chainID)
+	} else {
+		sig, err = signer.Sign(m.SignBytes(chainID))
+	}
+	if err != nil {
+		return err
+	}
+	m.Sign = sig
+	return nil
+}
+
+// VerifySign checks that the vote is signed on the chain of chainID by its
+// validator. The public key is extracted from the id of the validator.
+func (m *BFTVote) VerifySign(chainID []byte) error {
+	pubKey, err := PeerID(m.GetValidator()).ExtractPublicKey()
+	if err != nil || pubKey == nil {
+		return ErrBFTBadSignature
+	}
+	if valid, err := pubKey.Verify(m.SignBytes(chainID), m.GetSign()); err != nil || !valid {
+		return ErrBFTBadSignature
+	}
+	return nil
+}
+
+// Verify checks that the commit is made of the valid precommits of a quorum
+// of validators for its block on the chain of chainID.
+func (m *BFTCommit) Verify(validators []PeerID, chainID []byte) error {
+	member := make(map[PeerID]bool, len(validators))
+	for _, v := range validators {
+		member[v] = true
+	}
+
+	signed := make(map[PeerID]bool, len(m.GetVotes()))
+	for _, v := range m.GetVotes() {
+		if v.GetType() != BFTVoteType_PRECOMMIT || v.GetHeight() != m.GetHeight() ||
+			v.GetRound() != m.GetRound() || !bytes.Equal(v.GetBlockHash(), m.GetBlockHash()) {
+			return ErrBFTInvalidVote
+		}
+		id := PeerID(v.GetValidator())
+		if !member[id] {
+			return ErrBFTNotValidator
+		}
+		if signed[id] {
+			return fmt.Errorf("%v: duplicate precommit of %s", ErrBFTCommitInvalid, IDB58Encode(id))
+		}
+		if err := v.VerifySign(chainID); err != nil {
+			return err
+		}
+		signed[id] = true
+	}
+	if len(signed) < BFTQuorum(len(validators)) {
+		return ErrBFTNoQuorum
+	}
+	return nil
+}
+
+// DecodeBFTCommit decodes the certificate in the Consensus field of a block
+// header.
+func DecodeBFTCommit(data []byte) (*BFTCommit, error) {
+	var c BFTCommit
+	if err := proto.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
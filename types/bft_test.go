@@ -0,0 +1,85 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBFTQuorum(t *testing.T) {
+	for n, want := range map[int]int{1: 1, 2: 2, 3: 3, 4: 3, 5: 4, 6: 5, 7: 5, 10: 7} {
+		assert.Equal(t, want, BFTQuorum(n), "validators %d", n)
+	}
+}
+
+func genBFTSigners(t *testing.T, n int) ([]crypto.PrivKey, []PeerID) {
+	keys := make([]crypto.PrivKey, n)
+	ids := make([]PeerID, n)
+	for i := range keys {
+		priv, pub, err := crypto.GenerateKeyPair(crypto.Secp256k1, 256)
+		require.NoError(t, err)
+		keys[i] = priv
+		ids[i], err = IDFromPublicKey(pub)
+		require.NoError(t, err)
+	}
+	return keys, ids
+}
+
+var testBFTChainID = append(ChainIdVersion(3), "bft test chain"...)
+
+func TestBFTVoteSign(t *testing.T) {
+	keys, ids := genBFTSigners(t, 1)
+
+	v := &BFTVote{Type: BFTVoteType_PREVOTE, Height: 10, Round: 1, BlockHash: []byte("hash")}
+	require.NoError(t, v.SignWith(keys[0], testBFTChainID))
+	assert.Equal(t, ids[0], PeerID(v.Validator))
+	assert.NoError(t, v.VerifySign(testBFTChainID))
+	assert.NoError(t, v.VerifySign(MakeChainId(testBFTChainID, 4)), "other version")
+	assert.Equal(t, ErrBFTBadSignature, v.VerifySign(append(ChainIdVersion(3), "other chain"...)), "other chain")
+
+	data, err := proto.Marshal(v)
+	require.NoError(t, err)
+	var decoded BFTVote
+	require.NoError(t, proto.Unmarshal(data, &decoded))
+	assert.NoError(t, decoded.VerifySign(testBFTChainID), "decoded")
+	assert.Equal(t, BFTVoteType_PREVOTE, decoded.Type)
+
+	v.Round = 2
+	assert.Equal(t, ErrBFTBadSignature, v.VerifySign(testBFTChainID), "modified")
+}
+
+func TestBFTCommitVerify(t *testing.T) {
+	keys, ids := genBFTSigners(t, 4)
+	hash := []byte("block hash")
+
+	precommit := func(i int, h []byte) *BFTVote {
+		v := &BFTVote{Type: BFTVoteType_PRECOMMIT, Height: 5, Round: 0, BlockHash: h}
+		require.NoError(t, v.SignWith(keys[i], testBFTChainID))
+		return v
+	}
+
+	commit := &BFTCommit{Height: 5, BlockHash: hash,
+		Votes: []*BFTVote{precommit(0, hash), precommit(1, hash), precommit(2, hash)}}
+	assert.NoError(t, commit.Verify(ids, testBFTChainID))
+
+	data, err := proto.Marshal(commit)
+	require.NoError(t, err)
+	decoded, err := DecodeBFTCommit(data)
+	require.NoError(t, err)
+	assert.NoError(t, decoded.Verify(ids, testBFTChainID), "decoded")
+
+	short := &BFTCommit{Height: 5, BlockHash: hash, Votes: commit.Votes[:2]}
+	assert.Equal(t, ErrBFTNoQuorum, short.Verify(ids, testBFTChainID))
+
+	dup := &BFTCommit{Height: 5, BlockHash: hash, Votes: append(commit.Votes[:2:2], commit.Votes[1])}
+	assert.Error(t, dup.Verify(ids, testBFTChainID))
+
+	other := &BFTCommit{Height: 5, BlockHash: hash,
+		Votes: []*BFTVote{precommit(0, hash), precommit(1, hash), precommit(2, []byte("other"))}}
+	assert.Equal(t, ErrBFTInvalidVote, other.Verify(ids, testBFTChainID))
+
+	assert.Equal(t, ErrBFTNotValidator, commit.Verify(ids[1:], testBFTChainID))
+}
@@ -28,6 +28,9 @@ const (
 	// DefaultMaxBlockSize is the maximum block size (currently 1MiB)
 	DefaultMaxBlockSize = 1 << 20
 	DefaultEvictPeriod  = 12
+	// DefaultReplaceBump is the minimum tip increase in percent for a tx
+	// to replace a pooled tx with the same nonce
+	DefaultReplaceBump = 10
 
 	// DefaultMaxHdrSize is the max size of the proto-buf serialized non-body
 	// fields. For the estimation detail, check 'TestBlockHeaderLimit' in
@@ -393,29 +396,49 @@ func (block *Block) BlockNo() BlockNo {
 	return block.GetHeader().GetBlockNo()
 }
 
+// BlockSigner signs blocks. A crypto.PrivKey is a BlockSigner, which is
+// implemented otherwise to sign by a key kept out of the node.
+type BlockSigner interface {
+	GetPublic() crypto.PubKey
+	Sign(msg []byte) ([]byte, error)
+}
+
+// HeaderSigner is a BlockSigner which signs the block header itself instead
+// of its digest bytes, so that a signer kept out of the node knows what it
+// signs.
+type HeaderSigner interface {
+	SignHeader(bh *BlockHeader) ([]byte, error)
+}
+
 // Sign adds a pubkey and a block signature to block.
-func (block *Block) Sign(privKey crypto.PrivKey) error {
+func (block *Block) Sign(signer BlockSigner) error {
 	var err error
 
-	if err = block.setPubKey(privKey.GetPublic()); err != nil {
-		return err
-	}
-
-	var msg []byte
-	if msg, err = block.Header.bytesForDigest(); err != nil {
+	if err = block.setPubKey(signer.GetPublic()); err != nil {
 		return err
 	}
 
 	var sig []byte
-	if sig, err = privKey.Sign(msg); err != nil {
-		return err
+	if hs, ok := signer.(HeaderSigner); ok {
+		if sig, err = hs.SignHeader(block.Header); err != nil {
+			return err
+		}
+	} else {
+		var msg []byte
+		if msg, err = block.Header.BytesForDigest(); err != nil {
+			return err
+		}
+		if sig, err = signer.Sign(msg); err != nil {
+			return err
+		}
 	}
 	block.Header.Sign = sig
 
 	return nil
 }
 
-func (bh *BlockHeader) bytesForDigest() ([]byte, error) {
+// BytesForDigest returns the bytes of bh signed by its block producer.
+func (bh *BlockHeader) BytesForDigest() ([]byte, error) {
 	var buf bytes.Buffer
 
 	if err := serializeBhForDigest(&buf, bh); err != nil {
@@ -433,7 +456,7 @@ func (block *Block) VerifySign() (valid bool, err error) {
 	}
 
 	var msg []byte
-	if msg, err = block.Header.bytesForDigest(); err != nil {
+	if msg, err = block.Header.BytesForDigest(); err != nil {
 		return false, err
 	}
 
@@ -525,6 +548,16 @@ func CalculateTxsRootHash(txs []*Tx) []byte {
 	return merkle.CalculateMerkleRoot(mes)
 }
 
+// CalculateTxsProof returns the audit path of the tx at idx in the merkle
+// tree of the txs root.
+func CalculateTxsProof(txs []*Tx, idx int) [][]byte {
+	mes := make([]merkle.MerkleEntry, len(txs))
+	for i, tx := range txs {
+		mes[i] = tx
+	}
+	return merkle.CalculateMerkleProof(mes, idx)
+}
+
 func NewTx() *Tx {
 	tx := &Tx{
 		Body: &TxBody{
@@ -548,6 +581,12 @@ func (tx *Tx) CalculateTxHash() []byte {
 	binary.Write(digest, binary.LittleEndian, txBody.Type)
 	digest.Write(txBody.ChainIdHash)
 	digest.Write(txBody.Sign)
+	// The time lock is hashed only if it is set, so the hashes of the txs
+	// made before it keep unchanged.
+	if txBody.HasTimeLock() {
+		binary.Write(digest, binary.LittleEndian, txBody.ValidAfter)
+		binary.Write(digest, binary.LittleEndian, txBody.ValidBefore)
+	}
 	return digest.Sum(nil)
 }
 
@@ -581,6 +620,8 @@ func (tx *Tx) Clone() *Tx {
 		Type:        tx.Body.Type,
 		ChainIdHash: Clone(tx.Body.ChainIdHash).([]byte),
 		Sign:        Clone(tx.Body.Sign).([]byte),
+		ValidAfter:  tx.Body.ValidAfter,
+		ValidBefore: tx.Body.ValidBefore,
 	}
 	res := &Tx{
 		Body: body,
@@ -597,6 +638,22 @@ func (b *TxBody) GetGasPriceBigInt() *big.Int {
 	return new(big.Int).SetBytes(b.GetGasPrice())
 }
 
+// GetTipPrice returns the priority tip per unit of gas, which is the part of
+// the gas price of the tx above the system gas price. Since the hardfork
+// version 3, the tip is paid to the block producer in addition to the fee and
+// the txs are picked from the mempool in the descending order of their tips.
+// Before that, the gas price of a tx is ignored and the tip is 0.
+func (b *TxBody) GetTipPrice(systemPrice *big.Int, version int32) *big.Int {
+	tip := new(big.Int)
+	if version < 3 {
+		return tip
+	}
+	if price := b.GetGasPriceBigInt(); price.Cmp(systemPrice) > 0 {
+		tip.Sub(price, systemPrice)
+	}
+	return tip
+}
+
 type MovingAverage struct {
 	values []int64
 	size   int
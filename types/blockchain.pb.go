@@ -56,6 +56,33 @@ func (TxType) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_blockchain_bcfdea0869ea68f3, []int{0}
 }
 
+// BFTVoteType is the kind of a BFT vote.
+type BFTVoteType int32
+
+const (
+	BFTVoteType_PROPOSAL  BFTVoteType = 0
+	BFTVoteType_PREVOTE   BFTVoteType = 1
+	BFTVoteType_PRECOMMIT BFTVoteType = 2
+)
+
+var BFTVoteType_name = map[int32]string{
+	0: "PROPOSAL",
+	1: "PREVOTE",
+	2: "PRECOMMIT",
+}
+var BFTVoteType_value = map[string]int32{
+	"PROPOSAL":  0,
+	"PREVOTE":   1,
+	"PRECOMMIT": 2,
+}
+
+func (x BFTVoteType) String() string {
+	return proto.EnumName(BFTVoteType_name, int32(x))
+}
+func (BFTVoteType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_blockchain_bcfdea0869ea68f3, []int{1}
+}
+
 type Block struct {
 	Hash                 []byte       `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
 	Header               *BlockHeader `protobuf:"bytes,2,opt,name=header" json:"header,omitempty"`
@@ -369,6 +396,8 @@ type TxBody struct {
 	Type                 TxType   `protobuf:"varint,8,opt,name=type,enum=types.TxType" json:"type,omitempty"`
 	ChainIdHash          []byte   `protobuf:"bytes,9,opt,name=chainIdHash,proto3" json:"chainIdHash,omitempty"`
 	Sign                 []byte   `protobuf:"bytes,10,opt,name=sign,proto3" json:"sign,omitempty"`
+	ValidAfter           uint64   `protobuf:"varint,11,opt,name=validAfter" json:"validAfter,omitempty"`
+	ValidBefore          uint64   `protobuf:"varint,12,opt,name=validBefore" json:"validBefore,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -468,6 +497,20 @@ func (m *TxBody) GetSign() []byte {
 	return nil
 }
 
+func (m *TxBody) GetValidAfter() uint64 {
+	if m != nil {
+		return m.ValidAfter
+	}
+	return 0
+}
+
+func (m *TxBody) GetValidBefore() uint64 {
+	if m != nil {
+		return m.ValidBefore
+	}
+	return 0
+}
+
 // TxIdx specifies a transaction's block hash and index within the block body
 type TxIdx struct {
 	BlockHash            []byte   `protobuf:"bytes,1,opt,name=blockHash,proto3" json:"blockHash,omitempty"`
@@ -1111,6 +1154,7 @@ func (m *Event) GetTxIndex() int32 {
 
 type FnArgument struct {
 	Name                 string   `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Type                 string   `protobuf:"bytes,2,opt,name=type" json:"type,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1147,12 +1191,20 @@ func (m *FnArgument) GetName() string {
 	return ""
 }
 
+func (m *FnArgument) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
 type Function struct {
 	Name                 string        `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
 	Arguments            []*FnArgument `protobuf:"bytes,2,rep,name=arguments" json:"arguments,omitempty"`
 	Payable              bool          `protobuf:"varint,3,opt,name=payable" json:"payable,omitempty"`
 	View                 bool          `protobuf:"varint,4,opt,name=view" json:"view,omitempty"`
 	FeeDelegation        bool          `protobuf:"varint,5,opt,name=fee_delegation,json=feeDelegation" json:"fee_delegation,omitempty"`
+	Returns              []*FnArgument `protobuf:"bytes,6,rep,name=returns" json:"returns,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
 	XXX_unrecognized     []byte        `json:"-"`
 	XXX_sizecache        int32         `json:"-"`
@@ -1217,6 +1269,13 @@ func (m *Function) GetFeeDelegation() bool {
 	return false
 }
 
+func (m *Function) GetReturns() []*FnArgument {
+	if m != nil {
+		return m.Returns
+	}
+	return nil
+}
+
 type StateVar struct {
 	Name                 string   `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
 	Type                 string   `protobuf:"bytes,2,opt,name=type" json:"type,omitempty"`
@@ -1272,13 +1331,14 @@ func (m *StateVar) GetLen() int32 {
 }
 
 type ABI struct {
-	Version              string      `protobuf:"bytes,1,opt,name=version" json:"version,omitempty"`
-	Language             string      `protobuf:"bytes,2,opt,name=language" json:"language,omitempty"`
-	Functions            []*Function `protobuf:"bytes,3,rep,name=functions" json:"functions,omitempty"`
-	StateVariables       []*StateVar `protobuf:"bytes,4,rep,name=state_variables,json=stateVariables" json:"state_variables,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
+	Version              string         `protobuf:"bytes,1,opt,name=version" json:"version,omitempty"`
+	Language             string         `protobuf:"bytes,2,opt,name=language" json:"language,omitempty"`
+	Functions            []*Function    `protobuf:"bytes,3,rep,name=functions" json:"functions,omitempty"`
+	StateVariables       []*StateVar    `protobuf:"bytes,4,rep,name=state_variables,json=stateVariables" json:"state_variables,omitempty"`
+	Events               []*EventSchema `protobuf:"bytes,5,rep,name=events" json:"events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
 func (m *ABI) Reset()         { *m = ABI{} }
@@ -1333,9 +1393,18 @@ func (m *ABI) GetStateVariables() []*StateVar {
 	return nil
 }
 
+func (m *ABI) GetEvents() []*EventSchema {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
 type Query struct {
 	ContractAddress      []byte   `protobuf:"bytes,1,opt,name=contractAddress,proto3" json:"contractAddress,omitempty"`
 	Queryinfo            []byte   `protobuf:"bytes,2,opt,name=queryinfo,proto3" json:"queryinfo,omitempty"`
+	BlockNo              uint64   `protobuf:"varint,3,opt,name=blockNo" json:"blockNo,omitempty"`
+	BlockHash            []byte   `protobuf:"bytes,4,opt,name=blockHash,proto3" json:"blockHash,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1379,6 +1448,20 @@ func (m *Query) GetQueryinfo() []byte {
 	return nil
 }
 
+func (m *Query) GetBlockNo() uint64 {
+	if m != nil {
+		return m.BlockNo
+	}
+	return 0
+}
+
+func (m *Query) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
 type StateQuery struct {
 	ContractAddress      []byte   `protobuf:"bytes,1,opt,name=contractAddress,proto3" json:"contractAddress,omitempty"`
 	Root                 []byte   `protobuf:"bytes,3,opt,name=root,proto3" json:"root,omitempty"`
@@ -1581,6 +1664,659 @@ func (m *Proposal) GetMultipleChoice() uint32 {
 	return 0
 }
 
+// EventSchema is the declaration of an event of a contract by abi.event,
+// whose arguments have the names and the types of the event fields.
+type EventSchema struct {
+	Name                 string        `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Arguments            []*FnArgument `protobuf:"bytes,2,rep,name=arguments" json:"arguments,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *EventSchema) Reset()         { *m = EventSchema{} }
+func (m *EventSchema) String() string { return proto.CompactTextString(m) }
+func (*EventSchema) ProtoMessage()    {}
+func (*EventSchema) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blockchain_bcfdea0869ea68f3, []int{22}
+}
+func (m *EventSchema) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EventSchema.Unmarshal(m, b)
+}
+func (m *EventSchema) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EventSchema.Marshal(b, m, deterministic)
+}
+func (dst *EventSchema) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventSchema.Merge(dst, src)
+}
+func (m *EventSchema) XXX_Size() int {
+	return xxx_messageInfo_EventSchema.Size(m)
+}
+func (m *EventSchema) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventSchema.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EventSchema proto.InternalMessageInfo
+
+func (m *EventSchema) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *EventSchema) GetArguments() []*FnArgument {
+	if m != nil {
+		return m.Arguments
+	}
+	return nil
+}
+
+// TxProof is the merkle branch of a tx to the txs root hash of the header of
+// the block including it. The audit path is the hashes of the siblings from
+// the tx to the root. Count is the number of the txs in the block, by which
+// the copies of the last nodes padding the tree are told from the real ones.
+type TxProof struct {
+	Tx                   *Tx      `protobuf:"bytes,1,opt,name=tx" json:"tx,omitempty"`
+	BlockHash            []byte   `protobuf:"bytes,2,opt,name=blockHash,proto3" json:"blockHash,omitempty"`
+	BlockNo              uint64   `protobuf:"varint,3,opt,name=blockNo" json:"blockNo,omitempty"`
+	Index                int32    `protobuf:"varint,4,opt,name=index" json:"index,omitempty"`
+	AuditPath            [][]byte `protobuf:"bytes,5,rep,name=auditPath,proto3" json:"auditPath,omitempty"`
+	Count                int32    `protobuf:"varint,6,opt,name=count" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TxProof) Reset()         { *m = TxProof{} }
+func (m *TxProof) String() string { return proto.CompactTextString(m) }
+func (*TxProof) ProtoMessage()    {}
+func (*TxProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blockchain_bcfdea0869ea68f3, []int{23}
+}
+func (m *TxProof) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TxProof.Unmarshal(m, b)
+}
+func (m *TxProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TxProof.Marshal(b, m, deterministic)
+}
+func (dst *TxProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxProof.Merge(dst, src)
+}
+func (m *TxProof) XXX_Size() int {
+	return xxx_messageInfo_TxProof.Size(m)
+}
+func (m *TxProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TxProof proto.InternalMessageInfo
+
+func (m *TxProof) GetTx() *Tx {
+	if m != nil {
+		return m.Tx
+	}
+	return nil
+}
+
+func (m *TxProof) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
+func (m *TxProof) GetBlockNo() uint64 {
+	if m != nil {
+		return m.BlockNo
+	}
+	return 0
+}
+
+func (m *TxProof) GetIndex() int32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *TxProof) GetAuditPath() [][]byte {
+	if m != nil {
+		return m.AuditPath
+	}
+	return nil
+}
+
+func (m *TxProof) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+// ReceiptProof is the merkle branch of a receipt to the receipts root hash of
+// the header of the block including its tx. The receipt is as stored in the
+// block, whose contract address is padded for the system contracts. Count is
+// the number of the leaves of the tree, which are the receipts and the bloom
+// filter of the block if any.
+type ReceiptProof struct {
+	Receipt              *Receipt `protobuf:"bytes,1,opt,name=receipt" json:"receipt,omitempty"`
+	BlockHash            []byte   `protobuf:"bytes,2,opt,name=blockHash,proto3" json:"blockHash,omitempty"`
+	BlockNo              uint64   `protobuf:"varint,3,opt,name=blockNo" json:"blockNo,omitempty"`
+	Index                int32    `protobuf:"varint,4,opt,name=index" json:"index,omitempty"`
+	AuditPath            [][]byte `protobuf:"bytes,5,rep,name=auditPath,proto3" json:"auditPath,omitempty"`
+	Count                int32    `protobuf:"varint,6,opt,name=count" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReceiptProof) Reset()         { *m = ReceiptProof{} }
+func (m *ReceiptProof) String() string { return proto.CompactTextString(m) }
+func (*ReceiptProof) ProtoMessage()    {}
+func (*ReceiptProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blockchain_bcfdea0869ea68f3, []int{24}
+}
+func (m *ReceiptProof) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReceiptProof.Unmarshal(m, b)
+}
+func (m *ReceiptProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReceiptProof.Marshal(b, m, deterministic)
+}
+func (dst *ReceiptProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReceiptProof.Merge(dst, src)
+}
+func (m *ReceiptProof) XXX_Size() int {
+	return xxx_messageInfo_ReceiptProof.Size(m)
+}
+func (m *ReceiptProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReceiptProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReceiptProof proto.InternalMessageInfo
+
+func (m *ReceiptProof) GetReceipt() *Receipt {
+	if m != nil {
+		return m.Receipt
+	}
+	return nil
+}
+
+func (m *ReceiptProof) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
+func (m *ReceiptProof) GetBlockNo() uint64 {
+	if m != nil {
+		return m.BlockNo
+	}
+	return 0
+}
+
+func (m *ReceiptProof) GetIndex() int32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *ReceiptProof) GetAuditPath() [][]byte {
+	if m != nil {
+		return m.AuditPath
+	}
+	return nil
+}
+
+func (m *ReceiptProof) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+// ChainExportHeader is the first record of a chain export file. The file
+// contains the blocks from `from` to `to` of the chain of genesisHash.
+type ChainExportHeader struct {
+	GenesisHash          []byte   `protobuf:"bytes,1,opt,name=genesisHash,proto3" json:"genesisHash,omitempty"`
+	From                 uint64   `protobuf:"varint,2,opt,name=from" json:"from,omitempty"`
+	To                   uint64   `protobuf:"varint,3,opt,name=to" json:"to,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChainExportHeader) Reset()         { *m = ChainExportHeader{} }
+func (m *ChainExportHeader) String() string { return proto.CompactTextString(m) }
+func (*ChainExportHeader) ProtoMessage()    {}
+func (*ChainExportHeader) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blockchain_bcfdea0869ea68f3, []int{25}
+}
+func (m *ChainExportHeader) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChainExportHeader.Unmarshal(m, b)
+}
+func (m *ChainExportHeader) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChainExportHeader.Marshal(b, m, deterministic)
+}
+func (dst *ChainExportHeader) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChainExportHeader.Merge(dst, src)
+}
+func (m *ChainExportHeader) XXX_Size() int {
+	return xxx_messageInfo_ChainExportHeader.Size(m)
+}
+func (m *ChainExportHeader) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChainExportHeader.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChainExportHeader proto.InternalMessageInfo
+
+func (m *ChainExportHeader) GetGenesisHash() []byte {
+	if m != nil {
+		return m.GenesisHash
+	}
+	return nil
+}
+
+func (m *ChainExportHeader) GetFrom() uint64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *ChainExportHeader) GetTo() uint64 {
+	if m != nil {
+		return m.To
+	}
+	return 0
+}
+
+// ExportedBlock is a record of a chain export file, which is a block with
+// the receipts of its txs.
+type ExportedBlock struct {
+	Block                *Block     `protobuf:"bytes,1,opt,name=block" json:"block,omitempty"`
+	Receipts             []*Receipt `protobuf:"bytes,2,rep,name=receipts" json:"receipts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ExportedBlock) Reset()         { *m = ExportedBlock{} }
+func (m *ExportedBlock) String() string { return proto.CompactTextString(m) }
+func (*ExportedBlock) ProtoMessage()    {}
+func (*ExportedBlock) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blockchain_bcfdea0869ea68f3, []int{26}
+}
+func (m *ExportedBlock) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportedBlock.Unmarshal(m, b)
+}
+func (m *ExportedBlock) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportedBlock.Marshal(b, m, deterministic)
+}
+func (dst *ExportedBlock) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportedBlock.Merge(dst, src)
+}
+func (m *ExportedBlock) XXX_Size() int {
+	return xxx_messageInfo_ExportedBlock.Size(m)
+}
+func (m *ExportedBlock) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportedBlock.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportedBlock proto.InternalMessageInfo
+
+func (m *ExportedBlock) GetBlock() *Block {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+func (m *ExportedBlock) GetReceipts() []*Receipt {
+	if m != nil {
+		return m.Receipts
+	}
+	return nil
+}
+
+// Multisig is the signer set and the threshold of a multisig account.
+type Multisig struct {
+	Threshold            uint32   `protobuf:"varint,1,opt,name=threshold" json:"threshold,omitempty"`
+	Signers              [][]byte `protobuf:"bytes,2,rep,name=signers,proto3" json:"signers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Multisig) Reset()         { *m = Multisig{} }
+func (m *Multisig) String() string { return proto.CompactTextString(m) }
+func (*Multisig) ProtoMessage()    {}
+func (*Multisig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blockchain_bcfdea0869ea68f3, []int{27}
+}
+func (m *Multisig) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Multisig.Unmarshal(m, b)
+}
+func (m *Multisig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Multisig.Marshal(b, m, deterministic)
+}
+func (dst *Multisig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Multisig.Merge(dst, src)
+}
+func (m *Multisig) XXX_Size() int {
+	return xxx_messageInfo_Multisig.Size(m)
+}
+func (m *Multisig) XXX_DiscardUnknown() {
+	xxx_messageInfo_Multisig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Multisig proto.InternalMessageInfo
+
+func (m *Multisig) GetThreshold() uint32 {
+	if m != nil {
+		return m.Threshold
+	}
+	return 0
+}
+
+func (m *Multisig) GetSigners() [][]byte {
+	if m != nil {
+		return m.Signers
+	}
+	return nil
+}
+
+// MultiSign is the set of the signatures of a multisig transaction, in the
+// order of the signers.
+type MultiSign struct {
+	Signs                []*SignerSign `protobuf:"bytes,1,rep,name=signs" json:"signs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *MultiSign) Reset()         { *m = MultiSign{} }
+func (m *MultiSign) String() string { return proto.CompactTextString(m) }
+func (*MultiSign) ProtoMessage()    {}
+func (*MultiSign) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blockchain_bcfdea0869ea68f3, []int{28}
+}
+func (m *MultiSign) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MultiSign.Unmarshal(m, b)
+}
+func (m *MultiSign) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MultiSign.Marshal(b, m, deterministic)
+}
+func (dst *MultiSign) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MultiSign.Merge(dst, src)
+}
+func (m *MultiSign) XXX_Size() int {
+	return xxx_messageInfo_MultiSign.Size(m)
+}
+func (m *MultiSign) XXX_DiscardUnknown() {
+	xxx_messageInfo_MultiSign.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MultiSign proto.InternalMessageInfo
+
+func (m *MultiSign) GetSigns() []*SignerSign {
+	if m != nil {
+		return m.Signs
+	}
+	return nil
+}
+
+// SignerSign is a signature of a signer of a multisig account.
+type SignerSign struct {
+	Signer               []byte   `protobuf:"bytes,1,opt,name=signer,proto3" json:"signer,omitempty"`
+	Sign                 []byte   `protobuf:"bytes,2,opt,name=sign,proto3" json:"sign,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignerSign) Reset()         { *m = SignerSign{} }
+func (m *SignerSign) String() string { return proto.CompactTextString(m) }
+func (*SignerSign) ProtoMessage()    {}
+func (*SignerSign) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blockchain_bcfdea0869ea68f3, []int{29}
+}
+func (m *SignerSign) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignerSign.Unmarshal(m, b)
+}
+func (m *SignerSign) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignerSign.Marshal(b, m, deterministic)
+}
+func (dst *SignerSign) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignerSign.Merge(dst, src)
+}
+func (m *SignerSign) XXX_Size() int {
+	return xxx_messageInfo_SignerSign.Size(m)
+}
+func (m *SignerSign) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignerSign.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignerSign proto.InternalMessageInfo
+
+func (m *SignerSign) GetSigner() []byte {
+	if m != nil {
+		return m.Signer
+	}
+	return nil
+}
+
+func (m *SignerSign) GetSign() []byte {
+	if m != nil {
+		return m.Sign
+	}
+	return nil
+}
+
+// BFTVote is a signed proposal or vote of a validator for the block of
+// blockHash at height and round. A vote with an empty blockHash is a vote
+// for nil.
+type BFTVote struct {
+	Type                 BFTVoteType `protobuf:"varint,1,opt,name=type,enum=types.BFTVoteType" json:"type,omitempty"`
+	Height               uint64      `protobuf:"varint,2,opt,name=height" json:"height,omitempty"`
+	Round                uint32      `protobuf:"varint,3,opt,name=round" json:"round,omitempty"`
+	BlockHash            []byte      `protobuf:"bytes,4,opt,name=blockHash,proto3" json:"blockHash,omitempty"`
+	Validator            []byte      `protobuf:"bytes,5,opt,name=validator,proto3" json:"validator,omitempty"`
+	Sign                 []byte      `protobuf:"bytes,6,opt,name=sign,proto3" json:"sign,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *BFTVote) Reset()         { *m = BFTVote{} }
+func (m *BFTVote) String() string { return proto.CompactTextString(m) }
+func (*BFTVote) ProtoMessage()    {}
+func (*BFTVote) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blockchain_bcfdea0869ea68f3, []int{30}
+}
+func (m *BFTVote) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BFTVote.Unmarshal(m, b)
+}
+func (m *BFTVote) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BFTVote.Marshal(b, m, deterministic)
+}
+func (dst *BFTVote) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BFTVote.Merge(dst, src)
+}
+func (m *BFTVote) XXX_Size() int {
+	return xxx_messageInfo_BFTVote.Size(m)
+}
+func (m *BFTVote) XXX_DiscardUnknown() {
+	xxx_messageInfo_BFTVote.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BFTVote proto.InternalMessageInfo
+
+func (m *BFTVote) GetType() BFTVoteType {
+	if m != nil {
+		return m.Type
+	}
+	return BFTVoteType_PROPOSAL
+}
+
+func (m *BFTVote) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *BFTVote) GetRound() uint32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *BFTVote) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
+func (m *BFTVote) GetValidator() []byte {
+	if m != nil {
+		return m.Validator
+	}
+	return nil
+}
+
+func (m *BFTVote) GetSign() []byte {
+	if m != nil {
+		return m.Sign
+	}
+	return nil
+}
+
+// BFTMessage is a message among the validators. A proposal carries the
+// proposed block and a commit message carries the certificate of a committed
+// block for the validators which missed its precommits.
+type BFTMessage struct {
+	Vote                 *BFTVote   `protobuf:"bytes,1,opt,name=vote" json:"vote,omitempty"`
+	Block                *Block     `protobuf:"bytes,2,opt,name=block" json:"block,omitempty"`
+	Commit               *BFTCommit `protobuf:"bytes,3,opt,name=commit" json:"commit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *BFTMessage) Reset()         { *m = BFTMessage{} }
+func (m *BFTMessage) String() string { return proto.CompactTextString(m) }
+func (*BFTMessage) ProtoMessage()    {}
+func (*BFTMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blockchain_bcfdea0869ea68f3, []int{31}
+}
+func (m *BFTMessage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BFTMessage.Unmarshal(m, b)
+}
+func (m *BFTMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BFTMessage.Marshal(b, m, deterministic)
+}
+func (dst *BFTMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BFTMessage.Merge(dst, src)
+}
+func (m *BFTMessage) XXX_Size() int {
+	return xxx_messageInfo_BFTMessage.Size(m)
+}
+func (m *BFTMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_BFTMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BFTMessage proto.InternalMessageInfo
+
+func (m *BFTMessage) GetVote() *BFTVote {
+	if m != nil {
+		return m.Vote
+	}
+	return nil
+}
+
+func (m *BFTMessage) GetBlock() *Block {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+func (m *BFTMessage) GetCommit() *BFTCommit {
+	if m != nil {
+		return m.Commit
+	}
+	return nil
+}
+
+// BFTCommit is the certificate of a committed block, which consists of the
+// precommits of a quorum of the validators in the round.
+type BFTCommit struct {
+	Height               uint64     `protobuf:"varint,1,opt,name=height" json:"height,omitempty"`
+	Round                uint32     `protobuf:"varint,2,opt,name=round" json:"round,omitempty"`
+	BlockHash            []byte     `protobuf:"bytes,3,opt,name=blockHash,proto3" json:"blockHash,omitempty"`
+	Votes                []*BFTVote `protobuf:"bytes,4,rep,name=votes" json:"votes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *BFTCommit) Reset()         { *m = BFTCommit{} }
+func (m *BFTCommit) String() string { return proto.CompactTextString(m) }
+func (*BFTCommit) ProtoMessage()    {}
+func (*BFTCommit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blockchain_bcfdea0869ea68f3, []int{32}
+}
+func (m *BFTCommit) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BFTCommit.Unmarshal(m, b)
+}
+func (m *BFTCommit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BFTCommit.Marshal(b, m, deterministic)
+}
+func (dst *BFTCommit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BFTCommit.Merge(dst, src)
+}
+func (m *BFTCommit) XXX_Size() int {
+	return xxx_messageInfo_BFTCommit.Size(m)
+}
+func (m *BFTCommit) XXX_DiscardUnknown() {
+	xxx_messageInfo_BFTCommit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BFTCommit proto.InternalMessageInfo
+
+func (m *BFTCommit) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *BFTCommit) GetRound() uint32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *BFTCommit) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
+func (m *BFTCommit) GetVotes() []*BFTVote {
+	if m != nil {
+		return m.Votes
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Block)(nil), "types.Block")
 	proto.RegisterType((*BlockHeader)(nil), "types.BlockHeader")
@@ -1604,104 +2340,145 @@ func init() {
 	proto.RegisterType((*StateQuery)(nil), "types.StateQuery")
 	proto.RegisterType((*FilterInfo)(nil), "types.FilterInfo")
 	proto.RegisterType((*Proposal)(nil), "types.Proposal")
+	proto.RegisterType((*EventSchema)(nil), "types.EventSchema")
+	proto.RegisterType((*TxProof)(nil), "types.TxProof")
+	proto.RegisterType((*ReceiptProof)(nil), "types.ReceiptProof")
+	proto.RegisterType((*ChainExportHeader)(nil), "types.ChainExportHeader")
+	proto.RegisterType((*ExportedBlock)(nil), "types.ExportedBlock")
+	proto.RegisterType((*Multisig)(nil), "types.Multisig")
+	proto.RegisterType((*MultiSign)(nil), "types.MultiSign")
+	proto.RegisterType((*SignerSign)(nil), "types.SignerSign")
+	proto.RegisterType((*BFTVote)(nil), "types.BFTVote")
+	proto.RegisterType((*BFTMessage)(nil), "types.BFTMessage")
+	proto.RegisterType((*BFTCommit)(nil), "types.BFTCommit")
 	proto.RegisterEnum("types.TxType", TxType_name, TxType_value)
+	proto.RegisterEnum("types.BFTVoteType", BFTVoteType_name, BFTVoteType_value)
 }
 
 func init() { proto.RegisterFile("blockchain.proto", fileDescriptor_blockchain_bcfdea0869ea68f3) }
 
 var fileDescriptor_blockchain_bcfdea0869ea68f3 = []byte{
-	// 1483 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x57, 0x4b, 0x8f, 0x1b, 0xc7,
-	0x11, 0x0e, 0xc9, 0x19, 0x2e, 0x59, 0xfb, 0xa2, 0x3a, 0x42, 0x32, 0x49, 0x84, 0x60, 0x33, 0x90,
-	0x82, 0x85, 0x90, 0x28, 0x80, 0x82, 0x20, 0x09, 0x72, 0xa2, 0x76, 0xb9, 0x0a, 0xa5, 0xcd, 0xee,
-	0xa6, 0xc5, 0x2c, 0x90, 0x93, 0xd0, 0x9c, 0x69, 0x92, 0x13, 0x0d, 0xa7, 0xa9, 0xe9, 0x26, 0x43,
-	0x9e, 0x7d, 0xf4, 0xcd, 0x37, 0x1f, 0x0d, 0xf8, 0xee, 0x3f, 0x65, 0x18, 0xbe, 0xfa, 0x1f, 0x18,
-	0x55, 0xdd, 0xf3, 0x20, 0x77, 0x6d, 0x43, 0x80, 0x0f, 0xbe, 0x75, 0x7d, 0x5d, 0xdd, 0x53, 0x55,
-	0x5f, 0x3d, 0x7a, 0xa0, 0x37, 0x4e, 0x55, 0xf4, 0x2e, 0x9a, 0x89, 0x24, 0x7b, 0xb6, 0xc8, 0x95,
-	0x51, 0xcc, 0x37, 0x9b, 0x85, 0xd4, 0xe1, 0x1c, 0xfc, 0x17, 0xb8, 0xc5, 0x18, 0x78, 0x33, 0xa1,
-	0x67, 0x41, 0xe3, 0xa4, 0x71, 0x7a, 0xc0, 0x69, 0xcd, 0x9e, 0x42, 0x7b, 0x26, 0x45, 0x2c, 0xf3,
-	0xa0, 0x79, 0xd2, 0x38, 0xdd, 0x7f, 0xce, 0x9e, 0xd1, 0xa1, 0x67, 0x74, 0xe2, 0x9f, 0xb4, 0xc3,
-	0x9d, 0x06, 0x7b, 0x0c, 0xde, 0x58, 0xc5, 0x9b, 0xa0, 0x45, 0x9a, 0xbd, 0xba, 0xe6, 0x0b, 0x15,
-	0x6f, 0x38, 0xed, 0x86, 0x1f, 0xb7, 0x60, 0xbf, 0x76, 0x9a, 0x05, 0xb0, 0x47, 0x46, 0x0d, 0xcf,
-	0xdd, 0x87, 0x0b, 0x91, 0x3d, 0x86, 0xc3, 0x45, 0x2e, 0x57, 0x56, 0x19, 0x0d, 0x6b, 0xd2, 0xfe,
-	0x36, 0x88, 0xe7, 0xc9, 0xb3, 0x2b, 0x45, 0x1f, 0xf6, 0x78, 0x21, 0xb2, 0x47, 0xd0, 0x35, 0xc9,
-	0x5c, 0x6a, 0x23, 0xe6, 0x8b, 0xc0, 0x3b, 0x69, 0x9c, 0xb6, 0x78, 0x05, 0xb0, 0xdf, 0xc3, 0x11,
-	0x29, 0x6a, 0xae, 0x94, 0xa1, 0xeb, 0x7d, 0xba, 0x7e, 0x07, 0x65, 0x27, 0xb0, 0x6f, 0xd6, 0x95,
-	0x52, 0x9b, 0x94, 0xea, 0x10, 0x7b, 0x0a, 0xbd, 0x5c, 0x46, 0x32, 0x59, 0x98, 0x4a, 0x6d, 0x8f,
-	0xd4, 0xee, 0xe0, 0xec, 0xd7, 0xd0, 0x89, 0x54, 0x36, 0x49, 0xf2, 0xb9, 0x0e, 0x3a, 0x64, 0x6e,
-	0x29, 0xb3, 0x5f, 0x40, 0x7b, 0xb1, 0x1c, 0xbf, 0x96, 0x9b, 0xa0, 0x4b, 0xa7, 0x9d, 0xc4, 0x4e,
-	0xe1, 0x38, 0x52, 0x49, 0x36, 0x16, 0x5a, 0xf6, 0xa3, 0x48, 0x2d, 0x33, 0x13, 0x00, 0x29, 0xec,
-	0xc2, 0xc8, 0xa0, 0x4e, 0xa6, 0x59, 0xb0, 0x6f, 0x19, 0xc4, 0x35, 0x46, 0x21, 0x52, 0x99, 0x96,
-	0x99, 0x5e, 0xea, 0xe0, 0x80, 0x36, 0x2a, 0x20, 0x3c, 0x85, 0x6e, 0x49, 0x10, 0xfb, 0x0d, 0xb4,
-	0xcc, 0x5a, 0x07, 0x8d, 0x93, 0xd6, 0xe9, 0xfe, 0xf3, 0xae, 0xe3, 0x6f, 0xb4, 0xe6, 0x88, 0x86,
-	0x4f, 0xa0, 0x3d, 0x5a, 0x5f, 0x26, 0xda, 0x7c, 0xbf, 0xda, 0x3f, 0xa0, 0x39, 0x5a, 0xdf, 0x9b,
-	0x4a, 0xbf, 0x73, 0xe9, 0x61, 0x13, 0xe9, 0xb0, 0x3c, 0x57, 0xcb, 0x8d, 0x4f, 0x9b, 0xf8, 0x11,
-	0xb2, 0xe5, 0x21, 0xf8, 0x99, 0xca, 0x22, 0x49, 0x57, 0x78, 0xdc, 0x0a, 0x48, 0xb6, 0x70, 0x21,
-	0xb0, 0xc9, 0x50, 0x88, 0xe8, 0x66, 0x2e, 0xa3, 0x64, 0x91, 0xc8, 0xcc, 0x50, 0x22, 0x1c, 0xf0,
-	0x0a, 0xc0, 0xd0, 0x8a, 0x39, 0x1d, 0xf3, 0x6c, 0x68, 0xad, 0x84, 0xf7, 0x2d, 0xc4, 0x26, 0x55,
-	0x22, 0x76, 0xec, 0x17, 0x22, 0x12, 0x35, 0x15, 0xfa, 0x32, 0x99, 0x27, 0x86, 0x38, 0xf7, 0x78,
-	0x29, 0xbb, 0xbd, 0x9b, 0x3c, 0x89, 0xa4, 0x23, 0xba, 0x94, 0xd1, 0x4b, 0x74, 0x8c, 0xc8, 0x3d,
-	0xaa, 0x79, 0x39, 0xda, 0x2c, 0x24, 0xa7, 0x2d, 0xcc, 0x28, 0x9b, 0xe2, 0x31, 0xa5, 0x8a, 0x25,
-	0xbb, 0x0e, 0x95, 0x3c, 0x42, 0xc5, 0x63, 0xf8, 0x57, 0xf0, 0x47, 0xeb, 0x61, 0xbc, 0x46, 0x4f,
-	0xc7, 0x65, 0x49, 0xd8, 0x00, 0x57, 0x00, 0xeb, 0x41, 0x2b, 0x89, 0xd7, 0x14, 0x1d, 0x9f, 0xe3,
-	0x32, 0x7c, 0x05, 0xdd, 0xd1, 0x7a, 0x98, 0xd9, 0x1a, 0x0f, 0xc1, 0x37, 0x78, 0x0b, 0x1d, 0xdc,
-	0x7f, 0x7e, 0x50, 0xda, 0x37, 0x8c, 0xd7, 0xdc, 0x6e, 0xb1, 0x5f, 0x41, 0xd3, 0xac, 0x1d, 0x4d,
-	0x35, 0x7a, 0x9b, 0x66, 0x1d, 0x7e, 0xd6, 0x00, 0xff, 0x8d, 0x11, 0x46, 0x7e, 0x37, 0x3f, 0x63,
-	0x91, 0x0a, 0xc4, 0x1d, 0x3f, 0x4e, 0xb4, 0x89, 0x1f, 0x4b, 0x32, 0xda, 0xd2, 0x53, 0xca, 0x18,
-	0x10, 0x6d, 0x54, 0x2e, 0xa6, 0x12, 0xeb, 0xc4, 0x51, 0x54, 0x87, 0xb0, 0xc4, 0xf4, 0xfb, 0x94,
-	0xcb, 0x48, 0xad, 0x64, 0xbe, 0xb9, 0x51, 0x49, 0x66, 0x88, 0x30, 0x8f, 0xdf, 0xc1, 0xc3, 0xaf,
-	0x1b, 0x70, 0xe0, 0x0a, 0xe2, 0x26, 0x57, 0x6a, 0x82, 0x3e, 0x6b, 0xb4, 0x79, 0xc7, 0x67, 0xf2,
-	0x83, 0xdb, 0x2d, 0x0c, 0x6a, 0x92, 0x45, 0xe9, 0x52, 0x27, 0x2a, 0x23, 0xd3, 0x3b, 0xbc, 0x02,
-	0x30, 0xa8, 0xef, 0xe4, 0xc6, 0xd9, 0x8d, 0x4b, 0x74, 0x67, 0x81, 0x97, 0x63, 0xb5, 0x5a, 0x7b,
-	0x4b, 0xb9, 0xdc, 0xbb, 0x15, 0xa9, 0xcb, 0xaa, 0x52, 0xc6, 0x44, 0x1c, 0x27, 0x66, 0x2e, 0x16,
-	0xae, 0x91, 0x38, 0x09, 0xf1, 0x99, 0x4c, 0xa6, 0x33, 0x43, 0x09, 0x75, 0xc8, 0x9d, 0x84, 0x76,
-	0x89, 0x65, 0x9c, 0x98, 0x1b, 0x61, 0x66, 0x41, 0xe7, 0xa4, 0x85, 0x64, 0x97, 0x40, 0xf8, 0x65,
-	0x03, 0x7a, 0x67, 0x2a, 0x33, 0xb9, 0x88, 0xcc, 0xad, 0xc8, 0xad, 0xbb, 0x0f, 0xc1, 0x5f, 0x89,
-	0x74, 0x29, 0x5d, 0x6e, 0x58, 0xe1, 0x07, 0x1c, 0xfc, 0x49, 0xb8, 0x53, 0x84, 0xb9, 0x5b, 0x86,
-	0xf9, 0x95, 0xd7, 0x69, 0xf5, 0xbc, 0xf0, 0xa3, 0x06, 0x1c, 0x13, 0x5b, 0xff, 0x5e, 0x22, 0xcb,
-	0xe4, 0xe5, 0xdf, 0xe1, 0x30, 0x72, 0x9e, 0x13, 0xe0, 0xc8, 0xfd, 0xb9, 0x23, 0xb7, 0x9e, 0x00,
-	0x7c, 0x5b, 0x93, 0xfd, 0x05, 0xba, 0x2b, 0x17, 0x2c, 0x1d, 0x34, 0xa9, 0x8b, 0xfd, 0xd2, 0x1d,
-	0xdb, 0x0d, 0x26, 0xaf, 0x34, 0xc3, 0x2f, 0x5a, 0xb0, 0xc7, 0x6d, 0x3f, 0xb7, 0x2d, 0xd9, 0xaa,
-	0xf6, 0xe3, 0x38, 0x97, 0x5a, 0xbb, 0x68, 0xef, 0xc2, 0x18, 0x09, 0xcc, 0xb0, 0xa5, 0xa6, 0xa0,
-	0x77, 0xb9, 0x93, 0xd0, 0xd7, 0x5c, 0xda, 0x4e, 0xd5, 0xe5, 0xb8, 0x44, 0x4d, 0xb3, 0xa6, 0xfa,
-	0x70, 0x3d, 0xca, 0x4a, 0x58, 0x53, 0x13, 0x29, 0xff, 0xa3, 0x65, 0xd9, 0xa3, 0x9c, 0xc8, 0xfe,
-	0x00, 0x0f, 0xa2, 0xe5, 0x7c, 0x99, 0x0a, 0x93, 0xac, 0xe4, 0x85, 0xd3, 0xb1, 0x44, 0xdc, 0xdd,
-	0xc0, 0xbc, 0x18, 0xa7, 0x4a, 0xcd, 0x5d, 0xcb, 0xb2, 0x02, 0x7b, 0x0c, 0x6d, 0xb9, 0x92, 0x99,
-	0xd1, 0x44, 0x47, 0x55, 0x1d, 0x03, 0x04, 0xb9, 0xdb, 0xab, 0x0f, 0xd9, 0xee, 0x9d, 0x21, 0x5b,
-	0x75, 0x23, 0xd8, 0xed, 0x46, 0x01, 0xec, 0x99, 0xf5, 0x30, 0x8b, 0xe5, 0x9a, 0x66, 0x92, 0xcf,
-	0x0b, 0x11, 0x5b, 0xdc, 0x24, 0x57, 0x73, 0x37, 0x91, 0x68, 0xcd, 0x8e, 0xa0, 0x69, 0x54, 0x70,
-	0x48, 0x48, 0xd3, 0x28, 0x7c, 0x00, 0x4c, 0xa4, 0x3c, 0x97, 0xa9, 0x9c, 0x0a, 0x83, 0x79, 0x7b,
-	0x44, 0x79, 0xbb, 0x0d, 0xe2, 0x37, 0xa6, 0x42, 0x93, 0xef, 0xc7, 0xd6, 0x36, 0x27, 0x86, 0xdf,
-	0x34, 0xc0, 0x27, 0x3f, 0x3e, 0x80, 0xaf, 0x47, 0xd0, 0x25, 0x9f, 0xaf, 0xc4, 0x5c, 0x3a, 0xca,
-	0x2a, 0x00, 0x6b, 0xe1, 0x7f, 0x5a, 0x65, 0xfd, 0x7c, 0xaa, 0x1d, 0x75, 0xa5, 0x8c, 0x7b, 0xa4,
-	0x88, 0xdd, 0xd5, 0x23, 0x67, 0x4b, 0xb9, 0xc6, 0xad, 0xbf, 0xc5, 0xed, 0x56, 0xf4, 0xda, 0xf7,
-	0x44, 0xaf, 0x88, 0xfa, 0xde, 0x76, 0xd4, 0x6b, 0x71, 0xed, 0x6c, 0xc5, 0x35, 0x3c, 0x01, 0xb8,
-	0x40, 0x7b, 0x96, 0x73, 0x69, 0x1f, 0x04, 0x19, 0x3a, 0xd2, 0x20, 0x5b, 0x69, 0x1d, 0x7e, 0xde,
-	0x80, 0xce, 0xc5, 0x32, 0x8b, 0x28, 0x78, 0xf7, 0x28, 0xb0, 0x3f, 0x41, 0x57, 0xb8, 0x0b, 0x8a,
-	0xfa, 0x78, 0xe0, 0xb2, 0xa2, 0xba, 0x9a, 0x57, 0x3a, 0x6e, 0x8a, 0x8a, 0x71, 0x2a, 0x29, 0x28,
-	0x1d, 0x5e, 0x88, 0x78, 0xfd, 0x2a, 0x91, 0xff, 0xa7, 0x78, 0x74, 0x38, 0xad, 0xd9, 0x13, 0x38,
-	0x9a, 0x48, 0xf9, 0x36, 0xae, 0x68, 0xf5, 0xef, 0xa1, 0x35, 0x3c, 0x87, 0x0e, 0xd5, 0xfc, 0xad,
-	0xc8, 0xef, 0xb5, 0x92, 0xb9, 0x41, 0x6b, 0x39, 0xb2, 0x93, 0xb5, 0x07, 0xad, 0x54, 0x66, 0x64,
-	0x84, 0xcf, 0x71, 0x89, 0xce, 0xb6, 0xfa, 0x2f, 0x86, 0x68, 0xe2, 0x4a, 0xe6, 0xd4, 0xfc, 0xec,
-	0x25, 0x85, 0x88, 0xb4, 0xa5, 0x22, 0x9b, 0x2e, 0xc5, 0xb4, 0xb8, 0xab, 0x94, 0xd9, 0x1f, 0xa1,
-	0x3b, 0x71, 0x91, 0x42, 0xbe, 0x31, 0x12, 0xc7, 0x45, 0x24, 0x1c, 0xce, 0x2b, 0x0d, 0xf6, 0x37,
-	0x38, 0xa6, 0x69, 0xf2, 0x76, 0x25, 0xf2, 0x04, 0xfd, 0xd7, 0x81, 0xb7, 0x75, 0xa8, 0x70, 0x88,
-	0x1f, 0x69, 0xb7, 0xb2, 0x6a, 0xe1, 0x35, 0xf8, 0xd4, 0xdb, 0x3e, 0x2c, 0x51, 0xdf, 0xe3, 0x91,
-	0x24, 0x9b, 0x28, 0x37, 0x6c, 0x2b, 0x20, 0xfc, 0xa4, 0x01, 0x50, 0xb5, 0xcc, 0x0f, 0xb8, 0x96,
-	0x81, 0x97, 0xe3, 0x10, 0xb6, 0xb3, 0x8e, 0xd6, 0xec, 0xb7, 0x00, 0x91, 0x9a, 0x2f, 0x70, 0x5f,
-	0xc6, 0x8e, 0xcb, 0x1a, 0x52, 0x9b, 0xdf, 0xaf, 0xe5, 0x46, 0x07, 0x3e, 0xf5, 0xf5, 0x3a, 0xf4,
-	0xca, 0xeb, 0x34, 0x7b, 0xad, 0xf0, 0xab, 0x06, 0xc0, 0x45, 0x92, 0x1a, 0x99, 0x0f, 0xb3, 0x89,
-	0xfa, 0xd1, 0x8a, 0xb2, 0x28, 0x22, 0xea, 0x27, 0xf6, 0x1f, 0xa0, 0x02, 0xca, 0x22, 0x32, 0x8a,
-	0x2c, 0x2f, 0x8a, 0xc8, 0x28, 0x74, 0x35, 0x96, 0x3a, 0x72, 0xe9, 0x47, 0x6b, 0x1a, 0x50, 0xf9,
-	0xd4, 0x1a, 0x59, 0x14, 0x64, 0x09, 0xe0, 0x3f, 0x03, 0xbe, 0xe8, 0x33, 0x43, 0x8f, 0xa9, 0xb3,
-	0xcc, 0x8e, 0x37, 0x9f, 0xef, 0xa0, 0x61, 0x0c, 0x9d, 0x9b, 0x5c, 0x2d, 0x94, 0x16, 0x29, 0x36,
-	0xb5, 0x24, 0x76, 0x49, 0xd7, 0x4c, 0x28, 0x58, 0xf8, 0xa5, 0x3c, 0x59, 0x50, 0xee, 0xdb, 0x2e,
-	0x52, 0x87, 0xf0, 0x2b, 0xf3, 0x65, 0x6a, 0x92, 0x45, 0x2a, 0xcf, 0x66, 0x0a, 0x1f, 0x99, 0x6d,
-	0x1a, 0xa2, 0x3b, 0xe8, 0xd3, 0x04, 0x1f, 0xcb, 0xf8, 0xae, 0x64, 0x00, 0xed, 0xab, 0x6b, 0xfe,
-	0xaf, 0xfe, 0x65, 0xef, 0x67, 0xec, 0x08, 0xe0, 0xe5, 0xf5, 0xed, 0x80, 0x5f, 0xf5, 0xaf, 0xce,
-	0x06, 0xbd, 0x06, 0x3b, 0x80, 0x0e, 0x1f, 0x9c, 0x0f, 0x6e, 0x2e, 0xaf, 0xff, 0xdb, 0x6b, 0xb2,
-	0x07, 0x70, 0x78, 0x31, 0x18, 0x9c, 0x0f, 0x2e, 0x07, 0x2f, 0xfb, 0xa3, 0xe1, 0xf5, 0x55, 0xaf,
-	0x85, 0x0a, 0x23, 0xde, 0xbf, 0x7a, 0x73, 0x31, 0xe0, 0x3d, 0x8f, 0x75, 0xc0, 0x3b, 0xeb, 0x5f,
-	0x5e, 0xf6, 0x7c, 0xbc, 0xd4, 0x1d, 0x6b, 0x8f, 0xdb, 0xf4, 0xc7, 0xf8, 0xe7, 0x6f, 0x03, 0x00,
-	0x00, 0xff, 0xff, 0x25, 0xc1, 0x82, 0x2d, 0x45, 0x0e, 0x00, 0x00,
+	// 1948 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x58, 0xcd, 0x8e, 0x23, 0x49,
+	0x11, 0xa6, 0xca, 0x55, 0x6e, 0x3b, 0xdc, 0xee, 0xf6, 0x14, 0x23, 0x28, 0x60, 0x85, 0x9a, 0xd2,
+	0xec, 0xd2, 0x6a, 0x60, 0x91, 0x86, 0x45, 0xbb, 0x88, 0x93, 0xbb, 0xdb, 0xbd, 0x78, 0xb6, 0x7f,
+	0x4c, 0xb6, 0x69, 0x69, 0x4f, 0xa3, 0x72, 0x55, 0xda, 0x2e, 0xb6, 0x5c, 0xe9, 0xad, 0x4c, 0x1b,
+	0xfb, 0x00, 0x17, 0x0e, 0x1c, 0xb8, 0xf1, 0x06, 0x5c, 0xb8, 0xee, 0x9d, 0xa7, 0x80, 0x33, 0x57,
+	0x84, 0xb8, 0xf2, 0x06, 0x28, 0x22, 0xb3, 0x7e, 0xec, 0xe9, 0x19, 0x18, 0x69, 0x0f, 0x7b, 0xb1,
+	0x32, 0xbe, 0x88, 0xcc, 0x8a, 0x88, 0x2f, 0x32, 0x32, 0xd3, 0xd0, 0x9b, 0xa4, 0x22, 0xfa, 0x2c,
+	0x9a, 0x87, 0x49, 0xf6, 0xfe, 0x32, 0x17, 0x4a, 0x78, 0xae, 0xda, 0x2e, 0xb9, 0x0c, 0x16, 0xe0,
+	0x9e, 0xa3, 0xca, 0xf3, 0xc0, 0x99, 0x87, 0x72, 0xee, 0x5b, 0x27, 0xd6, 0xe9, 0x21, 0xa3, 0xb1,
+	0x77, 0x06, 0xcd, 0x39, 0x0f, 0x63, 0x9e, 0xfb, 0xf6, 0x89, 0x75, 0xda, 0x79, 0xee, 0xbd, 0x4f,
+	0x93, 0xde, 0xa7, 0x19, 0xbf, 0x20, 0x0d, 0x33, 0x16, 0xde, 0x33, 0x70, 0x26, 0x22, 0xde, 0xfa,
+	0x0d, 0xb2, 0xec, 0xd5, 0x2d, 0xcf, 0x45, 0xbc, 0x65, 0xa4, 0x0d, 0xfe, 0xd8, 0x80, 0x4e, 0x6d,
+	0xb6, 0xe7, 0xc3, 0x01, 0x39, 0x35, 0xbc, 0x34, 0x1f, 0x2e, 0x44, 0xef, 0x19, 0x74, 0x97, 0x39,
+	0x5f, 0x6b, 0x63, 0x74, 0xcc, 0x26, 0xfd, 0x2e, 0x88, 0xf3, 0x29, 0xb2, 0x5b, 0x41, 0x1f, 0x76,
+	0x58, 0x21, 0x7a, 0xef, 0x40, 0x5b, 0x25, 0x0b, 0x2e, 0x55, 0xb8, 0x58, 0xfa, 0xce, 0x89, 0x75,
+	0xda, 0x60, 0x15, 0xe0, 0xbd, 0x07, 0x47, 0x64, 0x28, 0x99, 0x10, 0x8a, 0x96, 0x77, 0x69, 0xf9,
+	0x3d, 0xd4, 0x3b, 0x81, 0x8e, 0xda, 0x54, 0x46, 0x4d, 0x32, 0xaa, 0x43, 0xde, 0x19, 0xf4, 0x72,
+	0x1e, 0xf1, 0x64, 0xa9, 0x2a, 0xb3, 0x03, 0x32, 0x7b, 0x05, 0xf7, 0xbe, 0x0d, 0xad, 0x48, 0x64,
+	0xd3, 0x24, 0x5f, 0x48, 0xbf, 0x45, 0xee, 0x96, 0xb2, 0xf7, 0x0d, 0x68, 0x2e, 0x57, 0x93, 0x4f,
+	0xf8, 0xd6, 0x6f, 0xd3, 0x6c, 0x23, 0x79, 0xa7, 0x70, 0x1c, 0x89, 0x24, 0x9b, 0x84, 0x92, 0xf7,
+	0xa3, 0x48, 0xac, 0x32, 0xe5, 0x03, 0x19, 0xec, 0xc3, 0xc8, 0xa0, 0x4c, 0x66, 0x99, 0xdf, 0xd1,
+	0x0c, 0xe2, 0x18, 0xb3, 0x10, 0x89, 0x4c, 0xf2, 0x4c, 0xae, 0xa4, 0x7f, 0x48, 0x8a, 0x0a, 0x08,
+	0x4e, 0xa1, 0x5d, 0x12, 0xe4, 0x7d, 0x07, 0x1a, 0x6a, 0x23, 0x7d, 0xeb, 0xa4, 0x71, 0xda, 0x79,
+	0xde, 0x36, 0xfc, 0x8d, 0x37, 0x0c, 0xd1, 0xe0, 0x5d, 0x68, 0x8e, 0x37, 0xd7, 0x89, 0x54, 0x6f,
+	0x36, 0xfb, 0x39, 0xd8, 0xe3, 0xcd, 0xa3, 0xa5, 0xf4, 0x3d, 0x53, 0x1e, 0xba, 0x90, 0xba, 0xe5,
+	0xbc, 0x5a, 0x6d, 0xfc, 0xc3, 0x86, 0xa6, 0x06, 0xbc, 0xa7, 0xe0, 0x66, 0x22, 0x8b, 0x38, 0x2d,
+	0xe1, 0x30, 0x2d, 0x20, 0xd9, 0xa1, 0x49, 0x81, 0x2e, 0x86, 0x42, 0xc4, 0x30, 0x73, 0x1e, 0x25,
+	0xcb, 0x84, 0x67, 0x8a, 0x0a, 0xe1, 0x90, 0x55, 0x00, 0xa6, 0x36, 0x5c, 0xd0, 0x34, 0x47, 0xa7,
+	0x56, 0x4b, 0xb8, 0xde, 0x32, 0xdc, 0xa6, 0x22, 0x8c, 0x0d, 0xfb, 0x85, 0x88, 0x44, 0xcd, 0x42,
+	0x79, 0x9d, 0x2c, 0x12, 0x45, 0x9c, 0x3b, 0xac, 0x94, 0x8d, 0x6e, 0x94, 0x27, 0x11, 0x37, 0x44,
+	0x97, 0x32, 0x46, 0x89, 0x81, 0x11, 0xb9, 0x47, 0xb5, 0x28, 0xc7, 0xdb, 0x25, 0x67, 0xa4, 0xc2,
+	0x8a, 0xd2, 0x25, 0x1e, 0x53, 0xa9, 0x68, 0xb2, 0xeb, 0x50, 0xc9, 0x23, 0xd4, 0x78, 0xfc, 0x2e,
+	0xc0, 0x3a, 0x4c, 0x93, 0xb8, 0x3f, 0x55, 0x3c, 0x27, 0x86, 0x1d, 0x56, 0x43, 0x70, 0x55, 0x92,
+	0xce, 0xf9, 0x54, 0xe4, 0x9c, 0x98, 0x76, 0x58, 0x1d, 0x0a, 0x3e, 0x04, 0x77, 0xbc, 0x19, 0xc6,
+	0x1b, 0xcc, 0xd5, 0xa4, 0xdc, 0x54, 0x9a, 0xa2, 0x0a, 0xf0, 0x7a, 0xd0, 0x48, 0xe2, 0x0d, 0xe5,
+	0xd7, 0x65, 0x38, 0x0c, 0x5e, 0x40, 0x7b, 0xbc, 0x19, 0x66, 0xba, 0x4b, 0x04, 0xe0, 0x2a, 0x5c,
+	0x85, 0x26, 0x76, 0x9e, 0x1f, 0x96, 0x11, 0x0e, 0xe3, 0x0d, 0xd3, 0x2a, 0xef, 0x5b, 0x60, 0xab,
+	0x8d, 0x21, 0xba, 0x56, 0x20, 0xb6, 0xda, 0x04, 0x7f, 0xb6, 0xc0, 0xbd, 0x57, 0xa1, 0xe2, 0xaf,
+	0x67, 0x78, 0x12, 0xa6, 0x21, 0xe2, 0x86, 0x61, 0x23, 0xea, 0xad, 0x13, 0x73, 0x72, 0x5a, 0x13,
+	0x5c, 0xca, 0x18, 0xbc, 0x54, 0x22, 0x0f, 0x67, 0x1c, 0x77, 0x9a, 0x21, 0xb9, 0x0e, 0xe1, 0x26,
+	0x95, 0x9f, 0xa7, 0x8c, 0x47, 0x62, 0xcd, 0xf3, 0xed, 0x48, 0x24, 0x99, 0x22, 0xca, 0x1d, 0xf6,
+	0x0a, 0x1e, 0xfc, 0xdb, 0x82, 0x43, 0xb3, 0xa5, 0x46, 0xb9, 0x10, 0x53, 0x8c, 0x59, 0xa2, 0xcf,
+	0x7b, 0x31, 0x53, 0x1c, 0x4c, 0xab, 0x30, 0xa9, 0x49, 0x16, 0xa5, 0x2b, 0x99, 0x88, 0x8c, 0x5c,
+	0x6f, 0xb1, 0x0a, 0xc0, 0xa4, 0x7e, 0xc6, 0xb7, 0xc6, 0x6f, 0x1c, 0x62, 0x38, 0x4b, 0x5c, 0x1c,
+	0xf7, 0xbb, 0xf6, 0xb7, 0x94, 0x4b, 0xdd, 0x43, 0x98, 0x9a, 0xba, 0x2c, 0x65, 0x2c, 0xe5, 0x49,
+	0xa2, 0x16, 0xe1, 0xd2, 0xb4, 0x22, 0x23, 0x21, 0x3e, 0xe7, 0xc9, 0x6c, 0xae, 0xa8, 0x24, 0xbb,
+	0xcc, 0x48, 0xe8, 0x57, 0xb8, 0x8a, 0x13, 0x35, 0x0a, 0xd5, 0xdc, 0x6f, 0x9d, 0x34, 0x90, 0xec,
+	0x12, 0x08, 0xfe, 0x69, 0x41, 0xef, 0x42, 0x64, 0x2a, 0x0f, 0x23, 0xf5, 0x10, 0xe6, 0x3a, 0xdc,
+	0xa7, 0xe0, 0xae, 0xc3, 0x74, 0xc5, 0x4d, 0x6d, 0x68, 0xe1, 0x7f, 0x04, 0xf8, 0x95, 0x08, 0xa7,
+	0x48, 0x73, 0xbb, 0x4c, 0xf3, 0x0b, 0xa7, 0xd5, 0xe8, 0x39, 0xc1, 0xef, 0x2d, 0x38, 0x26, 0xb6,
+	0x7e, 0xb9, 0x42, 0x96, 0x29, 0xca, 0x9f, 0x41, 0x37, 0x32, 0x91, 0x13, 0x60, 0xc8, 0xfd, 0xba,
+	0x21, 0xb7, 0x5e, 0x00, 0x6c, 0xd7, 0xd2, 0xfb, 0x29, 0xb4, 0xd7, 0x26, 0x59, 0xd2, 0xb7, 0xa9,
+	0x0f, 0x7e, 0xd3, 0x4c, 0xdb, 0x4f, 0x26, 0xab, 0x2c, 0x83, 0x2f, 0x1a, 0x70, 0xc0, 0xf4, 0x89,
+	0xa0, 0x9b, 0xba, 0x36, 0xed, 0xc7, 0x71, 0xce, 0xa5, 0x34, 0xd9, 0xde, 0x87, 0x31, 0x13, 0x58,
+	0x61, 0x2b, 0x49, 0x49, 0x6f, 0x33, 0x23, 0x61, 0xac, 0x39, 0xd7, 0xbd, 0xae, 0xcd, 0x70, 0x88,
+	0x96, 0x6a, 0x43, 0xfb, 0xc3, 0x74, 0x39, 0x2d, 0xe1, 0x9e, 0x9a, 0x72, 0xfe, 0x2b, 0xc9, 0xcb,
+	0x2e, 0x67, 0x44, 0xef, 0x87, 0xf0, 0x24, 0x5a, 0x2d, 0x56, 0x69, 0xa8, 0x92, 0x35, 0xbf, 0x32,
+	0x36, 0x9a, 0x88, 0x57, 0x15, 0x58, 0x17, 0x93, 0x54, 0x88, 0x85, 0x69, 0x7a, 0x5a, 0xf0, 0x9e,
+	0x41, 0x93, 0xaf, 0x79, 0xa6, 0x24, 0xd1, 0x51, 0xed, 0x8e, 0x01, 0x82, 0xcc, 0xe8, 0xea, 0xc7,
+	0x74, 0xfb, 0x95, 0x63, 0xba, 0xea, 0x46, 0xb0, 0xdf, 0x8d, 0x7c, 0x38, 0x50, 0x9b, 0x61, 0x16,
+	0xf3, 0x0d, 0xf5, 0x3c, 0x97, 0x15, 0x22, 0x36, 0xc9, 0x69, 0x2e, 0x16, 0xe6, 0x4c, 0xa3, 0xb1,
+	0x77, 0x04, 0xb6, 0x12, 0x7e, 0x97, 0x10, 0x5b, 0x09, 0xbc, 0x42, 0x4c, 0x39, 0xbf, 0xe4, 0x29,
+	0x9f, 0x85, 0x0a, 0xeb, 0xf6, 0x88, 0xea, 0x76, 0x17, 0xc4, 0x6f, 0xcc, 0x42, 0x49, 0xb1, 0x1f,
+	0x6b, 0xdf, 0x8c, 0x18, 0xfc, 0xc7, 0x02, 0x97, 0xe2, 0x78, 0x0b, 0xbe, 0xde, 0x81, 0x36, 0xc5,
+	0x7c, 0x1b, 0x2e, 0xb8, 0xa1, 0xac, 0x02, 0x70, 0x2f, 0xfc, 0x5a, 0x8a, 0xac, 0x9f, 0xcf, 0xa4,
+	0xa1, 0xae, 0x94, 0x51, 0x47, 0x86, 0xd8, 0x5d, 0x1d, 0x0a, 0xb6, 0x94, 0x6b, 0xdc, 0xba, 0x3b,
+	0xdc, 0xee, 0x64, 0xaf, 0xf9, 0x48, 0xf6, 0x8a, 0xac, 0x1f, 0xec, 0x66, 0xbd, 0x96, 0xd7, 0xd6,
+	0x4e, 0x5e, 0x83, 0x0f, 0x00, 0xae, 0xd0, 0x9f, 0xd5, 0x82, 0xeb, 0x2b, 0x45, 0x86, 0x81, 0x58,
+	0xe4, 0x2b, 0x8d, 0x11, 0xa3, 0x33, 0x4e, 0x07, 0x47, 0xe3, 0xe0, 0xef, 0x16, 0xb4, 0xae, 0x56,
+	0x59, 0x44, 0x09, 0x7d, 0x6c, 0xd2, 0x8f, 0xa1, 0x1d, 0x9a, 0x45, 0x8b, 0x3d, 0xf3, 0xc4, 0x54,
+	0x4a, 0xf5, 0x39, 0x56, 0xd9, 0x98, 0xb3, 0x39, 0x9c, 0xa4, 0x9c, 0x12, 0xd5, 0x62, 0x85, 0x88,
+	0xcb, 0xaf, 0x13, 0xfe, 0x1b, 0xca, 0x51, 0x8b, 0xd1, 0xd8, 0x7b, 0x17, 0x8e, 0xa6, 0x9c, 0xbf,
+	0x8c, 0x2b, 0xaa, 0xdd, 0xc7, 0xa8, 0xfe, 0x01, 0x1c, 0xe4, 0x5c, 0xad, 0xf2, 0x4c, 0xfa, 0xcd,
+	0xd7, 0xf9, 0x50, 0x58, 0x04, 0x97, 0xd0, 0xa2, 0xa6, 0xf1, 0x10, 0xe6, 0xff, 0x6f, 0x1e, 0x70,
+	0x57, 0xa6, 0x3c, 0x23, 0x8f, 0x5d, 0x86, 0xc3, 0xe0, 0x6f, 0x16, 0x34, 0xfa, 0xe7, 0x43, 0x8c,
+	0x67, 0xcd, 0x73, 0xea, 0x9e, 0x7a, 0x91, 0x42, 0x44, 0xde, 0xd3, 0x30, 0x9b, 0xad, 0xc2, 0x59,
+	0xb1, 0x56, 0x29, 0x7b, 0x3f, 0x82, 0xf6, 0xd4, 0xa4, 0x15, 0x0b, 0x06, 0x5d, 0x3e, 0x2e, 0x5c,
+	0x36, 0x38, 0xab, 0x2c, 0xbc, 0x8f, 0xe0, 0x98, 0x8e, 0xa3, 0x97, 0xeb, 0x30, 0x4f, 0x30, 0x59,
+	0xd2, 0x77, 0x76, 0x26, 0x15, 0x01, 0xb1, 0x23, 0x69, 0x46, 0xda, 0x0c, 0x6f, 0xfa, 0x66, 0x1b,
+	0xbb, 0x27, 0x8d, 0xda, 0x4d, 0x9f, 0xca, 0xff, 0x3e, 0x9a, 0xf3, 0x45, 0x58, 0x6c, 0xe6, 0xe0,
+	0x0f, 0x16, 0xb8, 0xd4, 0x49, 0xdf, 0x6e, 0x5b, 0x7c, 0x8e, 0x53, 0x92, 0x6c, 0x2a, 0xcc, 0xd1,
+	0x5e, 0x01, 0x6f, 0xbe, 0xc5, 0x57, 0x05, 0xee, 0xec, 0x15, 0x78, 0xf0, 0x27, 0x0b, 0xa0, 0x6a,
+	0xec, 0x6f, 0xe1, 0x8e, 0x07, 0x4e, 0x2e, 0x44, 0x71, 0x55, 0xa4, 0x31, 0x5e, 0xb1, 0x22, 0xb1,
+	0x58, 0xa2, 0x9e, 0xc7, 0xa6, 0xba, 0x6a, 0x48, 0xed, 0x96, 0xf1, 0x09, 0xdf, 0xea, 0x3c, 0x1d,
+	0xb2, 0x3a, 0xf4, 0xc2, 0x69, 0xd9, 0xbd, 0x46, 0xf0, 0x2f, 0x0b, 0xe0, 0x2a, 0x49, 0x15, 0xcf,
+	0x87, 0x18, 0xdb, 0x97, 0xd5, 0x3a, 0x8a, 0x4c, 0x50, 0xd7, 0xd3, 0x59, 0xaa, 0x80, 0x32, 0x83,
+	0x4a, 0xf8, 0x4e, 0x2d, 0x83, 0x4a, 0x60, 0xa8, 0x31, 0x97, 0x91, 0xd9, 0x10, 0x34, 0xa6, 0x63,
+	0x34, 0x9f, 0x69, 0x27, 0x8b, 0xb6, 0x51, 0x02, 0xf8, 0x36, 0xc2, 0x97, 0x4b, 0xa6, 0xe8, 0xca,
+	0x77, 0x91, 0xe9, 0x43, 0xd8, 0x65, 0x7b, 0x68, 0x10, 0x43, 0x6b, 0x94, 0x8b, 0xa5, 0x90, 0x61,
+	0x8a, 0xad, 0x37, 0x89, 0x4d, 0x65, 0xdb, 0x09, 0x25, 0x0b, 0xbf, 0x94, 0x27, 0x4b, 0xda, 0x8d,
+	0xba, 0xd7, 0xd5, 0x21, 0xfc, 0xca, 0x62, 0x95, 0xaa, 0x64, 0x99, 0xf2, 0x8b, 0xb9, 0xc0, 0xcb,
+	0x74, 0x93, 0x8e, 0xfa, 0x3d, 0x34, 0x60, 0xd0, 0xa9, 0x15, 0xe1, 0x97, 0xd2, 0x5c, 0x82, 0xbf,
+	0x58, 0x70, 0x30, 0xde, 0xe8, 0xd3, 0x5c, 0xdf, 0x56, 0xad, 0x47, 0x6e, 0xab, 0xbb, 0xc5, 0x67,
+	0xbf, 0xa1, 0xbb, 0xee, 0x15, 0xed, 0x53, 0x70, 0x13, 0xea, 0xad, 0xba, 0x8d, 0x6b, 0x61, 0xf7,
+	0xee, 0xe2, 0xee, 0xdf, 0x5d, 0x9e, 0x82, 0xab, 0x5f, 0x36, 0x4d, 0x3d, 0x87, 0x84, 0xe0, 0xaf,
+	0x16, 0x1c, 0x9a, 0x3b, 0x83, 0xf6, 0xf6, 0x14, 0x3b, 0x18, 0xc9, 0xc6, 0xe5, 0x23, 0xe3, 0xb2,
+	0xb1, 0x62, 0x85, 0xfa, 0x2b, 0xe0, 0xfc, 0xa7, 0xf0, 0xe4, 0x02, 0x9f, 0x35, 0x83, 0xcd, 0x52,
+	0xe4, 0xca, 0x3c, 0xf8, 0x4f, 0xa0, 0x33, 0xe3, 0x19, 0x97, 0x89, 0xac, 0xbd, 0x3f, 0xea, 0x50,
+	0x79, 0xb2, 0xdb, 0xe4, 0x57, 0xfd, 0x64, 0xd7, 0x9e, 0xda, 0x4a, 0x04, 0x2f, 0xa1, 0xab, 0x57,
+	0xe5, 0x71, 0xf9, 0x2e, 0xa1, 0x00, 0xf6, 0xee, 0xe8, 0xa4, 0x64, 0x5a, 0xe5, 0x9d, 0x41, 0xab,
+	0x78, 0x91, 0x9b, 0x2a, 0xd9, 0x4f, 0x5e, 0xa9, 0x0f, 0xce, 0xa1, 0x75, 0x83, 0x75, 0x28, 0x93,
+	0x19, 0xc6, 0xae, 0xe6, 0x39, 0x97, 0x73, 0x91, 0xea, 0x12, 0xef, 0xb2, 0x0a, 0xc0, 0x4c, 0xe2,
+	0x0b, 0x8d, 0xe7, 0x7a, 0xd1, 0x43, 0x56, 0x88, 0xc1, 0x07, 0xd0, 0xa6, 0x35, 0xee, 0xf1, 0x01,
+	0xf7, 0x7d, 0x70, 0x11, 0x2f, 0x1e, 0xce, 0x45, 0x7d, 0xde, 0x93, 0x2d, 0xfe, 0x32, 0xad, 0x0f,
+	0x3e, 0x02, 0xa8, 0x40, 0xba, 0xfe, 0x91, 0x64, 0x32, 0x65, 0xa4, 0xf2, 0x8d, 0x68, 0x57, 0x6f,
+	0xc4, 0xe0, 0x0b, 0x0b, 0x0e, 0xce, 0xaf, 0xc6, 0x0f, 0x42, 0x71, 0xef, 0x3d, 0x73, 0x38, 0x59,
+	0xf4, 0x10, 0x2d, 0xff, 0xb7, 0xd1, 0xda, 0xda, 0x6b, 0xb4, 0xba, 0x68, 0xeb, 0x74, 0x1b, 0x09,
+	0x19, 0xcd, 0xc5, 0x2a, 0x8b, 0x29, 0xe7, 0x5d, 0xa6, 0x85, 0x37, 0x77, 0x63, 0xd4, 0xd2, 0x83,
+	0x33, 0x54, 0x22, 0x37, 0xf7, 0x94, 0x0a, 0x28, 0x3d, 0x6e, 0xd6, 0x3c, 0xfe, 0x1d, 0xc0, 0xf9,
+	0xd5, 0xf8, 0x86, 0x4b, 0x89, 0x87, 0x5d, 0x00, 0xce, 0x5a, 0x94, 0xcf, 0xac, 0xa3, 0x5d, 0x9f,
+	0x19, 0xe9, 0x2a, 0x9e, 0xed, 0xd7, 0xf3, 0x7c, 0x0a, 0xcd, 0x48, 0x2c, 0xf0, 0xe9, 0xbe, 0xf7,
+	0x5f, 0xd4, 0xd5, 0xf8, 0x82, 0x70, 0x66, 0xf4, 0xc1, 0x6f, 0xa1, 0x5d, 0x82, 0xb5, 0x54, 0x58,
+	0x8f, 0xa7, 0xc2, 0x7e, 0x6d, 0x2a, 0x1a, 0xfb, 0xa9, 0x78, 0x06, 0x2e, 0xba, 0x5b, 0x1c, 0xbf,
+	0xfb, 0xb1, 0x68, 0xe5, 0x59, 0x82, 0xff, 0x77, 0x20, 0x19, 0x1e, 0x40, 0xf3, 0xf6, 0x8e, 0xdd,
+	0xf4, 0xaf, 0x7b, 0x5f, 0xf3, 0x8e, 0x00, 0x3e, 0xbe, 0x7b, 0x18, 0xb0, 0xdb, 0xfe, 0xed, 0xc5,
+	0xa0, 0x67, 0x79, 0x87, 0xd0, 0x62, 0x83, 0xcb, 0xc1, 0xe8, 0xfa, 0xee, 0xd3, 0x9e, 0xed, 0x3d,
+	0x81, 0xee, 0xd5, 0x60, 0x70, 0x39, 0xb8, 0x1e, 0x7c, 0xdc, 0x1f, 0x0f, 0xef, 0x6e, 0x7b, 0x0d,
+	0x34, 0x18, 0xb3, 0xfe, 0xed, 0xfd, 0xd5, 0x80, 0xf5, 0x1c, 0xaf, 0x05, 0xce, 0x45, 0xff, 0xfa,
+	0xba, 0xe7, 0xe2, 0xa2, 0x66, 0x5a, 0xf3, 0xec, 0x43, 0xe8, 0xd4, 0xc8, 0xc7, 0x29, 0x23, 0x76,
+	0x37, 0xba, 0xbb, 0xa7, 0x2f, 0x76, 0xe0, 0x60, 0xc4, 0x06, 0x0f, 0x77, 0x63, 0xfc, 0x5c, 0x17,
+	0xda, 0x23, 0x36, 0xb8, 0xb8, 0xbb, 0xb9, 0x19, 0x8e, 0x7b, 0xf6, 0xa4, 0x49, 0xff, 0x16, 0xfe,
+	0xe4, 0xbf, 0x03, 0x00, 0x41, 0xb1, 0x36, 0xc0, 0x41, 0x14, 0x00, 0x00,
 }
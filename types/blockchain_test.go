@@ -39,7 +39,7 @@ func genKeyPair(assert *assert.Assertions) (crypto.PrivKey, crypto.PubKey) {
 }
 
 func genSign(assert *assert.Assertions, block *Block, privKey crypto.PrivKey) []byte {
-	msg, err := block.Header.bytesForDigest()
+	msg, err := block.Header.BytesForDigest()
 	assert.Nil(err)
 
 	sig, err := privKey.Sign(msg)
@@ -52,7 +52,7 @@ func TestBlockSignBasic(t *testing.T) {
 	signAssert := assert.New(t)
 
 	message := func(block *Block) []byte {
-		msg, err := block.Header.bytesForDigest()
+		msg, err := block.Header.BytesForDigest()
 		signAssert.Nil(err)
 
 		return msg
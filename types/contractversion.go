@@ -0,0 +1,14 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package types
+
+// Latest returns the current version, or nil if there is no version.
+func (m *ContractVersionList) Latest() *ContractVersion {
+	if len(m.GetVersions()) == 0 {
+		return nil
+	}
+	return m.Versions[len(m.Versions)-1]
+}
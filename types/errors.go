@@ -15,6 +15,9 @@ var (
 	//ErrSameNonceInMempool is returned by MemPool Service if transaction which has same nonce is already exists
 	ErrSameNonceAlreadyInMempool = errors.New("tx with same nonce is already in mempool")
 
+	//ErrTxReplaceUnderpriced is returned by MemPool Service if transaction doesn't offer enough gas price to replace a transaction which has same nonce
+	ErrTxReplaceUnderpriced = errors.New("replacement tx is underpriced")
+
 	//ErrTxFormatInvalid is returned by MemPool Service if transaction does not exists ErrTxFormatInvalid = errors.New("tx invalid format")
 	ErrTxFormatInvalid = errors.New("tx invalid format")
 
@@ -50,6 +53,16 @@ var (
 
 	ErrSignNotMatch = errors.New("signature not matched")
 
+	ErrTxInvalidMultiSign = errors.New("invalid signatures of multisig tx")
+
+	ErrTxInvalidTimeLock = errors.New("tx invalid time lock")
+
+	ErrTxNotYetValid = errors.New("tx is not valid yet")
+
+	ErrTxExpired = errors.New("tx is expired")
+
+	ErrMultiSignNotEnough = errors.New("not enough signatures for multisig account")
+
 	ErrCouldNotRecoverPubKey = errors.New("could not recover pubkey from sign")
 
 	ErrShouldUnlockAccount = errors.New("should unlock account first")
@@ -75,8 +88,20 @@ var (
 
 	ErrCreatorNotMatch = errors.New("creator not matched")
 
+	//ErrNotUpgradeAuthority is returned if a redeploy is sent by other than the upgrade authority of the contract
+	ErrNotUpgradeAuthority = errors.New("sender is not the upgrade authority")
+
+	//ErrNotUpgradable is returned if a redeploy is sent to a contract which renounced the upgrade authority
+	ErrNotUpgradable = errors.New("contract is not upgradable")
+
 	ErrNotAllowedFeeDelegation = errors.New("fee delegation is not allowed")
 
+	//ErrNotContractAccount is returned if the sender of a tx is a contract which doesn't have the check_account function
+	ErrNotContractAccount = errors.New("sender is not a contract account")
+
+	//ErrNotAllowedContractAccount is returned if a tx is rejected by the check_account function of the sender
+	ErrNotAllowedContractAccount = errors.New("tx is not allowed by the contract account")
+
 	ErrNotEnoughGas = errors.New("not enough gas")
 )
 
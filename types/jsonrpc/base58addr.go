@@ -1,4 +1,4 @@
-package util
+package jsonrpc
 
 import (
 	"encoding/json"
@@ -128,6 +128,8 @@ func FillTxBody(source *InOutTxBody, target *types.TxBody) error {
 		}
 	}
 	target.Type = source.Type
+	target.ValidAfter = source.ValidAfter
+	target.ValidBefore = source.ValidBefore
 	return nil
 }
 
@@ -207,6 +209,8 @@ func ConvTxEx(tx *types.Tx, payloadType EncodingType) *InOutTx {
 	out.Body.ChainIdHash = base58.Encode(tx.Body.ChainIdHash)
 	out.Body.Sign = base58.Encode(tx.Body.Sign)
 	out.Body.Type = tx.Body.Type
+	out.Body.ValidAfter = tx.Body.ValidAfter
+	out.Body.ValidBefore = tx.Body.ValidBefore
 	return out
 }
 
@@ -0,0 +1,36 @@
+package jsonrpc
+
+import (
+	"github.com/aergoio/aergo/types"
+	"github.com/mr-tron/base58/base58"
+)
+
+type InOutContractVersion struct {
+	Version  uint64
+	CodeHash string
+	BlockNo  uint64
+	TxHash   string `json:",omitempty"`
+}
+
+type InOutContractVersions struct {
+	Versions []*InOutContractVersion
+}
+
+func (v *InOutContractVersions) String() string {
+	return toString(v)
+}
+
+func ConvContractVersions(l *types.ContractVersionList) *InOutContractVersions {
+	out := &InOutContractVersions{
+		Versions: make([]*InOutContractVersion, len(l.GetVersions())),
+	}
+	for i, v := range l.GetVersions() {
+		out.Versions[i] = &InOutContractVersion{
+			Version:  v.GetVersion(),
+			CodeHash: base58.Encode(v.GetCodeHash()),
+			BlockNo:  v.GetBlockNo(),
+			TxHash:   base58.Encode(v.GetTxHash()),
+		}
+	}
+	return out
+}
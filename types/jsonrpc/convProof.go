@@ -0,0 +1,60 @@
+package jsonrpc
+
+import (
+	"github.com/aergoio/aergo/types"
+	"github.com/mr-tron/base58/base58"
+)
+
+type InOutMerkleProof struct {
+	BlockHash string
+	BlockNo   uint64
+	Index     int32
+	Count     int32
+	AuditPath []string
+}
+
+type InOutTxProof struct {
+	Tx *InOutTx
+	InOutMerkleProof
+}
+
+type InOutReceiptProof struct {
+	Receipt *types.Receipt
+	InOutMerkleProof
+}
+
+func (p *InOutTxProof) String() string {
+	return toString(p)
+}
+
+func (p *InOutReceiptProof) String() string {
+	return toString(p)
+}
+
+func ConvTxProof(p *types.TxProof) *InOutTxProof {
+	return &InOutTxProof{
+		Tx:               ConvTx(p.GetTx()),
+		InOutMerkleProof: convMerkleProof(p.GetBlockHash(), p.GetBlockNo(), p.GetIndex(), p.GetCount(), p.GetAuditPath()),
+	}
+}
+
+func ConvReceiptProof(p *types.ReceiptProof) *InOutReceiptProof {
+	return &InOutReceiptProof{
+		Receipt:          p.GetReceipt(),
+		InOutMerkleProof: convMerkleProof(p.GetBlockHash(), p.GetBlockNo(), p.GetIndex(), p.GetCount(), p.GetAuditPath()),
+	}
+}
+
+func convMerkleProof(blockHash []byte, blockNo uint64, idx, count int32, auditPath [][]byte) InOutMerkleProof {
+	out := InOutMerkleProof{
+		BlockHash: base58.Encode(blockHash),
+		BlockNo:   blockNo,
+		Index:     idx,
+		Count:     count,
+		AuditPath: make([]string, len(auditPath)),
+	}
+	for i, h := range auditPath {
+		out.AuditPath[i] = base58.Encode(h)
+	}
+	return out
+}
@@ -1,4 +1,4 @@
-package util
+package jsonrpc
 
 import "github.com/aergoio/aergo/types"
 
@@ -25,6 +25,8 @@ type InOutTxBody struct {
 	Type        types.TxType `json:",omitempty"`
 	ChainIdHash string       `json:",omitempty"`
 	Sign        string       `json:",omitempty"`
+	ValidAfter  uint64       `json:",omitempty"`
+	ValidBefore uint64       `json:",omitempty"`
 }
 
 type InOutTxIdx struct {
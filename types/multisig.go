@@ -0,0 +1,135 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package types
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// A multisig account is an account which registered a set of signers and a
+// threshold by the setMultisig system transaction. Its transactions must be
+// signed by at least the threshold number of the signers instead of its own
+// key. The signatures are put in the Sign field of the tx body as a MultiSign
+// message prefixed by multiSignPrefix, which never starts a DER signature.
+
+const (
+	// MaxMultisigSigners is the maximum number of the signers of a multisig
+	// account.
+	MaxMultisigSigners = 16
+
+	multiSignPrefix byte = 'M'
+)
+
+// IsSigner returns true if address is one of the signers.
+func (m *Multisig) IsSigner(address []byte) bool {
+	for _, signer := range m.GetSigners() {
+		if bytes.Equal(signer, address) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseMultisigArgs parses the arguments of the setMultisig system
+// transaction: the threshold followed by the addresses of the signers. The
+// threshold "0" without any signer clears the multisig of the account.
+func ParseMultisigArgs(args []interface{}) (*Multisig, error) {
+	if len(args) < 1 {
+		return nil, fmt.Errorf("the threshold is required")
+	}
+	s, ok := args[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid threshold")
+	}
+	threshold, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid threshold: %s", s)
+	}
+	ms := &Multisig{Threshold: uint32(threshold)}
+	for _, arg := range args[1:] {
+		encoded, ok := arg.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid signer")
+		}
+		signer, err := DecodeAddress(encoded)
+		if err != nil || len(signer) != AddressLength {
+			return nil, fmt.Errorf("invalid signer: %s", encoded)
+		}
+		if ms.IsSigner(signer) {
+			return nil, fmt.Errorf("duplicate signer: %s", encoded)
+		}
+		ms.Signers = append(ms.Signers, signer)
+	}
+	if len(ms.Signers) > MaxMultisigSigners {
+		return nil, fmt.Errorf("too many signers (max: %d)", MaxMultisigSigners)
+	}
+	if ms.Threshold == 0 && len(ms.Signers) == 0 {
+		return ms, nil
+	}
+	if ms.Threshold == 0 || int(ms.Threshold) > len(ms.Signers) {
+		return nil, fmt.Errorf("the threshold must be between 1 and the number of the signers")
+	}
+	return ms, nil
+}
+
+// Add adds or replaces the signature of signer. The signatures are kept in
+// the order of the signers, so the same signatures are encoded the same
+// regardless of the order in which they are added.
+func (m *MultiSign) Add(signer, sign []byte) {
+	i := sort.Search(len(m.Signs), func(i int) bool {
+		return bytes.Compare(m.Signs[i].Signer, signer) >= 0
+	})
+	if i < len(m.Signs) && bytes.Equal(m.Signs[i].Signer, signer) {
+		m.Signs[i].Sign = sign
+		return
+	}
+	m.Signs = append(m.Signs, nil)
+	copy(m.Signs[i+1:], m.Signs[i:])
+	m.Signs[i] = &SignerSign{Signer: signer, Sign: sign}
+}
+
+// Merge adds all the signatures of other.
+func (m *MultiSign) Merge(other *MultiSign) {
+	for _, s := range other.GetSigns() {
+		m.Add(s.Signer, s.Sign)
+	}
+}
+
+// Encode returns the value of the Sign field of a tx body.
+func (m *MultiSign) Encode() ([]byte, error) {
+	b, err := proto.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{multiSignPrefix}, b...), nil
+}
+
+// IsMultiSign returns true if sign is the value of the Sign field of a
+// multisig transaction.
+func IsMultiSign(sign []byte) bool {
+	return len(sign) > 0 && sign[0] == multiSignPrefix
+}
+
+// DecodeMultiSign decodes the Sign field of a multisig transaction. An empty
+// MultiSign is returned for an unsigned transaction.
+func DecodeMultiSign(sign []byte) (*MultiSign, error) {
+	ms := &MultiSign{}
+	if len(sign) == 0 {
+		return ms, nil
+	}
+	if !IsMultiSign(sign) {
+		return nil, ErrTxInvalidMultiSign
+	}
+	if err := proto.Unmarshal(sign[1:], ms); err != nil {
+		return nil, ErrTxInvalidMultiSign
+	}
+	return ms, nil
+}
@@ -0,0 +1,71 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMultisigArgs(t *testing.T) {
+	const (
+		signer1 = "AmLt7Z3y2XTu7YS8KHNuyKM2QAszpFHSX77FLKEt7FAuRW7GEhj7"
+		signer2 = "AmNqJN2P1MA2Uc6X5byA4mDg2iuo95ANAyWCmd3LkZe4GhJkSyr4"
+	)
+	tests := []struct {
+		args      []interface{}
+		wantErr   bool
+		threshold uint32
+		signers   int
+	}{
+		{[]interface{}{}, true, 0, 0},
+		{[]interface{}{"0"}, false, 0, 0},
+		{[]interface{}{"1", signer1}, false, 1, 1},
+		{[]interface{}{"2", signer1, signer2}, false, 2, 2},
+		{[]interface{}{"0", signer1}, true, 0, 0},
+		{[]interface{}{"3", signer1, signer2}, true, 0, 0},
+		{[]interface{}{"2", signer1, signer1}, true, 0, 0},
+		{[]interface{}{"1", "invalid"}, true, 0, 0},
+		{[]interface{}{1, signer1}, true, 0, 0},
+		{[]interface{}{"-1", signer1}, true, 0, 0},
+	}
+	for i, test := range tests {
+		ms, err := ParseMultisigArgs(test.args)
+		if test.wantErr {
+			assert.Errorf(t, err, "case %d", i)
+			continue
+		}
+		assert.NoErrorf(t, err, "case %d", i)
+		assert.Equalf(t, test.threshold, ms.GetThreshold(), "case %d", i)
+		assert.Lenf(t, ms.GetSigners(), test.signers, "case %d", i)
+	}
+}
+
+func TestMultiSign(t *testing.T) {
+	a := &MultiSign{}
+	a.Add([]byte{2}, []byte("sign2"))
+	a.Add([]byte{1}, []byte("sign1"))
+	b := &MultiSign{}
+	b.Add([]byte{3}, []byte("sign3"))
+	b.Add([]byte{1}, []byte("sign1"))
+	a.Merge(b)
+
+	assert.Len(t, a.Signs, 3)
+	for i, s := range a.Signs {
+		assert.Equal(t, []byte{byte(i + 1)}, s.Signer, "sorted by the signers")
+	}
+
+	encoded, err := a.Encode()
+	assert.NoError(t, err)
+	assert.True(t, IsMultiSign(encoded))
+	decoded, err := DecodeMultiSign(encoded)
+	assert.NoError(t, err)
+	assert.Len(t, decoded.Signs, 3)
+	assert.Equal(t, []byte("sign3"), decoded.Signs[2].Sign)
+
+	empty, err := DecodeMultiSign(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, empty.Signs)
+
+	_, err = DecodeMultiSign([]byte{0x30, 0x44})
+	assert.Equal(t, ErrTxInvalidMultiSign, err)
+}
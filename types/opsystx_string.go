@@ -12,12 +12,13 @@ func _() {
 	_ = x[OpvoteDAO-1]
 	_ = x[Opstake-2]
 	_ = x[Opunstake-3]
-	_ = x[OpSysTxMax-4]
+	_ = x[OpsetMultisig-4]
+	_ = x[OpSysTxMax-5]
 }
 
-const _OpSysTx_name = "OpvoteBPOpvoteDAOOpstakeOpunstakeOpSysTxMax"
+const _OpSysTx_name = "OpvoteBPOpvoteDAOOpstakeOpunstakeOpsetMultisigOpSysTxMax"
 
-var _OpSysTx_index = [...]uint8{0, 8, 17, 24, 33, 43}
+var _OpSysTx_index = [...]uint8{0, 8, 17, 24, 33, 46, 56}
 
 func (i OpSysTx) String() string {
 	if i < 0 || i >= OpSysTx(len(_OpSysTx_index)-1) {
@@ -113,6 +113,49 @@ func (ResultStatus) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_p2p_6496de2d566cf566, []int{0}
 }
 
+// SnapshotKind is the kind of the part of a state snapshot requested to a
+// peer.
+type SnapshotKind int32
+
+const (
+	// SNAPSHOT_BLOCK requests the block of blockNo, returned in data.
+	SnapshotKind_SNAPSHOT_BLOCK SnapshotKind = 0
+	// SNAPSHOT_LEAVES requests the leaves of the trie of root following the key
+	// after, returned in keys with the values they refer to in values.
+	SnapshotKind_SNAPSHOT_LEAVES SnapshotKind = 1
+	// SNAPSHOT_VALUES requests the values stored by hashes, such as the contract
+	// codes, returned in values.
+	SnapshotKind_SNAPSHOT_VALUES SnapshotKind = 2
+	// SNAPSHOT_SQL_LIST requests the names of the SQL databases of the contracts,
+	// returned in names.
+	SnapshotKind_SNAPSHOT_SQL_LIST SnapshotKind = 3
+	// SNAPSHOT_SQL_FILE requests a chunk of the SQL database file of name from
+	// offset, returned in data with the size of the file in size.
+	SnapshotKind_SNAPSHOT_SQL_FILE SnapshotKind = 4
+)
+
+var SnapshotKind_name = map[int32]string{
+	0: "SNAPSHOT_BLOCK",
+	1: "SNAPSHOT_LEAVES",
+	2: "SNAPSHOT_VALUES",
+	3: "SNAPSHOT_SQL_LIST",
+	4: "SNAPSHOT_SQL_FILE",
+}
+var SnapshotKind_value = map[string]int32{
+	"SNAPSHOT_BLOCK":    0,
+	"SNAPSHOT_LEAVES":   1,
+	"SNAPSHOT_VALUES":   2,
+	"SNAPSHOT_SQL_LIST": 3,
+	"SNAPSHOT_SQL_FILE": 4,
+}
+
+func (x SnapshotKind) String() string {
+	return proto.EnumName(SnapshotKind_name, int32(x))
+}
+func (SnapshotKind) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_p2p_6496de2d566cf566, []int{1}
+}
+
 // MsgHeader contains common properties of all p2p messages
 type MsgHeader struct {
 	// Deprecated client version.
@@ -1536,6 +1579,182 @@ func (m *CertificateRenewedNotice) GetCertificate() *AgentCertificate {
 	return nil
 }
 
+// GetStateSnapshotRequest requests a part of the state snapshot of a block.
+// The fields other than kind are the arguments of the kind.
+type GetStateSnapshotRequest struct {
+	Kind                 SnapshotKind `protobuf:"varint,1,opt,name=kind,enum=types.SnapshotKind" json:"kind,omitempty"`
+	BlockNo              uint64       `protobuf:"varint,2,opt,name=blockNo" json:"blockNo,omitempty"`
+	Root                 []byte       `protobuf:"bytes,3,opt,name=root,proto3" json:"root,omitempty"`
+	After                []byte       `protobuf:"bytes,4,opt,name=after,proto3" json:"after,omitempty"`
+	Hashes               [][]byte     `protobuf:"bytes,5,rep,name=hashes,proto3" json:"hashes,omitempty"`
+	Name                 string       `protobuf:"bytes,6,opt,name=name" json:"name,omitempty"`
+	Offset               uint64       `protobuf:"varint,7,opt,name=offset" json:"offset,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *GetStateSnapshotRequest) Reset()         { *m = GetStateSnapshotRequest{} }
+func (m *GetStateSnapshotRequest) String() string { return proto.CompactTextString(m) }
+func (*GetStateSnapshotRequest) ProtoMessage()    {}
+func (*GetStateSnapshotRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_p2p_6496de2d566cf566, []int{27}
+}
+func (m *GetStateSnapshotRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStateSnapshotRequest.Unmarshal(m, b)
+}
+func (m *GetStateSnapshotRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStateSnapshotRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetStateSnapshotRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStateSnapshotRequest.Merge(dst, src)
+}
+func (m *GetStateSnapshotRequest) XXX_Size() int {
+	return xxx_messageInfo_GetStateSnapshotRequest.Size(m)
+}
+func (m *GetStateSnapshotRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStateSnapshotRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStateSnapshotRequest proto.InternalMessageInfo
+
+func (m *GetStateSnapshotRequest) GetKind() SnapshotKind {
+	if m != nil {
+		return m.Kind
+	}
+	return SnapshotKind_SNAPSHOT_BLOCK
+}
+
+func (m *GetStateSnapshotRequest) GetBlockNo() uint64 {
+	if m != nil {
+		return m.BlockNo
+	}
+	return 0
+}
+
+func (m *GetStateSnapshotRequest) GetRoot() []byte {
+	if m != nil {
+		return m.Root
+	}
+	return nil
+}
+
+func (m *GetStateSnapshotRequest) GetAfter() []byte {
+	if m != nil {
+		return m.After
+	}
+	return nil
+}
+
+func (m *GetStateSnapshotRequest) GetHashes() [][]byte {
+	if m != nil {
+		return m.Hashes
+	}
+	return nil
+}
+
+func (m *GetStateSnapshotRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *GetStateSnapshotRequest) GetOffset() uint64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+// GetStateSnapshotResponse is the part of the state snapshot requested by
+// GetStateSnapshotRequest. hasNext is set if more leaves follow the keys.
+type GetStateSnapshotResponse struct {
+	Status               ResultStatus `protobuf:"varint,1,opt,name=status,enum=types.ResultStatus" json:"status,omitempty"`
+	Keys                 [][]byte     `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty"`
+	Values               [][]byte     `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty"`
+	HasNext              bool         `protobuf:"varint,4,opt,name=hasNext" json:"hasNext,omitempty"`
+	Names                []string     `protobuf:"bytes,5,rep,name=names" json:"names,omitempty"`
+	Data                 []byte       `protobuf:"bytes,6,opt,name=data,proto3" json:"data,omitempty"`
+	Size                 uint64       `protobuf:"varint,7,opt,name=size" json:"size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *GetStateSnapshotResponse) Reset()         { *m = GetStateSnapshotResponse{} }
+func (m *GetStateSnapshotResponse) String() string { return proto.CompactTextString(m) }
+func (*GetStateSnapshotResponse) ProtoMessage()    {}
+func (*GetStateSnapshotResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_p2p_6496de2d566cf566, []int{28}
+}
+func (m *GetStateSnapshotResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStateSnapshotResponse.Unmarshal(m, b)
+}
+func (m *GetStateSnapshotResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStateSnapshotResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetStateSnapshotResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStateSnapshotResponse.Merge(dst, src)
+}
+func (m *GetStateSnapshotResponse) XXX_Size() int {
+	return xxx_messageInfo_GetStateSnapshotResponse.Size(m)
+}
+func (m *GetStateSnapshotResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStateSnapshotResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStateSnapshotResponse proto.InternalMessageInfo
+
+func (m *GetStateSnapshotResponse) GetStatus() ResultStatus {
+	if m != nil {
+		return m.Status
+	}
+	return ResultStatus_OK
+}
+
+func (m *GetStateSnapshotResponse) GetKeys() [][]byte {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+func (m *GetStateSnapshotResponse) GetValues() [][]byte {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+func (m *GetStateSnapshotResponse) GetHasNext() bool {
+	if m != nil {
+		return m.HasNext
+	}
+	return false
+}
+
+func (m *GetStateSnapshotResponse) GetNames() []string {
+	if m != nil {
+		return m.Names
+	}
+	return nil
+}
+
+func (m *GetStateSnapshotResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *GetStateSnapshotResponse) GetSize() uint64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*MsgHeader)(nil), "types.MsgHeader")
 	proto.RegisterType((*P2PMessage)(nil), "types.P2PMessage")
@@ -1564,91 +1783,106 @@ func init() {
 	proto.RegisterType((*IssueCertificateRequest)(nil), "types.IssueCertificateRequest")
 	proto.RegisterType((*IssueCertificateResponse)(nil), "types.IssueCertificateResponse")
 	proto.RegisterType((*CertificateRenewedNotice)(nil), "types.CertificateRenewedNotice")
+	proto.RegisterType((*GetStateSnapshotRequest)(nil), "types.GetStateSnapshotRequest")
+	proto.RegisterType((*GetStateSnapshotResponse)(nil), "types.GetStateSnapshotResponse")
 	proto.RegisterEnum("types.ResultStatus", ResultStatus_name, ResultStatus_value)
+	proto.RegisterEnum("types.SnapshotKind", SnapshotKind_name, SnapshotKind_value)
 }
 
 func init() { proto.RegisterFile("p2p.proto", fileDescriptor_p2p_6496de2d566cf566) }
 
 var fileDescriptor_p2p_6496de2d566cf566 = []byte{
-	// 1279 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xcf, 0x6e, 0xdb, 0x46,
-	0x13, 0xff, 0x28, 0xd9, 0xb2, 0x34, 0xa2, 0x6c, 0x7a, 0xfd, 0x25, 0x66, 0xdd, 0x20, 0x15, 0x88,
-	0xa0, 0x55, 0xd3, 0x20, 0x28, 0x9c, 0x53, 0xd1, 0x13, 0x2d, 0x32, 0x12, 0x1b, 0x79, 0x29, 0xac,
-	0xa4, 0x34, 0x3d, 0xa9, 0x94, 0xb4, 0x91, 0xd8, 0xda, 0xa4, 0xca, 0x5d, 0xc5, 0x76, 0x2e, 0x05,
-	0x7a, 0xe8, 0x1b, 0xf4, 0x15, 0xfa, 0x18, 0x7d, 0x83, 0x3e, 0x52, 0x81, 0x62, 0x97, 0x4b, 0x89,
-	0xb4, 0x93, 0x18, 0x35, 0x72, 0xdb, 0xf9, 0xc3, 0x99, 0xdf, 0xcc, 0xfc, 0x76, 0x96, 0x50, 0x5b,
-	0x1e, 0x2f, 0x9f, 0x2e, 0x93, 0x98, 0xc7, 0x68, 0x9b, 0x5f, 0x2d, 0x29, 0x3b, 0x32, 0x26, 0x67,
-	0xf1, 0xf4, 0xe7, 0xe9, 0x22, 0x08, 0xa3, 0xd4, 0x70, 0x04, 0x51, 0x3c, 0xa3, 0xe9, 0xd9, 0xfa,
-	0x47, 0x83, 0xda, 0x29, 0x9b, 0x77, 0x69, 0x30, 0xa3, 0x09, 0x7a, 0x04, 0x8d, 0xe9, 0x59, 0x48,
-	0x23, 0xfe, 0x92, 0x26, 0x2c, 0x8c, 0x23, 0x53, 0x6b, 0x6a, 0xad, 0x1a, 0x29, 0x2a, 0xd1, 0x03,
-	0xa8, 0xf1, 0xf0, 0x9c, 0x32, 0x1e, 0x9c, 0x2f, 0xcd, 0x52, 0x53, 0x6b, 0x95, 0xc9, 0x46, 0x81,
-	0x76, 0xa1, 0x14, 0xce, 0xcc, 0xb2, 0xfc, 0xb0, 0x14, 0xce, 0xd0, 0x7d, 0xa8, 0xcc, 0x63, 0xc6,
-	0xc2, 0xa5, 0xb9, 0xd5, 0xd4, 0x5a, 0x55, 0xa2, 0x24, 0xa1, 0x5f, 0x52, 0x9a, 0x78, 0x8e, 0xb9,
-	0xdd, 0xd4, 0x5a, 0x3a, 0x51, 0x12, 0x7a, 0x08, 0x12, 0x5f, 0x7f, 0x35, 0x79, 0x41, 0xaf, 0xcc,
-	0x8a, 0xb4, 0xe5, 0x34, 0x08, 0xc1, 0x16, 0x0b, 0xe7, 0x91, 0xb9, 0x23, 0x2d, 0xf2, 0x8c, 0x9a,
-	0x50, 0x67, 0xab, 0x89, 0xac, 0x68, 0x1a, 0x9f, 0x99, 0xd5, 0xa6, 0xd6, 0x6a, 0x90, 0xbc, 0x4a,
-	0x64, 0x3b, 0xa3, 0xd1, 0x9c, 0x2f, 0xcc, 0x9a, 0x34, 0x2a, 0xc9, 0xfa, 0x0e, 0xa0, 0x7f, 0xdc,
-	0x3f, 0xa5, 0x8c, 0x05, 0x73, 0x8a, 0x5a, 0x50, 0x59, 0xc8, 0x4e, 0xc8, 0xc2, 0xeb, 0xc7, 0xc6,
-	0x53, 0xd9, 0xc3, 0xa7, 0xeb, 0x0e, 0x11, 0x65, 0x17, 0x28, 0x66, 0x01, 0x0f, 0x64, 0xf9, 0x3a,
-	0x91, 0x67, 0xcb, 0x87, 0xad, 0x7e, 0x18, 0xcd, 0xd1, 0xe7, 0xb0, 0x37, 0xa1, 0x8c, 0x8f, 0x65,
-	0xe3, 0xc7, 0x8b, 0x80, 0x2d, 0x64, 0x38, 0x9d, 0x34, 0x84, 0xfa, 0x44, 0x68, 0xbb, 0x01, 0x5b,
-	0xa0, 0xcf, 0xa0, 0x2e, 0xfd, 0x16, 0x34, 0x9c, 0x2f, 0xb8, 0x0c, 0xb5, 0x45, 0x40, 0xa8, 0xba,
-	0x52, 0x63, 0xf5, 0x60, 0xab, 0x1f, 0x47, 0x73, 0x31, 0x96, 0xc2, 0x97, 0xef, 0x0e, 0xf7, 0x10,
-	0x72, 0xdf, 0xbe, 0x23, 0xda, 0xdf, 0x25, 0xa8, 0x0c, 0x78, 0xc0, 0x57, 0x0c, 0x3d, 0x86, 0x0a,
-	0xa3, 0xd1, 0xa6, 0x4e, 0xa4, 0xea, 0xec, 0x53, 0x9a, 0xd8, 0xb3, 0x59, 0x42, 0x19, 0x23, 0xca,
-	0xe3, 0x66, 0xf2, 0xd2, 0xed, 0xc9, 0xcb, 0xd7, 0x93, 0x23, 0x13, 0x76, 0x24, 0x05, 0x3d, 0x47,
-	0xd2, 0x40, 0x27, 0x99, 0x88, 0x8e, 0xa0, 0x1a, 0xc5, 0xee, 0xe5, 0x32, 0x66, 0x54, 0x32, 0xa1,
-	0x4a, 0xd6, 0xb2, 0xf8, 0xea, 0x8d, 0x62, 0x62, 0x45, 0x12, 0x2a, 0x13, 0x85, 0x65, 0x4e, 0x23,
-	0xca, 0x42, 0xa6, 0x88, 0x90, 0x89, 0xe8, 0x5b, 0xd0, 0xa7, 0x34, 0xe1, 0xe1, 0xeb, 0x70, 0x1a,
-	0x70, 0xca, 0xcc, 0x6a, 0xb3, 0xdc, 0xaa, 0x1f, 0x1f, 0xaa, 0x0a, 0xed, 0x39, 0x8d, 0x78, 0x7b,
-	0x63, 0x27, 0x05, 0x67, 0xf4, 0x18, 0x8c, 0x90, 0xb1, 0x15, 0xcd, 0x79, 0x48, 0xc2, 0x54, 0xc9,
-	0x0d, 0xbd, 0xd5, 0x02, 0xbd, 0x13, 0xdb, 0x17, 0xc1, 0x15, 0x8e, 0x79, 0x38, 0x95, 0x60, 0xcf,
-	0x53, 0x1e, 0xa9, 0x6b, 0x93, 0x89, 0xd6, 0x2b, 0x30, 0x54, 0x57, 0x29, 0x23, 0xf4, 0x97, 0x15,
-	0x65, 0xfc, 0x3f, 0x8d, 0x40, 0x44, 0x0e, 0x2e, 0x07, 0xe1, 0x5b, 0x2a, 0x9b, 0xdf, 0x20, 0x99,
-	0x68, 0xfd, 0x04, 0xfb, 0xb9, 0xc8, 0x6c, 0x19, 0x47, 0x8c, 0xa2, 0xaf, 0xa0, 0xc2, 0xe4, 0x9c,
-	0x65, 0xe8, 0xdd, 0xe3, 0x03, 0x15, 0x9a, 0x50, 0xb6, 0x3a, 0xe3, 0x29, 0x05, 0x88, 0x72, 0x41,
-	0x2d, 0xd8, 0x16, 0x17, 0x8f, 0x99, 0x25, 0xd9, 0xa7, 0x77, 0xc1, 0x48, 0x1d, 0xac, 0x2e, 0xec,
-	0x62, 0x7a, 0x21, 0x47, 0xae, 0x2a, 0x7e, 0x00, 0xb5, 0xc9, 0x35, 0x4e, 0x6e, 0x14, 0x02, 0xf5,
-	0x24, 0x75, 0x56, 0x64, 0xcc, 0x44, 0x8b, 0xc1, 0x81, 0x0c, 0xd3, 0x4f, 0xe2, 0xd9, 0x6a, 0x4a,
-	0x67, 0x2a, 0xdc, 0x43, 0x80, 0x65, 0xaa, 0x11, 0x5b, 0x21, 0x8d, 0x97, 0xd3, 0xbc, 0x3f, 0x20,
-	0xb2, 0x60, 0x5b, 0x1e, 0x25, 0xf1, 0xea, 0xc7, 0xba, 0x2a, 0x42, 0x26, 0x21, 0xa9, 0xc9, 0xfa,
-	0x4d, 0x83, 0xfb, 0x1d, 0xaa, 0x28, 0x2b, 0x2f, 0xf1, 0x7a, 0x16, 0x08, 0xb6, 0x72, 0xb7, 0x54,
-	0x9e, 0xc5, 0xc2, 0x28, 0xdc, 0x4b, 0x25, 0x09, 0x7d, 0xfc, 0xfa, 0x35, 0xa3, 0x19, 0xc9, 0x95,
-	0x94, 0xae, 0xa5, 0xb7, 0x54, 0xb2, 0xbb, 0x41, 0xe4, 0x19, 0x19, 0x50, 0x0e, 0xd8, 0x54, 0xb1,
-	0x5a, 0x1c, 0xad, 0x3f, 0x35, 0x38, 0xbc, 0x01, 0xe2, 0x2e, 0x63, 0x13, 0xf0, 0x02, 0xb6, 0xa0,
-	0xe9, 0xdc, 0x74, 0xa2, 0x24, 0xf4, 0x04, 0x76, 0xd2, 0x0d, 0xc5, 0xcc, 0x72, 0x61, 0xa0, 0xb9,
-	0x94, 0x24, 0x73, 0x11, 0x1d, 0x5d, 0x04, 0x0c, 0xd3, 0x4b, 0xae, 0x96, 0x73, 0x26, 0x5a, 0x5f,
-	0xc2, 0x5e, 0x86, 0x33, 0xeb, 0xd2, 0x26, 0xa5, 0x96, 0x4f, 0x69, 0xfd, 0x0a, 0xc6, 0xc6, 0xf5,
-	0x2e, 0xb5, 0x3c, 0x82, 0x8a, 0x1c, 0x51, 0xc6, 0xc1, 0xe2, 0xf8, 0x94, 0x2d, 0x8f, 0xb5, 0x5c,
-	0xc4, 0xfa, 0x0c, 0xee, 0x61, 0x7a, 0x31, 0x4c, 0x82, 0x88, 0x05, 0x53, 0x1e, 0xc6, 0x11, 0x53,
-	0x84, 0x3a, 0x82, 0x2a, 0xbf, 0xec, 0xe6, 0x31, 0xaf, 0x65, 0xeb, 0x6b, 0xc9, 0x86, 0xfc, 0x47,
-	0xb7, 0xd5, 0xf9, 0x47, 0x3a, 0xbb, 0xe2, 0x27, 0x1f, 0x73, 0x76, 0x9f, 0x42, 0x99, 0x5f, 0x66,
-	0x73, 0xab, 0xa9, 0x08, 0xc3, 0x4b, 0x22, 0xb4, 0x1f, 0x18, 0x55, 0x07, 0xf6, 0x3b, 0x94, 0x9f,
-	0x86, 0x8c, 0x85, 0xd1, 0xfc, 0x96, 0x22, 0x44, 0x4b, 0x18, 0x8f, 0x97, 0x8b, 0xcd, 0x22, 0x5f,
-	0xcb, 0xd6, 0x13, 0x40, 0x1d, 0xca, 0xed, 0x68, 0x4a, 0x19, 0x8f, 0x93, 0xdb, 0xda, 0xf1, 0xbb,
-	0x06, 0x07, 0x05, 0xf7, 0xbb, 0xb4, 0xc2, 0x02, 0x3d, 0x50, 0x01, 0x72, 0x6f, 0x4b, 0x41, 0x27,
-	0xd6, 0x42, 0x26, 0xe3, 0x38, 0x7b, 0x5a, 0x36, 0x1a, 0xeb, 0x0b, 0xa8, 0x77, 0x28, 0x17, 0xae,
-	0x27, 0x57, 0x38, 0xce, 0x6f, 0x09, 0xad, 0xb8, 0x76, 0x7e, 0x94, 0x80, 0x33, 0xc7, 0xbb, 0x01,
-	0x2e, 0xac, 0xbc, 0xd2, 0xb5, 0x95, 0x67, 0x4d, 0xe4, 0x55, 0x48, 0x19, 0x96, 0xf5, 0xef, 0x08,
-	0xaa, 0xcb, 0x84, 0xbe, 0xc9, 0xed, 0xc8, 0xb5, 0x9c, 0x6e, 0x3c, 0xfa, 0x06, 0xaf, 0xce, 0x27,
-	0x34, 0xc9, 0x9e, 0xec, 0x8d, 0x66, 0xbd, 0x54, 0xd2, 0xa2, 0xe5, 0xd9, 0x4a, 0xe4, 0xb8, 0xb3,
-	0x1c, 0x1f, 0x93, 0x7f, 0xef, 0xbf, 0x61, 0x9f, 0xc0, 0xa1, 0x77, 0xed, 0xf9, 0x53, 0xe5, 0x89,
-	0xb5, 0x6a, 0xde, 0xb4, 0xdd, 0x05, 0xd6, 0x37, 0x50, 0xcf, 0xbd, 0xc5, 0xb2, 0x1b, 0x1f, 0x78,
-	0xb7, 0xf3, 0xbe, 0xd6, 0x08, 0xcc, 0x42, 0xfa, 0x88, 0x5e, 0xac, 0x5f, 0x95, 0xbb, 0x87, 0x7d,
-	0xfc, 0x57, 0x09, 0xf4, 0x3c, 0x54, 0x54, 0x81, 0x92, 0xff, 0xc2, 0xf8, 0x1f, 0xd2, 0xa1, 0xda,
-	0xb6, 0x71, 0xdb, 0xed, 0xb9, 0x8e, 0xa1, 0xa1, 0x3a, 0xec, 0x8c, 0xf0, 0x0b, 0xec, 0x7f, 0x8f,
-	0x8d, 0x12, 0xfa, 0x3f, 0x18, 0x1e, 0x7e, 0x69, 0xf7, 0x3c, 0x67, 0x6c, 0x93, 0xce, 0xe8, 0xd4,
-	0xc5, 0x43, 0xa3, 0x8c, 0xee, 0xc1, 0xbe, 0xe3, 0xda, 0x4e, 0xcf, 0xc3, 0xee, 0xd8, 0x7d, 0xd5,
-	0x76, 0x5d, 0xc7, 0x75, 0x8c, 0x2d, 0xd4, 0x80, 0x1a, 0xf6, 0x87, 0xe3, 0xe7, 0xfe, 0x08, 0x3b,
-	0xc6, 0x36, 0x42, 0xb0, 0x6b, 0xf7, 0x88, 0x6b, 0x3b, 0x3f, 0x8c, 0xdd, 0x57, 0xde, 0x60, 0x38,
-	0x30, 0x2a, 0xe2, 0xcb, 0xbe, 0x4b, 0x4e, 0xbd, 0xc1, 0xc0, 0xf3, 0xf1, 0xd8, 0x71, 0xb1, 0xe7,
-	0x3a, 0xc6, 0x0e, 0xba, 0x0f, 0x88, 0xb8, 0x03, 0x7f, 0x44, 0xda, 0x22, 0x60, 0xd7, 0x1e, 0x0d,
-	0x86, 0xae, 0x63, 0x54, 0xd1, 0x21, 0x1c, 0x3c, 0xb7, 0xbd, 0x9e, 0xeb, 0x8c, 0xfb, 0xc4, 0x6d,
-	0xfb, 0xd8, 0xf1, 0x86, 0x9e, 0x8f, 0x8d, 0x9a, 0x00, 0x69, 0x9f, 0xf8, 0x44, 0x78, 0x01, 0x32,
-	0x40, 0xf7, 0x47, 0xc3, 0xb1, 0xff, 0x7c, 0x4c, 0x6c, 0xdc, 0x71, 0x8d, 0x3a, 0xda, 0x87, 0xc6,
-	0x08, 0x7b, 0xa7, 0xfd, 0x9e, 0x2b, 0x10, 0xbb, 0x8e, 0xa1, 0x8b, 0x22, 0x3d, 0x3c, 0x74, 0x09,
-	0xb6, 0x7b, 0x46, 0x03, 0xed, 0x41, 0x7d, 0x84, 0xed, 0x97, 0xb6, 0xd7, 0xb3, 0x4f, 0x7a, 0xae,
-	0xb1, 0x2b, 0xb0, 0x3b, 0xf6, 0xd0, 0x1e, 0xf7, 0xfc, 0xc1, 0xc0, 0xd8, 0x43, 0x07, 0xb0, 0x37,
-	0xc2, 0xf6, 0x68, 0xd8, 0x75, 0xf1, 0xd0, 0x6b, 0xdb, 0x22, 0x84, 0x31, 0xa9, 0xc8, 0xff, 0xef,
-	0x67, 0xff, 0x06, 0x00, 0x00, 0xff, 0xff, 0xcc, 0xa1, 0xfe, 0x94, 0x96, 0x0c, 0x00, 0x00,
+	// 1463 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0xcd, 0x6e, 0xdb, 0xc6,
+	0x16, 0xbe, 0xfa, 0xb1, 0x2c, 0x1d, 0x51, 0x36, 0x3d, 0x4e, 0x62, 0x5e, 0xdf, 0x20, 0x57, 0x20,
+	0x82, 0x46, 0x75, 0x83, 0xa0, 0x70, 0x56, 0x45, 0x57, 0xb4, 0x48, 0x4b, 0xac, 0x69, 0x52, 0x1d,
+	0x4a, 0x6e, 0xba, 0x52, 0x29, 0x69, 0x2c, 0xb1, 0xb1, 0x49, 0x95, 0x43, 0xf9, 0x27, 0x9b, 0x02,
+	0x5d, 0xf4, 0x0d, 0xfa, 0x0a, 0x7d, 0x8c, 0x3e, 0x40, 0x8b, 0x3e, 0x52, 0x81, 0x62, 0x86, 0x43,
+	0x89, 0xb4, 0xe3, 0x18, 0x75, 0xb3, 0x9b, 0xf3, 0xcd, 0x99, 0xf3, 0xfb, 0xcd, 0x19, 0x12, 0x6a,
+	0xf3, 0xfd, 0xf9, 0xab, 0x79, 0x14, 0xc6, 0x21, 0x5a, 0x8b, 0xaf, 0xe7, 0x84, 0xee, 0xca, 0xa3,
+	0xb3, 0x70, 0xfc, 0x76, 0x3c, 0xf3, 0xfc, 0x20, 0xd9, 0xd8, 0x85, 0x20, 0x9c, 0x90, 0x64, 0xad,
+	0xfe, 0x55, 0x80, 0xda, 0x31, 0x9d, 0x76, 0x89, 0x37, 0x21, 0x11, 0x7a, 0x0e, 0x8d, 0xf1, 0x99,
+	0x4f, 0x82, 0xf8, 0x84, 0x44, 0xd4, 0x0f, 0x03, 0xa5, 0xd0, 0x2c, 0xb4, 0x6a, 0x38, 0x0f, 0xa2,
+	0xa7, 0x50, 0x8b, 0xfd, 0x73, 0x42, 0x63, 0xef, 0x7c, 0xae, 0x14, 0x9b, 0x85, 0x56, 0x09, 0xaf,
+	0x00, 0xb4, 0x01, 0x45, 0x7f, 0xa2, 0x94, 0xf8, 0xc1, 0xa2, 0x3f, 0x41, 0x4f, 0xa0, 0x32, 0x0d,
+	0x29, 0xf5, 0xe7, 0x4a, 0xb9, 0x59, 0x68, 0x55, 0xb1, 0x90, 0x18, 0x3e, 0x27, 0x24, 0x32, 0x75,
+	0x65, 0xad, 0x59, 0x68, 0x49, 0x58, 0x48, 0xe8, 0x19, 0xf0, 0xf8, 0x7a, 0x8b, 0xd1, 0x11, 0xb9,
+	0x56, 0x2a, 0x7c, 0x2f, 0x83, 0x20, 0x04, 0x65, 0xea, 0x4f, 0x03, 0x65, 0x9d, 0xef, 0xf0, 0x35,
+	0x6a, 0x42, 0x9d, 0x2e, 0x46, 0x3c, 0xa3, 0x71, 0x78, 0xa6, 0x54, 0x9b, 0x85, 0x56, 0x03, 0x67,
+	0x21, 0xe6, 0xed, 0x8c, 0x04, 0xd3, 0x78, 0xa6, 0xd4, 0xf8, 0xa6, 0x90, 0xd4, 0xaf, 0x00, 0x7a,
+	0xfb, 0xbd, 0x63, 0x42, 0xa9, 0x37, 0x25, 0xa8, 0x05, 0x95, 0x19, 0xaf, 0x04, 0x4f, 0xbc, 0xbe,
+	0x2f, 0xbf, 0xe2, 0x35, 0x7c, 0xb5, 0xac, 0x10, 0x16, 0xfb, 0x2c, 0x8a, 0x89, 0x17, 0x7b, 0x3c,
+	0x7d, 0x09, 0xf3, 0xb5, 0xea, 0x40, 0xb9, 0xe7, 0x07, 0x53, 0xf4, 0x09, 0x6c, 0x8e, 0x08, 0x8d,
+	0x87, 0xbc, 0xf0, 0xc3, 0x99, 0x47, 0x67, 0xdc, 0x9c, 0x84, 0x1b, 0x0c, 0x3e, 0x60, 0x68, 0xd7,
+	0xa3, 0x33, 0xf4, 0x7f, 0xa8, 0x73, 0xbd, 0x19, 0xf1, 0xa7, 0xb3, 0x98, 0x9b, 0x2a, 0x63, 0x60,
+	0x50, 0x97, 0x23, 0xaa, 0x05, 0xe5, 0x5e, 0x18, 0x4c, 0x59, 0x5b, 0x72, 0x27, 0xdf, 0x6f, 0xee,
+	0x19, 0x64, 0xce, 0xbe, 0xc7, 0xda, 0x9f, 0x45, 0xa8, 0xb8, 0xb1, 0x17, 0x2f, 0x28, 0xda, 0x83,
+	0x0a, 0x25, 0xc1, 0x2a, 0x4f, 0x24, 0xf2, 0xec, 0x11, 0x12, 0x69, 0x93, 0x49, 0x44, 0x28, 0xc5,
+	0x42, 0xe3, 0xb6, 0xf3, 0xe2, 0xfd, 0xce, 0x4b, 0x37, 0x9d, 0x23, 0x05, 0xd6, 0x39, 0x05, 0x4d,
+	0x9d, 0xd3, 0x40, 0xc2, 0xa9, 0x88, 0x76, 0xa1, 0x1a, 0x84, 0xc6, 0xd5, 0x3c, 0xa4, 0x84, 0x33,
+	0xa1, 0x8a, 0x97, 0x32, 0x3b, 0x75, 0x21, 0x98, 0x58, 0xe1, 0x84, 0x4a, 0x45, 0xb6, 0x33, 0x25,
+	0x01, 0xa1, 0x3e, 0x15, 0x44, 0x48, 0x45, 0xf4, 0x25, 0x48, 0x63, 0x12, 0xc5, 0xfe, 0xa9, 0x3f,
+	0xf6, 0x62, 0x42, 0x95, 0x6a, 0xb3, 0xd4, 0xaa, 0xef, 0xef, 0x88, 0x0c, 0xb5, 0x29, 0x09, 0xe2,
+	0xf6, 0x6a, 0x1f, 0xe7, 0x94, 0xd1, 0x1e, 0xc8, 0x3e, 0xa5, 0x0b, 0x92, 0xd1, 0xe0, 0x84, 0xa9,
+	0xe2, 0x5b, 0xb8, 0xda, 0x02, 0xa9, 0x13, 0x6a, 0x97, 0xde, 0xb5, 0x1d, 0xc6, 0xfe, 0x98, 0x07,
+	0x7b, 0x9e, 0xf0, 0x48, 0x5c, 0x9b, 0x54, 0x54, 0xdf, 0x80, 0x2c, 0xaa, 0x4a, 0x28, 0x26, 0x3f,
+	0x2c, 0x08, 0x8d, 0xff, 0x51, 0x0b, 0x98, 0x65, 0xef, 0xca, 0xf5, 0xdf, 0x11, 0x5e, 0xfc, 0x06,
+	0x4e, 0x45, 0xf5, 0x7b, 0xd8, 0xca, 0x58, 0xa6, 0xf3, 0x30, 0xa0, 0x04, 0x7d, 0x06, 0x15, 0xca,
+	0xfb, 0xcc, 0x4d, 0x6f, 0xec, 0x6f, 0x0b, 0xd3, 0x98, 0xd0, 0xc5, 0x59, 0x9c, 0x50, 0x00, 0x0b,
+	0x15, 0xd4, 0x82, 0x35, 0x76, 0xf1, 0xa8, 0x52, 0x6c, 0x96, 0xee, 0x08, 0x23, 0x51, 0x50, 0xbb,
+	0xb0, 0x61, 0x93, 0x4b, 0xde, 0x72, 0x91, 0xf1, 0x53, 0xa8, 0x8d, 0x6e, 0x70, 0x72, 0x05, 0xb0,
+	0xa8, 0x47, 0x89, 0xb2, 0x20, 0x63, 0x2a, 0xaa, 0x14, 0xb6, 0xb9, 0x99, 0x5e, 0x14, 0x4e, 0x16,
+	0x63, 0x32, 0x11, 0xe6, 0x9e, 0x01, 0xcc, 0x13, 0x84, 0x4d, 0x85, 0xc4, 0x5e, 0x06, 0xb9, 0xdb,
+	0x20, 0x52, 0x61, 0x8d, 0x2f, 0x39, 0xf1, 0xea, 0xfb, 0x92, 0x48, 0x82, 0x3b, 0xc1, 0xc9, 0x96,
+	0xfa, 0x53, 0x01, 0x9e, 0x74, 0x88, 0xa0, 0x2c, 0xbf, 0xc4, 0xcb, 0x5e, 0x20, 0x28, 0x67, 0x6e,
+	0x29, 0x5f, 0xb3, 0x81, 0x91, 0xbb, 0x97, 0x42, 0x62, 0x78, 0x78, 0x7a, 0x4a, 0x49, 0x4a, 0x72,
+	0x21, 0x25, 0x63, 0xe9, 0x1d, 0xe1, 0xec, 0x6e, 0x60, 0xbe, 0x46, 0x32, 0x94, 0x3c, 0x3a, 0x16,
+	0xac, 0x66, 0x4b, 0xf5, 0xd7, 0x02, 0xec, 0xdc, 0x0a, 0xe2, 0x21, 0x6d, 0x63, 0xe1, 0x79, 0x74,
+	0x46, 0x92, 0xbe, 0x49, 0x58, 0x48, 0xe8, 0x25, 0xac, 0x27, 0x13, 0x8a, 0x2a, 0xa5, 0x5c, 0x43,
+	0x33, 0x2e, 0x71, 0xaa, 0xc2, 0x2a, 0x3a, 0xf3, 0xa8, 0x4d, 0xae, 0x62, 0x31, 0x9c, 0x53, 0x51,
+	0xfd, 0x14, 0x36, 0xd3, 0x38, 0xd3, 0x2a, 0xad, 0x5c, 0x16, 0xb2, 0x2e, 0xd5, 0x1f, 0x41, 0x5e,
+	0xa9, 0x3e, 0x24, 0x97, 0xe7, 0x50, 0xe1, 0x2d, 0x4a, 0x39, 0x98, 0x6f, 0x9f, 0xd8, 0xcb, 0xc6,
+	0x5a, 0xca, 0xc7, 0xfa, 0x1a, 0x1e, 0xdb, 0xe4, 0xb2, 0x1f, 0x79, 0x01, 0xf5, 0xc6, 0xb1, 0x1f,
+	0x06, 0x54, 0x10, 0x6a, 0x17, 0xaa, 0xf1, 0x55, 0x37, 0x1b, 0xf3, 0x52, 0x56, 0x3f, 0xe7, 0x6c,
+	0xc8, 0x1e, 0xba, 0x2f, 0xcf, 0x5f, 0x92, 0xde, 0xe5, 0x8f, 0x7c, 0xcc, 0xde, 0xfd, 0x0f, 0x4a,
+	0xf1, 0x55, 0xda, 0xb7, 0x9a, 0xb0, 0xd0, 0xbf, 0xc2, 0x0c, 0xfd, 0x40, 0xab, 0x3a, 0xb0, 0xd5,
+	0x21, 0xf1, 0xb1, 0x4f, 0xa9, 0x1f, 0x4c, 0xef, 0x49, 0x82, 0x95, 0x84, 0xc6, 0xe1, 0x7c, 0xb6,
+	0x1a, 0xe4, 0x4b, 0x59, 0x7d, 0x09, 0xa8, 0x43, 0x62, 0x2d, 0x18, 0x13, 0x1a, 0x87, 0xd1, 0x7d,
+	0xe5, 0xf8, 0xb9, 0x00, 0xdb, 0x39, 0xf5, 0x87, 0x94, 0x42, 0x05, 0xc9, 0x13, 0x06, 0x32, 0x6f,
+	0x4b, 0x0e, 0x63, 0x63, 0x21, 0x95, 0xed, 0x30, 0x7d, 0x5a, 0x56, 0x88, 0xfa, 0x02, 0xea, 0x1d,
+	0x12, 0x33, 0xd5, 0x83, 0x6b, 0x3b, 0xcc, 0x4e, 0x89, 0x42, 0x7e, 0xec, 0x7c, 0x07, 0xdb, 0x19,
+	0xc5, 0x87, 0x05, 0x9c, 0x1b, 0x79, 0xc5, 0x1b, 0x23, 0x4f, 0x1d, 0xf1, 0xab, 0x90, 0x30, 0x2c,
+	0xad, 0xdf, 0x2e, 0x54, 0xe7, 0x11, 0xb9, 0xc8, 0xcc, 0xc8, 0xa5, 0x9c, 0x4c, 0x3c, 0x72, 0x61,
+	0x2f, 0xce, 0x47, 0x24, 0x4a, 0x9f, 0xec, 0x15, 0xb2, 0x1c, 0x2a, 0x49, 0xd2, 0x7c, 0xad, 0x46,
+	0xbc, 0xdd, 0xa9, 0x8f, 0x8f, 0xc9, 0xbf, 0xbb, 0x6f, 0xd8, 0x7f, 0x61, 0xc7, 0xbc, 0xf1, 0xfc,
+	0x89, 0xf4, 0xd8, 0x58, 0x55, 0x6e, 0xef, 0x3d, 0x24, 0xac, 0x2f, 0xa0, 0x9e, 0x79, 0x8b, 0x79,
+	0x35, 0x3e, 0xf0, 0x6e, 0x67, 0x75, 0xd5, 0x01, 0x28, 0x39, 0xf7, 0x01, 0xb9, 0x5c, 0xbe, 0x2a,
+	0xff, 0xc2, 0xec, 0xef, 0xc9, 0x8d, 0x67, 0x71, 0x12, 0x37, 0xf0, 0xe6, 0x74, 0x16, 0xc6, 0x69,
+	0x5b, 0x5f, 0x40, 0xf9, 0xad, 0x1f, 0x4c, 0x6e, 0x24, 0x96, 0x6a, 0x1d, 0xf9, 0xc1, 0x04, 0x73,
+	0x85, 0x0f, 0xbc, 0x5a, 0x08, 0xca, 0x51, 0x18, 0x26, 0xc5, 0x96, 0x30, 0x5f, 0xa3, 0x47, 0xb0,
+	0xe6, 0x9d, 0xc6, 0x24, 0x12, 0x5f, 0x49, 0x89, 0x90, 0xe9, 0xd8, 0x5a, 0xae, 0x63, 0x08, 0xca,
+	0x81, 0x77, 0x4e, 0xc4, 0xc7, 0x11, 0x5f, 0x67, 0x1e, 0xa8, 0xf5, 0xec, 0x03, 0xa5, 0xfe, 0x51,
+	0x00, 0xe5, 0x76, 0x32, 0x0f, 0x69, 0x14, 0x82, 0xf2, 0x5b, 0x72, 0x9d, 0xb2, 0x87, 0xaf, 0x99,
+	0xd7, 0x0b, 0xef, 0x6c, 0x41, 0x92, 0xf1, 0x25, 0x61, 0x21, 0xdd, 0x3d, 0xb6, 0x58, 0xa6, 0x2c,
+	0xde, 0x24, 0xa5, 0x1a, 0x4e, 0x84, 0xe5, 0x77, 0x75, 0x65, 0xf5, 0x5d, 0xbd, 0xbc, 0x05, 0xeb,
+	0xab, 0x5b, 0xb0, 0xf7, 0x5b, 0x11, 0xa4, 0x6c, 0x70, 0xa8, 0x02, 0x45, 0xe7, 0x48, 0xfe, 0x0f,
+	0x92, 0xa0, 0xda, 0xd6, 0xec, 0xb6, 0x61, 0x19, 0xba, 0x5c, 0x40, 0x75, 0x58, 0x1f, 0xd8, 0x47,
+	0xb6, 0xf3, 0x8d, 0x2d, 0x17, 0xd1, 0x23, 0x90, 0x4d, 0xfb, 0x44, 0xb3, 0x4c, 0x7d, 0xa8, 0xe1,
+	0xce, 0xe0, 0xd8, 0xb0, 0xfb, 0x72, 0x09, 0x3d, 0x86, 0x2d, 0xdd, 0xd0, 0x74, 0xcb, 0xb4, 0x8d,
+	0xa1, 0xf1, 0xa6, 0x6d, 0x18, 0xba, 0xa1, 0xcb, 0x65, 0xd4, 0x80, 0x9a, 0xed, 0xf4, 0x87, 0x87,
+	0xce, 0xc0, 0xd6, 0xe5, 0x35, 0x84, 0x60, 0x43, 0xb3, 0xb0, 0xa1, 0xe9, 0xdf, 0x0e, 0x8d, 0x37,
+	0xa6, 0xdb, 0x77, 0xe5, 0x0a, 0x3b, 0xd9, 0x33, 0xf0, 0xb1, 0xe9, 0xba, 0xa6, 0x63, 0x0f, 0x75,
+	0xc3, 0x36, 0x0d, 0x5d, 0x5e, 0x47, 0x4f, 0x00, 0x61, 0xc3, 0x75, 0x06, 0xb8, 0xcd, 0x0c, 0x76,
+	0xb5, 0x81, 0xdb, 0x37, 0x74, 0xb9, 0x8a, 0x76, 0x60, 0xfb, 0x50, 0x33, 0x2d, 0x43, 0x1f, 0xf6,
+	0xb0, 0xd1, 0x76, 0x6c, 0xdd, 0xec, 0x9b, 0x8e, 0x2d, 0xd7, 0x58, 0x90, 0xda, 0x81, 0x83, 0x99,
+	0x16, 0x20, 0x19, 0x24, 0x67, 0xd0, 0x1f, 0x3a, 0x87, 0x43, 0xac, 0xd9, 0x1d, 0x43, 0xae, 0xa3,
+	0x2d, 0x68, 0x0c, 0x6c, 0xf3, 0xb8, 0x67, 0x19, 0x2c, 0x62, 0x43, 0x97, 0x25, 0x96, 0xa4, 0x69,
+	0xf7, 0x0d, 0x6c, 0x6b, 0x96, 0xdc, 0x40, 0x9b, 0x50, 0x1f, 0xd8, 0xda, 0x89, 0x66, 0x5a, 0xda,
+	0x81, 0x65, 0xc8, 0x1b, 0x2c, 0x76, 0x5d, 0xeb, 0x6b, 0x43, 0xcb, 0x71, 0x5d, 0x79, 0x13, 0x6d,
+	0xc3, 0xe6, 0xc0, 0xd6, 0x06, 0xfd, 0xae, 0x61, 0xf7, 0xcd, 0xb6, 0xc6, 0x4c, 0xc8, 0x7b, 0xef,
+	0x40, 0xca, 0x92, 0x95, 0x25, 0xe8, 0xda, 0x5a, 0xcf, 0xed, 0x3a, 0xfd, 0xe1, 0x81, 0xe5, 0xb4,
+	0x59, 0x2d, 0xb7, 0x61, 0x73, 0x89, 0x59, 0x86, 0x76, 0x62, 0xb8, 0x72, 0x21, 0x07, 0x9e, 0x68,
+	0xd6, 0xc0, 0x70, 0xe5, 0x22, 0x2b, 0xc5, 0x12, 0x74, 0xbf, 0xb6, 0x86, 0x96, 0xe9, 0x8a, 0xda,
+	0xe6, 0xe0, 0x43, 0xd3, 0x32, 0xe4, 0xf2, 0xa8, 0xc2, 0x7f, 0xcb, 0x5e, 0xff, 0x3d, 0x00, 0x00,
+	0x12, 0xa2, 0xfa, 0xad, 0x0e, 0x00, 0x00,
 }
@@ -191,3 +191,11 @@ func (m *CertificateRenewedNotice) MarshalZerologObject(e *zerolog.Event) {
 		e.Str("cert", m.Certificate.String())
 	}
 }
+
+func (m *GetStateSnapshotRequest) MarshalZerologObject(e *zerolog.Event) {
+	e.Str("kind", m.Kind.String()).Uint64(LogBlkNo, m.BlockNo).Str("root", enc.ToString(m.Root)).Str("after", enc.ToString(m.After)).Int("hashes", len(m.Hashes)).Str("name", m.Name).Uint64("offset", m.Offset)
+}
+
+func (m *GetStateSnapshotResponse) MarshalZerologObject(e *zerolog.Event) {
+	e.Str(LogRespStatus, m.Status.String()).Bool(LogHasNext, m.HasNext).Int("keys", len(m.Keys)).Int("values", len(m.Values)).Int("names", len(m.Names)).Int("data", len(m.Data)).Uint64("size", m.Size)
+}
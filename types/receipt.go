@@ -471,6 +471,12 @@ func (rm *ReceiptMerkle) GetHash() []byte {
 	return h.Sum(nil)
 }
 
+// MerkleHash returns the hash of r as an entry of the receipts root of the
+// block of blockNo.
+func (r *Receipt) MerkleHash(hardForkConfig BlockVersionner, blockNo BlockNo) []byte {
+	return (&ReceiptMerkle{r, blockNo, hardForkConfig}).GetHash()
+}
+
 type Receipts struct {
 	bloom          *bloomFilter
 	receipts       []*Receipt
@@ -521,6 +527,33 @@ func (rs *Receipts) MerkleRoot() []byte {
 	if rs == nil {
 		return merkle.CalculateMerkleRoot(nil)
 	}
+	return merkle.CalculateMerkleRoot(rs.merkleEntries())
+}
+
+// MerkleProof returns the audit path of the receipt at idx in the merkle tree
+// of the receipts root.
+func (rs *Receipts) MerkleProof(idx int) [][]byte {
+	if rs == nil || idx >= len(rs.receipts) {
+		return nil
+	}
+	return merkle.CalculateMerkleProof(rs.merkleEntries(), idx)
+}
+
+// MerkleLeafCount returns the number of the leaves of the merkle tree of the
+// receipts root, which are the receipts and the bloom filter if any.
+func (rs *Receipts) MerkleLeafCount() int {
+	if rs == nil {
+		return 0
+	}
+	if rs.bloom != nil {
+		return len(rs.receipts) + 1
+	}
+	return len(rs.receipts)
+}
+
+// merkleEntries returns the receipts followed by the bloom filter of the
+// block, if any.
+func (rs *Receipts) merkleEntries() []merkle.MerkleEntry {
 	rsSize := len(rs.receipts)
 	if rs.bloom != nil {
 		rsSize++
@@ -532,7 +565,7 @@ func (rs *Receipts) MerkleRoot() []byte {
 	if rs.bloom != nil {
 		mes[rsSize-1] = rs.bloom
 	}
-	return merkle.CalculateMerkleRoot(mes)
+	return mes
 }
 
 func (rs *Receipts) MarshalBinary() ([]byte, error) {
@@ -843,6 +876,16 @@ type ArgFilter struct {
 	value interface{}
 }
 
+// ArgNo returns the position of the event argument to be checked.
+func (af ArgFilter) ArgNo() int {
+	return af.argNo
+}
+
+// Value returns the expected value of the event argument.
+func (af ArgFilter) Value() interface{} {
+	return af.value
+}
+
 const MAXBLOCKRANGE = 10000
 const padprefix = 0x80
 
@@ -860,6 +903,17 @@ func AddressOrigin(addr []byte) []byte {
 }
 
 func (fi *FilterInfo) ValidateCheck(to uint64) error {
+	return fi.validateCheck(to, true)
+}
+
+// ValidateIndexedCheck validates fi like ValidateCheck but without the block
+// range limit, which only protects the block-by-block event scan. It is used
+// when events are looked up from the event index.
+func (fi *FilterInfo) ValidateIndexedCheck(to uint64) error {
+	return fi.validateCheck(to, false)
+}
+
+func (fi *FilterInfo) validateCheck(to uint64, checkRange bool) error {
 	if fi.ContractAddress == nil {
 		return errors.New("invalid contractAddress:" + string(fi.ContractAddress))
 	}
@@ -868,6 +922,9 @@ func (fi *FilterInfo) ValidateCheck(to uint64) error {
 	} else if len(fi.ContractAddress) != AddressLength {
 		return errors.New("invalid contractAddress:" + string(fi.ContractAddress))
 	}
+	if !checkRange {
+		return nil
+	}
 	if fi.RecentBlockCnt > 0 {
 		if fi.RecentBlockCnt > MAXBLOCKRANGE {
 			return errors.New(fmt.Sprintf("too large value at recentBlockCnt %d (max %d)",
@@ -2237,6 +2237,242 @@ func (m *EnterpriseConfig) GetValues() []string {
 	return nil
 }
 
+// AccountAtBlock is an account or contract address and the block whose state
+// is queried, which is the best block if both blockNo and blockHash are
+// empty. It's compatible with SingleBytes on the wire.
+type AccountAtBlock struct {
+	Value                []byte   `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	BlockNo              uint64   `protobuf:"varint,2,opt,name=blockNo" json:"blockNo,omitempty"`
+	BlockHash            []byte   `protobuf:"bytes,3,opt,name=blockHash,proto3" json:"blockHash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AccountAtBlock) Reset()         { *m = AccountAtBlock{} }
+func (m *AccountAtBlock) String() string { return proto.CompactTextString(m) }
+func (*AccountAtBlock) ProtoMessage()    {}
+func (*AccountAtBlock) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_8d86ee9ecec344df, []int{42}
+}
+func (m *AccountAtBlock) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountAtBlock.Unmarshal(m, b)
+}
+func (m *AccountAtBlock) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountAtBlock.Marshal(b, m, deterministic)
+}
+func (dst *AccountAtBlock) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountAtBlock.Merge(dst, src)
+}
+func (m *AccountAtBlock) XXX_Size() int {
+	return xxx_messageInfo_AccountAtBlock.Size(m)
+}
+func (m *AccountAtBlock) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountAtBlock.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccountAtBlock proto.InternalMessageInfo
+
+func (m *AccountAtBlock) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *AccountAtBlock) GetBlockNo() uint64 {
+	if m != nil {
+		return m.BlockNo
+	}
+	return 0
+}
+
+func (m *AccountAtBlock) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
+// TipStat is the distribution of the priority tips of the ready txs in the
+// mempool. Tips are the tips per unit of gas at TipStatPercentiles, which are
+// empty if there is no ready tx.
+type TipStat struct {
+	BlockVersion         int32    `protobuf:"varint,1,opt,name=blockVersion" json:"blockVersion,omitempty"`
+	GasPrice             []byte   `protobuf:"bytes,2,opt,name=gasPrice,proto3" json:"gasPrice,omitempty"`
+	Count                uint64   `protobuf:"varint,3,opt,name=count" json:"count,omitempty"`
+	Tipped               uint64   `protobuf:"varint,4,opt,name=tipped" json:"tipped,omitempty"`
+	Tips                 [][]byte `protobuf:"bytes,5,rep,name=tips,proto3" json:"tips,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TipStat) Reset()         { *m = TipStat{} }
+func (m *TipStat) String() string { return proto.CompactTextString(m) }
+func (*TipStat) ProtoMessage()    {}
+func (*TipStat) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_8d86ee9ecec344df, []int{43}
+}
+func (m *TipStat) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TipStat.Unmarshal(m, b)
+}
+func (m *TipStat) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TipStat.Marshal(b, m, deterministic)
+}
+func (dst *TipStat) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TipStat.Merge(dst, src)
+}
+func (m *TipStat) XXX_Size() int {
+	return xxx_messageInfo_TipStat.Size(m)
+}
+func (m *TipStat) XXX_DiscardUnknown() {
+	xxx_messageInfo_TipStat.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TipStat proto.InternalMessageInfo
+
+func (m *TipStat) GetBlockVersion() int32 {
+	if m != nil {
+		return m.BlockVersion
+	}
+	return 0
+}
+
+func (m *TipStat) GetGasPrice() []byte {
+	if m != nil {
+		return m.GasPrice
+	}
+	return nil
+}
+
+func (m *TipStat) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *TipStat) GetTipped() uint64 {
+	if m != nil {
+		return m.Tipped
+	}
+	return 0
+}
+
+func (m *TipStat) GetTips() [][]byte {
+	if m != nil {
+		return m.Tips
+	}
+	return nil
+}
+
+// ContractVersion is a version of the code of a contract. The versions are
+// numbered from 1 by deploy and increase by each redeploy. BlockNo is the
+// number of the block from which the code is active, which is 0 if the
+// version was deployed before the history was recorded.
+type ContractVersion struct {
+	Version              uint64   `protobuf:"varint,1,opt,name=version" json:"version,omitempty"`
+	CodeHash             []byte   `protobuf:"bytes,2,opt,name=codeHash,proto3" json:"codeHash,omitempty"`
+	BlockNo              uint64   `protobuf:"varint,3,opt,name=blockNo" json:"blockNo,omitempty"`
+	TxHash               []byte   `protobuf:"bytes,4,opt,name=txHash,proto3" json:"txHash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ContractVersion) Reset()         { *m = ContractVersion{} }
+func (m *ContractVersion) String() string { return proto.CompactTextString(m) }
+func (*ContractVersion) ProtoMessage()    {}
+func (*ContractVersion) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_8d86ee9ecec344df, []int{44}
+}
+func (m *ContractVersion) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ContractVersion.Unmarshal(m, b)
+}
+func (m *ContractVersion) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ContractVersion.Marshal(b, m, deterministic)
+}
+func (dst *ContractVersion) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContractVersion.Merge(dst, src)
+}
+func (m *ContractVersion) XXX_Size() int {
+	return xxx_messageInfo_ContractVersion.Size(m)
+}
+func (m *ContractVersion) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContractVersion.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContractVersion proto.InternalMessageInfo
+
+func (m *ContractVersion) GetVersion() uint64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *ContractVersion) GetCodeHash() []byte {
+	if m != nil {
+		return m.CodeHash
+	}
+	return nil
+}
+
+func (m *ContractVersion) GetBlockNo() uint64 {
+	if m != nil {
+		return m.BlockNo
+	}
+	return 0
+}
+
+func (m *ContractVersion) GetTxHash() []byte {
+	if m != nil {
+		return m.TxHash
+	}
+	return nil
+}
+
+// ContractVersionList is the code history of a contract in the order of the
+// versions.
+type ContractVersionList struct {
+	Versions             []*ContractVersion `protobuf:"bytes,1,rep,name=versions" json:"versions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ContractVersionList) Reset()         { *m = ContractVersionList{} }
+func (m *ContractVersionList) String() string { return proto.CompactTextString(m) }
+func (*ContractVersionList) ProtoMessage()    {}
+func (*ContractVersionList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_8d86ee9ecec344df, []int{45}
+}
+func (m *ContractVersionList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ContractVersionList.Unmarshal(m, b)
+}
+func (m *ContractVersionList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ContractVersionList.Marshal(b, m, deterministic)
+}
+func (dst *ContractVersionList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContractVersionList.Merge(dst, src)
+}
+func (m *ContractVersionList) XXX_Size() int {
+	return xxx_messageInfo_ContractVersionList.Size(m)
+}
+func (m *ContractVersionList) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContractVersionList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContractVersionList proto.InternalMessageInfo
+
+func (m *ContractVersionList) GetVersions() []*ContractVersion {
+	if m != nil {
+		return m.Versions
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*BlockchainStatus)(nil), "types.BlockchainStatus")
 	proto.RegisterType((*ChainId)(nil), "types.ChainId")
@@ -2283,6 +2519,10 @@ func init() {
 	proto.RegisterType((*ConsensusInfo)(nil), "types.ConsensusInfo")
 	proto.RegisterType((*EnterpriseConfigKey)(nil), "types.EnterpriseConfigKey")
 	proto.RegisterType((*EnterpriseConfig)(nil), "types.EnterpriseConfig")
+	proto.RegisterType((*AccountAtBlock)(nil), "types.AccountAtBlock")
+	proto.RegisterType((*TipStat)(nil), "types.TipStat")
+	proto.RegisterType((*ContractVersion)(nil), "types.ContractVersion")
+	proto.RegisterType((*ContractVersionList)(nil), "types.ContractVersionList")
 	proto.RegisterEnum("types.CommitStatus", CommitStatus_name, CommitStatus_value)
 	proto.RegisterEnum("types.VerifyStatus", VerifyStatus_name, VerifyStatus_value)
 }
@@ -2328,8 +2568,8 @@ type AergoRPCServiceClient interface {
 	GetBlockTX(ctx context.Context, in *SingleBytes, opts ...grpc.CallOption) (*TxInBlock, error)
 	// Return transaction receipt, queried by transaction hash
 	GetReceipt(ctx context.Context, in *SingleBytes, opts ...grpc.CallOption) (*Receipt, error)
-	// Return ABI stored at contract address
-	GetABI(ctx context.Context, in *SingleBytes, opts ...grpc.CallOption) (*ABI, error)
+	// Return ABI stored at contract address, at the best block or a given one
+	GetABI(ctx context.Context, in *AccountAtBlock, opts ...grpc.CallOption) (*ABI, error)
 	// Sign and send a transaction from an unlocked account
 	SendTX(ctx context.Context, in *Tx, opts ...grpc.CallOption) (*CommitResult, error)
 	// Sign transaction with unlocked account
@@ -2338,8 +2578,8 @@ type AergoRPCServiceClient interface {
 	VerifyTX(ctx context.Context, in *Tx, opts ...grpc.CallOption) (*VerifyResult, error)
 	// Commit a signed transaction
 	CommitTX(ctx context.Context, in *TxList, opts ...grpc.CallOption) (*CommitResultList, error)
-	// Return state of account
-	GetState(ctx context.Context, in *SingleBytes, opts ...grpc.CallOption) (*State, error)
+	// Return state of account, at the best block or a given one
+	GetState(ctx context.Context, in *AccountAtBlock, opts ...grpc.CallOption) (*State, error)
 	// Return state of account, including merkle proof
 	GetStateAndProof(ctx context.Context, in *AccountAndRoot, opts ...grpc.CallOption) (*AccountProof, error)
 	// Create a new account in this node
@@ -2382,6 +2622,19 @@ type AergoRPCServiceClient interface {
 	GetEnterpriseConfig(ctx context.Context, in *EnterpriseConfigKey, opts ...grpc.CallOption) (*EnterpriseConfig, error)
 	// Return a status of changeCluster enterprise tx,  queried by requestID
 	GetConfChangeProgress(ctx context.Context, in *SingleBytes, opts ...grpc.CallOption) (*ConfChangeProgress, error)
+	// Execute a transaction against the state of the best block without sending it,
+	// and return the receipt it would have in the next block. The nonce, the chain
+	// id hash and the hash are filled in if omitted, and no signature is required
+	SimulateTx(ctx context.Context, in *Tx, opts ...grpc.CallOption) (*Receipt, error)
+	// Return the distribution of the priority tips of the ready transactions in
+	// the mempool
+	GetTipStat(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*TipStat, error)
+	// Return transaction with its merkle proof, queried by transaction hash
+	GetTxProof(ctx context.Context, in *SingleBytes, opts ...grpc.CallOption) (*TxProof, error)
+	// Return transaction receipt with its merkle proof, queried by transaction hash
+	GetReceiptProof(ctx context.Context, in *SingleBytes, opts ...grpc.CallOption) (*ReceiptProof, error)
+	// Return the code versions of contract, at the best block or a given one
+	ListContractVersions(ctx context.Context, in *AccountAtBlock, opts ...grpc.CallOption) (*ContractVersionList, error)
 }
 
 type aergoRPCServiceClient struct {
@@ -2573,7 +2826,7 @@ func (c *aergoRPCServiceClient) GetReceipt(ctx context.Context, in *SingleBytes,
 	return out, nil
 }
 
-func (c *aergoRPCServiceClient) GetABI(ctx context.Context, in *SingleBytes, opts ...grpc.CallOption) (*ABI, error) {
+func (c *aergoRPCServiceClient) GetABI(ctx context.Context, in *AccountAtBlock, opts ...grpc.CallOption) (*ABI, error) {
 	out := new(ABI)
 	err := grpc.Invoke(ctx, "/types.AergoRPCService/GetABI", in, out, c.cc, opts...)
 	if err != nil {
@@ -2618,7 +2871,7 @@ func (c *aergoRPCServiceClient) CommitTX(ctx context.Context, in *TxList, opts .
 	return out, nil
 }
 
-func (c *aergoRPCServiceClient) GetState(ctx context.Context, in *SingleBytes, opts ...grpc.CallOption) (*State, error) {
+func (c *aergoRPCServiceClient) GetState(ctx context.Context, in *AccountAtBlock, opts ...grpc.CallOption) (*State, error) {
 	out := new(State)
 	err := grpc.Invoke(ctx, "/types.AergoRPCService/GetState", in, out, c.cc, opts...)
 	if err != nil {
@@ -2839,6 +3092,51 @@ func (c *aergoRPCServiceClient) GetConfChangeProgress(ctx context.Context, in *S
 	return out, nil
 }
 
+func (c *aergoRPCServiceClient) SimulateTx(ctx context.Context, in *Tx, opts ...grpc.CallOption) (*Receipt, error) {
+	out := new(Receipt)
+	err := grpc.Invoke(ctx, "/types.AergoRPCService/SimulateTx", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aergoRPCServiceClient) GetTipStat(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*TipStat, error) {
+	out := new(TipStat)
+	err := grpc.Invoke(ctx, "/types.AergoRPCService/GetTipStat", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aergoRPCServiceClient) GetTxProof(ctx context.Context, in *SingleBytes, opts ...grpc.CallOption) (*TxProof, error) {
+	out := new(TxProof)
+	err := grpc.Invoke(ctx, "/types.AergoRPCService/GetTxProof", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aergoRPCServiceClient) GetReceiptProof(ctx context.Context, in *SingleBytes, opts ...grpc.CallOption) (*ReceiptProof, error) {
+	out := new(ReceiptProof)
+	err := grpc.Invoke(ctx, "/types.AergoRPCService/GetReceiptProof", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aergoRPCServiceClient) ListContractVersions(ctx context.Context, in *AccountAtBlock, opts ...grpc.CallOption) (*ContractVersionList, error) {
+	out := new(ContractVersionList)
+	err := grpc.Invoke(ctx, "/types.AergoRPCService/ListContractVersions", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for AergoRPCService service
 
 type AergoRPCServiceServer interface {
@@ -2872,8 +3170,8 @@ type AergoRPCServiceServer interface {
 	GetBlockTX(context.Context, *SingleBytes) (*TxInBlock, error)
 	// Return transaction receipt, queried by transaction hash
 	GetReceipt(context.Context, *SingleBytes) (*Receipt, error)
-	// Return ABI stored at contract address
-	GetABI(context.Context, *SingleBytes) (*ABI, error)
+	// Return ABI stored at contract address, at the best block or a given one
+	GetABI(context.Context, *AccountAtBlock) (*ABI, error)
 	// Sign and send a transaction from an unlocked account
 	SendTX(context.Context, *Tx) (*CommitResult, error)
 	// Sign transaction with unlocked account
@@ -2882,8 +3180,8 @@ type AergoRPCServiceServer interface {
 	VerifyTX(context.Context, *Tx) (*VerifyResult, error)
 	// Commit a signed transaction
 	CommitTX(context.Context, *TxList) (*CommitResultList, error)
-	// Return state of account
-	GetState(context.Context, *SingleBytes) (*State, error)
+	// Return state of account, at the best block or a given one
+	GetState(context.Context, *AccountAtBlock) (*State, error)
 	// Return state of account, including merkle proof
 	GetStateAndProof(context.Context, *AccountAndRoot) (*AccountProof, error)
 	// Create a new account in this node
@@ -2926,6 +3224,19 @@ type AergoRPCServiceServer interface {
 	GetEnterpriseConfig(context.Context, *EnterpriseConfigKey) (*EnterpriseConfig, error)
 	// Return a status of changeCluster enterprise tx,  queried by requestID
 	GetConfChangeProgress(context.Context, *SingleBytes) (*ConfChangeProgress, error)
+	// Execute a transaction against the state of the best block without sending it,
+	// and return the receipt it would have in the next block. The nonce, the chain
+	// id hash and the hash are filled in if omitted, and no signature is required
+	SimulateTx(context.Context, *Tx) (*Receipt, error)
+	// Return the distribution of the priority tips of the ready transactions in
+	// the mempool
+	GetTipStat(context.Context, *Empty) (*TipStat, error)
+	// Return transaction with its merkle proof, queried by transaction hash
+	GetTxProof(context.Context, *SingleBytes) (*TxProof, error)
+	// Return transaction receipt with its merkle proof, queried by transaction hash
+	GetReceiptProof(context.Context, *SingleBytes) (*ReceiptProof, error)
+	// Return the code versions of contract, at the best block or a given one
+	ListContractVersions(context.Context, *AccountAtBlock) (*ContractVersionList, error)
 }
 
 func RegisterAergoRPCServiceServer(s *grpc.Server, srv AergoRPCServiceServer) {
@@ -3209,7 +3520,7 @@ func _AergoRPCService_GetReceipt_Handler(srv interface{}, ctx context.Context, d
 }
 
 func _AergoRPCService_GetABI_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SingleBytes)
+	in := new(AccountAtBlock)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -3221,7 +3532,7 @@ func _AergoRPCService_GetABI_Handler(srv interface{}, ctx context.Context, dec f
 		FullMethod: "/types.AergoRPCService/GetABI",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AergoRPCServiceServer).GetABI(ctx, req.(*SingleBytes))
+		return srv.(AergoRPCServiceServer).GetABI(ctx, req.(*AccountAtBlock))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -3299,7 +3610,7 @@ func _AergoRPCService_CommitTX_Handler(srv interface{}, ctx context.Context, dec
 }
 
 func _AergoRPCService_GetState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SingleBytes)
+	in := new(AccountAtBlock)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -3311,7 +3622,7 @@ func _AergoRPCService_GetState_Handler(srv interface{}, ctx context.Context, dec
 		FullMethod: "/types.AergoRPCService/GetState",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AergoRPCServiceServer).GetState(ctx, req.(*SingleBytes))
+		return srv.(AergoRPCServiceServer).GetState(ctx, req.(*AccountAtBlock))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -3697,6 +4008,96 @@ func _AergoRPCService_GetConfChangeProgress_Handler(srv interface{}, ctx context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AergoRPCService_SimulateTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Tx)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AergoRPCServiceServer).SimulateTx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.AergoRPCService/SimulateTx",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AergoRPCServiceServer).SimulateTx(ctx, req.(*Tx))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AergoRPCService_GetTipStat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AergoRPCServiceServer).GetTipStat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.AergoRPCService/GetTipStat",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AergoRPCServiceServer).GetTipStat(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AergoRPCService_GetTxProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SingleBytes)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AergoRPCServiceServer).GetTxProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.AergoRPCService/GetTxProof",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AergoRPCServiceServer).GetTxProof(ctx, req.(*SingleBytes))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AergoRPCService_GetReceiptProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SingleBytes)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AergoRPCServiceServer).GetReceiptProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.AergoRPCService/GetReceiptProof",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AergoRPCServiceServer).GetReceiptProof(ctx, req.(*SingleBytes))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AergoRPCService_ListContractVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountAtBlock)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AergoRPCServiceServer).ListContractVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.AergoRPCService/ListContractVersions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AergoRPCServiceServer).ListContractVersions(ctx, req.(*AccountAtBlock))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _AergoRPCService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "types.AergoRPCService",
 	HandlerType: (*AergoRPCServiceServer)(nil),
@@ -3857,6 +4258,26 @@ var _AergoRPCService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetConfChangeProgress",
 			Handler:    _AergoRPCService_GetConfChangeProgress_Handler,
 		},
+		{
+			MethodName: "SimulateTx",
+			Handler:    _AergoRPCService_SimulateTx_Handler,
+		},
+		{
+			MethodName: "GetTipStat",
+			Handler:    _AergoRPCService_GetTipStat_Handler,
+		},
+		{
+			MethodName: "GetTxProof",
+			Handler:    _AergoRPCService_GetTxProof_Handler,
+		},
+		{
+			MethodName: "GetReceiptProof",
+			Handler:    _AergoRPCService_GetReceiptProof_Handler,
+		},
+		{
+			MethodName: "ListContractVersions",
+			Handler:    _AergoRPCService_ListContractVersions_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -3881,169 +4302,182 @@ var _AergoRPCService_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("rpc.proto", fileDescriptor_rpc_8d86ee9ecec344df) }
 
 var fileDescriptor_rpc_8d86ee9ecec344df = []byte{
-	// 2612 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x59, 0xdd, 0x73, 0x1b, 0xb7,
-	0x11, 0x27, 0x29, 0x51, 0x22, 0x97, 0xa4, 0x44, 0xc1, 0xb2, 0xad, 0xb0, 0x89, 0xa3, 0xa2, 0x6e,
-	0xa2, 0xb8, 0x89, 0x1a, 0xd3, 0x49, 0x9a, 0x7e, 0x25, 0xa5, 0x18, 0xda, 0xe2, 0x58, 0xa6, 0x54,
-	0x90, 0x71, 0x95, 0x97, 0xb2, 0xa7, 0x3b, 0x90, 0xbc, 0x11, 0x79, 0x77, 0xb9, 0x03, 0xf5, 0x91,
-	0x99, 0x3e, 0xf5, 0xa9, 0xff, 0x41, 0x67, 0xfa, 0x5f, 0xf5, 0xbd, 0xd3, 0xfe, 0x29, 0x1d, 0x2c,
-	0x80, 0xfb, 0xa0, 0xce, 0x9d, 0xba, 0x6f, 0xb7, 0x8b, 0xdf, 0x7e, 0x01, 0x8b, 0xc5, 0x02, 0x07,
-	0xd5, 0x30, 0xb0, 0x0f, 0x83, 0xd0, 0x17, 0x3e, 0x29, 0x8b, 0xdb, 0x80, 0x47, 0xad, 0xe6, 0xc5,
-	0xdc, 0xb7, 0x2f, 0xed, 0x99, 0xe5, 0x7a, 0x6a, 0xa0, 0xd5, 0xb0, 0x6c, 0xdb, 0x5f, 0x7a, 0x42,
-	0x93, 0xe0, 0xf9, 0x0e, 0xd7, 0xdf, 0xd5, 0xa0, 0x1d, 0xe8, 0xcf, 0xfa, 0x82, 0x8b, 0xd0, 0xb5,
-	0x0d, 0x28, 0xb4, 0x26, 0x5a, 0x80, 0xfe, 0xbb, 0x08, 0xcd, 0xa3, 0x58, 0xe9, 0x50, 0x58, 0x62,
-	0x19, 0x91, 0x0f, 0x60, 0xfb, 0x82, 0x47, 0x62, 0x8c, 0xd6, 0xc6, 0x33, 0x2b, 0x9a, 0xed, 0x15,
-	0xf7, 0x8b, 0x07, 0x75, 0xd6, 0x90, 0x6c, 0x84, 0x1f, 0x5b, 0xd1, 0x8c, 0xbc, 0x0f, 0x35, 0xc4,
-	0xcd, 0xb8, 0x3b, 0x9d, 0x89, 0xbd, 0xd2, 0x7e, 0xf1, 0x60, 0x9d, 0x81, 0x64, 0x1d, 0x23, 0x87,
-	0xfc, 0x14, 0xb6, 0x6c, 0xdf, 0x8b, 0xb8, 0x17, 0x2d, 0xa3, 0xb1, 0xeb, 0x4d, 0xfc, 0xbd, 0xb5,
-	0xfd, 0xe2, 0x41, 0x95, 0x35, 0x62, 0x6e, 0xdf, 0x9b, 0xf8, 0xe4, 0x67, 0x40, 0x50, 0x0f, 0xfa,
-	0x30, 0x76, 0x1d, 0x65, 0x72, 0x1d, 0x4d, 0xa2, 0x27, 0x5d, 0x39, 0xd0, 0x77, 0xd0, 0xe8, 0xcf,
-	0x01, 0x34, 0x4e, 0xea, 0x2b, 0xef, 0x17, 0x0f, 0x6a, 0xed, 0xe6, 0x21, 0xce, 0xcf, 0xa1, 0xc2,
-	0x79, 0x13, 0x9f, 0x55, 0x6d, 0xf3, 0x49, 0xff, 0x5a, 0x84, 0x4d, 0xad, 0x80, 0xec, 0x42, 0x79,
-	0x61, 0x4d, 0x5d, 0x1b, 0xe3, 0xa9, 0x32, 0x45, 0x90, 0x07, 0xb0, 0x11, 0x2c, 0x2f, 0xe6, 0xae,
-	0x8d, 0x21, 0x54, 0x98, 0xa6, 0xc8, 0x1e, 0x6c, 0x2e, 0x2c, 0xd7, 0xf3, 0xb8, 0x40, 0xbf, 0x2b,
-	0xcc, 0x90, 0xe4, 0x5d, 0xa8, 0xc6, 0x21, 0xa0, 0xa3, 0x55, 0x96, 0x30, 0xa4, 0xdc, 0x15, 0x0f,
-	0x23, 0xd7, 0xf7, 0xd0, 0xbf, 0x32, 0x33, 0x24, 0xfd, 0x57, 0x09, 0xaa, 0xb1, 0x93, 0xe4, 0x11,
-	0x94, 0x5c, 0x07, 0x5d, 0xa9, 0xb5, 0xb7, 0x32, 0x21, 0x38, 0xac, 0xe4, 0x3a, 0xa4, 0x05, 0x95,
-	0x8b, 0x60, 0xb0, 0x5c, 0x5c, 0xf0, 0x10, 0x3d, 0x6b, 0xb0, 0x98, 0x26, 0x14, 0xea, 0x0b, 0xeb,
-	0x06, 0x57, 0x28, 0x72, 0x7f, 0xe0, 0xe8, 0xe0, 0x3a, 0xcb, 0xf0, 0xa4, 0x97, 0x0b, 0xeb, 0x46,
-	0xf8, 0x97, 0xdc, 0x8b, 0xf4, 0x74, 0x26, 0x0c, 0xf2, 0x01, 0x6c, 0x45, 0xc2, 0xba, 0x74, 0xbd,
-	0xe9, 0xc2, 0xf5, 0xdc, 0xc5, 0x72, 0x81, 0xce, 0xd6, 0xd9, 0x0a, 0x57, 0x5a, 0x12, 0xbe, 0xb0,
-	0xe6, 0x9a, 0xbd, 0xb7, 0x81, 0xa8, 0x0c, 0x4f, 0x7a, 0x3a, 0xb5, 0xa2, 0x20, 0x74, 0x6d, 0xbe,
-	0xb7, 0x89, 0xe3, 0x31, 0x2d, 0xbd, 0xf0, 0xac, 0x05, 0x57, 0x83, 0x15, 0xe5, 0x45, 0xcc, 0x20,
-	0x4f, 0xa0, 0x89, 0x9a, 0xae, 0x7c, 0xe1, 0x7a, 0xd3, 0xc0, 0xbf, 0xe6, 0xe1, 0x5e, 0x15, 0x41,
-	0x77, 0xf8, 0xd2, 0x13, 0x45, 0x86, 0xfc, 0xda, 0x0a, 0x9d, 0x3d, 0x50, 0x9e, 0xa4, 0x79, 0xf4,
-	0x31, 0x40, 0xd7, 0xa4, 0x72, 0x24, 0x57, 0x36, 0xe4, 0x81, 0x1f, 0x0a, 0xbd, 0xe0, 0x9a, 0xa2,
-	0x36, 0x94, 0xfb, 0x5e, 0xb0, 0x14, 0x84, 0xc0, 0x7a, 0x2a, 0xbf, 0xf1, 0x5b, 0x2e, 0x9f, 0xe5,
-	0x38, 0x21, 0x8f, 0xa2, 0xbd, 0xd2, 0xfe, 0xda, 0x41, 0x9d, 0x19, 0x52, 0xa6, 0xcf, 0x95, 0x35,
-	0x5f, 0xaa, 0xd9, 0xae, 0x33, 0x45, 0x48, 0x23, 0x91, 0x1d, 0xba, 0x81, 0xd0, 0x73, 0xac, 0x29,
-	0x3a, 0x81, 0x8d, 0xd3, 0xa5, 0x90, 0x56, 0x76, 0xa1, 0xec, 0x7a, 0x0e, 0xbf, 0x41, 0x33, 0x0d,
-	0xa6, 0x88, 0xac, 0x9d, 0xe2, 0xff, 0x6f, 0x67, 0x13, 0xca, 0xbd, 0x45, 0x20, 0x6e, 0xe9, 0x4f,
-	0xa0, 0x36, 0x74, 0xbd, 0xe9, 0x9c, 0x1f, 0xdd, 0x0a, 0x9e, 0xd2, 0x52, 0x4c, 0x69, 0xa1, 0x8f,
-	0xa1, 0xae, 0x40, 0x43, 0x11, 0xca, 0xa5, 0xcb, 0xa0, 0xaa, 0x06, 0xf5, 0x01, 0x6c, 0x75, 0x54,
-	0x65, 0xe9, 0xac, 0xfa, 0x94, 0xd1, 0xf6, 0xc7, 0x04, 0xe7, 0x39, 0xcc, 0xf7, 0x85, 0x8c, 0x4a,
-	0x73, 0x34, 0xd2, 0x90, 0x72, 0xae, 0x25, 0x42, 0x07, 0x8b, 0xdf, 0xe4, 0x11, 0x40, 0xd7, 0x5f,
-	0x04, 0xd2, 0x02, 0x77, 0xf4, 0x2e, 0x4b, 0x71, 0xe8, 0x3f, 0x4b, 0xb0, 0x7e, 0xc6, 0x79, 0x48,
-	0x3e, 0x4e, 0x26, 0x4b, 0x6d, 0x18, 0xa2, 0x37, 0x8c, 0x1c, 0xd5, 0x3e, 0x26, 0x13, 0xf8, 0x0c,
-	0xaa, 0xb2, 0x6e, 0xe0, 0x56, 0x40, 0x7b, 0xb5, 0xf6, 0x7d, 0x8d, 0x1f, 0xf0, 0x6b, 0xac, 0x60,
-	0x03, 0x5f, 0xb8, 0x36, 0x67, 0x09, 0x4e, 0x46, 0x18, 0x09, 0x4b, 0xa8, 0x59, 0x2f, 0x33, 0x45,
-	0xc8, 0x59, 0x9f, 0xb9, 0x8e, 0xc3, 0x3d, 0x9c, 0xf5, 0x0a, 0xd3, 0x94, 0x4c, 0xeb, 0xb9, 0x15,
-	0xcd, 0xba, 0x33, 0x6e, 0x5f, 0xe2, 0xce, 0x59, 0x63, 0x09, 0x43, 0x6e, 0x88, 0x88, 0xcf, 0x27,
-	0x01, 0xe7, 0x21, 0x6e, 0x98, 0x0a, 0x8b, 0xe9, 0x74, 0x79, 0xd8, 0xc4, 0x39, 0x37, 0x24, 0xf9,
-	0x35, 0xd4, 0x6d, 0x1e, 0x0a, 0x77, 0xe2, 0xda, 0x96, 0xe0, 0xd1, 0x5e, 0x65, 0x7f, 0xed, 0xa0,
-	0xd6, 0x7e, 0xa8, 0x3d, 0xef, 0x4c, 0xb9, 0x27, 0xba, 0xc9, 0x38, 0xcb, 0x80, 0xc9, 0x33, 0xa8,
-	0x5b, 0xb6, 0xcd, 0x03, 0xc1, 0x1d, 0xe6, 0xcf, 0x39, 0xee, 0xa2, 0xad, 0xf6, 0x76, 0x6a, 0x9a,
-	0x24, 0x9b, 0x65, 0x40, 0xf4, 0x13, 0xa8, 0xc8, 0x91, 0x13, 0x37, 0x12, 0xe4, 0xc7, 0x50, 0x96,
-	0xfe, 0xc9, 0x09, 0x96, 0x66, 0x6b, 0x69, 0x49, 0x35, 0x42, 0xaf, 0x00, 0x24, 0xf4, 0xcc, 0x0a,
-	0xad, 0x45, 0x94, 0xbb, 0x79, 0xe4, 0x74, 0xa5, 0x8f, 0x03, 0x4d, 0x49, 0x6c, 0x5c, 0xa7, 0x1a,
-	0x0c, 0xbf, 0x25, 0xd6, 0x9f, 0x4c, 0x22, 0xae, 0x12, 0xba, 0xc1, 0x34, 0x45, 0x9a, 0xb0, 0x66,
-	0x45, 0x36, 0x4e, 0x6a, 0x85, 0xc9, 0x4f, 0xfa, 0x25, 0xc0, 0x99, 0x35, 0xe5, 0xda, 0x6e, 0x22,
-	0x57, 0xcc, 0xc8, 0x19, 0x1b, 0xa5, 0xc4, 0x06, 0xbd, 0x81, 0x2d, 0x5c, 0xee, 0x23, 0xdf, 0xb9,
-	0x95, 0x2a, 0xf0, 0x0c, 0xc0, 0xca, 0x62, 0x36, 0x23, 0x12, 0x29, 0x9d, 0xa5, 0x5c, 0x9d, 0x69,
-	0xbf, 0x1f, 0xc3, 0xfa, 0x85, 0xef, 0xdc, 0xa2, 0xd7, 0xc9, 0xe1, 0x13, 0x9b, 0x61, 0x38, 0x4a,
-	0xff, 0x04, 0xdb, 0x29, 0xcb, 0xe8, 0x38, 0x85, 0xba, 0x9c, 0x24, 0x3f, 0xf4, 0x54, 0x51, 0x57,
-	0x13, 0x97, 0xe1, 0x91, 0x8f, 0x60, 0x23, 0xb0, 0xa6, 0xb2, 0xd0, 0xaa, 0xbc, 0xdd, 0x31, 0xcb,
-	0x10, 0xc7, 0xcf, 0x34, 0x80, 0xfe, 0x42, 0x5b, 0x38, 0xe6, 0x96, 0xa3, 0xd7, 0xf0, 0x31, 0x6c,
-	0xa8, 0xfa, 0xaf, 0x17, 0xb1, 0x9e, 0x76, 0x8e, 0xe9, 0x31, 0xfa, 0x67, 0x68, 0x20, 0xe3, 0x15,
-	0x17, 0x96, 0x63, 0x09, 0x2b, 0x77, 0x25, 0x9f, 0xc8, 0x95, 0x94, 0x8a, 0xb5, 0x23, 0x24, 0xad,
-	0x4a, 0x99, 0x64, 0x1a, 0x21, 0x53, 0x5a, 0xdc, 0xa8, 0x4d, 0xaf, 0x36, 0x8f, 0x21, 0xe3, 0xf9,
-	0x5b, 0xc7, 0x1d, 0xa2, 0xd6, 0xa4, 0x03, 0x3b, 0x19, 0xf3, 0xe8, 0xf9, 0xc7, 0x2b, 0x9e, 0xef,
-	0xa6, 0xcd, 0x19, 0x64, 0x1c, 0x01, 0x87, 0x7a, 0xd7, 0x5f, 0x2c, 0x5c, 0xc1, 0x78, 0xb4, 0x9c,
-	0xe7, 0xd7, 0xf1, 0x8f, 0xa0, 0xcc, 0xc3, 0xd0, 0x57, 0xfe, 0x6f, 0xb5, 0xef, 0x99, 0x13, 0x16,
-	0xe5, 0x54, 0xab, 0xc3, 0x14, 0x42, 0xae, 0xbe, 0xc3, 0x85, 0xe5, 0xce, 0x75, 0x83, 0xa2, 0x29,
-	0xda, 0x81, 0x66, 0xda, 0x0c, 0x3a, 0xfa, 0x09, 0x6c, 0x86, 0x48, 0x19, 0x4f, 0xb3, 0x8a, 0x15,
-	0x92, 0x19, 0x0c, 0x1d, 0x41, 0xfd, 0x35, 0x0f, 0xdd, 0xc9, 0xad, 0xf6, 0xf4, 0x1d, 0x28, 0x89,
-	0x1b, 0x5d, 0xc3, 0xaa, 0x5a, 0x72, 0x74, 0xc3, 0x4a, 0xe2, 0xe6, 0x4d, 0x0e, 0x2b, 0xf1, 0x8c,
-	0xc3, 0x74, 0x24, 0xf7, 0x6d, 0x18, 0xf9, 0x9e, 0x35, 0x97, 0x35, 0x34, 0xb0, 0xa2, 0x28, 0x98,
-	0x85, 0x56, 0x64, 0xca, 0x78, 0x8a, 0x43, 0x0e, 0x60, 0x53, 0x77, 0x89, 0x7a, 0x25, 0x4d, 0xaf,
-	0xa1, 0x0b, 0x33, 0x33, 0xc3, 0xf4, 0x6f, 0x45, 0xa8, 0xf7, 0x17, 0xf2, 0x84, 0x7c, 0xee, 0x87,
-	0x0b, 0x4b, 0xa6, 0xd3, 0xda, 0xb5, 0x3b, 0x59, 0xa9, 0xb8, 0xa9, 0x33, 0x86, 0xc9, 0x61, 0xb9,
-	0xfa, 0xfe, 0xdc, 0x91, 0x16, 0xd1, 0x40, 0x95, 0x19, 0x52, 0x8e, 0x78, 0xfc, 0x1a, 0x47, 0xd4,
-	0xc4, 0x1a, 0x92, 0x1c, 0x42, 0xe5, 0x92, 0xdf, 0x46, 0xc2, 0x0f, 0xb9, 0xde, 0x47, 0x79, 0xea,
-	0x63, 0x0c, 0xfd, 0x1c, 0x36, 0x87, 0xba, 0xd9, 0x78, 0x00, 0x1b, 0xd6, 0x22, 0x75, 0xc0, 0x68,
-	0x4a, 0xe6, 0xc0, 0xf5, 0x8c, 0x7b, 0xba, 0xf0, 0xe0, 0x37, 0xfd, 0x0d, 0xac, 0xbf, 0xf6, 0x05,
-	0x36, 0x21, 0xb6, 0xe5, 0x39, 0xae, 0x23, 0xeb, 0xbb, 0x12, 0x4b, 0x18, 0x29, 0x8d, 0xa5, 0xb4,
-	0x46, 0xda, 0x06, 0x90, 0xd2, 0x7a, 0xf7, 0x6e, 0xc5, 0xed, 0x5a, 0x15, 0xdb, 0xb3, 0x5d, 0x28,
-	0x27, 0xb3, 0xda, 0x60, 0x8a, 0xa0, 0x0e, 0x6c, 0xeb, 0x79, 0x95, 0xa2, 0xd8, 0xe7, 0x1d, 0xc0,
-	0xa6, 0x69, 0x9e, 0xb2, 0xcd, 0x9e, 0x8e, 0x88, 0x99, 0x61, 0xf2, 0x21, 0x6c, 0xa8, 0x6e, 0x06,
-	0x3b, 0x8f, 0x5a, 0x5c, 0xbd, 0x8d, 0x2a, 0xa6, 0x87, 0x29, 0x83, 0x4a, 0xac, 0x7e, 0xd5, 0xaf,
-	0x47, 0x00, 0x71, 0x68, 0xaa, 0x85, 0xa9, 0xb2, 0x14, 0x27, 0x15, 0xad, 0x4e, 0x76, 0x1d, 0xed,
-	0x6f, 0x95, 0x4e, 0x73, 0x16, 0x5c, 0xf9, 0x52, 0x3c, 0x7b, 0x16, 0xc8, 0x71, 0xa6, 0x46, 0xb4,
-	0xd9, 0x92, 0x31, 0x4b, 0x3b, 0xb0, 0x39, 0xf0, 0x1d, 0xce, 0xf8, 0xf7, 0x58, 0x0e, 0xdc, 0x05,
-	0xf7, 0x97, 0x71, 0x0f, 0xa0, 0x49, 0xd5, 0x38, 0x2f, 0x02, 0xdf, 0xe3, 0xf1, 0x64, 0x27, 0x0c,
-	0xfa, 0x19, 0xac, 0x0f, 0xac, 0x05, 0x97, 0x2b, 0x29, 0x3b, 0x44, 0x1d, 0x13, 0x7e, 0x4b, 0x9d,
-	0x17, 0xea, 0xdc, 0xd6, 0x0b, 0x6c, 0x48, 0x6a, 0x43, 0x45, 0x4a, 0xe1, 0x5c, 0xbc, 0x9f, 0x92,
-	0x4c, 0xdc, 0x96, 0xc3, 0x5a, 0xcd, 0x2e, 0x94, 0xfd, 0x6b, 0x4f, 0x17, 0xb5, 0x3a, 0x53, 0x04,
-	0xd9, 0x87, 0x9a, 0xc3, 0x23, 0xe1, 0x7a, 0x96, 0x90, 0xc7, 0xb2, 0x6a, 0xbb, 0xd2, 0x2c, 0xda,
-	0x83, 0x9a, 0x3c, 0x08, 0x23, 0x9d, 0x0b, 0x2d, 0xa8, 0x78, 0xfe, 0xb1, 0xea, 0x0b, 0x8a, 0xea,
-	0x7c, 0x37, 0x34, 0x9e, 0xfd, 0x33, 0xff, 0x7a, 0xc8, 0xe7, 0x13, 0x7d, 0xa1, 0x88, 0x69, 0xfa,
-	0x1e, 0x54, 0x5f, 0x72, 0x73, 0x1c, 0x34, 0x61, 0xed, 0x92, 0xdf, 0xe2, 0x14, 0x57, 0x99, 0xfc,
-	0xa4, 0x7f, 0x29, 0x01, 0x0c, 0x79, 0x78, 0xc5, 0x43, 0x8c, 0xe6, 0x73, 0xd8, 0x88, 0x70, 0xdb,
-	0xeb, 0x65, 0x78, 0xcf, 0xe4, 0x4d, 0x0c, 0x39, 0x54, 0x65, 0xa1, 0xe7, 0x89, 0xf0, 0x96, 0x69,
-	0xb0, 0x14, 0xb3, 0x7d, 0x6f, 0xe2, 0x9a, 0x2c, 0xca, 0x11, 0xeb, 0xe2, 0xb8, 0x16, 0x53, 0xe0,
-	0xd6, 0x2f, 0xa1, 0x96, 0xd2, 0x96, 0x78, 0x57, 0xd4, 0xde, 0x25, 0x2d, 0x60, 0x29, 0xd5, 0x2a,
-	0xfe, 0xaa, 0xf4, 0x65, 0xb1, 0x75, 0x02, 0xb5, 0x94, 0xc6, 0x1c, 0xd1, 0x0f, 0xd3, 0xa2, 0xc9,
-	0xa1, 0xa6, 0x84, 0xfa, 0x82, 0x2f, 0x52, 0xda, 0xe8, 0x0f, 0xb2, 0x29, 0x34, 0x03, 0xa4, 0x0d,
-	0xe5, 0x20, 0xf4, 0x83, 0x48, 0x07, 0xf3, 0xee, 0x1d, 0xd1, 0xc3, 0x33, 0x39, 0xac, 0x62, 0x51,
-	0xd0, 0x96, 0xec, 0x17, 0x62, 0xe6, 0xdb, 0x44, 0x42, 0x9f, 0x42, 0xb5, 0x77, 0xc5, 0x3d, 0x61,
-	0x4e, 0x53, 0x2e, 0x89, 0xd5, 0xd3, 0x14, 0x11, 0x4c, 0x8f, 0xd1, 0x3e, 0x34, 0xba, 0x99, 0xfb,
-	0x2c, 0x81, 0x75, 0x89, 0x33, 0xe9, 0x2b, 0xbf, 0x25, 0x0f, 0x2f, 0xac, 0xca, 0x20, 0x7e, 0x4b,
-	0xbf, 0x2e, 0x02, 0x59, 0x19, 0x71, 0xfd, 0x2f, 0x82, 0x88, 0x7e, 0x08, 0xf7, 0x7a, 0x9e, 0xe0,
-	0x61, 0x10, 0xba, 0x11, 0x57, 0x11, 0xbe, 0xe4, 0x39, 0x01, 0xd0, 0x13, 0x68, 0xae, 0x02, 0x73,
-	0xc2, 0xdc, 0x82, 0x92, 0xef, 0xe9, 0x1c, 0x2c, 0xf9, 0x9e, 0xdc, 0xf9, 0x18, 0xa9, 0xb1, 0xa9,
-	0xa9, 0x27, 0xff, 0x28, 0x9a, 0xe3, 0x54, 0xbf, 0x00, 0x54, 0xa1, 0x3c, 0x3a, 0x1f, 0x9f, 0xbe,
-	0x6c, 0x16, 0xc8, 0x2e, 0x34, 0x47, 0xe7, 0xe3, 0xc1, 0xe9, 0xa0, 0xdb, 0x1b, 0x8f, 0x4e, 0x4f,
-	0xc7, 0x27, 0xa7, 0x7f, 0x68, 0x16, 0xc9, 0x7d, 0xd8, 0x19, 0x9d, 0x8f, 0x3b, 0x27, 0xac, 0xd7,
-	0xf9, 0xe6, 0xbb, 0x71, 0xef, 0xbc, 0x3f, 0x1c, 0x0d, 0x9b, 0x25, 0x72, 0x0f, 0xb6, 0x47, 0xe7,
-	0xe3, 0xfe, 0xe0, 0x75, 0xe7, 0xa4, 0xff, 0xcd, 0xf8, 0xb8, 0x33, 0x3c, 0x6e, 0xae, 0xad, 0x30,
-	0x87, 0xfd, 0x17, 0x83, 0xe6, 0xba, 0x56, 0x60, 0x98, 0xcf, 0x4f, 0xd9, 0xab, 0xce, 0xa8, 0x59,
-	0x26, 0x3f, 0x82, 0x87, 0xc8, 0x1e, 0x7e, 0xfb, 0xfc, 0x79, 0xbf, 0xdb, 0xef, 0x0d, 0x46, 0xe3,
-	0xa3, 0xce, 0x49, 0x67, 0xd0, 0xed, 0x35, 0x37, 0xb4, 0xcc, 0x71, 0x67, 0x38, 0x1e, 0x76, 0x5e,
-	0xf5, 0x94, 0x4f, 0xcd, 0xcd, 0x58, 0xd5, 0xa8, 0xc7, 0x06, 0x9d, 0x93, 0x71, 0x8f, 0xb1, 0x53,
-	0xd6, 0xac, 0x3e, 0x99, 0x98, 0x83, 0x57, 0xc7, 0xb4, 0x0b, 0xcd, 0xd7, 0x3d, 0xd6, 0x7f, 0xfe,
-	0xdd, 0x78, 0x38, 0xea, 0x8c, 0xbe, 0x1d, 0xaa, 0xf0, 0xf6, 0xe1, 0xdd, 0x2c, 0x57, 0xfa, 0x37,
-	0x1e, 0x9c, 0x8e, 0xc6, 0xaf, 0x3a, 0xa3, 0xee, 0x71, 0xb3, 0x48, 0x1e, 0x41, 0x2b, 0x8b, 0xc8,
-	0x84, 0x57, 0x6a, 0xff, 0x9d, 0xc0, 0x76, 0x87, 0x87, 0x53, 0x9f, 0x9d, 0x75, 0xe5, 0x0e, 0x93,
-	0xb7, 0xda, 0xa7, 0x50, 0x95, 0xb5, 0x70, 0x88, 0x37, 0x08, 0x53, 0xed, 0x75, 0x75, 0x6c, 0xe5,
-	0x1c, 0x74, 0xb4, 0x40, 0x9e, 0xc2, 0xc6, 0x2b, 0x7c, 0xa5, 0x21, 0xe6, 0xa6, 0xa2, 0xc8, 0x88,
-	0xf1, 0xef, 0x97, 0x3c, 0x12, 0xad, 0xad, 0x2c, 0x9b, 0x16, 0xc8, 0xe7, 0x00, 0xc9, 0xdb, 0x0d,
-	0x89, 0x93, 0x53, 0xde, 0x05, 0x5b, 0x0f, 0xd3, 0xed, 0x53, 0xea, 0x71, 0x87, 0x16, 0xc8, 0xa7,
-	0x50, 0x7f, 0xc1, 0x45, 0xf2, 0x0c, 0x91, 0x15, 0xbc, 0xf3, 0x96, 0x42, 0x0b, 0xe4, 0x50, 0xbf,
-	0x5a, 0x48, 0x15, 0x2b, 0xf0, 0x9d, 0x34, 0x1c, 0x2f, 0xdd, 0xb4, 0x40, 0xbe, 0x86, 0xa6, 0xdc,
-	0x3f, 0xa9, 0x4e, 0x31, 0x22, 0x06, 0x98, 0xdc, 0x1f, 0x5a, 0x0f, 0xee, 0x76, 0x94, 0x72, 0x94,
-	0x16, 0xc8, 0x11, 0xec, 0xc4, 0x0a, 0xe2, 0x26, 0x35, 0x47, 0xc3, 0x5e, 0x5e, 0x93, 0xa8, 0x75,
-	0x3c, 0x85, 0xed, 0x58, 0xc7, 0x50, 0x84, 0xdc, 0x5a, 0xac, 0xb8, 0x9e, 0xe9, 0x8d, 0x69, 0xe1,
-	0xd3, 0x22, 0xe9, 0xc0, 0xc3, 0x3b, 0x66, 0x73, 0x45, 0x73, 0x9b, 0x53, 0x54, 0x71, 0x08, 0x95,
-	0x17, 0x5c, 0x69, 0x20, 0x39, 0x0b, 0xbd, 0x6a, 0x94, 0x7c, 0x05, 0x4d, 0x83, 0x4f, 0xba, 0xf1,
-	0x1c, 0xb9, 0x37, 0x58, 0x24, 0x5f, 0xe3, 0x62, 0xc6, 0x17, 0x0d, 0xf2, 0x60, 0xf5, 0x36, 0xa2,
-	0x67, 0xea, 0xfe, 0x5d, 0xfe, 0x94, 0x3b, 0xb4, 0x40, 0x0e, 0xa0, 0xfc, 0x82, 0x8b, 0xd1, 0x79,
-	0xae, 0xd5, 0xa4, 0x41, 0xa5, 0x05, 0xf2, 0x19, 0x80, 0x31, 0xf5, 0x06, 0x78, 0x33, 0x86, 0xf7,
-	0x3d, 0x13, 0x60, 0x1b, 0xa5, 0x18, 0xb7, 0xb9, 0x1b, 0x88, 0x5c, 0x29, 0x93, 0xd8, 0x1a, 0x43,
-	0x0b, 0xf2, 0xea, 0xf1, 0x82, 0x8b, 0xce, 0x51, 0x3f, 0x17, 0x0f, 0xa6, 0x7d, 0x3d, 0xea, 0x2b,
-	0xec, 0x90, 0x7b, 0xce, 0xe8, 0x9c, 0x24, 0xce, 0xb6, 0xf2, 0x5a, 0x72, 0x2a, 0x37, 0xfb, 0xc6,
-	0xd0, 0x9d, 0x7a, 0x59, 0x6c, 0x26, 0xc6, 0x8f, 0xa1, 0xa2, 0x8a, 0x46, 0xbe, 0xbe, 0x74, 0x27,
-	0x8f, 0x33, 0x52, 0x51, 0x16, 0x46, 0xe7, 0xa4, 0x11, 0xa3, 0x65, 0x0a, 0xc5, 0xfb, 0x6f, 0xf5,
-	0xfa, 0x80, 0xbb, 0x49, 0xa6, 0x88, 0xaa, 0x0d, 0xff, 0x2d, 0x45, 0x10, 0x41, 0x0b, 0xe4, 0x77,
-	0x98, 0x22, 0x48, 0x75, 0x3c, 0xe7, 0x2c, 0xf4, 0xfd, 0x49, 0x5c, 0x23, 0xb2, 0x8f, 0x2f, 0xb1,
-	0x9f, 0x9a, 0x8d, 0x58, 0x5c, 0x83, 0x46, 0x37, 0xe4, 0x52, 0x5e, 0x3f, 0xc5, 0x24, 0xaf, 0x02,
-	0xea, 0x0e, 0xd1, 0x5a, 0xb9, 0x12, 0xe0, 0xf6, 0xa9, 0xc9, 0x35, 0x50, 0x74, 0xb4, 0x92, 0xff,
-	0x24, 0x0b, 0xd7, 0x81, 0x7d, 0x0a, 0xb5, 0x13, 0xdf, 0xbe, 0x7c, 0x0b, 0x23, 0x6d, 0x68, 0x7c,
-	0xeb, 0xcd, 0xdf, 0x4e, 0xe6, 0x0b, 0x68, 0xa8, 0x3b, 0x8a, 0x91, 0x31, 0x41, 0xa7, 0x6f, 0x2e,
-	0xf9, 0x72, 0xbd, 0x9b, 0xb4, 0xdc, 0x1d, 0x5b, 0xf9, 0x85, 0xf9, 0x2b, 0xb8, 0x9f, 0x91, 0x7b,
-	0xa9, 0xaf, 0x24, 0xff, 0xab, 0xfc, 0x33, 0x68, 0xfc, 0x7e, 0xc9, 0xc3, 0xdb, 0xae, 0xef, 0x89,
-	0xd0, 0xb2, 0x93, 0x02, 0x8a, 0xdc, 0x37, 0x08, 0x75, 0x80, 0x64, 0x84, 0x54, 0xb6, 0xec, 0xa4,
-	0x33, 0x43, 0x89, 0x3f, 0xb8, 0xc3, 0x32, 0x8b, 0xfe, 0x14, 0xd3, 0x0c, 0x9b, 0x56, 0x92, 0x7e,
-	0x2c, 0xd3, 0x2d, 0x6c, 0x2b, 0xfd, 0x32, 0x14, 0x2f, 0xa0, 0x14, 0x79, 0x8d, 0xed, 0xfd, 0x4e,
-	0xaa, 0xe5, 0x5f, 0x91, 0x30, 0xb7, 0x04, 0x2c, 0xd4, 0xdb, 0x49, 0x96, 0x28, 0xc1, 0xd5, 0xd4,
-	0x54, 0x4f, 0x72, 0xb1, 0xa3, 0x2b, 0x97, 0x23, 0x75, 0x8c, 0xa9, 0xfc, 0xc6, 0x2b, 0xd0, 0x1b,
-	0xc4, 0x57, 0xae, 0x4c, 0xb4, 0x40, 0x3e, 0xc1, 0x04, 0x8d, 0x3b, 0xff, 0x74, 0xaf, 0x1f, 0x7b,
-	0x6a, 0x46, 0x71, 0xf9, 0xf1, 0x38, 0xc0, 0xd6, 0x4d, 0xd7, 0x74, 0x13, 0xe2, 0x73, 0x77, 0x2e,
-	0x54, 0x5f, 0xdc, 0xca, 0x74, 0x78, 0x58, 0xd0, 0x9f, 0xa9, 0x27, 0x2f, 0x64, 0x44, 0x79, 0x22,
-	0xcd, 0xb4, 0x88, 0x9e, 0x96, 0x2f, 0xa0, 0x21, 0x43, 0x4a, 0x3a, 0x79, 0x03, 0x8a, 0x9b, 0xff,
-	0xf8, 0xe0, 0x4c, 0x40, 0xb4, 0x40, 0xbe, 0xc4, 0xad, 0x9e, 0xed, 0x26, 0xf3, 0x4f, 0x9e, 0x0c,
-	0x86, 0x16, 0xc8, 0x09, 0xdc, 0x7b, 0xc1, 0xc5, 0x9d, 0x9e, 0xb0, 0x65, 0x84, 0xef, 0x76, 0x95,
-	0x71, 0x89, 0x5a, 0x1d, 0xa3, 0x05, 0x72, 0x0c, 0xf7, 0x95, 0x1f, 0x93, 0xee, 0xcc, 0xf2, 0xa6,
-	0xfc, 0x2c, 0xf4, 0xa7, 0xf8, 0xb0, 0x9a, 0x57, 0xaf, 0xde, 0x49, 0x75, 0xe4, 0x59, 0x38, 0x2d,
-	0x5c, 0x6c, 0xe0, 0x7f, 0xa6, 0x67, 0xff, 0x09, 0x00, 0x00, 0xff, 0xff, 0xc7, 0x7d, 0x4d, 0x37,
-	0xcd, 0x1a, 0x00, 0x00,
+	// 2823 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x19, 0x5d, 0x73, 0x23, 0x47,
+	0x51, 0x92, 0x25, 0x5b, 0x6a, 0x49, 0xb6, 0x3c, 0xf6, 0xf9, 0x14, 0x91, 0x5c, 0xcc, 0x70, 0x24,
+	0xce, 0x71, 0x31, 0x39, 0x5f, 0x12, 0x02, 0x84, 0x04, 0x59, 0xd1, 0x9d, 0x55, 0xe7, 0xb3, 0xcd,
+	0x48, 0x39, 0x9c, 0x17, 0xc4, 0x7a, 0x77, 0x24, 0x6d, 0x59, 0xda, 0xdd, 0xec, 0x8e, 0xfc, 0x91,
+	0x2a, 0x9e, 0x78, 0xa2, 0xf8, 0x03, 0xfc, 0x15, 0xfe, 0x06, 0xef, 0x14, 0xfc, 0x14, 0x6a, 0x7a,
+	0x66, 0xf6, 0x43, 0x96, 0x29, 0x8e, 0xb7, 0xed, 0x9e, 0xfe, 0x9e, 0x9e, 0x9e, 0xee, 0x59, 0xa8,
+	0x84, 0x81, 0xbd, 0x1f, 0x84, 0xbe, 0xf0, 0x49, 0x49, 0xdc, 0x06, 0x3c, 0x6a, 0x35, 0x2e, 0xa6,
+	0xbe, 0x7d, 0x69, 0x4f, 0x2c, 0xd7, 0x53, 0x0b, 0xad, 0xba, 0x65, 0xdb, 0xfe, 0xdc, 0x13, 0x1a,
+	0x04, 0xcf, 0x77, 0xb8, 0xfe, 0xae, 0x04, 0x07, 0x81, 0xfe, 0xac, 0xcd, 0xb8, 0x08, 0x5d, 0xdb,
+	0x10, 0x85, 0xd6, 0x48, 0x33, 0xd0, 0x7f, 0xe7, 0xa1, 0x71, 0x18, 0x0b, 0xed, 0x0b, 0x4b, 0xcc,
+	0x23, 0xf2, 0x01, 0x6c, 0x5c, 0xf0, 0x48, 0x0c, 0x51, 0xdb, 0x70, 0x62, 0x45, 0x93, 0x66, 0x7e,
+	0x37, 0xbf, 0x57, 0x63, 0x75, 0x89, 0x46, 0xf2, 0x23, 0x2b, 0x9a, 0x90, 0xf7, 0xa1, 0x8a, 0x74,
+	0x13, 0xee, 0x8e, 0x27, 0xa2, 0x59, 0xd8, 0xcd, 0xef, 0x15, 0x19, 0x48, 0xd4, 0x11, 0x62, 0xc8,
+	0x4f, 0x61, 0xdd, 0xf6, 0xbd, 0x88, 0x7b, 0xd1, 0x3c, 0x1a, 0xba, 0xde, 0xc8, 0x6f, 0xae, 0xec,
+	0xe6, 0xf7, 0x2a, 0xac, 0x1e, 0x63, 0x7b, 0xde, 0xc8, 0x27, 0x3f, 0x03, 0x82, 0x72, 0xd0, 0x86,
+	0xa1, 0xeb, 0x28, 0x95, 0x45, 0x54, 0x89, 0x96, 0x74, 0xe4, 0x42, 0xcf, 0x41, 0xa5, 0x3f, 0x07,
+	0xd0, 0x74, 0x52, 0x5e, 0x69, 0x37, 0xbf, 0x57, 0x3d, 0x68, 0xec, 0x63, 0x7c, 0xf6, 0x15, 0x9d,
+	0x37, 0xf2, 0x59, 0xc5, 0x36, 0x9f, 0xf4, 0x2f, 0x79, 0x58, 0xd3, 0x02, 0xc8, 0x36, 0x94, 0x66,
+	0xd6, 0xd8, 0xb5, 0xd1, 0x9f, 0x0a, 0x53, 0x00, 0xd9, 0x81, 0xd5, 0x60, 0x7e, 0x31, 0x75, 0x6d,
+	0x74, 0xa1, 0xcc, 0x34, 0x44, 0x9a, 0xb0, 0x36, 0xb3, 0x5c, 0xcf, 0xe3, 0x02, 0xed, 0x2e, 0x33,
+	0x03, 0x92, 0x77, 0xa1, 0x12, 0xbb, 0x80, 0x86, 0x56, 0x58, 0x82, 0x90, 0x7c, 0x57, 0x3c, 0x8c,
+	0x5c, 0xdf, 0x43, 0xfb, 0x4a, 0xcc, 0x80, 0xf4, 0x5f, 0x05, 0xa8, 0xc4, 0x46, 0x92, 0x47, 0x50,
+	0x70, 0x1d, 0x34, 0xa5, 0x7a, 0xb0, 0x9e, 0x71, 0xc1, 0x61, 0x05, 0xd7, 0x21, 0x2d, 0x28, 0x5f,
+	0x04, 0x27, 0xf3, 0xd9, 0x05, 0x0f, 0xd1, 0xb2, 0x3a, 0x8b, 0x61, 0x42, 0xa1, 0x36, 0xb3, 0x6e,
+	0x70, 0x87, 0x22, 0xf7, 0x07, 0x8e, 0x06, 0x16, 0x59, 0x06, 0x27, 0xad, 0x9c, 0x59, 0x37, 0xc2,
+	0xbf, 0xe4, 0x5e, 0xa4, 0xc3, 0x99, 0x20, 0xc8, 0x07, 0xb0, 0x1e, 0x09, 0xeb, 0xd2, 0xf5, 0xc6,
+	0x33, 0xd7, 0x73, 0x67, 0xf3, 0x19, 0x1a, 0x5b, 0x63, 0x0b, 0x58, 0xa9, 0x49, 0xf8, 0xc2, 0x9a,
+	0x6a, 0x74, 0x73, 0x15, 0xa9, 0x32, 0x38, 0x69, 0xe9, 0xd8, 0x8a, 0x82, 0xd0, 0xb5, 0x79, 0x73,
+	0x0d, 0xd7, 0x63, 0x58, 0x5a, 0xe1, 0x59, 0x33, 0xae, 0x16, 0xcb, 0xca, 0x8a, 0x18, 0x41, 0x9e,
+	0x40, 0x03, 0x25, 0x5d, 0xf9, 0xc2, 0xf5, 0xc6, 0x81, 0x7f, 0xcd, 0xc3, 0x66, 0x05, 0x89, 0xee,
+	0xe0, 0xa5, 0x25, 0x0a, 0x0c, 0xf9, 0xb5, 0x15, 0x3a, 0x4d, 0x50, 0x96, 0xa4, 0x71, 0xf4, 0x31,
+	0x40, 0xc7, 0xa4, 0x72, 0x24, 0x77, 0x36, 0xe4, 0x81, 0x1f, 0x0a, 0xbd, 0xe1, 0x1a, 0xa2, 0x36,
+	0x94, 0x7a, 0x5e, 0x30, 0x17, 0x84, 0x40, 0x31, 0x95, 0xdf, 0xf8, 0x2d, 0xb7, 0xcf, 0x72, 0x9c,
+	0x90, 0x47, 0x51, 0xb3, 0xb0, 0xbb, 0xb2, 0x57, 0x63, 0x06, 0x94, 0xe9, 0x73, 0x65, 0x4d, 0xe7,
+	0x2a, 0xda, 0x35, 0xa6, 0x00, 0xa9, 0x24, 0xb2, 0x43, 0x37, 0x10, 0x3a, 0xc6, 0x1a, 0xa2, 0x23,
+	0x58, 0x3d, 0x9d, 0x0b, 0xa9, 0x65, 0x1b, 0x4a, 0xae, 0xe7, 0xf0, 0x1b, 0x54, 0x53, 0x67, 0x0a,
+	0xc8, 0xea, 0xc9, 0xff, 0xff, 0x7a, 0xd6, 0xa0, 0xd4, 0x9d, 0x05, 0xe2, 0x96, 0xfe, 0x04, 0xaa,
+	0x7d, 0xd7, 0x1b, 0x4f, 0xf9, 0xe1, 0xad, 0xe0, 0x29, 0x29, 0xf9, 0x94, 0x14, 0xfa, 0x18, 0x6a,
+	0x8a, 0xa8, 0x2f, 0x42, 0xb9, 0x75, 0x19, 0xaa, 0x8a, 0xa1, 0xfa, 0x00, 0xd6, 0xdb, 0xaa, 0xb2,
+	0xb4, 0x17, 0x6d, 0xca, 0x48, 0xfb, 0x43, 0x42, 0xe7, 0x39, 0xcc, 0xf7, 0x85, 0xf4, 0x4a, 0x63,
+	0x34, 0xa5, 0x01, 0x65, 0xac, 0x25, 0x85, 0x76, 0x16, 0xbf, 0xc9, 0x23, 0x80, 0x8e, 0x3f, 0x0b,
+	0xa4, 0x06, 0xee, 0xe8, 0x53, 0x96, 0xc2, 0xd0, 0x7f, 0x16, 0xa0, 0x78, 0xc6, 0x79, 0x48, 0x9e,
+	0x26, 0xc1, 0x52, 0x07, 0x86, 0xe8, 0x03, 0x23, 0x57, 0xb5, 0x8d, 0x49, 0x00, 0x9f, 0x43, 0x45,
+	0xd6, 0x0d, 0x3c, 0x0a, 0xa8, 0xaf, 0x7a, 0xf0, 0x40, 0xd3, 0x9f, 0xf0, 0x6b, 0xac, 0x60, 0x27,
+	0xbe, 0x70, 0x6d, 0xce, 0x12, 0x3a, 0xe9, 0x61, 0x24, 0x2c, 0xa1, 0xa2, 0x5e, 0x62, 0x0a, 0x90,
+	0x51, 0x9f, 0xb8, 0x8e, 0xc3, 0x3d, 0x8c, 0x7a, 0x99, 0x69, 0x48, 0xa6, 0xf5, 0xd4, 0x8a, 0x26,
+	0x9d, 0x09, 0xb7, 0x2f, 0xf1, 0xe4, 0xac, 0xb0, 0x04, 0x21, 0x0f, 0x44, 0xc4, 0xa7, 0xa3, 0x80,
+	0xf3, 0x10, 0x0f, 0x4c, 0x99, 0xc5, 0x70, 0xba, 0x3c, 0xac, 0x61, 0xcc, 0x0d, 0x48, 0x7e, 0x0d,
+	0x35, 0x9b, 0x87, 0xc2, 0x1d, 0xb9, 0xb6, 0x25, 0x78, 0xd4, 0x2c, 0xef, 0xae, 0xec, 0x55, 0x0f,
+	0x1e, 0x6a, 0xcb, 0xdb, 0x63, 0xee, 0x89, 0x4e, 0xb2, 0xce, 0x32, 0xc4, 0xe4, 0x39, 0xd4, 0x2c,
+	0xdb, 0xe6, 0x81, 0xe0, 0x0e, 0xf3, 0xa7, 0x1c, 0x4f, 0xd1, 0xfa, 0xc1, 0x46, 0x2a, 0x4c, 0x12,
+	0xcd, 0x32, 0x44, 0xf4, 0x63, 0x28, 0xcb, 0x95, 0x63, 0x37, 0x12, 0xe4, 0xc7, 0x50, 0x92, 0xf6,
+	0xc9, 0x00, 0x4b, 0xb5, 0xd5, 0x34, 0xa7, 0x5a, 0xa1, 0x57, 0x00, 0x92, 0xf4, 0xcc, 0x0a, 0xad,
+	0x59, 0xb4, 0xf4, 0xf0, 0xc8, 0x70, 0xa5, 0xaf, 0x03, 0x0d, 0x49, 0xda, 0xb8, 0x4e, 0xd5, 0x19,
+	0x7e, 0x4b, 0x5a, 0x7f, 0x34, 0x8a, 0xb8, 0x4a, 0xe8, 0x3a, 0xd3, 0x10, 0x69, 0xc0, 0x8a, 0x15,
+	0xd9, 0x18, 0xd4, 0x32, 0x93, 0x9f, 0xf4, 0x0b, 0x80, 0x33, 0x6b, 0xcc, 0xb5, 0xde, 0x84, 0x2f,
+	0x9f, 0xe1, 0x33, 0x3a, 0x0a, 0x89, 0x0e, 0x7a, 0x03, 0xeb, 0xb8, 0xdd, 0x87, 0xbe, 0x73, 0x2b,
+	0x45, 0xe0, 0x1d, 0x80, 0x95, 0xc5, 0x1c, 0x46, 0x04, 0x52, 0x32, 0x0b, 0x4b, 0x65, 0xa6, 0xed,
+	0x7e, 0x0c, 0xc5, 0x0b, 0xdf, 0xb9, 0x6d, 0x16, 0x33, 0x97, 0x4f, 0xac, 0x86, 0xe1, 0x2a, 0xfd,
+	0x23, 0x6c, 0xa4, 0x34, 0xa3, 0xe1, 0x14, 0x6a, 0x32, 0x48, 0x7e, 0xe8, 0xa9, 0xa2, 0xae, 0x02,
+	0x97, 0xc1, 0x91, 0x8f, 0x60, 0x35, 0xb0, 0xc6, 0xb2, 0xd0, 0xaa, 0xbc, 0xdd, 0x34, 0xdb, 0x10,
+	0xfb, 0xcf, 0x34, 0x01, 0xfd, 0x85, 0xd6, 0x70, 0xc4, 0x2d, 0x47, 0xef, 0xe1, 0x63, 0x58, 0x55,
+	0xf5, 0x5f, 0x6f, 0x62, 0x2d, 0x6d, 0x1c, 0xd3, 0x6b, 0xf4, 0x4f, 0x50, 0x47, 0xc4, 0x6b, 0x2e,
+	0x2c, 0xc7, 0x12, 0xd6, 0xd2, 0x9d, 0x7c, 0x22, 0x77, 0x52, 0x0a, 0x6e, 0x16, 0x32, 0x07, 0x2e,
+	0xa5, 0x92, 0x69, 0x0a, 0x99, 0xd2, 0xe2, 0x46, 0x1d, 0x7a, 0x75, 0x78, 0x0c, 0x18, 0xc7, 0xaf,
+	0x88, 0x27, 0x44, 0xed, 0x49, 0x1b, 0x36, 0x33, 0xea, 0xd1, 0xf2, 0xa7, 0x0b, 0x96, 0x6f, 0xa7,
+	0xd5, 0x19, 0xca, 0xd8, 0x03, 0x0e, 0xb5, 0x8e, 0x3f, 0x9b, 0xb9, 0x82, 0xf1, 0x68, 0x3e, 0x5d,
+	0x5e, 0xc7, 0x3f, 0x82, 0x12, 0x0f, 0x43, 0x5f, 0xd9, 0xbf, 0x7e, 0xb0, 0x65, 0x6e, 0x58, 0xe4,
+	0x53, 0xad, 0x0e, 0x53, 0x14, 0x72, 0xf7, 0x1d, 0x2e, 0x2c, 0x77, 0xaa, 0x1b, 0x14, 0x0d, 0xd1,
+	0x36, 0x34, 0xd2, 0x6a, 0xd0, 0xd0, 0x8f, 0x61, 0x2d, 0x44, 0xc8, 0x58, 0x9a, 0x15, 0xac, 0x28,
+	0x99, 0xa1, 0xa1, 0x03, 0xa8, 0xbd, 0xe1, 0xa1, 0x3b, 0xba, 0xd5, 0x96, 0xbe, 0x03, 0x05, 0x71,
+	0xa3, 0x6b, 0x58, 0x45, 0x73, 0x0e, 0x6e, 0x58, 0x41, 0xdc, 0xdc, 0x67, 0xb0, 0x62, 0xcf, 0x18,
+	0x4c, 0x07, 0xf2, 0xdc, 0x86, 0x91, 0xef, 0x59, 0x53, 0x59, 0x43, 0x03, 0x2b, 0x8a, 0x82, 0x49,
+	0x68, 0x45, 0xa6, 0x8c, 0xa7, 0x30, 0x64, 0x0f, 0xd6, 0x74, 0x97, 0xd8, 0x2c, 0x64, 0x7a, 0x0d,
+	0x5d, 0x98, 0x99, 0x59, 0xa6, 0x7f, 0xcb, 0x43, 0xad, 0x37, 0x93, 0x37, 0xe4, 0x0b, 0x3f, 0x9c,
+	0x59, 0x32, 0x9d, 0x56, 0xae, 0xdd, 0xd1, 0x42, 0xc5, 0x4d, 0xdd, 0x31, 0x4c, 0x2e, 0xcb, 0xdd,
+	0xf7, 0xa7, 0x8e, 0xd4, 0x88, 0x0a, 0x2a, 0xcc, 0x80, 0x72, 0xc5, 0xe3, 0xd7, 0xb8, 0xa2, 0x02,
+	0x6b, 0x40, 0xb2, 0x0f, 0xe5, 0x4b, 0x7e, 0x1b, 0x09, 0x3f, 0xe4, 0xcd, 0xe2, 0xbd, 0xe2, 0x63,
+	0x1a, 0xfa, 0x19, 0xac, 0xf5, 0x75, 0xb3, 0xb1, 0x03, 0xab, 0xd6, 0x2c, 0x75, 0xc1, 0x68, 0x48,
+	0xe6, 0xc0, 0xf5, 0x84, 0x7b, 0xba, 0xf0, 0xe0, 0x37, 0xfd, 0x12, 0x8a, 0x6f, 0x7c, 0x81, 0x4d,
+	0x88, 0x6d, 0x79, 0x8e, 0xeb, 0xc8, 0xfa, 0xae, 0xd8, 0x12, 0x44, 0x4a, 0x62, 0x21, 0x2d, 0x91,
+	0x1e, 0x00, 0x48, 0x6e, 0x7d, 0x7a, 0xd7, 0xe3, 0x76, 0xad, 0x82, 0xed, 0xd9, 0x36, 0x94, 0x92,
+	0xa8, 0xd6, 0x99, 0x02, 0xa8, 0x03, 0x1b, 0x3a, 0xae, 0x92, 0x15, 0xfb, 0xbc, 0x3d, 0x58, 0x33,
+	0xcd, 0x53, 0xb6, 0xd9, 0xd3, 0x1e, 0x31, 0xb3, 0x4c, 0x3e, 0x84, 0x55, 0xd5, 0xcd, 0x60, 0xe7,
+	0x51, 0x8d, 0xab, 0xb7, 0x11, 0xc5, 0xf4, 0x32, 0x65, 0x50, 0x8e, 0xc5, 0x2f, 0xda, 0xf5, 0x08,
+	0x20, 0x76, 0x4d, 0xb5, 0x30, 0x15, 0x96, 0xc2, 0xa4, 0xbc, 0xd5, 0xc9, 0xae, 0xbd, 0xfd, 0x8d,
+	0x92, 0x69, 0xee, 0x82, 0x2b, 0x5f, 0x70, 0x93, 0xe2, 0xd5, 0x94, 0x1d, 0x4c, 0xad, 0x68, 0xb5,
+	0x05, 0xa3, 0x96, 0xb6, 0x61, 0xed, 0xc4, 0x77, 0x38, 0xe3, 0xdf, 0x63, 0x39, 0x70, 0x67, 0xdc,
+	0x9f, 0xc7, 0x3d, 0x80, 0x06, 0x55, 0xe3, 0x3c, 0x0b, 0x7c, 0x8f, 0xc7, 0xc1, 0x4e, 0x10, 0xf4,
+	0x53, 0x28, 0x9e, 0x58, 0x33, 0x2e, 0x77, 0x52, 0x76, 0x88, 0xda, 0x27, 0xfc, 0x96, 0x32, 0x2f,
+	0xd4, 0xbd, 0xad, 0x37, 0xd8, 0x80, 0xd4, 0x86, 0xb2, 0xe4, 0xc2, 0x58, 0xbc, 0x9f, 0xe2, 0x4c,
+	0xcc, 0x96, 0xcb, 0x5a, 0xcc, 0x36, 0x94, 0xfc, 0x6b, 0x4f, 0x17, 0xb5, 0x1a, 0x53, 0x00, 0xd9,
+	0x85, 0xaa, 0xc3, 0x23, 0xe1, 0x7a, 0x96, 0x90, 0xd7, 0xb2, 0x6a, 0xbb, 0xd2, 0x28, 0xda, 0x85,
+	0xaa, 0xbc, 0x08, 0x23, 0x9d, 0x0b, 0x2d, 0x28, 0x7b, 0xfe, 0x91, 0xea, 0x0b, 0xf2, 0xea, 0x7e,
+	0x37, 0xb0, 0x5c, 0x8b, 0x26, 0xfe, 0x75, 0x9f, 0x4f, 0x47, 0x7a, 0xa0, 0x88, 0x61, 0xfa, 0x1e,
+	0x54, 0x5e, 0x71, 0x73, 0x1d, 0x34, 0x60, 0xe5, 0x92, 0xdf, 0x62, 0x88, 0x2b, 0x4c, 0x7e, 0xd2,
+	0x3f, 0x17, 0x00, 0xfa, 0x3c, 0xbc, 0xe2, 0x21, 0x7a, 0xf3, 0x19, 0xac, 0x46, 0x78, 0xec, 0xf5,
+	0x36, 0xbc, 0x67, 0xf2, 0x26, 0x26, 0xd9, 0x57, 0x65, 0xa1, 0xeb, 0x89, 0xf0, 0x96, 0x69, 0x62,
+	0xc9, 0x66, 0xfb, 0xde, 0xc8, 0x35, 0x59, 0xb4, 0x84, 0xad, 0x83, 0xeb, 0x9a, 0x4d, 0x11, 0xb7,
+	0x7e, 0x09, 0xd5, 0x94, 0xb4, 0xc4, 0xba, 0xbc, 0xb6, 0x2e, 0x69, 0x01, 0x0b, 0xa9, 0x56, 0xf1,
+	0x57, 0x85, 0x2f, 0xf2, 0xad, 0x63, 0xa8, 0xa6, 0x24, 0x2e, 0x61, 0xfd, 0x30, 0xcd, 0x9a, 0x5c,
+	0x6a, 0x8a, 0xa9, 0x27, 0xf8, 0x2c, 0x25, 0x8d, 0xfe, 0x00, 0x90, 0x2c, 0x90, 0x03, 0x28, 0x05,
+	0xa1, 0x1f, 0x44, 0xda, 0x99, 0x77, 0xef, 0xb0, 0xee, 0x9f, 0xc9, 0x65, 0xe5, 0x8b, 0x22, 0x6d,
+	0xc9, 0x7e, 0x21, 0x46, 0xbe, 0x8d, 0x27, 0xf4, 0x19, 0x54, 0xba, 0x57, 0xdc, 0x13, 0xe6, 0x36,
+	0xe5, 0x12, 0x58, 0xbc, 0x4d, 0x91, 0x82, 0xe9, 0x35, 0xda, 0x83, 0x7a, 0x27, 0x33, 0xcf, 0x12,
+	0x28, 0x4a, 0x3a, 0x93, 0xbe, 0xf2, 0x5b, 0xe2, 0x70, 0x60, 0x55, 0x0a, 0xf1, 0x5b, 0xda, 0x75,
+	0x11, 0xc8, 0xca, 0x88, 0xfb, 0x7f, 0x11, 0x44, 0xf4, 0x43, 0xd8, 0xea, 0x7a, 0x82, 0x87, 0x41,
+	0xe8, 0x46, 0x5c, 0x79, 0xf8, 0x8a, 0x2f, 0x71, 0x80, 0x1e, 0x43, 0x63, 0x91, 0x70, 0x89, 0x9b,
+	0xeb, 0x50, 0xf0, 0x3d, 0x9d, 0x83, 0x05, 0xdf, 0x93, 0x27, 0x1f, 0x3d, 0x35, 0x3a, 0x35, 0x94,
+	0xee, 0xe2, 0xd5, 0x78, 0xbf, 0xbc, 0xdb, 0xbf, 0xff, 0x0c, 0xca, 0x73, 0x7d, 0x61, 0xde, 0x05,
+	0xf4, 0xf1, 0x49, 0x10, 0xf4, 0xaf, 0x79, 0x58, 0x1b, 0xb8, 0x81, 0xcc, 0x2e, 0xd9, 0x03, 0xe1,
+	0xc2, 0x1b, 0xdd, 0x02, 0xe7, 0xb1, 0x5f, 0xc8, 0xe0, 0xf4, 0x38, 0x79, 0x86, 0x13, 0x63, 0x21,
+	0x1e, 0x27, 0x11, 0x4e, 0xaa, 0xae, 0x9a, 0x78, 0x15, 0x20, 0x3d, 0x13, 0x6e, 0x10, 0x70, 0x07,
+	0x2f, 0x93, 0x22, 0xd3, 0x10, 0x6e, 0x85, 0x1b, 0x44, 0xcd, 0x12, 0x0e, 0x72, 0xf8, 0x4d, 0x6f,
+	0x61, 0xa3, 0xe3, 0x7b, 0x22, 0xb4, 0x6c, 0x61, 0x14, 0xa6, 0x5a, 0xf2, 0xbc, 0x72, 0xec, 0x2a,
+	0x31, 0xc5, 0xf6, 0x1d, 0x8e, 0x7e, 0x69, 0x53, 0x0c, 0x9c, 0x0e, 0xc7, 0x4a, 0x36, 0x1c, 0xd2,
+	0x9c, 0x9b, 0xa3, 0xe4, 0x15, 0x43, 0x43, 0xb4, 0x07, 0x5b, 0x0b, 0xaa, 0x31, 0xcf, 0x0e, 0xa0,
+	0xac, 0xf5, 0x99, 0x4c, 0xdb, 0x49, 0xb2, 0x3c, 0x4d, 0xcd, 0x62, 0xba, 0x27, 0xff, 0xc8, 0x9b,
+	0x16, 0x48, 0xbf, 0xda, 0x54, 0xa0, 0x34, 0x38, 0x1f, 0x9e, 0xbe, 0x6a, 0xe4, 0xc8, 0x36, 0x34,
+	0x06, 0xe7, 0xc3, 0x93, 0xd3, 0x93, 0x4e, 0x77, 0x38, 0x38, 0x3d, 0x1d, 0x1e, 0x9f, 0xfe, 0xbe,
+	0x91, 0x27, 0x0f, 0x60, 0x73, 0x70, 0x3e, 0x6c, 0x1f, 0xb3, 0x6e, 0xfb, 0x9b, 0xef, 0x86, 0xdd,
+	0xf3, 0x5e, 0x7f, 0xd0, 0x6f, 0x14, 0xc8, 0x16, 0x6c, 0x0c, 0xce, 0x87, 0xbd, 0x93, 0x37, 0xed,
+	0xe3, 0xde, 0x37, 0xc3, 0xa3, 0x76, 0xff, 0xa8, 0xb1, 0xb2, 0x80, 0xec, 0xf7, 0x5e, 0x9e, 0x34,
+	0x8a, 0x5a, 0x80, 0x41, 0xbe, 0x38, 0x65, 0xaf, 0xdb, 0x83, 0x46, 0x89, 0xfc, 0x08, 0x1e, 0x22,
+	0xba, 0xff, 0xed, 0x8b, 0x17, 0xbd, 0x4e, 0xaf, 0x7b, 0x32, 0x18, 0x1e, 0xb6, 0x8f, 0xdb, 0x27,
+	0x9d, 0x6e, 0x63, 0x55, 0xf3, 0x1c, 0xb5, 0xfb, 0xc3, 0x7e, 0xfb, 0x75, 0x57, 0xd9, 0xd4, 0x58,
+	0x8b, 0x45, 0x0d, 0xba, 0xec, 0xa4, 0x7d, 0x3c, 0xec, 0x32, 0x76, 0xca, 0x1a, 0x95, 0x27, 0x23,
+	0xd3, 0x2c, 0x69, 0x9f, 0xb6, 0xa1, 0xf1, 0xa6, 0xcb, 0x7a, 0x2f, 0xbe, 0x1b, 0xf6, 0x07, 0xed,
+	0xc1, 0xb7, 0x7d, 0xe5, 0xde, 0x2e, 0xbc, 0x9b, 0xc5, 0x4a, 0xfb, 0x86, 0x27, 0xa7, 0x83, 0xe1,
+	0xeb, 0xf6, 0xa0, 0x73, 0xd4, 0xc8, 0x93, 0x47, 0xd0, 0xca, 0x52, 0x64, 0xdc, 0x2b, 0x1c, 0xfc,
+	0x7d, 0x1b, 0x36, 0xda, 0x3c, 0x1c, 0xfb, 0xec, 0xac, 0x23, 0xab, 0xa2, 0x4c, 0xac, 0x67, 0x50,
+	0x91, 0xf7, 0x57, 0x1f, 0xa7, 0x3e, 0x73, 0x43, 0xeb, 0x1b, 0xad, 0xb5, 0xa4, 0x39, 0xa1, 0x39,
+	0xf2, 0x0c, 0x56, 0x5f, 0xe3, 0xcb, 0x1a, 0x31, 0xd3, 0xa5, 0x02, 0x23, 0xc6, 0xbf, 0x9f, 0xf3,
+	0x48, 0xb4, 0xd6, 0xb3, 0x68, 0x9a, 0x23, 0x9f, 0x01, 0x24, 0xef, 0x6d, 0x24, 0x2e, 0x28, 0x72,
+	0x7e, 0x6f, 0x3d, 0x4c, 0xb7, 0xbc, 0xa9, 0x07, 0x39, 0x9a, 0x23, 0x9f, 0x40, 0xed, 0x25, 0x17,
+	0xc9, 0xd3, 0x51, 0x96, 0xf1, 0xce, 0xfb, 0x17, 0xcd, 0x91, 0x7d, 0xfd, 0xd2, 0x84, 0x87, 0x2e,
+	0x4b, 0xbe, 0x99, 0x26, 0x97, 0xeb, 0x52, 0xc3, 0xd7, 0xd0, 0x90, 0xb9, 0x98, 0xea, 0xee, 0x23,
+	0x62, 0x08, 0x93, 0x99, 0xaf, 0xb5, 0x73, 0x77, 0x0a, 0x90, 0xab, 0x34, 0x47, 0x0e, 0x61, 0x33,
+	0x16, 0x10, 0x0f, 0x16, 0x4b, 0x24, 0x34, 0x97, 0x35, 0xf6, 0x5a, 0xc6, 0x33, 0xd8, 0x88, 0x65,
+	0xf4, 0x45, 0xc8, 0xad, 0xd9, 0x82, 0xe9, 0x99, 0x79, 0x86, 0xe6, 0x3e, 0xc9, 0x93, 0x36, 0x3c,
+	0xbc, 0xa3, 0x76, 0x29, 0xeb, 0xd2, 0x81, 0x02, 0x45, 0xec, 0x43, 0xf9, 0x25, 0xd7, 0x85, 0x6f,
+	0xc9, 0x46, 0x2f, 0x2a, 0x25, 0x5f, 0x41, 0xc3, 0xd0, 0x27, 0x13, 0xd4, 0x12, 0xbe, 0x7b, 0x34,
+	0x92, 0xaf, 0x71, 0x33, 0xe3, 0xe1, 0x90, 0xec, 0x2c, 0x4e, 0x90, 0x3a, 0x52, 0x0f, 0xee, 0xe2,
+	0xc7, 0xdc, 0xa1, 0x39, 0xb2, 0x07, 0xa5, 0x97, 0x5c, 0x0c, 0xce, 0x97, 0x6a, 0x4d, 0x86, 0x0a,
+	0x9a, 0x23, 0x9f, 0x02, 0x18, 0x55, 0xf7, 0x90, 0x37, 0x62, 0xf2, 0x9e, 0x67, 0x1c, 0x3c, 0x40,
+	0x2e, 0xc6, 0x6d, 0xee, 0x06, 0x62, 0x29, 0x97, 0x49, 0x6c, 0x4d, 0x43, 0x73, 0xe4, 0x63, 0x58,
+	0x7d, 0xc9, 0x45, 0xfb, 0xb0, 0x17, 0x9f, 0x85, 0xec, 0x95, 0xd2, 0x02, 0x83, 0x3e, 0xec, 0xd1,
+	0x9c, 0x9c, 0x2e, 0xfb, 0xdc, 0x73, 0x06, 0xe7, 0x24, 0xb1, 0xb7, 0xb5, 0x6c, 0x92, 0xa2, 0xf2,
+	0xbc, 0xaf, 0xf6, 0xdd, 0xb1, 0x97, 0xa5, 0xcd, 0xb8, 0xf9, 0x14, 0xca, 0xaa, 0x6e, 0x2c, 0x97,
+	0x97, 0x1e, 0xc0, 0x30, 0x28, 0x65, 0xa5, 0x61, 0x70, 0x4e, 0xea, 0x31, 0xb5, 0xcc, 0xa2, 0xf8,
+	0x08, 0x2e, 0x4e, 0x7d, 0x98, 0x9b, 0x32, 0x4b, 0x54, 0x79, 0xb8, 0xc7, 0xc5, 0x5a, 0xd2, 0xd7,
+	0x0b, 0x4e, 0x73, 0xe4, 0xb7, 0x98, 0x28, 0x08, 0xb5, 0x3d, 0xe7, 0x2c, 0xf4, 0xfd, 0xd1, 0x1d,
+	0x56, 0xf5, 0x6c, 0xd6, 0xda, 0xca, 0xa2, 0x91, 0x16, 0x77, 0xa2, 0xde, 0x09, 0xb9, 0xe4, 0x57,
+	0x78, 0x92, 0xbc, 0xe7, 0xa8, 0xe9, 0xaf, 0xb5, 0x30, 0xcc, 0xa1, 0xa1, 0x55, 0xb9, 0x13, 0x0a,
+	0x8e, 0x16, 0x4e, 0x01, 0xc9, 0x92, 0x6b, 0xdf, 0x3e, 0x81, 0xea, 0xb1, 0x6f, 0x5f, 0xbe, 0x85,
+	0x92, 0x03, 0xa8, 0x7f, 0xeb, 0x4d, 0xdf, 0x8e, 0xe7, 0x73, 0xa8, 0xab, 0xe9, 0xd2, 0xf0, 0x18,
+	0xa7, 0xd3, 0x33, 0xe7, 0x72, 0xbe, 0xee, 0x4d, 0x9a, 0xef, 0x8e, 0xae, 0xe5, 0xe5, 0xf9, 0x2b,
+	0x78, 0x90, 0xe1, 0x7b, 0xa5, 0x87, 0xc9, 0xff, 0x95, 0xff, 0x39, 0xd4, 0x7f, 0x37, 0xe7, 0xe1,
+	0xad, 0xb9, 0x84, 0xe3, 0x50, 0x22, 0xf6, 0x1e, 0xa6, 0x36, 0x90, 0x0c, 0x93, 0x4a, 0x98, 0xcd,
+	0x74, 0x66, 0x28, 0xf6, 0x9d, 0x3b, 0x28, 0xb3, 0xe9, 0x2a, 0xd3, 0x70, 0xdc, 0x20, 0xe9, 0x67,
+	0x4e, 0x3d, 0x7c, 0xb4, 0xd2, 0x6f, 0x7a, 0xf1, 0x06, 0x4a, 0x96, 0x37, 0x38, 0x98, 0x6d, 0xa6,
+	0x86, 0xb5, 0x05, 0x0e, 0x33, 0xdf, 0x61, 0xb9, 0xde, 0x48, 0xb2, 0x44, 0x31, 0x2e, 0xa6, 0xa6,
+	0x7a, 0x4c, 0x6d, 0xed, 0x64, 0xd1, 0x66, 0xee, 0x54, 0x97, 0x99, 0xca, 0x6f, 0x1c, 0x5e, 0xef,
+	0x61, 0x5f, 0x18, 0x76, 0xb1, 0x54, 0xc8, 0x04, 0x8d, 0x67, 0xb6, 0xf4, 0x94, 0xd6, 0xda, 0x48,
+	0x01, 0x5a, 0xcb, 0xe7, 0xea, 0x52, 0xc0, 0xa6, 0x5b, 0x57, 0x76, 0xe3, 0xe2, 0x0b, 0x77, 0x2a,
+	0xd4, 0x44, 0xd3, 0xca, 0xf4, 0xe6, 0x58, 0xd6, 0x9f, 0xab, 0xc7, 0x4a, 0x44, 0x44, 0xcb, 0x58,
+	0x1a, 0x69, 0x16, 0x1d, 0x96, 0xcf, 0xa1, 0x2e, 0x5d, 0x4a, 0x66, 0x30, 0x43, 0x14, 0x8f, 0x6d,
+	0xf1, 0xf5, 0x99, 0x10, 0xd1, 0x1c, 0xf9, 0x02, 0x8f, 0x7a, 0x76, 0x0e, 0x58, 0x7e, 0xff, 0x64,
+	0x68, 0x68, 0x8e, 0x1c, 0xc3, 0xd6, 0x4b, 0x2e, 0xee, 0x74, 0xf3, 0x2d, 0xc3, 0x7c, 0x77, 0x1e,
+	0x68, 0x3d, 0xbc, 0x67, 0x8d, 0xe6, 0xc8, 0x11, 0x3c, 0x50, 0x76, 0x8c, 0x3a, 0x13, 0xcb, 0x1b,
+	0xf3, 0xb3, 0xd0, 0x1f, 0xe3, 0x93, 0xf8, 0xb2, 0x2a, 0xfe, 0x4e, 0x6a, 0x96, 0xca, 0x92, 0xd3,
+	0x1c, 0xf9, 0x08, 0xa0, 0xef, 0xce, 0xe6, 0x53, 0x4b, 0xf0, 0xc1, 0x4d, 0xba, 0xaa, 0xde, 0xad,
+	0xfd, 0x4f, 0x31, 0x0f, 0x4c, 0x87, 0x9f, 0x75, 0xdb, 0x50, 0xeb, 0xd5, 0xf8, 0x76, 0x19, 0xdc,
+	0xa8, 0x7a, 0xf8, 0xdf, 0x6e, 0x17, 0x4d, 0x43, 0x73, 0xe4, 0x4b, 0xcc, 0x56, 0xad, 0xf1, 0x7e,
+	0xc6, 0xad, 0xac, 0x69, 0x86, 0xfb, 0x15, 0x6c, 0xcb, 0xed, 0x5d, 0x68, 0xa6, 0xa3, 0xfb, 0xca,
+	0x78, 0x6b, 0x79, 0xf3, 0xad, 0x32, 0xe4, 0x62, 0x15, 0xff, 0x9c, 0x3e, 0xff, 0xcf, 0x00, 0xab,
+	0xdc, 0xd2, 0x8d, 0x9f, 0x1d, 0x00, 0x00,
 }
@@ -0,0 +1,69 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package types
+
+// A tx can be time-locked by ValidAfter and ValidBefore since the hardfork
+// version 4. It can be included only in a block whose height or timestamp is
+// at or after ValidAfter and before ValidBefore. Zero means no bound. A bound
+// less than TimeLockThreshold is a block number, otherwise it is a unix time
+// in nanoseconds like the timestamp of a block.
+
+// TimeLockThreshold is the least bound of a time lock regarded as a time.
+const TimeLockThreshold uint64 = 1e15
+
+// HasTimeLock returns true if either bound of the time lock is set.
+func (b *TxBody) HasTimeLock() bool {
+	return b.GetValidAfter() != 0 || b.GetValidBefore() != 0
+}
+
+// reached returns true if the block of blockNo and ts is at or after bound.
+func reached(bound uint64, blockNo BlockNo, ts int64) bool {
+	if bound < TimeLockThreshold {
+		return blockNo >= bound
+	}
+	return ts >= 0 && uint64(ts) >= bound
+}
+
+// CheckTimeLock returns ErrTxNotYetValid or ErrTxExpired unless the tx can be
+// included in the block of blockNo and ts.
+func (b *TxBody) CheckTimeLock(blockNo BlockNo, ts int64) error {
+	if after := b.GetValidAfter(); after != 0 && !reached(after, blockNo, ts) {
+		return ErrTxNotYetValid
+	}
+	if b.IsExpired(blockNo, ts) {
+		return ErrTxExpired
+	}
+	return nil
+}
+
+// IsExpired returns true if the tx can't be included in the block of blockNo
+// and ts or any later block.
+func (b *TxBody) IsExpired(blockNo BlockNo, ts int64) bool {
+	before := b.GetValidBefore()
+	return before != 0 && reached(before, blockNo, ts)
+}
+
+// validateTimeLock checks that both bounds are of the same kind and the range
+// is not empty.
+func validateTimeLock(b *TxBody) error {
+	after, before := b.GetValidAfter(), b.GetValidBefore()
+	if after == 0 || before == 0 {
+		return nil
+	}
+	if (after < TimeLockThreshold) != (before < TimeLockThreshold) || after >= before {
+		return ErrTxInvalidTimeLock
+	}
+	return nil
+}
+
+// ValidateTimeLock returns ErrTxInvalidTimeLock if the tx has a time lock
+// before the hardfork version 4.
+func (b *TxBody) ValidateTimeLock(version int32) error {
+	if version < 4 && b.HasTimeLock() {
+		return ErrTxInvalidTimeLock
+	}
+	return nil
+}
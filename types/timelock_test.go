@@ -0,0 +1,78 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckTimeLock(t *testing.T) {
+	const ts = int64(TimeLockThreshold) + 1000
+	tests := []struct {
+		name    string
+		after   uint64
+		before  uint64
+		blockNo BlockNo
+		want    error
+	}{
+		{"no lock", 0, 0, 10, nil},
+		{"block not yet", 11, 0, 10, ErrTxNotYetValid},
+		{"block reached", 10, 0, 10, nil},
+		{"block valid", 0, 11, 10, nil},
+		{"block expired", 0, 10, 10, ErrTxExpired},
+		{"block range", 5, 20, 10, nil},
+		{"time not yet", uint64(ts) + 1, 0, 10, ErrTxNotYetValid},
+		{"time reached", uint64(ts), 0, 10, nil},
+		{"time valid", 0, uint64(ts) + 1, 10, nil},
+		{"time expired", 0, uint64(ts), 10, ErrTxExpired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := &TxBody{ValidAfter: tt.after, ValidBefore: tt.before}
+			assert.Equal(t, tt.want, body.CheckTimeLock(tt.blockNo, ts))
+			assert.Equal(t, tt.want == ErrTxExpired, body.IsExpired(tt.blockNo, ts))
+		})
+	}
+}
+
+func TestValidateTimeLock(t *testing.T) {
+	tests := []struct {
+		name   string
+		after  uint64
+		before uint64
+		want   error
+	}{
+		{"no lock", 0, 0, nil},
+		{"after only", 10, 0, nil},
+		{"before only", 0, TimeLockThreshold, nil},
+		{"range", 10, 20, nil},
+		{"empty range", 20, 20, ErrTxInvalidTimeLock},
+		{"mixed kinds", 10, TimeLockThreshold + 10, ErrTxInvalidTimeLock},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := &TxBody{ValidAfter: tt.after, ValidBefore: tt.before}
+			assert.Equal(t, tt.want, validateTimeLock(body))
+		})
+	}
+
+	body := &TxBody{ValidAfter: 10}
+	assert.Equal(t, ErrTxInvalidTimeLock, body.ValidateTimeLock(3), "before v4")
+	assert.NoError(t, body.ValidateTimeLock(4), "since v4")
+	assert.NoError(t, (&TxBody{}).ValidateTimeLock(3), "no lock before v4")
+}
+
+func TestTimeLockHash(t *testing.T) {
+	tx := &Tx{Body: &TxBody{Nonce: 1, Account: []byte("account"), Amount: []byte{1}}}
+	hash := tx.CalculateTxHash()
+
+	tx.Body.ValidAfter = 10
+	locked := tx.CalculateTxHash()
+	assert.NotEqual(t, hash, locked, "the time lock is hashed")
+
+	tx.Body.ValidAfter = 0
+	assert.Equal(t, hash, tx.CalculateTxHash(), "the hash without a time lock is kept")
+
+	tx.Body.ValidAfter = 10
+	assert.Equal(t, locked, tx.Clone().GetHash(), "clone")
+}
@@ -0,0 +1,39 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package types
+
+import "math/big"
+
+// TipStatPercentiles are the percentiles at which the tips of TipStat are
+// sampled.
+var TipStatPercentiles = []uint32{0, 10, 25, 50, 75, 90, 100}
+
+// NewTipStat makes the distribution of tips, which must be sorted in the
+// ascending order.
+func NewTipStat(version int32, gasPrice *big.Int, tips []*big.Int) *TipStat {
+	stat := &TipStat{
+		BlockVersion: version,
+		GasPrice:     gasPrice.Bytes(),
+		Count:        uint64(len(tips)),
+	}
+	for _, tip := range tips {
+		if tip.Sign() > 0 {
+			stat.Tipped++
+		}
+	}
+	if len(tips) == 0 {
+		return stat
+	}
+	for _, p := range TipStatPercentiles {
+		// the nearest-rank method
+		rank := (int(p)*len(tips) + 99) / 100
+		if rank > 0 {
+			rank--
+		}
+		stat.Tips = append(stat.Tips, tips[rank].Bytes())
+	}
+	return stat
+}
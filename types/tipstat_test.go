@@ -0,0 +1,80 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTipPrice(t *testing.T) {
+	systemPrice := big.NewInt(50)
+	tests := []struct {
+		name     string
+		gasPrice int64
+		version  int32
+		want     int64
+	}{
+		{"before v3", 80, 2, 0},
+		{"no gas price", 0, 3, 0},
+		{"lower", 40, 3, 0},
+		{"equal", 50, 3, 0},
+		{"higher", 80, 3, 30},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := &TxBody{GasPrice: big.NewInt(tt.gasPrice).Bytes()}
+			assert.Equal(t, tt.want, body.GetTipPrice(systemPrice, tt.version).Int64())
+		})
+	}
+}
+
+func TestMaxFeeWithTip(t *testing.T) {
+	systemPrice := big.NewInt(50)
+	balance := big.NewInt(10000000)
+	tests := []struct {
+		name     string
+		gasPrice int64
+		gasLimit uint64
+		version  int32
+		want     int64
+	}{
+		{"before v3", 80, 100000, 2, 5000000},
+		{"no tip", 0, 100000, 3, 5000000},
+		{"tip", 80, 100000, 3, 8000000},
+		{"tip without gas limit", 80, 0, 3, 10000000 / 80 * 80},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := NewTransaction(&Tx{Body: &TxBody{
+				GasPrice: big.NewInt(tt.gasPrice).Bytes(),
+				GasLimit: tt.gasLimit,
+			}})
+			fee, err := tx.GetMaxFee(balance, systemPrice, tt.version)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, fee.Int64())
+		})
+	}
+}
+
+func TestNewTipStat(t *testing.T) {
+	stat := NewTipStat(3, big.NewInt(50), nil)
+	assert.Equal(t, uint64(0), stat.Count)
+	assert.Empty(t, stat.Tips)
+
+	var tips []*big.Int
+	for i := 0; i < 20; i++ {
+		tips = append(tips, big.NewInt(int64(i)))
+	}
+	stat = NewTipStat(3, big.NewInt(50), tips)
+	assert.Equal(t, uint64(20), stat.Count)
+	assert.Equal(t, uint64(19), stat.Tipped)
+	assert.Equal(t, big.NewInt(50).Bytes(), stat.GasPrice)
+
+	want := []int64{0, 1, 4, 9, 14, 17, 19}
+	if assert.Len(t, stat.Tips, len(TipStatPercentiles)) {
+		for i, tip := range stat.Tips {
+			assert.Equal(t, want[i], new(big.Int).SetBytes(tip).Int64(), "p%d", TipStatPercentiles[i])
+		}
+	}
+}
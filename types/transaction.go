@@ -126,6 +126,10 @@ func (tx *transaction) Validate(chainidhash []byte, isPublic bool) error {
 		return ErrTxInvalidRecipient
 	}
 
+	if err := validateTimeLock(tx.GetBody()); err != nil {
+		return err
+	}
+
 	switch tx.GetBody().Type {
 	case TxType_REDEPLOY:
 		if isPublic {
@@ -212,6 +216,13 @@ func ValidateSystemTx(tx *TxBody) error {
 				return ErrTxInvalidPayload
 			}
 		}
+	case OpsetMultisig:
+		if tx.GetAmountBigInt().Sign() != 0 {
+			return ErrTxInvalidAmount
+		}
+		if _, err := ParseMultisigArgs(ci.Args); err != nil {
+			return err
+		}
 	case OpvoteDAO:
 		if len(ci.Args) < 1 {
 			return fmt.Errorf("the number of args less then 1")
@@ -391,11 +402,14 @@ func (tx *transaction) Clone() *transaction {
 	return res
 }
 
+// GetMaxFee returns the fee for the gas limit of tx, which includes the tip
+// for the whole gas limit since the hardfork version 3.
 func (tx *transaction) GetMaxFee(balance, gasPrice *big.Int, version int32) (*big.Int, error) {
 	if fee.IsZeroFee() {
 		return fee.NewZeroFee(), nil
 	}
 	if version >= 2 {
+		gasPrice = new(big.Int).Add(gasPrice, tx.GetBody().GetTipPrice(gasPrice, version))
 		minGasLimit := fee.TxGas(len(tx.GetBody().GetPayload()))
 		gasLimit := tx.GetBody().GasLimit
 		if gasLimit == 0 {
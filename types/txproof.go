@@ -0,0 +1,59 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package types
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/aergoio/aergo/internal/merkle"
+)
+
+var (
+	ErrProofBlockMismatch = errors.New("proof of another block")
+	ErrBadTxProof         = errors.New("invalid merkle proof of tx")
+	ErrBadReceiptProof    = errors.New("invalid merkle proof of receipt")
+)
+
+// Verify checks that the tx of m is included in the block of header.
+func (m *TxProof) Verify(header *BlockHeader) error {
+	if err := verifyProofBlock(header, m.GetBlockHash(), m.GetBlockNo()); err != nil {
+		return err
+	}
+	tx := m.GetTx()
+	if tx.GetBody() == nil || !bytes.Equal(tx.CalculateTxHash(), tx.GetHash()) {
+		return ErrBadTxProof
+	}
+	if !merkle.VerifyMerkleProof(header.GetTxsRootHash(), tx.GetHash(), int(m.GetIndex()), int(m.GetCount()), m.GetAuditPath()) {
+		return ErrBadTxProof
+	}
+	return nil
+}
+
+// Verify checks that the receipt of m is included in the block of header.
+// hardForkConfig decides the format of the receipt by the block number.
+func (m *ReceiptProof) Verify(header *BlockHeader, hardForkConfig BlockVersionner) error {
+	if err := verifyProofBlock(header, m.GetBlockHash(), m.GetBlockNo()); err != nil {
+		return err
+	}
+	if m.GetReceipt() == nil {
+		return ErrBadReceiptProof
+	}
+	leaf := m.GetReceipt().MerkleHash(hardForkConfig, header.GetBlockNo())
+	if !merkle.VerifyMerkleProof(header.GetReceiptsRootHash(), leaf, int(m.GetIndex()), int(m.GetCount()), m.GetAuditPath()) {
+		return ErrBadReceiptProof
+	}
+	return nil
+}
+
+// verifyProofBlock checks that a proof is made for the block of header.
+func verifyProofBlock(header *BlockHeader, blockHash []byte, blockNo BlockNo) error {
+	if header == nil || header.GetBlockNo() != blockNo ||
+		!bytes.Equal((&Block{Header: header}).calculateBlockHash(), blockHash) {
+		return ErrProofBlockMismatch
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/willf/bloom"
+)
+
+func TestTxProof(t *testing.T) {
+	var txs []*Tx
+	for i := 0; i < 5; i++ {
+		tx := &Tx{Body: &TxBody{Nonce: uint64(i + 1), Amount: []byte{1}}}
+		tx.Hash = tx.CalculateTxHash()
+		txs = append(txs, tx)
+	}
+	block := NewBlock(&BlockHeaderInfo{No: 3}, nil, &Receipts{}, txs, nil, nil)
+
+	for i, tx := range txs {
+		proof := &TxProof{Tx: tx, BlockHash: block.BlockHash(), BlockNo: 3, Index: int32(i),
+			Count: int32(len(txs)), AuditPath: CalculateTxsProof(txs, i)}
+		assert.NoError(t, proof.Verify(block.GetHeader()), "tx %d", i)
+
+		proof.Index = int32((i + 1) % len(txs))
+		assert.Equal(t, ErrBadTxProof, proof.Verify(block.GetHeader()), "tx %d", i)
+		proof.Index = int32(i)
+
+		proof.BlockNo = 4
+		assert.Equal(t, ErrProofBlockMismatch, proof.Verify(block.GetHeader()), "tx %d", i)
+	}
+
+	forged := *txs[0]
+	forged.Body = &TxBody{Nonce: 1, Amount: []byte{100}}
+	proof := &TxProof{Tx: &forged, BlockHash: block.BlockHash(), BlockNo: 3, Count: int32(len(txs)),
+		AuditPath: CalculateTxsProof(txs, 0)}
+	assert.Equal(t, ErrBadTxProof, proof.Verify(block.GetHeader()), "forged body")
+
+	// the last tx is copied to the index after it to fill the tree
+	proof = &TxProof{Tx: txs[4], BlockHash: block.BlockHash(), BlockNo: 3, Index: 5, Count: int32(len(txs)),
+		AuditPath: CalculateTxsProof(txs, 4)}
+	assert.Equal(t, ErrBadTxProof, proof.Verify(block.GetHeader()), "copy of the last tx")
+	proof.Count = 6
+	assert.Equal(t, ErrBadTxProof, proof.Verify(block.GetHeader()), "copy of the last tx")
+}
+
+func TestReceiptProof(t *testing.T) {
+	bv := DummyBlockVersionner(0)
+	receipts := &Receipts{}
+	receipts.SetHardFork(bv, 3)
+	for i := 0; i < 3; i++ {
+		r := NewReceipt(make([]byte, AddressLength), "SUCCESS", "{}")
+		r.TxHash = []byte{byte(i)}
+		r.Events = []*Event{{ContractAddress: r.ContractAddress, EventName: "ev", TxHash: r.TxHash}}
+		receipts.Set(append(receipts.Get(), r))
+	}
+	bf := bloom.New(BloomBitBits, BloomHashKNum)
+	bf.Add([]byte("ev"))
+	require.NoError(t, receipts.MergeBloom(bf))
+	block := NewBlock(&BlockHeaderInfo{No: 3}, nil, receipts, nil, nil, nil)
+
+	for i, r := range receipts.Get() {
+		proof := &ReceiptProof{Receipt: r, BlockHash: block.BlockHash(), BlockNo: 3, Index: int32(i),
+			Count: int32(receipts.MerkleLeafCount()), AuditPath: receipts.MerkleProof(i)}
+		assert.NoError(t, proof.Verify(block.GetHeader(), bv), "receipt %d", i)
+
+		modified := *r
+		modified.Status = "ERROR"
+		proof.Receipt = &modified
+		assert.Equal(t, ErrBadReceiptProof, proof.Verify(block.GetHeader(), bv), "receipt %d", i)
+	}
+	assert.Nil(t, receipts.MerkleProof(3), "bloom filter")
+}
@@ -57,6 +57,9 @@ const (
 	Opstake
 	// Opunstake represents a unstaking tranaction.
 	Opunstake
+	// OpsetMultisig represents a transaction registering the signers of a
+	// multisig account.
+	OpsetMultisig
 	// OpSysTxMax is the maximum of system tx OP numbers.
 	OpSysTxMax
 